@@ -0,0 +1,106 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// portNameField is the request field that carries a port name
+const portNameField = protoreflect.Name("port_name")
+
+// AliasInterceptor rewrites port aliases in incoming requests to the device
+// paths they currently resolve to, so every RPC accepts an alias anywhere a
+// port name is accepted
+type AliasInterceptor struct {
+	scanner *serial.Scanner
+}
+
+// NewAliasInterceptor creates a new alias-resolving interceptor
+func NewAliasInterceptor(scanner *serial.Scanner) *AliasInterceptor {
+	return &AliasInterceptor{scanner: scanner}
+}
+
+// Unary returns a unary server interceptor resolving aliases
+func (a *AliasInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.resolve(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor resolving aliases in every
+// received message
+func (a *AliasInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &aliasServerStream{ServerStream: ss, aliases: a})
+	}
+}
+
+// resolve replaces an alias in the message's port_name field
+func (a *AliasInterceptor) resolve(req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName(portNameField)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return nil
+	}
+
+	name := m.Get(field).String()
+	if name == "" || !a.scanner.IsAlias(name) {
+		return nil
+	}
+
+	resolved, err := a.scanner.Resolve(name)
+	if err != nil {
+		if errors.Is(err, serial.ErrAliasNotConnected) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Errorf(codes.Internal, "failed to resolve alias %s: %v", name, err)
+	}
+
+	m.Set(field, protoreflect.ValueOfString(resolved))
+	return nil
+}
+
+// aliasServerStream resolves aliases in messages received on a stream
+type aliasServerStream struct {
+	grpc.ServerStream
+	aliases *AliasInterceptor
+}
+
+func (s *aliasServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.aliases.resolve(m)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// SetAuditLog sets the log that port operations are recorded to. A nil log
+// disables auditing.
+func (s *SerialServer) SetAuditLog(l *audit.Logger) {
+	s.auditLog = l
+	if l != nil {
+		go s.recordExpiredSessions()
+	}
+}
+
+// recordExpiredSessions appends an audit entry for every session the agent
+// closes because it was idle
+func (s *SerialServer) recordExpiredSessions() {
+	events, _ := s.manager.Events().Subscribe()
+	for event := range events {
+		if event.Type != serial.EventSessionExpired {
+			continue
+		}
+		s.auditLog.Record(audit.Entry{
+			Time:      event.Timestamp,
+			Operation: "IdleClose",
+			Identity:  "agent",
+			ClientID:  event.ClientID,
+			PortName:  event.PortName,
+			SessionID: event.SessionID,
+			Success:   true,
+			Message:   event.Message,
+		})
+	}
+}
+
+// record appends an audit entry, filling in the caller's identity and address
+func (s *SerialServer) record(ctx context.Context, e audit.Entry) {
+	if s.auditLog == nil {
+		return
+	}
+
+	e.Identity = "anonymous"
+	if id, ok := auth.FromContext(ctx); ok {
+		e.Identity = id.Name
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		e.Peer = p.Addr.String()
+	}
+	s.auditLog.Record(e)
+}
+
+// recordDenied appends an audit entry for a request rejected by the auth
+// interceptor. id is nil when the caller could not be authenticated.
+func recordDenied(ctx context.Context, l *audit.Logger, method string, id *auth.Identity, req interface{}, err error) {
+	if l == nil {
+		return
+	}
+
+	st := status.Convert(err)
+	e := audit.Entry{
+		Operation: path.Base(method),
+		Identity:  "unauthenticated",
+		Message:   st.Code().String() + ": " + st.Message(),
+	}
+	if id != nil {
+		e.Identity = id.Name
+	}
+	if pn, ok := req.(portNamer); ok {
+		e.PortName = pn.GetPortName()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		e.Peer = p.Addr.String()
+	}
+	l.Record(e)
+}
+
+// recordWrite appends an audit entry for an operation writing n bytes
+func (s *SerialServer) recordWrite(ctx context.Context, operation, portName, sessionID string, n int, err error) {
+	entry := audit.Entry{
+		Operation: operation,
+		PortName:  portName,
+		SessionID: sessionID,
+		Bytes:     uint64(n),
+		Success:   err == nil,
+	}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+}
+
+// formatConfig summarizes a port configuration for the audit log, e.g.
+// "115200 8N1 rts/cts"
+func formatConfig(cfg serial.PortConfig) string {
+	parity := map[serial.Parity]string{
+		serial.ParityNone:  "N",
+		serial.ParityOdd:   "O",
+		serial.ParityEven:  "E",
+		serial.ParityMark:  "M",
+		serial.ParitySpace: "S",
+	}[cfg.Parity]
+	stopBits := map[serial.StopBits]string{
+		serial.StopBits1:     "1",
+		serial.StopBits1Half: "1.5",
+		serial.StopBits2:     "2",
+	}[cfg.StopBits]
+
+	summary := fmt.Sprintf("%d %d%s%s", cfg.BaudRate, cfg.DataBits, parity, stopBits)
+	switch cfg.FlowControl {
+	case serial.FlowControlHardware:
+		summary += " rts/cts"
+	case serial.FlowControlSoftware:
+		summary += " xon/xoff"
+	}
+	if cfg.RS485.Enabled {
+		summary += " rs485"
+	}
+	return summary
+}
+
+// GetAuditLog returns recorded port operations for administrators
+func (s *SerialServer) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	if s.auditLog == nil {
+		return nil, status.Error(codes.FailedPrecondition, "audit log is disabled")
+	}
+
+	filter := audit.Filter{
+		PortName:  req.PortName,
+		Identity:  req.Identity,
+		Operation: req.Operation,
+		Limit:     int(req.Limit),
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	entries, err := s.auditLog.Query(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read audit log: %v", err)
+	}
+
+	resp := &pb.GetAuditLogResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditEntry{
+			Timestamp: e.Time.UnixNano(),
+			Operation: e.Operation,
+			Identity:  e.Identity,
+			ClientId:  e.ClientID,
+			Peer:      e.Peer,
+			PortName:  e.PortName,
+			SessionId: e.SessionID,
+			Bytes:     e.Bytes,
+			Success:   e.Success,
+			Message:   e.Message,
+			Reason:    e.Reason,
+		})
+	}
+	return resp, nil
+}
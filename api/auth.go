@@ -0,0 +1,288 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// adminMethods require an identity with admin rights
+var adminMethods = map[string]bool{
+	pb.SerialService_CreateAccessLink_FullMethodName:  true,
+	pb.SerialService_ListSessions_FullMethodName:      true,
+	pb.SerialService_ListClients_FullMethodName:       true,
+	pb.SerialService_ForceClose_FullMethodName:        true,
+	pb.SerialService_GetAuditLog_FullMethodName:       true,
+	pb.SerialService_GetSessionHistory_FullMethodName: true,
+	pb.SerialService_GetCaptureIndex_FullMethodName:   true,
+	pb.SerialService_CreateGroup_FullMethodName:       true,
+	pb.SerialService_DeleteGroup_FullMethodName:       true,
+	pb.SerialService_ResetDevice_FullMethodName:       true,
+}
+
+// writeMethods modify port state and are denied to read-only identities
+var writeMethods = map[string]bool{
+	pb.SerialService_Write_FullMethodName:               true,
+	pb.SerialService_QueueWrite_FullMethodName:          true,
+	pb.SerialService_Transact_FullMethodName:            true,
+	pb.SerialService_WriteBatch_FullMethodName:          true,
+	pb.SerialService_Flush_FullMethodName:               true,
+	pb.SerialService_SCPIQuery_FullMethodName:           true,
+	pb.SerialService_SCPIErrors_FullMethodName:          true,
+	pb.SerialService_SendAT_FullMethodName:              true,
+	pb.SerialService_StreamWrite_FullMethodName:         true,
+	pb.SerialService_BiDirectionalStream_FullMethodName: true,
+	pb.SerialService_ConfigurePort_FullMethodName:       true,
+	pb.SerialService_RunScript_FullMethodName:           true,
+	pb.SerialService_Emulate_FullMethodName:             true,
+	pb.SerialService_TakeOver_FullMethodName:            true,
+	pb.SerialService_TestPort_FullMethodName:            true,
+	pb.SerialService_IdentifyDevice_FullMethodName:      true,
+	pb.SerialService_AddTap_FullMethodName:              true,
+	pb.SerialService_RemoveTap_FullMethodName:           true,
+	pb.SerialService_StartPassthrough_FullMethodName:    true,
+	pb.SerialService_StopPassthrough_FullMethodName:     true,
+	pb.SerialService_ProxyPorts_FullMethodName:          true,
+	pb.SerialService_CreateJob_FullMethodName:           true,
+	pb.SerialService_DeleteJob_FullMethodName:           true,
+	pb.SerialService_WriteGroup_FullMethodName:          true,
+}
+
+// portNamer is implemented by every request message that targets a port
+type portNamer interface {
+	GetPortName() string
+}
+
+// sessionIDer is implemented by request messages that use a session
+type sessionIDer interface {
+	GetSessionId() string
+}
+
+// attachmentIDer is implemented by request messages that use an attachment
+type attachmentIDer interface {
+	GetAttachmentId() string
+}
+
+// SessionOwners looks up which identity may use a session or attachment ID
+type SessionOwners interface {
+	SessionUsableBy(portName string, id string, owner string) bool
+}
+
+// AuthInterceptor authenticates requests and enforces token scopes
+type AuthInterceptor struct {
+	authn    *auth.Authenticator
+	auditLog *audit.Logger
+	owners   SessionOwners
+}
+
+// NewAuthInterceptor creates a new authentication interceptor. Denied
+// requests are recorded to auditLog, which may be nil. Session and
+// attachment IDs may only be used by the identity that opened them, as
+// reported by owners.
+func NewAuthInterceptor(authn *auth.Authenticator, auditLog *audit.Logger, owners SessionOwners) *AuthInterceptor {
+	return &AuthInterceptor{authn: authn, auditLog: auditLog, owners: owners}
+}
+
+// Unary returns a unary server interceptor enforcing authentication
+func (a *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			recordDenied(ctx, a.auditLog, info.FullMethod, id, req, err)
+			return nil, err
+		}
+
+		if err := checkPortAccess(id, info.FullMethod, req); err != nil {
+			recordDenied(ctx, a.auditLog, info.FullMethod, id, req, err)
+			return nil, err
+		}
+
+		if err := checkSessionOwner(id, a.owners, req); err != nil {
+			recordDenied(ctx, a.auditLog, info.FullMethod, id, req, err)
+			return nil, err
+		}
+
+		return handler(auth.NewContext(ctx, id), req)
+	}
+}
+
+// Stream returns a stream server interceptor enforcing authentication
+func (a *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			recordDenied(ss.Context(), a.auditLog, info.FullMethod, id, nil, err)
+			return err
+		}
+
+		return handler(srv, &authServerStream{
+			ServerStream: ss,
+			ctx:          auth.NewContext(ss.Context(), id),
+			identity:     id,
+			method:       info.FullMethod,
+			auditLog:     a.auditLog,
+			owners:       a.owners,
+		})
+	}
+}
+
+// authorize authenticates the caller and checks method-level permissions.
+// The identity is returned with the error when the caller was authenticated
+// but lacks permission.
+func (a *AuthInterceptor) authorize(ctx context.Context, method string) (*auth.Identity, error) {
+	// Health checks come from probes and watchdogs that carry no token
+	if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return &auth.Identity{Name: "health-probe", ReadOnly: true}, nil
+	}
+
+	id, err := a.authn.Authenticate(tokenFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if adminMethods[method] && !id.Admin {
+		return id, status.Error(codes.PermissionDenied, "admin privileges required")
+	}
+
+	if writeMethods[method] && id.ReadOnly {
+		return id, status.Error(codes.PermissionDenied, "token is read-only")
+	}
+
+	return id, nil
+}
+
+// checkPortAccess verifies that a request only targets ports in scope and
+// that write methods only target ports the identity may write to
+func checkPortAccess(id *auth.Identity, method string, req interface{}) error {
+	pn, ok := req.(portNamer)
+	if !ok || pn.GetPortName() == "" {
+		return nil
+	}
+
+	// Grants and guest scopes hold canonical names, so match the name the
+	// port is keyed by rather than however the client spelled it
+	name := serial.CanonicalPortName(pn.GetPortName())
+
+	if !id.CanAccessPort(name) {
+		return status.Errorf(codes.PermissionDenied, "access to port %s is not permitted", name)
+	}
+
+	if writeMethods[method] && !id.CanWritePort(name) {
+		return status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", name)
+	}
+
+	// Read-only roles and guests may open a port to observe it, but not in
+	// a way that locks writers out
+	if open, ok := req.(*pb.OpenPortRequest); ok && locksOutWriters(open) && !id.CanWritePort(name) {
+		return status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", name)
+	}
+
+	return nil
+}
+
+// checkSessionOwner verifies that the session and attachment IDs a request
+// uses belong to the caller. IDs are credentials: whoever holds one may read
+// from, write to, or close the session, so admins aside, only the identity
+// that opened a session may use it.
+func checkSessionOwner(id *auth.Identity, owners SessionOwners, req interface{}) error {
+	if owners == nil || id.Admin {
+		return nil
+	}
+
+	pn, ok := req.(portNamer)
+	if !ok {
+		return nil
+	}
+
+	var sessionID string
+	if r, ok := req.(sessionIDer); ok {
+		sessionID = r.GetSessionId()
+	} else if r, ok := req.(attachmentIDer); ok {
+		sessionID = r.GetAttachmentId()
+	}
+
+	if sessionID != "" && !owners.SessionUsableBy(pn.GetPortName(), sessionID, id.Name) {
+		return status.Errorf(codes.PermissionDenied, "session on port %s belongs to another client", pn.GetPortName())
+	}
+
+	return nil
+}
+
+// locksOutWriters reports whether an open keeps other clients from writing
+// to the port or has the agent write on the client's behalf, which requires
+// write access: exclusive and prioritized opens, and taps
+func locksOutWriters(req *pb.OpenPortRequest) bool {
+	return req.Exclusive || req.Priority > 0 || len(req.Taps) > 0
+}
+
+// tokenFromContext extracts a bearer token from the request metadata
+func tokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+}
+
+// authServerStream wraps a ServerStream to carry the identity and check the
+// port scope of every received message
+type authServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	identity *auth.Identity
+	method   string
+	auditLog *audit.Logger
+	owners   SessionOwners
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := checkPortAccess(s.identity, s.method, m); err != nil {
+		recordDenied(s.ctx, s.auditLog, s.method, s.identity, m, err)
+		return err
+	}
+	if err := checkSessionOwner(s.identity, s.owners, m); err != nil {
+		recordDenied(s.ctx, s.auditLog, s.method, s.identity, m, err)
+		return err
+	}
+	return nil
+}
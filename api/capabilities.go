@@ -0,0 +1,130 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/Shoaibashk/BaudLink/internal/probe"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// Capability categories reported by GetAgentInfo
+const (
+	capabilityFraming   = "framing"
+	capabilityChecksum  = "checksum"
+	capabilityFilter    = "filter"
+	capabilityProtocol  = "protocol"
+	capabilityTransport = "transport"
+	capabilityFeature   = "feature"
+)
+
+// capabilityVersions holds the version of capabilities that have changed
+// incompatibly since they were introduced; all others are at version 1.
+// Keys are category/name.
+var capabilityVersions = map[string]uint32{}
+
+// agentCapability is a capability of the agent, independent of API version
+type agentCapability struct {
+	Category string
+	Name     string
+	Version  uint32
+	Enabled  bool
+}
+
+// agentLimits are the largest requests the agent accepts, independent of
+// API version
+type agentLimits struct {
+	MaxWriteBytes  int
+	MaxChunkSize   int
+	MaxConnections int
+}
+
+// capability describes a supported capability
+func capability(category, name string, enabled bool) agentCapability {
+	version, ok := capabilityVersions[category+"/"+name]
+	if !ok {
+		version = 1
+	}
+	return agentCapability{
+		Category: category,
+		Name:     name,
+		Version:  version,
+		Enabled:  enabled,
+	}
+}
+
+// capabilities lists everything the agent supports, marking what the
+// configuration turns off
+func (s *SerialServer) capabilities() []agentCapability {
+	var caps []agentCapability
+	add := func(category string, names []string) {
+		for _, name := range names {
+			caps = append(caps, capability(category, name, true))
+		}
+	}
+
+	add(capabilityFraming, serial.Framers)
+	add(capabilityChecksum, serial.ChecksumAlgorithms)
+	add(capabilityFilter, serial.Filters)
+	add(capabilityProtocol, probe.Probes)
+
+	cfg := s.config
+	caps = append(caps,
+		capability(capabilityTransport, "grpc", true),
+		capability(capabilityTransport, "unix", cfg.Server.UnixSocket != ""),
+		capability(capabilityTransport, "pty", cfg.Passthrough.Enabled),
+		capability(capabilityTransport, "tcp", cfg.Passthrough.Enabled && cfg.Passthrough.AllowTCP),
+		capability(capabilityTransport, "http-files", cfg.Files.Enabled),
+		capability(capabilityTransport, "gpsd", cfg.GPSD.Enabled),
+	)
+
+	add(capabilityFeature, []string{
+		"attach",
+		"transact",
+		"write-batch",
+		"write-queue",
+		"stream-ack",
+		"scripting",
+		"emulator",
+		"proxy",
+		"sniff",
+		"modem",
+		"scpi",
+		"probe",
+		"session-reconnect",
+		"port-queue",
+	})
+	caps = append(caps,
+		capability(capabilityFeature, "auth", cfg.Auth.Enabled),
+		capability(capabilityFeature, "audit", cfg.Audit.Enabled),
+		capability(capabilityFeature, "history", cfg.History.Enabled),
+		capability(capabilityFeature, "passthrough", cfg.Passthrough.Enabled),
+		capability(capabilityFeature, "taps", cfg.Taps.Enabled),
+		capability(capabilityFeature, "federation", len(cfg.Federation.Remotes) > 0),
+		capability(capabilityFeature, "shared-access", cfg.Serial.AllowSharedAccess),
+	)
+
+	return caps
+}
+
+// limits reports the largest requests the agent accepts
+func (s *SerialServer) limits() agentLimits {
+	return agentLimits{
+		MaxWriteBytes:  s.config.Server.MaxWriteBytes,
+		MaxChunkSize:   s.config.Server.MaxChunkSize,
+		MaxConnections: s.config.Server.MaxConnections,
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// connKey is the context key of a tracked connection
+type connKey struct{}
+
+// trackedConn is the state of one client connection
+type trackedConn struct {
+	id            uint64
+	peer          string
+	connectedAt   time.Time
+	rejected      bool // Connected while the agent was at its limit
+	identity      string
+	lastRequest   time.Time
+	requests      uint64
+	activeStreams int
+}
+
+// ConnectionTracker counts client connections and refuses requests on
+// connections made while the agent already serves its limit. It is installed
+// both as a stats handler, to see connections open and close, and as an
+// interceptor, to attribute requests to them.
+type ConnectionTracker struct {
+	limit int
+
+	mu       sync.Mutex
+	conns    map[uint64]*trackedConn
+	nextID   uint64
+	accepted int
+	rejected uint64
+}
+
+// NewConnectionTracker creates a tracker allowing limit concurrent connections
+func NewConnectionTracker(limit int) *ConnectionTracker {
+	return &ConnectionTracker{
+		limit: limit,
+		conns: make(map[uint64]*trackedConn),
+	}
+}
+
+// TagConn attaches a tracked connection to the connection's context
+func (t *ConnectionTracker) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextID++
+	conn := &trackedConn{
+		id:          t.nextID,
+		peer:        info.RemoteAddr.String(),
+		connectedAt: time.Now(),
+	}
+	return context.WithValue(ctx, connKey{}, conn)
+}
+
+// HandleConn admits or rejects a new connection and forgets closed ones
+func (t *ConnectionTracker) HandleConn(ctx context.Context, s stats.ConnStats) {
+	conn, ok := ctx.Value(connKey{}).(*trackedConn)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch s.(type) {
+	case *stats.ConnBegin:
+		if t.accepted >= t.limit {
+			conn.rejected = true
+			t.rejected++
+		} else {
+			t.accepted++
+		}
+		t.conns[conn.id] = conn
+	case *stats.ConnEnd:
+		if _, exists := t.conns[conn.id]; !exists {
+			return
+		}
+		if !conn.rejected {
+			t.accepted--
+		}
+		delete(t.conns, conn.id)
+	}
+}
+
+// TagRPC is required by stats.Handler; RPCs are tracked by the interceptors
+func (t *ConnectionTracker) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC is required by stats.Handler; RPCs are tracked by the interceptors
+func (t *ConnectionTracker) HandleRPC(context.Context, stats.RPCStats) {}
+
+// Unary returns a unary server interceptor enforcing the connection limit
+func (t *ConnectionTracker) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, err := t.begin(ctx, info.FullMethod, false); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor enforcing the connection limit
+// and counting the connection's active streams
+func (t *ConnectionTracker) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		conn, err := t.begin(ss.Context(), info.FullMethod, true)
+		if err != nil {
+			return err
+		}
+		if conn != nil {
+			defer func() {
+				t.mu.Lock()
+				conn.activeStreams--
+				t.mu.Unlock()
+			}()
+		}
+		return handler(srv, ss)
+	}
+}
+
+// begin records a request on its connection, refusing it if the connection
+// was made over the limit. Health checks are always answered so probes can
+// tell a busy agent from a dead one.
+func (t *ConnectionTracker) begin(ctx context.Context, method string, stream bool) (*trackedConn, error) {
+	conn, ok := ctx.Value(connKey{}).(*trackedConn)
+	if !ok {
+		return nil, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if conn.rejected {
+		if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.ResourceExhausted, "connection limit of %d reached", t.limit)
+	}
+
+	if id, ok := auth.FromContext(ctx); ok {
+		conn.identity = id.Name
+	}
+	conn.lastRequest = time.Now()
+	conn.requests++
+	if stream {
+		conn.activeStreams++
+	}
+	return conn, nil
+}
+
+// clients returns the admitted connections, oldest first
+func (t *ConnectionTracker) clients() []*pb.ClientInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	conns := make([]*trackedConn, 0, len(t.conns))
+	for _, conn := range t.conns {
+		if !conn.rejected {
+			conns = append(conns, conn)
+		}
+	}
+	sort.Slice(conns, func(i, j int) bool { return conns[i].id < conns[j].id })
+
+	clients := make([]*pb.ClientInfo, 0, len(conns))
+	for _, conn := range conns {
+		info := &pb.ClientInfo{
+			Peer:          conn.peer,
+			Identity:      conn.identity,
+			ConnectedAt:   conn.connectedAt.Unix(),
+			Requests:      conn.requests,
+			ActiveStreams: uint32(conn.activeStreams),
+		}
+		if !conn.lastRequest.IsZero() {
+			info.LastRequest = conn.lastRequest.Unix()
+		}
+		clients = append(clients, info)
+	}
+	return clients
+}
+
+// SetConnectionTracker sets the tracker whose connections ListClients reports
+func (s *SerialServer) SetConnectionTracker(t *ConnectionTracker) {
+	s.connections = t
+}
+
+// ListClients returns the clients connected to the agent
+func (s *SerialServer) ListClients(ctx context.Context, req *pb.ListClientsRequest) (*pb.ListClientsResponse, error) {
+	if s.connections == nil {
+		return &pb.ListClientsResponse{}, nil
+	}
+
+	s.connections.mu.Lock()
+	rejected := s.connections.rejected
+	s.connections.mu.Unlock()
+
+	return &pb.ListClientsResponse{
+		Clients:             s.connections.clients(),
+		MaxConnections:      uint32(s.connections.limit),
+		RejectedConnections: rejected,
+	}, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/emulator"
+	"github.com/Shoaibashk/BaudLink/internal/pty"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// Emulate impersonates a device with an emulator script on a virtual or
+// physical port and streams the transcript until the client cancels
+func (s *SerialServer) Emulate(req *pb.EmulateRequest, stream pb.SerialService_EmulateServer) error {
+	sc, err := emulator.Parse(req.Script)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if s.shuttingDown() {
+		return errShuttingDown
+	}
+
+	clientID := req.ClientId
+	if clientID == "" {
+		clientID = "default-client"
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	entry := audit.Entry{Operation: "Emulate", ClientID: clientID, PortName: req.PortName, Message: "emulating " + emulatorName(sc)}
+
+	var device io.ReadWriter
+	var address string
+
+	if req.PortName == "" {
+		term, err := pty.Open(emulatorPipeName(sc))
+		if err != nil {
+			entry.Message = err.Error()
+			s.record(ctx, entry)
+			if errors.Is(err, pty.ErrUnsupported) {
+				return status.Error(codes.Unimplemented, err.Error())
+			}
+			return status.Errorf(codes.Internal, "failed to create virtual port: %v", err)
+		}
+		defer term.Close()
+		device, address = term, term.Name()
+		entry.PortName = address
+	} else {
+		session, err := s.manager.OpenPort(req.PortName, s.requestPortConfig(req.PortName, req.Config), clientID, true, 0)
+		if err != nil {
+			entry.Message = err.Error()
+			s.record(ctx, entry)
+			if errors.Is(err, serial.ErrPortLocked) {
+				return status.Errorf(codes.FailedPrecondition, "failed to open port: %v", err)
+			}
+			return status.Errorf(codes.Internal, "failed to open port: %v", err)
+		}
+		defer s.manager.ClosePort(session.PortName, session.ID)
+		device = emulator.NewSessionDevice(ctx, s.manager, session.PortName, session.ID)
+		address = session.PortName
+		entry.SessionID = session.ID
+	}
+
+	entry.Success = true
+	s.record(ctx, entry)
+
+	if err := stream.Send(&pb.EmulatorEvent{Type: pb.EmulatorEventType_EMULATOR_EVENT_TYPE_STARTED, Address: address, Message: emulatorName(sc)}); err != nil {
+		return err
+	}
+
+	events := make(chan emulator.Event, 64)
+	var runErr error
+	em := emulator.New(sc, func(event emulator.Event) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+	go func() {
+		defer close(events)
+		runErr = em.Run(ctx, device)
+	}()
+
+	shutdown := s.shutdown
+	for {
+		select {
+		case <-shutdown:
+			shutdown = nil
+			if err := stream.Send(&pb.EmulatorEvent{Type: pb.EmulatorEventType_EMULATOR_EVENT_TYPE_SHUTDOWN}); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				if runErr != nil {
+					return status.Errorf(codes.Aborted, "emulator stopped: %v", runErr)
+				}
+				return nil
+			}
+			if err := stream.Send(convertEmulatorEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// emulatorName returns the script's name for messages
+func emulatorName(sc *emulator.Script) string {
+	if sc.Name != "" {
+		return sc.Name
+	}
+	return "device"
+}
+
+// emulatorPipeName derives a named pipe name from a script name, used where
+// terminals are named pipes
+func emulatorPipeName(sc *emulator.Script) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, emulatorName(sc))
+	return "baudlink-emulator-" + strings.Trim(name, "-")
+}
+
+func convertEmulatorEvent(event emulator.Event) *pb.EmulatorEvent {
+	return &pb.EmulatorEvent{
+		Type:      convertEmulatorEventType(event.Type),
+		State:     event.State,
+		Rule:      event.Rule,
+		Data:      event.Data,
+		Message:   event.Message,
+		Timestamp: event.Timestamp.UnixNano(),
+	}
+}
+
+func convertEmulatorEventType(t emulator.EventType) pb.EmulatorEventType {
+	switch t {
+	case emulator.EventReceived:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_RECEIVED
+	case emulator.EventMatched:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_MATCHED
+	case emulator.EventSent:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_SENT
+	case emulator.EventState:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_STATE
+	case emulator.EventFailed:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_FAILED
+	default:
+		return pb.EmulatorEventType_EMULATOR_EVENT_TYPE_UNSPECIFIED
+	}
+}
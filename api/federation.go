@@ -0,0 +1,382 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/pkg/client"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// remoteSeparator separates a remote agent's name from its port names
+const remoteSeparator = ":"
+
+// portInfoName is the PortInfo field carrying the port name
+const portInfoName = protoreflect.Name("name")
+
+// remoteAgent is a federated agent and its connection
+type remoteAgent struct {
+	name   string
+	client *client.Client
+}
+
+// FederationInterceptor forwards requests for ports of remote agents,
+// named <remote>:<port>, to those agents and adds their ports to ListPorts.
+// It must run after authentication so local tokens govern access to remote
+// ports.
+type FederationInterceptor struct {
+	remotes map[string]*remoteAgent
+}
+
+// NewFederationInterceptor connects to the configured remote agents.
+// Connections are established lazily, so unreachable remotes do not prevent
+// the agent from starting.
+func NewFederationInterceptor(remotes []config.RemoteAgentConfig) (*FederationInterceptor, error) {
+	f := &FederationInterceptor{remotes: make(map[string]*remoteAgent)}
+
+	for _, r := range remotes {
+		// Forwarded calls carry the trace context, so a trace follows the
+		// request to the remote agent
+		opts := []client.Option{
+			client.WithToken(r.Token),
+			client.WithDialOptions(grpc.WithStatsHandler(otelgrpc.NewClientHandler())),
+		}
+		if r.TLS {
+			tlsConfig, err := remoteTLSConfig(r.CAFile)
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("remote %s: %w", r.Name, err)
+			}
+			opts = append(opts, client.WithTLS(tlsConfig))
+		}
+
+		c, err := client.Dial(r.Address, opts...)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("remote %s: %w", r.Name, err)
+		}
+		f.remotes[r.Name] = &remoteAgent{name: r.Name, client: c}
+	}
+
+	return f, nil
+}
+
+// Close closes the connections to remote agents
+func (f *FederationInterceptor) Close() {
+	for _, r := range f.remotes {
+		r.client.Close()
+	}
+}
+
+// Unary returns a unary server interceptor forwarding remote port requests
+func (f *FederationInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == pb.SerialService_ListPorts_FullMethodName {
+			// The ETag has to cover the remote ports, so fetch the full local
+			// list and compute it over the merged result
+			listReq := proto.Clone(req.(*pb.ListPortsRequest)).(*pb.ListPortsRequest)
+			listReq.IfChangedSince = ""
+			resp, err := handler(ctx, listReq)
+			if err != nil {
+				return nil, err
+			}
+			listResp := resp.(*pb.ListPortsResponse)
+			f.addRemotePorts(ctx, listReq, listResp)
+			setPortsETag(req.(*pb.ListPortsRequest), listResp)
+			return listResp, nil
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		remote := f.route(msg)
+		if remote == nil {
+			return handler(ctx, req)
+		}
+
+		reply, err := newMessage(info.FullMethod, false)
+		if err != nil {
+			return nil, err
+		}
+		if err := remote.client.Conn().Invoke(ctx, info.FullMethod, msg, reply); err != nil {
+			return nil, err
+		}
+
+		rewritePortNames(reply.ProtoReflect(), func(name string) string {
+			return remote.name + remoteSeparator + name
+		})
+		return reply, nil
+	}
+}
+
+// Stream returns a stream server interceptor forwarding streams whose first
+// message targets a remote port
+func (f *FederationInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		first, err := newMessage(info.FullMethod, true)
+		if err != nil {
+			return err
+		}
+		if err := ss.RecvMsg(first); err != nil {
+			if err == io.EOF {
+				// Nothing was sent; let the handler see the empty stream
+				return handler(srv, &replayServerStream{ServerStream: ss})
+			}
+			return err
+		}
+
+		remote := f.route(first)
+		if remote == nil {
+			return handler(srv, &replayServerStream{ServerStream: ss, first: first})
+		}
+		return f.proxyStream(ss, info, remote, first)
+	}
+}
+
+// route strips a remote prefix from the message's port name and returns the
+// remote agent it names, or nil for local ports
+func (f *FederationInterceptor) route(msg proto.Message) *remoteAgent {
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName(portNameField)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return nil
+	}
+
+	remoteName, portName, ok := strings.Cut(m.Get(field).String(), remoteSeparator)
+	if !ok {
+		return nil
+	}
+	remote := f.remotes[remoteName]
+	if remote == nil {
+		return nil
+	}
+
+	m.Set(field, protoreflect.ValueOfString(portName))
+	return remote
+}
+
+// proxyStream relays a stream between the caller and a remote agent
+func (f *FederationInterceptor) proxyStream(ss grpc.ServerStream, info *grpc.StreamServerInfo, remote *remoteAgent, first proto.Message) error {
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+
+	desc := &grpc.StreamDesc{
+		StreamName:    info.FullMethod,
+		ServerStreams: info.IsServerStream,
+		ClientStreams: info.IsClientStream,
+	}
+	cs, err := remote.client.Conn().NewStream(ctx, desc, info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if err := cs.SendMsg(first); err != nil {
+		return err
+	}
+
+	if !info.IsClientStream {
+		if err := cs.CloseSend(); err != nil {
+			return err
+		}
+	} else {
+		go func() {
+			for {
+				msg, err := newMessage(info.FullMethod, true)
+				if err == nil {
+					err = ss.RecvMsg(msg)
+				}
+				if err != nil {
+					if err == io.EOF {
+						cs.CloseSend()
+					} else {
+						cancel()
+					}
+					return
+				}
+
+				// Every message of a stream must target the same remote
+				if r := f.route(msg); r != remote {
+					cancel()
+					return
+				}
+				if err := cs.SendMsg(msg); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		msg, err := newMessage(info.FullMethod, false)
+		if err != nil {
+			return err
+		}
+		if err := cs.RecvMsg(msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		rewritePortNames(msg.ProtoReflect(), func(name string) string {
+			return remote.name + remoteSeparator + name
+		})
+		if err := ss.SendMsg(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// addRemotePorts appends the ports of every remote agent to a ListPorts
+// response. Unreachable remotes are skipped.
+func (f *FederationInterceptor) addRemotePorts(ctx context.Context, req *pb.ListPortsRequest, resp *pb.ListPortsResponse) {
+	id, _ := auth.FromContext(ctx)
+
+	for _, remote := range f.remotes {
+		remoteResp, err := remote.client.Service().ListPorts(ctx, req)
+		if err != nil {
+			log.Printf("Failed to list ports of remote agent %s: %v", remote.name, err)
+			continue
+		}
+
+		for _, port := range remoteResp.Ports {
+			rewritePortNames(port.ProtoReflect(), func(name string) string {
+				return remote.name + remoteSeparator + name
+			})
+			if id != nil && !id.CanAccessPort(port.Name) {
+				continue
+			}
+			resp.Ports = append(resp.Ports, port)
+		}
+	}
+}
+
+// rewritePortNames applies rename to every port name in a message,
+// including those of nested messages
+func rewritePortNames(m protoreflect.Message, rename func(string) string) {
+	portInfo := (&pb.PortInfo{}).ProtoReflect().Descriptor().FullName()
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			if fd.Name() == portNameField || (fd.Name() == portInfoName && m.Descriptor().FullName() == portInfo) {
+				if name := v.String(); name != "" {
+					m.Set(fd, protoreflect.ValueOfString(rename(name)))
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				rewritePortNames(list.Get(i).Message(), rename)
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			rewritePortNames(v.Message(), rename)
+		}
+		return true
+	})
+}
+
+// newMessage returns an empty request or response message for a method
+func newMessage(fullMethod string, input bool) (proto.Message, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "invalid method name %s", fullMethod)
+	}
+
+	sd, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown service %s", service)
+	}
+	serviceDesc, ok := sd.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unknown service %s", service)
+	}
+	md := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, status.Errorf(codes.Internal, "unknown method %s", fullMethod)
+	}
+
+	desc := md.Output()
+	if input {
+		desc = md.Input()
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(desc.FullName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown message %s", desc.FullName())
+	}
+	return mt.New().Interface(), nil
+}
+
+// remoteTLSConfig returns the TLS configuration for connecting to a remote
+// agent, trusting caFile if given and the system roots otherwise
+func remoteTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// replayServerStream returns an already received first message before
+// reading the rest of the stream
+type replayServerStream struct {
+	grpc.ServerStream
+	first proto.Message
+}
+
+func (s *replayServerStream) RecvMsg(m interface{}) error {
+	if s.first != nil {
+		msg, ok := m.(proto.Message)
+		if !ok {
+			return status.Error(codes.Internal, "unexpected message type")
+		}
+		proto.Reset(msg)
+		proto.Merge(msg, s.first)
+		s.first = nil
+		return nil
+	}
+	return s.ServerStream.RecvMsg(m)
+}
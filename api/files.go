@@ -0,0 +1,160 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+)
+
+// FileInfo describes a file available for download
+type FileInfo struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	IsDir    bool      `json:"is_dir"`
+	Modified time.Time `json:"modified"`
+}
+
+// FileHandler serves captures, recordings, and received files from a data
+// directory over HTTP. Range requests are supported so large artifacts can
+// be resumed with standard tools such as curl.
+type FileHandler struct {
+	root  *os.Root
+	authn *auth.Authenticator
+}
+
+// NewFileHandler creates a file handler rooted at dir. authn may be nil when
+// authentication is disabled.
+func NewFileHandler(dir string, authn *auth.Authenticator) (*FileHandler, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileHandler{
+		root:  root,
+		authn: authn,
+	}, nil
+}
+
+// Close releases the handler's directory handle
+func (h *FileHandler) Close() error {
+	return h.root.Close()
+}
+
+// ServeHTTP implements http.Handler
+func (h *FileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="baudlink"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	f, err := h.root.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "failed to stat file", http.StatusInternalServerError)
+		return
+	}
+
+	if info.IsDir() {
+		h.serveListing(w, f)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="`+info.Name()+`"`)
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// serveListing writes a JSON listing of a directory
+func (h *FileHandler) serveListing(w http.ResponseWriter, dir *os.File) {
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		http.Error(w, "failed to read directory", http.StatusInternalServerError)
+		return
+	}
+
+	files := make([]FileInfo, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, FileInfo{
+			Name:     e.Name(),
+			Size:     info.Size(),
+			IsDir:    e.IsDir(),
+			Modified: info.ModTime(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(files)
+}
+
+// authorized checks the request's bearer token. Guest tokens and tokens
+// whose roles are limited to some ports cannot download files, which are not
+// tied to a port.
+func (h *FileHandler) authorized(r *http.Request) bool {
+	if h.authn == nil {
+		return true
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+
+	id, err := h.authn.Authenticate(token)
+	if err != nil {
+		return false
+	}
+
+	return !id.Guest && !id.Scoped()
+}
@@ -0,0 +1,507 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// portGroup is a named set of ports operated on together
+type portGroup struct {
+	name        string
+	description string
+	ports       []string // Port names or aliases
+	configured  bool
+}
+
+// groupSession is a group opened by OpenGroup
+type groupSession struct {
+	id      string
+	group   string
+	members []groupMember
+}
+
+// groupMember is a port opened as part of a group session
+type groupMember struct {
+	port      string // Resolved port name
+	sessionID string
+}
+
+// portGroups holds the agent's port groups and the group sessions opened on
+// them
+type portGroups struct {
+	mu       sync.Mutex
+	groups   map[string]*portGroup
+	sessions map[string]*groupSession
+}
+
+// newPortGroups creates the group registry with the configured groups
+func newPortGroups(configured []config.GroupConfig) *portGroups {
+	g := &portGroups{
+		groups:   make(map[string]*portGroup),
+		sessions: make(map[string]*groupSession),
+	}
+	for _, gc := range configured {
+		g.groups[gc.Name] = &portGroup{
+			name:        gc.Name,
+			description: gc.Description,
+			ports:       gc.Ports,
+			configured:  true,
+		}
+	}
+	return g
+}
+
+// get returns the named group, or nil
+func (g *portGroups) get(name string) *portGroup {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.groups[name]
+}
+
+// session returns the group session with the given ID, or nil
+func (g *portGroups) session(id string) *groupSession {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.sessions[id]
+}
+
+// ListGroups lists the configured and created port groups
+func (s *SerialServer) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	s.groups.mu.Lock()
+	resp := &pb.ListGroupsResponse{
+		Groups: make([]*pb.PortGroup, 0, len(s.groups.groups)),
+	}
+	for _, group := range s.groups.groups {
+		resp.Groups = append(resp.Groups, &pb.PortGroup{
+			Name:        group.name,
+			Description: group.description,
+			Ports:       group.ports,
+			Configured:  group.configured,
+		})
+	}
+	s.groups.mu.Unlock()
+
+	sort.Slice(resp.Groups, func(i, j int) bool {
+		return resp.Groups[i].Name < resp.Groups[j].Name
+	})
+	return resp, nil
+}
+
+// CreateGroup defines a port group until the agent restarts
+func (s *SerialServer) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.Ports) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one port is required")
+	}
+	seen := make(map[string]bool)
+	for _, port := range req.Ports {
+		if port == "" || seen[port] {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid or duplicate port name %q", port)
+		}
+		seen[port] = true
+	}
+
+	s.groups.mu.Lock()
+	_, exists := s.groups.groups[req.Name]
+	if !exists {
+		s.groups.groups[req.Name] = &portGroup{
+			name:        req.Name,
+			description: req.Description,
+			ports:       req.Ports,
+		}
+	}
+	s.groups.mu.Unlock()
+
+	entry := audit.Entry{Operation: "CreateGroup", Success: !exists, Message: req.Name}
+	if exists {
+		entry.Message = fmt.Sprintf("group %s already exists", req.Name)
+	}
+	s.record(ctx, entry)
+
+	if exists {
+		return &pb.CreateGroupResponse{
+			Success: false,
+			Message: entry.Message,
+		}, nil
+	}
+
+	return &pb.CreateGroupResponse{
+		Success: true,
+		Message: "group created",
+	}, nil
+}
+
+// DeleteGroup removes a group created with CreateGroup. Group sessions
+// already open on it are unaffected.
+func (s *SerialServer) DeleteGroup(ctx context.Context, req *pb.DeleteGroupRequest) (*pb.DeleteGroupResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	var err error
+	s.groups.mu.Lock()
+	group, exists := s.groups.groups[req.Name]
+	switch {
+	case !exists:
+		err = fmt.Errorf("group %s not found", req.Name)
+	case group.configured:
+		err = fmt.Errorf("group %s is defined in the agent configuration", req.Name)
+	default:
+		delete(s.groups.groups, req.Name)
+	}
+	s.groups.mu.Unlock()
+
+	entry := audit.Entry{Operation: "DeleteGroup", Success: err == nil, Message: req.Name}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
+	if err != nil {
+		return &pb.DeleteGroupResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteGroupResponse{
+		Success: true,
+		Message: "group deleted",
+	}, nil
+}
+
+// OpenGroup opens every port of a group with the same settings. Either
+// every member opens or none stays open.
+func (s *SerialServer) OpenGroup(ctx context.Context, req *pb.OpenGroupRequest) (*pb.OpenGroupResponse, error) {
+	if req.Group == "" {
+		return nil, status.Error(codes.InvalidArgument, "group is required")
+	}
+	if s.shuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	group := s.groups.get(req.Group)
+	if group == nil {
+		return &pb.OpenGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("group %s not found", req.Group),
+		}, nil
+	}
+
+	// Members are resolved here; the alias and auth interceptors only see
+	// the group name
+	ports := make([]string, len(group.ports))
+	for i, name := range group.ports {
+		port := name
+		if s.scanner.IsAlias(name) {
+			resolved, err := s.scanner.Resolve(name)
+			if err != nil {
+				return &pb.OpenGroupResponse{
+					Success: false,
+					Message: fmt.Sprintf("failed to resolve %s: %v", name, err),
+				}, nil
+			}
+			port = resolved
+		}
+		// Exclusive access locks writers out, so it requires write access;
+		// other members are opened for sniffing by read-only callers
+		if err := checkGroupAccess(ctx, port, req.Exclusive); err != nil {
+			return nil, err
+		}
+		ports[i] = port
+	}
+
+	results := make([]*pb.GroupMemberResult, len(ports))
+	failed := 0
+	for i, port := range ports {
+		result := &pb.GroupMemberResult{PortName: port}
+		resp, err := s.OpenPort(ctx, &pb.OpenPortRequest{
+			PortName:  port,
+			Config:    req.Config,
+			ClientId:  req.ClientId,
+			Exclusive: req.Exclusive,
+		})
+		switch {
+		case err != nil:
+			result.Message = status.Convert(err).Message()
+		case !resp.Success:
+			result.Message = resp.Message
+		default:
+			result.Success = true
+			result.SessionId = resp.SessionId
+			result.Message = resp.Message
+		}
+		if !result.Success {
+			failed++
+		}
+		results[i] = result
+	}
+
+	if failed > 0 {
+		for _, result := range results {
+			if result.Success {
+				s.manager.ClosePort(result.PortName, result.SessionId)
+				result.Success = false
+				result.SessionId = ""
+				result.Message = "closed because another member failed to open"
+			}
+		}
+		return &pb.OpenGroupResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to open %d of %d ports", failed, len(ports)),
+			Members: results,
+		}, nil
+	}
+
+	gs := &groupSession{
+		id:    uuid.New().String(),
+		group: group.name,
+	}
+	for _, result := range results {
+		gs.members = append(gs.members, groupMember{port: result.PortName, sessionID: result.SessionId})
+	}
+
+	s.groups.mu.Lock()
+	s.groups.sessions[gs.id] = gs
+	s.groups.mu.Unlock()
+
+	return &pb.OpenGroupResponse{
+		Success:        true,
+		Message:        "group opened successfully",
+		GroupSessionId: gs.id,
+		Members:        results,
+	}, nil
+}
+
+// CloseGroup closes every port of a group session
+func (s *SerialServer) CloseGroup(ctx context.Context, req *pb.CloseGroupRequest) (*pb.CloseGroupResponse, error) {
+	if req.GroupSessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_session_id is required")
+	}
+
+	s.groups.mu.Lock()
+	gs := s.groups.sessions[req.GroupSessionId]
+	delete(s.groups.sessions, req.GroupSessionId)
+	s.groups.mu.Unlock()
+
+	if gs == nil {
+		return &pb.CloseGroupResponse{
+			Success: false,
+			Message: "invalid group session ID",
+		}, nil
+	}
+
+	resp := &pb.CloseGroupResponse{Success: true, Message: "group closed successfully"}
+	for _, m := range gs.members {
+		result := &pb.GroupMemberResult{PortName: m.port, SessionId: m.sessionID}
+		closed, err := s.ClosePort(ctx, &pb.ClosePortRequest{PortName: m.port, SessionId: m.sessionID})
+		switch {
+		case err != nil:
+			result.Message = status.Convert(err).Message()
+		default:
+			result.Success = closed.Success
+			result.Message = closed.Message
+		}
+		if !result.Success {
+			resp.Success = false
+			resp.Message = "some ports failed to close"
+		}
+		resp.Members = append(resp.Members, result)
+	}
+
+	return resp, nil
+}
+
+// WriteGroup writes the same data to every port of a group session at once
+func (s *SerialServer) WriteGroup(ctx context.Context, req *pb.WriteGroupRequest) (*pb.WriteGroupResponse, error) {
+	if req.GroupSessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "group_session_id is required")
+	}
+
+	gs := s.groups.session(req.GroupSessionId)
+	if gs == nil {
+		return &pb.WriteGroupResponse{
+			Success: false,
+			Message: "invalid group session ID",
+		}, nil
+	}
+	for _, m := range gs.members {
+		if err := checkGroupAccess(ctx, m.port, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// Members are written concurrently so they receive the data together
+	results := make([]*pb.GroupMemberResult, len(gs.members))
+	var wg sync.WaitGroup
+	for i, m := range gs.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := &pb.GroupMemberResult{PortName: m.port, SessionId: m.sessionID}
+			written, err := s.Write(ctx, &pb.WriteRequest{PortName: m.port, SessionId: m.sessionID, Data: req.Data})
+			if err != nil {
+				result.Message = status.Convert(err).Message()
+			} else {
+				result.Success = written.Success
+				result.Message = written.Message
+				result.BytesWritten = written.BytesWritten
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	resp := &pb.WriteGroupResponse{Success: true, Message: "data written successfully", Members: results}
+	for _, result := range results {
+		if !result.Success {
+			resp.Success = false
+			resp.Message = "some ports failed to write"
+		}
+	}
+	return resp, nil
+}
+
+// groupEvent is data received on one member of a group stream
+type groupEvent struct {
+	port  string
+	event serial.DataEvent
+	done  bool // The member's stream ended
+}
+
+// StreamGroup streams data from every port of a group session, merged into
+// one stream with each chunk tagged with the port it came from
+func (s *SerialServer) StreamGroup(req *pb.StreamGroupRequest, stream pb.SerialService_StreamGroupServer) error {
+	if req.GroupSessionId == "" {
+		return status.Error(codes.InvalidArgument, "group_session_id is required")
+	}
+
+	gs := s.groups.session(req.GroupSessionId)
+	if gs == nil {
+		return status.Error(codes.NotFound, "invalid group session ID")
+	}
+
+	ctx := stream.Context()
+	for _, m := range gs.members {
+		if err := checkGroupAccess(ctx, m.port, false); err != nil {
+			return err
+		}
+	}
+
+	chunkSize := int(req.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = 1024
+	}
+
+	merged := make(chan groupEvent)
+	for _, m := range gs.members {
+		reader := serial.NewReader(s.manager, m.port, m.sessionID, chunkSize)
+		if err := reader.Start(ctx); err != nil {
+			return status.Errorf(codes.Internal, "failed to start reader on %s: %v", m.port, err)
+		}
+		defer reader.Stop()
+
+		go func(port string, subscription <-chan serial.DataEvent) {
+			for event := range subscription {
+				select {
+				case merged <- groupEvent{port: port, event: event}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case merged <- groupEvent{port: port, done: true}:
+			case <-ctx.Done():
+			}
+		}(m.port, reader.Subscribe())
+	}
+
+	active := len(gs.members)
+	shutdown := s.shutdown
+
+	for active > 0 {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-shutdown:
+			shutdown = nil
+			if err := stream.Send(&pb.DataChunk{Shutdown: true}); err != nil {
+				return err
+			}
+		case ge := <-merged:
+			if ge.done {
+				active--
+				continue
+			}
+
+			event := ge.event
+			if event.Error != nil {
+				if event.Error == serial.ErrSessionTakenOver {
+					return status.Errorf(codes.Aborted, "%s: %v", ge.port, event.Error)
+				}
+				continue
+			}
+
+			var chunk *pb.DataChunk
+			if event.Overflow != nil {
+				chunk = &pb.DataChunk{PortName: ge.port, Overflow: convertOverflow(event.Overflow)}
+			} else {
+				chunk = newDataChunk(ge.port, event, req.IncludeTimestamps)
+			}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkGroupAccess checks that the caller may use, or with write set write
+// to, a group member. Group requests name no port, so the auth interceptor
+// cannot check members itself.
+func checkGroupAccess(ctx context.Context, port string, write bool) error {
+	id, _ := auth.FromContext(ctx)
+	if id == nil {
+		return nil
+	}
+	port = serial.CanonicalPortName(port)
+	if !id.CanAccessPort(port) {
+		return status.Errorf(codes.PermissionDenied, "access to port %s is not permitted", port)
+	}
+	if write && !id.CanWritePort(port) {
+		return status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", port)
+	}
+	return nil
+}
@@ -19,15 +19,30 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"io"
+	"log"
+	"regexp"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/history"
+	"github.com/Shoaibashk/BaudLink/internal/jobs"
+	"github.com/Shoaibashk/BaudLink/internal/modem"
+	"github.com/Shoaibashk/BaudLink/internal/script"
 	"github.com/Shoaibashk/BaudLink/internal/serial"
 
 	pb "github.com/Shoaibashk/BaudLink/api/proto"
@@ -47,49 +62,77 @@ type SerialServer struct {
 	scanner   *serial.Scanner
 	config    *config.Config
 	startTime time.Time
-	readers   map[string]*serial.Reader
+	modems    *modem.Registry
+	authn     *auth.Authenticator
+	auditLog  *audit.Logger
+	history   *history.Store
+	scheduler *jobs.Scheduler
+
+	connections *ConnectionTracker
+	groups      *portGroups
+
+	shutdown     chan struct{} // Closed when the agent starts shutting down
+	shutdownOnce sync.Once
 }
 
-// NewSerialServer creates a new SerialServer
-func NewSerialServer(manager *serial.Manager, scanner *serial.Scanner, cfg *config.Config) *SerialServer {
+// NewSerialServer creates a new SerialServer. authn may be nil when
+// authentication is disabled.
+func NewSerialServer(manager *serial.Manager, scanner *serial.Scanner, cfg *config.Config, authn *auth.Authenticator) *SerialServer {
 	return &SerialServer{
 		manager:   manager,
 		scanner:   scanner,
 		config:    cfg,
 		startTime: time.Now(),
-		readers:   make(map[string]*serial.Reader),
+		modems:    modem.NewRegistry(manager, nil),
+		authn:     authn,
+		groups:    newPortGroups(cfg.Groups),
+		shutdown:  make(chan struct{}),
 	}
 }
 
 // ListPorts returns all available serial ports
 func (s *SerialServer) ListPorts(ctx context.Context, req *pb.ListPortsRequest) (*pb.ListPortsResponse, error) {
-	ports, err := s.scanner.Scan()
+	ports, err := s.scanner.Ports(req.Refresh)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to scan ports: %v", err)
 	}
 
+	id, _ := auth.FromContext(ctx)
+
 	var response pb.ListPortsResponse
 	for _, p := range ports {
 		if req.OnlyAvailable && p.IsOpen {
 			continue
 		}
+		if id != nil && !id.CanAccessPort(p.Name) {
+			continue
+		}
 
-		response.Ports = append(response.Ports, &pb.PortInfo{
-			Name:         p.Name,
-			Description:  p.Description,
-			HardwareId:   p.HardwareID,
-			Manufacturer: p.Manufacturer,
-			Product:      p.Product,
-			SerialNumber: p.SerialNumber,
-			PortType:     convertPortType(p.PortType),
-			IsOpen:       p.IsOpen,
-			LockedBy:     p.LockedBy,
-		})
+		response.Ports = append(response.Ports, s.convertPortInfo(p))
 	}
 
+	setPortsETag(req, &response)
 	return &response, nil
 }
 
+// setPortsETag sets the ETag of a ListPorts response and, when it matches
+// the request's if_changed_since, replaces the ports with not_modified
+func setPortsETag(req *pb.ListPortsRequest, resp *pb.ListPortsResponse) {
+	h := fnv.New64a()
+	opts := proto.MarshalOptions{Deterministic: true}
+	for _, p := range resp.Ports {
+		data, _ := opts.Marshal(p)
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	resp.Etag = fmt.Sprintf("%016x", h.Sum64())
+
+	if req.IfChangedSince != "" && req.IfChangedSince == resp.Etag {
+		resp.Ports = nil
+		resp.NotModified = true
+	}
+}
+
 // GetPortInfo returns information about a specific port
 func (s *SerialServer) GetPortInfo(ctx context.Context, req *pb.GetPortInfoRequest) (*pb.PortInfo, error) {
 	if req.PortName == "" {
@@ -101,17 +144,7 @@ func (s *SerialServer) GetPortInfo(ctx context.Context, req *pb.GetPortInfoReque
 		return nil, status.Errorf(codes.NotFound, "port not found: %v", err)
 	}
 
-	return &pb.PortInfo{
-		Name:         port.Name,
-		Description:  port.Description,
-		HardwareId:   port.HardwareID,
-		Manufacturer: port.Manufacturer,
-		Product:      port.Product,
-		SerialNumber: port.SerialNumber,
-		PortType:     convertPortType(port.PortType),
-		IsOpen:       port.IsOpen,
-		LockedBy:     port.LockedBy,
-	}, nil
+	return s.convertPortInfo(*port), nil
 }
 
 // OpenPort opens a serial port
@@ -119,32 +152,232 @@ func (s *SerialServer) OpenPort(ctx context.Context, req *pb.OpenPortRequest) (*
 	if req.PortName == "" {
 		return nil, status.Error(codes.InvalidArgument, "port_name is required")
 	}
+	if s.shuttingDown() {
+		return nil, errShuttingDown
+	}
 
 	clientID := req.ClientId
 	if clientID == "" {
 		clientID = "default-client"
 	}
 
+	// Clients without write access may only observe the port. Taps write to
+	// the agent's disk or network, and exclusive or prioritized opens lock
+	// writers out, so those are refused; other opens are served in sniff
+	// mode, which never configures or drives a port another client has open
+	// and, for these clients, never opens a closed port, which would lock
+	// writers out as well.
+	var readOnly bool
+	if id, _ := auth.FromContext(ctx); id != nil && !id.CanWritePort(serial.CanonicalPortName(req.PortName)) {
+		if locksOutWriters(req) {
+			s.record(ctx, audit.Entry{Operation: "OpenPort", ClientID: clientID, PortName: req.PortName, Message: "PermissionDenied: taps, exclusive, and prioritized opens require write access"})
+			return nil, status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", req.PortName)
+		}
+		readOnly = true
+	}
+
 	cfg := s.convertToSerialConfig(req.Config)
 
-	session, err := s.manager.OpenPort(req.PortName, cfg, clientID, req.Exclusive)
+	// Without an explicit config, apply the profile matching the device
+	var profileName string
+	if req.Config == nil {
+		if profile := s.profileForName(req.PortName); profile != nil {
+			cfg = s.settingsToPortConfig(profile.Settings)
+			profileName = profile.Name
+		}
+	}
+
+	retry := s.manager.OpenRetry()
+	if req.Retry != nil {
+		retry = convertRetryPolicy(req.Retry)
+		if err := retry.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if req.Mode == pb.OpenMode_OPEN_MODE_SNIFF || readOnly {
+		return s.sniffPort(ctx, req, cfg, clientID, profileName, readOnly)
+	}
+
+	entry := audit.Entry{Operation: "OpenPort", ClientID: clientID, PortName: req.PortName}
+
+	// With shared access, clients opening a port another client has open
+	// share its session through an attachment
+	if len(req.Taps) == 0 {
+		if att, session, err := s.manager.JoinShared(req.PortName, clientID, req.Exclusive); err == nil {
+			s.bindOwner(ctx, session, att.ID)
+			entry.SessionID = att.ID
+			entry.Success = true
+			entry.Message = "attached to shared session"
+			s.record(ctx, entry)
+
+			return &pb.OpenPortResponse{
+				Success:   true,
+				Message:   "attached to shared session",
+				SessionId: att.ID,
+				Attached:  true,
+			}, nil
+		}
+	}
+
+	spanCtx, span := startSpan(ctx, "serial.Open", req.PortName, "",
+		attribute.Int("serial.baud_rate", cfg.BaudRate),
+		attribute.Bool("serial.exclusive", req.Exclusive),
+	)
+	wait := time.Duration(req.WaitMs) * time.Millisecond
+	session, err := s.manager.OpenPortQueued(spanCtx, req.PortName, cfg, clientID, req.Exclusive, int(req.Priority), retry, wait)
+	if err == nil {
+		span.SetAttributes(attribute.String("serial.session_id", session.ID), attribute.Bool("serial.managed", session.Managed))
+	}
+	endSpan(span, err)
 	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
 		if err == serial.ErrPortLocked {
 			return &pb.OpenPortResponse{
 				Success: false,
 				Message: "port is locked by another client",
 			}, nil
 		}
+		if err == serial.ErrPortShared {
+			return &pb.OpenPortResponse{
+				Success: false,
+				Message: "port is shared by another client",
+			}, nil
+		}
+		if err == serial.ErrQueueTimeout {
+			return &pb.OpenPortResponse{
+				Success: false,
+				Message: fmt.Sprintf("port is still locked after waiting %s", wait),
+			}, nil
+		}
 		return nil, status.Errorf(codes.Internal, "failed to open port: %v", err)
 	}
 
+	for _, tap := range req.Taps {
+		if _, err := s.manager.AddTap(session.PortName, session.ID, convertTapConfig(tap)); err != nil {
+			if !session.Managed {
+				s.manager.ClosePort(session.PortName, session.ID)
+			}
+			entry.Message = "failed to start tap: " + err.Error()
+			s.record(ctx, entry)
+			return &pb.OpenPortResponse{
+				Success: false,
+				Message: "failed to start tap: " + err.Error(),
+			}, nil
+		}
+	}
+
+	if req.Reconnect && !session.Managed {
+		s.enableReconnect(session)
+	}
+
+	s.bindOwner(ctx, session, session.ID)
+
+	message := "port opened successfully"
+	if session.Managed {
+		message = "attached to managed session"
+		profileName = ""
+	}
+
+	entry.SessionID = session.ID
+	entry.Success = true
+	entry.Message = message
+	s.record(ctx, entry)
+
 	return &pb.OpenPortResponse{
 		Success:   true,
-		Message:   "port opened successfully",
+		Message:   message,
 		SessionId: session.ID,
+		Profile:   profileName,
+	}, nil
+}
+
+// sniffPort opens a port in sniff mode: a read-only attachment to the
+// port's session if it is open, otherwise, unless attachOnly is set, a
+// session that refuses writes
+func (s *SerialServer) sniffPort(ctx context.Context, req *pb.OpenPortRequest, cfg serial.PortConfig, clientID string, profileName string, attachOnly bool) (*pb.OpenPortResponse, error) {
+	if len(req.Taps) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "taps cannot be started in sniff mode")
+	}
+
+	entry := audit.Entry{Operation: "OpenPort", ClientID: clientID, PortName: req.PortName}
+
+	_, span := startSpan(ctx, "serial.Sniff", req.PortName, "")
+	id, session, err := s.manager.Sniff(req.PortName, cfg, clientID, attachOnly)
+	endSpan(span, err)
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		switch err {
+		case serial.ErrPortNotOpen:
+			return &pb.OpenPortResponse{
+				Success: false,
+				Message: "port is not open; clients without write access can only sniff a port another client has open",
+			}, nil
+		case serial.ErrPortLocked:
+			return &pb.OpenPortResponse{
+				Success: false,
+				Message: "port is locked by another client",
+			}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to sniff port: %v", err)
+	}
+
+	s.bindOwner(ctx, session, id)
+
+	attached := id != session.ID
+	message := "port opened for sniffing"
+	if attached {
+		message = "attached to session for sniffing"
+		profileName = ""
+	} else if req.Reconnect {
+		s.enableReconnect(session)
+	}
+
+	entry.SessionID = id
+	entry.Success = true
+	entry.Message = message
+	s.record(ctx, entry)
+
+	return &pb.OpenPortResponse{
+		Success:   true,
+		Message:   message,
+		SessionId: id,
+		Profile:   profileName,
+		ReadOnly:  true,
+		Attached:  attached,
 	}, nil
 }
 
+// bindOwner ties a session or attachment ID handed to the caller to the
+// caller's identity, so no other identity can use it
+func (s *SerialServer) bindOwner(ctx context.Context, session *serial.Session, id string) {
+	if caller, ok := auth.FromContext(ctx); ok {
+		session.SetOwner(id, caller.Name)
+	}
+}
+
+// visibleID returns a session or attachment ID of the port if the caller may
+// use it, and an empty string otherwise
+func (s *SerialServer) visibleID(ctx context.Context, portName string, id string) string {
+	caller, _ := auth.FromContext(ctx)
+	if caller == nil || caller.Admin || id == "" || s.manager.SessionUsableBy(portName, id, caller.Name) {
+		return id
+	}
+	return ""
+}
+
+// enableReconnect lets a session survive its device being unplugged,
+// matching the device by serial number when it returns
+func (s *SerialServer) enableReconnect(session *serial.Session) {
+	var serialNumber string
+	if info, err := s.scanner.GetPort(session.PortName); err == nil {
+		serialNumber = info.SerialNumber
+	}
+	s.manager.EnableReconnect(session, serialNumber)
+}
+
 // ClosePort closes a serial port
 func (s *SerialServer) ClosePort(ctx context.Context, req *pb.ClosePortRequest) (*pb.ClosePortResponse, error) {
 	if req.PortName == "" {
@@ -154,13 +387,16 @@ func (s *SerialServer) ClosePort(ctx context.Context, req *pb.ClosePortRequest)
 		return nil, status.Error(codes.InvalidArgument, "session_id is required")
 	}
 
-	// Stop any active reader
-	if reader, exists := s.readers[req.PortName]; exists {
-		reader.Stop()
-		delete(s.readers, req.PortName)
+	_, span := startSpan(ctx, "serial.Close", req.PortName, req.SessionId)
+	err := s.manager.ClosePort(req.PortName, req.SessionId)
+	endSpan(span, err)
+
+	entry := audit.Entry{Operation: "ClosePort", PortName: req.PortName, SessionID: req.SessionId, Success: err == nil, Message: "port closed"}
+	if err != nil {
+		entry.Message = err.Error()
 	}
+	s.record(ctx, entry)
 
-	err := s.manager.ClosePort(req.PortName, req.SessionId)
 	if err != nil {
 		if err == serial.ErrInvalidSession {
 			return &pb.ClosePortResponse{
@@ -183,7 +419,7 @@ func (s *SerialServer) GetPortStatus(ctx context.Context, req *pb.GetPortStatusR
 		return nil, status.Error(codes.InvalidArgument, "port_name is required")
 	}
 
-	session, err := s.manager.GetStatus(req.PortName)
+	portStatus, err := s.manager.GetStatus(req.PortName)
 	if err != nil {
 		if err == serial.ErrPortNotOpen {
 			return &pb.PortStatus{
@@ -195,22 +431,170 @@ func (s *SerialServer) GetPortStatus(ctx context.Context, req *pb.GetPortStatusR
 	}
 
 	return &pb.PortStatus{
-		PortName:      session.PortName,
+		PortName:      portStatus.PortName,
 		IsOpen:        true,
-		IsLocked:      session.Exclusive,
-		LockedBy:      session.ClientID,
-		SessionId:     session.ID,
-		CurrentConfig: s.convertFromSerialConfig(session.Config),
-		Statistics: &pb.PortStatistics{
-			BytesSent:     session.Statistics.BytesSent,
-			BytesReceived: session.Statistics.BytesReceived,
-			Errors:        session.Statistics.Errors,
-			OpenedAt:      session.Statistics.OpenedAt.Unix(),
-			LastActivity:  session.Statistics.LastActivity.Unix(),
-		},
+		IsLocked:      portStatus.Exclusive,
+		LockedBy:      portStatus.ClientID,
+		SessionId:     s.visibleID(ctx, portStatus.PortName, portStatus.SessionID),
+		CurrentConfig: s.convertFromSerialConfig(portStatus.Config),
+		Statistics:    convertStatistics(portStatus.Statistics),
+		Managed:       portStatus.Managed,
+		Sniff:         portStatus.Sniff,
+		Disconnected:  portStatus.Disconnected,
+		Reconnect:     portStatus.Reconnect,
+		Taps:          convertTaps(portStatus.Taps),
+		BufferedBytes: uint32(portStatus.BufferedBytes),
+		Attachments:   s.convertAttachments(ctx, portStatus.PortName, portStatus.Attachments),
+		Flow:          convertFlowStatus(portStatus.Flow),
+	}, nil
+}
+
+// AttachSession attaches a client to an already-open session
+func (s *SerialServer) AttachSession(ctx context.Context, req *pb.AttachSessionRequest) (*pb.AttachSessionResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if s.shuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	role := serial.RoleReadWrite
+	if req.Role == pb.SessionRole_SESSION_ROLE_READ_ONLY {
+		role = serial.RoleReadOnly
+	}
+
+	if id, ok := auth.FromContext(ctx); ok && !id.CanWritePort(serial.CanonicalPortName(req.PortName)) && role != serial.RoleReadOnly {
+		s.record(ctx, audit.Entry{Operation: "AttachSession", ClientID: req.ClientId, PortName: req.PortName, Message: "PermissionDenied: read-write attachment requires write access"})
+		return nil, status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", req.PortName)
+	}
+
+	clientID := req.ClientId
+	if clientID == "" {
+		clientID = "default-client"
+	}
+
+	att, session, err := s.manager.Attach(req.PortName, clientID, role)
+	if err != nil {
+		return &pb.AttachSessionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	s.bindOwner(ctx, session, att.ID)
+
+	return &pb.AttachSessionResponse{
+		Success:      true,
+		Message:      "attached to session",
+		AttachmentId: att.ID,
+		SessionId:    s.visibleID(ctx, session.PortName, session.ID),
+	}, nil
+}
+
+// TakeOver claims an open port from its current owner. Admins may always
+// take over a port; other clients need a higher priority than the owner.
+func (s *SerialServer) TakeOver(ctx context.Context, req *pb.TakeOverRequest) (*pb.TakeOverResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+
+	clientID := req.ClientId
+	if clientID == "" {
+		clientID = "default-client"
+	}
+
+	id, _ := auth.FromContext(ctx)
+	force := id != nil && id.Admin
+
+	result, err := s.manager.TakeOver(req.PortName, clientID, int(req.Priority), req.Exclusive, force)
+
+	entry := audit.Entry{Operation: "TakeOver", ClientID: clientID, PortName: req.PortName, Reason: req.Reason}
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		return &pb.TakeOverResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	s.bindOwner(ctx, result.Session, result.Session.ID)
+
+	caller := "anonymous"
+	if id != nil {
+		caller = id.Name
+	}
+	log.Printf("audit: port %s taken over by client %s (identity %s) from client %s: %s",
+		req.PortName, clientID, caller, result.PreviousClientID, req.Reason)
+
+	entry.SessionID = result.Session.ID
+	entry.Success = true
+	entry.Message = "taken over from client " + result.PreviousClientID
+	s.record(ctx, entry)
+
+	return &pb.TakeOverResponse{
+		Success:          true,
+		Message:          "port taken over",
+		SessionId:        result.Session.ID,
+		PreviousClientId: result.PreviousClientID,
+	}, nil
+}
+
+// DetachSession removes a client's attachment from a session
+func (s *SerialServer) DetachSession(ctx context.Context, req *pb.DetachSessionRequest) (*pb.DetachSessionResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.AttachmentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "attachment_id is required")
+	}
+
+	if err := s.manager.Detach(req.PortName, req.AttachmentId); err != nil {
+		return &pb.DetachSessionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DetachSessionResponse{
+		Success: true,
+		Message: "detached from session",
 	}, nil
 }
 
+// GetPortHolders returns the clients holding a port
+func (s *SerialServer) GetPortHolders(ctx context.Context, req *pb.GetPortHoldersRequest) (*pb.GetPortHoldersResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+
+	holders, err := s.manager.Holders(req.PortName)
+	if err != nil {
+		if err == serial.ErrPortNotOpen {
+			return &pb.GetPortHoldersResponse{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get port holders: %v", err)
+	}
+
+	resp := &pb.GetPortHoldersResponse{
+		Holders: make([]*pb.PortHolder, 0, len(holders)),
+	}
+	for _, h := range holders {
+		resp.Holders = append(resp.Holders, &pb.PortHolder{
+			Id:              s.visibleID(ctx, req.PortName, h.ID),
+			ClientId:        h.ClientID,
+			Role:            convertRole(h.Role),
+			Opener:          h.Opener,
+			Since:           h.Since.Unix(),
+			BytesSent:       h.BytesSent,
+			BytesReceived:   h.BytesReceived,
+			QueuedWrites:    uint32(h.QueuedWrites),
+			QuotaRejections: h.QuotaRejections,
+		})
+	}
+
+	return resp, nil
+}
+
 // Write writes data to a port
 func (s *SerialServer) Write(ctx context.Context, req *pb.WriteRequest) (*pb.WriteResponse, error) {
 	if req.PortName == "" {
@@ -220,22 +604,75 @@ func (s *SerialServer) Write(ctx context.Context, req *pb.WriteRequest) (*pb.Wri
 		return nil, status.Error(codes.InvalidArgument, "session_id is required")
 	}
 
-	n, err := s.manager.Write(req.PortName, req.SessionId, req.Data)
+	_, span := startSpan(ctx, "serial.Write", req.PortName, req.SessionId)
+	n, err := s.write(req.PortName, req.SessionId, req.Data, req.CorrelationId)
+	span.SetAttributes(attribute.Int("serial.bytes", n))
+	endSpan(span, err)
+	s.recordWrite(ctx, "Write", req.PortName, req.SessionId, n, err)
 	if err != nil {
+		if errors.Is(err, serial.ErrRateLimited) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		return &pb.WriteResponse{
-			Success: false,
-			Message: err.Error(),
+			Success:       false,
+			Message:       err.Error(),
+			CorrelationId: req.CorrelationId,
 		}, nil
 	}
 
 	if req.Flush {
-		s.manager.Flush(req.PortName, req.SessionId)
+		if err := s.manager.Drain(req.PortName, req.SessionId); err != nil {
+			return &pb.WriteResponse{
+				Success:       false,
+				BytesWritten:  uint32(n),
+				Message:       fmt.Sprintf("failed to drain output: %v", err),
+				CorrelationId: req.CorrelationId,
+			}, nil
+		}
 	}
 
 	return &pb.WriteResponse{
-		Success:      true,
-		BytesWritten: uint32(n),
-		Message:      "data written successfully",
+		Success:       true,
+		BytesWritten:  uint32(n),
+		Message:       "data written successfully",
+		CorrelationId: req.CorrelationId,
+	}, nil
+}
+
+// QueueWrite enqueues data on the session's ordered write queue
+func (s *SerialServer) QueueWrite(ctx context.Context, req *pb.QueueWriteRequest) (*pb.QueueWriteResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	delay := time.Duration(req.DelayMs) * time.Millisecond
+	ticket, err := s.manager.QueueWrite(req.PortName, req.SessionId, req.Data, int(req.Priority), req.CorrelationId, delay)
+
+	// Queued data is recorded when accepted rather than when written
+	var queued int
+	if err == nil {
+		queued = len(req.Data)
+	}
+	s.recordWrite(ctx, "QueueWrite", req.PortName, req.SessionId, queued, err)
+
+	if err != nil {
+		if err == serial.ErrQueueFull {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return &pb.QueueWriteResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.QueueWriteResponse{
+		Success:    true,
+		TicketId:   ticket.ID,
+		QueueDepth: uint32(s.manager.QueueDepth(req.PortName)),
+		Message:    "write queued",
 	}, nil
 }
 
@@ -256,6 +693,7 @@ func (s *SerialServer) Read(ctx context.Context, req *pb.ReadRequest) (*pb.ReadR
 	var data []byte
 	var err error
 
+	_, span := startSpan(ctx, "serial.Read", req.PortName, req.SessionId)
 	if req.TimeoutMs > 0 {
 		result := serial.ReadWithTimeout(s.manager, req.PortName, req.SessionId, maxBytes, time.Duration(req.TimeoutMs)*time.Millisecond)
 		data = result.Data
@@ -263,6 +701,8 @@ func (s *SerialServer) Read(ctx context.Context, req *pb.ReadRequest) (*pb.ReadR
 	} else {
 		data, err = s.manager.Read(req.PortName, req.SessionId, maxBytes)
 	}
+	span.SetAttributes(attribute.Int("serial.bytes", len(data)))
+	endSpan(span, err)
 
 	if err != nil {
 		return &pb.ReadResponse{
@@ -279,72 +719,389 @@ func (s *SerialServer) Read(ctx context.Context, req *pb.ReadRequest) (*pb.ReadR
 	}, nil
 }
 
-// StreamRead streams data from a port
-func (s *SerialServer) StreamRead(req *pb.StreamReadRequest, stream pb.SerialService_StreamReadServer) error {
+// Transact writes a request and collects the response server-side
+func (s *SerialServer) Transact(ctx context.Context, req *pb.TransactRequest) (*pb.TransactResponse, error) {
 	if req.PortName == "" {
-		return status.Error(codes.InvalidArgument, "port_name is required")
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
 	}
 	if req.SessionId == "" {
-		return status.Error(codes.InvalidArgument, "session_id is required")
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
 	}
 
-	chunkSize := int(req.ChunkSize)
-	if chunkSize <= 0 {
-		chunkSize = 1024
+	opts := serial.TransactOptions{
+		Request:       req.Data,
+		Terminator:    req.Terminator,
+		ExpectedBytes: int(req.ExpectedBytes),
+		Timeout:       time.Duration(req.TimeoutMs) * time.Millisecond,
+		FlushInput:    req.FlushInput,
 	}
 
-	reader := serial.NewReader(s.manager, req.PortName, req.SessionId, chunkSize)
-	s.readers[req.PortName] = reader
+	if req.Pattern != "" {
+		re, err := regexp.Compile(req.Pattern)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pattern: %v", err)
+		}
+		opts.Pattern = re
+	}
 
-	if err := reader.Start(stream.Context()); err != nil {
-		return status.Errorf(codes.Internal, "failed to start reader: %v", err)
+	_, span := startSpan(ctx, "serial.Transact", req.PortName, req.SessionId)
+	result, err := s.manager.Transact(req.PortName, req.SessionId, opts)
+	if err == nil {
+		span.SetAttributes(
+			attribute.Int("serial.bytes", len(result.Data)),
+			attribute.Bool("serial.matched", result.Matched),
+		)
 	}
-	defer reader.Stop()
+	endSpan(span, err)
 
-	subscription := reader.Subscribe()
+	var sent int
+	if err == nil {
+		sent = len(req.Data)
+	}
+	s.recordWrite(ctx, "Transact", req.PortName, req.SessionId, sent, err)
 
-	for {
-		select {
-		case <-stream.Context().Done():
-			return nil
-		case event, ok := <-subscription:
-			if !ok {
-				return nil
-			}
+	if err != nil {
+		if errors.Is(err, serial.ErrRateLimited) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return &pb.TransactResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
 
-			if event.Error != nil {
-				if event.Error == serial.ErrPortClosed {
-					return nil
-				}
-				continue
-			}
+	message := "response matched"
+	if !result.Matched {
+		message = "timeout waiting for response"
+	} else if result.ChecksumError {
+		message = "response checksum mismatch"
+	}
 
-			chunk := &pb.DataChunk{
-				PortName: req.PortName,
-				Data:     event.Data,
-				Sequence: event.Sequence,
-			}
+	return &pb.TransactResponse{
+		Success:       result.Matched && !result.ChecksumError,
+		Data:          result.Data,
+		Matched:       result.Matched,
+		ElapsedMs:     uint32(result.Elapsed.Milliseconds()),
+		Message:       message,
+		ChecksumError: result.ChecksumError,
+	}, nil
+}
 
-			if req.IncludeTimestamps {
-				chunk.Timestamp = event.Timestamp.UnixNano()
-			}
+// WriteBatch writes an ordered list of items without interleaving other
+// traffic, verifying expected echoes and stopping at the first failure
+func (s *SerialServer) WriteBatch(ctx context.Context, req *pb.WriteBatchRequest) (*pb.WriteBatchResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
 
-			if err := stream.Send(chunk); err != nil {
-				return err
-			}
+	items := make([]serial.BatchItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = serial.BatchItem{
+			Data:        item.Data,
+			Delay:       time.Duration(item.DelayMs) * time.Millisecond,
+			Echo:        item.ExpectedEcho,
+			EchoTimeout: time.Duration(item.EchoTimeoutMs) * time.Millisecond,
 		}
 	}
-}
 
-// StreamWrite writes streaming data to a port
-func (s *SerialServer) StreamWrite(stream pb.SerialService_StreamWriteServer) error {
-	var totalBytes uint64
-	var chunksProcessed uint32
+	_, span := startSpan(ctx, "serial.WriteBatch", req.PortName, req.SessionId)
+	results, err := s.manager.WriteBatch(req.PortName, req.SessionId, items)
 
-	for {
-		chunk, err := stream.Recv()
-		if err == io.EOF {
-			return stream.SendAndClose(&pb.StreamWriteResponse{
+	resp := &pb.WriteBatchResponse{Success: err == nil}
+	for i, result := range results {
+		item := &pb.WriteBatchItemResult{
+			Executed:     result.Executed,
+			Success:      result.Executed && result.Err == nil,
+			BytesWritten: uint32(result.Written),
+			Echo:         result.Echo,
+		}
+		if result.Err != nil {
+			item.Message = result.Err.Error()
+			resp.Message = fmt.Sprintf("item %d: %v", i, result.Err)
+		}
+		if !item.Success {
+			resp.Success = false
+		}
+		resp.BytesWritten += item.BytesWritten
+		resp.Results = append(resp.Results, item)
+	}
+
+	batchErr := err
+	if batchErr == nil && !resp.Success {
+		batchErr = errors.New(resp.Message)
+	}
+	span.SetAttributes(attribute.Int("serial.bytes", int(resp.BytesWritten)))
+	endSpan(span, batchErr)
+	s.recordWrite(ctx, "WriteBatch", req.PortName, req.SessionId, int(resp.BytesWritten), batchErr)
+
+	if err != nil {
+		if errors.Is(err, serial.ErrRateLimited) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		resp.Message = err.Error()
+		return resp, nil
+	}
+
+	if resp.Success {
+		resp.Message = fmt.Sprintf("wrote %d items", len(results))
+	}
+	return resp, nil
+}
+
+// Flush discards pending input or output, or waits for output to drain
+func (s *SerialServer) Flush(ctx context.Context, req *pb.FlushRequest) (*pb.FlushResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	var err error
+	switch req.Mode {
+	case pb.FlushMode_FLUSH_MODE_BOTH:
+		err = s.manager.Flush(req.PortName, req.SessionId)
+	case pb.FlushMode_FLUSH_MODE_INPUT:
+		err = s.manager.FlushInput(req.PortName, req.SessionId)
+	case pb.FlushMode_FLUSH_MODE_OUTPUT:
+		err = s.manager.FlushOutput(req.PortName, req.SessionId)
+	case pb.FlushMode_FLUSH_MODE_DRAIN:
+		err = s.manager.Drain(req.PortName, req.SessionId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid flush mode: %v", req.Mode)
+	}
+
+	if err != nil {
+		return &pb.FlushResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.FlushResponse{
+		Success: true,
+		Message: "port flushed successfully",
+	}, nil
+}
+
+// GetBufferStatus reports the data waiting in a session's driver queues and
+// receive buffer
+func (s *SerialServer) GetBufferStatus(ctx context.Context, req *pb.GetBufferStatusRequest) (*pb.BufferStatus, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	buffers, err := s.manager.GetBufferStatus(req.PortName, req.SessionId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed to get buffer status: %v", err)
+	}
+
+	return &pb.BufferStatus{
+		DriverInput:     uint32(buffers.DriverInput),
+		DriverOutput:    uint32(buffers.DriverOutput),
+		DriverReported:  buffers.DriverReported,
+		Buffered:        uint32(buffers.Buffered),
+		BufferSize:      uint32(buffers.BufferSize),
+		Dropped:         buffers.Dropped,
+		WriteQueueDepth: uint32(buffers.WriteQueueDepth),
+	}, nil
+}
+
+// StreamRead streams data from a port
+func (s *SerialServer) StreamRead(req *pb.StreamReadRequest, stream pb.SerialService_StreamReadServer) error {
+	if req.PortName == "" {
+		return status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	chunkSize := int(req.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = 1024
+	}
+
+	// The span covers the whole stream, so its duration is how long the
+	// client stayed subscribed
+	_, span := startSpan(stream.Context(), "serial.StreamRead", req.PortName, req.SessionId)
+	var chunks, total int
+	var streamErr error
+	defer func() {
+		span.SetAttributes(attribute.Int("serial.chunks", chunks), attribute.Int("serial.bytes", total))
+		endSpan(span, streamErr)
+	}()
+
+	reader := serial.NewReader(s.manager, req.PortName, req.SessionId, chunkSize)
+
+	var streamID string
+	if req.AckWindow > 0 {
+		streamID = reader.EnableAcks(int(req.AckWindow))
+	}
+
+	if err := reader.Start(stream.Context()); err != nil {
+		streamErr = err
+		return status.Errorf(codes.Internal, "failed to start reader: %v", err)
+	}
+	defer reader.Stop()
+
+	subscription := reader.Subscribe()
+	shutdown := s.shutdown
+
+	// Acknowledged streams open with the ID the client acks with
+	if streamID != "" {
+		if err := stream.Send(&pb.DataChunk{PortName: req.PortName, StreamId: streamID}); err != nil {
+			streamErr = err
+			return err
+		}
+	}
+
+	// Offset of the next byte in the stream, for hex dumps
+	var offset uint64
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			span.AddEvent("client disconnected")
+			return nil
+		case <-shutdown:
+			// Data keeps flowing until the server stops
+			shutdown = nil
+			span.AddEvent("agent shutting down")
+			if err := stream.Send(&pb.DataChunk{PortName: req.PortName, Shutdown: true}); err != nil {
+				streamErr = err
+				return err
+			}
+		case event, ok := <-subscription:
+			if !ok {
+				return nil
+			}
+
+			if event.Error != nil {
+				if event.Error == serial.ErrPortClosed {
+					span.AddEvent("port closed")
+					return nil
+				}
+				if event.Error == serial.ErrSessionTakenOver {
+					streamErr = event.Error
+					return status.Error(codes.Aborted, event.Error.Error())
+				}
+				span.AddEvent("read error", trace.WithAttributes(attribute.String("error", event.Error.Error())))
+				continue
+			}
+
+			if event.Gap {
+				span.AddEvent("gap", trace.WithAttributes(attribute.Int64("serial.sequence", int64(event.Sequence))))
+			}
+			if event.Overflow != nil {
+				span.AddEvent("overflow", trace.WithAttributes(
+					attribute.Int("serial.lost_chunks", event.Overflow.Chunks),
+					attribute.Int("serial.lost_bytes", event.Overflow.Bytes)))
+				// Keep hex dump offsets in step with the device's stream
+				offset += uint64(event.Overflow.Bytes)
+				if err := stream.Send(&pb.DataChunk{
+					PortName: req.PortName,
+					Overflow: convertOverflow(event.Overflow),
+				}); err != nil {
+					streamErr = err
+					return err
+				}
+				continue
+			}
+
+			chunk := newDataChunk(req.PortName, event, req.IncludeTimestamps)
+
+			if req.HexDump {
+				chunk.HexDump = hexDump(event.Data, offset)
+			}
+			offset += uint64(len(event.Data))
+
+			if err := stream.Send(chunk); err != nil {
+				streamErr = err
+				return err
+			}
+			chunks++
+			total += len(event.Data)
+		}
+	}
+}
+
+// newDataChunk converts data received on a port into a stream chunk
+func newDataChunk(portName string, event serial.DataEvent, includeTimestamps bool) *pb.DataChunk {
+	chunk := &pb.DataChunk{
+		PortName:        portName,
+		Data:            event.Data,
+		Sequence:        event.Sequence,
+		Gap:             event.Gap,
+		SessionSequence: event.SessionSequence,
+	}
+
+	if includeTimestamps {
+		chunk.Timestamp = event.Timestamp.UnixNano()
+		if received := event.Received; !received.First.IsZero() {
+			chunk.FirstByteTime = received.First.UnixNano()
+			chunk.LastByteTime = received.Last.UnixNano()
+			chunk.FirstByteMonotonic = serial.MonotonicNanos(received.First)
+			chunk.LastByteMonotonic = serial.MonotonicNanos(received.Last)
+		}
+	}
+
+	return chunk
+}
+
+// AckStream acknowledges chunks received on an acknowledged StreamRead
+func (s *SerialServer) AckStream(ctx context.Context, req *pb.AckStreamRequest) (*pb.AckStreamResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if req.StreamId == "" {
+		return nil, status.Error(codes.InvalidArgument, "stream_id is required")
+	}
+
+	pending, err := s.manager.AckStream(req.PortName, req.SessionId, req.StreamId, req.Sequence)
+	if err != nil {
+		return &pb.AckStreamResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AckStreamResponse{
+		Success:        true,
+		Message:        "chunks acknowledged",
+		Unacknowledged: uint32(pending),
+	}, nil
+}
+
+// StreamWrite writes streaming data to a port
+func (s *SerialServer) StreamWrite(stream pb.SerialService_StreamWriteServer) error {
+	var totalBytes uint64
+	var chunksProcessed uint32
+
+	// The stream is audited once it ends, with the total written
+	var portName, sessionID string
+	recordStream := func(err error) {
+		if portName != "" {
+			s.recordWrite(stream.Context(), "StreamWrite", portName, sessionID, int(totalBytes), err)
+		}
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			recordStream(nil)
+			return stream.SendAndClose(&pb.StreamWriteResponse{
 				Success:           true,
 				TotalBytesWritten: totalBytes,
 				ChunksProcessed:   chunksProcessed,
@@ -352,17 +1109,23 @@ func (s *SerialServer) StreamWrite(stream pb.SerialService_StreamWriteServer) er
 			})
 		}
 		if err != nil {
+			recordStream(err)
 			return err
 		}
 
 		// We need a session ID from somewhere - use first chunk's port
+		portName = chunk.PortName
 		session := s.manager.GetSession(chunk.PortName)
 		if session == nil {
+			recordStream(serial.ErrPortNotOpen)
 			return status.Error(codes.NotFound, "port not open")
 		}
+		sessionID = session.ID
 
-		n, err := s.manager.Write(chunk.PortName, session.ID, chunk.Data)
+		n, err := s.write(chunk.PortName, session.ID, chunk.Data, chunk.CorrelationId)
 		if err != nil {
+			atomic.AddUint64(&totalBytes, uint64(n))
+			recordStream(err)
 			return status.Errorf(codes.Internal, "write failed: %v", err)
 		}
 
@@ -379,25 +1142,41 @@ func (s *SerialServer) BiDirectionalStream(stream pb.SerialService_BiDirectional
 	errChan := make(chan error, 2)
 
 	go func() {
+		// The stream is audited once it ends, with the total written
+		var portName, sessionID string
+		var totalBytes int
+		recordStream := func(err error) {
+			if portName != "" {
+				s.recordWrite(ctx, "BiDirectionalStream", portName, sessionID, totalBytes, err)
+			}
+		}
+
 		for {
 			chunk, err := stream.Recv()
 			if err == io.EOF {
+				recordStream(nil)
 				errChan <- nil
 				return
 			}
 			if err != nil {
+				recordStream(err)
 				errChan <- err
 				return
 			}
 
+			portName = chunk.PortName
 			session := s.manager.GetSession(chunk.PortName)
 			if session == nil {
+				recordStream(serial.ErrPortNotOpen)
 				errChan <- status.Error(codes.NotFound, "port not open")
 				return
 			}
+			sessionID = session.ID
 
-			_, err = s.manager.Write(chunk.PortName, session.ID, chunk.Data)
+			n, err := s.write(chunk.PortName, session.ID, chunk.Data, chunk.CorrelationId)
+			totalBytes += n
 			if err != nil {
+				recordStream(err)
 				errChan <- err
 				return
 			}
@@ -408,14 +1187,92 @@ func (s *SerialServer) BiDirectionalStream(stream pb.SerialService_BiDirectional
 	// Note: This requires a reader to be set up for the port
 	// For now, this is a simplified implementation
 
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case err := <-errChan:
-		return err
+	shutdown := s.shutdown
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errChan:
+			return err
+		case <-shutdown:
+			shutdown = nil
+			if err := stream.Send(&pb.DataChunk{Shutdown: true}); err != nil {
+				return err
+			}
+		}
 	}
 }
 
+// StreamEvents streams session events such as write completions
+func (s *SerialServer) StreamEvents(req *pb.StreamEventsRequest, stream pb.SerialService_StreamEventsServer) error {
+	events, cancel := s.manager.Events().Subscribe()
+	defer cancel()
+
+	id, _ := auth.FromContext(stream.Context())
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			// Shutdown concerns every subscriber
+			if event.Type != serial.EventAgentShutdown {
+				if req.PortName != "" && !serial.SamePort(event.PortName, req.PortName) {
+					continue
+				}
+				if req.SessionId != "" && event.SessionID != req.SessionId {
+					continue
+				}
+				if id != nil && !id.CanAccessPort(event.PortName) {
+					continue
+				}
+			}
+
+			msg := convertEvent(event)
+			msg.SessionId = s.visibleID(stream.Context(), event.PortName, event.SessionID)
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RunScript executes a send/expect script against a session and streams
+// the transcript back to the client
+func (s *SerialServer) RunScript(req *pb.RunScriptRequest, stream pb.SerialService_RunScriptServer) error {
+	if req.PortName == "" {
+		return status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	sc, err := script.Parse(req.Script)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := s.manager.ValidateSession(req.PortName, req.SessionId); err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	var sendErr error
+	runner := script.NewRunner(s.manager, req.PortName, req.SessionId, func(event script.Event) {
+		if sendErr == nil {
+			sendErr = stream.Send(convertScriptEvent(event))
+		}
+	})
+
+	// Step failures are reported in the transcript rather than as an RPC error
+	runner.Run(stream.Context(), sc)
+
+	return sendErr
+}
+
 // ConfigurePort configures a port
 func (s *SerialServer) ConfigurePort(ctx context.Context, req *pb.ConfigurePortRequest) (*pb.ConfigurePortResponse, error) {
 	if req.PortName == "" {
@@ -428,6 +1285,13 @@ func (s *SerialServer) ConfigurePort(ctx context.Context, req *pb.ConfigurePortR
 	cfg := s.convertToSerialConfig(req.Config)
 
 	err := s.manager.Configure(req.PortName, req.SessionId, cfg)
+
+	entry := audit.Entry{Operation: "ConfigurePort", PortName: req.PortName, SessionID: req.SessionId, Success: err == nil, Message: formatConfig(cfg)}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
 	if err != nil {
 		return &pb.ConfigurePortResponse{
 			Success: false,
@@ -447,12 +1311,213 @@ func (s *SerialServer) GetPortConfig(ctx context.Context, req *pb.GetPortConfigR
 		return nil, status.Error(codes.InvalidArgument, "port_name is required")
 	}
 
-	session, err := s.manager.GetStatus(req.PortName)
+	portStatus, err := s.manager.GetStatus(req.PortName)
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "port not open: %v", err)
 	}
 
-	return s.convertFromSerialConfig(session.Config), nil
+	return s.convertFromSerialConfig(portStatus.Config), nil
+}
+
+// AddTap starts mirroring a session's traffic to a file or TCP socket
+func (s *SerialServer) AddTap(ctx context.Context, req *pb.AddTapRequest) (*pb.AddTapResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if req.Tap == nil {
+		return nil, status.Error(codes.InvalidArgument, "tap is required")
+	}
+
+	tap, err := s.manager.AddTap(req.PortName, req.SessionId, convertTapConfig(req.Tap))
+	if err != nil {
+		return &pb.AddTapResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AddTapResponse{
+		Success: true,
+		Message: "tap started",
+		TapId:   tap.ID,
+	}, nil
+}
+
+// RemoveTap stops a tap
+func (s *SerialServer) RemoveTap(ctx context.Context, req *pb.RemoveTapRequest) (*pb.RemoveTapResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	if err := s.manager.RemoveTap(req.PortName, req.SessionId, req.TapId); err != nil {
+		return &pb.RemoveTapResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RemoveTapResponse{
+		Success: true,
+		Message: "tap stopped",
+	}, nil
+}
+
+// StartPassthrough hands an exclusive session's port to a program on the
+// agent through a pseudo-terminal or TCP listener
+func (s *SerialServer) StartPassthrough(ctx context.Context, req *pb.StartPassthroughRequest) (*pb.StartPassthroughResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	entry := audit.Entry{Operation: "StartPassthrough", PortName: req.PortName, SessionID: req.SessionId}
+
+	p, err := s.manager.StartPassthrough(req.PortName, req.SessionId, serial.PassthroughOptions{
+		PTY:        req.Pty,
+		TCPAddress: req.TcpAddress,
+	})
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		return &pb.StartPassthroughResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	entry.Success = true
+	entry.Message = "passthrough on " + p.Address
+	s.record(ctx, entry)
+
+	return &pb.StartPassthroughResponse{
+		Success: true,
+		Message: "passthrough started",
+		Address: p.Address,
+	}, nil
+}
+
+// StopPassthrough returns a passed-through port to normal operation
+func (s *SerialServer) StopPassthrough(ctx context.Context, req *pb.StopPassthroughRequest) (*pb.StopPassthroughResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	var p *serial.Passthrough
+	if session, err := s.manager.ValidateSession(req.PortName, req.SessionId); err == nil {
+		p = session.Passthrough()
+	}
+
+	err := s.manager.StopPassthrough(req.PortName, req.SessionId)
+
+	entry := audit.Entry{Operation: "StopPassthrough", PortName: req.PortName, SessionID: req.SessionId, Success: err == nil, Message: "passthrough stopped"}
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		return &pb.StopPassthroughResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	s.record(ctx, entry)
+
+	resp := &pb.StopPassthroughResponse{
+		Success: true,
+		Message: "passthrough stopped",
+	}
+	if p != nil {
+		resp.BytesIn = p.BytesIn()
+		resp.BytesOut = p.BytesOut()
+	}
+	return resp, nil
+}
+
+// TestPort runs a loopback test on a session and reports latency,
+// throughput, and error rate
+func (s *SerialServer) TestPort(ctx context.Context, req *pb.TestPortRequest) (*pb.TestPortReport, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	report, err := s.manager.LoopbackTest(req.PortName, req.SessionId, serial.LoopbackOptions{
+		Pattern:    req.Pattern,
+		Iterations: int(req.Iterations),
+		Timeout:    time.Duration(req.TimeoutMs) * time.Millisecond,
+	})
+	if err != nil {
+		return &pb.TestPortReport{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	resp := &pb.TestPortReport{
+		Success:        report.PacketsLost == 0 && report.ByteErrors == 0,
+		Message:        "loopback test passed",
+		Iterations:     uint32(report.Iterations),
+		PacketsLost:    uint32(report.PacketsLost),
+		BytesSent:      uint64(report.BytesSent),
+		BytesReceived:  uint64(report.BytesReceived),
+		ByteErrors:     uint64(report.ByteErrors),
+		ErrorRate:      report.ErrorRate(),
+		MinLatencyUs:   report.MinLatency.Microseconds(),
+		AvgLatencyUs:   report.AvgLatency.Microseconds(),
+		MaxLatencyUs:   report.MaxLatency.Microseconds(),
+		ThroughputBps:  report.ThroughputBps,
+		TheoreticalBps: report.TheoreticalBps,
+		BaudRate:       uint32(report.BaudRate),
+	}
+	if report.BytesReceived == 0 {
+		resp.Message = "no data returned; check the TX-RX jumper or echo device"
+	} else if !resp.Success {
+		resp.Message = "loopback test detected lost or corrupted data"
+	}
+
+	return resp, nil
+}
+
+// GetStatistics reports counters, rolling throughput, error counts, and
+// queue depths for one or all open sessions
+func (s *SerialServer) GetStatistics(ctx context.Context, req *pb.GetStatisticsRequest) (*pb.GetStatisticsResponse, error) {
+	var sessions []*serial.Session
+	if req.PortName != "" {
+		session := s.manager.GetSession(req.PortName)
+		if session == nil {
+			return nil, status.Error(codes.NotFound, "port is not open")
+		}
+		sessions = append(sessions, session)
+	} else {
+		sessions = s.manager.Sessions()
+	}
+
+	id, _ := auth.FromContext(ctx)
+
+	resp := &pb.GetStatisticsResponse{
+		Sessions: make([]*pb.SessionStatistics, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		if id != nil && !id.CanAccessPort(session.PortName) {
+			continue
+		}
+		stats := s.convertSessionStatistics(session)
+		stats.SessionId = s.visibleID(ctx, session.PortName, stats.SessionId)
+		resp.Sessions = append(resp.Sessions, stats)
+	}
+
+	return resp, nil
 }
 
 // Ping checks if the server is alive
@@ -468,42 +1533,346 @@ func (s *SerialServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingR
 	}, nil
 }
 
-// GetAgentInfo returns information about the agent
-func (s *SerialServer) GetAgentInfo(ctx context.Context, req *pb.GetAgentInfoRequest) (*pb.AgentInfo, error) {
-	return &pb.AgentInfo{
-		Version:     Version,
-		BuildCommit: Commit,
-		BuildDate:   BuildDate,
-		Os:          runtime.GOOS,
-		Arch:        runtime.GOARCH,
-		UptimeSeconds: int64(time.Since(s.startTime).Seconds()),
-		SupportedFeatures: []string{
-			"grpc",
-			"port-scan",
-			"port-lock",
-			"streaming",
-		},
-		Config: &pb.AgentConfig{
-			GrpcAddress:    s.config.Server.GRPCAddress,
-			TlsEnabled:     s.config.TLS.Enabled,
-			MaxConnections: uint32(s.config.Server.MaxConnections),
-		},
+// agentInfo is what GetAgentInfo reports, independent of API version
+type agentInfo struct {
+	Version      string
+	BuildCommit  string
+	BuildDate    string
+	OS           string
+	Arch         string
+	Uptime       time.Duration
+	GRPCAddress  string
+	TLSEnabled   bool
+	Capabilities []agentCapability
+	Limits       agentLimits
+	APIVersions  []string
+}
+
+// agentInfo returns information about the agent
+func (s *SerialServer) agentInfo() agentInfo {
+	return agentInfo{
+		Version:      Version,
+		BuildCommit:  Commit,
+		BuildDate:    BuildDate,
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		Uptime:       time.Since(s.startTime),
+		GRPCAddress:  s.config.Server.GRPCAddress,
+		TLSEnabled:   s.config.TLS.Enabled,
+		Capabilities: s.capabilities(),
+		Limits:       s.limits(),
+		APIVersions:  APIVersions(),
+	}
+}
+
+// CreateAccessLink mints a temporary, scope-limited access token
+func (s *SerialServer) CreateAccessLink(ctx context.Context, req *pb.CreateAccessLinkRequest) (*pb.AccessLink, error) {
+	if s.authn == nil {
+		return nil, status.Error(codes.FailedPrecondition, "authentication is not enabled")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	if maxTTL := time.Duration(s.config.Auth.MaxLinkTTL) * time.Second; maxTTL > 0 && ttl > maxTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl exceeds maximum of %s", maxTTL)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	token, err := s.authn.MintGuestToken(auth.GuestClaims{
+		Name:      req.Name,
+		Ports:     canonicalPortNames(req.Ports),
+		ReadOnly:  req.ReadOnly,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access link: %v", err)
+	}
+
+	address := s.config.Auth.PublicAddress
+	if address == "" {
+		address = s.config.Server.GRPCAddress
+	}
+
+	return &pb.AccessLink{
+		Token:            token,
+		ConnectionString: auth.ConnectionString(address, token),
+		ExpiresAt:        expiresAt.Unix(),
+	}, nil
+}
+
+// canonicalPortNames returns the canonical form of each port name, so a
+// guest token's scope matches the names ports are keyed by
+func canonicalPortNames(names []string) []string {
+	if len(names) == 0 {
+		return names
+	}
+	canonical := make([]string, len(names))
+	for i, name := range names {
+		canonical[i] = serial.CanonicalPortName(name)
+	}
+	return canonical
+}
+
+// ListSessions returns every active session on the agent
+func (s *SerialServer) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	sessions := s.manager.Sessions()
+
+	resp := &pb.ListSessionsResponse{
+		Sessions: make([]*pb.SessionInfo, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, s.convertSessionInfo(session))
+	}
+
+	return resp, nil
+}
+
+// ForceClose closes a session on behalf of an administrator
+func (s *SerialServer) ForceClose(ctx context.Context, req *pb.ForceCloseRequest) (*pb.ForceCloseResponse, error) {
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.manager.ForceClose(req.SessionId)
+	if err == serial.ErrInvalidSession {
+		s.record(ctx, audit.Entry{Operation: "ForceClose", SessionID: req.SessionId, Message: "session not found", Reason: req.Reason})
+		return &pb.ForceCloseResponse{
+			Success: false,
+			Message: "session not found",
+		}, nil
+	}
+
+	caller := "anonymous"
+	if id, ok := auth.FromContext(ctx); ok {
+		caller = id.Name
+	}
+	log.Printf("audit: session %s on port %s (client %s) force-closed by %s: %s",
+		req.SessionId, session.PortName, session.ClientID, caller, req.Reason)
+
+	entry := audit.Entry{
+		Operation: "ForceClose",
+		ClientID:  session.ClientID,
+		PortName:  session.PortName,
+		SessionID: req.SessionId,
+		Success:   err == nil,
+		Message:   "session closed",
+		Reason:    req.Reason,
+	}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to close port: %v", err)
+	}
+
+	return &pb.ForceCloseResponse{
+		Success:  true,
+		Message:  "session closed",
+		PortName: session.PortName,
+	}, nil
+}
+
+// ResetDevice resets the USB adapter behind a port
+func (s *SerialServer) ResetDevice(ctx context.Context, req *pb.ResetDeviceRequest) (*pb.ResetDeviceResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+
+	err := serial.ResetDevice(req.PortName)
+
+	caller := "anonymous"
+	if id, ok := auth.FromContext(ctx); ok {
+		caller = id.Name
+	}
+	entry := audit.Entry{
+		Operation: "ResetDevice",
+		PortName:  req.PortName,
+		Success:   err == nil,
+		Message:   "device reset",
+		Reason:    req.Reason,
+	}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
+	if err != nil {
+		return &pb.ResetDeviceResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	log.Printf("audit: USB device on port %s reset by %s: %s", req.PortName, caller, req.Reason)
+	return &pb.ResetDeviceResponse{
+		Success: true,
+		Message: "device reset",
 	}, nil
 }
 
 // Helper functions
 
+func (s *SerialServer) convertSessionInfo(session *serial.Session) *pb.SessionInfo {
+	stats := session.Statistics.Snapshot()
+
+	subscribers := session.SubscriberCount()
+
+	return &pb.SessionInfo{
+		SessionId:     session.ID,
+		PortName:      session.PortName,
+		ClientId:      session.ClientID,
+		Exclusive:     session.Exclusive,
+		Managed:       session.Managed,
+		Priority:      int32(session.Priority),
+		OpenedAt:      stats.OpenedAt.Unix(),
+		UptimeSeconds: int64(time.Since(stats.OpenedAt).Seconds()),
+		BytesSent:     stats.BytesSent,
+		BytesReceived: stats.BytesReceived,
+		Errors:        stats.Errors,
+		Subscribers:   uint32(subscribers),
+		Attachments:   uint32(session.AttachmentCount()),
+	}
+}
+
+func (s *SerialServer) convertSessionStatistics(session *serial.Session) *pb.SessionStatistics {
+	stats := session.Statistics.Snapshot()
+
+	subscribers := session.SubscriberCount()
+
+	unix := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+
+	rates := session.Rates()
+	result := &pb.SessionStatistics{
+		PortName:      session.PortName,
+		SessionId:     session.ID,
+		BytesSent:     stats.BytesSent,
+		BytesReceived: stats.BytesReceived,
+		Rates:         make([]*pb.ThroughputRate, 0, len(rates)),
+		Errors: &pb.ErrorCounts{
+			Io:          stats.Errors,
+			Framing:     stats.FramingErrors,
+			Checksum:    stats.ChecksumErrors,
+			RateLimited: stats.RateLimited,
+		},
+		WriteQueueDepth: uint32(s.manager.QueueDepth(session.PortName)),
+		BufferedBytes:   uint32(session.BufferedBytes()),
+		Subscribers:     uint32(subscribers),
+		OpenedAt:        unix(stats.OpenedAt),
+		LastActivity:    unix(stats.LastActivity),
+		LastSent:        unix(session.LastSent()),
+		LastReceived:    unix(session.LastReceived()),
+		ReadLatency:     convertLatencyStats(stats.ReadLatency),
+		WriteDuration:   convertLatencyStats(stats.WriteDuration),
+	}
+	for _, rate := range rates {
+		result.Rates = append(result.Rates, &pb.ThroughputRate{
+			WindowSeconds: uint32(rate.Window / time.Second),
+			SentBps:       rate.SentBps,
+			ReceivedBps:   rate.ReceivedBps,
+		})
+	}
+
+	return result
+}
+
+// write sends data to a port. Writes carrying a correlation ID are tracked:
+// the output is drained and a write-complete event is published so that
+// fire-and-forget clients can confirm delivery on the event stream.
+func (s *SerialServer) write(portName, sessionID string, data []byte, correlationID string) (int, error) {
+	if correlationID == "" {
+		return s.manager.Write(portName, sessionID, data)
+	}
+	return s.manager.WriteTracked(portName, sessionID, data, correlationID, true)
+}
+
+func convertEvent(event serial.Event) *pb.SessionEvent {
+	return &pb.SessionEvent{
+		Type:          convertEventType(event.Type),
+		PortName:      event.PortName,
+		SessionId:     event.SessionID,
+		TicketId:      event.TicketID,
+		Timestamp:     event.Timestamp.UnixNano(),
+		CorrelationId: event.CorrelationID,
+		BytesWritten:  uint32(event.BytesWritten),
+		Drained:       event.Drained,
+		Message:       event.Message,
+		Rule:          event.Rule,
+		Job:           event.Job,
+		Data:          event.Data,
+		ClientId:      event.ClientID,
+		QueuePosition: uint32(event.QueuePosition),
+	}
+}
+
+func convertScriptEvent(event script.Event) *pb.ScriptEvent {
+	return &pb.ScriptEvent{
+		Step:      uint32(event.Step),
+		Type:      convertScriptEventType(event.Type),
+		Data:      event.Data,
+		Message:   event.Message,
+		Timestamp: event.Timestamp.UnixNano(),
+	}
+}
+
+func convertScriptEventType(t script.EventType) pb.ScriptEventType {
+	switch t {
+	case script.EventSent:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_SENT
+	case script.EventReceived:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_RECEIVED
+	case script.EventMatched:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_MATCHED
+	case script.EventSlept:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_SLEPT
+	case script.EventFailed:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_FAILED
+	case script.EventCompleted:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_COMPLETED
+	default:
+		return pb.ScriptEventType_SCRIPT_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+func convertEventType(t serial.EventType) pb.EventType {
+	switch t {
+	case serial.EventWriteComplete:
+		return pb.EventType_EVENT_TYPE_WRITE_COMPLETE
+	case serial.EventSessionTerminated:
+		return pb.EventType_EVENT_TYPE_SESSION_TERMINATED
+	case serial.EventSessionSuspended:
+		return pb.EventType_EVENT_TYPE_SESSION_SUSPENDED
+	case serial.EventSessionResumed:
+		return pb.EventType_EVENT_TYPE_SESSION_RESUMED
+	case serial.EventRuleMatched:
+		return pb.EventType_EVENT_TYPE_RULE_MATCHED
+	case serial.EventJobCompleted:
+		return pb.EventType_EVENT_TYPE_JOB_COMPLETED
+	case serial.EventSessionExpired:
+		return pb.EventType_EVENT_TYPE_SESSION_EXPIRED
+	case serial.EventAgentShutdown:
+		return pb.EventType_EVENT_TYPE_AGENT_SHUTDOWN
+	case serial.EventQueuePosition:
+		return pb.EventType_EVENT_TYPE_QUEUE_POSITION
+	default:
+		return pb.EventType_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
 func (s *SerialServer) convertToSerialConfig(cfg *pb.PortConfig) serial.PortConfig {
 	if cfg == nil {
-		return serial.PortConfig{
-			BaudRate:       s.config.Serial.Defaults.BaudRate,
-			DataBits:       s.config.Serial.Defaults.DataBits,
-			StopBits:       serial.StopBits(s.config.Serial.Defaults.StopBits),
-			Parity:         serial.ParityNone,
-			FlowControl:    serial.FlowControlNone,
-			ReadTimeoutMs:  s.config.Serial.Defaults.ReadTimeoutMs,
-			WriteTimeoutMs: s.config.Serial.Defaults.WriteTimeoutMs,
-		}
+		return s.settingsToPortConfig(config.SerialDefaults{})
 	}
 
 	return serial.PortConfig{
@@ -512,8 +1881,34 @@ func (s *SerialServer) convertToSerialConfig(cfg *pb.PortConfig) serial.PortConf
 		StopBits:       convertStopBits(cfg.StopBits),
 		Parity:         convertParity(cfg.Parity),
 		FlowControl:    convertFlowControl(cfg.FlowControl),
+		XonChar:        int(cfg.XonChar),
+		XoffChar:       int(cfg.XoffChar),
 		ReadTimeoutMs:  int(cfg.ReadTimeoutMs),
 		WriteTimeoutMs: int(cfg.WriteTimeoutMs),
+		Framer:         cfg.Framer,
+
+		WriteChunkSize:    int(cfg.WriteChunkSize),
+		WriteChunkDelayMs: int(cfg.WriteChunkDelayMs),
+		WriteLineDelayMs:  int(cfg.WriteLineDelayMs),
+
+		RS485: serial.RS485Config{
+			Enabled:           cfg.Rs485.GetEnabled(),
+			RTSActiveLow:      cfg.Rs485.GetRtsActiveLow(),
+			DelayBeforeSendMs: int(cfg.Rs485.GetDelayBeforeSendMs()),
+			DelayAfterSendMs:  int(cfg.Rs485.GetDelayAfterSendMs()),
+		},
+
+		Checksum: serial.ChecksumConfig{
+			Algorithm:  cfg.Checksum.GetAlgorithm(),
+			Polynomial: cfg.Checksum.GetPolynomial(),
+			Placement:  cfg.Checksum.GetPlacement(),
+			Terminator: string(cfg.Checksum.GetTerminator()),
+			BigEndian:  cfg.Checksum.GetBigEndian(),
+			Hex:        cfg.Checksum.GetHex(),
+		},
+
+		ReadFilters:  cfg.ReadFilters,
+		WriteFilters: cfg.WriteFilters,
 	}
 }
 
@@ -524,8 +1919,160 @@ func (s *SerialServer) convertFromSerialConfig(cfg serial.PortConfig) *pb.PortCo
 		StopBits:       convertStopBitsBack(cfg.StopBits),
 		Parity:         convertParityBack(cfg.Parity),
 		FlowControl:    convertFlowControlBack(cfg.FlowControl),
+		XonChar:        uint32(cfg.XonChar),
+		XoffChar:       uint32(cfg.XoffChar),
 		ReadTimeoutMs:  uint32(cfg.ReadTimeoutMs),
 		WriteTimeoutMs: uint32(cfg.WriteTimeoutMs),
+		Framer:         cfg.Framer,
+
+		WriteChunkSize:    uint32(cfg.WriteChunkSize),
+		WriteChunkDelayMs: uint32(cfg.WriteChunkDelayMs),
+		WriteLineDelayMs:  uint32(cfg.WriteLineDelayMs),
+
+		Rs485: &pb.RS485Config{
+			Enabled:           cfg.RS485.Enabled,
+			RtsActiveLow:      cfg.RS485.RTSActiveLow,
+			DelayBeforeSendMs: uint32(cfg.RS485.DelayBeforeSendMs),
+			DelayAfterSendMs:  uint32(cfg.RS485.DelayAfterSendMs),
+		},
+
+		Checksum: &pb.ChecksumConfig{
+			Algorithm:  cfg.Checksum.Algorithm,
+			Polynomial: cfg.Checksum.Polynomial,
+			Placement:  cfg.Checksum.Placement,
+			Terminator: []byte(cfg.Checksum.Terminator),
+			BigEndian:  cfg.Checksum.BigEndian,
+			Hex:        cfg.Checksum.Hex,
+		},
+
+		ReadFilters:  cfg.ReadFilters,
+		WriteFilters: cfg.WriteFilters,
+	}
+}
+
+func convertFlowStatus(flow *serial.FlowStatus) *pb.FlowStatus {
+	if flow == nil {
+		return nil
+	}
+	return &pb.FlowStatus{
+		Cts:    flow.CTS,
+		Dsr:    flow.DSR,
+		Dcd:    flow.DCD,
+		Paused: flow.Paused,
+	}
+}
+
+func convertLatencyStats(stats serial.LatencyStats) *pb.LatencyStats {
+	us := func(d time.Duration) uint64 {
+		return uint64(d.Microseconds())
+	}
+	return &pb.LatencyStats{
+		Count:    stats.Count,
+		TotalUs:  us(stats.Total),
+		P50Us:    us(stats.P50),
+		P90Us:    us(stats.P90),
+		P99Us:    us(stats.P99),
+		MaxUs:    us(stats.Max),
+		JitterUs: us(stats.Jitter),
+	}
+}
+
+func convertOverflow(overflow *serial.Overflow) *pb.Overflow {
+	return &pb.Overflow{
+		LostChunks:    uint32(overflow.Chunks),
+		LostBytes:     uint64(overflow.Bytes),
+		FirstSequence: overflow.FirstSequence,
+		LastSequence:  overflow.LastSequence,
+	}
+}
+
+func (s *SerialServer) convertAttachments(ctx context.Context, portName string, attachments []*serial.Attachment) []*pb.AttachmentInfo {
+	var result []*pb.AttachmentInfo
+	for _, att := range attachments {
+		result = append(result, &pb.AttachmentInfo{
+			AttachmentId:  s.visibleID(ctx, portName, att.ID),
+			ClientId:      att.ClientID,
+			Role:          convertRole(att.Role),
+			AttachedAt:    att.AttachedAt.Unix(),
+			BytesSent:     atomic.LoadUint64(&att.BytesSent),
+			BytesReceived: atomic.LoadUint64(&att.BytesReceived),
+		})
+	}
+	return result
+}
+
+func convertRole(role serial.Role) pb.SessionRole {
+	if role == serial.RoleReadOnly {
+		return pb.SessionRole_SESSION_ROLE_READ_ONLY
+	}
+	return pb.SessionRole_SESSION_ROLE_READ_WRITE
+}
+
+func convertTapConfig(cfg *pb.TapConfig) serial.TapOptions {
+	opts := serial.TapOptions{
+		File:       cfg.MirrorToFile,
+		TCPAddress: cfg.MirrorToTcp,
+	}
+	switch cfg.Direction {
+	case pb.TapDirection_TAP_DIRECTION_RX:
+		opts.Direction = serial.TapRX
+	case pb.TapDirection_TAP_DIRECTION_TX:
+		opts.Direction = serial.TapTX
+	default:
+		opts.Direction = serial.TapBoth
+	}
+	return opts
+}
+
+func convertTaps(taps []*serial.Tap) []*pb.TapInfo {
+	result := make([]*pb.TapInfo, 0, len(taps))
+	for _, tap := range taps {
+		result = append(result, &pb.TapInfo{
+			TapId:          tap.ID,
+			Config:         convertTapOptions(tap.Options),
+			BytesWritten:   tap.BytesWritten(),
+			RecordsDropped: tap.RecordsDropped(),
+		})
+	}
+	return result
+}
+
+func convertTapOptions(opts serial.TapOptions) *pb.TapConfig {
+	direction := pb.TapDirection_TAP_DIRECTION_BOTH
+	switch opts.Direction {
+	case serial.TapRX:
+		direction = pb.TapDirection_TAP_DIRECTION_RX
+	case serial.TapTX:
+		direction = pb.TapDirection_TAP_DIRECTION_TX
+	}
+	return &pb.TapConfig{
+		Direction:    direction,
+		MirrorToFile: opts.File,
+		MirrorToTcp:  opts.TCPAddress,
+	}
+}
+
+func convertRetryPolicy(p *pb.RetryPolicy) serial.RetryPolicy {
+	return serial.RetryPolicy{
+		Attempts: int(p.Attempts),
+		Delay:    time.Duration(p.DelayMs) * time.Millisecond,
+		MaxDelay: time.Duration(p.MaxDelayMs) * time.Millisecond,
+		Jitter:   p.Jitter,
+	}
+}
+
+func convertStatistics(stats serial.PortStatistics) *pb.PortStatistics {
+	return &pb.PortStatistics{
+		BytesSent:        stats.BytesSent,
+		BytesReceived:    stats.BytesReceived,
+		Errors:           stats.Errors,
+		OpenedAt:         stats.OpenedAt.Unix(),
+		LastActivity:     stats.LastActivity.Unix(),
+		FramesParsed:     stats.FramesParsed,
+		FramingErrors:    stats.FramingErrors,
+		ChecksumErrors:   stats.ChecksumErrors,
+		AverageFrameSize: stats.AverageFrameSize(),
+		HealthScore:      stats.HealthScore(),
 	}
 }
 
@@ -539,6 +2086,8 @@ func convertPortType(pt serial.PortType) pb.PortType {
 		return pb.PortType_PORT_TYPE_BLUETOOTH
 	case serial.PortTypeVirtual:
 		return pb.PortType_PORT_TYPE_VIRTUAL
+	case serial.PortTypeNetwork:
+		return pb.PortType_PORT_TYPE_NETWORK
 	default:
 		return pb.PortType_PORT_TYPE_UNSPECIFIED
 	}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// NewHealthServer creates a gRPC health server reporting the agent and the
+// serial service as serving
+func NewHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(pb.SerialService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
+
+// healthStatus is the JSON body returned by the /healthz endpoint
+type healthStatus struct {
+	Status       string `json:"status"`
+	OpenSessions int    `json:"open_sessions"`
+	LastScan     string `json:"last_scan,omitempty"`
+}
+
+// HealthHandler returns an HTTP handler reporting the agent's serving status,
+// open session count, and last port scan time. It responds with 503 once the
+// health server stops serving, e.g. during shutdown.
+func HealthHandler(hs *health.Server, manager *serial.Manager, scanner *serial.Scanner) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		servingStatus := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if resp, err := hs.Check(r.Context(), &healthpb.HealthCheckRequest{}); err == nil {
+			servingStatus = resp.Status
+		}
+
+		body := healthStatus{
+			Status:       servingStatus.String(),
+			OpenSessions: len(manager.ListOpenPorts()),
+		}
+		if last := scanner.LastScan(); !last.IsZero() {
+			body.LastScan = last.UTC().Format(time.RFC3339)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if servingStatus != healthpb.HealthCheckResponse_SERVING {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+// readyPort is a managed port's entry in the /readyz body
+type readyPort struct {
+	Name    string `json:"name"`
+	Port    string `json:"port,omitempty"`
+	Healthy bool   `json:"healthy"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// readyStatus is the JSON body returned by the /readyz endpoint
+type readyStatus struct {
+	Status string      `json:"status"`
+	Ready  bool        `json:"ready"`
+	Ports  []readyPort `json:"ports"`
+}
+
+// ReadinessHandler returns an HTTP handler for readiness probes. The agent is
+// ready while it is serving and every managed port is open with its device
+// connected; otherwise it responds with 503 and the body names the ports
+// that are not. supervisor is nil when no ports are managed.
+func ReadinessHandler(hs *health.Server, supervisor *serial.Supervisor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		servingStatus := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if resp, err := hs.Check(r.Context(), &healthpb.HealthCheckRequest{}); err == nil {
+			servingStatus = resp.Status
+		}
+
+		body := readyStatus{
+			Status: servingStatus.String(),
+			Ready:  servingStatus == healthpb.HealthCheckResponse_SERVING,
+			Ports:  []readyPort{},
+		}
+		if supervisor != nil {
+			for _, status := range supervisor.Status() {
+				body.Ports = append(body.Ports, readyPort{
+					Name:    status.Name,
+					Port:    status.PortName,
+					Healthy: status.Healthy,
+					Reason:  status.Reason,
+				})
+				if !status.Healthy {
+					body.Ready = false
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !body.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(body)
+	})
+}
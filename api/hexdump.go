@@ -0,0 +1,59 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hexDumpWidth is the number of bytes shown per dump line
+const hexDumpWidth = 16
+
+// hexDump formats data like hexdump -C: each line shows the offset of its
+// first byte, sixteen bytes in hex, and their printable ASCII characters.
+// Offsets start at offset so that consecutive chunks of a stream line up.
+func hexDump(data []byte, offset uint64) string {
+	var b strings.Builder
+
+	for i := 0; i < len(data); i += hexDumpWidth {
+		line := data[i:min(i+hexDumpWidth, len(data))]
+
+		fmt.Fprintf(&b, "%08x  ", offset+uint64(i))
+		for j := 0; j < hexDumpWidth; j++ {
+			if j < len(line) {
+				fmt.Fprintf(&b, "%02x ", line[j])
+			} else {
+				b.WriteString("   ")
+			}
+			if j == hexDumpWidth/2-1 {
+				b.WriteByte(' ')
+			}
+		}
+
+		b.WriteString(" |")
+		for _, c := range line {
+			if c < 0x20 || c > 0x7e {
+				c = '.'
+			}
+			b.WriteByte(c)
+		}
+		b.WriteString("|\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/history"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// SetHistory sets the database past sessions and captures are queried
+// from. A nil store disables the history RPCs.
+func (s *SerialServer) SetHistory(store *history.Store) {
+	s.history = store
+}
+
+// GetSessionHistory returns past and current sessions for administrators
+func (s *SerialServer) GetSessionHistory(ctx context.Context, req *pb.GetSessionHistoryRequest) (*pb.GetSessionHistoryResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "history is disabled")
+	}
+
+	filter := history.SessionFilter{
+		PortName: req.PortName,
+		ClientID: req.ClientId,
+		Limit:    int(req.Limit),
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	records, totals, err := s.history.Sessions(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read session history: %v", err)
+	}
+
+	resp := &pb.GetSessionHistoryResponse{
+		Totals: &pb.SessionTotals{
+			Sessions:      totals.Sessions,
+			BytesSent:     totals.BytesSent,
+			BytesReceived: totals.BytesReceived,
+			Errors:        totals.Errors,
+		},
+	}
+	for _, r := range records {
+		resp.Sessions = append(resp.Sessions, &pb.SessionRecord{
+			SessionId:     r.ID,
+			PortName:      r.PortName,
+			ClientId:      r.ClientID,
+			Exclusive:     r.Exclusive,
+			Managed:       r.Managed,
+			Sniff:         r.Sniff,
+			OpenedAt:      r.OpenedAt.UnixNano(),
+			ClosedAt:      historyTime(r.ClosedAt),
+			BytesSent:     r.BytesSent,
+			BytesReceived: r.BytesReceived,
+			Errors:        r.Errors,
+			Interrupted:   r.Interrupted,
+		})
+	}
+	return resp, nil
+}
+
+// GetCaptureIndex returns the captures taps wrote for administrators
+func (s *SerialServer) GetCaptureIndex(ctx context.Context, req *pb.GetCaptureIndexRequest) (*pb.GetCaptureIndexResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "history is disabled")
+	}
+
+	filter := history.CaptureFilter{
+		PortName:  req.PortName,
+		SessionID: req.SessionId,
+		Limit:     int(req.Limit),
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	records, err := s.history.Captures(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read capture index: %v", err)
+	}
+
+	resp := &pb.GetCaptureIndexResponse{}
+	for _, r := range records {
+		resp.Captures = append(resp.Captures, &pb.CaptureRecord{
+			TapId:     r.TapID,
+			SessionId: r.SessionID,
+			PortName:  r.PortName,
+			Config: convertTapOptions(serial.TapOptions{
+				Direction:  r.Direction,
+				File:       r.File,
+				TCPAddress: r.TCPAddress,
+			}),
+			StartedAt:      r.StartedAt.UnixNano(),
+			StoppedAt:      historyTime(r.StoppedAt),
+			BytesWritten:   r.BytesWritten,
+			RecordsDropped: r.RecordsDropped,
+			Interrupted:    r.Interrupted,
+		})
+	}
+	return resp, nil
+}
+
+// historyTime converts a time to Unix nanoseconds, with 0 for the zero time
+func historyTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
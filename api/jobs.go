@@ -0,0 +1,208 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/jobs"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// SetScheduler sets the scheduler running scheduled jobs. A nil scheduler
+// disables the job RPCs.
+func (s *SerialServer) SetScheduler(scheduler *jobs.Scheduler) {
+	s.scheduler = scheduler
+}
+
+// CreateJob schedules a transaction to run periodically against a port
+func (s *SerialServer) CreateJob(ctx context.Context, req *pb.CreateJobRequest) (*pb.CreateJobResponse, error) {
+	if s.scheduler == nil {
+		return nil, status.Error(codes.Unavailable, "scheduled jobs are not available")
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if len(req.Data) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "data is required")
+	}
+
+	job := jobs.Job{
+		Name:          req.Name,
+		Port:          req.PortName,
+		SessionID:     req.SessionId,
+		Schedule:      req.Schedule,
+		Request:       req.Data,
+		Terminator:    req.Terminator,
+		ExpectedBytes: int(req.ExpectedBytes),
+		Timeout:       time.Duration(req.TimeoutMs) * time.Millisecond,
+		FlushInput:    req.FlushInput,
+		Config:        s.convertToSerialConfig(req.Config),
+	}
+
+	if req.Pattern != "" {
+		re, err := regexp.Compile(req.Pattern)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pattern: %v", err)
+		}
+		job.Pattern = re
+	}
+
+	if req.SessionId != "" {
+		if _, err := s.manager.ValidateSession(req.PortName, req.SessionId); err != nil {
+			return &pb.CreateJobResponse{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	created, err := s.scheduler.Add(job)
+
+	entry := audit.Entry{Operation: "CreateJob", PortName: req.PortName, SessionID: req.SessionId, Success: err == nil, Message: req.Name + " " + req.Schedule}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
+	if err != nil {
+		return &pb.CreateJobResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateJobResponse{
+		Success: true,
+		Message: "job scheduled",
+		JobId:   created.ID,
+	}, nil
+}
+
+// DeleteJob stops and removes a scheduled job
+func (s *SerialServer) DeleteJob(ctx context.Context, req *pb.DeleteJobRequest) (*pb.DeleteJobResponse, error) {
+	if s.scheduler == nil {
+		return nil, status.Error(codes.Unavailable, "scheduled jobs are not available")
+	}
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	st, err := s.scheduler.Get(req.JobId)
+	if err != nil {
+		return &pb.DeleteJobResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	// The request names no port, so check the job's port here
+	if id, _ := auth.FromContext(ctx); id != nil && !id.CanWritePort(st.Port) {
+		return nil, status.Errorf(codes.PermissionDenied, "write access to port %s is not permitted", st.Port)
+	}
+
+	err = s.scheduler.Remove(st.ID)
+
+	entry := audit.Entry{Operation: "DeleteJob", PortName: st.Port, SessionID: st.SessionID, Success: err == nil, Message: st.Name}
+	if err != nil {
+		entry.Message = err.Error()
+	}
+	s.record(ctx, entry)
+
+	if err != nil {
+		return &pb.DeleteJobResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteJobResponse{
+		Success: true,
+		Message: "job deleted",
+	}, nil
+}
+
+// GetJobResults returns scheduled jobs with the result of their latest run
+func (s *SerialServer) GetJobResults(ctx context.Context, req *pb.GetJobResultsRequest) (*pb.GetJobResultsResponse, error) {
+	if s.scheduler == nil {
+		return nil, status.Error(codes.Unavailable, "scheduled jobs are not available")
+	}
+
+	var statuses []*jobs.Status
+	if req.JobId != "" {
+		st, err := s.scheduler.Get(req.JobId)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		statuses = append(statuses, st)
+	} else {
+		statuses = s.scheduler.List()
+	}
+
+	id, _ := auth.FromContext(ctx)
+
+	var response pb.GetJobResultsResponse
+	for _, st := range statuses {
+		if req.PortName != "" && st.Port != req.PortName {
+			continue
+		}
+		if id != nil && !id.CanAccessPort(st.Port) {
+			continue
+		}
+		info := convertJobStatus(st)
+		info.SessionId = s.visibleID(ctx, st.Port, st.SessionID)
+		response.Jobs = append(response.Jobs, info)
+	}
+
+	return &response, nil
+}
+
+func convertJobStatus(st *jobs.Status) *pb.JobInfo {
+	info := &pb.JobInfo{
+		JobId:     st.ID,
+		Name:      st.Name,
+		PortName:  st.Port,
+		SessionId: st.SessionID,
+		Schedule:  st.Schedule,
+		NextRun:   st.NextRun.UnixNano(),
+		Runs:      st.Runs,
+		Failures:  st.Failures,
+	}
+	if r := st.LastResult; r != nil {
+		info.LastResult = &pb.JobResult{
+			Timestamp:     r.Timestamp.UnixNano(),
+			Success:       r.Success,
+			Data:          r.Data,
+			Matched:       r.Matched,
+			ChecksumError: r.ChecksumError,
+			ElapsedMs:     uint32(r.Elapsed.Milliseconds()),
+			Message:       r.Message,
+		}
+	}
+	return info
+}
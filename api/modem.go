@@ -0,0 +1,136 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/modem"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// SendAT sends an AT command on a session and waits for its final result.
+// The first AT command starts an AT session, which reads the port until the
+// session closes; unsolicited result codes are delivered by SubscribeURC.
+func (s *SerialServer) SendAT(ctx context.Context, req *pb.SendATRequest) (*pb.SendATResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if req.Command == "" {
+		return nil, status.Error(codes.InvalidArgument, "command is required")
+	}
+
+	at, err := s.modems.Session(req.PortName, req.SessionId)
+	if err != nil {
+		return &pb.SendATResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	resp, err := at.Send(ctx, req.Command, time.Duration(req.TimeoutMs)*time.Millisecond)
+
+	var sent int
+	if resp != nil {
+		sent = len(req.Command) + 1
+	}
+	s.recordWrite(ctx, "SendAT", req.PortName, req.SessionId, sent, err)
+
+	if errors.Is(err, serial.ErrRateLimited) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		result := &pb.SendATResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: -1,
+		}
+		if resp != nil {
+			result.Lines = resp.Lines
+			result.ElapsedMs = uint32(resp.Elapsed.Milliseconds())
+		}
+		return result, nil
+	}
+
+	message := resp.Result
+	if resp.ErrorMessage != "" && resp.ErrorCode >= 0 {
+		message += " (" + resp.ErrorMessage + ")"
+	}
+
+	return &pb.SendATResponse{
+		Success:      resp.OK,
+		Message:      message,
+		Lines:        resp.Lines,
+		Result:       resp.Result,
+		ErrorCode:    int32(resp.ErrorCode),
+		ErrorMessage: resp.ErrorMessage,
+		ElapsedMs:    uint32(resp.Elapsed.Milliseconds()),
+	}, nil
+}
+
+// SubscribeURC streams unsolicited result codes received on a session until
+// the client cancels or the session closes
+func (s *SerialServer) SubscribeURC(req *pb.SubscribeURCRequest, stream pb.SerialService_SubscribeURCServer) error {
+	if req.PortName == "" {
+		return status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	at, err := s.modems.Session(req.PortName, req.SessionId)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	urcs, cancel := at.SubscribeURC()
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case u, ok := <-urcs:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(convertURC(u)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func convertURC(u modem.URC) *pb.URCEvent {
+	return &pb.URCEvent{
+		Name:      u.Name,
+		Value:     u.Value,
+		Line:      u.Line,
+		Body:      u.Body,
+		Timestamp: u.Time.UnixNano(),
+	}
+}
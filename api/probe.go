@@ -0,0 +1,136 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/probe"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// IdentifyDevice tries well-known protocol probes on a port at several baud
+// rates and reports the most likely device types. The port is opened
+// exclusively for the duration of the probe.
+func (s *SerialServer) IdentifyDevice(ctx context.Context, req *pb.IdentifyDeviceRequest) (*pb.IdentifyDeviceResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+
+	clientID := req.ClientId
+	if clientID == "" {
+		clientID = "baudlink-probe"
+	}
+
+	opts := probe.Options{
+		Probes:           req.Probes,
+		Config:           s.convertToSerialConfig(req.Config),
+		Timeout:          time.Duration(req.TimeoutMs) * time.Millisecond,
+		ListenTime:       time.Duration(req.ListenMs) * time.Millisecond,
+		ModbusMaxAddress: int(req.ModbusMaxAddress),
+		Exhaustive:       req.Exhaustive,
+		ClientID:         clientID,
+	}
+	for _, baud := range req.BaudRates {
+		opts.BaudRates = append(opts.BaudRates, int(baud))
+	}
+	for _, name := range req.Probes {
+		if !isProbe(name) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown probe: %s", name)
+		}
+	}
+
+	entry := audit.Entry{Operation: "IdentifyDevice", ClientID: clientID, PortName: req.PortName}
+
+	report, err := probe.Run(ctx, s.manager, req.PortName, opts)
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		if errors.Is(err, serial.ErrPortLocked) {
+			return &pb.IdentifyDeviceResponse{
+				Success: false,
+				Message: "port is in use; close it before probing",
+			}, nil
+		}
+		if report == nil {
+			return nil, status.Errorf(codes.Internal, "failed to probe port: %v", err)
+		}
+		return &pb.IdentifyDeviceResponse{
+			Success:    false,
+			Message:    err.Error(),
+			Candidates: convertCandidates(report.Candidates),
+			BaudRates:  convertBaudRates(report.BaudRates),
+		}, nil
+	}
+
+	resp := &pb.IdentifyDeviceResponse{
+		Success:    report.Best() != nil,
+		Message:    "no device recognized",
+		Candidates: convertCandidates(report.Candidates),
+		BaudRates:  convertBaudRates(report.BaudRates),
+	}
+	if best := report.Best(); best != nil {
+		resp.Message = fmt.Sprintf("%s at %d baud", best.Protocol, best.BaudRate)
+	}
+
+	entry.Success = true
+	entry.Message = resp.Message
+	s.record(ctx, entry)
+
+	return resp, nil
+}
+
+// isProbe reports whether name is a known probe
+func isProbe(name string) bool {
+	for _, p := range probe.Probes {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+func convertCandidates(candidates []probe.Candidate) []*pb.DeviceCandidate {
+	result := make([]*pb.DeviceCandidate, len(candidates))
+	for i, c := range candidates {
+		result[i] = &pb.DeviceCandidate{
+			Protocol:   c.Protocol,
+			BaudRate:   uint32(c.BaudRate),
+			Confidence: c.Confidence,
+			Detail:     c.Detail,
+			Response:   c.Response,
+		}
+	}
+	return result
+}
+
+func convertBaudRates(rates []int) []uint32 {
+	result := make([]uint32, len(rates))
+	for i, r := range rates {
+		result[i] = uint32(r)
+	}
+	return result
+}
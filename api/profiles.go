@@ -0,0 +1,230 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// deviceIdentity returns the identity used to match profiles against a port
+func deviceIdentity(p serial.PortInfo) config.DeviceIdentity {
+	return config.DeviceIdentity{
+		Name:         p.Name,
+		VID:          p.VID,
+		PID:          p.PID,
+		SerialNumber: p.SerialNumber,
+	}
+}
+
+// profileForPort returns the profile matching a discovered port, or nil
+func (s *SerialServer) profileForPort(p serial.PortInfo) *config.ProfileConfig {
+	return s.config.MatchProfile(deviceIdentity(p))
+}
+
+// profileForName looks up a port by name and returns its matching profile.
+// Ports that are not currently enumerated are matched by name only.
+func (s *SerialServer) profileForName(portName string) *config.ProfileConfig {
+	if port, err := s.scanner.GetPort(portName); err == nil {
+		return s.profileForPort(*port)
+	}
+	return s.config.MatchProfile(config.DeviceIdentity{Name: portName})
+}
+
+// settingsToPortConfig converts configured settings to a port configuration,
+// filling unset fields from the agent defaults
+func (s *SerialServer) settingsToPortConfig(settings config.SerialDefaults) serial.PortConfig {
+	return PortConfigFromSettings(settings, s.config.Serial.Defaults)
+}
+
+// PortConfigFromSettings converts configured settings to a port
+// configuration, filling unset fields from defaults
+func PortConfigFromSettings(settings, defaults config.SerialDefaults) serial.PortConfig {
+
+	if settings.BaudRate == 0 {
+		settings.BaudRate = defaults.BaudRate
+	}
+	if settings.DataBits == 0 {
+		settings.DataBits = defaults.DataBits
+	}
+	if settings.StopBits == 0 {
+		settings.StopBits = defaults.StopBits
+	}
+	if settings.Parity == "" {
+		settings.Parity = defaults.Parity
+	}
+	if settings.FlowControl == "" {
+		settings.FlowControl = defaults.FlowControl
+	}
+	if settings.XonChar == 0 {
+		settings.XonChar = defaults.XonChar
+	}
+	if settings.XoffChar == 0 {
+		settings.XoffChar = defaults.XoffChar
+	}
+	if settings.ReadTimeoutMs == 0 {
+		settings.ReadTimeoutMs = defaults.ReadTimeoutMs
+	}
+	if settings.WriteTimeoutMs == 0 {
+		settings.WriteTimeoutMs = defaults.WriteTimeoutMs
+	}
+	if settings.Framer == "" {
+		settings.Framer = defaults.Framer
+	}
+	if settings.WriteChunkSize == 0 {
+		settings.WriteChunkSize = defaults.WriteChunkSize
+	}
+	if settings.WriteChunkDelayMs == 0 {
+		settings.WriteChunkDelayMs = defaults.WriteChunkDelayMs
+	}
+	if settings.WriteLineDelayMs == 0 {
+		settings.WriteLineDelayMs = defaults.WriteLineDelayMs
+	}
+	if !settings.RS485 {
+		settings.RS485 = defaults.RS485
+		settings.RS485RTSActiveLow = defaults.RS485RTSActiveLow
+	}
+	if settings.RS485DelayBeforeSendMs == 0 {
+		settings.RS485DelayBeforeSendMs = defaults.RS485DelayBeforeSendMs
+	}
+	if settings.RS485DelayAfterSendMs == 0 {
+		settings.RS485DelayAfterSendMs = defaults.RS485DelayAfterSendMs
+	}
+	if settings.Checksum == "" {
+		settings.Checksum = defaults.Checksum
+		settings.ChecksumPolynomial = defaults.ChecksumPolynomial
+		settings.ChecksumPlacement = defaults.ChecksumPlacement
+		settings.ChecksumTerminator = defaults.ChecksumTerminator
+		settings.ChecksumBigEndian = defaults.ChecksumBigEndian
+		settings.ChecksumHex = defaults.ChecksumHex
+	}
+	if len(settings.ReadFilters) == 0 {
+		settings.ReadFilters = defaults.ReadFilters
+	}
+	if len(settings.WriteFilters) == 0 {
+		settings.WriteFilters = defaults.WriteFilters
+	}
+
+	return serial.PortConfig{
+		BaudRate:       settings.BaudRate,
+		DataBits:       settings.DataBits,
+		StopBits:       parseStopBits(settings.StopBits),
+		Parity:         parseParity(settings.Parity),
+		FlowControl:    parseFlowControl(settings.FlowControl),
+		XonChar:        settings.XonChar,
+		XoffChar:       settings.XoffChar,
+		ReadTimeoutMs:  settings.ReadTimeoutMs,
+		WriteTimeoutMs: settings.WriteTimeoutMs,
+		Framer:         settings.Framer,
+
+		WriteChunkSize:    settings.WriteChunkSize,
+		WriteChunkDelayMs: settings.WriteChunkDelayMs,
+		WriteLineDelayMs:  settings.WriteLineDelayMs,
+
+		RS485: serial.RS485Config{
+			Enabled:           settings.RS485,
+			RTSActiveLow:      settings.RS485RTSActiveLow,
+			DelayBeforeSendMs: settings.RS485DelayBeforeSendMs,
+			DelayAfterSendMs:  settings.RS485DelayAfterSendMs,
+		},
+
+		Checksum: serial.ChecksumConfig{
+			Algorithm:  strings.ToLower(settings.Checksum),
+			Polynomial: settings.ChecksumPolynomial,
+			Placement:  strings.ToLower(settings.ChecksumPlacement),
+			Terminator: settings.ChecksumTerminator,
+			BigEndian:  settings.ChecksumBigEndian,
+			Hex:        settings.ChecksumHex,
+		},
+
+		ReadFilters:  settings.ReadFilters,
+		WriteFilters: settings.WriteFilters,
+	}
+}
+
+// convertPortInfo converts a discovered port, annotating it with its profile
+func (s *SerialServer) convertPortInfo(p serial.PortInfo) *pb.PortInfo {
+	info := &pb.PortInfo{
+		Name:         p.Name,
+		Description:  p.Description,
+		HardwareId:   p.HardwareID,
+		Manufacturer: p.Manufacturer,
+		Product:      p.Product,
+		SerialNumber: p.SerialNumber,
+		PortType:     convertPortType(p.PortType),
+		IsOpen:       p.IsOpen,
+		LockedBy:     p.LockedBy,
+		Alias:        p.Alias,
+		Driver:       p.Driver,
+		BusPath:      p.BusPath,
+		UsbInterface: p.USBInterface,
+		DevicePath:   p.DevicePath,
+		FriendlyName: p.FriendlyName,
+		Properties:   p.Properties,
+		Claimants:    p.Claimants,
+	}
+
+	if profile := s.profileForPort(p); profile != nil {
+		info.Profile = profile.Name
+		if info.Alias == "" {
+			info.Alias = profile.Alias
+		}
+		if profile.Description != "" {
+			info.Description = profile.Description
+		}
+	}
+
+	return info
+}
+
+// parseStopBits converts the configured stop bit count (1 or 2)
+func parseStopBits(sb int) serial.StopBits {
+	if sb == 2 {
+		return serial.StopBits2
+	}
+	return serial.StopBits1
+}
+
+func parseParity(p string) serial.Parity {
+	switch strings.ToLower(p) {
+	case "odd":
+		return serial.ParityOdd
+	case "even":
+		return serial.ParityEven
+	case "mark":
+		return serial.ParityMark
+	case "space":
+		return serial.ParitySpace
+	default:
+		return serial.ParityNone
+	}
+}
+
+func parseFlowControl(fc string) serial.FlowControl {
+	switch strings.ToLower(fc) {
+	case "hardware":
+		return serial.FlowControlHardware
+	case "software":
+		return serial.FlowControlSoftware
+	default:
+		return serial.FlowControlNone
+	}
+}
@@ -43,6 +43,7 @@ const (
 	PortType_PORT_TYPE_NATIVE      PortType = 2
 	PortType_PORT_TYPE_BLUETOOTH   PortType = 3
 	PortType_PORT_TYPE_VIRTUAL     PortType = 4
+	PortType_PORT_TYPE_NETWORK     PortType = 5
 )
 
 // Enum value maps for PortType.
@@ -53,6 +54,7 @@ var (
 		2: "PORT_TYPE_NATIVE",
 		3: "PORT_TYPE_BLUETOOTH",
 		4: "PORT_TYPE_VIRTUAL",
+		5: "PORT_TYPE_NETWORK",
 	}
 	PortType_value = map[string]int32{
 		"PORT_TYPE_UNSPECIFIED": 0,
@@ -60,6 +62,7 @@ var (
 		"PORT_TYPE_NATIVE":      2,
 		"PORT_TYPE_BLUETOOTH":   3,
 		"PORT_TYPE_VIRTUAL":     4,
+		"PORT_TYPE_NETWORK":     5,
 	}
 )
 
@@ -90,6 +93,101 @@ func (PortType) EnumDescriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{0}
 }
 
+type OpenMode int32
+
+const (
+	OpenMode_OPEN_MODE_UNSPECIFIED OpenMode = 0 // Open for reading and writing
+	OpenMode_OPEN_MODE_SNIFF       OpenMode = 1 // Observe read-only; attaches to the port's session if it is open
+)
+
+// Enum value maps for OpenMode.
+var (
+	OpenMode_name = map[int32]string{
+		0: "OPEN_MODE_UNSPECIFIED",
+		1: "OPEN_MODE_SNIFF",
+	}
+	OpenMode_value = map[string]int32{
+		"OPEN_MODE_UNSPECIFIED": 0,
+		"OPEN_MODE_SNIFF":       1,
+	}
+)
+
+func (x OpenMode) Enum() *OpenMode {
+	p := new(OpenMode)
+	*p = x
+	return p
+}
+
+func (x OpenMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OpenMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[1].Descriptor()
+}
+
+func (OpenMode) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[1]
+}
+
+func (x OpenMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OpenMode.Descriptor instead.
+func (OpenMode) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{1}
+}
+
+type SessionRole int32
+
+const (
+	SessionRole_SESSION_ROLE_UNSPECIFIED SessionRole = 0 // Treated as read-write
+	SessionRole_SESSION_ROLE_READ_WRITE  SessionRole = 1
+	SessionRole_SESSION_ROLE_READ_ONLY   SessionRole = 2
+)
+
+// Enum value maps for SessionRole.
+var (
+	SessionRole_name = map[int32]string{
+		0: "SESSION_ROLE_UNSPECIFIED",
+		1: "SESSION_ROLE_READ_WRITE",
+		2: "SESSION_ROLE_READ_ONLY",
+	}
+	SessionRole_value = map[string]int32{
+		"SESSION_ROLE_UNSPECIFIED": 0,
+		"SESSION_ROLE_READ_WRITE":  1,
+		"SESSION_ROLE_READ_ONLY":   2,
+	}
+)
+
+func (x SessionRole) Enum() *SessionRole {
+	p := new(SessionRole)
+	*p = x
+	return p
+}
+
+func (x SessionRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[2].Descriptor()
+}
+
+func (SessionRole) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[2]
+}
+
+func (x SessionRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionRole.Descriptor instead.
+func (SessionRole) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{2}
+}
+
 type DataBits int32
 
 const (
@@ -129,11 +227,11 @@ func (x DataBits) String() string {
 }
 
 func (DataBits) Descriptor() protoreflect.EnumDescriptor {
-	return file_serial_proto_enumTypes[1].Descriptor()
+	return file_serial_proto_enumTypes[3].Descriptor()
 }
 
 func (DataBits) Type() protoreflect.EnumType {
-	return &file_serial_proto_enumTypes[1]
+	return &file_serial_proto_enumTypes[3]
 }
 
 func (x DataBits) Number() protoreflect.EnumNumber {
@@ -142,7 +240,7 @@ func (x DataBits) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DataBits.Descriptor instead.
 func (DataBits) EnumDescriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{1}
+	return file_serial_proto_rawDescGZIP(), []int{3}
 }
 
 type StopBits int32
@@ -181,11 +279,11 @@ func (x StopBits) String() string {
 }
 
 func (StopBits) Descriptor() protoreflect.EnumDescriptor {
-	return file_serial_proto_enumTypes[2].Descriptor()
+	return file_serial_proto_enumTypes[4].Descriptor()
 }
 
 func (StopBits) Type() protoreflect.EnumType {
-	return &file_serial_proto_enumTypes[2]
+	return &file_serial_proto_enumTypes[4]
 }
 
 func (x StopBits) Number() protoreflect.EnumNumber {
@@ -194,7 +292,7 @@ func (x StopBits) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use StopBits.Descriptor instead.
 func (StopBits) EnumDescriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{2}
+	return file_serial_proto_rawDescGZIP(), []int{4}
 }
 
 type Parity int32
@@ -239,11 +337,11 @@ func (x Parity) String() string {
 }
 
 func (Parity) Descriptor() protoreflect.EnumDescriptor {
-	return file_serial_proto_enumTypes[3].Descriptor()
+	return file_serial_proto_enumTypes[5].Descriptor()
 }
 
 func (Parity) Type() protoreflect.EnumType {
-	return &file_serial_proto_enumTypes[3]
+	return &file_serial_proto_enumTypes[5]
 }
 
 func (x Parity) Number() protoreflect.EnumNumber {
@@ -252,7 +350,7 @@ func (x Parity) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Parity.Descriptor instead.
 func (Parity) EnumDescriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{3}
+	return file_serial_proto_rawDescGZIP(), []int{5}
 }
 
 type FlowControl int32
@@ -291,11 +389,11 @@ func (x FlowControl) String() string {
 }
 
 func (FlowControl) Descriptor() protoreflect.EnumDescriptor {
-	return file_serial_proto_enumTypes[4].Descriptor()
+	return file_serial_proto_enumTypes[6].Descriptor()
 }
 
 func (FlowControl) Type() protoreflect.EnumType {
-	return &file_serial_proto_enumTypes[4]
+	return &file_serial_proto_enumTypes[6]
 }
 
 func (x FlowControl) Number() protoreflect.EnumNumber {
@@ -304,13 +402,366 @@ func (x FlowControl) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FlowControl.Descriptor instead.
 func (FlowControl) EnumDescriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{4}
+	return file_serial_proto_rawDescGZIP(), []int{6}
+}
+
+type TapDirection int32
+
+const (
+	TapDirection_TAP_DIRECTION_UNSPECIFIED TapDirection = 0 // Treated as both
+	TapDirection_TAP_DIRECTION_RX          TapDirection = 1
+	TapDirection_TAP_DIRECTION_TX          TapDirection = 2
+	TapDirection_TAP_DIRECTION_BOTH        TapDirection = 3
+)
+
+// Enum value maps for TapDirection.
+var (
+	TapDirection_name = map[int32]string{
+		0: "TAP_DIRECTION_UNSPECIFIED",
+		1: "TAP_DIRECTION_RX",
+		2: "TAP_DIRECTION_TX",
+		3: "TAP_DIRECTION_BOTH",
+	}
+	TapDirection_value = map[string]int32{
+		"TAP_DIRECTION_UNSPECIFIED": 0,
+		"TAP_DIRECTION_RX":          1,
+		"TAP_DIRECTION_TX":          2,
+		"TAP_DIRECTION_BOTH":        3,
+	}
+)
+
+func (x TapDirection) Enum() *TapDirection {
+	p := new(TapDirection)
+	*p = x
+	return p
+}
+
+func (x TapDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TapDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[7].Descriptor()
+}
+
+func (TapDirection) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[7]
+}
+
+func (x TapDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TapDirection.Descriptor instead.
+func (TapDirection) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{7}
+}
+
+type ProxyDirection int32
+
+const (
+	ProxyDirection_PROXY_DIRECTION_UNSPECIFIED ProxyDirection = 0
+	ProxyDirection_PROXY_DIRECTION_A_TO_B      ProxyDirection = 1
+	ProxyDirection_PROXY_DIRECTION_B_TO_A      ProxyDirection = 2
+)
+
+// Enum value maps for ProxyDirection.
+var (
+	ProxyDirection_name = map[int32]string{
+		0: "PROXY_DIRECTION_UNSPECIFIED",
+		1: "PROXY_DIRECTION_A_TO_B",
+		2: "PROXY_DIRECTION_B_TO_A",
+	}
+	ProxyDirection_value = map[string]int32{
+		"PROXY_DIRECTION_UNSPECIFIED": 0,
+		"PROXY_DIRECTION_A_TO_B":      1,
+		"PROXY_DIRECTION_B_TO_A":      2,
+	}
+)
+
+func (x ProxyDirection) Enum() *ProxyDirection {
+	p := new(ProxyDirection)
+	*p = x
+	return p
+}
+
+func (x ProxyDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProxyDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[8].Descriptor()
+}
+
+func (ProxyDirection) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[8]
+}
+
+func (x ProxyDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProxyDirection.Descriptor instead.
+func (ProxyDirection) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{8}
+}
+
+type FlushMode int32
+
+const (
+	FlushMode_FLUSH_MODE_BOTH   FlushMode = 0 // Discard pending input and untransmitted output
+	FlushMode_FLUSH_MODE_INPUT  FlushMode = 1 // Discard received data not read yet
+	FlushMode_FLUSH_MODE_OUTPUT FlushMode = 2 // Discard written data not transmitted yet
+	FlushMode_FLUSH_MODE_DRAIN  FlushMode = 3 // Wait until written data has been transmitted
+)
+
+// Enum value maps for FlushMode.
+var (
+	FlushMode_name = map[int32]string{
+		0: "FLUSH_MODE_BOTH",
+		1: "FLUSH_MODE_INPUT",
+		2: "FLUSH_MODE_OUTPUT",
+		3: "FLUSH_MODE_DRAIN",
+	}
+	FlushMode_value = map[string]int32{
+		"FLUSH_MODE_BOTH":   0,
+		"FLUSH_MODE_INPUT":  1,
+		"FLUSH_MODE_OUTPUT": 2,
+		"FLUSH_MODE_DRAIN":  3,
+	}
+)
+
+func (x FlushMode) Enum() *FlushMode {
+	p := new(FlushMode)
+	*p = x
+	return p
+}
+
+func (x FlushMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FlushMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[9].Descriptor()
+}
+
+func (FlushMode) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[9]
+}
+
+func (x FlushMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FlushMode.Descriptor instead.
+func (FlushMode) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{9}
+}
+
+type ScriptEventType int32
+
+const (
+	ScriptEventType_SCRIPT_EVENT_TYPE_UNSPECIFIED ScriptEventType = 0
+	ScriptEventType_SCRIPT_EVENT_TYPE_SENT        ScriptEventType = 1 // Data was written
+	ScriptEventType_SCRIPT_EVENT_TYPE_RECEIVED    ScriptEventType = 2 // Data was read while expecting
+	ScriptEventType_SCRIPT_EVENT_TYPE_MATCHED     ScriptEventType = 3 // An expect pattern matched
+	ScriptEventType_SCRIPT_EVENT_TYPE_SLEPT       ScriptEventType = 4 // A sleep step finished
+	ScriptEventType_SCRIPT_EVENT_TYPE_FAILED      ScriptEventType = 5 // A step failed; the script stopped
+	ScriptEventType_SCRIPT_EVENT_TYPE_COMPLETED   ScriptEventType = 6 // All steps completed
+)
+
+// Enum value maps for ScriptEventType.
+var (
+	ScriptEventType_name = map[int32]string{
+		0: "SCRIPT_EVENT_TYPE_UNSPECIFIED",
+		1: "SCRIPT_EVENT_TYPE_SENT",
+		2: "SCRIPT_EVENT_TYPE_RECEIVED",
+		3: "SCRIPT_EVENT_TYPE_MATCHED",
+		4: "SCRIPT_EVENT_TYPE_SLEPT",
+		5: "SCRIPT_EVENT_TYPE_FAILED",
+		6: "SCRIPT_EVENT_TYPE_COMPLETED",
+	}
+	ScriptEventType_value = map[string]int32{
+		"SCRIPT_EVENT_TYPE_UNSPECIFIED": 0,
+		"SCRIPT_EVENT_TYPE_SENT":        1,
+		"SCRIPT_EVENT_TYPE_RECEIVED":    2,
+		"SCRIPT_EVENT_TYPE_MATCHED":     3,
+		"SCRIPT_EVENT_TYPE_SLEPT":       4,
+		"SCRIPT_EVENT_TYPE_FAILED":      5,
+		"SCRIPT_EVENT_TYPE_COMPLETED":   6,
+	}
+)
+
+func (x ScriptEventType) Enum() *ScriptEventType {
+	p := new(ScriptEventType)
+	*p = x
+	return p
+}
+
+func (x ScriptEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScriptEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[10].Descriptor()
+}
+
+func (ScriptEventType) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[10]
+}
+
+func (x ScriptEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScriptEventType.Descriptor instead.
+func (ScriptEventType) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{10}
+}
+
+type EmulatorEventType int32
+
+const (
+	EmulatorEventType_EMULATOR_EVENT_TYPE_UNSPECIFIED EmulatorEventType = 0
+	EmulatorEventType_EMULATOR_EVENT_TYPE_STARTED     EmulatorEventType = 1 // The emulator is running; address names the port
+	EmulatorEventType_EMULATOR_EVENT_TYPE_RECEIVED    EmulatorEventType = 2 // Data was received from the client
+	EmulatorEventType_EMULATOR_EVENT_TYPE_MATCHED     EmulatorEventType = 3 // A rule matched received data
+	EmulatorEventType_EMULATOR_EVENT_TYPE_SENT        EmulatorEventType = 4 // A reply or periodic message was sent
+	EmulatorEventType_EMULATOR_EVENT_TYPE_STATE       EmulatorEventType = 5 // A state was entered
+	EmulatorEventType_EMULATOR_EVENT_TYPE_FAILED      EmulatorEventType = 6 // The port failed; the emulator stopped
+	EmulatorEventType_EMULATOR_EVENT_TYPE_SHUTDOWN    EmulatorEventType = 7 // The agent is shutting down
+)
+
+// Enum value maps for EmulatorEventType.
+var (
+	EmulatorEventType_name = map[int32]string{
+		0: "EMULATOR_EVENT_TYPE_UNSPECIFIED",
+		1: "EMULATOR_EVENT_TYPE_STARTED",
+		2: "EMULATOR_EVENT_TYPE_RECEIVED",
+		3: "EMULATOR_EVENT_TYPE_MATCHED",
+		4: "EMULATOR_EVENT_TYPE_SENT",
+		5: "EMULATOR_EVENT_TYPE_STATE",
+		6: "EMULATOR_EVENT_TYPE_FAILED",
+		7: "EMULATOR_EVENT_TYPE_SHUTDOWN",
+	}
+	EmulatorEventType_value = map[string]int32{
+		"EMULATOR_EVENT_TYPE_UNSPECIFIED": 0,
+		"EMULATOR_EVENT_TYPE_STARTED":     1,
+		"EMULATOR_EVENT_TYPE_RECEIVED":    2,
+		"EMULATOR_EVENT_TYPE_MATCHED":     3,
+		"EMULATOR_EVENT_TYPE_SENT":        4,
+		"EMULATOR_EVENT_TYPE_STATE":       5,
+		"EMULATOR_EVENT_TYPE_FAILED":      6,
+		"EMULATOR_EVENT_TYPE_SHUTDOWN":    7,
+	}
+)
+
+func (x EmulatorEventType) Enum() *EmulatorEventType {
+	p := new(EmulatorEventType)
+	*p = x
+	return p
+}
+
+func (x EmulatorEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmulatorEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[11].Descriptor()
+}
+
+func (EmulatorEventType) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[11]
+}
+
+func (x EmulatorEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmulatorEventType.Descriptor instead.
+func (EmulatorEventType) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{11}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED        EventType = 0
+	EventType_EVENT_TYPE_WRITE_COMPLETE     EventType = 1
+	EventType_EVENT_TYPE_SESSION_TERMINATED EventType = 2 // Session ended by a takeover
+	EventType_EVENT_TYPE_SESSION_SUSPENDED  EventType = 3 // Reconnecting session lost its device
+	EventType_EVENT_TYPE_SESSION_RESUMED    EventType = 4 // Reconnecting session reopened its device
+	EventType_EVENT_TYPE_RULE_MATCHED       EventType = 5 // Received data matched a configured rule
+	EventType_EVENT_TYPE_JOB_COMPLETED      EventType = 6 // A scheduled job ran
+	EventType_EVENT_TYPE_SESSION_EXPIRED    EventType = 7 // Session closed by the idle timeout
+	EventType_EVENT_TYPE_AGENT_SHUTDOWN     EventType = 8 // The agent is shutting down (sent to every subscriber)
+	EventType_EVENT_TYPE_QUEUE_POSITION     EventType = 9 // A client waiting for a locked port moved in its queue, or was granted the port
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_WRITE_COMPLETE",
+		2: "EVENT_TYPE_SESSION_TERMINATED",
+		3: "EVENT_TYPE_SESSION_SUSPENDED",
+		4: "EVENT_TYPE_SESSION_RESUMED",
+		5: "EVENT_TYPE_RULE_MATCHED",
+		6: "EVENT_TYPE_JOB_COMPLETED",
+		7: "EVENT_TYPE_SESSION_EXPIRED",
+		8: "EVENT_TYPE_AGENT_SHUTDOWN",
+		9: "EVENT_TYPE_QUEUE_POSITION",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":        0,
+		"EVENT_TYPE_WRITE_COMPLETE":     1,
+		"EVENT_TYPE_SESSION_TERMINATED": 2,
+		"EVENT_TYPE_SESSION_SUSPENDED":  3,
+		"EVENT_TYPE_SESSION_RESUMED":    4,
+		"EVENT_TYPE_RULE_MATCHED":       5,
+		"EVENT_TYPE_JOB_COMPLETED":      6,
+		"EVENT_TYPE_SESSION_EXPIRED":    7,
+		"EVENT_TYPE_AGENT_SHUTDOWN":     8,
+		"EVENT_TYPE_QUEUE_POSITION":     9,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_serial_proto_enumTypes[12].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_serial_proto_enumTypes[12]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{12}
 }
 
 type ListPortsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional filter to include only available (unopened) ports
 	OnlyAvailable bool `protobuf:"varint,1,opt,name=only_available,json=onlyAvailable,proto3" json:"only_available,omitempty"`
+	// ETag of a previous response. If the list is unchanged the response
+	// sets not_modified and omits the ports.
+	IfChangedSince string `protobuf:"bytes,2,opt,name=if_changed_since,json=ifChangedSince,proto3" json:"if_changed_since,omitempty"`
+	// Enumerate the ports now instead of using the agent's cached list
+	Refresh       bool `protobuf:"varint,3,opt,name=refresh,proto3" json:"refresh,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -352,9 +803,25 @@ func (x *ListPortsRequest) GetOnlyAvailable() bool {
 	return false
 }
 
+func (x *ListPortsRequest) GetIfChangedSince() string {
+	if x != nil {
+		return x.IfChangedSince
+	}
+	return ""
+}
+
+func (x *ListPortsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
 type ListPortsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ports         []*PortInfo            `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`                                   // Identifies this port list
+	NotModified   bool                   `protobuf:"varint,3,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"` // The list matches if_changed_since
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -396,6 +863,20 @@ func (x *ListPortsResponse) GetPorts() []*PortInfo {
 	return nil
 }
 
+func (x *ListPortsResponse) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *ListPortsResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
 type GetPortInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
@@ -442,15 +923,24 @@ func (x *GetPortInfoRequest) GetPortName() string {
 
 type PortInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                           // e.g., "COM3" or "/dev/ttyUSB0"
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                                             // Human-readable description
-	HardwareId    string                 `protobuf:"bytes,3,opt,name=hardware_id,json=hardwareId,proto3" json:"hardware_id,omitempty"`                             // USB VID:PID or similar
-	Manufacturer  string                 `protobuf:"bytes,4,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`                                           // Device manufacturer
-	Product       string                 `protobuf:"bytes,5,opt,name=product,proto3" json:"product,omitempty"`                                                     // Product name
-	SerialNumber  string                 `protobuf:"bytes,6,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`                       // Device serial number
-	PortType      PortType               `protobuf:"varint,7,opt,name=port_type,json=portType,proto3,enum=baudlink.serial.v1.PortType" json:"port_type,omitempty"` // Type of port
-	IsOpen        bool                   `protobuf:"varint,8,opt,name=is_open,json=isOpen,proto3" json:"is_open,omitempty"`                                        // Whether port is currently open
-	LockedBy      string                 `protobuf:"bytes,9,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"`                                   // Client ID if locked
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                        // e.g., "COM3" or "/dev/ttyUSB0"
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                                                                          // Human-readable description
+	HardwareId    string                 `protobuf:"bytes,3,opt,name=hardware_id,json=hardwareId,proto3" json:"hardware_id,omitempty"`                                                          // USB VID:PID or similar
+	Manufacturer  string                 `protobuf:"bytes,4,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`                                                                        // Device manufacturer
+	Product       string                 `protobuf:"bytes,5,opt,name=product,proto3" json:"product,omitempty"`                                                                                  // Product name
+	SerialNumber  string                 `protobuf:"bytes,6,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`                                                    // Device serial number
+	PortType      PortType               `protobuf:"varint,7,opt,name=port_type,json=portType,proto3,enum=baudlink.serial.v1.PortType" json:"port_type,omitempty"`                              // Type of port
+	IsOpen        bool                   `protobuf:"varint,8,opt,name=is_open,json=isOpen,proto3" json:"is_open,omitempty"`                                                                     // Whether port is currently open
+	LockedBy      string                 `protobuf:"bytes,9,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"`                                                                // Client ID if locked
+	Profile       string                 `protobuf:"bytes,10,opt,name=profile,proto3" json:"profile,omitempty"`                                                                                 // Name of the matching configuration profile
+	Alias         string                 `protobuf:"bytes,11,opt,name=alias,proto3" json:"alias,omitempty"`                                                                                     // Configured alias for this device
+	Driver        string                 `protobuf:"bytes,12,opt,name=driver,proto3" json:"driver,omitempty"`                                                                                   // Kernel driver or Windows driver service
+	BusPath       string                 `protobuf:"bytes,13,opt,name=bus_path,json=busPath,proto3" json:"bus_path,omitempty"`                                                                  // Physical USB port, e.g. "1-1.2" or "Port_#0002.Hub_#0004"
+	UsbInterface  string                 `protobuf:"bytes,14,opt,name=usb_interface,json=usbInterface,proto3" json:"usb_interface,omitempty"`                                                   // Interface number on multi-port adapters
+	DevicePath    string                 `protobuf:"bytes,15,opt,name=device_path,json=devicePath,proto3" json:"device_path,omitempty"`                                                         // sysfs device path or Windows device instance ID
+	FriendlyName  string                 `protobuf:"bytes,16,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`                                                   // Windows device manager name
+	Properties    map[string]string      `protobuf:"bytes,17,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Selected udev properties or registry values
+	Claimants     []string               `protobuf:"bytes,18,rep,name=claimants,proto3" json:"claimants,omitempty"`                                                                             // Services that grab the port on their own: getty, ModemManager
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -548,16 +1038,85 @@ func (x *PortInfo) GetLockedBy() string {
 	return ""
 }
 
-type OpenPortRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	Config        *PortConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Unique client identifier for locking
-	Exclusive     bool                   `protobuf:"varint,4,opt,name=exclusive,proto3" json:"exclusive,omitempty"`              // Request exclusive access
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
+func (x *PortInfo) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *PortInfo) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *PortInfo) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *PortInfo) GetBusPath() string {
+	if x != nil {
+		return x.BusPath
+	}
+	return ""
+}
+
+func (x *PortInfo) GetUsbInterface() string {
+	if x != nil {
+		return x.UsbInterface
+	}
+	return ""
+}
+
+func (x *PortInfo) GetDevicePath() string {
+	if x != nil {
+		return x.DevicePath
+	}
+	return ""
+}
+
+func (x *PortInfo) GetFriendlyName() string {
+	if x != nil {
+		return x.FriendlyName
+	}
+	return ""
+}
+
+func (x *PortInfo) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *PortInfo) GetClaimants() []string {
+	if x != nil {
+		return x.Claimants
+	}
+	return nil
+}
+
+type OpenPortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	Config        *PortConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`                     // Omit to apply the matching profile or agent defaults
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Unique client identifier for locking
+	Exclusive     bool                   `protobuf:"varint,4,opt,name=exclusive,proto3" json:"exclusive,omitempty"`              // Request exclusive access
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`                // Higher-priority clients may take over the port
+	Retry         *RetryPolicy           `protobuf:"bytes,6,opt,name=retry,proto3" json:"retry,omitempty"`                       // Omit to use the agent's retry defaults
+	Reconnect     bool                   `protobuf:"varint,7,opt,name=reconnect,proto3" json:"reconnect,omitempty"`              // Suspend instead of failing when the device is unplugged, and reopen it when it returns
+	Taps          []*TapConfig           `protobuf:"bytes,8,rep,name=taps,proto3" json:"taps,omitempty"`                         // Taps started with the session
+	Mode          OpenMode               `protobuf:"varint,9,opt,name=mode,proto3,enum=baudlink.serial.v1.OpenMode" json:"mode,omitempty"`
+	WaitMs        uint32                 `protobuf:"varint,10,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"` // When the port is locked, wait up to this long in its queue (0 = fail at once)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
 func (x *OpenPortRequest) Reset() {
 	*x = OpenPortRequest{}
 	mi := &file_serial_proto_msgTypes[4]
@@ -616,18 +1175,133 @@ func (x *OpenPortRequest) GetExclusive() bool {
 	return false
 }
 
+func (x *OpenPortRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *OpenPortRequest) GetRetry() *RetryPolicy {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *OpenPortRequest) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *OpenPortRequest) GetTaps() []*TapConfig {
+	if x != nil {
+		return x.Taps
+	}
+	return nil
+}
+
+func (x *OpenPortRequest) GetMode() OpenMode {
+	if x != nil {
+		return x.Mode
+	}
+	return OpenMode_OPEN_MODE_UNSPECIFIED
+}
+
+func (x *OpenPortRequest) GetWaitMs() uint32 {
+	if x != nil {
+		return x.WaitMs
+	}
+	return 0
+}
+
+// RetryPolicy controls retries of transient open failures, such as a USB
+// adapter reporting busy while it enumerates
+type RetryPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      uint32                 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`                         // Total attempts including the first (0 or 1 = no retry)
+	DelayMs       uint32                 `protobuf:"varint,2,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`            // Delay before the first retry, doubled for each further retry
+	MaxDelayMs    uint32                 `protobuf:"varint,3,opt,name=max_delay_ms,json=maxDelayMs,proto3" json:"max_delay_ms,omitempty"` // Upper bound on the delay (0 = no bound)
+	Jitter        float64                `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`                            // Fraction of each delay that is randomized, 0-1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_serial_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RetryPolicy) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxDelayMs() uint32 {
+	if x != nil {
+		return x.MaxDelayMs
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
 type OpenPortResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Session ID for this connection
+	Profile       string                 `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`                      // Profile applied when no config was given
+	ReadOnly      bool                   `protobuf:"varint,5,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`   // session_id may only be used to read
+	Attached      bool                   `protobuf:"varint,6,opt,name=attached,proto3" json:"attached,omitempty"`                   // session_id is an attachment; release it with DetachSession or ClosePort
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *OpenPortResponse) Reset() {
 	*x = OpenPortResponse{}
-	mi := &file_serial_proto_msgTypes[5]
+	mi := &file_serial_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -639,7 +1313,7 @@ func (x *OpenPortResponse) String() string {
 func (*OpenPortResponse) ProtoMessage() {}
 
 func (x *OpenPortResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[5]
+	mi := &file_serial_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,7 +1326,7 @@ func (x *OpenPortResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenPortResponse.ProtoReflect.Descriptor instead.
 func (*OpenPortResponse) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{5}
+	return file_serial_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *OpenPortResponse) GetSuccess() bool {
@@ -676,6 +1350,27 @@ func (x *OpenPortResponse) GetSessionId() string {
 	return ""
 }
 
+func (x *OpenPortResponse) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *OpenPortResponse) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *OpenPortResponse) GetAttached() bool {
+	if x != nil {
+		return x.Attached
+	}
+	return false
+}
+
 type ClosePortRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
@@ -686,7 +1381,7 @@ type ClosePortRequest struct {
 
 func (x *ClosePortRequest) Reset() {
 	*x = ClosePortRequest{}
-	mi := &file_serial_proto_msgTypes[6]
+	mi := &file_serial_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -698,7 +1393,7 @@ func (x *ClosePortRequest) String() string {
 func (*ClosePortRequest) ProtoMessage() {}
 
 func (x *ClosePortRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[6]
+	mi := &file_serial_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -711,7 +1406,7 @@ func (x *ClosePortRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePortRequest.ProtoReflect.Descriptor instead.
 func (*ClosePortRequest) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{6}
+	return file_serial_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ClosePortRequest) GetPortName() string {
@@ -738,7 +1433,7 @@ type ClosePortResponse struct {
 
 func (x *ClosePortResponse) Reset() {
 	*x = ClosePortResponse{}
-	mi := &file_serial_proto_msgTypes[7]
+	mi := &file_serial_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -750,7 +1445,7 @@ func (x *ClosePortResponse) String() string {
 func (*ClosePortResponse) ProtoMessage() {}
 
 func (x *ClosePortResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[7]
+	mi := &file_serial_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,7 +1458,7 @@ func (x *ClosePortResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePortResponse.ProtoReflect.Descriptor instead.
 func (*ClosePortResponse) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{7}
+	return file_serial_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ClosePortResponse) GetSuccess() bool {
@@ -789,7 +1484,7 @@ type GetPortStatusRequest struct {
 
 func (x *GetPortStatusRequest) Reset() {
 	*x = GetPortStatusRequest{}
-	mi := &file_serial_proto_msgTypes[8]
+	mi := &file_serial_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -801,7 +1496,7 @@ func (x *GetPortStatusRequest) String() string {
 func (*GetPortStatusRequest) ProtoMessage() {}
 
 func (x *GetPortStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[8]
+	mi := &file_serial_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -814,7 +1509,7 @@ func (x *GetPortStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetPortStatusRequest) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{8}
+	return file_serial_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPortStatusRequest) GetPortName() string {
@@ -833,13 +1528,21 @@ type PortStatus struct {
 	SessionId     string                 `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	CurrentConfig *PortConfig            `protobuf:"bytes,6,opt,name=current_config,json=currentConfig,proto3" json:"current_config,omitempty"`
 	Statistics    *PortStatistics        `protobuf:"bytes,7,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	Managed       bool                   `protobuf:"varint,8,opt,name=managed,proto3" json:"managed,omitempty"`                                   // Session is kept open by the agent
+	Disconnected  bool                   `protobuf:"varint,9,opt,name=disconnected,proto3" json:"disconnected,omitempty"`                         // Managed or reconnecting device is currently unplugged
+	BufferedBytes uint32                 `protobuf:"varint,10,opt,name=buffered_bytes,json=bufferedBytes,proto3" json:"buffered_bytes,omitempty"` // Received bytes waiting in the managed buffer
+	Attachments   []*AttachmentInfo      `protobuf:"bytes,11,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Reconnect     bool                   `protobuf:"varint,12,opt,name=reconnect,proto3" json:"reconnect,omitempty"` // Session is suspended rather than closed when its device is lost
+	Taps          []*TapInfo             `protobuf:"bytes,13,rep,name=taps,proto3" json:"taps,omitempty"`
+	Flow          *FlowStatus            `protobuf:"bytes,14,opt,name=flow,proto3" json:"flow,omitempty"`    // Unset when the device does not report modem lines
+	Sniff         bool                   `protobuf:"varint,15,opt,name=sniff,proto3" json:"sniff,omitempty"` // Session was opened for sniffing and refuses writes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PortStatus) Reset() {
 	*x = PortStatus{}
-	mi := &file_serial_proto_msgTypes[9]
+	mi := &file_serial_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -851,7 +1554,7 @@ func (x *PortStatus) String() string {
 func (*PortStatus) ProtoMessage() {}
 
 func (x *PortStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[9]
+	mi := &file_serial_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -864,7 +1567,7 @@ func (x *PortStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortStatus.ProtoReflect.Descriptor instead.
 func (*PortStatus) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{9}
+	return file_serial_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PortStatus) GetPortName() string {
@@ -916,109 +1619,87 @@ func (x *PortStatus) GetStatistics() *PortStatistics {
 	return nil
 }
 
-type PortStatistics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BytesSent     uint64                 `protobuf:"varint,1,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
-	BytesReceived uint64                 `protobuf:"varint,2,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
-	Errors        uint64                 `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
-	OpenedAt      int64                  `protobuf:"varint,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`             // Unix timestamp
-	LastActivity  int64                  `protobuf:"varint,5,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"` // Unix timestamp
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PortStatistics) Reset() {
-	*x = PortStatistics{}
-	mi := &file_serial_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PortStatistics) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PortStatus) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
 }
 
-func (*PortStatistics) ProtoMessage() {}
-
-func (x *PortStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[10]
+func (x *PortStatus) GetDisconnected() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Disconnected
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use PortStatistics.ProtoReflect.Descriptor instead.
-func (*PortStatistics) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{10}
+func (x *PortStatus) GetBufferedBytes() uint32 {
+	if x != nil {
+		return x.BufferedBytes
+	}
+	return 0
 }
 
-func (x *PortStatistics) GetBytesSent() uint64 {
+func (x *PortStatus) GetAttachments() []*AttachmentInfo {
 	if x != nil {
-		return x.BytesSent
+		return x.Attachments
 	}
-	return 0
+	return nil
 }
 
-func (x *PortStatistics) GetBytesReceived() uint64 {
+func (x *PortStatus) GetReconnect() bool {
 	if x != nil {
-		return x.BytesReceived
+		return x.Reconnect
 	}
-	return 0
+	return false
 }
 
-func (x *PortStatistics) GetErrors() uint64 {
+func (x *PortStatus) GetTaps() []*TapInfo {
 	if x != nil {
-		return x.Errors
+		return x.Taps
 	}
-	return 0
+	return nil
 }
 
-func (x *PortStatistics) GetOpenedAt() int64 {
+func (x *PortStatus) GetFlow() *FlowStatus {
 	if x != nil {
-		return x.OpenedAt
+		return x.Flow
 	}
-	return 0
+	return nil
 }
 
-func (x *PortStatistics) GetLastActivity() int64 {
+func (x *PortStatus) GetSniff() bool {
 	if x != nil {
-		return x.LastActivity
+		return x.Sniff
 	}
-	return 0
+	return false
 }
 
-type PortConfig struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	BaudRate       uint32                 `protobuf:"varint,1,opt,name=baud_rate,json=baudRate,proto3" json:"baud_rate,omitempty"` // e.g., 9600, 115200
-	DataBits       DataBits               `protobuf:"varint,2,opt,name=data_bits,json=dataBits,proto3,enum=baudlink.serial.v1.DataBits" json:"data_bits,omitempty"`
-	StopBits       StopBits               `protobuf:"varint,3,opt,name=stop_bits,json=stopBits,proto3,enum=baudlink.serial.v1.StopBits" json:"stop_bits,omitempty"`
-	Parity         Parity                 `protobuf:"varint,4,opt,name=parity,proto3,enum=baudlink.serial.v1.Parity" json:"parity,omitempty"`
-	FlowControl    FlowControl            `protobuf:"varint,5,opt,name=flow_control,json=flowControl,proto3,enum=baudlink.serial.v1.FlowControl" json:"flow_control,omitempty"`
-	ReadTimeoutMs  uint32                 `protobuf:"varint,6,opt,name=read_timeout_ms,json=readTimeoutMs,proto3" json:"read_timeout_ms,omitempty"`    // Read timeout in milliseconds
-	WriteTimeoutMs uint32                 `protobuf:"varint,7,opt,name=write_timeout_ms,json=writeTimeoutMs,proto3" json:"write_timeout_ms,omitempty"` // Write timeout in milliseconds
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// FlowStatus reports the modem lines that govern flow control
+type FlowStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cts           bool                   `protobuf:"varint,1,opt,name=cts,proto3" json:"cts,omitempty"`       // Clear To Send is asserted
+	Dsr           bool                   `protobuf:"varint,2,opt,name=dsr,proto3" json:"dsr,omitempty"`       // Data Set Ready is asserted
+	Dcd           bool                   `protobuf:"varint,3,opt,name=dcd,proto3" json:"dcd,omitempty"`       // Data Carrier Detect is asserted
+	Paused        bool                   `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"` // Hardware flow control holds output (CTS deasserted)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PortConfig) Reset() {
-	*x = PortConfig{}
+func (x *FlowStatus) Reset() {
+	*x = FlowStatus{}
 	mi := &file_serial_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PortConfig) String() string {
+func (x *FlowStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortConfig) ProtoMessage() {}
+func (*FlowStatus) ProtoMessage() {}
 
-func (x *PortConfig) ProtoReflect() protoreflect.Message {
+func (x *FlowStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1030,83 +1711,62 @@ func (x *PortConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortConfig.ProtoReflect.Descriptor instead.
-func (*PortConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use FlowStatus.ProtoReflect.Descriptor instead.
+func (*FlowStatus) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *PortConfig) GetBaudRate() uint32 {
+func (x *FlowStatus) GetCts() bool {
 	if x != nil {
-		return x.BaudRate
+		return x.Cts
 	}
-	return 0
+	return false
 }
 
-func (x *PortConfig) GetDataBits() DataBits {
+func (x *FlowStatus) GetDsr() bool {
 	if x != nil {
-		return x.DataBits
+		return x.Dsr
 	}
-	return DataBits_DATA_BITS_UNSPECIFIED
+	return false
 }
 
-func (x *PortConfig) GetStopBits() StopBits {
+func (x *FlowStatus) GetDcd() bool {
 	if x != nil {
-		return x.StopBits
+		return x.Dcd
 	}
-	return StopBits_STOP_BITS_UNSPECIFIED
-}
-
-func (x *PortConfig) GetParity() Parity {
-	if x != nil {
-		return x.Parity
-	}
-	return Parity_PARITY_UNSPECIFIED
-}
-
-func (x *PortConfig) GetFlowControl() FlowControl {
-	if x != nil {
-		return x.FlowControl
-	}
-	return FlowControl_FLOW_CONTROL_UNSPECIFIED
-}
-
-func (x *PortConfig) GetReadTimeoutMs() uint32 {
-	if x != nil {
-		return x.ReadTimeoutMs
-	}
-	return 0
+	return false
 }
 
-func (x *PortConfig) GetWriteTimeoutMs() uint32 {
+func (x *FlowStatus) GetPaused() bool {
 	if x != nil {
-		return x.WriteTimeoutMs
+		return x.Paused
 	}
-	return 0
+	return false
 }
 
-type ConfigurePortRequest struct {
+type AttachSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Config        *PortConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Role          SessionRole            `protobuf:"varint,3,opt,name=role,proto3,enum=baudlink.serial.v1.SessionRole" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigurePortRequest) Reset() {
-	*x = ConfigurePortRequest{}
+func (x *AttachSessionRequest) Reset() {
+	*x = AttachSessionRequest{}
 	mi := &file_serial_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigurePortRequest) String() string {
+func (x *AttachSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigurePortRequest) ProtoMessage() {}
+func (*AttachSessionRequest) ProtoMessage() {}
 
-func (x *ConfigurePortRequest) ProtoReflect() protoreflect.Message {
+func (x *AttachSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1118,54 +1778,56 @@ func (x *ConfigurePortRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigurePortRequest.ProtoReflect.Descriptor instead.
-func (*ConfigurePortRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttachSessionRequest.ProtoReflect.Descriptor instead.
+func (*AttachSessionRequest) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ConfigurePortRequest) GetPortName() string {
+func (x *AttachSessionRequest) GetPortName() string {
 	if x != nil {
 		return x.PortName
 	}
 	return ""
 }
 
-func (x *ConfigurePortRequest) GetSessionId() string {
+func (x *AttachSessionRequest) GetClientId() string {
 	if x != nil {
-		return x.SessionId
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *ConfigurePortRequest) GetConfig() *PortConfig {
+func (x *AttachSessionRequest) GetRole() SessionRole {
 	if x != nil {
-		return x.Config
+		return x.Role
 	}
-	return nil
+	return SessionRole_SESSION_ROLE_UNSPECIFIED
 }
 
-type ConfigurePortResponse struct {
+type AttachSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,3,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"` // Use in place of session_id for I/O
+	SessionId     string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`          // Session the attachment belongs to
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigurePortResponse) Reset() {
-	*x = ConfigurePortResponse{}
+func (x *AttachSessionResponse) Reset() {
+	*x = AttachSessionResponse{}
 	mi := &file_serial_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigurePortResponse) String() string {
+func (x *AttachSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigurePortResponse) ProtoMessage() {}
+func (*AttachSessionResponse) ProtoMessage() {}
 
-func (x *ConfigurePortResponse) ProtoReflect() protoreflect.Message {
+func (x *AttachSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1177,46 +1839,61 @@ func (x *ConfigurePortResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigurePortResponse.ProtoReflect.Descriptor instead.
-func (*ConfigurePortResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttachSessionResponse.ProtoReflect.Descriptor instead.
+func (*AttachSessionResponse) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ConfigurePortResponse) GetSuccess() bool {
+func (x *AttachSessionResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ConfigurePortResponse) GetMessage() string {
+func (x *AttachSessionResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type GetPortConfigRequest struct {
+func (x *AttachSessionResponse) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *AttachSessionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type DetachSessionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPortConfigRequest) Reset() {
-	*x = GetPortConfigRequest{}
+func (x *DetachSessionRequest) Reset() {
+	*x = DetachSessionRequest{}
 	mi := &file_serial_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPortConfigRequest) String() string {
+func (x *DetachSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPortConfigRequest) ProtoMessage() {}
+func (*DetachSessionRequest) ProtoMessage() {}
 
-func (x *GetPortConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *DetachSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1228,42 +1905,47 @@ func (x *GetPortConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPortConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetPortConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetachSessionRequest.ProtoReflect.Descriptor instead.
+func (*DetachSessionRequest) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetPortConfigRequest) GetPortName() string {
+func (x *DetachSessionRequest) GetPortName() string {
 	if x != nil {
 		return x.PortName
 	}
 	return ""
 }
 
-type WriteRequest struct {
+func (x *DetachSessionRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DetachSessionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Flush         bool                   `protobuf:"varint,4,opt,name=flush,proto3" json:"flush,omitempty"` // Flush buffer after write
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WriteRequest) Reset() {
-	*x = WriteRequest{}
+func (x *DetachSessionResponse) Reset() {
+	*x = DetachSessionResponse{}
 	mi := &file_serial_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WriteRequest) String() string {
+func (x *DetachSessionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteRequest) ProtoMessage() {}
+func (*DetachSessionResponse) ProtoMessage() {}
 
-func (x *WriteRequest) ProtoReflect() protoreflect.Message {
+func (x *DetachSessionResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1275,62 +1957,50 @@ func (x *WriteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteRequest.ProtoReflect.Descriptor instead.
-func (*WriteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetachSessionResponse.ProtoReflect.Descriptor instead.
+func (*DetachSessionResponse) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *WriteRequest) GetPortName() string {
+func (x *DetachSessionResponse) GetSuccess() bool {
 	if x != nil {
-		return x.PortName
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *WriteRequest) GetSessionId() string {
+func (x *DetachSessionResponse) GetMessage() string {
 	if x != nil {
-		return x.SessionId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *WriteRequest) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
-}
-
-func (x *WriteRequest) GetFlush() bool {
-	if x != nil {
-		return x.Flush
-	}
-	return false
-}
-
-type WriteResponse struct {
+type TakeOverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	BytesWritten  uint32                 `protobuf:"varint,2,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Client claiming the port
+	Exclusive     bool                   `protobuf:"varint,3,opt,name=exclusive,proto3" json:"exclusive,omitempty"`              // Request exclusive access after takeover
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`                // Must exceed the current owner's unless admin
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                     // Recorded in the audit log
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WriteResponse) Reset() {
-	*x = WriteResponse{}
+func (x *TakeOverRequest) Reset() {
+	*x = TakeOverRequest{}
 	mi := &file_serial_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WriteResponse) String() string {
+func (x *TakeOverRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WriteResponse) ProtoMessage() {}
+func (*TakeOverRequest) ProtoMessage() {}
 
-func (x *WriteResponse) ProtoReflect() protoreflect.Message {
+func (x *TakeOverRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1342,56 +2012,70 @@ func (x *WriteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WriteResponse.ProtoReflect.Descriptor instead.
-func (*WriteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TakeOverRequest.ProtoReflect.Descriptor instead.
+func (*TakeOverRequest) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *WriteResponse) GetSuccess() bool {
+func (x *TakeOverRequest) GetPortName() string {
 	if x != nil {
-		return x.Success
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *TakeOverRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TakeOverRequest) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
 	}
 	return false
 }
 
-func (x *WriteResponse) GetBytesWritten() uint32 {
+func (x *TakeOverRequest) GetPriority() int32 {
 	if x != nil {
-		return x.BytesWritten
+		return x.Priority
 	}
 	return 0
 }
 
-func (x *WriteResponse) GetMessage() string {
+func (x *TakeOverRequest) GetReason() string {
 	if x != nil {
-		return x.Message
+		return x.Reason
 	}
 	return ""
 }
 
-type ReadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	MaxBytes      uint32                 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`    // Maximum bytes to read
-	TimeoutMs     uint32                 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Timeout for this read operation
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type TakeOverResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SessionId        string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`                        // New session ID for this connection
+	PreviousClientId string                 `protobuf:"bytes,4,opt,name=previous_client_id,json=previousClientId,proto3" json:"previous_client_id,omitempty"` // Client that owned the port
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ReadRequest) Reset() {
-	*x = ReadRequest{}
+func (x *TakeOverResponse) Reset() {
+	*x = TakeOverResponse{}
 	mi := &file_serial_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReadRequest) String() string {
+func (x *TakeOverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadRequest) ProtoMessage() {}
+func (*TakeOverResponse) ProtoMessage() {}
 
-func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+func (x *TakeOverResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1403,63 +2087,60 @@ func (x *ReadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
-func (*ReadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TakeOverResponse.ProtoReflect.Descriptor instead.
+func (*TakeOverResponse) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ReadRequest) GetPortName() string {
+func (x *TakeOverResponse) GetSuccess() bool {
 	if x != nil {
-		return x.PortName
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *ReadRequest) GetSessionId() string {
+func (x *TakeOverResponse) GetMessage() string {
 	if x != nil {
-		return x.SessionId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ReadRequest) GetMaxBytes() uint32 {
+func (x *TakeOverResponse) GetSessionId() string {
 	if x != nil {
-		return x.MaxBytes
+		return x.SessionId
 	}
-	return 0
+	return ""
 }
 
-func (x *ReadRequest) GetTimeoutMs() uint32 {
+func (x *TakeOverResponse) GetPreviousClientId() string {
 	if x != nil {
-		return x.TimeoutMs
+		return x.PreviousClientId
 	}
-	return 0
+	return ""
 }
 
-type ReadResponse struct {
+type GetPortHoldersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	BytesRead     uint32                 `protobuf:"varint,3,opt,name=bytes_read,json=bytesRead,proto3" json:"bytes_read,omitempty"`
-	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReadResponse) Reset() {
-	*x = ReadResponse{}
+func (x *GetPortHoldersRequest) Reset() {
+	*x = GetPortHoldersRequest{}
 	mi := &file_serial_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReadResponse) String() string {
+func (x *GetPortHoldersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReadResponse) ProtoMessage() {}
+func (*GetPortHoldersRequest) ProtoMessage() {}
 
-func (x *ReadResponse) ProtoReflect() protoreflect.Message {
+func (x *GetPortHoldersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_serial_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1471,64 +2152,92 @@ func (x *ReadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReadResponse.ProtoReflect.Descriptor instead.
-func (*ReadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetPortHoldersRequest.ProtoReflect.Descriptor instead.
+func (*GetPortHoldersRequest) Descriptor() ([]byte, []int) {
 	return file_serial_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ReadResponse) GetSuccess() bool {
+func (x *GetPortHoldersRequest) GetPortName() string {
 	if x != nil {
-		return x.Success
+		return x.PortName
 	}
-	return false
+	return ""
 }
 
-func (x *ReadResponse) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
+type GetPortHoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holders       []*PortHolder          `protobuf:"bytes,1,rep,name=holders,proto3" json:"holders,omitempty"` // The opener first, then attached clients
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReadResponse) GetBytesRead() uint32 {
+func (x *GetPortHoldersResponse) Reset() {
+	*x = GetPortHoldersResponse{}
+	mi := &file_serial_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortHoldersResponse) ProtoMessage() {}
+
+func (x *GetPortHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[19]
 	if x != nil {
-		return x.BytesRead
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ReadResponse) GetMessage() string {
+// Deprecated: Use GetPortHoldersResponse.ProtoReflect.Descriptor instead.
+func (*GetPortHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPortHoldersResponse) GetHolders() []*PortHolder {
 	if x != nil {
-		return x.Message
+		return x.Holders
 	}
-	return ""
+	return nil
 }
 
-type StreamReadRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	PortName          string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	SessionId         string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ChunkSize         uint32                 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`                         // Preferred chunk size
-	IncludeTimestamps bool                   `protobuf:"varint,4,opt,name=include_timestamps,json=includeTimestamps,proto3" json:"include_timestamps,omitempty"` // Include timestamps in chunks
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type PortHolder struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Session ID of the opener, attachment ID otherwise
+	ClientId        string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Role            SessionRole            `protobuf:"varint,3,opt,name=role,proto3,enum=baudlink.serial.v1.SessionRole" json:"role,omitempty"`
+	Opener          bool                   `protobuf:"varint,4,opt,name=opener,proto3" json:"opener,omitempty"` // Opened the port; the port closes when it does
+	Since           int64                  `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`   // Unix timestamp
+	BytesSent       uint64                 `protobuf:"varint,6,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   uint64                 `protobuf:"varint,7,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	QueuedWrites    uint32                 `protobuf:"varint,8,opt,name=queued_writes,json=queuedWrites,proto3" json:"queued_writes,omitempty"`          // QueueWrite requests waiting their turn
+	QuotaRejections uint64                 `protobuf:"varint,9,opt,name=quota_rejections,json=quotaRejections,proto3" json:"quota_rejections,omitempty"` // Client's writes refused by the shared write quota since the agent started
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *StreamReadRequest) Reset() {
-	*x = StreamReadRequest{}
-	mi := &file_serial_proto_msgTypes[19]
+func (x *PortHolder) Reset() {
+	*x = PortHolder{}
+	mi := &file_serial_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamReadRequest) String() string {
+func (x *PortHolder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamReadRequest) ProtoMessage() {}
+func (*PortHolder) ProtoMessage() {}
 
-func (x *StreamReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[19]
+func (x *PortHolder) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1539,64 +2248,8168 @@ func (x *StreamReadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamReadRequest.ProtoReflect.Descriptor instead.
-func (*StreamReadRequest) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PortHolder.ProtoReflect.Descriptor instead.
+func (*PortHolder) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *StreamReadRequest) GetPortName() string {
+func (x *PortHolder) GetId() string {
 	if x != nil {
-		return x.PortName
+		return x.Id
 	}
 	return ""
 }
 
-func (x *StreamReadRequest) GetSessionId() string {
+func (x *PortHolder) GetClientId() string {
 	if x != nil {
-		return x.SessionId
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *StreamReadRequest) GetChunkSize() uint32 {
+func (x *PortHolder) GetRole() SessionRole {
 	if x != nil {
-		return x.ChunkSize
+		return x.Role
+	}
+	return SessionRole_SESSION_ROLE_UNSPECIFIED
+}
+
+func (x *PortHolder) GetOpener() bool {
+	if x != nil {
+		return x.Opener
+	}
+	return false
+}
+
+func (x *PortHolder) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *PortHolder) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PortHolder) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PortHolder) GetQueuedWrites() uint32 {
+	if x != nil {
+		return x.QueuedWrites
+	}
+	return 0
+}
+
+func (x *PortHolder) GetQuotaRejections() uint64 {
+	if x != nil {
+		return x.QuotaRejections
+	}
+	return 0
+}
+
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Role          SessionRole            `protobuf:"varint,3,opt,name=role,proto3,enum=baudlink.serial.v1.SessionRole" json:"role,omitempty"`
+	AttachedAt    int64                  `protobuf:"varint,4,opt,name=attached_at,json=attachedAt,proto3" json:"attached_at,omitempty"` // Unix timestamp
+	BytesSent     uint64                 `protobuf:"varint,5,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived uint64                 `protobuf:"varint,6,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_serial_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AttachmentInfo) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetRole() SessionRole {
+	if x != nil {
+		return x.Role
+	}
+	return SessionRole_SESSION_ROLE_UNSPECIFIED
+}
+
+func (x *AttachmentInfo) GetAttachedAt() int64 {
+	if x != nil {
+		return x.AttachedAt
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+type PortStatistics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BytesSent        uint64                 `protobuf:"varint,1,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived    uint64                 `protobuf:"varint,2,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Errors           uint64                 `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	OpenedAt         int64                  `protobuf:"varint,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`                            // Unix timestamp
+	LastActivity     int64                  `protobuf:"varint,5,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`                // Unix timestamp
+	FramesParsed     uint64                 `protobuf:"varint,6,opt,name=frames_parsed,json=framesParsed,proto3" json:"frames_parsed,omitempty"`                // Frames parsed by the active framer
+	FramingErrors    uint64                 `protobuf:"varint,7,opt,name=framing_errors,json=framingErrors,proto3" json:"framing_errors,omitempty"`             // Malformed or oversized frames
+	ChecksumErrors   uint64                 `protobuf:"varint,8,opt,name=checksum_errors,json=checksumErrors,proto3" json:"checksum_errors,omitempty"`          // Frames failing checksum verification
+	AverageFrameSize float64                `protobuf:"fixed64,9,opt,name=average_frame_size,json=averageFrameSize,proto3" json:"average_frame_size,omitempty"` // Mean size of parsed frames in bytes
+	HealthScore      float64                `protobuf:"fixed64,10,opt,name=health_score,json=healthScore,proto3" json:"health_score,omitempty"`                 // Link health from 0 (bad) to 100 (clean)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PortStatistics) Reset() {
+	*x = PortStatistics{}
+	mi := &file_serial_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortStatistics) ProtoMessage() {}
+
+func (x *PortStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortStatistics.ProtoReflect.Descriptor instead.
+func (*PortStatistics) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PortStatistics) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetLastActivity() int64 {
+	if x != nil {
+		return x.LastActivity
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetFramesParsed() uint64 {
+	if x != nil {
+		return x.FramesParsed
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetFramingErrors() uint64 {
+	if x != nil {
+		return x.FramingErrors
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetChecksumErrors() uint64 {
+	if x != nil {
+		return x.ChecksumErrors
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetAverageFrameSize() float64 {
+	if x != nil {
+		return x.AverageFrameSize
+	}
+	return 0
+}
+
+func (x *PortStatistics) GetHealthScore() float64 {
+	if x != nil {
+		return x.HealthScore
+	}
+	return 0
+}
+
+type PortConfig struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BaudRate          uint32                 `protobuf:"varint,1,opt,name=baud_rate,json=baudRate,proto3" json:"baud_rate,omitempty"` // e.g., 9600, 115200
+	DataBits          DataBits               `protobuf:"varint,2,opt,name=data_bits,json=dataBits,proto3,enum=baudlink.serial.v1.DataBits" json:"data_bits,omitempty"`
+	StopBits          StopBits               `protobuf:"varint,3,opt,name=stop_bits,json=stopBits,proto3,enum=baudlink.serial.v1.StopBits" json:"stop_bits,omitempty"`
+	Parity            Parity                 `protobuf:"varint,4,opt,name=parity,proto3,enum=baudlink.serial.v1.Parity" json:"parity,omitempty"`
+	FlowControl       FlowControl            `protobuf:"varint,5,opt,name=flow_control,json=flowControl,proto3,enum=baudlink.serial.v1.FlowControl" json:"flow_control,omitempty"`
+	ReadTimeoutMs     uint32                 `protobuf:"varint,6,opt,name=read_timeout_ms,json=readTimeoutMs,proto3" json:"read_timeout_ms,omitempty"`                // Read timeout in milliseconds
+	WriteTimeoutMs    uint32                 `protobuf:"varint,7,opt,name=write_timeout_ms,json=writeTimeoutMs,proto3" json:"write_timeout_ms,omitempty"`             // Write timeout in milliseconds
+	Framer            string                 `protobuf:"bytes,8,opt,name=framer,proto3" json:"framer,omitempty"`                                                      // Frame dissector: "line", "nmea" (empty = none)
+	WriteChunkSize    uint32                 `protobuf:"varint,9,opt,name=write_chunk_size,json=writeChunkSize,proto3" json:"write_chunk_size,omitempty"`             // Maximum bytes per write (0 = unlimited)
+	WriteChunkDelayMs uint32                 `protobuf:"varint,10,opt,name=write_chunk_delay_ms,json=writeChunkDelayMs,proto3" json:"write_chunk_delay_ms,omitempty"` // Delay between write chunks
+	WriteLineDelayMs  uint32                 `protobuf:"varint,11,opt,name=write_line_delay_ms,json=writeLineDelayMs,proto3" json:"write_line_delay_ms,omitempty"`    // Delay after each newline written
+	Rs485             *RS485Config           `protobuf:"bytes,12,opt,name=rs485,proto3" json:"rs485,omitempty"`                                                       // Half-duplex RS-485 driver control
+	Checksum          *ChecksumConfig        `protobuf:"bytes,13,opt,name=checksum,proto3" json:"checksum,omitempty"`                                                 // Checksum appended to writes and verified on responses
+	ReadFilters       []string               `protobuf:"bytes,14,rep,name=read_filters,json=readFilters,proto3" json:"read_filters,omitempty"`                        // Filters applied in order to received data
+	WriteFilters      []string               `protobuf:"bytes,15,rep,name=write_filters,json=writeFilters,proto3" json:"write_filters,omitempty"`                     // Filters applied in order to written data
+	XonChar           uint32                 `protobuf:"varint,16,opt,name=xon_char,json=xonChar,proto3" json:"xon_char,omitempty"`                                   // Software flow control resume character (0 = DC1)
+	XoffChar          uint32                 `protobuf:"varint,17,opt,name=xoff_char,json=xoffChar,proto3" json:"xoff_char,omitempty"`                                // Software flow control pause character (0 = DC3)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PortConfig) Reset() {
+	*x = PortConfig{}
+	mi := &file_serial_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortConfig) ProtoMessage() {}
+
+func (x *PortConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortConfig.ProtoReflect.Descriptor instead.
+func (*PortConfig) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PortConfig) GetBaudRate() uint32 {
+	if x != nil {
+		return x.BaudRate
+	}
+	return 0
+}
+
+func (x *PortConfig) GetDataBits() DataBits {
+	if x != nil {
+		return x.DataBits
+	}
+	return DataBits_DATA_BITS_UNSPECIFIED
+}
+
+func (x *PortConfig) GetStopBits() StopBits {
+	if x != nil {
+		return x.StopBits
+	}
+	return StopBits_STOP_BITS_UNSPECIFIED
+}
+
+func (x *PortConfig) GetParity() Parity {
+	if x != nil {
+		return x.Parity
+	}
+	return Parity_PARITY_UNSPECIFIED
+}
+
+func (x *PortConfig) GetFlowControl() FlowControl {
+	if x != nil {
+		return x.FlowControl
+	}
+	return FlowControl_FLOW_CONTROL_UNSPECIFIED
+}
+
+func (x *PortConfig) GetReadTimeoutMs() uint32 {
+	if x != nil {
+		return x.ReadTimeoutMs
+	}
+	return 0
+}
+
+func (x *PortConfig) GetWriteTimeoutMs() uint32 {
+	if x != nil {
+		return x.WriteTimeoutMs
+	}
+	return 0
+}
+
+func (x *PortConfig) GetFramer() string {
+	if x != nil {
+		return x.Framer
+	}
+	return ""
+}
+
+func (x *PortConfig) GetWriteChunkSize() uint32 {
+	if x != nil {
+		return x.WriteChunkSize
+	}
+	return 0
+}
+
+func (x *PortConfig) GetWriteChunkDelayMs() uint32 {
+	if x != nil {
+		return x.WriteChunkDelayMs
+	}
+	return 0
+}
+
+func (x *PortConfig) GetWriteLineDelayMs() uint32 {
+	if x != nil {
+		return x.WriteLineDelayMs
+	}
+	return 0
+}
+
+func (x *PortConfig) GetRs485() *RS485Config {
+	if x != nil {
+		return x.Rs485
+	}
+	return nil
+}
+
+func (x *PortConfig) GetChecksum() *ChecksumConfig {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *PortConfig) GetReadFilters() []string {
+	if x != nil {
+		return x.ReadFilters
+	}
+	return nil
+}
+
+func (x *PortConfig) GetWriteFilters() []string {
+	if x != nil {
+		return x.WriteFilters
+	}
+	return nil
+}
+
+func (x *PortConfig) GetXonChar() uint32 {
+	if x != nil {
+		return x.XonChar
+	}
+	return 0
+}
+
+func (x *PortConfig) GetXoffChar() uint32 {
+	if x != nil {
+		return x.XoffChar
+	}
+	return 0
+}
+
+// RS485Config keys an RS-485 transceiver's driver enable line through RTS
+type RS485Config struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Enabled           bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RtsActiveLow      bool                   `protobuf:"varint,2,opt,name=rts_active_low,json=rtsActiveLow,proto3" json:"rts_active_low,omitempty"`                  // Drive RTS low rather than high while sending
+	DelayBeforeSendMs uint32                 `protobuf:"varint,3,opt,name=delay_before_send_ms,json=delayBeforeSendMs,proto3" json:"delay_before_send_ms,omitempty"` // Delay between enabling the driver and the first byte
+	DelayAfterSendMs  uint32                 `protobuf:"varint,4,opt,name=delay_after_send_ms,json=delayAfterSendMs,proto3" json:"delay_after_send_ms,omitempty"`    // Delay between the last byte and releasing the driver
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RS485Config) Reset() {
+	*x = RS485Config{}
+	mi := &file_serial_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RS485Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RS485Config) ProtoMessage() {}
+
+func (x *RS485Config) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RS485Config.ProtoReflect.Descriptor instead.
+func (*RS485Config) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RS485Config) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RS485Config) GetRtsActiveLow() bool {
+	if x != nil {
+		return x.RtsActiveLow
+	}
+	return false
+}
+
+func (x *RS485Config) GetDelayBeforeSendMs() uint32 {
+	if x != nil {
+		return x.DelayBeforeSendMs
+	}
+	return 0
+}
+
+func (x *RS485Config) GetDelayAfterSendMs() uint32 {
+	if x != nil {
+		return x.DelayAfterSendMs
+	}
+	return 0
+}
+
+// ChecksumConfig adds a checksum to every write and verifies it on framed
+// responses
+type ChecksumConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`                   // "crc16", "crc32", "lrc", "xor" (empty = none)
+	Polynomial    uint32                 `protobuf:"varint,2,opt,name=polynomial,proto3" json:"polynomial,omitempty"`                // CRC polynomial in normal form (0 = algorithm default)
+	Placement     string                 `protobuf:"bytes,3,opt,name=placement,proto3" json:"placement,omitempty"`                   // "end" (default) or "before_terminator"
+	Terminator    []byte                 `protobuf:"bytes,4,opt,name=terminator,proto3" json:"terminator,omitempty"`                 // Trailer the checksum precedes with before_terminator
+	BigEndian     bool                   `protobuf:"varint,5,opt,name=big_endian,json=bigEndian,proto3" json:"big_endian,omitempty"` // Most significant byte first (default: least)
+	Hex           bool                   `protobuf:"varint,6,opt,name=hex,proto3" json:"hex,omitempty"`                              // Encode the checksum as uppercase ASCII hex
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecksumConfig) Reset() {
+	*x = ChecksumConfig{}
+	mi := &file_serial_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecksumConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksumConfig) ProtoMessage() {}
+
+func (x *ChecksumConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksumConfig.ProtoReflect.Descriptor instead.
+func (*ChecksumConfig) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ChecksumConfig) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *ChecksumConfig) GetPolynomial() uint32 {
+	if x != nil {
+		return x.Polynomial
+	}
+	return 0
+}
+
+func (x *ChecksumConfig) GetPlacement() string {
+	if x != nil {
+		return x.Placement
+	}
+	return ""
+}
+
+func (x *ChecksumConfig) GetTerminator() []byte {
+	if x != nil {
+		return x.Terminator
+	}
+	return nil
+}
+
+func (x *ChecksumConfig) GetBigEndian() bool {
+	if x != nil {
+		return x.BigEndian
+	}
+	return false
+}
+
+func (x *ChecksumConfig) GetHex() bool {
+	if x != nil {
+		return x.Hex
+	}
+	return false
+}
+
+type ConfigurePortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Config        *PortConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurePortRequest) Reset() {
+	*x = ConfigurePortRequest{}
+	mi := &file_serial_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurePortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurePortRequest) ProtoMessage() {}
+
+func (x *ConfigurePortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurePortRequest.ProtoReflect.Descriptor instead.
+func (*ConfigurePortRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfigurePortRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *ConfigurePortRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ConfigurePortRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type ConfigurePortResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurePortResponse) Reset() {
+	*x = ConfigurePortResponse{}
+	mi := &file_serial_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurePortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurePortResponse) ProtoMessage() {}
+
+func (x *ConfigurePortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigurePortResponse.ProtoReflect.Descriptor instead.
+func (*ConfigurePortResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ConfigurePortResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfigurePortResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetPortConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortConfigRequest) Reset() {
+	*x = GetPortConfigRequest{}
+	mi := &file_serial_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortConfigRequest) ProtoMessage() {}
+
+func (x *GetPortConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetPortConfigRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetPortConfigRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+// TapConfig mirrors session traffic to exactly one destination
+type TapConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     TapDirection           `protobuf:"varint,1,opt,name=direction,proto3,enum=baudlink.serial.v1.TapDirection" json:"direction,omitempty"`
+	MirrorToFile  string                 `protobuf:"bytes,2,opt,name=mirror_to_file,json=mirrorToFile,proto3" json:"mirror_to_file,omitempty"` // Path relative to the agent's tap directory
+	MirrorToTcp   string                 `protobuf:"bytes,3,opt,name=mirror_to_tcp,json=mirrorToTcp,proto3" json:"mirror_to_tcp,omitempty"`    // host:port receiving the records
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TapConfig) Reset() {
+	*x = TapConfig{}
+	mi := &file_serial_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TapConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapConfig) ProtoMessage() {}
+
+func (x *TapConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapConfig.ProtoReflect.Descriptor instead.
+func (*TapConfig) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TapConfig) GetDirection() TapDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TapDirection_TAP_DIRECTION_UNSPECIFIED
+}
+
+func (x *TapConfig) GetMirrorToFile() string {
+	if x != nil {
+		return x.MirrorToFile
+	}
+	return ""
+}
+
+func (x *TapConfig) GetMirrorToTcp() string {
+	if x != nil {
+		return x.MirrorToTcp
+	}
+	return ""
+}
+
+type TapInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TapId          string                 `protobuf:"bytes,1,opt,name=tap_id,json=tapId,proto3" json:"tap_id,omitempty"`
+	Config         *TapConfig             `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	BytesWritten   uint64                 `protobuf:"varint,3,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`       // Payload bytes mirrored
+	RecordsDropped uint64                 `protobuf:"varint,4,opt,name=records_dropped,json=recordsDropped,proto3" json:"records_dropped,omitempty"` // Records lost to a slow or unavailable destination
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TapInfo) Reset() {
+	*x = TapInfo{}
+	mi := &file_serial_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TapInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapInfo) ProtoMessage() {}
+
+func (x *TapInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapInfo.ProtoReflect.Descriptor instead.
+func (*TapInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TapInfo) GetTapId() string {
+	if x != nil {
+		return x.TapId
+	}
+	return ""
+}
+
+func (x *TapInfo) GetConfig() *TapConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *TapInfo) GetBytesWritten() uint64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *TapInfo) GetRecordsDropped() uint64 {
+	if x != nil {
+		return x.RecordsDropped
+	}
+	return 0
+}
+
+type AddTapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Tap           *TapConfig             `protobuf:"bytes,3,opt,name=tap,proto3" json:"tap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTapRequest) Reset() {
+	*x = AddTapRequest{}
+	mi := &file_serial_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTapRequest) ProtoMessage() {}
+
+func (x *AddTapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTapRequest.ProtoReflect.Descriptor instead.
+func (*AddTapRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddTapRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *AddTapRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AddTapRequest) GetTap() *TapConfig {
+	if x != nil {
+		return x.Tap
+	}
+	return nil
+}
+
+type AddTapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TapId         string                 `protobuf:"bytes,3,opt,name=tap_id,json=tapId,proto3" json:"tap_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTapResponse) Reset() {
+	*x = AddTapResponse{}
+	mi := &file_serial_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTapResponse) ProtoMessage() {}
+
+func (x *AddTapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTapResponse.ProtoReflect.Descriptor instead.
+func (*AddTapResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AddTapResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddTapResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddTapResponse) GetTapId() string {
+	if x != nil {
+		return x.TapId
+	}
+	return ""
+}
+
+type RemoveTapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	TapId         string                 `protobuf:"bytes,3,opt,name=tap_id,json=tapId,proto3" json:"tap_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTapRequest) Reset() {
+	*x = RemoveTapRequest{}
+	mi := &file_serial_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTapRequest) ProtoMessage() {}
+
+func (x *RemoveTapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTapRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTapRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveTapRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *RemoveTapRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RemoveTapRequest) GetTapId() string {
+	if x != nil {
+		return x.TapId
+	}
+	return ""
+}
+
+type RemoveTapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTapResponse) Reset() {
+	*x = RemoveTapResponse{}
+	mi := &file_serial_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTapResponse) ProtoMessage() {}
+
+func (x *RemoveTapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTapResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTapResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RemoveTapResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveTapResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StartPassthroughRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`    // Exclusive session from OpenPort
+	Pty           bool                   `protobuf:"varint,3,opt,name=pty,proto3" json:"pty,omitempty"`                                // Expose the port as a pseudo-terminal on the agent
+	TcpAddress    string                 `protobuf:"bytes,4,opt,name=tcp_address,json=tcpAddress,proto3" json:"tcp_address,omitempty"` // Or listen on host:port, e.g. "127.0.0.1:0"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPassthroughRequest) Reset() {
+	*x = StartPassthroughRequest{}
+	mi := &file_serial_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPassthroughRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPassthroughRequest) ProtoMessage() {}
+
+func (x *StartPassthroughRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPassthroughRequest.ProtoReflect.Descriptor instead.
+func (*StartPassthroughRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StartPassthroughRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *StartPassthroughRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StartPassthroughRequest) GetPty() bool {
+	if x != nil {
+		return x.Pty
+	}
+	return false
+}
+
+func (x *StartPassthroughRequest) GetTcpAddress() string {
+	if x != nil {
+		return x.TcpAddress
+	}
+	return ""
+}
+
+type StartPassthroughResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // Terminal path or listening TCP address
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartPassthroughResponse) Reset() {
+	*x = StartPassthroughResponse{}
+	mi := &file_serial_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartPassthroughResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartPassthroughResponse) ProtoMessage() {}
+
+func (x *StartPassthroughResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartPassthroughResponse.ProtoReflect.Descriptor instead.
+func (*StartPassthroughResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *StartPassthroughResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartPassthroughResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartPassthroughResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type StopPassthroughRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopPassthroughRequest) Reset() {
+	*x = StopPassthroughRequest{}
+	mi := &file_serial_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopPassthroughRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopPassthroughRequest) ProtoMessage() {}
+
+func (x *StopPassthroughRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopPassthroughRequest.ProtoReflect.Descriptor instead.
+func (*StopPassthroughRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StopPassthroughRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *StopPassthroughRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type StopPassthroughResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BytesIn       uint64                 `protobuf:"varint,3,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`    // Bytes passed from the port to the program
+	BytesOut      uint64                 `protobuf:"varint,4,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"` // Bytes passed from the program to the port
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopPassthroughResponse) Reset() {
+	*x = StopPassthroughResponse{}
+	mi := &file_serial_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopPassthroughResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopPassthroughResponse) ProtoMessage() {}
+
+func (x *StopPassthroughResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopPassthroughResponse.ProtoReflect.Descriptor instead.
+func (*StopPassthroughResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StopPassthroughResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopPassthroughResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StopPassthroughResponse) GetBytesIn() uint64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *StopPassthroughResponse) GetBytesOut() uint64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+// ProxyPortsRequest connects two ports back to back. The proxy runs until
+// the client cancels the stream.
+type ProxyPortsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`               // Port A
+	PeerPortName  string                 `protobuf:"bytes,2,opt,name=peer_port_name,json=peerPortName,proto3" json:"peer_port_name,omitempty"` // Port B
+	Config        *PortConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`                                   // Port A; omit to apply its profile or the agent defaults
+	PeerConfig    *PortConfig            `protobuf:"bytes,4,opt,name=peer_config,json=peerConfig,proto3" json:"peer_config,omitempty"`         // Port B; omit to apply its profile or the agent defaults
+	ClientId      string                 `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	FiltersAToB   []string               `protobuf:"bytes,6,rep,name=filters_a_to_b,json=filtersAToB,proto3" json:"filters_a_to_b,omitempty"`    // Filters applied to data from A before it is written to B
+	FiltersBToA   []string               `protobuf:"bytes,7,rep,name=filters_b_to_a,json=filtersBToA,proto3" json:"filters_b_to_a,omitempty"`    // Filters applied to data from B before it is written to A
+	DelayAToBMs   uint32                 `protobuf:"varint,8,opt,name=delay_a_to_b_ms,json=delayAToBMs,proto3" json:"delay_a_to_b_ms,omitempty"` // Latency added to data from A to B
+	DelayBToAMs   uint32                 `protobuf:"varint,9,opt,name=delay_b_to_a_ms,json=delayBToAMs,proto3" json:"delay_b_to_a_ms,omitempty"` // Latency added to data from B to A
+	Taps          []*TapConfig           `protobuf:"bytes,10,rep,name=taps,proto3" json:"taps,omitempty"`                                        // Taps on port A, capturing A to B as RX and B to A as TX
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyPortsRequest) Reset() {
+	*x = ProxyPortsRequest{}
+	mi := &file_serial_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyPortsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyPortsRequest) ProtoMessage() {}
+
+func (x *ProxyPortsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyPortsRequest.ProtoReflect.Descriptor instead.
+func (*ProxyPortsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ProxyPortsRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *ProxyPortsRequest) GetPeerPortName() string {
+	if x != nil {
+		return x.PeerPortName
+	}
+	return ""
+}
+
+func (x *ProxyPortsRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ProxyPortsRequest) GetPeerConfig() *PortConfig {
+	if x != nil {
+		return x.PeerConfig
+	}
+	return nil
+}
+
+func (x *ProxyPortsRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ProxyPortsRequest) GetFiltersAToB() []string {
+	if x != nil {
+		return x.FiltersAToB
+	}
+	return nil
+}
+
+func (x *ProxyPortsRequest) GetFiltersBToA() []string {
+	if x != nil {
+		return x.FiltersBToA
+	}
+	return nil
+}
+
+func (x *ProxyPortsRequest) GetDelayAToBMs() uint32 {
+	if x != nil {
+		return x.DelayAToBMs
+	}
+	return 0
+}
+
+func (x *ProxyPortsRequest) GetDelayBToAMs() uint32 {
+	if x != nil {
+		return x.DelayBToAMs
+	}
+	return 0
+}
+
+func (x *ProxyPortsRequest) GetTaps() []*TapConfig {
+	if x != nil {
+		return x.Taps
+	}
+	return nil
+}
+
+type ProxyEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     ProxyDirection         `protobuf:"varint,1,opt,name=direction,proto3,enum=baudlink.serial.v1.ProxyDirection" json:"direction,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                  // Bytes as received
+	Forwarded     []byte                 `protobuf:"bytes,3,opt,name=forwarded,proto3" json:"forwarded,omitempty"`                        // Bytes written to the other port, set when filters changed them
+	FilterError   string                 `protobuf:"bytes,4,opt,name=filter_error,json=filterError,proto3" json:"filter_error,omitempty"` // The filters rejected the chunk, which was not forwarded
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                       // Unix timestamp in nanoseconds the chunk was received
+	Sequence      uint64                 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Lost          uint64                 `protobuf:"varint,7,opt,name=lost,proto3" json:"lost,omitempty"`         // Chunks dropped before this one because the client fell behind
+	Started       bool                   `protobuf:"varint,8,opt,name=started,proto3" json:"started,omitempty"`   // Marker: both ports are open and forwarding, sent first
+	Shutdown      bool                   `protobuf:"varint,9,opt,name=shutdown,proto3" json:"shutdown,omitempty"` // Marker: the agent is shutting down and the stream will end
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyEvent) Reset() {
+	*x = ProxyEvent{}
+	mi := &file_serial_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyEvent) ProtoMessage() {}
+
+func (x *ProxyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyEvent.ProtoReflect.Descriptor instead.
+func (*ProxyEvent) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ProxyEvent) GetDirection() ProxyDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return ProxyDirection_PROXY_DIRECTION_UNSPECIFIED
+}
+
+func (x *ProxyEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ProxyEvent) GetForwarded() []byte {
+	if x != nil {
+		return x.Forwarded
+	}
+	return nil
+}
+
+func (x *ProxyEvent) GetFilterError() string {
+	if x != nil {
+		return x.FilterError
+	}
+	return ""
+}
+
+func (x *ProxyEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ProxyEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ProxyEvent) GetLost() uint64 {
+	if x != nil {
+		return x.Lost
+	}
+	return 0
+}
+
+func (x *ProxyEvent) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *ProxyEvent) GetShutdown() bool {
+	if x != nil {
+		return x.Shutdown
+	}
+	return false
+}
+
+type WriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Flush         bool                   `protobuf:"varint,4,opt,name=flush,proto3" json:"flush,omitempty"`                                     // Wait until the data has been transmitted
+	CorrelationId string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"` // Echoed back in the write-complete event
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRequest) Reset() {
+	*x = WriteRequest{}
+	mi := &file_serial_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRequest) ProtoMessage() {}
+
+func (x *WriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRequest.ProtoReflect.Descriptor instead.
+func (*WriteRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WriteRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *WriteRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *WriteRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteRequest) GetFlush() bool {
+	if x != nil {
+		return x.Flush
+	}
+	return false
+}
+
+func (x *WriteRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+type WriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	BytesWritten  uint32                 `protobuf:"varint,2,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,4,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteResponse) Reset() {
+	*x = WriteResponse{}
+	mi := &file_serial_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteResponse) ProtoMessage() {}
+
+func (x *WriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteResponse.ProtoReflect.Descriptor instead.
+func (*WriteResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WriteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WriteResponse) GetBytesWritten() uint32 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *WriteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+type QueueWriteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`                               // Higher priorities are written first
+	CorrelationId string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"` // Echoed back in the write-complete event
+	DelayMs       uint32                 `protobuf:"varint,6,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`                  // Do not write before this delay elapses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueWriteRequest) Reset() {
+	*x = QueueWriteRequest{}
+	mi := &file_serial_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueWriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueWriteRequest) ProtoMessage() {}
+
+func (x *QueueWriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueWriteRequest.ProtoReflect.Descriptor instead.
+func (*QueueWriteRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *QueueWriteRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *QueueWriteRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *QueueWriteRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *QueueWriteRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *QueueWriteRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *QueueWriteRequest) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+type QueueWriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`        // Identifies the write-complete event
+	QueueDepth    uint32                 `protobuf:"varint,3,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"` // Pending writes including this one
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueWriteResponse) Reset() {
+	*x = QueueWriteResponse{}
+	mi := &file_serial_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueWriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueWriteResponse) ProtoMessage() {}
+
+func (x *QueueWriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueWriteResponse.ProtoReflect.Descriptor instead.
+func (*QueueWriteResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *QueueWriteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *QueueWriteResponse) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *QueueWriteResponse) GetQueueDepth() uint32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *QueueWriteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	MaxBytes      uint32                 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`    // Maximum bytes to read
+	TimeoutMs     uint32                 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Timeout for this read operation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadRequest) Reset() {
+	*x = ReadRequest{}
+	mi := &file_serial_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRequest) ProtoMessage() {}
+
+func (x *ReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRequest.ProtoReflect.Descriptor instead.
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReadRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *ReadRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ReadRequest) GetMaxBytes() uint32 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *ReadRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type ReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	BytesRead     uint32                 `protobuf:"varint,3,opt,name=bytes_read,json=bytesRead,proto3" json:"bytes_read,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadResponse) Reset() {
+	*x = ReadResponse{}
+	mi := &file_serial_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadResponse) ProtoMessage() {}
+
+func (x *ReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadResponse.ProtoReflect.Descriptor instead.
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReadResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReadResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReadResponse) GetBytesRead() uint32 {
+	if x != nil {
+		return x.BytesRead
+	}
+	return 0
+}
+
+func (x *ReadResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TransactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                         // Request to send
+	Terminator    []byte                 `protobuf:"bytes,4,opt,name=terminator,proto3" json:"terminator,omitempty"`                             // Complete when the response contains this
+	Pattern       string                 `protobuf:"bytes,5,opt,name=pattern,proto3" json:"pattern,omitempty"`                                   // Complete when this regex matches
+	ExpectedBytes uint32                 `protobuf:"varint,6,opt,name=expected_bytes,json=expectedBytes,proto3" json:"expected_bytes,omitempty"` // Complete after this many bytes
+	TimeoutMs     uint32                 `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`             // Overall timeout (default: read timeout)
+	FlushInput    bool                   `protobuf:"varint,8,opt,name=flush_input,json=flushInput,proto3" json:"flush_input,omitempty"`          // Discard stale input before sending
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactRequest) Reset() {
+	*x = TransactRequest{}
+	mi := &file_serial_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactRequest) ProtoMessage() {}
+
+func (x *TransactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactRequest.ProtoReflect.Descriptor instead.
+func (*TransactRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TransactRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *TransactRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TransactRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TransactRequest) GetTerminator() []byte {
+	if x != nil {
+		return x.Terminator
+	}
+	return nil
+}
+
+func (x *TransactRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *TransactRequest) GetExpectedBytes() uint32 {
+	if x != nil {
+		return x.ExpectedBytes
+	}
+	return 0
+}
+
+func (x *TransactRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *TransactRequest) GetFlushInput() bool {
+	if x != nil {
+		return x.FlushInput
+	}
+	return false
+}
+
+type TransactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`        // Collected response
+	Matched       bool                   `protobuf:"varint,3,opt,name=matched,proto3" json:"matched,omitempty"` // A completion condition was satisfied
+	ElapsedMs     uint32                 `protobuf:"varint,4,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	ChecksumError bool                   `protobuf:"varint,6,opt,name=checksum_error,json=checksumError,proto3" json:"checksum_error,omitempty"` // Response failed checksum verification (data left intact)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactResponse) Reset() {
+	*x = TransactResponse{}
+	mi := &file_serial_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactResponse) ProtoMessage() {}
+
+func (x *TransactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactResponse.ProtoReflect.Descriptor instead.
+func (*TransactResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TransactResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TransactResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TransactResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *TransactResponse) GetElapsedMs() uint32 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *TransactResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TransactResponse) GetChecksumError() bool {
+	if x != nil {
+		return x.ChecksumError
+	}
+	return false
+}
+
+type WriteBatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	DelayMs       uint32                 `protobuf:"varint,2,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`                     // Wait after writing this item
+	ExpectedEcho  []byte                 `protobuf:"bytes,3,opt,name=expected_echo,json=expectedEcho,proto3" json:"expected_echo,omitempty"`       // Fail unless the device echoes this back
+	EchoTimeoutMs uint32                 `protobuf:"varint,4,opt,name=echo_timeout_ms,json=echoTimeoutMs,proto3" json:"echo_timeout_ms,omitempty"` // Wait for the echo (default: read timeout)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBatchItem) Reset() {
+	*x = WriteBatchItem{}
+	mi := &file_serial_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBatchItem) ProtoMessage() {}
+
+func (x *WriteBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBatchItem.ProtoReflect.Descriptor instead.
+func (*WriteBatchItem) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WriteBatchItem) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WriteBatchItem) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *WriteBatchItem) GetExpectedEcho() []byte {
+	if x != nil {
+		return x.ExpectedEcho
+	}
+	return nil
+}
+
+func (x *WriteBatchItem) GetEchoTimeoutMs() uint32 {
+	if x != nil {
+		return x.EchoTimeoutMs
+	}
+	return 0
+}
+
+type WriteBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Items         []*WriteBatchItem      `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"` // Written in order under the session lock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBatchRequest) Reset() {
+	*x = WriteBatchRequest{}
+	mi := &file_serial_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBatchRequest) ProtoMessage() {}
+
+func (x *WriteBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBatchRequest.ProtoReflect.Descriptor instead.
+func (*WriteBatchRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WriteBatchRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *WriteBatchRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *WriteBatchRequest) GetItems() []*WriteBatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WriteBatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Executed      bool                   `protobuf:"varint,1,opt,name=executed,proto3" json:"executed,omitempty"` // False for items skipped after a failure
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	BytesWritten  uint32                 `protobuf:"varint,3,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	Echo          []byte                 `protobuf:"bytes,4,opt,name=echo,proto3" json:"echo,omitempty"`       // Data received while waiting for the echo
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"` // Why the item failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBatchItemResult) Reset() {
+	*x = WriteBatchItemResult{}
+	mi := &file_serial_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBatchItemResult) ProtoMessage() {}
+
+func (x *WriteBatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBatchItemResult.ProtoReflect.Descriptor instead.
+func (*WriteBatchItemResult) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WriteBatchItemResult) GetExecuted() bool {
+	if x != nil {
+		return x.Executed
+	}
+	return false
+}
+
+func (x *WriteBatchItemResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WriteBatchItemResult) GetBytesWritten() uint32 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *WriteBatchItemResult) GetEcho() []byte {
+	if x != nil {
+		return x.Echo
+	}
+	return nil
+}
+
+func (x *WriteBatchItemResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WriteBatchResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Success       bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Every item succeeded
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*WriteBatchItemResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`                                // One per requested item
+	BytesWritten  uint32                  `protobuf:"varint,4,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"` // Total across all items
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteBatchResponse) Reset() {
+	*x = WriteBatchResponse{}
+	mi := &file_serial_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteBatchResponse) ProtoMessage() {}
+
+func (x *WriteBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteBatchResponse.ProtoReflect.Descriptor instead.
+func (*WriteBatchResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *WriteBatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WriteBatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteBatchResponse) GetResults() []*WriteBatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *WriteBatchResponse) GetBytesWritten() uint32 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+type FlushRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Mode          FlushMode              `protobuf:"varint,3,opt,name=mode,proto3,enum=baudlink.serial.v1.FlushMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushRequest) Reset() {
+	*x = FlushRequest{}
+	mi := &file_serial_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushRequest) ProtoMessage() {}
+
+func (x *FlushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushRequest.ProtoReflect.Descriptor instead.
+func (*FlushRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FlushRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *FlushRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FlushRequest) GetMode() FlushMode {
+	if x != nil {
+		return x.Mode
+	}
+	return FlushMode_FLUSH_MODE_BOTH
+}
+
+type FlushResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlushResponse) Reset() {
+	*x = FlushResponse{}
+	mi := &file_serial_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlushResponse) ProtoMessage() {}
+
+func (x *FlushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlushResponse.ProtoReflect.Descriptor instead.
+func (*FlushResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *FlushResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FlushResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetBufferStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBufferStatusRequest) Reset() {
+	*x = GetBufferStatusRequest{}
+	mi := &file_serial_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBufferStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBufferStatusRequest) ProtoMessage() {}
+
+func (x *GetBufferStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBufferStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBufferStatusRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetBufferStatusRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *GetBufferStatusRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type BufferStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DriverInput     uint32                 `protobuf:"varint,1,opt,name=driver_input,json=driverInput,proto3" json:"driver_input,omitempty"`               // Bytes received by the driver and not read by the agent
+	DriverOutput    uint32                 `protobuf:"varint,2,opt,name=driver_output,json=driverOutput,proto3" json:"driver_output,omitempty"`            // Bytes written to the driver and not transmitted
+	DriverReported  bool                   `protobuf:"varint,3,opt,name=driver_reported,json=driverReported,proto3" json:"driver_reported,omitempty"`      // The platform reported the driver queue lengths
+	Buffered        uint32                 `protobuf:"varint,4,opt,name=buffered,proto3" json:"buffered,omitempty"`                                        // Received bytes waiting in the agent's buffer
+	BufferSize      uint32                 `protobuf:"varint,5,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`                  // Capacity of the agent's buffer
+	Dropped         uint64                 `protobuf:"varint,6,opt,name=dropped,proto3" json:"dropped,omitempty"`                                          // Bytes discarded because the agent's buffer overflowed
+	WriteQueueDepth uint32                 `protobuf:"varint,7,opt,name=write_queue_depth,json=writeQueueDepth,proto3" json:"write_queue_depth,omitempty"` // Pending QueueWrite requests
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BufferStatus) Reset() {
+	*x = BufferStatus{}
+	mi := &file_serial_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BufferStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BufferStatus) ProtoMessage() {}
+
+func (x *BufferStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BufferStatus.ProtoReflect.Descriptor instead.
+func (*BufferStatus) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *BufferStatus) GetDriverInput() uint32 {
+	if x != nil {
+		return x.DriverInput
+	}
+	return 0
+}
+
+func (x *BufferStatus) GetDriverOutput() uint32 {
+	if x != nil {
+		return x.DriverOutput
+	}
+	return 0
+}
+
+func (x *BufferStatus) GetDriverReported() bool {
+	if x != nil {
+		return x.DriverReported
+	}
+	return false
+}
+
+func (x *BufferStatus) GetBuffered() uint32 {
+	if x != nil {
+		return x.Buffered
+	}
+	return 0
+}
+
+func (x *BufferStatus) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *BufferStatus) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *BufferStatus) GetWriteQueueDepth() uint32 {
+	if x != nil {
+		return x.WriteQueueDepth
+	}
+	return 0
+}
+
+type SCPIQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Commands      []string               `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`                             // Sent in order; queries (header ending in '?') read a response
+	TimeoutMs     uint32                 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`         // Timeout of each query (default: read timeout)
+	Terminator    string                 `protobuf:"bytes,5,opt,name=terminator,proto3" json:"terminator,omitempty"`                         // Ends commands and responses (default: "\n")
+	StopOnError   bool                   `protobuf:"varint,6,opt,name=stop_on_error,json=stopOnError,proto3" json:"stop_on_error,omitempty"` // Skip the remaining commands after a failure
+	CheckErrors   bool                   `protobuf:"varint,7,opt,name=check_errors,json=checkErrors,proto3" json:"check_errors,omitempty"`   // Drain the error queue with SYST:ERR? afterwards
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIQueryRequest) Reset() {
+	*x = SCPIQueryRequest{}
+	mi := &file_serial_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIQueryRequest) ProtoMessage() {}
+
+func (x *SCPIQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIQueryRequest.ProtoReflect.Descriptor instead.
+func (*SCPIQueryRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SCPIQueryRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SCPIQueryRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SCPIQueryRequest) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *SCPIQueryRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *SCPIQueryRequest) GetTerminator() string {
+	if x != nil {
+		return x.Terminator
+	}
+	return ""
+}
+
+func (x *SCPIQueryRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.StopOnError
+	}
+	return false
+}
+
+func (x *SCPIQueryRequest) GetCheckErrors() bool {
+	if x != nil {
+		return x.CheckErrors
+	}
+	return false
+}
+
+type SCPIQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Every command succeeded and no instrument errors were reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*SCPIResult          `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"` // One per command sent
+	Errors        []*SCPIError           `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`   // Error queue entries (check_errors only)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIQueryResponse) Reset() {
+	*x = SCPIQueryResponse{}
+	mi := &file_serial_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIQueryResponse) ProtoMessage() {}
+
+func (x *SCPIQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIQueryResponse.ProtoReflect.Descriptor instead.
+func (*SCPIQueryResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SCPIQueryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SCPIQueryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SCPIQueryResponse) GetResults() []*SCPIResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SCPIQueryResponse) GetErrors() []*SCPIError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SCPIResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Response      string                 `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"` // Response without the terminator (queries only)
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"` // Why the command failed
+	ElapsedMs     uint32                 `protobuf:"varint,5,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIResult) Reset() {
+	*x = SCPIResult{}
+	mi := &file_serial_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIResult) ProtoMessage() {}
+
+func (x *SCPIResult) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIResult.ProtoReflect.Descriptor instead.
+func (*SCPIResult) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SCPIResult) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SCPIResult) GetResponse() string {
+	if x != nil {
+		return x.Response
+	}
+	return ""
+}
+
+func (x *SCPIResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SCPIResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SCPIResult) GetElapsedMs() uint32 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+type SCPIError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`      // e.g. -113
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // e.g. "Undefined header"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIError) Reset() {
+	*x = SCPIError{}
+	mi := &file_serial_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIError) ProtoMessage() {}
+
+func (x *SCPIError) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIError.ProtoReflect.Descriptor instead.
+func (*SCPIError) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SCPIError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *SCPIError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SCPIErrorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	TimeoutMs     uint32                 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Timeout of each SYST:ERR? query (default: read timeout)
+	Terminator    string                 `protobuf:"bytes,4,opt,name=terminator,proto3" json:"terminator,omitempty"`                 // Ends commands and responses (default: "\n")
+	MaxErrors     uint32                 `protobuf:"varint,5,opt,name=max_errors,json=maxErrors,proto3" json:"max_errors,omitempty"` // Maximum entries read (default: 32)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIErrorsRequest) Reset() {
+	*x = SCPIErrorsRequest{}
+	mi := &file_serial_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIErrorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIErrorsRequest) ProtoMessage() {}
+
+func (x *SCPIErrorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIErrorsRequest.ProtoReflect.Descriptor instead.
+func (*SCPIErrorsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SCPIErrorsRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SCPIErrorsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SCPIErrorsRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *SCPIErrorsRequest) GetTerminator() string {
+	if x != nil {
+		return x.Terminator
+	}
+	return ""
+}
+
+func (x *SCPIErrorsRequest) GetMaxErrors() uint32 {
+	if x != nil {
+		return x.MaxErrors
+	}
+	return 0
+}
+
+type SCPIErrorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // The queue was read until it reported no error
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Errors        []*SCPIError           `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SCPIErrorsResponse) Reset() {
+	*x = SCPIErrorsResponse{}
+	mi := &file_serial_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SCPIErrorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCPIErrorsResponse) ProtoMessage() {}
+
+func (x *SCPIErrorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCPIErrorsResponse.ProtoReflect.Descriptor instead.
+func (*SCPIErrorsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SCPIErrorsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SCPIErrorsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SCPIErrorsResponse) GetErrors() []*SCPIError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SendATRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`                       // e.g. "AT+CSQ"; a carriage return is appended
+	TimeoutMs     uint32                 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Wait for the final result (default: 5000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendATRequest) Reset() {
+	*x = SendATRequest{}
+	mi := &file_serial_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendATRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendATRequest) ProtoMessage() {}
+
+func (x *SendATRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendATRequest.ProtoReflect.Descriptor instead.
+func (*SendATRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SendATRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SendATRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SendATRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SendATRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type SendATResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // The final result was OK or CONNECT
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Lines         []string               `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`                                   // Information lines, e.g. "+CSQ: 21,99"
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`                                 // Final result code, e.g. "OK" or "+CME ERROR: 10"
+	ErrorCode     int32                  `protobuf:"varint,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`         // Numeric +CME/+CMS error, or -1
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // e.g. "SIM not inserted"
+	ElapsedMs     uint32                 `protobuf:"varint,7,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendATResponse) Reset() {
+	*x = SendATResponse{}
+	mi := &file_serial_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendATResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendATResponse) ProtoMessage() {}
+
+func (x *SendATResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendATResponse.ProtoReflect.Descriptor instead.
+func (*SendATResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SendATResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SendATResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SendATResponse) GetLines() []string {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *SendATResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *SendATResponse) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *SendATResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SendATResponse) GetElapsedMs() uint32 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+type SubscribeURCRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeURCRequest) Reset() {
+	*x = SubscribeURCRequest{}
+	mi := &file_serial_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeURCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeURCRequest) ProtoMessage() {}
+
+func (x *SubscribeURCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeURCRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeURCRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SubscribeURCRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SubscribeURCRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type URCEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`            // e.g. "+CMTI" or "RING"
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`          // Text after the colon, e.g. "\"SM\",3"
+	Line          string                 `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`            // The complete line
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`            // Second line of +CMT, +CDS, and +CBM
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in nanoseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URCEvent) Reset() {
+	*x = URCEvent{}
+	mi := &file_serial_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URCEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URCEvent) ProtoMessage() {}
+
+func (x *URCEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URCEvent.ProtoReflect.Descriptor instead.
+func (*URCEvent) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *URCEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *URCEvent) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *URCEvent) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *URCEvent) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *URCEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type RunScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Script        []byte                 `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"` // YAML script with send/expect/sleep steps
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunScriptRequest) Reset() {
+	*x = RunScriptRequest{}
+	mi := &file_serial_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScriptRequest) ProtoMessage() {}
+
+func (x *RunScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScriptRequest.ProtoReflect.Descriptor instead.
+func (*RunScriptRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RunScriptRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *RunScriptRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RunScriptRequest) GetScript() []byte {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+type ScriptEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          uint32                 `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"` // 1-based step number (0 = whole script)
+	Type          ScriptEventType        `protobuf:"varint,2,opt,name=type,proto3,enum=baudlink.serial.v1.ScriptEventType" json:"type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in nanoseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptEvent) Reset() {
+	*x = ScriptEvent{}
+	mi := &file_serial_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptEvent) ProtoMessage() {}
+
+func (x *ScriptEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptEvent.ProtoReflect.Descriptor instead.
+func (*ScriptEvent) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ScriptEvent) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *ScriptEvent) GetType() ScriptEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ScriptEventType_SCRIPT_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ScriptEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ScriptEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScriptEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// EmulateRequest impersonates a device with an emulator script. Without a
+// port_name the agent creates a pseudo-terminal (a named pipe on Windows)
+// for clients to open; otherwise the port is opened exclusively and the
+// script answers on it. The emulator runs until the client cancels.
+type EmulateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Script        []byte                 `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`                     // YAML emulator script
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Port to answer on (empty = virtual port)
+	Config        *PortConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`                     // Line settings for port_name (default: profile or agent default)
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmulateRequest) Reset() {
+	*x = EmulateRequest{}
+	mi := &file_serial_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmulateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmulateRequest) ProtoMessage() {}
+
+func (x *EmulateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmulateRequest.ProtoReflect.Descriptor instead.
+func (*EmulateRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *EmulateRequest) GetScript() []byte {
+	if x != nil {
+		return x.Script
+	}
+	return nil
+}
+
+func (x *EmulateRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *EmulateRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *EmulateRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type EmulatorEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EmulatorEventType      `protobuf:"varint,1,opt,name=type,proto3,enum=baudlink.serial.v1.EmulatorEventType" json:"type,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // Current state, or the state entered
+	Rule          string                 `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`   // Rule that matched or replied
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Address       string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`      // Started: terminal path or port name to open
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in nanoseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmulatorEvent) Reset() {
+	*x = EmulatorEvent{}
+	mi := &file_serial_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmulatorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmulatorEvent) ProtoMessage() {}
+
+func (x *EmulatorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmulatorEvent.ProtoReflect.Descriptor instead.
+func (*EmulatorEvent) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *EmulatorEvent) GetType() EmulatorEventType {
+	if x != nil {
+		return x.Type
+	}
+	return EmulatorEventType_EMULATOR_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *EmulatorEvent) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *EmulatorEvent) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *EmulatorEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EmulatorEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EmulatorEvent) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EmulatorEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type CreateJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`              // Session to run on (default: managed session, or open the port for each run)
+	Schedule      string                 `protobuf:"bytes,4,opt,name=schedule,proto3" json:"schedule,omitempty"`                                 // Cron expression, or a descriptor such as "@every 60s"
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`                                         // Request to send
+	Terminator    []byte                 `protobuf:"bytes,6,opt,name=terminator,proto3" json:"terminator,omitempty"`                             // Complete when the response contains this
+	Pattern       string                 `protobuf:"bytes,7,opt,name=pattern,proto3" json:"pattern,omitempty"`                                   // Complete when this regex matches
+	ExpectedBytes uint32                 `protobuf:"varint,8,opt,name=expected_bytes,json=expectedBytes,proto3" json:"expected_bytes,omitempty"` // Complete after this many bytes
+	TimeoutMs     uint32                 `protobuf:"varint,9,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`             // Overall timeout (default: read timeout)
+	FlushInput    bool                   `protobuf:"varint,10,opt,name=flush_input,json=flushInput,proto3" json:"flush_input,omitempty"`         // Discard stale input before sending
+	Config        *PortConfig            `protobuf:"bytes,11,opt,name=config,proto3" json:"config,omitempty"`                                    // Line settings when the job opens the port
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobRequest) Reset() {
+	*x = CreateJobRequest{}
+	mi := &file_serial_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobRequest) ProtoMessage() {}
+
+func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetTerminator() []byte {
+	if x != nil {
+		return x.Terminator
+	}
+	return nil
+}
+
+func (x *CreateJobRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *CreateJobRequest) GetExpectedBytes() uint32 {
+	if x != nil {
+		return x.ExpectedBytes
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *CreateJobRequest) GetFlushInput() bool {
+	if x != nil {
+		return x.FlushInput
+	}
+	return false
+}
+
+func (x *CreateJobRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CreateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	JobId         string                 `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobResponse) Reset() {
+	*x = CreateJobResponse{}
+	mi := &file_serial_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobResponse) ProtoMessage() {}
+
+func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
+func (*CreateJobResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Job ID or name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_serial_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DeleteJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_serial_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeleteJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetJobResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`          // Optional filter by job ID or name
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Optional filter by port
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResultsRequest) Reset() {
+	*x = GetJobResultsRequest{}
+	mi := &file_serial_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResultsRequest) ProtoMessage() {}
+
+func (x *GetJobResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobResultsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetJobResultsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobResultsRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+type GetJobResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*JobInfo             `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobResultsResponse) Reset() {
+	*x = GetJobResultsResponse{}
+	mi := &file_serial_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResultsResponse) ProtoMessage() {}
+
+func (x *GetJobResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResultsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetJobResultsResponse) GetJobs() []*JobInfo {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type JobInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PortName      string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Port name or alias
+	SessionId     string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Schedule      string                 `protobuf:"bytes,5,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	NextRun       int64                  `protobuf:"varint,6,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // Unix timestamp in nanoseconds
+	Runs          uint64                 `protobuf:"varint,7,opt,name=runs,proto3" json:"runs,omitempty"`
+	Failures      uint64                 `protobuf:"varint,8,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastResult    *JobResult             `protobuf:"bytes,9,opt,name=last_result,json=lastResult,proto3" json:"last_result,omitempty"` // Unset until the job has run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobInfo) Reset() {
+	*x = JobInfo{}
+	mi := &file_serial_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInfo) ProtoMessage() {}
+
+func (x *JobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInfo.ProtoReflect.Descriptor instead.
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *JobInfo) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobInfo) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *JobInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *JobInfo) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *JobInfo) GetNextRun() int64 {
+	if x != nil {
+		return x.NextRun
+	}
+	return 0
+}
+
+func (x *JobInfo) GetRuns() uint64 {
+	if x != nil {
+		return x.Runs
+	}
+	return 0
+}
+
+func (x *JobInfo) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *JobInfo) GetLastResult() *JobResult {
+	if x != nil {
+		return x.LastResult
+	}
+	return nil
+}
+
+type JobResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in nanoseconds
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                         // Collected response
+	Matched       bool                   `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`                                  // A completion condition was satisfied
+	ChecksumError bool                   `protobuf:"varint,5,opt,name=checksum_error,json=checksumError,proto3" json:"checksum_error,omitempty"` // Response failed checksum verification
+	ElapsedMs     uint32                 `protobuf:"varint,6,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobResult) Reset() {
+	*x = JobResult{}
+	mi := &file_serial_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResult) ProtoMessage() {}
+
+func (x *JobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResult.ProtoReflect.Descriptor instead.
+func (*JobResult) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *JobResult) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *JobResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *JobResult) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *JobResult) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+func (x *JobResult) GetChecksumError() bool {
+	if x != nil {
+		return x.ChecksumError
+	}
+	return false
+}
+
+func (x *JobResult) GetElapsedMs() uint32 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *JobResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PortGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Ports         []string               `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`            // Port names or aliases
+	Configured    bool                   `protobuf:"varint,4,opt,name=configured,proto3" json:"configured,omitempty"` // Defined in the agent configuration rather than by CreateGroup
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortGroup) Reset() {
+	*x = PortGroup{}
+	mi := &file_serial_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortGroup) ProtoMessage() {}
+
+func (x *PortGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortGroup.ProtoReflect.Descriptor instead.
+func (*PortGroup) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *PortGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PortGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PortGroup) GetPorts() []string {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *PortGroup) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+type ListGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	mi := &file_serial_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{80}
+}
+
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*PortGroup           `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_serial_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*PortGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Ports         []string               `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_serial_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetPorts() []string {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type CreateGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupResponse) Reset() {
+	*x = CreateGroupResponse{}
+	mi := &file_serial_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupResponse) ProtoMessage() {}
+
+func (x *CreateGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *CreateGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeleteGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupRequest) Reset() {
+	*x = DeleteGroupRequest{}
+	mi := &file_serial_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupRequest) ProtoMessage() {}
+
+func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DeleteGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGroupResponse) Reset() {
+	*x = DeleteGroupResponse{}
+	mi := &file_serial_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGroupResponse) ProtoMessage() {}
+
+func (x *DeleteGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DeleteGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type OpenGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Config        *PortConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"` // Applied to every member; omit to use profiles or agent defaults
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Exclusive     bool                   `protobuf:"varint,4,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenGroupRequest) Reset() {
+	*x = OpenGroupRequest{}
+	mi := &file_serial_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenGroupRequest) ProtoMessage() {}
+
+func (x *OpenGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenGroupRequest.ProtoReflect.Descriptor instead.
+func (*OpenGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *OpenGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *OpenGroupRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *OpenGroupRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OpenGroupRequest) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+type OpenGroupResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GroupSessionId string                 `protobuf:"bytes,3,opt,name=group_session_id,json=groupSessionId,proto3" json:"group_session_id,omitempty"` // Used by WriteGroup, StreamGroup, and CloseGroup
+	Members        []*GroupMemberResult   `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenGroupResponse) Reset() {
+	*x = OpenGroupResponse{}
+	mi := &file_serial_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenGroupResponse) ProtoMessage() {}
+
+func (x *OpenGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenGroupResponse.ProtoReflect.Descriptor instead.
+func (*OpenGroupResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *OpenGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *OpenGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OpenGroupResponse) GetGroupSessionId() string {
+	if x != nil {
+		return x.GroupSessionId
+	}
+	return ""
+}
+
+func (x *OpenGroupResponse) GetMembers() []*GroupMemberResult {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type GroupMemberResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Member session, usable with the single-port RPCs
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	BytesWritten  uint32                 `protobuf:"varint,5,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"` // WriteGroup only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMemberResult) Reset() {
+	*x = GroupMemberResult{}
+	mi := &file_serial_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMemberResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMemberResult) ProtoMessage() {}
+
+func (x *GroupMemberResult) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMemberResult.ProtoReflect.Descriptor instead.
+func (*GroupMemberResult) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GroupMemberResult) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *GroupMemberResult) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GroupMemberResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GroupMemberResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GroupMemberResult) GetBytesWritten() uint32 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+type CloseGroupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GroupSessionId string                 `protobuf:"bytes,1,opt,name=group_session_id,json=groupSessionId,proto3" json:"group_session_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CloseGroupRequest) Reset() {
+	*x = CloseGroupRequest{}
+	mi := &file_serial_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseGroupRequest) ProtoMessage() {}
+
+func (x *CloseGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseGroupRequest.ProtoReflect.Descriptor instead.
+func (*CloseGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *CloseGroupRequest) GetGroupSessionId() string {
+	if x != nil {
+		return x.GroupSessionId
+	}
+	return ""
+}
+
+type CloseGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Members       []*GroupMemberResult   `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseGroupResponse) Reset() {
+	*x = CloseGroupResponse{}
+	mi := &file_serial_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseGroupResponse) ProtoMessage() {}
+
+func (x *CloseGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseGroupResponse.ProtoReflect.Descriptor instead.
+func (*CloseGroupResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *CloseGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CloseGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CloseGroupResponse) GetMembers() []*GroupMemberResult {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type WriteGroupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GroupSessionId string                 `protobuf:"bytes,1,opt,name=group_session_id,json=groupSessionId,proto3" json:"group_session_id,omitempty"`
+	Data           []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WriteGroupRequest) Reset() {
+	*x = WriteGroupRequest{}
+	mi := &file_serial_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteGroupRequest) ProtoMessage() {}
+
+func (x *WriteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteGroupRequest.ProtoReflect.Descriptor instead.
+func (*WriteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *WriteGroupRequest) GetGroupSessionId() string {
+	if x != nil {
+		return x.GroupSessionId
+	}
+	return ""
+}
+
+func (x *WriteGroupRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WriteGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Whether every member was written
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Members       []*GroupMemberResult   `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteGroupResponse) Reset() {
+	*x = WriteGroupResponse{}
+	mi := &file_serial_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteGroupResponse) ProtoMessage() {}
+
+func (x *WriteGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteGroupResponse.ProtoReflect.Descriptor instead.
+func (*WriteGroupResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *WriteGroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WriteGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WriteGroupResponse) GetMembers() []*GroupMemberResult {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type StreamGroupRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupSessionId    string                 `protobuf:"bytes,1,opt,name=group_session_id,json=groupSessionId,proto3" json:"group_session_id,omitempty"`
+	ChunkSize         uint32                 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`                         // Preferred chunk size
+	IncludeTimestamps bool                   `protobuf:"varint,3,opt,name=include_timestamps,json=includeTimestamps,proto3" json:"include_timestamps,omitempty"` // Include timestamps in chunks
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StreamGroupRequest) Reset() {
+	*x = StreamGroupRequest{}
+	mi := &file_serial_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamGroupRequest) ProtoMessage() {}
+
+func (x *StreamGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamGroupRequest.ProtoReflect.Descriptor instead.
+func (*StreamGroupRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *StreamGroupRequest) GetGroupSessionId() string {
+	if x != nil {
+		return x.GroupSessionId
+	}
+	return ""
+}
+
+func (x *StreamGroupRequest) GetChunkSize() uint32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *StreamGroupRequest) GetIncludeTimestamps() bool {
+	if x != nil {
+		return x.IncludeTimestamps
+	}
+	return false
+}
+
+type StreamReadRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PortName          string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId         string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ChunkSize         uint32                 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`                         // Preferred chunk size
+	IncludeTimestamps bool                   `protobuf:"varint,4,opt,name=include_timestamps,json=includeTimestamps,proto3" json:"include_timestamps,omitempty"` // Include timestamps in chunks
+	HexDump           bool                   `protobuf:"varint,5,opt,name=hex_dump,json=hexDump,proto3" json:"hex_dump,omitempty"`                               // Also return a formatted hex+ASCII dump of each chunk
+	AckWindow         uint32                 `protobuf:"varint,6,opt,name=ack_window,json=ackWindow,proto3" json:"ack_window,omitempty"`                         // Deliver losslessly, pausing once this many chunks are unacknowledged
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StreamReadRequest) Reset() {
+	*x = StreamReadRequest{}
+	mi := &file_serial_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamReadRequest) ProtoMessage() {}
+
+func (x *StreamReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamReadRequest.ProtoReflect.Descriptor instead.
+func (*StreamReadRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *StreamReadRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *StreamReadRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StreamReadRequest) GetChunkSize() uint32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *StreamReadRequest) GetIncludeTimestamps() bool {
+	if x != nil {
+		return x.IncludeTimestamps
+	}
+	return false
+}
+
+func (x *StreamReadRequest) GetHexDump() bool {
+	if x != nil {
+		return x.HexDump
+	}
+	return false
+}
+
+func (x *StreamReadRequest) GetAckWindow() uint32 {
+	if x != nil {
+		return x.AckWindow
+	}
+	return 0
+}
+
+type DataChunk struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PortName           string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	Data               []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Timestamp          int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                // Unix timestamp in nanoseconds
+	Sequence           uint32                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`                                                  // Sequence number for ordering
+	CorrelationId      string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`                    // Optional write correlation ID
+	Gap                bool                   `protobuf:"varint,6,opt,name=gap,proto3" json:"gap,omitempty"`                                                            // Marker: data may have been lost while the device was reconnected
+	HexDump            string                 `protobuf:"bytes,7,opt,name=hex_dump,json=hexDump,proto3" json:"hex_dump,omitempty"`                                      // Hex+ASCII dump with stream offsets, when requested
+	Shutdown           bool                   `protobuf:"varint,8,opt,name=shutdown,proto3" json:"shutdown,omitempty"`                                                  // Marker: the agent is shutting down and the stream will end
+	StreamId           string                 `protobuf:"bytes,9,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`                                   // Acknowledged streams: ID for AckStream, sent in the first chunk
+	Overflow           *Overflow              `protobuf:"bytes,10,opt,name=overflow,proto3" json:"overflow,omitempty"`                                                  // Marker: chunks were dropped because the client fell behind
+	SessionSequence    uint64                 `protobuf:"varint,11,opt,name=session_sequence,json=sessionSequence,proto3" json:"session_sequence,omitempty"`            // Sequence number across every stream of the session
+	FirstByteTime      int64                  `protobuf:"varint,12,opt,name=first_byte_time,json=firstByteTime,proto3" json:"first_byte_time,omitempty"`                // Unix time in nanoseconds the first byte was received
+	LastByteTime       int64                  `protobuf:"varint,13,opt,name=last_byte_time,json=lastByteTime,proto3" json:"last_byte_time,omitempty"`                   // Unix time in nanoseconds the last byte was received
+	FirstByteMonotonic int64                  `protobuf:"varint,14,opt,name=first_byte_monotonic,json=firstByteMonotonic,proto3" json:"first_byte_monotonic,omitempty"` // Monotonic nanoseconds since agent start the first byte was received
+	LastByteMonotonic  int64                  `protobuf:"varint,15,opt,name=last_byte_monotonic,json=lastByteMonotonic,proto3" json:"last_byte_monotonic,omitempty"`    // Monotonic nanoseconds since agent start the last byte was received
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DataChunk) Reset() {
+	*x = DataChunk{}
+	mi := &file_serial_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChunk) ProtoMessage() {}
+
+func (x *DataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *DataChunk) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *DataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DataChunk) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *DataChunk) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DataChunk) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *DataChunk) GetGap() bool {
+	if x != nil {
+		return x.Gap
+	}
+	return false
+}
+
+func (x *DataChunk) GetHexDump() string {
+	if x != nil {
+		return x.HexDump
+	}
+	return ""
+}
+
+func (x *DataChunk) GetShutdown() bool {
+	if x != nil {
+		return x.Shutdown
+	}
+	return false
+}
+
+func (x *DataChunk) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *DataChunk) GetOverflow() *Overflow {
+	if x != nil {
+		return x.Overflow
+	}
+	return nil
+}
+
+func (x *DataChunk) GetSessionSequence() uint64 {
+	if x != nil {
+		return x.SessionSequence
+	}
+	return 0
+}
+
+func (x *DataChunk) GetFirstByteTime() int64 {
+	if x != nil {
+		return x.FirstByteTime
+	}
+	return 0
+}
+
+func (x *DataChunk) GetLastByteTime() int64 {
+	if x != nil {
+		return x.LastByteTime
+	}
+	return 0
+}
+
+func (x *DataChunk) GetFirstByteMonotonic() int64 {
+	if x != nil {
+		return x.FirstByteMonotonic
+	}
+	return 0
+}
+
+func (x *DataChunk) GetLastByteMonotonic() int64 {
+	if x != nil {
+		return x.LastByteMonotonic
+	}
+	return 0
+}
+
+type Overflow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LostChunks    uint32                 `protobuf:"varint,1,opt,name=lost_chunks,json=lostChunks,proto3" json:"lost_chunks,omitempty"`
+	LostBytes     uint64                 `protobuf:"varint,2,opt,name=lost_bytes,json=lostBytes,proto3" json:"lost_bytes,omitempty"`
+	FirstSequence uint32                 `protobuf:"varint,3,opt,name=first_sequence,json=firstSequence,proto3" json:"first_sequence,omitempty"` // Sequence number of the first chunk lost
+	LastSequence  uint32                 `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`    // Sequence number of the last chunk lost
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Overflow) Reset() {
+	*x = Overflow{}
+	mi := &file_serial_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Overflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Overflow) ProtoMessage() {}
+
+func (x *Overflow) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Overflow.ProtoReflect.Descriptor instead.
+func (*Overflow) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *Overflow) GetLostChunks() uint32 {
+	if x != nil {
+		return x.LostChunks
+	}
+	return 0
+}
+
+func (x *Overflow) GetLostBytes() uint64 {
+	if x != nil {
+		return x.LostBytes
+	}
+	return 0
+}
+
+func (x *Overflow) GetFirstSequence() uint32 {
+	if x != nil {
+		return x.FirstSequence
+	}
+	return 0
+}
+
+func (x *Overflow) GetLastSequence() uint32 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+type AckStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	StreamId      string                 `protobuf:"bytes,3,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"` // Acknowledges this chunk and every earlier one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckStreamRequest) Reset() {
+	*x = AckStreamRequest{}
+	mi := &file_serial_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckStreamRequest) ProtoMessage() {}
+
+func (x *AckStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckStreamRequest.ProtoReflect.Descriptor instead.
+func (*AckStreamRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *AckStreamRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *AckStreamRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AckStreamRequest) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *AckStreamRequest) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type AckStreamResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Unacknowledged uint32                 `protobuf:"varint,3,opt,name=unacknowledged,proto3" json:"unacknowledged,omitempty"` // Chunks sent but not yet acknowledged
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AckStreamResponse) Reset() {
+	*x = AckStreamResponse{}
+	mi := &file_serial_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckStreamResponse) ProtoMessage() {}
+
+func (x *AckStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckStreamResponse.ProtoReflect.Descriptor instead.
+func (*AckStreamResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *AckStreamResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AckStreamResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AckStreamResponse) GetUnacknowledged() uint32 {
+	if x != nil {
+		return x.Unacknowledged
+	}
+	return 0
+}
+
+type StreamWriteResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TotalBytesWritten uint64                 `protobuf:"varint,2,opt,name=total_bytes_written,json=totalBytesWritten,proto3" json:"total_bytes_written,omitempty"`
+	ChunksProcessed   uint32                 `protobuf:"varint,3,opt,name=chunks_processed,json=chunksProcessed,proto3" json:"chunks_processed,omitempty"`
+	Message           string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StreamWriteResponse) Reset() {
+	*x = StreamWriteResponse{}
+	mi := &file_serial_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamWriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamWriteResponse) ProtoMessage() {}
+
+func (x *StreamWriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamWriteResponse.ProtoReflect.Descriptor instead.
+func (*StreamWriteResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *StreamWriteResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StreamWriteResponse) GetTotalBytesWritten() uint64 {
+	if x != nil {
+		return x.TotalBytesWritten
+	}
+	return 0
+}
+
+func (x *StreamWriteResponse) GetChunksProcessed() uint32 {
+	if x != nil {
+		return x.ChunksProcessed
+	}
+	return 0
+}
+
+func (x *StreamWriteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`    // Optional filter by port
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Optional filter by session
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_serial_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *StreamEventsRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SessionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=baudlink.serial.v1.EventType" json:"type,omitempty"`
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                             // Unix timestamp in nanoseconds
+	CorrelationId string                 `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"` // Correlation ID of the originating write
+	BytesWritten  uint32                 `protobuf:"varint,6,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	Drained       bool                   `protobuf:"varint,7,opt,name=drained,proto3" json:"drained,omitempty"`                                   // Output was drained to the wire
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`                                    // Error or informational message
+	TicketId      string                 `protobuf:"bytes,9,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`                  // Ticket of the originating queued write
+	Rule          string                 `protobuf:"bytes,10,opt,name=rule,proto3" json:"rule,omitempty"`                                         // Name of the matching rule
+	Data          []byte                 `protobuf:"bytes,11,opt,name=data,proto3" json:"data,omitempty"`                                         // Data matched by the rule, or the job's response
+	Job           string                 `protobuf:"bytes,12,opt,name=job,proto3" json:"job,omitempty"`                                           // Name of the completed job
+	ClientId      string                 `protobuf:"bytes,13,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                 // Client waiting in the port's queue
+	QueuePosition uint32                 `protobuf:"varint,14,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"` // Place in the port's queue, 1 = next; 0 once the port is granted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionEvent) Reset() {
+	*x = SessionEvent{}
+	mi := &file_serial_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEvent) ProtoMessage() {}
+
+func (x *SessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEvent.ProtoReflect.Descriptor instead.
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SessionEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *SessionEvent) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetBytesWritten() uint32 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetDrained() bool {
+	if x != nil {
+		return x.Drained
+	}
+	return false
+}
+
+func (x *SessionEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SessionEvent) GetJob() string {
+	if x != nil {
+		return x.Job
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetQueuePosition() uint32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_serial_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *PingRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ServerTime    int64                  `protobuf:"varint,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_serial_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *PingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PingResponse) GetServerTime() int64 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+type GetStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Empty for all open ports
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatisticsRequest) Reset() {
+	*x = GetStatisticsRequest{}
+	mi := &file_serial_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsRequest) ProtoMessage() {}
+
+func (x *GetStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *GetStatisticsRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+type GetStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*SessionStatistics   `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatisticsResponse) Reset() {
+	*x = GetStatisticsResponse{}
+	mi := &file_serial_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatisticsResponse) ProtoMessage() {}
+
+func (x *GetStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GetStatisticsResponse) GetSessions() []*SessionStatistics {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type SessionStatistics struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PortName        string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId       string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	BytesSent       uint64                 `protobuf:"varint,3,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   uint64                 `protobuf:"varint,4,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Rates           []*ThroughputRate      `protobuf:"bytes,5,rep,name=rates,proto3" json:"rates,omitempty"` // Rolling rates over 1s, 10s, and 60s windows
+	Errors          *ErrorCounts           `protobuf:"bytes,6,opt,name=errors,proto3" json:"errors,omitempty"`
+	WriteQueueDepth uint32                 `protobuf:"varint,7,opt,name=write_queue_depth,json=writeQueueDepth,proto3" json:"write_queue_depth,omitempty"` // Pending QueueWrite requests
+	BufferedBytes   uint32                 `protobuf:"varint,8,opt,name=buffered_bytes,json=bufferedBytes,proto3" json:"buffered_bytes,omitempty"`         // Received bytes waiting in the managed buffer
+	Subscribers     uint32                 `protobuf:"varint,9,opt,name=subscribers,proto3" json:"subscribers,omitempty"`                                  // Active read subscriptions
+	OpenedAt        int64                  `protobuf:"varint,10,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`                       // Unix timestamps in seconds; 0 = never
+	LastActivity    int64                  `protobuf:"varint,11,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	LastSent        int64                  `protobuf:"varint,12,opt,name=last_sent,json=lastSent,proto3" json:"last_sent,omitempty"`
+	LastReceived    int64                  `protobuf:"varint,13,opt,name=last_received,json=lastReceived,proto3" json:"last_received,omitempty"`
+	ReadLatency     *LatencyStats          `protobuf:"bytes,14,opt,name=read_latency,json=readLatency,proto3" json:"read_latency,omitempty"`       // Wait between receiving data and a stream taking it
+	WriteDuration   *LatencyStats          `protobuf:"bytes,15,opt,name=write_duration,json=writeDuration,proto3" json:"write_duration,omitempty"` // Duration of write calls to the port
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SessionStatistics) Reset() {
+	*x = SessionStatistics{}
+	mi := &file_serial_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStatistics) ProtoMessage() {}
+
+func (x *SessionStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStatistics.ProtoReflect.Descriptor instead.
+func (*SessionStatistics) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *SessionStatistics) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SessionStatistics) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionStatistics) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetRates() []*ThroughputRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *SessionStatistics) GetErrors() *ErrorCounts {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *SessionStatistics) GetWriteQueueDepth() uint32 {
+	if x != nil {
+		return x.WriteQueueDepth
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetBufferedBytes() uint32 {
+	if x != nil {
+		return x.BufferedBytes
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetSubscribers() uint32 {
+	if x != nil {
+		return x.Subscribers
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetLastActivity() int64 {
+	if x != nil {
+		return x.LastActivity
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetLastSent() int64 {
+	if x != nil {
+		return x.LastSent
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetLastReceived() int64 {
+	if x != nil {
+		return x.LastReceived
+	}
+	return 0
+}
+
+func (x *SessionStatistics) GetReadLatency() *LatencyStats {
+	if x != nil {
+		return x.ReadLatency
+	}
+	return nil
+}
+
+func (x *SessionStatistics) GetWriteDuration() *LatencyStats {
+	if x != nil {
+		return x.WriteDuration
+	}
+	return nil
+}
+
+// Percentiles, maximum, and jitter cover the most recent 1024 measurements
+type LatencyStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`                    // Measurements since the session opened
+	TotalUs       uint64                 `protobuf:"varint,2,opt,name=total_us,json=totalUs,proto3" json:"total_us,omitempty"` // Sum of all measurements
+	P50Us         uint64                 `protobuf:"varint,3,opt,name=p50_us,json=p50Us,proto3" json:"p50_us,omitempty"`
+	P90Us         uint64                 `protobuf:"varint,4,opt,name=p90_us,json=p90Us,proto3" json:"p90_us,omitempty"`
+	P99Us         uint64                 `protobuf:"varint,5,opt,name=p99_us,json=p99Us,proto3" json:"p99_us,omitempty"`
+	MaxUs         uint64                 `protobuf:"varint,6,opt,name=max_us,json=maxUs,proto3" json:"max_us,omitempty"`
+	JitterUs      uint64                 `protobuf:"varint,7,opt,name=jitter_us,json=jitterUs,proto3" json:"jitter_us,omitempty"` // Standard deviation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyStats) Reset() {
+	*x = LatencyStats{}
+	mi := &file_serial_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyStats) ProtoMessage() {}
+
+func (x *LatencyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyStats.ProtoReflect.Descriptor instead.
+func (*LatencyStats) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *LatencyStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetTotalUs() uint64 {
+	if x != nil {
+		return x.TotalUs
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetP50Us() uint64 {
+	if x != nil {
+		return x.P50Us
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetP90Us() uint64 {
+	if x != nil {
+		return x.P90Us
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetP99Us() uint64 {
+	if x != nil {
+		return x.P99Us
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetMaxUs() uint64 {
+	if x != nil {
+		return x.MaxUs
+	}
+	return 0
+}
+
+func (x *LatencyStats) GetJitterUs() uint64 {
+	if x != nil {
+		return x.JitterUs
+	}
+	return 0
+}
+
+type ThroughputRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowSeconds uint32                 `protobuf:"varint,1,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	SentBps       float64                `protobuf:"fixed64,2,opt,name=sent_bps,json=sentBps,proto3" json:"sent_bps,omitempty"`             // Bytes per second written
+	ReceivedBps   float64                `protobuf:"fixed64,3,opt,name=received_bps,json=receivedBps,proto3" json:"received_bps,omitempty"` // Bytes per second received
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThroughputRate) Reset() {
+	*x = ThroughputRate{}
+	mi := &file_serial_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThroughputRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThroughputRate) ProtoMessage() {}
+
+func (x *ThroughputRate) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThroughputRate.ProtoReflect.Descriptor instead.
+func (*ThroughputRate) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ThroughputRate) GetWindowSeconds() uint32 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *ThroughputRate) GetSentBps() float64 {
+	if x != nil {
+		return x.SentBps
+	}
+	return 0
+}
+
+func (x *ThroughputRate) GetReceivedBps() float64 {
+	if x != nil {
+		return x.ReceivedBps
+	}
+	return 0
+}
+
+type ErrorCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Io            uint64                 `protobuf:"varint,1,opt,name=io,proto3" json:"io,omitempty"`                                      // Read and write failures
+	Framing       uint64                 `protobuf:"varint,2,opt,name=framing,proto3" json:"framing,omitempty"`                            // Malformed frames (framer only)
+	Checksum      uint64                 `protobuf:"varint,3,opt,name=checksum,proto3" json:"checksum,omitempty"`                          // Frames failing their checksum (framer only)
+	RateLimited   uint64                 `protobuf:"varint,4,opt,name=rate_limited,json=rateLimited,proto3" json:"rate_limited,omitempty"` // Writes rejected by the port rate limit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorCounts) Reset() {
+	*x = ErrorCounts{}
+	mi := &file_serial_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorCounts) ProtoMessage() {}
+
+func (x *ErrorCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorCounts.ProtoReflect.Descriptor instead.
+func (*ErrorCounts) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *ErrorCounts) GetIo() uint64 {
+	if x != nil {
+		return x.Io
+	}
+	return 0
+}
+
+func (x *ErrorCounts) GetFraming() uint64 {
+	if x != nil {
+		return x.Framing
+	}
+	return 0
+}
+
+func (x *ErrorCounts) GetChecksum() uint64 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *ErrorCounts) GetRateLimited() uint64 {
+	if x != nil {
+		return x.RateLimited
+	}
+	return 0
+}
+
+type TestPortRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Pattern       []byte                 `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`                       // Data sent each iteration (default: 64 counting bytes)
+	Iterations    uint32                 `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`                // Number of round trips (default: 10)
+	TimeoutMs     uint32                 `protobuf:"varint,5,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"` // Per-iteration timeout (default: derived from baud rate)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestPortRequest) Reset() {
+	*x = TestPortRequest{}
+	mi := &file_serial_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestPortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestPortRequest) ProtoMessage() {}
+
+func (x *TestPortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestPortRequest.ProtoReflect.Descriptor instead.
+func (*TestPortRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *TestPortRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *TestPortRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TestPortRequest) GetPattern() []byte {
+	if x != nil {
+		return x.Pattern
+	}
+	return nil
+}
+
+func (x *TestPortRequest) GetIterations() uint32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *TestPortRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type TestPortReport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // All patterns returned intact
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Iterations     uint32                 `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	PacketsLost    uint32                 `protobuf:"varint,4,opt,name=packets_lost,json=packetsLost,proto3" json:"packets_lost,omitempty"` // Iterations that timed out
+	BytesSent      uint64                 `protobuf:"varint,5,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived  uint64                 `protobuf:"varint,6,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	ByteErrors     uint64                 `protobuf:"varint,7,opt,name=byte_errors,json=byteErrors,proto3" json:"byte_errors,omitempty"`         // Received bytes differing from the pattern
+	ErrorRate      float64                `protobuf:"fixed64,8,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`           // Fraction of bytes lost or corrupted
+	MinLatencyUs   int64                  `protobuf:"varint,9,opt,name=min_latency_us,json=minLatencyUs,proto3" json:"min_latency_us,omitempty"` // Round-trip latency in microseconds
+	AvgLatencyUs   int64                  `protobuf:"varint,10,opt,name=avg_latency_us,json=avgLatencyUs,proto3" json:"avg_latency_us,omitempty"`
+	MaxLatencyUs   int64                  `protobuf:"varint,11,opt,name=max_latency_us,json=maxLatencyUs,proto3" json:"max_latency_us,omitempty"`
+	ThroughputBps  float64                `protobuf:"fixed64,12,opt,name=throughput_bps,json=throughputBps,proto3" json:"throughput_bps,omitempty"`    // Measured bytes per second
+	TheoreticalBps float64                `protobuf:"fixed64,13,opt,name=theoretical_bps,json=theoreticalBps,proto3" json:"theoretical_bps,omitempty"` // Maximum bytes per second at the baud rate
+	BaudRate       uint32                 `protobuf:"varint,14,opt,name=baud_rate,json=baudRate,proto3" json:"baud_rate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestPortReport) Reset() {
+	*x = TestPortReport{}
+	mi := &file_serial_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestPortReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestPortReport) ProtoMessage() {}
+
+func (x *TestPortReport) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestPortReport.ProtoReflect.Descriptor instead.
+func (*TestPortReport) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *TestPortReport) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TestPortReport) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TestPortReport) GetIterations() uint32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetPacketsLost() uint32 {
+	if x != nil {
+		return x.PacketsLost
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetByteErrors() uint64 {
+	if x != nil {
+		return x.ByteErrors
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetMinLatencyUs() int64 {
+	if x != nil {
+		return x.MinLatencyUs
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetAvgLatencyUs() int64 {
+	if x != nil {
+		return x.AvgLatencyUs
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetMaxLatencyUs() int64 {
+	if x != nil {
+		return x.MaxLatencyUs
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetThroughputBps() float64 {
+	if x != nil {
+		return x.ThroughputBps
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetTheoreticalBps() float64 {
+	if x != nil {
+		return x.TheoreticalBps
+	}
+	return 0
+}
+
+func (x *TestPortReport) GetBaudRate() uint32 {
+	if x != nil {
+		return x.BaudRate
+	}
+	return 0
+}
+
+type IdentifyDeviceRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PortName         string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	ClientId         string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	BaudRates        []uint32               `protobuf:"varint,3,rep,packed,name=baud_rates,json=baudRates,proto3" json:"baud_rates,omitempty"`                 // Rates to try in order (default: 9600, 115200, 19200, 38400, 57600, 4800)
+	Probes           []string               `protobuf:"bytes,4,rep,name=probes,proto3" json:"probes,omitempty"`                                                // "nmea", "at", "scpi", "modbus" (default: all)
+	Config           *PortConfig            `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                                                // Line settings; baud_rate is ignored (default: 8N1)
+	TimeoutMs        uint32                 `protobuf:"varint,6,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`                        // Response timeout of each request (default: 500)
+	ListenMs         uint32                 `protobuf:"varint,7,opt,name=listen_ms,json=listenMs,proto3" json:"listen_ms,omitempty"`                           // Time spent listening for unsolicited data (default: 1500)
+	ModbusMaxAddress uint32                 `protobuf:"varint,8,opt,name=modbus_max_address,json=modbusMaxAddress,proto3" json:"modbus_max_address,omitempty"` // Highest Modbus slave address scanned (default: 10)
+	Exhaustive       bool                   `protobuf:"varint,9,opt,name=exhaustive,proto3" json:"exhaustive,omitempty"`                                       // Try every baud rate even after a confident match
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *IdentifyDeviceRequest) Reset() {
+	*x = IdentifyDeviceRequest{}
+	mi := &file_serial_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentifyDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifyDeviceRequest) ProtoMessage() {}
+
+func (x *IdentifyDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifyDeviceRequest.ProtoReflect.Descriptor instead.
+func (*IdentifyDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *IdentifyDeviceRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *IdentifyDeviceRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IdentifyDeviceRequest) GetBaudRates() []uint32 {
+	if x != nil {
+		return x.BaudRates
+	}
+	return nil
+}
+
+func (x *IdentifyDeviceRequest) GetProbes() []string {
+	if x != nil {
+		return x.Probes
+	}
+	return nil
+}
+
+func (x *IdentifyDeviceRequest) GetConfig() *PortConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *IdentifyDeviceRequest) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *IdentifyDeviceRequest) GetListenMs() uint32 {
+	if x != nil {
+		return x.ListenMs
+	}
+	return 0
+}
+
+func (x *IdentifyDeviceRequest) GetModbusMaxAddress() uint32 {
+	if x != nil {
+		return x.ModbusMaxAddress
+	}
+	return 0
+}
+
+func (x *IdentifyDeviceRequest) GetExhaustive() bool {
+	if x != nil {
+		return x.Exhaustive
+	}
+	return false
+}
+
+type IdentifyDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // A device was recognized
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Candidates    []*DeviceCandidate     `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`                        // Most likely first
+	BaudRates     []uint32               `protobuf:"varint,4,rep,packed,name=baud_rates,json=baudRates,proto3" json:"baud_rates,omitempty"` // Rates that were tried
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentifyDeviceResponse) Reset() {
+	*x = IdentifyDeviceResponse{}
+	mi := &file_serial_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentifyDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifyDeviceResponse) ProtoMessage() {}
+
+func (x *IdentifyDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifyDeviceResponse.ProtoReflect.Descriptor instead.
+func (*IdentifyDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *IdentifyDeviceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IdentifyDeviceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *IdentifyDeviceResponse) GetCandidates() []*DeviceCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *IdentifyDeviceResponse) GetBaudRates() []uint32 {
+	if x != nil {
+		return x.BaudRates
+	}
+	return nil
+}
+
+type DeviceCandidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protocol      string                 `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"` // "nmea", "at", "scpi", "modbus", or "text"
+	BaudRate      uint32                 `protobuf:"varint,2,opt,name=baud_rate,json=baudRate,proto3" json:"baud_rate,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0 to 1
+	Detail        string                 `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`           // e.g. the *IDN? reply or Modbus slave addresses
+	Response      []byte                 `protobuf:"bytes,5,opt,name=response,proto3" json:"response,omitempty"`       // Raw data the identification is based on
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceCandidate) Reset() {
+	*x = DeviceCandidate{}
+	mi := &file_serial_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceCandidate) ProtoMessage() {}
+
+func (x *DeviceCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceCandidate.ProtoReflect.Descriptor instead.
+func (*DeviceCandidate) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *DeviceCandidate) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *DeviceCandidate) GetBaudRate() uint32 {
+	if x != nil {
+		return x.BaudRate
+	}
+	return 0
+}
+
+func (x *DeviceCandidate) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *DeviceCandidate) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *DeviceCandidate) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type GetAgentInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentInfoRequest) Reset() {
+	*x = GetAgentInfoRequest{}
+	mi := &file_serial_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentInfoRequest) ProtoMessage() {}
+
+func (x *GetAgentInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentInfoRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{115}
+}
+
+type AgentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	BuildCommit   string                 `protobuf:"bytes,2,opt,name=build_commit,json=buildCommit,proto3" json:"build_commit,omitempty"`
+	BuildDate     string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	Os            string                 `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
+	Arch          string                 `protobuf:"bytes,5,opt,name=arch,proto3" json:"arch,omitempty"`
+	UptimeSeconds int64                  `protobuf:"varint,6,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// Deprecated: Marked as deprecated in serial.proto.
+	SupportedFeatures []string      `protobuf:"bytes,7,rep,name=supported_features,json=supportedFeatures,proto3" json:"supported_features,omitempty"` // Use capabilities
+	Config            *AgentConfig  `protobuf:"bytes,8,opt,name=config,proto3" json:"config,omitempty"`
+	Capabilities      []*Capability `protobuf:"bytes,9,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Limits            *AgentLimits  `protobuf:"bytes,10,opt,name=limits,proto3" json:"limits,omitempty"`
+	ApiVersions       []string      `protobuf:"bytes,11,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"` // API versions served, oldest first, e.g. v1
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AgentInfo) Reset() {
+	*x = AgentInfo{}
+	mi := &file_serial_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentInfo) ProtoMessage() {}
+
+func (x *AgentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentInfo.ProtoReflect.Descriptor instead.
+func (*AgentInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *AgentInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetBuildCommit() string {
+	if x != nil {
+		return x.BuildCommit
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in serial.proto.
+func (x *AgentInfo) GetSupportedFeatures() []string {
+	if x != nil {
+		return x.SupportedFeatures
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetConfig() *AgentConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetCapabilities() []*Capability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetLimits() *AgentLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetApiVersions() []string {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+type AgentConfig struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GrpcAddress    string                 `protobuf:"bytes,1,opt,name=grpc_address,json=grpcAddress,proto3" json:"grpc_address,omitempty"`
+	TlsEnabled     bool                   `protobuf:"varint,2,opt,name=tls_enabled,json=tlsEnabled,proto3" json:"tls_enabled,omitempty"`
+	MaxConnections uint32                 `protobuf:"varint,3,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentConfig) Reset() {
+	*x = AgentConfig{}
+	mi := &file_serial_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfig) ProtoMessage() {}
+
+func (x *AgentConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfig.ProtoReflect.Descriptor instead.
+func (*AgentConfig) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *AgentConfig) GetGrpcAddress() string {
+	if x != nil {
+		return x.GrpcAddress
+	}
+	return ""
+}
+
+func (x *AgentConfig) GetTlsEnabled() bool {
+	if x != nil {
+		return x.TlsEnabled
+	}
+	return false
+}
+
+func (x *AgentConfig) GetMaxConnections() uint32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+// Capability is one thing the agent supports, so clients can check for it
+// instead of guessing from the version string
+type Capability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"` // framing, checksum, filter, protocol, transport, or feature
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`         // Name as used in requests and configuration, e.g. nmea
+	Version       uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`  // Bumped when the capability changes incompatibly
+	Enabled       bool                   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`  // False when supported but turned off in the configuration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Capability) Reset() {
+	*x = Capability{}
+	mi := &file_serial_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability) ProtoMessage() {}
+
+func (x *Capability) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability.ProtoReflect.Descriptor instead.
+func (*Capability) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *Capability) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Capability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Capability) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Capability) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// AgentLimits are the largest requests the agent accepts
+type AgentLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxWriteBytes  uint32                 `protobuf:"varint,1,opt,name=max_write_bytes,json=maxWriteBytes,proto3" json:"max_write_bytes,omitempty"`  // Largest data field of a request
+	MaxChunkSize   uint32                 `protobuf:"varint,2,opt,name=max_chunk_size,json=maxChunkSize,proto3" json:"max_chunk_size,omitempty"`     // Largest chunk or read size a client may ask for
+	MaxConnections uint32                 `protobuf:"varint,3,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"` // Concurrent client connections
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentLimits) Reset() {
+	*x = AgentLimits{}
+	mi := &file_serial_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentLimits) ProtoMessage() {}
+
+func (x *AgentLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentLimits.ProtoReflect.Descriptor instead.
+func (*AgentLimits) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *AgentLimits) GetMaxWriteBytes() uint32 {
+	if x != nil {
+		return x.MaxWriteBytes
+	}
+	return 0
+}
+
+func (x *AgentLimits) GetMaxChunkSize() uint32 {
+	if x != nil {
+		return x.MaxChunkSize
+	}
+	return 0
+}
+
+func (x *AgentLimits) GetMaxConnections() uint32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+type CreateAccessLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                // Label identifying the guest
+	Ports         []string               `protobuf:"bytes,2,rep,name=ports,proto3" json:"ports,omitempty"`                              // Ports the guest may access (empty = all)
+	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`       // Deny writes and configuration changes
+	TtlSeconds    uint32                 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Lifetime of the link
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccessLinkRequest) Reset() {
+	*x = CreateAccessLinkRequest{}
+	mi := &file_serial_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccessLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccessLinkRequest) ProtoMessage() {}
+
+func (x *CreateAccessLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccessLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccessLinkRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *CreateAccessLinkRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccessLinkRequest) GetPorts() []string {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *CreateAccessLinkRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *CreateAccessLinkRequest) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type AccessLink struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Token            string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                               // Bearer token for the guest
+	ConnectionString string                 `protobuf:"bytes,2,opt,name=connection_string,json=connectionString,proto3" json:"connection_string,omitempty"` // baudlink://host:port?token=...
+	ExpiresAt        int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                     // Unix timestamp
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AccessLink) Reset() {
+	*x = AccessLink{}
+	mi := &file_serial_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessLink) ProtoMessage() {}
+
+func (x *AccessLink) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessLink.ProtoReflect.Descriptor instead.
+func (*AccessLink) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *AccessLink) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AccessLink) GetConnectionString() string {
+	if x != nil {
+		return x.ConnectionString
+	}
+	return ""
+}
+
+func (x *AccessLink) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_serial_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{122}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*SessionInfo         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_serial_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Exclusive     bool                   `protobuf:"varint,4,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	Managed       bool                   `protobuf:"varint,5,opt,name=managed,proto3" json:"managed,omitempty"` // Kept open by the agent
+	Priority      int32                  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	OpenedAt      int64                  `protobuf:"varint,7,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"` // Unix timestamp
+	UptimeSeconds int64                  `protobuf:"varint,8,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	BytesSent     uint64                 `protobuf:"varint,9,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived uint64                 `protobuf:"varint,10,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Errors        uint64                 `protobuf:"varint,11,opt,name=errors,proto3" json:"errors,omitempty"`
+	Subscribers   uint32                 `protobuf:"varint,12,opt,name=subscribers,proto3" json:"subscribers,omitempty"` // Active read streams and subscriptions
+	Attachments   uint32                 `protobuf:"varint,13,opt,name=attachments,proto3" json:"attachments,omitempty"` // Clients attached via AttachSession
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	mi := &file_serial_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *SessionInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+func (x *SessionInfo) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
+}
+
+func (x *SessionInfo) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetSubscribers() uint32 {
+	if x != nil {
+		return x.Subscribers
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetAttachments() uint32 {
+	if x != nil {
+		return x.Attachments
+	}
+	return 0
+}
+
+type ListClientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClientsRequest) Reset() {
+	*x = ListClientsRequest{}
+	mi := &file_serial_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientsRequest) ProtoMessage() {}
+
+func (x *ListClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListClientsRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{125}
+}
+
+type ListClientsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Clients             []*ClientInfo          `protobuf:"bytes,1,rep,name=clients,proto3" json:"clients,omitempty"` // Connections counted against the limit, oldest first
+	MaxConnections      uint32                 `protobuf:"varint,2,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	RejectedConnections uint64                 `protobuf:"varint,3,opt,name=rejected_connections,json=rejectedConnections,proto3" json:"rejected_connections,omitempty"` // Connections refused since the agent started
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListClientsResponse) Reset() {
+	*x = ListClientsResponse{}
+	mi := &file_serial_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClientsResponse) ProtoMessage() {}
+
+func (x *ListClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListClientsResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *ListClientsResponse) GetClients() []*ClientInfo {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *ListClientsResponse) GetMaxConnections() uint32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *ListClientsResponse) GetRejectedConnections() uint64 {
+	if x != nil {
+		return x.RejectedConnections
+	}
+	return 0
+}
+
+type ClientInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`                                   // Remote address
+	Identity      string                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`                           // Token name (empty when authentication is disabled)
+	ConnectedAt   int64                  `protobuf:"varint,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"` // Unix timestamp
+	LastRequest   int64                  `protobuf:"varint,4,opt,name=last_request,json=lastRequest,proto3" json:"last_request,omitempty"` // Unix timestamp (0 = none yet)
+	Requests      uint64                 `protobuf:"varint,5,opt,name=requests,proto3" json:"requests,omitempty"`
+	ActiveStreams uint32                 `protobuf:"varint,6,opt,name=active_streams,json=activeStreams,proto3" json:"active_streams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientInfo) Reset() {
+	*x = ClientInfo{}
+	mi := &file_serial_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientInfo) ProtoMessage() {}
+
+func (x *ClientInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientInfo.ProtoReflect.Descriptor instead.
+func (*ClientInfo) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ClientInfo) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *ClientInfo) GetConnectedAt() int64 {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return 0
+}
+
+func (x *ClientInfo) GetLastRequest() int64 {
+	if x != nil {
+		return x.LastRequest
+	}
+	return 0
+}
+
+func (x *ClientInfo) GetRequests() uint64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *ClientInfo) GetActiveStreams() uint32 {
+	if x != nil {
+		return x.ActiveStreams
+	}
+	return 0
+}
+
+type ForceCloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Recorded in the audit log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceCloseRequest) Reset() {
+	*x = ForceCloseRequest{}
+	mi := &file_serial_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceCloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceCloseRequest) ProtoMessage() {}
+
+func (x *ForceCloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceCloseRequest.ProtoReflect.Descriptor instead.
+func (*ForceCloseRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *ForceCloseRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ForceCloseRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceCloseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PortName      string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Port that was closed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceCloseResponse) Reset() {
+	*x = ForceCloseResponse{}
+	mi := &file_serial_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceCloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceCloseResponse) ProtoMessage() {}
+
+func (x *ForceCloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceCloseResponse.ProtoReflect.Descriptor instead.
+func (*ForceCloseResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *ForceCloseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ForceCloseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ForceCloseResponse) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+// ResetDeviceRequest resets the USB device behind a port as if it had been
+// unplugged and plugged back in
+type ResetDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Recorded in the audit log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetDeviceRequest) Reset() {
+	*x = ResetDeviceRequest{}
+	mi := &file_serial_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetDeviceRequest) ProtoMessage() {}
+
+func (x *ResetDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ResetDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *ResetDeviceRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *ResetDeviceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResetDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetDeviceResponse) Reset() {
+	*x = ResetDeviceResponse{}
+	mi := &file_serial_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetDeviceResponse) ProtoMessage() {}
+
+func (x *ResetDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetDeviceResponse.ProtoReflect.Descriptor instead.
+func (*ResetDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *ResetDeviceResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResetDeviceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         int64                  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`                      // Unix timestamp; 0 for the oldest retained entry
+	Until         int64                  `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`                      // Unix timestamp; 0 for now
+	PortName      string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Only entries for this port
+	Identity      string                 `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`                 // Only entries by this token identity
+	Operation     string                 `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`               // Only this RPC, e.g. "Write"
+	Limit         uint32                 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                      // Most recent entries to return (default 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogRequest) Reset() {
+	*x = GetAuditLogRequest{}
+	mi := &file_serial_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogRequest) ProtoMessage() {}
+
+func (x *GetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *GetAuditLogRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetPortName() string {
+	if x != nil {
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *StreamReadRequest) GetIncludeTimestamps() bool {
+type GetAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogResponse) Reset() {
+	*x = GetAuditLogResponse{}
+	mi := &file_serial_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogResponse) ProtoMessage() {}
+
+func (x *GetAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *GetAuditLogResponse) GetEntries() []*AuditEntry {
 	if x != nil {
-		return x.IncludeTimestamps
+		return x.Entries
 	}
-	return false
+	return nil
 }
 
-type DataChunk struct {
+type AuditEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PortName      string                 `protobuf:"bytes,1,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp in nanoseconds
-	Sequence      uint32                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`   // Sequence number for ordering
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix nanoseconds
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`  // RPC name, e.g. "OpenPort"
+	Identity      string                 `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`    // Token identity, or "anonymous"
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Peer          string                 `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"` // Remote address of the caller
+	PortName      string                 `protobuf:"bytes,6,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	SessionId     string                 `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Bytes         uint64                 `protobuf:"varint,8,opt,name=bytes,proto3" json:"bytes,omitempty"` // Bytes written to the port
+	Success       bool                   `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"` // Result or error message
+	Reason        string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`   // Reason given for TakeOver and ForceClose
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DataChunk) Reset() {
-	*x = DataChunk{}
-	mi := &file_serial_proto_msgTypes[20]
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_serial_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DataChunk) String() string {
+func (x *AuditEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataChunk) ProtoMessage() {}
+func (*AuditEntry) ProtoMessage() {}
 
-func (x *DataChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[20]
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1607,64 +10420,114 @@ func (x *DataChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
-func (*DataChunk) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{134}
 }
 
-func (x *DataChunk) GetPortName() string {
+func (x *AuditEntry) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPortName() string {
 	if x != nil {
 		return x.PortName
 	}
 	return ""
 }
 
-func (x *DataChunk) GetData() []byte {
+func (x *AuditEntry) GetSessionId() string {
 	if x != nil {
-		return x.Data
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-func (x *DataChunk) GetTimestamp() int64 {
+func (x *AuditEntry) GetBytes() uint64 {
 	if x != nil {
-		return x.Timestamp
+		return x.Bytes
 	}
 	return 0
 }
 
-func (x *DataChunk) GetSequence() uint32 {
+func (x *AuditEntry) GetSuccess() bool {
 	if x != nil {
-		return x.Sequence
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-type StreamWriteResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	TotalBytesWritten uint64                 `protobuf:"varint,2,opt,name=total_bytes_written,json=totalBytesWritten,proto3" json:"total_bytes_written,omitempty"`
-	ChunksProcessed   uint32                 `protobuf:"varint,3,opt,name=chunks_processed,json=chunksProcessed,proto3" json:"chunks_processed,omitempty"`
-	Message           string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *AuditEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *StreamWriteResponse) Reset() {
-	*x = StreamWriteResponse{}
-	mi := &file_serial_proto_msgTypes[21]
+func (x *AuditEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetSessionHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         int64                  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`                      // Unix timestamp; only sessions open at or after it
+	Until         int64                  `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`                      // Unix timestamp; only sessions opened at or before it
+	PortName      string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"` // Only sessions on this port
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"` // Only sessions owned by this client
+	Limit         uint32                 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                      // Most recently opened sessions to return (default 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSessionHistoryRequest) Reset() {
+	*x = GetSessionHistoryRequest{}
+	mi := &file_serial_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamWriteResponse) String() string {
+func (x *GetSessionHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamWriteResponse) ProtoMessage() {}
+func (*GetSessionHistoryRequest) ProtoMessage() {}
 
-func (x *StreamWriteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[21]
+func (x *GetSessionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1675,61 +10538,69 @@ func (x *StreamWriteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamWriteResponse.ProtoReflect.Descriptor instead.
-func (*StreamWriteResponse) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetSessionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{135}
 }
 
-func (x *StreamWriteResponse) GetSuccess() bool {
+func (x *GetSessionHistoryRequest) GetSince() int64 {
 	if x != nil {
-		return x.Success
+		return x.Since
 	}
-	return false
+	return 0
 }
 
-func (x *StreamWriteResponse) GetTotalBytesWritten() uint64 {
+func (x *GetSessionHistoryRequest) GetUntil() int64 {
 	if x != nil {
-		return x.TotalBytesWritten
+		return x.Until
 	}
 	return 0
 }
 
-func (x *StreamWriteResponse) GetChunksProcessed() uint32 {
+func (x *GetSessionHistoryRequest) GetPortName() string {
 	if x != nil {
-		return x.ChunksProcessed
+		return x.PortName
 	}
-	return 0
+	return ""
 }
 
-func (x *StreamWriteResponse) GetMessage() string {
+func (x *GetSessionHistoryRequest) GetClientId() string {
 	if x != nil {
-		return x.Message
+		return x.ClientId
 	}
 	return ""
 }
 
-type PingRequest struct {
+func (x *GetSessionHistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetSessionHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Sessions      []*SessionRecord       `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"` // Oldest first
+	Totals        *SessionTotals         `protobuf:"bytes,2,opt,name=totals,proto3" json:"totals,omitempty"`     // Sums over every matching session, not only those returned
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
-	mi := &file_serial_proto_msgTypes[22]
+func (x *GetSessionHistoryResponse) Reset() {
+	*x = GetSessionHistoryResponse{}
+	mi := &file_serial_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingRequest) String() string {
+func (x *GetSessionHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*GetSessionHistoryResponse) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[22]
+func (x *GetSessionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1740,41 +10611,59 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use GetSessionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetSessionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{136}
 }
 
-func (x *PingRequest) GetMessage() string {
+func (x *GetSessionHistoryResponse) GetSessions() []*SessionRecord {
 	if x != nil {
-		return x.Message
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-type PingResponse struct {
+func (x *GetSessionHistoryResponse) GetTotals() *SessionTotals {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+// SessionRecord is a session the agent had open, kept across restarts
+type SessionRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	ServerTime    int64                  `protobuf:"varint,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // Unix timestamp
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	PortName      string                 `protobuf:"bytes,2,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Exclusive     bool                   `protobuf:"varint,4,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	Managed       bool                   `protobuf:"varint,5,opt,name=managed,proto3" json:"managed,omitempty"`
+	OpenedAt      int64                  `protobuf:"varint,6,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"` // Unix nanoseconds
+	ClosedAt      int64                  `protobuf:"varint,7,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"` // Unix nanoseconds; 0 while the session is open
+	BytesSent     uint64                 `protobuf:"varint,8,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived uint64                 `protobuf:"varint,9,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Errors        uint64                 `protobuf:"varint,10,opt,name=errors,proto3" json:"errors,omitempty"`
+	Interrupted   bool                   `protobuf:"varint,11,opt,name=interrupted,proto3" json:"interrupted,omitempty"` // The agent stopped without closing the session
+	Sniff         bool                   `protobuf:"varint,12,opt,name=sniff,proto3" json:"sniff,omitempty"`             // Opened to observe only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
-	mi := &file_serial_proto_msgTypes[23]
+func (x *SessionRecord) Reset() {
+	*x = SessionRecord{}
+	mi := &file_serial_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingResponse) String() string {
+func (x *SessionRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResponse) ProtoMessage() {}
+func (*SessionRecord) ProtoMessage() {}
 
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[23]
+func (x *SessionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1785,46 +10674,120 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use SessionRecord.ProtoReflect.Descriptor instead.
+func (*SessionRecord) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{137}
 }
 
-func (x *PingResponse) GetMessage() string {
+func (x *SessionRecord) GetSessionId() string {
 	if x != nil {
-		return x.Message
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *PingResponse) GetServerTime() int64 {
+func (x *SessionRecord) GetPortName() string {
 	if x != nil {
-		return x.ServerTime
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *SessionRecord) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SessionRecord) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+func (x *SessionRecord) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
+}
+
+func (x *SessionRecord) GetOpenedAt() int64 {
+	if x != nil {
+		return x.OpenedAt
 	}
 	return 0
 }
 
-type GetAgentInfoRequest struct {
+func (x *SessionRecord) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return 0
+}
+
+func (x *SessionRecord) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *SessionRecord) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *SessionRecord) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *SessionRecord) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
+func (x *SessionRecord) GetSniff() bool {
+	if x != nil {
+		return x.Sniff
+	}
+	return false
+}
+
+type SessionTotals struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      uint64                 `protobuf:"varint,1,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	BytesSent     uint64                 `protobuf:"varint,2,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived uint64                 `protobuf:"varint,3,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Errors        uint64                 `protobuf:"varint,4,opt,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAgentInfoRequest) Reset() {
-	*x = GetAgentInfoRequest{}
-	mi := &file_serial_proto_msgTypes[24]
+func (x *SessionTotals) Reset() {
+	*x = SessionTotals{}
+	mi := &file_serial_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentInfoRequest) String() string {
+func (x *SessionTotals) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentInfoRequest) ProtoMessage() {}
+func (*SessionTotals) ProtoMessage() {}
 
-func (x *GetAgentInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[24]
+func (x *SessionTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,40 +10798,65 @@ func (x *GetAgentInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetAgentInfoRequest) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SessionTotals.ProtoReflect.Descriptor instead.
+func (*SessionTotals) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{138}
 }
 
-type AgentInfo struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Version           string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	BuildCommit       string                 `protobuf:"bytes,2,opt,name=build_commit,json=buildCommit,proto3" json:"build_commit,omitempty"`
-	BuildDate         string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
-	Os                string                 `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
-	Arch              string                 `protobuf:"bytes,5,opt,name=arch,proto3" json:"arch,omitempty"`
-	UptimeSeconds     int64                  `protobuf:"varint,6,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
-	SupportedFeatures []string               `protobuf:"bytes,7,rep,name=supported_features,json=supportedFeatures,proto3" json:"supported_features,omitempty"`
-	Config            *AgentConfig           `protobuf:"bytes,8,opt,name=config,proto3" json:"config,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *SessionTotals) GetSessions() uint64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
 }
 
-func (x *AgentInfo) Reset() {
-	*x = AgentInfo{}
-	mi := &file_serial_proto_msgTypes[25]
+func (x *SessionTotals) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *SessionTotals) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *SessionTotals) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+type GetCaptureIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         int64                  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`                         // Unix timestamp; only captures running at or after it
+	Until         int64                  `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`                         // Unix timestamp; only captures started at or before it
+	PortName      string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`    // Only captures of this port
+	SessionId     string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Only captures of this session
+	Limit         uint32                 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                         // Most recently started captures to return (default 1000)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCaptureIndexRequest) Reset() {
+	*x = GetCaptureIndexRequest{}
+	mi := &file_serial_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentInfo) String() string {
+func (x *GetCaptureIndexRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentInfo) ProtoMessage() {}
+func (*GetCaptureIndexRequest) ProtoMessage() {}
 
-func (x *AgentInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[25]
+func (x *GetCaptureIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1879,91 +10867,121 @@ func (x *AgentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentInfo.ProtoReflect.Descriptor instead.
-func (*AgentInfo) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use GetCaptureIndexRequest.ProtoReflect.Descriptor instead.
+func (*GetCaptureIndexRequest) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{139}
 }
 
-func (x *AgentInfo) GetVersion() string {
+func (x *GetCaptureIndexRequest) GetSince() int64 {
 	if x != nil {
-		return x.Version
+		return x.Since
 	}
-	return ""
+	return 0
 }
 
-func (x *AgentInfo) GetBuildCommit() string {
+func (x *GetCaptureIndexRequest) GetUntil() int64 {
 	if x != nil {
-		return x.BuildCommit
+		return x.Until
 	}
-	return ""
+	return 0
 }
 
-func (x *AgentInfo) GetBuildDate() string {
+func (x *GetCaptureIndexRequest) GetPortName() string {
 	if x != nil {
-		return x.BuildDate
+		return x.PortName
 	}
 	return ""
 }
 
-func (x *AgentInfo) GetOs() string {
+func (x *GetCaptureIndexRequest) GetSessionId() string {
 	if x != nil {
-		return x.Os
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *AgentInfo) GetArch() string {
+func (x *GetCaptureIndexRequest) GetLimit() uint32 {
 	if x != nil {
-		return x.Arch
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *AgentInfo) GetUptimeSeconds() int64 {
-	if x != nil {
-		return x.UptimeSeconds
-	}
-	return 0
+type GetCaptureIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Captures      []*CaptureRecord       `protobuf:"bytes,1,rep,name=captures,proto3" json:"captures,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentInfo) GetSupportedFeatures() []string {
+func (x *GetCaptureIndexResponse) Reset() {
+	*x = GetCaptureIndexResponse{}
+	mi := &file_serial_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCaptureIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCaptureIndexResponse) ProtoMessage() {}
+
+func (x *GetCaptureIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[140]
 	if x != nil {
-		return x.SupportedFeatures
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AgentInfo) GetConfig() *AgentConfig {
+// Deprecated: Use GetCaptureIndexResponse.ProtoReflect.Descriptor instead.
+func (*GetCaptureIndexResponse) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *GetCaptureIndexResponse) GetCaptures() []*CaptureRecord {
 	if x != nil {
-		return x.Config
+		return x.Captures
 	}
 	return nil
 }
 
-type AgentConfig struct {
+// CaptureRecord is a tap's capture of a session's traffic
+type CaptureRecord struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	GrpcAddress    string                 `protobuf:"bytes,1,opt,name=grpc_address,json=grpcAddress,proto3" json:"grpc_address,omitempty"`
-	TlsEnabled     bool                   `protobuf:"varint,2,opt,name=tls_enabled,json=tlsEnabled,proto3" json:"tls_enabled,omitempty"`
-	MaxConnections uint32                 `protobuf:"varint,3,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	TapId          string                 `protobuf:"bytes,1,opt,name=tap_id,json=tapId,proto3" json:"tap_id,omitempty"`
+	SessionId      string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	PortName       string                 `protobuf:"bytes,3,opt,name=port_name,json=portName,proto3" json:"port_name,omitempty"`
+	Config         *TapConfig             `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                         // Where the traffic was mirrored to
+	StartedAt      int64                  `protobuf:"varint,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // Unix nanoseconds
+	StoppedAt      int64                  `protobuf:"varint,6,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"` // Unix nanoseconds; 0 while the tap is running
+	BytesWritten   uint64                 `protobuf:"varint,7,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	RecordsDropped uint64                 `protobuf:"varint,8,opt,name=records_dropped,json=recordsDropped,proto3" json:"records_dropped,omitempty"`
+	Interrupted    bool                   `protobuf:"varint,9,opt,name=interrupted,proto3" json:"interrupted,omitempty"` // The agent stopped without removing the tap
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *AgentConfig) Reset() {
-	*x = AgentConfig{}
-	mi := &file_serial_proto_msgTypes[26]
+func (x *CaptureRecord) Reset() {
+	*x = CaptureRecord{}
+	mi := &file_serial_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentConfig) String() string {
+func (x *CaptureRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentConfig) ProtoMessage() {}
+func (*CaptureRecord) ProtoMessage() {}
 
-func (x *AgentConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_serial_proto_msgTypes[26]
+func (x *CaptureRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_serial_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1974,43 +10992,89 @@ func (x *AgentConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentConfig.ProtoReflect.Descriptor instead.
-func (*AgentConfig) Descriptor() ([]byte, []int) {
-	return file_serial_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use CaptureRecord.ProtoReflect.Descriptor instead.
+func (*CaptureRecord) Descriptor() ([]byte, []int) {
+	return file_serial_proto_rawDescGZIP(), []int{141}
 }
 
-func (x *AgentConfig) GetGrpcAddress() string {
+func (x *CaptureRecord) GetTapId() string {
 	if x != nil {
-		return x.GrpcAddress
+		return x.TapId
 	}
 	return ""
 }
 
-func (x *AgentConfig) GetTlsEnabled() bool {
+func (x *CaptureRecord) GetSessionId() string {
 	if x != nil {
-		return x.TlsEnabled
+		return x.SessionId
 	}
-	return false
+	return ""
 }
 
-func (x *AgentConfig) GetMaxConnections() uint32 {
+func (x *CaptureRecord) GetPortName() string {
 	if x != nil {
-		return x.MaxConnections
+		return x.PortName
+	}
+	return ""
+}
+
+func (x *CaptureRecord) GetConfig() *TapConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CaptureRecord) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *CaptureRecord) GetStoppedAt() int64 {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return 0
+}
+
+func (x *CaptureRecord) GetBytesWritten() uint64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *CaptureRecord) GetRecordsDropped() uint64 {
+	if x != nil {
+		return x.RecordsDropped
 	}
 	return 0
 }
 
+func (x *CaptureRecord) GetInterrupted() bool {
+	if x != nil {
+		return x.Interrupted
+	}
+	return false
+}
+
 var File_serial_proto protoreflect.FileDescriptor
 
 const file_serial_proto_rawDesc = "" +
 	"\n" +
-	"\fserial.proto\x12\x12baudlink.serial.v1\"9\n" +
+	"\fserial.proto\x12\x12baudlink.serial.v1\"}\n" +
 	"\x10ListPortsRequest\x12%\n" +
-	"\x0eonly_available\x18\x01 \x01(\bR\ronlyAvailable\"G\n" +
+	"\x0eonly_available\x18\x01 \x01(\bR\ronlyAvailable\x12(\n" +
+	"\x10if_changed_since\x18\x02 \x01(\tR\x0eifChangedSince\x12\x18\n" +
+	"\arefresh\x18\x03 \x01(\bR\arefresh\"~\n" +
 	"\x11ListPortsResponse\x122\n" +
-	"\x05ports\x18\x01 \x03(\v2\x1c.baudlink.serial.v1.PortInfoR\x05ports\"1\n" +
+	"\x05ports\x18\x01 \x03(\v2\x1c.baudlink.serial.v1.PortInfoR\x05ports\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12!\n" +
+	"\fnot_modified\x18\x03 \x01(\bR\vnotModified\"1\n" +
 	"\x12GetPortInfoRequest\x12\x1b\n" +
-	"\tport_name\x18\x01 \x01(\tR\bportName\"\xb5\x02\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\"\xae\x05\n" +
 	"\bPortInfo\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
@@ -2021,17 +11085,49 @@ const file_serial_proto_rawDesc = "" +
 	"\rserial_number\x18\x06 \x01(\tR\fserialNumber\x129\n" +
 	"\tport_type\x18\a \x01(\x0e2\x1c.baudlink.serial.v1.PortTypeR\bportType\x12\x17\n" +
 	"\ais_open\x18\b \x01(\bR\x06isOpen\x12\x1b\n" +
-	"\tlocked_by\x18\t \x01(\tR\blockedBy\"\xa1\x01\n" +
+	"\tlocked_by\x18\t \x01(\tR\blockedBy\x12\x18\n" +
+	"\aprofile\x18\n" +
+	" \x01(\tR\aprofile\x12\x14\n" +
+	"\x05alias\x18\v \x01(\tR\x05alias\x12\x16\n" +
+	"\x06driver\x18\f \x01(\tR\x06driver\x12\x19\n" +
+	"\bbus_path\x18\r \x01(\tR\abusPath\x12#\n" +
+	"\rusb_interface\x18\x0e \x01(\tR\fusbInterface\x12\x1f\n" +
+	"\vdevice_path\x18\x0f \x01(\tR\n" +
+	"devicePath\x12#\n" +
+	"\rfriendly_name\x18\x10 \x01(\tR\ffriendlyName\x12L\n" +
+	"\n" +
+	"properties\x18\x11 \x03(\v2,.baudlink.serial.v1.PortInfo.PropertiesEntryR\n" +
+	"properties\x12\x1c\n" +
+	"\tclaimants\x18\x12 \x03(\tR\tclaimants\x1a=\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x03\n" +
 	"\x0fOpenPortRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x126\n" +
 	"\x06config\x18\x02 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\x12\x1b\n" +
 	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1c\n" +
-	"\texclusive\x18\x04 \x01(\bR\texclusive\"e\n" +
+	"\texclusive\x18\x04 \x01(\bR\texclusive\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x125\n" +
+	"\x05retry\x18\x06 \x01(\v2\x1f.baudlink.serial.v1.RetryPolicyR\x05retry\x12\x1c\n" +
+	"\treconnect\x18\a \x01(\bR\treconnect\x121\n" +
+	"\x04taps\x18\b \x03(\v2\x1d.baudlink.serial.v1.TapConfigR\x04taps\x120\n" +
+	"\x04mode\x18\t \x01(\x0e2\x1c.baudlink.serial.v1.OpenModeR\x04mode\x12\x17\n" +
+	"\await_ms\x18\n" +
+	" \x01(\rR\x06waitMs\"~\n" +
+	"\vRetryPolicy\x12\x1a\n" +
+	"\battempts\x18\x01 \x01(\rR\battempts\x12\x19\n" +
+	"\bdelay_ms\x18\x02 \x01(\rR\adelayMs\x12 \n" +
+	"\fmax_delay_ms\x18\x03 \x01(\rR\n" +
+	"maxDelayMs\x12\x16\n" +
+	"\x06jitter\x18\x04 \x01(\x01R\x06jitter\"\xb8\x01\n" +
 	"\x10OpenPortResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x03 \x01(\tR\tsessionId\"N\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x18\n" +
+	"\aprofile\x18\x04 \x01(\tR\aprofile\x12\x1b\n" +
+	"\tread_only\x18\x05 \x01(\bR\breadOnly\x12\x1a\n" +
+	"\battached\x18\x06 \x01(\bR\battached\"N\n" +
 	"\x10ClosePortRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
 	"\n" +
@@ -2040,7 +11136,7 @@ const file_serial_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
 	"\x14GetPortStatusRequest\x12\x1b\n" +
-	"\tport_name\x18\x01 \x01(\tR\bportName\"\xa6\x02\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\"\xea\x04\n" +
 	"\n" +
 	"PortStatus\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x17\n" +
@@ -2052,14 +11148,88 @@ const file_serial_proto_rawDesc = "" +
 	"\x0ecurrent_config\x18\x06 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\rcurrentConfig\x12B\n" +
 	"\n" +
 	"statistics\x18\a \x01(\v2\".baudlink.serial.v1.PortStatisticsR\n" +
-	"statistics\"\xb0\x01\n" +
+	"statistics\x12\x18\n" +
+	"\amanaged\x18\b \x01(\bR\amanaged\x12\"\n" +
+	"\fdisconnected\x18\t \x01(\bR\fdisconnected\x12%\n" +
+	"\x0ebuffered_bytes\x18\n" +
+	" \x01(\rR\rbufferedBytes\x12D\n" +
+	"\vattachments\x18\v \x03(\v2\".baudlink.serial.v1.AttachmentInfoR\vattachments\x12\x1c\n" +
+	"\treconnect\x18\f \x01(\bR\treconnect\x12/\n" +
+	"\x04taps\x18\r \x03(\v2\x1b.baudlink.serial.v1.TapInfoR\x04taps\x122\n" +
+	"\x04flow\x18\x0e \x01(\v2\x1e.baudlink.serial.v1.FlowStatusR\x04flow\x12\x14\n" +
+	"\x05sniff\x18\x0f \x01(\bR\x05sniff\"Z\n" +
+	"\n" +
+	"FlowStatus\x12\x10\n" +
+	"\x03cts\x18\x01 \x01(\bR\x03cts\x12\x10\n" +
+	"\x03dsr\x18\x02 \x01(\bR\x03dsr\x12\x10\n" +
+	"\x03dcd\x18\x03 \x01(\bR\x03dcd\x12\x16\n" +
+	"\x06paused\x18\x04 \x01(\bR\x06paused\"\x85\x01\n" +
+	"\x14AttachSessionRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x123\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1f.baudlink.serial.v1.SessionRoleR\x04role\"\x8f\x01\n" +
+	"\x15AttachSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rattachment_id\x18\x03 \x01(\tR\fattachmentId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\"X\n" +
+	"\x14DetachSessionRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\tR\fattachmentId\"K\n" +
+	"\x15DetachSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9d\x01\n" +
+	"\x0fTakeOverRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1c\n" +
+	"\texclusive\x18\x03 \x01(\bR\texclusive\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\x93\x01\n" +
+	"\x10TakeOverResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12,\n" +
+	"\x12previous_client_id\x18\x04 \x01(\tR\x10previousClientId\"4\n" +
+	"\x15GetPortHoldersRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\"R\n" +
+	"\x16GetPortHoldersResponse\x128\n" +
+	"\aholders\x18\x01 \x03(\v2\x1e.baudlink.serial.v1.PortHolderR\aholders\"\xb2\x02\n" +
+	"\n" +
+	"PortHolder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x123\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1f.baudlink.serial.v1.SessionRoleR\x04role\x12\x16\n" +
+	"\x06opener\x18\x04 \x01(\bR\x06opener\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\x03R\x05since\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x06 \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\a \x01(\x04R\rbytesReceived\x12#\n" +
+	"\rqueued_writes\x18\b \x01(\rR\fqueuedWrites\x12)\n" +
+	"\x10quota_rejections\x18\t \x01(\x04R\x0fquotaRejections\"\xee\x01\n" +
+	"\x0eAttachmentInfo\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x123\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x1f.baudlink.serial.v1.SessionRoleR\x04role\x12\x1f\n" +
+	"\vattached_at\x18\x04 \x01(\x03R\n" +
+	"attachedAt\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x05 \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x06 \x01(\x04R\rbytesReceived\"\xf6\x02\n" +
 	"\x0ePortStatistics\x12\x1d\n" +
 	"\n" +
 	"bytes_sent\x18\x01 \x01(\x04R\tbytesSent\x12%\n" +
 	"\x0ebytes_received\x18\x02 \x01(\x04R\rbytesReceived\x12\x16\n" +
 	"\x06errors\x18\x03 \x01(\x04R\x06errors\x12\x1b\n" +
 	"\topened_at\x18\x04 \x01(\x03R\bopenedAt\x12#\n" +
-	"\rlast_activity\x18\x05 \x01(\x03R\flastActivity\"\xe9\x02\n" +
+	"\rlast_activity\x18\x05 \x01(\x03R\flastActivity\x12#\n" +
+	"\rframes_parsed\x18\x06 \x01(\x04R\fframesParsed\x12%\n" +
+	"\x0eframing_errors\x18\a \x01(\x04R\rframingErrors\x12'\n" +
+	"\x0fchecksum_errors\x18\b \x01(\x04R\x0echecksumErrors\x12,\n" +
+	"\x12average_frame_size\x18\t \x01(\x01R\x10averageFrameSize\x12!\n" +
+	"\fhealth_score\x18\n" +
+	" \x01(\x01R\vhealthScore\"\x82\x06\n" +
 	"\n" +
 	"PortConfig\x12\x1b\n" +
 	"\tbaud_rate\x18\x01 \x01(\rR\bbaudRate\x129\n" +
@@ -2068,7 +11238,35 @@ const file_serial_proto_rawDesc = "" +
 	"\x06parity\x18\x04 \x01(\x0e2\x1a.baudlink.serial.v1.ParityR\x06parity\x12B\n" +
 	"\fflow_control\x18\x05 \x01(\x0e2\x1f.baudlink.serial.v1.FlowControlR\vflowControl\x12&\n" +
 	"\x0fread_timeout_ms\x18\x06 \x01(\rR\rreadTimeoutMs\x12(\n" +
-	"\x10write_timeout_ms\x18\a \x01(\rR\x0ewriteTimeoutMs\"\x8a\x01\n" +
+	"\x10write_timeout_ms\x18\a \x01(\rR\x0ewriteTimeoutMs\x12\x16\n" +
+	"\x06framer\x18\b \x01(\tR\x06framer\x12(\n" +
+	"\x10write_chunk_size\x18\t \x01(\rR\x0ewriteChunkSize\x12/\n" +
+	"\x14write_chunk_delay_ms\x18\n" +
+	" \x01(\rR\x11writeChunkDelayMs\x12-\n" +
+	"\x13write_line_delay_ms\x18\v \x01(\rR\x10writeLineDelayMs\x125\n" +
+	"\x05rs485\x18\f \x01(\v2\x1f.baudlink.serial.v1.RS485ConfigR\x05rs485\x12>\n" +
+	"\bchecksum\x18\r \x01(\v2\".baudlink.serial.v1.ChecksumConfigR\bchecksum\x12!\n" +
+	"\fread_filters\x18\x0e \x03(\tR\vreadFilters\x12#\n" +
+	"\rwrite_filters\x18\x0f \x03(\tR\fwriteFilters\x12\x19\n" +
+	"\bxon_char\x18\x10 \x01(\rR\axonChar\x12\x1b\n" +
+	"\txoff_char\x18\x11 \x01(\rR\bxoffChar\"\xad\x01\n" +
+	"\vRS485Config\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12$\n" +
+	"\x0erts_active_low\x18\x02 \x01(\bR\frtsActiveLow\x12/\n" +
+	"\x14delay_before_send_ms\x18\x03 \x01(\rR\x11delayBeforeSendMs\x12-\n" +
+	"\x13delay_after_send_ms\x18\x04 \x01(\rR\x10delayAfterSendMs\"\xbd\x01\n" +
+	"\x0eChecksumConfig\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x1e\n" +
+	"\n" +
+	"polynomial\x18\x02 \x01(\rR\n" +
+	"polynomial\x12\x1c\n" +
+	"\tplacement\x18\x03 \x01(\tR\tplacement\x12\x1e\n" +
+	"\n" +
+	"terminator\x18\x04 \x01(\fR\n" +
+	"terminator\x12\x1d\n" +
+	"\n" +
+	"big_endian\x18\x05 \x01(\bR\tbigEndian\x12\x10\n" +
+	"\x03hex\x18\x06 \x01(\bR\x03hex\"\x8a\x01\n" +
 	"\x14ConfigurePortRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
 	"\n" +
@@ -2078,17 +11276,103 @@ const file_serial_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
 	"\x14GetPortConfigRequest\x12\x1b\n" +
-	"\tport_name\x18\x01 \x01(\tR\bportName\"t\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\"\x95\x01\n" +
+	"\tTapConfig\x12>\n" +
+	"\tdirection\x18\x01 \x01(\x0e2 .baudlink.serial.v1.TapDirectionR\tdirection\x12$\n" +
+	"\x0emirror_to_file\x18\x02 \x01(\tR\fmirrorToFile\x12\"\n" +
+	"\rmirror_to_tcp\x18\x03 \x01(\tR\vmirrorToTcp\"\xa5\x01\n" +
+	"\aTapInfo\x12\x15\n" +
+	"\x06tap_id\x18\x01 \x01(\tR\x05tapId\x125\n" +
+	"\x06config\x18\x02 \x01(\v2\x1d.baudlink.serial.v1.TapConfigR\x06config\x12#\n" +
+	"\rbytes_written\x18\x03 \x01(\x04R\fbytesWritten\x12'\n" +
+	"\x0frecords_dropped\x18\x04 \x01(\x04R\x0erecordsDropped\"|\n" +
+	"\rAddTapRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12/\n" +
+	"\x03tap\x18\x03 \x01(\v2\x1d.baudlink.serial.v1.TapConfigR\x03tap\"[\n" +
+	"\x0eAddTapResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x15\n" +
+	"\x06tap_id\x18\x03 \x01(\tR\x05tapId\"e\n" +
+	"\x10RemoveTapRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x15\n" +
+	"\x06tap_id\x18\x03 \x01(\tR\x05tapId\"G\n" +
+	"\x11RemoveTapResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x88\x01\n" +
+	"\x17StartPassthroughRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x10\n" +
+	"\x03pty\x18\x03 \x01(\bR\x03pty\x12\x1f\n" +
+	"\vtcp_address\x18\x04 \x01(\tR\n" +
+	"tcpAddress\"h\n" +
+	"\x18StartPassthroughResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"T\n" +
+	"\x16StopPassthroughRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\x85\x01\n" +
+	"\x17StopPassthroughResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bbytes_in\x18\x03 \x01(\x04R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\x04 \x01(\x04R\bbytesOut\"\xb5\x03\n" +
+	"\x11ProxyPortsRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12$\n" +
+	"\x0epeer_port_name\x18\x02 \x01(\tR\fpeerPortName\x126\n" +
+	"\x06config\x18\x03 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\x12?\n" +
+	"\vpeer_config\x18\x04 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\n" +
+	"peerConfig\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\x12#\n" +
+	"\x0efilters_a_to_b\x18\x06 \x03(\tR\vfiltersAToB\x12#\n" +
+	"\x0efilters_b_to_a\x18\a \x03(\tR\vfiltersBToA\x12$\n" +
+	"\x0fdelay_a_to_b_ms\x18\b \x01(\rR\vdelayAToBMs\x12$\n" +
+	"\x0fdelay_b_to_a_ms\x18\t \x01(\rR\vdelayBToAMs\x121\n" +
+	"\x04taps\x18\n" +
+	" \x03(\v2\x1d.baudlink.serial.v1.TapConfigR\x04taps\"\xa7\x02\n" +
+	"\n" +
+	"ProxyEvent\x12@\n" +
+	"\tdirection\x18\x01 \x01(\x0e2\".baudlink.serial.v1.ProxyDirectionR\tdirection\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1c\n" +
+	"\tforwarded\x18\x03 \x01(\fR\tforwarded\x12!\n" +
+	"\ffilter_error\x18\x04 \x01(\tR\vfilterError\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x04R\bsequence\x12\x12\n" +
+	"\x04lost\x18\a \x01(\x04R\x04lost\x12\x18\n" +
+	"\astarted\x18\b \x01(\bR\astarted\x12\x1a\n" +
+	"\bshutdown\x18\t \x01(\bR\bshutdown\"\x9b\x01\n" +
 	"\fWriteRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12\x14\n" +
-	"\x05flush\x18\x04 \x01(\bR\x05flush\"h\n" +
+	"\x05flush\x18\x04 \x01(\bR\x05flush\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\"\x8f\x01\n" +
 	"\rWriteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
 	"\rbytes_written\x18\x02 \x01(\rR\fbytesWritten\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x85\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12%\n" +
+	"\x0ecorrelation_id\x18\x04 \x01(\tR\rcorrelationId\"\xc1\x01\n" +
+	"\x11QueueWriteRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12\x19\n" +
+	"\bdelay_ms\x18\x06 \x01(\rR\adelayMs\"\x86\x01\n" +
+	"\x12QueueWriteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\x12\x1f\n" +
+	"\vqueue_depth\x18\x03 \x01(\rR\n" +
+	"queueDepth\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x85\x01\n" +
 	"\vReadRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
 	"\n" +
@@ -2101,31 +11385,453 @@ const file_serial_proto_rawDesc = "" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1d\n" +
 	"\n" +
 	"bytes_read\x18\x03 \x01(\rR\tbytesRead\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\"\x9d\x01\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\x82\x02\n" +
+	"\x0fTransactRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1e\n" +
+	"\n" +
+	"terminator\x18\x04 \x01(\fR\n" +
+	"terminator\x12\x18\n" +
+	"\apattern\x18\x05 \x01(\tR\apattern\x12%\n" +
+	"\x0eexpected_bytes\x18\x06 \x01(\rR\rexpectedBytes\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\a \x01(\rR\ttimeoutMs\x12\x1f\n" +
+	"\vflush_input\x18\b \x01(\bR\n" +
+	"flushInput\"\xba\x01\n" +
+	"\x10TransactResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x18\n" +
+	"\amatched\x18\x03 \x01(\bR\amatched\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x04 \x01(\rR\telapsedMs\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12%\n" +
+	"\x0echecksum_error\x18\x06 \x01(\bR\rchecksumError\"\x8c\x01\n" +
+	"\x0eWriteBatchItem\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x19\n" +
+	"\bdelay_ms\x18\x02 \x01(\rR\adelayMs\x12#\n" +
+	"\rexpected_echo\x18\x03 \x01(\fR\fexpectedEcho\x12&\n" +
+	"\x0fecho_timeout_ms\x18\x04 \x01(\rR\rechoTimeoutMs\"\x89\x01\n" +
+	"\x11WriteBatchRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x128\n" +
+	"\x05items\x18\x03 \x03(\v2\".baudlink.serial.v1.WriteBatchItemR\x05items\"\x9f\x01\n" +
+	"\x14WriteBatchItemResult\x12\x1a\n" +
+	"\bexecuted\x18\x01 \x01(\bR\bexecuted\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rbytes_written\x18\x03 \x01(\rR\fbytesWritten\x12\x12\n" +
+	"\x04echo\x18\x04 \x01(\fR\x04echo\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xb1\x01\n" +
+	"\x12WriteBatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12B\n" +
+	"\aresults\x18\x03 \x03(\v2(.baudlink.serial.v1.WriteBatchItemResultR\aresults\x12#\n" +
+	"\rbytes_written\x18\x04 \x01(\rR\fbytesWritten\"}\n" +
+	"\fFlushRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x121\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1d.baudlink.serial.v1.FlushModeR\x04mode\"C\n" +
+	"\rFlushResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"T\n" +
+	"\x16GetBufferStatusRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\x82\x02\n" +
+	"\fBufferStatus\x12!\n" +
+	"\fdriver_input\x18\x01 \x01(\rR\vdriverInput\x12#\n" +
+	"\rdriver_output\x18\x02 \x01(\rR\fdriverOutput\x12'\n" +
+	"\x0fdriver_reported\x18\x03 \x01(\bR\x0edriverReported\x12\x1a\n" +
+	"\bbuffered\x18\x04 \x01(\rR\bbuffered\x12\x1f\n" +
+	"\vbuffer_size\x18\x05 \x01(\rR\n" +
+	"bufferSize\x12\x18\n" +
+	"\adropped\x18\x06 \x01(\x04R\adropped\x12*\n" +
+	"\x11write_queue_depth\x18\a \x01(\rR\x0fwriteQueueDepth\"\xf0\x01\n" +
+	"\x10SCPIQueryRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\bcommands\x18\x03 \x03(\tR\bcommands\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\rR\ttimeoutMs\x12\x1e\n" +
+	"\n" +
+	"terminator\x18\x05 \x01(\tR\n" +
+	"terminator\x12\"\n" +
+	"\rstop_on_error\x18\x06 \x01(\bR\vstopOnError\x12!\n" +
+	"\fcheck_errors\x18\a \x01(\bR\vcheckErrors\"\xb8\x01\n" +
+	"\x11SCPIQueryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x128\n" +
+	"\aresults\x18\x03 \x03(\v2\x1e.baudlink.serial.v1.SCPIResultR\aresults\x125\n" +
+	"\x06errors\x18\x04 \x03(\v2\x1d.baudlink.serial.v1.SCPIErrorR\x06errors\"\x95\x01\n" +
+	"\n" +
+	"SCPIResult\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x1a\n" +
+	"\bresponse\x18\x02 \x01(\tR\bresponse\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x05 \x01(\rR\telapsedMs\"9\n" +
+	"\tSCPIError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xad\x01\n" +
+	"\x11SCPIErrorsRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x03 \x01(\rR\ttimeoutMs\x12\x1e\n" +
+	"\n" +
+	"terminator\x18\x04 \x01(\tR\n" +
+	"terminator\x12\x1d\n" +
+	"\n" +
+	"max_errors\x18\x05 \x01(\rR\tmaxErrors\"\x7f\n" +
+	"\x12SCPIErrorsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1d.baudlink.serial.v1.SCPIErrorR\x06errors\"\x84\x01\n" +
+	"\rSendATRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\rR\ttimeoutMs\"\xd5\x01\n" +
+	"\x0eSendATResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05lines\x18\x03 \x03(\tR\x05lines\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\x05R\terrorCode\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\a \x01(\rR\telapsedMs\"Q\n" +
+	"\x13SubscribeURCRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"z\n" +
+	"\bURCEvent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\tR\x04line\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"f\n" +
+	"\x10RunScriptRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06script\x18\x03 \x01(\fR\x06script\"\xa6\x01\n" +
+	"\vScriptEvent\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\rR\x04step\x127\n" +
+	"\x04type\x18\x02 \x01(\x0e2#.baudlink.serial.v1.ScriptEventTypeR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\x9a\x01\n" +
+	"\x0eEmulateRequest\x12\x16\n" +
+	"\x06script\x18\x01 \x01(\fR\x06script\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\x126\n" +
+	"\x06config\x18\x03 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\"\xda\x01\n" +
+	"\rEmulatorEvent\x129\n" +
+	"\x04type\x18\x01 \x01(\x0e2%.baudlink.serial.v1.EmulatorEventTypeR\x04type\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04rule\x18\x03 \x01(\tR\x04rule\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"\xeb\x02\n" +
+	"\x10CreateJobRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\bschedule\x18\x04 \x01(\tR\bschedule\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x1e\n" +
+	"\n" +
+	"terminator\x18\x06 \x01(\fR\n" +
+	"terminator\x12\x18\n" +
+	"\apattern\x18\a \x01(\tR\apattern\x12%\n" +
+	"\x0eexpected_bytes\x18\b \x01(\rR\rexpectedBytes\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\t \x01(\rR\ttimeoutMs\x12\x1f\n" +
+	"\vflush_input\x18\n" +
+	" \x01(\bR\n" +
+	"flushInput\x126\n" +
+	"\x06config\x18\v \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\"^\n" +
+	"\x11CreateJobResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x15\n" +
+	"\x06job_id\x18\x03 \x01(\tR\x05jobId\")\n" +
+	"\x10DeleteJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"G\n" +
+	"\x11DeleteJobResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"J\n" +
+	"\x14GetJobResultsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\"H\n" +
+	"\x15GetJobResultsResponse\x12/\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x1b.baudlink.serial.v1.JobInfoR\x04jobs\"\x97\x02\n" +
+	"\aJobInfo\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\bschedule\x18\x05 \x01(\tR\bschedule\x12\x19\n" +
+	"\bnext_run\x18\x06 \x01(\x03R\anextRun\x12\x12\n" +
+	"\x04runs\x18\a \x01(\x04R\x04runs\x12\x1a\n" +
+	"\bfailures\x18\b \x01(\x04R\bfailures\x12>\n" +
+	"\vlast_result\x18\t \x01(\v2\x1d.baudlink.serial.v1.JobResultR\n" +
+	"lastResult\"\xd1\x01\n" +
+	"\tJobResult\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x18\n" +
+	"\amatched\x18\x04 \x01(\bR\amatched\x12%\n" +
+	"\x0echecksum_error\x18\x05 \x01(\bR\rchecksumError\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x06 \x01(\rR\telapsedMs\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"w\n" +
+	"\tPortGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05ports\x18\x03 \x03(\tR\x05ports\x12\x1e\n" +
+	"\n" +
+	"configured\x18\x04 \x01(\bR\n" +
+	"configured\"\x13\n" +
+	"\x11ListGroupsRequest\"K\n" +
+	"\x12ListGroupsResponse\x125\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1d.baudlink.serial.v1.PortGroupR\x06groups\"`\n" +
+	"\x12CreateGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05ports\x18\x03 \x03(\tR\x05ports\"I\n" +
+	"\x13CreateGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"(\n" +
+	"\x12DeleteGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"I\n" +
+	"\x13DeleteGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9b\x01\n" +
+	"\x10OpenGroupRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x126\n" +
+	"\x06config\x18\x02 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1c\n" +
+	"\texclusive\x18\x04 \x01(\bR\texclusive\"\xb2\x01\n" +
+	"\x11OpenGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x10group_session_id\x18\x03 \x01(\tR\x0egroupSessionId\x12?\n" +
+	"\amembers\x18\x04 \x03(\v2%.baudlink.serial.v1.GroupMemberResultR\amembers\"\xa8\x01\n" +
+	"\x11GroupMemberResult\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12#\n" +
+	"\rbytes_written\x18\x05 \x01(\rR\fbytesWritten\"=\n" +
+	"\x11CloseGroupRequest\x12(\n" +
+	"\x10group_session_id\x18\x01 \x01(\tR\x0egroupSessionId\"\x89\x01\n" +
+	"\x12CloseGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\amembers\x18\x03 \x03(\v2%.baudlink.serial.v1.GroupMemberResultR\amembers\"Q\n" +
+	"\x11WriteGroupRequest\x12(\n" +
+	"\x10group_session_id\x18\x01 \x01(\tR\x0egroupSessionId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x89\x01\n" +
+	"\x12WriteGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12?\n" +
+	"\amembers\x18\x03 \x03(\v2%.baudlink.serial.v1.GroupMemberResultR\amembers\"\x8c\x01\n" +
+	"\x12StreamGroupRequest\x12(\n" +
+	"\x10group_session_id\x18\x01 \x01(\tR\x0egroupSessionId\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x02 \x01(\rR\tchunkSize\x12-\n" +
+	"\x12include_timestamps\x18\x03 \x01(\bR\x11includeTimestamps\"\xd7\x01\n" +
 	"\x11StreamReadRequest\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1d\n" +
 	"\n" +
 	"chunk_size\x18\x03 \x01(\rR\tchunkSize\x12-\n" +
-	"\x12include_timestamps\x18\x04 \x01(\bR\x11includeTimestamps\"v\n" +
+	"\x12include_timestamps\x18\x04 \x01(\bR\x11includeTimestamps\x12\x19\n" +
+	"\bhex_dump\x18\x05 \x01(\bR\ahexDump\x12\x1d\n" +
+	"\n" +
+	"ack_window\x18\x06 \x01(\rR\tackWindow\"\x98\x04\n" +
 	"\tDataChunk\x12\x1b\n" +
 	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x1a\n" +
-	"\bsequence\x18\x04 \x01(\rR\bsequence\"\xa4\x01\n" +
+	"\bsequence\x18\x04 \x01(\rR\bsequence\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12\x10\n" +
+	"\x03gap\x18\x06 \x01(\bR\x03gap\x12\x19\n" +
+	"\bhex_dump\x18\a \x01(\tR\ahexDump\x12\x1a\n" +
+	"\bshutdown\x18\b \x01(\bR\bshutdown\x12\x1b\n" +
+	"\tstream_id\x18\t \x01(\tR\bstreamId\x128\n" +
+	"\boverflow\x18\n" +
+	" \x01(\v2\x1c.baudlink.serial.v1.OverflowR\boverflow\x12)\n" +
+	"\x10session_sequence\x18\v \x01(\x04R\x0fsessionSequence\x12&\n" +
+	"\x0ffirst_byte_time\x18\f \x01(\x03R\rfirstByteTime\x12$\n" +
+	"\x0elast_byte_time\x18\r \x01(\x03R\flastByteTime\x120\n" +
+	"\x14first_byte_monotonic\x18\x0e \x01(\x03R\x12firstByteMonotonic\x12.\n" +
+	"\x13last_byte_monotonic\x18\x0f \x01(\x03R\x11lastByteMonotonic\"\x96\x01\n" +
+	"\bOverflow\x12\x1f\n" +
+	"\vlost_chunks\x18\x01 \x01(\rR\n" +
+	"lostChunks\x12\x1d\n" +
+	"\n" +
+	"lost_bytes\x18\x02 \x01(\x04R\tlostBytes\x12%\n" +
+	"\x0efirst_sequence\x18\x03 \x01(\rR\rfirstSequence\x12#\n" +
+	"\rlast_sequence\x18\x04 \x01(\rR\flastSequence\"\x87\x01\n" +
+	"\x10AckStreamRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tstream_id\x18\x03 \x01(\tR\bstreamId\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\rR\bsequence\"o\n" +
+	"\x11AckStreamResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
+	"\x0eunacknowledged\x18\x03 \x01(\rR\x0eunacknowledged\"\xa4\x01\n" +
 	"\x13StreamWriteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12.\n" +
 	"\x13total_bytes_written\x18\x02 \x01(\x04R\x11totalBytesWritten\x12)\n" +
 	"\x10chunks_processed\x18\x03 \x01(\rR\x0fchunksProcessed\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\"'\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"Q\n" +
+	"\x13StreamEventsRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\xb6\x03\n" +
+	"\fSessionEvent\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.baudlink.serial.v1.EventTypeR\x04type\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12#\n" +
+	"\rbytes_written\x18\x06 \x01(\rR\fbytesWritten\x12\x18\n" +
+	"\adrained\x18\a \x01(\bR\adrained\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x1b\n" +
+	"\tticket_id\x18\t \x01(\tR\bticketId\x12\x12\n" +
+	"\x04rule\x18\n" +
+	" \x01(\tR\x04rule\x12\x12\n" +
+	"\x04data\x18\v \x01(\fR\x04data\x12\x10\n" +
+	"\x03job\x18\f \x01(\tR\x03job\x12\x1b\n" +
+	"\tclient_id\x18\r \x01(\tR\bclientId\x12%\n" +
+	"\x0equeue_position\x18\x0e \x01(\rR\rqueuePosition\"'\n" +
 	"\vPingRequest\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"I\n" +
 	"\fPingResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
 	"\vserver_time\x18\x02 \x01(\x03R\n" +
-	"serverTime\"\x15\n" +
-	"\x13GetAgentInfoRequest\"\x9a\x02\n" +
+	"serverTime\"3\n" +
+	"\x14GetStatisticsRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\"Z\n" +
+	"\x15GetStatisticsResponse\x12A\n" +
+	"\bsessions\x18\x01 \x03(\v2%.baudlink.serial.v1.SessionStatisticsR\bsessions\"\x8f\x05\n" +
+	"\x11SessionStatistics\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x03 \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x04 \x01(\x04R\rbytesReceived\x128\n" +
+	"\x05rates\x18\x05 \x03(\v2\".baudlink.serial.v1.ThroughputRateR\x05rates\x127\n" +
+	"\x06errors\x18\x06 \x01(\v2\x1f.baudlink.serial.v1.ErrorCountsR\x06errors\x12*\n" +
+	"\x11write_queue_depth\x18\a \x01(\rR\x0fwriteQueueDepth\x12%\n" +
+	"\x0ebuffered_bytes\x18\b \x01(\rR\rbufferedBytes\x12 \n" +
+	"\vsubscribers\x18\t \x01(\rR\vsubscribers\x12\x1b\n" +
+	"\topened_at\x18\n" +
+	" \x01(\x03R\bopenedAt\x12#\n" +
+	"\rlast_activity\x18\v \x01(\x03R\flastActivity\x12\x1b\n" +
+	"\tlast_sent\x18\f \x01(\x03R\blastSent\x12#\n" +
+	"\rlast_received\x18\r \x01(\x03R\flastReceived\x12C\n" +
+	"\fread_latency\x18\x0e \x01(\v2 .baudlink.serial.v1.LatencyStatsR\vreadLatency\x12G\n" +
+	"\x0ewrite_duration\x18\x0f \x01(\v2 .baudlink.serial.v1.LatencyStatsR\rwriteDuration\"\xb8\x01\n" +
+	"\fLatencyStats\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x12\x19\n" +
+	"\btotal_us\x18\x02 \x01(\x04R\atotalUs\x12\x15\n" +
+	"\x06p50_us\x18\x03 \x01(\x04R\x05p50Us\x12\x15\n" +
+	"\x06p90_us\x18\x04 \x01(\x04R\x05p90Us\x12\x15\n" +
+	"\x06p99_us\x18\x05 \x01(\x04R\x05p99Us\x12\x15\n" +
+	"\x06max_us\x18\x06 \x01(\x04R\x05maxUs\x12\x1b\n" +
+	"\tjitter_us\x18\a \x01(\x04R\bjitterUs\"u\n" +
+	"\x0eThroughputRate\x12%\n" +
+	"\x0ewindow_seconds\x18\x01 \x01(\rR\rwindowSeconds\x12\x19\n" +
+	"\bsent_bps\x18\x02 \x01(\x01R\asentBps\x12!\n" +
+	"\freceived_bps\x18\x03 \x01(\x01R\vreceivedBps\"v\n" +
+	"\vErrorCounts\x12\x0e\n" +
+	"\x02io\x18\x01 \x01(\x04R\x02io\x12\x18\n" +
+	"\aframing\x18\x02 \x01(\x04R\aframing\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\x04R\bchecksum\x12!\n" +
+	"\frate_limited\x18\x04 \x01(\x04R\vrateLimited\"\xa6\x01\n" +
+	"\x0fTestPortRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
+	"\apattern\x18\x03 \x01(\fR\apattern\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x04 \x01(\rR\n" +
+	"iterations\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x05 \x01(\rR\ttimeoutMs\"\xec\x03\n" +
+	"\x0eTestPortReport\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x03 \x01(\rR\n" +
+	"iterations\x12!\n" +
+	"\fpackets_lost\x18\x04 \x01(\rR\vpacketsLost\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x05 \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x06 \x01(\x04R\rbytesReceived\x12\x1f\n" +
+	"\vbyte_errors\x18\a \x01(\x04R\n" +
+	"byteErrors\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\b \x01(\x01R\terrorRate\x12$\n" +
+	"\x0emin_latency_us\x18\t \x01(\x03R\fminLatencyUs\x12$\n" +
+	"\x0eavg_latency_us\x18\n" +
+	" \x01(\x03R\favgLatencyUs\x12$\n" +
+	"\x0emax_latency_us\x18\v \x01(\x03R\fmaxLatencyUs\x12%\n" +
+	"\x0ethroughput_bps\x18\f \x01(\x01R\rthroughputBps\x12'\n" +
+	"\x0ftheoretical_bps\x18\r \x01(\x01R\x0etheoreticalBps\x12\x1b\n" +
+	"\tbaud_rate\x18\x0e \x01(\rR\bbaudRate\"\xca\x02\n" +
+	"\x15IdentifyDeviceRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x1d\n" +
+	"\n" +
+	"baud_rates\x18\x03 \x03(\rR\tbaudRates\x12\x16\n" +
+	"\x06probes\x18\x04 \x03(\tR\x06probes\x126\n" +
+	"\x06config\x18\x05 \x01(\v2\x1e.baudlink.serial.v1.PortConfigR\x06config\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x06 \x01(\rR\ttimeoutMs\x12\x1b\n" +
+	"\tlisten_ms\x18\a \x01(\rR\blistenMs\x12,\n" +
+	"\x12modbus_max_address\x18\b \x01(\rR\x10modbusMaxAddress\x12\x1e\n" +
+	"\n" +
+	"exhaustive\x18\t \x01(\bR\n" +
+	"exhaustive\"\xb0\x01\n" +
+	"\x16IdentifyDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12C\n" +
+	"\n" +
+	"candidates\x18\x03 \x03(\v2#.baudlink.serial.v1.DeviceCandidateR\n" +
+	"candidates\x12\x1d\n" +
+	"\n" +
+	"baud_rates\x18\x04 \x03(\rR\tbaudRates\"\x9e\x01\n" +
+	"\x0fDeviceCandidate\x12\x1a\n" +
+	"\bprotocol\x18\x01 \x01(\tR\bprotocol\x12\x1b\n" +
+	"\tbaud_rate\x18\x02 \x01(\rR\bbaudRate\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\x12\x1a\n" +
+	"\bresponse\x18\x05 \x01(\fR\bresponse\"\x15\n" +
+	"\x13GetAgentInfoRequest\"\xbe\x03\n" +
 	"\tAgentInfo\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12!\n" +
 	"\fbuild_commit\x18\x02 \x01(\tR\vbuildCommit\x12\x1d\n" +
@@ -2133,20 +11839,178 @@ const file_serial_proto_rawDesc = "" +
 	"build_date\x18\x03 \x01(\tR\tbuildDate\x12\x0e\n" +
 	"\x02os\x18\x04 \x01(\tR\x02os\x12\x12\n" +
 	"\x04arch\x18\x05 \x01(\tR\x04arch\x12%\n" +
-	"\x0euptime_seconds\x18\x06 \x01(\x03R\ruptimeSeconds\x12-\n" +
-	"\x12supported_features\x18\a \x03(\tR\x11supportedFeatures\x127\n" +
-	"\x06config\x18\b \x01(\v2\x1f.baudlink.serial.v1.AgentConfigR\x06config\"z\n" +
+	"\x0euptime_seconds\x18\x06 \x01(\x03R\ruptimeSeconds\x121\n" +
+	"\x12supported_features\x18\a \x03(\tB\x02\x18\x01R\x11supportedFeatures\x127\n" +
+	"\x06config\x18\b \x01(\v2\x1f.baudlink.serial.v1.AgentConfigR\x06config\x12B\n" +
+	"\fcapabilities\x18\t \x03(\v2\x1e.baudlink.serial.v1.CapabilityR\fcapabilities\x127\n" +
+	"\x06limits\x18\n" +
+	" \x01(\v2\x1f.baudlink.serial.v1.AgentLimitsR\x06limits\x12!\n" +
+	"\fapi_versions\x18\v \x03(\tR\vapiVersions\"z\n" +
 	"\vAgentConfig\x12!\n" +
 	"\fgrpc_address\x18\x01 \x01(\tR\vgrpcAddress\x12\x1f\n" +
 	"\vtls_enabled\x18\x02 \x01(\bR\n" +
 	"tlsEnabled\x12'\n" +
-	"\x0fmax_connections\x18\x03 \x01(\rR\x0emaxConnections*~\n" +
+	"\x0fmax_connections\x18\x03 \x01(\rR\x0emaxConnections\"p\n" +
+	"\n" +
+	"Capability\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\"\x84\x01\n" +
+	"\vAgentLimits\x12&\n" +
+	"\x0fmax_write_bytes\x18\x01 \x01(\rR\rmaxWriteBytes\x12$\n" +
+	"\x0emax_chunk_size\x18\x02 \x01(\rR\fmaxChunkSize\x12'\n" +
+	"\x0fmax_connections\x18\x03 \x01(\rR\x0emaxConnections\"\x81\x01\n" +
+	"\x17CreateAccessLinkRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05ports\x18\x02 \x03(\tR\x05ports\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\rR\n" +
+	"ttlSeconds\"n\n" +
+	"\n" +
+	"AccessLink\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12+\n" +
+	"\x11connection_string\x18\x02 \x01(\tR\x10connectionString\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\"\x15\n" +
+	"\x13ListSessionsRequest\"S\n" +
+	"\x14ListSessionsResponse\x12;\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1f.baudlink.serial.v1.SessionInfoR\bsessions\"\xa0\x03\n" +
+	"\vSessionInfo\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1c\n" +
+	"\texclusive\x18\x04 \x01(\bR\texclusive\x12\x18\n" +
+	"\amanaged\x18\x05 \x01(\bR\amanaged\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x12\x1b\n" +
+	"\topened_at\x18\a \x01(\x03R\bopenedAt\x12%\n" +
+	"\x0euptime_seconds\x18\b \x01(\x03R\ruptimeSeconds\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\t \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\n" +
+	" \x01(\x04R\rbytesReceived\x12\x16\n" +
+	"\x06errors\x18\v \x01(\x04R\x06errors\x12 \n" +
+	"\vsubscribers\x18\f \x01(\rR\vsubscribers\x12 \n" +
+	"\vattachments\x18\r \x01(\rR\vattachments\"\x14\n" +
+	"\x12ListClientsRequest\"\xab\x01\n" +
+	"\x13ListClientsResponse\x128\n" +
+	"\aclients\x18\x01 \x03(\v2\x1e.baudlink.serial.v1.ClientInfoR\aclients\x12'\n" +
+	"\x0fmax_connections\x18\x02 \x01(\rR\x0emaxConnections\x121\n" +
+	"\x14rejected_connections\x18\x03 \x01(\x04R\x13rejectedConnections\"\xc5\x01\n" +
+	"\n" +
+	"ClientInfo\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x1a\n" +
+	"\bidentity\x18\x02 \x01(\tR\bidentity\x12!\n" +
+	"\fconnected_at\x18\x03 \x01(\x03R\vconnectedAt\x12!\n" +
+	"\flast_request\x18\x04 \x01(\x03R\vlastRequest\x12\x1a\n" +
+	"\brequests\x18\x05 \x01(\x04R\brequests\x12%\n" +
+	"\x0eactive_streams\x18\x06 \x01(\rR\ractiveStreams\"J\n" +
+	"\x11ForceCloseRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"e\n" +
+	"\x12ForceCloseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\"I\n" +
+	"\x12ResetDeviceRequest\x12\x1b\n" +
+	"\tport_name\x18\x01 \x01(\tR\bportName\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"I\n" +
+	"\x13ResetDeviceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xad\x01\n" +
+	"\x12GetAuditLogRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x02 \x01(\x03R\x05until\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\x12\x1a\n" +
+	"\bidentity\x18\x04 \x01(\tR\bidentity\x12\x1c\n" +
+	"\toperation\x18\x05 \x01(\tR\toperation\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\rR\x05limit\"O\n" +
+	"\x13GetAuditLogResponse\x128\n" +
+	"\aentries\x18\x01 \x03(\v2\x1e.baudlink.serial.v1.AuditEntryR\aentries\"\xb3\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x1a\n" +
+	"\bidentity\x18\x03 \x01(\tR\bidentity\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x12\n" +
+	"\x04peer\x18\x05 \x01(\tR\x04peer\x12\x1b\n" +
+	"\tport_name\x18\x06 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\a \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05bytes\x18\b \x01(\x04R\x05bytes\x12\x18\n" +
+	"\asuccess\x18\t \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\n" +
+	" \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\"\x96\x01\n" +
+	"\x18GetSessionHistoryRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x02 \x01(\x03R\x05until\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\"\x95\x01\n" +
+	"\x19GetSessionHistoryResponse\x12=\n" +
+	"\bsessions\x18\x01 \x03(\v2!.baudlink.serial.v1.SessionRecordR\bsessions\x129\n" +
+	"\x06totals\x18\x02 \x01(\v2!.baudlink.serial.v1.SessionTotalsR\x06totals\"\xf0\x02\n" +
+	"\rSessionRecord\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tport_name\x18\x02 \x01(\tR\bportName\x12\x1b\n" +
+	"\tclient_id\x18\x03 \x01(\tR\bclientId\x12\x1c\n" +
+	"\texclusive\x18\x04 \x01(\bR\texclusive\x12\x18\n" +
+	"\amanaged\x18\x05 \x01(\bR\amanaged\x12\x1b\n" +
+	"\topened_at\x18\x06 \x01(\x03R\bopenedAt\x12\x1b\n" +
+	"\tclosed_at\x18\a \x01(\x03R\bclosedAt\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\b \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\t \x01(\x04R\rbytesReceived\x12\x16\n" +
+	"\x06errors\x18\n" +
+	" \x01(\x04R\x06errors\x12 \n" +
+	"\vinterrupted\x18\v \x01(\bR\vinterrupted\x12\x14\n" +
+	"\x05sniff\x18\f \x01(\bR\x05sniff\"\x89\x01\n" +
+	"\rSessionTotals\x12\x1a\n" +
+	"\bsessions\x18\x01 \x01(\x04R\bsessions\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x02 \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\x03 \x01(\x04R\rbytesReceived\x12\x16\n" +
+	"\x06errors\x18\x04 \x01(\x04R\x06errors\"\x96\x01\n" +
+	"\x16GetCaptureIndexRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x02 \x01(\x03R\x05until\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\"X\n" +
+	"\x17GetCaptureIndexResponse\x12=\n" +
+	"\bcaptures\x18\x01 \x03(\v2!.baudlink.serial.v1.CaptureRecordR\bcaptures\"\xc7\x02\n" +
+	"\rCaptureRecord\x12\x15\n" +
+	"\x06tap_id\x18\x01 \x01(\tR\x05tapId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tport_name\x18\x03 \x01(\tR\bportName\x125\n" +
+	"\x06config\x18\x04 \x01(\v2\x1d.baudlink.serial.v1.TapConfigR\x06config\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\x03R\tstartedAt\x12\x1d\n" +
+	"\n" +
+	"stopped_at\x18\x06 \x01(\x03R\tstoppedAt\x12#\n" +
+	"\rbytes_written\x18\a \x01(\x04R\fbytesWritten\x12'\n" +
+	"\x0frecords_dropped\x18\b \x01(\x04R\x0erecordsDropped\x12 \n" +
+	"\vinterrupted\x18\t \x01(\bR\vinterrupted*\x95\x01\n" +
 	"\bPortType\x12\x19\n" +
 	"\x15PORT_TYPE_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rPORT_TYPE_USB\x10\x01\x12\x14\n" +
 	"\x10PORT_TYPE_NATIVE\x10\x02\x12\x17\n" +
 	"\x13PORT_TYPE_BLUETOOTH\x10\x03\x12\x15\n" +
-	"\x11PORT_TYPE_VIRTUAL\x10\x04*i\n" +
+	"\x11PORT_TYPE_VIRTUAL\x10\x04\x12\x15\n" +
+	"\x11PORT_TYPE_NETWORK\x10\x05*:\n" +
+	"\bOpenMode\x12\x19\n" +
+	"\x15OPEN_MODE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fOPEN_MODE_SNIFF\x10\x01*d\n" +
+	"\vSessionRole\x12\x1c\n" +
+	"\x18SESSION_ROLE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SESSION_ROLE_READ_WRITE\x10\x01\x12\x1a\n" +
+	"\x16SESSION_ROLE_READ_ONLY\x10\x02*i\n" +
 	"\bDataBits\x12\x19\n" +
 	"\x15DATA_BITS_UNSPECIFIED\x10\x00\x12\x0f\n" +
 	"\vDATA_BITS_5\x10\x05\x12\x0f\n" +
@@ -2170,23 +12034,116 @@ const file_serial_proto_rawDesc = "" +
 	"\x18FLOW_CONTROL_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11FLOW_CONTROL_NONE\x10\x01\x12\x19\n" +
 	"\x15FLOW_CONTROL_HARDWARE\x10\x02\x12\x19\n" +
-	"\x15FLOW_CONTROL_SOFTWARE\x10\x032\xcf\t\n" +
+	"\x15FLOW_CONTROL_SOFTWARE\x10\x03*q\n" +
+	"\fTapDirection\x12\x1d\n" +
+	"\x19TAP_DIRECTION_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TAP_DIRECTION_RX\x10\x01\x12\x14\n" +
+	"\x10TAP_DIRECTION_TX\x10\x02\x12\x16\n" +
+	"\x12TAP_DIRECTION_BOTH\x10\x03*i\n" +
+	"\x0eProxyDirection\x12\x1f\n" +
+	"\x1bPROXY_DIRECTION_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PROXY_DIRECTION_A_TO_B\x10\x01\x12\x1a\n" +
+	"\x16PROXY_DIRECTION_B_TO_A\x10\x02*c\n" +
+	"\tFlushMode\x12\x13\n" +
+	"\x0fFLUSH_MODE_BOTH\x10\x00\x12\x14\n" +
+	"\x10FLUSH_MODE_INPUT\x10\x01\x12\x15\n" +
+	"\x11FLUSH_MODE_OUTPUT\x10\x02\x12\x14\n" +
+	"\x10FLUSH_MODE_DRAIN\x10\x03*\xeb\x01\n" +
+	"\x0fScriptEventType\x12!\n" +
+	"\x1dSCRIPT_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16SCRIPT_EVENT_TYPE_SENT\x10\x01\x12\x1e\n" +
+	"\x1aSCRIPT_EVENT_TYPE_RECEIVED\x10\x02\x12\x1d\n" +
+	"\x19SCRIPT_EVENT_TYPE_MATCHED\x10\x03\x12\x1b\n" +
+	"\x17SCRIPT_EVENT_TYPE_SLEPT\x10\x04\x12\x1c\n" +
+	"\x18SCRIPT_EVENT_TYPE_FAILED\x10\x05\x12\x1f\n" +
+	"\x1bSCRIPT_EVENT_TYPE_COMPLETED\x10\x06*\x9b\x02\n" +
+	"\x11EmulatorEventType\x12#\n" +
+	"\x1fEMULATOR_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bEMULATOR_EVENT_TYPE_STARTED\x10\x01\x12 \n" +
+	"\x1cEMULATOR_EVENT_TYPE_RECEIVED\x10\x02\x12\x1f\n" +
+	"\x1bEMULATOR_EVENT_TYPE_MATCHED\x10\x03\x12\x1c\n" +
+	"\x18EMULATOR_EVENT_TYPE_SENT\x10\x04\x12\x1d\n" +
+	"\x19EMULATOR_EVENT_TYPE_STATE\x10\x05\x12\x1e\n" +
+	"\x1aEMULATOR_EVENT_TYPE_FAILED\x10\x06\x12 \n" +
+	"\x1cEMULATOR_EVENT_TYPE_SHUTDOWN\x10\a*\xc4\x02\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EVENT_TYPE_WRITE_COMPLETE\x10\x01\x12!\n" +
+	"\x1dEVENT_TYPE_SESSION_TERMINATED\x10\x02\x12 \n" +
+	"\x1cEVENT_TYPE_SESSION_SUSPENDED\x10\x03\x12\x1e\n" +
+	"\x1aEVENT_TYPE_SESSION_RESUMED\x10\x04\x12\x1b\n" +
+	"\x17EVENT_TYPE_RULE_MATCHED\x10\x05\x12\x1c\n" +
+	"\x18EVENT_TYPE_JOB_COMPLETED\x10\x06\x12\x1e\n" +
+	"\x1aEVENT_TYPE_SESSION_EXPIRED\x10\a\x12\x1d\n" +
+	"\x19EVENT_TYPE_AGENT_SHUTDOWN\x10\b\x12\x1d\n" +
+	"\x19EVENT_TYPE_QUEUE_POSITION\x10\t2\xa0)\n" +
 	"\rSerialService\x12X\n" +
 	"\tListPorts\x12$.baudlink.serial.v1.ListPortsRequest\x1a%.baudlink.serial.v1.ListPortsResponse\x12S\n" +
 	"\vGetPortInfo\x12&.baudlink.serial.v1.GetPortInfoRequest\x1a\x1c.baudlink.serial.v1.PortInfo\x12U\n" +
 	"\bOpenPort\x12#.baudlink.serial.v1.OpenPortRequest\x1a$.baudlink.serial.v1.OpenPortResponse\x12X\n" +
 	"\tClosePort\x12$.baudlink.serial.v1.ClosePortRequest\x1a%.baudlink.serial.v1.ClosePortResponse\x12Y\n" +
-	"\rGetPortStatus\x12(.baudlink.serial.v1.GetPortStatusRequest\x1a\x1e.baudlink.serial.v1.PortStatus\x12L\n" +
+	"\rGetPortStatus\x12(.baudlink.serial.v1.GetPortStatusRequest\x1a\x1e.baudlink.serial.v1.PortStatus\x12d\n" +
+	"\rAttachSession\x12(.baudlink.serial.v1.AttachSessionRequest\x1a).baudlink.serial.v1.AttachSessionResponse\x12d\n" +
+	"\rDetachSession\x12(.baudlink.serial.v1.DetachSessionRequest\x1a).baudlink.serial.v1.DetachSessionResponse\x12U\n" +
+	"\bTakeOver\x12#.baudlink.serial.v1.TakeOverRequest\x1a$.baudlink.serial.v1.TakeOverResponse\x12g\n" +
+	"\x0eGetPortHolders\x12).baudlink.serial.v1.GetPortHoldersRequest\x1a*.baudlink.serial.v1.GetPortHoldersResponse\x12L\n" +
 	"\x05Write\x12 .baudlink.serial.v1.WriteRequest\x1a!.baudlink.serial.v1.WriteResponse\x12I\n" +
-	"\x04Read\x12\x1f.baudlink.serial.v1.ReadRequest\x1a .baudlink.serial.v1.ReadResponse\x12T\n" +
+	"\x04Read\x12\x1f.baudlink.serial.v1.ReadRequest\x1a .baudlink.serial.v1.ReadResponse\x12[\n" +
 	"\n" +
-	"StreamRead\x12%.baudlink.serial.v1.StreamReadRequest\x1a\x1d.baudlink.serial.v1.DataChunk0\x01\x12W\n" +
+	"QueueWrite\x12%.baudlink.serial.v1.QueueWriteRequest\x1a&.baudlink.serial.v1.QueueWriteResponse\x12U\n" +
+	"\bTransact\x12#.baudlink.serial.v1.TransactRequest\x1a$.baudlink.serial.v1.TransactResponse\x12[\n" +
+	"\n" +
+	"WriteBatch\x12%.baudlink.serial.v1.WriteBatchRequest\x1a&.baudlink.serial.v1.WriteBatchResponse\x12L\n" +
+	"\x05Flush\x12 .baudlink.serial.v1.FlushRequest\x1a!.baudlink.serial.v1.FlushResponse\x12_\n" +
+	"\x0fGetBufferStatus\x12*.baudlink.serial.v1.GetBufferStatusRequest\x1a .baudlink.serial.v1.BufferStatus\x12X\n" +
+	"\tSCPIQuery\x12$.baudlink.serial.v1.SCPIQueryRequest\x1a%.baudlink.serial.v1.SCPIQueryResponse\x12[\n" +
+	"\n" +
+	"SCPIErrors\x12%.baudlink.serial.v1.SCPIErrorsRequest\x1a&.baudlink.serial.v1.SCPIErrorsResponse\x12O\n" +
+	"\x06SendAT\x12!.baudlink.serial.v1.SendATRequest\x1a\".baudlink.serial.v1.SendATResponse\x12T\n" +
+	"\n" +
+	"StreamRead\x12%.baudlink.serial.v1.StreamReadRequest\x1a\x1d.baudlink.serial.v1.DataChunk0\x01\x12X\n" +
+	"\tAckStream\x12$.baudlink.serial.v1.AckStreamRequest\x1a%.baudlink.serial.v1.AckStreamResponse\x12W\n" +
 	"\vStreamWrite\x12\x1d.baudlink.serial.v1.DataChunk\x1a'.baudlink.serial.v1.StreamWriteResponse(\x01\x12W\n" +
-	"\x13BiDirectionalStream\x12\x1d.baudlink.serial.v1.DataChunk\x1a\x1d.baudlink.serial.v1.DataChunk(\x010\x01\x12d\n" +
+	"\x13BiDirectionalStream\x12\x1d.baudlink.serial.v1.DataChunk\x1a\x1d.baudlink.serial.v1.DataChunk(\x010\x01\x12[\n" +
+	"\fStreamEvents\x12'.baudlink.serial.v1.StreamEventsRequest\x1a .baudlink.serial.v1.SessionEvent0\x01\x12W\n" +
+	"\fSubscribeURC\x12'.baudlink.serial.v1.SubscribeURCRequest\x1a\x1c.baudlink.serial.v1.URCEvent0\x01\x12T\n" +
+	"\tRunScript\x12$.baudlink.serial.v1.RunScriptRequest\x1a\x1f.baudlink.serial.v1.ScriptEvent0\x01\x12R\n" +
+	"\aEmulate\x12\".baudlink.serial.v1.EmulateRequest\x1a!.baudlink.serial.v1.EmulatorEvent0\x01\x12X\n" +
+	"\tCreateJob\x12$.baudlink.serial.v1.CreateJobRequest\x1a%.baudlink.serial.v1.CreateJobResponse\x12X\n" +
+	"\tDeleteJob\x12$.baudlink.serial.v1.DeleteJobRequest\x1a%.baudlink.serial.v1.DeleteJobResponse\x12d\n" +
+	"\rGetJobResults\x12(.baudlink.serial.v1.GetJobResultsRequest\x1a).baudlink.serial.v1.GetJobResultsResponse\x12[\n" +
+	"\n" +
+	"ListGroups\x12%.baudlink.serial.v1.ListGroupsRequest\x1a&.baudlink.serial.v1.ListGroupsResponse\x12^\n" +
+	"\vCreateGroup\x12&.baudlink.serial.v1.CreateGroupRequest\x1a'.baudlink.serial.v1.CreateGroupResponse\x12^\n" +
+	"\vDeleteGroup\x12&.baudlink.serial.v1.DeleteGroupRequest\x1a'.baudlink.serial.v1.DeleteGroupResponse\x12X\n" +
+	"\tOpenGroup\x12$.baudlink.serial.v1.OpenGroupRequest\x1a%.baudlink.serial.v1.OpenGroupResponse\x12[\n" +
+	"\n" +
+	"CloseGroup\x12%.baudlink.serial.v1.CloseGroupRequest\x1a&.baudlink.serial.v1.CloseGroupResponse\x12[\n" +
+	"\n" +
+	"WriteGroup\x12%.baudlink.serial.v1.WriteGroupRequest\x1a&.baudlink.serial.v1.WriteGroupResponse\x12V\n" +
+	"\vStreamGroup\x12&.baudlink.serial.v1.StreamGroupRequest\x1a\x1d.baudlink.serial.v1.DataChunk0\x01\x12d\n" +
 	"\rConfigurePort\x12(.baudlink.serial.v1.ConfigurePortRequest\x1a).baudlink.serial.v1.ConfigurePortResponse\x12Y\n" +
-	"\rGetPortConfig\x12(.baudlink.serial.v1.GetPortConfigRequest\x1a\x1e.baudlink.serial.v1.PortConfig\x12I\n" +
+	"\rGetPortConfig\x12(.baudlink.serial.v1.GetPortConfigRequest\x1a\x1e.baudlink.serial.v1.PortConfig\x12O\n" +
+	"\x06AddTap\x12!.baudlink.serial.v1.AddTapRequest\x1a\".baudlink.serial.v1.AddTapResponse\x12X\n" +
+	"\tRemoveTap\x12$.baudlink.serial.v1.RemoveTapRequest\x1a%.baudlink.serial.v1.RemoveTapResponse\x12m\n" +
+	"\x10StartPassthrough\x12+.baudlink.serial.v1.StartPassthroughRequest\x1a,.baudlink.serial.v1.StartPassthroughResponse\x12j\n" +
+	"\x0fStopPassthrough\x12*.baudlink.serial.v1.StopPassthroughRequest\x1a+.baudlink.serial.v1.StopPassthroughResponse\x12U\n" +
+	"\n" +
+	"ProxyPorts\x12%.baudlink.serial.v1.ProxyPortsRequest\x1a\x1e.baudlink.serial.v1.ProxyEvent0\x01\x12I\n" +
 	"\x04Ping\x12\x1f.baudlink.serial.v1.PingRequest\x1a .baudlink.serial.v1.PingResponse\x12V\n" +
-	"\fGetAgentInfo\x12'.baudlink.serial.v1.GetAgentInfoRequest\x1a\x1d.baudlink.serial.v1.AgentInfoB3Z1github.com/Shoaibashk/BaudLink/api/proto;serialpbb\x06proto3"
+	"\fGetAgentInfo\x12'.baudlink.serial.v1.GetAgentInfoRequest\x1a\x1d.baudlink.serial.v1.AgentInfo\x12S\n" +
+	"\bTestPort\x12#.baudlink.serial.v1.TestPortRequest\x1a\".baudlink.serial.v1.TestPortReport\x12g\n" +
+	"\x0eIdentifyDevice\x12).baudlink.serial.v1.IdentifyDeviceRequest\x1a*.baudlink.serial.v1.IdentifyDeviceResponse\x12d\n" +
+	"\rGetStatistics\x12(.baudlink.serial.v1.GetStatisticsRequest\x1a).baudlink.serial.v1.GetStatisticsResponse\x12_\n" +
+	"\x10CreateAccessLink\x12+.baudlink.serial.v1.CreateAccessLinkRequest\x1a\x1e.baudlink.serial.v1.AccessLink\x12a\n" +
+	"\fListSessions\x12'.baudlink.serial.v1.ListSessionsRequest\x1a(.baudlink.serial.v1.ListSessionsResponse\x12^\n" +
+	"\vListClients\x12&.baudlink.serial.v1.ListClientsRequest\x1a'.baudlink.serial.v1.ListClientsResponse\x12[\n" +
+	"\n" +
+	"ForceClose\x12%.baudlink.serial.v1.ForceCloseRequest\x1a&.baudlink.serial.v1.ForceCloseResponse\x12^\n" +
+	"\vResetDevice\x12&.baudlink.serial.v1.ResetDeviceRequest\x1a'.baudlink.serial.v1.ResetDeviceResponse\x12^\n" +
+	"\vGetAuditLog\x12&.baudlink.serial.v1.GetAuditLogRequest\x1a'.baudlink.serial.v1.GetAuditLogResponse\x12p\n" +
+	"\x11GetSessionHistory\x12,.baudlink.serial.v1.GetSessionHistoryRequest\x1a-.baudlink.serial.v1.GetSessionHistoryResponse\x12j\n" +
+	"\x0fGetCaptureIndex\x12*.baudlink.serial.v1.GetCaptureIndexRequest\x1a+.baudlink.serial.v1.GetCaptureIndexResponseBKZ1github.com/Shoaibashk/BaudLink/api/proto;serialpb\xaa\x02\x15BaudLink.Client.Protob\x06proto3"
 
 var (
 	file_serial_proto_rawDescOnce sync.Once
@@ -2200,87 +12157,352 @@ func file_serial_proto_rawDescGZIP() []byte {
 	return file_serial_proto_rawDescData
 }
 
-var file_serial_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_serial_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_serial_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_serial_proto_msgTypes = make([]protoimpl.MessageInfo, 143)
 var file_serial_proto_goTypes = []any{
-	(PortType)(0),                 // 0: baudlink.serial.v1.PortType
-	(DataBits)(0),                 // 1: baudlink.serial.v1.DataBits
-	(StopBits)(0),                 // 2: baudlink.serial.v1.StopBits
-	(Parity)(0),                   // 3: baudlink.serial.v1.Parity
-	(FlowControl)(0),              // 4: baudlink.serial.v1.FlowControl
-	(*ListPortsRequest)(nil),      // 5: baudlink.serial.v1.ListPortsRequest
-	(*ListPortsResponse)(nil),     // 6: baudlink.serial.v1.ListPortsResponse
-	(*GetPortInfoRequest)(nil),    // 7: baudlink.serial.v1.GetPortInfoRequest
-	(*PortInfo)(nil),              // 8: baudlink.serial.v1.PortInfo
-	(*OpenPortRequest)(nil),       // 9: baudlink.serial.v1.OpenPortRequest
-	(*OpenPortResponse)(nil),      // 10: baudlink.serial.v1.OpenPortResponse
-	(*ClosePortRequest)(nil),      // 11: baudlink.serial.v1.ClosePortRequest
-	(*ClosePortResponse)(nil),     // 12: baudlink.serial.v1.ClosePortResponse
-	(*GetPortStatusRequest)(nil),  // 13: baudlink.serial.v1.GetPortStatusRequest
-	(*PortStatus)(nil),            // 14: baudlink.serial.v1.PortStatus
-	(*PortStatistics)(nil),        // 15: baudlink.serial.v1.PortStatistics
-	(*PortConfig)(nil),            // 16: baudlink.serial.v1.PortConfig
-	(*ConfigurePortRequest)(nil),  // 17: baudlink.serial.v1.ConfigurePortRequest
-	(*ConfigurePortResponse)(nil), // 18: baudlink.serial.v1.ConfigurePortResponse
-	(*GetPortConfigRequest)(nil),  // 19: baudlink.serial.v1.GetPortConfigRequest
-	(*WriteRequest)(nil),          // 20: baudlink.serial.v1.WriteRequest
-	(*WriteResponse)(nil),         // 21: baudlink.serial.v1.WriteResponse
-	(*ReadRequest)(nil),           // 22: baudlink.serial.v1.ReadRequest
-	(*ReadResponse)(nil),          // 23: baudlink.serial.v1.ReadResponse
-	(*StreamReadRequest)(nil),     // 24: baudlink.serial.v1.StreamReadRequest
-	(*DataChunk)(nil),             // 25: baudlink.serial.v1.DataChunk
-	(*StreamWriteResponse)(nil),   // 26: baudlink.serial.v1.StreamWriteResponse
-	(*PingRequest)(nil),           // 27: baudlink.serial.v1.PingRequest
-	(*PingResponse)(nil),          // 28: baudlink.serial.v1.PingResponse
-	(*GetAgentInfoRequest)(nil),   // 29: baudlink.serial.v1.GetAgentInfoRequest
-	(*AgentInfo)(nil),             // 30: baudlink.serial.v1.AgentInfo
-	(*AgentConfig)(nil),           // 31: baudlink.serial.v1.AgentConfig
+	(PortType)(0),                     // 0: baudlink.serial.v1.PortType
+	(OpenMode)(0),                     // 1: baudlink.serial.v1.OpenMode
+	(SessionRole)(0),                  // 2: baudlink.serial.v1.SessionRole
+	(DataBits)(0),                     // 3: baudlink.serial.v1.DataBits
+	(StopBits)(0),                     // 4: baudlink.serial.v1.StopBits
+	(Parity)(0),                       // 5: baudlink.serial.v1.Parity
+	(FlowControl)(0),                  // 6: baudlink.serial.v1.FlowControl
+	(TapDirection)(0),                 // 7: baudlink.serial.v1.TapDirection
+	(ProxyDirection)(0),               // 8: baudlink.serial.v1.ProxyDirection
+	(FlushMode)(0),                    // 9: baudlink.serial.v1.FlushMode
+	(ScriptEventType)(0),              // 10: baudlink.serial.v1.ScriptEventType
+	(EmulatorEventType)(0),            // 11: baudlink.serial.v1.EmulatorEventType
+	(EventType)(0),                    // 12: baudlink.serial.v1.EventType
+	(*ListPortsRequest)(nil),          // 13: baudlink.serial.v1.ListPortsRequest
+	(*ListPortsResponse)(nil),         // 14: baudlink.serial.v1.ListPortsResponse
+	(*GetPortInfoRequest)(nil),        // 15: baudlink.serial.v1.GetPortInfoRequest
+	(*PortInfo)(nil),                  // 16: baudlink.serial.v1.PortInfo
+	(*OpenPortRequest)(nil),           // 17: baudlink.serial.v1.OpenPortRequest
+	(*RetryPolicy)(nil),               // 18: baudlink.serial.v1.RetryPolicy
+	(*OpenPortResponse)(nil),          // 19: baudlink.serial.v1.OpenPortResponse
+	(*ClosePortRequest)(nil),          // 20: baudlink.serial.v1.ClosePortRequest
+	(*ClosePortResponse)(nil),         // 21: baudlink.serial.v1.ClosePortResponse
+	(*GetPortStatusRequest)(nil),      // 22: baudlink.serial.v1.GetPortStatusRequest
+	(*PortStatus)(nil),                // 23: baudlink.serial.v1.PortStatus
+	(*FlowStatus)(nil),                // 24: baudlink.serial.v1.FlowStatus
+	(*AttachSessionRequest)(nil),      // 25: baudlink.serial.v1.AttachSessionRequest
+	(*AttachSessionResponse)(nil),     // 26: baudlink.serial.v1.AttachSessionResponse
+	(*DetachSessionRequest)(nil),      // 27: baudlink.serial.v1.DetachSessionRequest
+	(*DetachSessionResponse)(nil),     // 28: baudlink.serial.v1.DetachSessionResponse
+	(*TakeOverRequest)(nil),           // 29: baudlink.serial.v1.TakeOverRequest
+	(*TakeOverResponse)(nil),          // 30: baudlink.serial.v1.TakeOverResponse
+	(*GetPortHoldersRequest)(nil),     // 31: baudlink.serial.v1.GetPortHoldersRequest
+	(*GetPortHoldersResponse)(nil),    // 32: baudlink.serial.v1.GetPortHoldersResponse
+	(*PortHolder)(nil),                // 33: baudlink.serial.v1.PortHolder
+	(*AttachmentInfo)(nil),            // 34: baudlink.serial.v1.AttachmentInfo
+	(*PortStatistics)(nil),            // 35: baudlink.serial.v1.PortStatistics
+	(*PortConfig)(nil),                // 36: baudlink.serial.v1.PortConfig
+	(*RS485Config)(nil),               // 37: baudlink.serial.v1.RS485Config
+	(*ChecksumConfig)(nil),            // 38: baudlink.serial.v1.ChecksumConfig
+	(*ConfigurePortRequest)(nil),      // 39: baudlink.serial.v1.ConfigurePortRequest
+	(*ConfigurePortResponse)(nil),     // 40: baudlink.serial.v1.ConfigurePortResponse
+	(*GetPortConfigRequest)(nil),      // 41: baudlink.serial.v1.GetPortConfigRequest
+	(*TapConfig)(nil),                 // 42: baudlink.serial.v1.TapConfig
+	(*TapInfo)(nil),                   // 43: baudlink.serial.v1.TapInfo
+	(*AddTapRequest)(nil),             // 44: baudlink.serial.v1.AddTapRequest
+	(*AddTapResponse)(nil),            // 45: baudlink.serial.v1.AddTapResponse
+	(*RemoveTapRequest)(nil),          // 46: baudlink.serial.v1.RemoveTapRequest
+	(*RemoveTapResponse)(nil),         // 47: baudlink.serial.v1.RemoveTapResponse
+	(*StartPassthroughRequest)(nil),   // 48: baudlink.serial.v1.StartPassthroughRequest
+	(*StartPassthroughResponse)(nil),  // 49: baudlink.serial.v1.StartPassthroughResponse
+	(*StopPassthroughRequest)(nil),    // 50: baudlink.serial.v1.StopPassthroughRequest
+	(*StopPassthroughResponse)(nil),   // 51: baudlink.serial.v1.StopPassthroughResponse
+	(*ProxyPortsRequest)(nil),         // 52: baudlink.serial.v1.ProxyPortsRequest
+	(*ProxyEvent)(nil),                // 53: baudlink.serial.v1.ProxyEvent
+	(*WriteRequest)(nil),              // 54: baudlink.serial.v1.WriteRequest
+	(*WriteResponse)(nil),             // 55: baudlink.serial.v1.WriteResponse
+	(*QueueWriteRequest)(nil),         // 56: baudlink.serial.v1.QueueWriteRequest
+	(*QueueWriteResponse)(nil),        // 57: baudlink.serial.v1.QueueWriteResponse
+	(*ReadRequest)(nil),               // 58: baudlink.serial.v1.ReadRequest
+	(*ReadResponse)(nil),              // 59: baudlink.serial.v1.ReadResponse
+	(*TransactRequest)(nil),           // 60: baudlink.serial.v1.TransactRequest
+	(*TransactResponse)(nil),          // 61: baudlink.serial.v1.TransactResponse
+	(*WriteBatchItem)(nil),            // 62: baudlink.serial.v1.WriteBatchItem
+	(*WriteBatchRequest)(nil),         // 63: baudlink.serial.v1.WriteBatchRequest
+	(*WriteBatchItemResult)(nil),      // 64: baudlink.serial.v1.WriteBatchItemResult
+	(*WriteBatchResponse)(nil),        // 65: baudlink.serial.v1.WriteBatchResponse
+	(*FlushRequest)(nil),              // 66: baudlink.serial.v1.FlushRequest
+	(*FlushResponse)(nil),             // 67: baudlink.serial.v1.FlushResponse
+	(*GetBufferStatusRequest)(nil),    // 68: baudlink.serial.v1.GetBufferStatusRequest
+	(*BufferStatus)(nil),              // 69: baudlink.serial.v1.BufferStatus
+	(*SCPIQueryRequest)(nil),          // 70: baudlink.serial.v1.SCPIQueryRequest
+	(*SCPIQueryResponse)(nil),         // 71: baudlink.serial.v1.SCPIQueryResponse
+	(*SCPIResult)(nil),                // 72: baudlink.serial.v1.SCPIResult
+	(*SCPIError)(nil),                 // 73: baudlink.serial.v1.SCPIError
+	(*SCPIErrorsRequest)(nil),         // 74: baudlink.serial.v1.SCPIErrorsRequest
+	(*SCPIErrorsResponse)(nil),        // 75: baudlink.serial.v1.SCPIErrorsResponse
+	(*SendATRequest)(nil),             // 76: baudlink.serial.v1.SendATRequest
+	(*SendATResponse)(nil),            // 77: baudlink.serial.v1.SendATResponse
+	(*SubscribeURCRequest)(nil),       // 78: baudlink.serial.v1.SubscribeURCRequest
+	(*URCEvent)(nil),                  // 79: baudlink.serial.v1.URCEvent
+	(*RunScriptRequest)(nil),          // 80: baudlink.serial.v1.RunScriptRequest
+	(*ScriptEvent)(nil),               // 81: baudlink.serial.v1.ScriptEvent
+	(*EmulateRequest)(nil),            // 82: baudlink.serial.v1.EmulateRequest
+	(*EmulatorEvent)(nil),             // 83: baudlink.serial.v1.EmulatorEvent
+	(*CreateJobRequest)(nil),          // 84: baudlink.serial.v1.CreateJobRequest
+	(*CreateJobResponse)(nil),         // 85: baudlink.serial.v1.CreateJobResponse
+	(*DeleteJobRequest)(nil),          // 86: baudlink.serial.v1.DeleteJobRequest
+	(*DeleteJobResponse)(nil),         // 87: baudlink.serial.v1.DeleteJobResponse
+	(*GetJobResultsRequest)(nil),      // 88: baudlink.serial.v1.GetJobResultsRequest
+	(*GetJobResultsResponse)(nil),     // 89: baudlink.serial.v1.GetJobResultsResponse
+	(*JobInfo)(nil),                   // 90: baudlink.serial.v1.JobInfo
+	(*JobResult)(nil),                 // 91: baudlink.serial.v1.JobResult
+	(*PortGroup)(nil),                 // 92: baudlink.serial.v1.PortGroup
+	(*ListGroupsRequest)(nil),         // 93: baudlink.serial.v1.ListGroupsRequest
+	(*ListGroupsResponse)(nil),        // 94: baudlink.serial.v1.ListGroupsResponse
+	(*CreateGroupRequest)(nil),        // 95: baudlink.serial.v1.CreateGroupRequest
+	(*CreateGroupResponse)(nil),       // 96: baudlink.serial.v1.CreateGroupResponse
+	(*DeleteGroupRequest)(nil),        // 97: baudlink.serial.v1.DeleteGroupRequest
+	(*DeleteGroupResponse)(nil),       // 98: baudlink.serial.v1.DeleteGroupResponse
+	(*OpenGroupRequest)(nil),          // 99: baudlink.serial.v1.OpenGroupRequest
+	(*OpenGroupResponse)(nil),         // 100: baudlink.serial.v1.OpenGroupResponse
+	(*GroupMemberResult)(nil),         // 101: baudlink.serial.v1.GroupMemberResult
+	(*CloseGroupRequest)(nil),         // 102: baudlink.serial.v1.CloseGroupRequest
+	(*CloseGroupResponse)(nil),        // 103: baudlink.serial.v1.CloseGroupResponse
+	(*WriteGroupRequest)(nil),         // 104: baudlink.serial.v1.WriteGroupRequest
+	(*WriteGroupResponse)(nil),        // 105: baudlink.serial.v1.WriteGroupResponse
+	(*StreamGroupRequest)(nil),        // 106: baudlink.serial.v1.StreamGroupRequest
+	(*StreamReadRequest)(nil),         // 107: baudlink.serial.v1.StreamReadRequest
+	(*DataChunk)(nil),                 // 108: baudlink.serial.v1.DataChunk
+	(*Overflow)(nil),                  // 109: baudlink.serial.v1.Overflow
+	(*AckStreamRequest)(nil),          // 110: baudlink.serial.v1.AckStreamRequest
+	(*AckStreamResponse)(nil),         // 111: baudlink.serial.v1.AckStreamResponse
+	(*StreamWriteResponse)(nil),       // 112: baudlink.serial.v1.StreamWriteResponse
+	(*StreamEventsRequest)(nil),       // 113: baudlink.serial.v1.StreamEventsRequest
+	(*SessionEvent)(nil),              // 114: baudlink.serial.v1.SessionEvent
+	(*PingRequest)(nil),               // 115: baudlink.serial.v1.PingRequest
+	(*PingResponse)(nil),              // 116: baudlink.serial.v1.PingResponse
+	(*GetStatisticsRequest)(nil),      // 117: baudlink.serial.v1.GetStatisticsRequest
+	(*GetStatisticsResponse)(nil),     // 118: baudlink.serial.v1.GetStatisticsResponse
+	(*SessionStatistics)(nil),         // 119: baudlink.serial.v1.SessionStatistics
+	(*LatencyStats)(nil),              // 120: baudlink.serial.v1.LatencyStats
+	(*ThroughputRate)(nil),            // 121: baudlink.serial.v1.ThroughputRate
+	(*ErrorCounts)(nil),               // 122: baudlink.serial.v1.ErrorCounts
+	(*TestPortRequest)(nil),           // 123: baudlink.serial.v1.TestPortRequest
+	(*TestPortReport)(nil),            // 124: baudlink.serial.v1.TestPortReport
+	(*IdentifyDeviceRequest)(nil),     // 125: baudlink.serial.v1.IdentifyDeviceRequest
+	(*IdentifyDeviceResponse)(nil),    // 126: baudlink.serial.v1.IdentifyDeviceResponse
+	(*DeviceCandidate)(nil),           // 127: baudlink.serial.v1.DeviceCandidate
+	(*GetAgentInfoRequest)(nil),       // 128: baudlink.serial.v1.GetAgentInfoRequest
+	(*AgentInfo)(nil),                 // 129: baudlink.serial.v1.AgentInfo
+	(*AgentConfig)(nil),               // 130: baudlink.serial.v1.AgentConfig
+	(*Capability)(nil),                // 131: baudlink.serial.v1.Capability
+	(*AgentLimits)(nil),               // 132: baudlink.serial.v1.AgentLimits
+	(*CreateAccessLinkRequest)(nil),   // 133: baudlink.serial.v1.CreateAccessLinkRequest
+	(*AccessLink)(nil),                // 134: baudlink.serial.v1.AccessLink
+	(*ListSessionsRequest)(nil),       // 135: baudlink.serial.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),      // 136: baudlink.serial.v1.ListSessionsResponse
+	(*SessionInfo)(nil),               // 137: baudlink.serial.v1.SessionInfo
+	(*ListClientsRequest)(nil),        // 138: baudlink.serial.v1.ListClientsRequest
+	(*ListClientsResponse)(nil),       // 139: baudlink.serial.v1.ListClientsResponse
+	(*ClientInfo)(nil),                // 140: baudlink.serial.v1.ClientInfo
+	(*ForceCloseRequest)(nil),         // 141: baudlink.serial.v1.ForceCloseRequest
+	(*ForceCloseResponse)(nil),        // 142: baudlink.serial.v1.ForceCloseResponse
+	(*ResetDeviceRequest)(nil),        // 143: baudlink.serial.v1.ResetDeviceRequest
+	(*ResetDeviceResponse)(nil),       // 144: baudlink.serial.v1.ResetDeviceResponse
+	(*GetAuditLogRequest)(nil),        // 145: baudlink.serial.v1.GetAuditLogRequest
+	(*GetAuditLogResponse)(nil),       // 146: baudlink.serial.v1.GetAuditLogResponse
+	(*AuditEntry)(nil),                // 147: baudlink.serial.v1.AuditEntry
+	(*GetSessionHistoryRequest)(nil),  // 148: baudlink.serial.v1.GetSessionHistoryRequest
+	(*GetSessionHistoryResponse)(nil), // 149: baudlink.serial.v1.GetSessionHistoryResponse
+	(*SessionRecord)(nil),             // 150: baudlink.serial.v1.SessionRecord
+	(*SessionTotals)(nil),             // 151: baudlink.serial.v1.SessionTotals
+	(*GetCaptureIndexRequest)(nil),    // 152: baudlink.serial.v1.GetCaptureIndexRequest
+	(*GetCaptureIndexResponse)(nil),   // 153: baudlink.serial.v1.GetCaptureIndexResponse
+	(*CaptureRecord)(nil),             // 154: baudlink.serial.v1.CaptureRecord
+	nil,                               // 155: baudlink.serial.v1.PortInfo.PropertiesEntry
 }
 var file_serial_proto_depIdxs = []int32{
-	8,  // 0: baudlink.serial.v1.ListPortsResponse.ports:type_name -> baudlink.serial.v1.PortInfo
-	0,  // 1: baudlink.serial.v1.PortInfo.port_type:type_name -> baudlink.serial.v1.PortType
-	16, // 2: baudlink.serial.v1.OpenPortRequest.config:type_name -> baudlink.serial.v1.PortConfig
-	16, // 3: baudlink.serial.v1.PortStatus.current_config:type_name -> baudlink.serial.v1.PortConfig
-	15, // 4: baudlink.serial.v1.PortStatus.statistics:type_name -> baudlink.serial.v1.PortStatistics
-	1,  // 5: baudlink.serial.v1.PortConfig.data_bits:type_name -> baudlink.serial.v1.DataBits
-	2,  // 6: baudlink.serial.v1.PortConfig.stop_bits:type_name -> baudlink.serial.v1.StopBits
-	3,  // 7: baudlink.serial.v1.PortConfig.parity:type_name -> baudlink.serial.v1.Parity
-	4,  // 8: baudlink.serial.v1.PortConfig.flow_control:type_name -> baudlink.serial.v1.FlowControl
-	16, // 9: baudlink.serial.v1.ConfigurePortRequest.config:type_name -> baudlink.serial.v1.PortConfig
-	31, // 10: baudlink.serial.v1.AgentInfo.config:type_name -> baudlink.serial.v1.AgentConfig
-	5,  // 11: baudlink.serial.v1.SerialService.ListPorts:input_type -> baudlink.serial.v1.ListPortsRequest
-	7,  // 12: baudlink.serial.v1.SerialService.GetPortInfo:input_type -> baudlink.serial.v1.GetPortInfoRequest
-	9,  // 13: baudlink.serial.v1.SerialService.OpenPort:input_type -> baudlink.serial.v1.OpenPortRequest
-	11, // 14: baudlink.serial.v1.SerialService.ClosePort:input_type -> baudlink.serial.v1.ClosePortRequest
-	13, // 15: baudlink.serial.v1.SerialService.GetPortStatus:input_type -> baudlink.serial.v1.GetPortStatusRequest
-	20, // 16: baudlink.serial.v1.SerialService.Write:input_type -> baudlink.serial.v1.WriteRequest
-	22, // 17: baudlink.serial.v1.SerialService.Read:input_type -> baudlink.serial.v1.ReadRequest
-	24, // 18: baudlink.serial.v1.SerialService.StreamRead:input_type -> baudlink.serial.v1.StreamReadRequest
-	25, // 19: baudlink.serial.v1.SerialService.StreamWrite:input_type -> baudlink.serial.v1.DataChunk
-	25, // 20: baudlink.serial.v1.SerialService.BiDirectionalStream:input_type -> baudlink.serial.v1.DataChunk
-	17, // 21: baudlink.serial.v1.SerialService.ConfigurePort:input_type -> baudlink.serial.v1.ConfigurePortRequest
-	19, // 22: baudlink.serial.v1.SerialService.GetPortConfig:input_type -> baudlink.serial.v1.GetPortConfigRequest
-	27, // 23: baudlink.serial.v1.SerialService.Ping:input_type -> baudlink.serial.v1.PingRequest
-	29, // 24: baudlink.serial.v1.SerialService.GetAgentInfo:input_type -> baudlink.serial.v1.GetAgentInfoRequest
-	6,  // 25: baudlink.serial.v1.SerialService.ListPorts:output_type -> baudlink.serial.v1.ListPortsResponse
-	8,  // 26: baudlink.serial.v1.SerialService.GetPortInfo:output_type -> baudlink.serial.v1.PortInfo
-	10, // 27: baudlink.serial.v1.SerialService.OpenPort:output_type -> baudlink.serial.v1.OpenPortResponse
-	12, // 28: baudlink.serial.v1.SerialService.ClosePort:output_type -> baudlink.serial.v1.ClosePortResponse
-	14, // 29: baudlink.serial.v1.SerialService.GetPortStatus:output_type -> baudlink.serial.v1.PortStatus
-	21, // 30: baudlink.serial.v1.SerialService.Write:output_type -> baudlink.serial.v1.WriteResponse
-	23, // 31: baudlink.serial.v1.SerialService.Read:output_type -> baudlink.serial.v1.ReadResponse
-	25, // 32: baudlink.serial.v1.SerialService.StreamRead:output_type -> baudlink.serial.v1.DataChunk
-	26, // 33: baudlink.serial.v1.SerialService.StreamWrite:output_type -> baudlink.serial.v1.StreamWriteResponse
-	25, // 34: baudlink.serial.v1.SerialService.BiDirectionalStream:output_type -> baudlink.serial.v1.DataChunk
-	18, // 35: baudlink.serial.v1.SerialService.ConfigurePort:output_type -> baudlink.serial.v1.ConfigurePortResponse
-	16, // 36: baudlink.serial.v1.SerialService.GetPortConfig:output_type -> baudlink.serial.v1.PortConfig
-	28, // 37: baudlink.serial.v1.SerialService.Ping:output_type -> baudlink.serial.v1.PingResponse
-	30, // 38: baudlink.serial.v1.SerialService.GetAgentInfo:output_type -> baudlink.serial.v1.AgentInfo
-	25, // [25:39] is the sub-list for method output_type
-	11, // [11:25] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	16,  // 0: baudlink.serial.v1.ListPortsResponse.ports:type_name -> baudlink.serial.v1.PortInfo
+	0,   // 1: baudlink.serial.v1.PortInfo.port_type:type_name -> baudlink.serial.v1.PortType
+	155, // 2: baudlink.serial.v1.PortInfo.properties:type_name -> baudlink.serial.v1.PortInfo.PropertiesEntry
+	36,  // 3: baudlink.serial.v1.OpenPortRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	18,  // 4: baudlink.serial.v1.OpenPortRequest.retry:type_name -> baudlink.serial.v1.RetryPolicy
+	42,  // 5: baudlink.serial.v1.OpenPortRequest.taps:type_name -> baudlink.serial.v1.TapConfig
+	1,   // 6: baudlink.serial.v1.OpenPortRequest.mode:type_name -> baudlink.serial.v1.OpenMode
+	36,  // 7: baudlink.serial.v1.PortStatus.current_config:type_name -> baudlink.serial.v1.PortConfig
+	35,  // 8: baudlink.serial.v1.PortStatus.statistics:type_name -> baudlink.serial.v1.PortStatistics
+	34,  // 9: baudlink.serial.v1.PortStatus.attachments:type_name -> baudlink.serial.v1.AttachmentInfo
+	43,  // 10: baudlink.serial.v1.PortStatus.taps:type_name -> baudlink.serial.v1.TapInfo
+	24,  // 11: baudlink.serial.v1.PortStatus.flow:type_name -> baudlink.serial.v1.FlowStatus
+	2,   // 12: baudlink.serial.v1.AttachSessionRequest.role:type_name -> baudlink.serial.v1.SessionRole
+	33,  // 13: baudlink.serial.v1.GetPortHoldersResponse.holders:type_name -> baudlink.serial.v1.PortHolder
+	2,   // 14: baudlink.serial.v1.PortHolder.role:type_name -> baudlink.serial.v1.SessionRole
+	2,   // 15: baudlink.serial.v1.AttachmentInfo.role:type_name -> baudlink.serial.v1.SessionRole
+	3,   // 16: baudlink.serial.v1.PortConfig.data_bits:type_name -> baudlink.serial.v1.DataBits
+	4,   // 17: baudlink.serial.v1.PortConfig.stop_bits:type_name -> baudlink.serial.v1.StopBits
+	5,   // 18: baudlink.serial.v1.PortConfig.parity:type_name -> baudlink.serial.v1.Parity
+	6,   // 19: baudlink.serial.v1.PortConfig.flow_control:type_name -> baudlink.serial.v1.FlowControl
+	37,  // 20: baudlink.serial.v1.PortConfig.rs485:type_name -> baudlink.serial.v1.RS485Config
+	38,  // 21: baudlink.serial.v1.PortConfig.checksum:type_name -> baudlink.serial.v1.ChecksumConfig
+	36,  // 22: baudlink.serial.v1.ConfigurePortRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	7,   // 23: baudlink.serial.v1.TapConfig.direction:type_name -> baudlink.serial.v1.TapDirection
+	42,  // 24: baudlink.serial.v1.TapInfo.config:type_name -> baudlink.serial.v1.TapConfig
+	42,  // 25: baudlink.serial.v1.AddTapRequest.tap:type_name -> baudlink.serial.v1.TapConfig
+	36,  // 26: baudlink.serial.v1.ProxyPortsRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	36,  // 27: baudlink.serial.v1.ProxyPortsRequest.peer_config:type_name -> baudlink.serial.v1.PortConfig
+	42,  // 28: baudlink.serial.v1.ProxyPortsRequest.taps:type_name -> baudlink.serial.v1.TapConfig
+	8,   // 29: baudlink.serial.v1.ProxyEvent.direction:type_name -> baudlink.serial.v1.ProxyDirection
+	62,  // 30: baudlink.serial.v1.WriteBatchRequest.items:type_name -> baudlink.serial.v1.WriteBatchItem
+	64,  // 31: baudlink.serial.v1.WriteBatchResponse.results:type_name -> baudlink.serial.v1.WriteBatchItemResult
+	9,   // 32: baudlink.serial.v1.FlushRequest.mode:type_name -> baudlink.serial.v1.FlushMode
+	72,  // 33: baudlink.serial.v1.SCPIQueryResponse.results:type_name -> baudlink.serial.v1.SCPIResult
+	73,  // 34: baudlink.serial.v1.SCPIQueryResponse.errors:type_name -> baudlink.serial.v1.SCPIError
+	73,  // 35: baudlink.serial.v1.SCPIErrorsResponse.errors:type_name -> baudlink.serial.v1.SCPIError
+	10,  // 36: baudlink.serial.v1.ScriptEvent.type:type_name -> baudlink.serial.v1.ScriptEventType
+	36,  // 37: baudlink.serial.v1.EmulateRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	11,  // 38: baudlink.serial.v1.EmulatorEvent.type:type_name -> baudlink.serial.v1.EmulatorEventType
+	36,  // 39: baudlink.serial.v1.CreateJobRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	90,  // 40: baudlink.serial.v1.GetJobResultsResponse.jobs:type_name -> baudlink.serial.v1.JobInfo
+	91,  // 41: baudlink.serial.v1.JobInfo.last_result:type_name -> baudlink.serial.v1.JobResult
+	92,  // 42: baudlink.serial.v1.ListGroupsResponse.groups:type_name -> baudlink.serial.v1.PortGroup
+	36,  // 43: baudlink.serial.v1.OpenGroupRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	101, // 44: baudlink.serial.v1.OpenGroupResponse.members:type_name -> baudlink.serial.v1.GroupMemberResult
+	101, // 45: baudlink.serial.v1.CloseGroupResponse.members:type_name -> baudlink.serial.v1.GroupMemberResult
+	101, // 46: baudlink.serial.v1.WriteGroupResponse.members:type_name -> baudlink.serial.v1.GroupMemberResult
+	109, // 47: baudlink.serial.v1.DataChunk.overflow:type_name -> baudlink.serial.v1.Overflow
+	12,  // 48: baudlink.serial.v1.SessionEvent.type:type_name -> baudlink.serial.v1.EventType
+	119, // 49: baudlink.serial.v1.GetStatisticsResponse.sessions:type_name -> baudlink.serial.v1.SessionStatistics
+	121, // 50: baudlink.serial.v1.SessionStatistics.rates:type_name -> baudlink.serial.v1.ThroughputRate
+	122, // 51: baudlink.serial.v1.SessionStatistics.errors:type_name -> baudlink.serial.v1.ErrorCounts
+	120, // 52: baudlink.serial.v1.SessionStatistics.read_latency:type_name -> baudlink.serial.v1.LatencyStats
+	120, // 53: baudlink.serial.v1.SessionStatistics.write_duration:type_name -> baudlink.serial.v1.LatencyStats
+	36,  // 54: baudlink.serial.v1.IdentifyDeviceRequest.config:type_name -> baudlink.serial.v1.PortConfig
+	127, // 55: baudlink.serial.v1.IdentifyDeviceResponse.candidates:type_name -> baudlink.serial.v1.DeviceCandidate
+	130, // 56: baudlink.serial.v1.AgentInfo.config:type_name -> baudlink.serial.v1.AgentConfig
+	131, // 57: baudlink.serial.v1.AgentInfo.capabilities:type_name -> baudlink.serial.v1.Capability
+	132, // 58: baudlink.serial.v1.AgentInfo.limits:type_name -> baudlink.serial.v1.AgentLimits
+	137, // 59: baudlink.serial.v1.ListSessionsResponse.sessions:type_name -> baudlink.serial.v1.SessionInfo
+	140, // 60: baudlink.serial.v1.ListClientsResponse.clients:type_name -> baudlink.serial.v1.ClientInfo
+	147, // 61: baudlink.serial.v1.GetAuditLogResponse.entries:type_name -> baudlink.serial.v1.AuditEntry
+	150, // 62: baudlink.serial.v1.GetSessionHistoryResponse.sessions:type_name -> baudlink.serial.v1.SessionRecord
+	151, // 63: baudlink.serial.v1.GetSessionHistoryResponse.totals:type_name -> baudlink.serial.v1.SessionTotals
+	154, // 64: baudlink.serial.v1.GetCaptureIndexResponse.captures:type_name -> baudlink.serial.v1.CaptureRecord
+	42,  // 65: baudlink.serial.v1.CaptureRecord.config:type_name -> baudlink.serial.v1.TapConfig
+	13,  // 66: baudlink.serial.v1.SerialService.ListPorts:input_type -> baudlink.serial.v1.ListPortsRequest
+	15,  // 67: baudlink.serial.v1.SerialService.GetPortInfo:input_type -> baudlink.serial.v1.GetPortInfoRequest
+	17,  // 68: baudlink.serial.v1.SerialService.OpenPort:input_type -> baudlink.serial.v1.OpenPortRequest
+	20,  // 69: baudlink.serial.v1.SerialService.ClosePort:input_type -> baudlink.serial.v1.ClosePortRequest
+	22,  // 70: baudlink.serial.v1.SerialService.GetPortStatus:input_type -> baudlink.serial.v1.GetPortStatusRequest
+	25,  // 71: baudlink.serial.v1.SerialService.AttachSession:input_type -> baudlink.serial.v1.AttachSessionRequest
+	27,  // 72: baudlink.serial.v1.SerialService.DetachSession:input_type -> baudlink.serial.v1.DetachSessionRequest
+	29,  // 73: baudlink.serial.v1.SerialService.TakeOver:input_type -> baudlink.serial.v1.TakeOverRequest
+	31,  // 74: baudlink.serial.v1.SerialService.GetPortHolders:input_type -> baudlink.serial.v1.GetPortHoldersRequest
+	54,  // 75: baudlink.serial.v1.SerialService.Write:input_type -> baudlink.serial.v1.WriteRequest
+	58,  // 76: baudlink.serial.v1.SerialService.Read:input_type -> baudlink.serial.v1.ReadRequest
+	56,  // 77: baudlink.serial.v1.SerialService.QueueWrite:input_type -> baudlink.serial.v1.QueueWriteRequest
+	60,  // 78: baudlink.serial.v1.SerialService.Transact:input_type -> baudlink.serial.v1.TransactRequest
+	63,  // 79: baudlink.serial.v1.SerialService.WriteBatch:input_type -> baudlink.serial.v1.WriteBatchRequest
+	66,  // 80: baudlink.serial.v1.SerialService.Flush:input_type -> baudlink.serial.v1.FlushRequest
+	68,  // 81: baudlink.serial.v1.SerialService.GetBufferStatus:input_type -> baudlink.serial.v1.GetBufferStatusRequest
+	70,  // 82: baudlink.serial.v1.SerialService.SCPIQuery:input_type -> baudlink.serial.v1.SCPIQueryRequest
+	74,  // 83: baudlink.serial.v1.SerialService.SCPIErrors:input_type -> baudlink.serial.v1.SCPIErrorsRequest
+	76,  // 84: baudlink.serial.v1.SerialService.SendAT:input_type -> baudlink.serial.v1.SendATRequest
+	107, // 85: baudlink.serial.v1.SerialService.StreamRead:input_type -> baudlink.serial.v1.StreamReadRequest
+	110, // 86: baudlink.serial.v1.SerialService.AckStream:input_type -> baudlink.serial.v1.AckStreamRequest
+	108, // 87: baudlink.serial.v1.SerialService.StreamWrite:input_type -> baudlink.serial.v1.DataChunk
+	108, // 88: baudlink.serial.v1.SerialService.BiDirectionalStream:input_type -> baudlink.serial.v1.DataChunk
+	113, // 89: baudlink.serial.v1.SerialService.StreamEvents:input_type -> baudlink.serial.v1.StreamEventsRequest
+	78,  // 90: baudlink.serial.v1.SerialService.SubscribeURC:input_type -> baudlink.serial.v1.SubscribeURCRequest
+	80,  // 91: baudlink.serial.v1.SerialService.RunScript:input_type -> baudlink.serial.v1.RunScriptRequest
+	82,  // 92: baudlink.serial.v1.SerialService.Emulate:input_type -> baudlink.serial.v1.EmulateRequest
+	84,  // 93: baudlink.serial.v1.SerialService.CreateJob:input_type -> baudlink.serial.v1.CreateJobRequest
+	86,  // 94: baudlink.serial.v1.SerialService.DeleteJob:input_type -> baudlink.serial.v1.DeleteJobRequest
+	88,  // 95: baudlink.serial.v1.SerialService.GetJobResults:input_type -> baudlink.serial.v1.GetJobResultsRequest
+	93,  // 96: baudlink.serial.v1.SerialService.ListGroups:input_type -> baudlink.serial.v1.ListGroupsRequest
+	95,  // 97: baudlink.serial.v1.SerialService.CreateGroup:input_type -> baudlink.serial.v1.CreateGroupRequest
+	97,  // 98: baudlink.serial.v1.SerialService.DeleteGroup:input_type -> baudlink.serial.v1.DeleteGroupRequest
+	99,  // 99: baudlink.serial.v1.SerialService.OpenGroup:input_type -> baudlink.serial.v1.OpenGroupRequest
+	102, // 100: baudlink.serial.v1.SerialService.CloseGroup:input_type -> baudlink.serial.v1.CloseGroupRequest
+	104, // 101: baudlink.serial.v1.SerialService.WriteGroup:input_type -> baudlink.serial.v1.WriteGroupRequest
+	106, // 102: baudlink.serial.v1.SerialService.StreamGroup:input_type -> baudlink.serial.v1.StreamGroupRequest
+	39,  // 103: baudlink.serial.v1.SerialService.ConfigurePort:input_type -> baudlink.serial.v1.ConfigurePortRequest
+	41,  // 104: baudlink.serial.v1.SerialService.GetPortConfig:input_type -> baudlink.serial.v1.GetPortConfigRequest
+	44,  // 105: baudlink.serial.v1.SerialService.AddTap:input_type -> baudlink.serial.v1.AddTapRequest
+	46,  // 106: baudlink.serial.v1.SerialService.RemoveTap:input_type -> baudlink.serial.v1.RemoveTapRequest
+	48,  // 107: baudlink.serial.v1.SerialService.StartPassthrough:input_type -> baudlink.serial.v1.StartPassthroughRequest
+	50,  // 108: baudlink.serial.v1.SerialService.StopPassthrough:input_type -> baudlink.serial.v1.StopPassthroughRequest
+	52,  // 109: baudlink.serial.v1.SerialService.ProxyPorts:input_type -> baudlink.serial.v1.ProxyPortsRequest
+	115, // 110: baudlink.serial.v1.SerialService.Ping:input_type -> baudlink.serial.v1.PingRequest
+	128, // 111: baudlink.serial.v1.SerialService.GetAgentInfo:input_type -> baudlink.serial.v1.GetAgentInfoRequest
+	123, // 112: baudlink.serial.v1.SerialService.TestPort:input_type -> baudlink.serial.v1.TestPortRequest
+	125, // 113: baudlink.serial.v1.SerialService.IdentifyDevice:input_type -> baudlink.serial.v1.IdentifyDeviceRequest
+	117, // 114: baudlink.serial.v1.SerialService.GetStatistics:input_type -> baudlink.serial.v1.GetStatisticsRequest
+	133, // 115: baudlink.serial.v1.SerialService.CreateAccessLink:input_type -> baudlink.serial.v1.CreateAccessLinkRequest
+	135, // 116: baudlink.serial.v1.SerialService.ListSessions:input_type -> baudlink.serial.v1.ListSessionsRequest
+	138, // 117: baudlink.serial.v1.SerialService.ListClients:input_type -> baudlink.serial.v1.ListClientsRequest
+	141, // 118: baudlink.serial.v1.SerialService.ForceClose:input_type -> baudlink.serial.v1.ForceCloseRequest
+	143, // 119: baudlink.serial.v1.SerialService.ResetDevice:input_type -> baudlink.serial.v1.ResetDeviceRequest
+	145, // 120: baudlink.serial.v1.SerialService.GetAuditLog:input_type -> baudlink.serial.v1.GetAuditLogRequest
+	148, // 121: baudlink.serial.v1.SerialService.GetSessionHistory:input_type -> baudlink.serial.v1.GetSessionHistoryRequest
+	152, // 122: baudlink.serial.v1.SerialService.GetCaptureIndex:input_type -> baudlink.serial.v1.GetCaptureIndexRequest
+	14,  // 123: baudlink.serial.v1.SerialService.ListPorts:output_type -> baudlink.serial.v1.ListPortsResponse
+	16,  // 124: baudlink.serial.v1.SerialService.GetPortInfo:output_type -> baudlink.serial.v1.PortInfo
+	19,  // 125: baudlink.serial.v1.SerialService.OpenPort:output_type -> baudlink.serial.v1.OpenPortResponse
+	21,  // 126: baudlink.serial.v1.SerialService.ClosePort:output_type -> baudlink.serial.v1.ClosePortResponse
+	23,  // 127: baudlink.serial.v1.SerialService.GetPortStatus:output_type -> baudlink.serial.v1.PortStatus
+	26,  // 128: baudlink.serial.v1.SerialService.AttachSession:output_type -> baudlink.serial.v1.AttachSessionResponse
+	28,  // 129: baudlink.serial.v1.SerialService.DetachSession:output_type -> baudlink.serial.v1.DetachSessionResponse
+	30,  // 130: baudlink.serial.v1.SerialService.TakeOver:output_type -> baudlink.serial.v1.TakeOverResponse
+	32,  // 131: baudlink.serial.v1.SerialService.GetPortHolders:output_type -> baudlink.serial.v1.GetPortHoldersResponse
+	55,  // 132: baudlink.serial.v1.SerialService.Write:output_type -> baudlink.serial.v1.WriteResponse
+	59,  // 133: baudlink.serial.v1.SerialService.Read:output_type -> baudlink.serial.v1.ReadResponse
+	57,  // 134: baudlink.serial.v1.SerialService.QueueWrite:output_type -> baudlink.serial.v1.QueueWriteResponse
+	61,  // 135: baudlink.serial.v1.SerialService.Transact:output_type -> baudlink.serial.v1.TransactResponse
+	65,  // 136: baudlink.serial.v1.SerialService.WriteBatch:output_type -> baudlink.serial.v1.WriteBatchResponse
+	67,  // 137: baudlink.serial.v1.SerialService.Flush:output_type -> baudlink.serial.v1.FlushResponse
+	69,  // 138: baudlink.serial.v1.SerialService.GetBufferStatus:output_type -> baudlink.serial.v1.BufferStatus
+	71,  // 139: baudlink.serial.v1.SerialService.SCPIQuery:output_type -> baudlink.serial.v1.SCPIQueryResponse
+	75,  // 140: baudlink.serial.v1.SerialService.SCPIErrors:output_type -> baudlink.serial.v1.SCPIErrorsResponse
+	77,  // 141: baudlink.serial.v1.SerialService.SendAT:output_type -> baudlink.serial.v1.SendATResponse
+	108, // 142: baudlink.serial.v1.SerialService.StreamRead:output_type -> baudlink.serial.v1.DataChunk
+	111, // 143: baudlink.serial.v1.SerialService.AckStream:output_type -> baudlink.serial.v1.AckStreamResponse
+	112, // 144: baudlink.serial.v1.SerialService.StreamWrite:output_type -> baudlink.serial.v1.StreamWriteResponse
+	108, // 145: baudlink.serial.v1.SerialService.BiDirectionalStream:output_type -> baudlink.serial.v1.DataChunk
+	114, // 146: baudlink.serial.v1.SerialService.StreamEvents:output_type -> baudlink.serial.v1.SessionEvent
+	79,  // 147: baudlink.serial.v1.SerialService.SubscribeURC:output_type -> baudlink.serial.v1.URCEvent
+	81,  // 148: baudlink.serial.v1.SerialService.RunScript:output_type -> baudlink.serial.v1.ScriptEvent
+	83,  // 149: baudlink.serial.v1.SerialService.Emulate:output_type -> baudlink.serial.v1.EmulatorEvent
+	85,  // 150: baudlink.serial.v1.SerialService.CreateJob:output_type -> baudlink.serial.v1.CreateJobResponse
+	87,  // 151: baudlink.serial.v1.SerialService.DeleteJob:output_type -> baudlink.serial.v1.DeleteJobResponse
+	89,  // 152: baudlink.serial.v1.SerialService.GetJobResults:output_type -> baudlink.serial.v1.GetJobResultsResponse
+	94,  // 153: baudlink.serial.v1.SerialService.ListGroups:output_type -> baudlink.serial.v1.ListGroupsResponse
+	96,  // 154: baudlink.serial.v1.SerialService.CreateGroup:output_type -> baudlink.serial.v1.CreateGroupResponse
+	98,  // 155: baudlink.serial.v1.SerialService.DeleteGroup:output_type -> baudlink.serial.v1.DeleteGroupResponse
+	100, // 156: baudlink.serial.v1.SerialService.OpenGroup:output_type -> baudlink.serial.v1.OpenGroupResponse
+	103, // 157: baudlink.serial.v1.SerialService.CloseGroup:output_type -> baudlink.serial.v1.CloseGroupResponse
+	105, // 158: baudlink.serial.v1.SerialService.WriteGroup:output_type -> baudlink.serial.v1.WriteGroupResponse
+	108, // 159: baudlink.serial.v1.SerialService.StreamGroup:output_type -> baudlink.serial.v1.DataChunk
+	40,  // 160: baudlink.serial.v1.SerialService.ConfigurePort:output_type -> baudlink.serial.v1.ConfigurePortResponse
+	36,  // 161: baudlink.serial.v1.SerialService.GetPortConfig:output_type -> baudlink.serial.v1.PortConfig
+	45,  // 162: baudlink.serial.v1.SerialService.AddTap:output_type -> baudlink.serial.v1.AddTapResponse
+	47,  // 163: baudlink.serial.v1.SerialService.RemoveTap:output_type -> baudlink.serial.v1.RemoveTapResponse
+	49,  // 164: baudlink.serial.v1.SerialService.StartPassthrough:output_type -> baudlink.serial.v1.StartPassthroughResponse
+	51,  // 165: baudlink.serial.v1.SerialService.StopPassthrough:output_type -> baudlink.serial.v1.StopPassthroughResponse
+	53,  // 166: baudlink.serial.v1.SerialService.ProxyPorts:output_type -> baudlink.serial.v1.ProxyEvent
+	116, // 167: baudlink.serial.v1.SerialService.Ping:output_type -> baudlink.serial.v1.PingResponse
+	129, // 168: baudlink.serial.v1.SerialService.GetAgentInfo:output_type -> baudlink.serial.v1.AgentInfo
+	124, // 169: baudlink.serial.v1.SerialService.TestPort:output_type -> baudlink.serial.v1.TestPortReport
+	126, // 170: baudlink.serial.v1.SerialService.IdentifyDevice:output_type -> baudlink.serial.v1.IdentifyDeviceResponse
+	118, // 171: baudlink.serial.v1.SerialService.GetStatistics:output_type -> baudlink.serial.v1.GetStatisticsResponse
+	134, // 172: baudlink.serial.v1.SerialService.CreateAccessLink:output_type -> baudlink.serial.v1.AccessLink
+	136, // 173: baudlink.serial.v1.SerialService.ListSessions:output_type -> baudlink.serial.v1.ListSessionsResponse
+	139, // 174: baudlink.serial.v1.SerialService.ListClients:output_type -> baudlink.serial.v1.ListClientsResponse
+	142, // 175: baudlink.serial.v1.SerialService.ForceClose:output_type -> baudlink.serial.v1.ForceCloseResponse
+	144, // 176: baudlink.serial.v1.SerialService.ResetDevice:output_type -> baudlink.serial.v1.ResetDeviceResponse
+	146, // 177: baudlink.serial.v1.SerialService.GetAuditLog:output_type -> baudlink.serial.v1.GetAuditLogResponse
+	149, // 178: baudlink.serial.v1.SerialService.GetSessionHistory:output_type -> baudlink.serial.v1.GetSessionHistoryResponse
+	153, // 179: baudlink.serial.v1.SerialService.GetCaptureIndex:output_type -> baudlink.serial.v1.GetCaptureIndexResponse
+	123, // [123:180] is the sub-list for method output_type
+	66,  // [66:123] is the sub-list for method input_type
+	66,  // [66:66] is the sub-list for extension type_name
+	66,  // [66:66] is the sub-list for extension extendee
+	0,   // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_serial_proto_init() }
@@ -2293,8 +12515,8 @@ func file_serial_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_serial_proto_rawDesc), len(file_serial_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   27,
+			NumEnums:      13,
+			NumMessages:   143,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
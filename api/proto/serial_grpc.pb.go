@@ -38,15 +38,58 @@ const (
 	SerialService_OpenPort_FullMethodName            = "/baudlink.serial.v1.SerialService/OpenPort"
 	SerialService_ClosePort_FullMethodName           = "/baudlink.serial.v1.SerialService/ClosePort"
 	SerialService_GetPortStatus_FullMethodName       = "/baudlink.serial.v1.SerialService/GetPortStatus"
+	SerialService_AttachSession_FullMethodName       = "/baudlink.serial.v1.SerialService/AttachSession"
+	SerialService_DetachSession_FullMethodName       = "/baudlink.serial.v1.SerialService/DetachSession"
+	SerialService_TakeOver_FullMethodName            = "/baudlink.serial.v1.SerialService/TakeOver"
+	SerialService_GetPortHolders_FullMethodName      = "/baudlink.serial.v1.SerialService/GetPortHolders"
 	SerialService_Write_FullMethodName               = "/baudlink.serial.v1.SerialService/Write"
 	SerialService_Read_FullMethodName                = "/baudlink.serial.v1.SerialService/Read"
+	SerialService_QueueWrite_FullMethodName          = "/baudlink.serial.v1.SerialService/QueueWrite"
+	SerialService_Transact_FullMethodName            = "/baudlink.serial.v1.SerialService/Transact"
+	SerialService_WriteBatch_FullMethodName          = "/baudlink.serial.v1.SerialService/WriteBatch"
+	SerialService_Flush_FullMethodName               = "/baudlink.serial.v1.SerialService/Flush"
+	SerialService_GetBufferStatus_FullMethodName     = "/baudlink.serial.v1.SerialService/GetBufferStatus"
+	SerialService_SCPIQuery_FullMethodName           = "/baudlink.serial.v1.SerialService/SCPIQuery"
+	SerialService_SCPIErrors_FullMethodName          = "/baudlink.serial.v1.SerialService/SCPIErrors"
+	SerialService_SendAT_FullMethodName              = "/baudlink.serial.v1.SerialService/SendAT"
 	SerialService_StreamRead_FullMethodName          = "/baudlink.serial.v1.SerialService/StreamRead"
+	SerialService_AckStream_FullMethodName           = "/baudlink.serial.v1.SerialService/AckStream"
 	SerialService_StreamWrite_FullMethodName         = "/baudlink.serial.v1.SerialService/StreamWrite"
 	SerialService_BiDirectionalStream_FullMethodName = "/baudlink.serial.v1.SerialService/BiDirectionalStream"
+	SerialService_StreamEvents_FullMethodName        = "/baudlink.serial.v1.SerialService/StreamEvents"
+	SerialService_SubscribeURC_FullMethodName        = "/baudlink.serial.v1.SerialService/SubscribeURC"
+	SerialService_RunScript_FullMethodName           = "/baudlink.serial.v1.SerialService/RunScript"
+	SerialService_Emulate_FullMethodName             = "/baudlink.serial.v1.SerialService/Emulate"
+	SerialService_CreateJob_FullMethodName           = "/baudlink.serial.v1.SerialService/CreateJob"
+	SerialService_DeleteJob_FullMethodName           = "/baudlink.serial.v1.SerialService/DeleteJob"
+	SerialService_GetJobResults_FullMethodName       = "/baudlink.serial.v1.SerialService/GetJobResults"
+	SerialService_ListGroups_FullMethodName          = "/baudlink.serial.v1.SerialService/ListGroups"
+	SerialService_CreateGroup_FullMethodName         = "/baudlink.serial.v1.SerialService/CreateGroup"
+	SerialService_DeleteGroup_FullMethodName         = "/baudlink.serial.v1.SerialService/DeleteGroup"
+	SerialService_OpenGroup_FullMethodName           = "/baudlink.serial.v1.SerialService/OpenGroup"
+	SerialService_CloseGroup_FullMethodName          = "/baudlink.serial.v1.SerialService/CloseGroup"
+	SerialService_WriteGroup_FullMethodName          = "/baudlink.serial.v1.SerialService/WriteGroup"
+	SerialService_StreamGroup_FullMethodName         = "/baudlink.serial.v1.SerialService/StreamGroup"
 	SerialService_ConfigurePort_FullMethodName       = "/baudlink.serial.v1.SerialService/ConfigurePort"
 	SerialService_GetPortConfig_FullMethodName       = "/baudlink.serial.v1.SerialService/GetPortConfig"
+	SerialService_AddTap_FullMethodName              = "/baudlink.serial.v1.SerialService/AddTap"
+	SerialService_RemoveTap_FullMethodName           = "/baudlink.serial.v1.SerialService/RemoveTap"
+	SerialService_StartPassthrough_FullMethodName    = "/baudlink.serial.v1.SerialService/StartPassthrough"
+	SerialService_StopPassthrough_FullMethodName     = "/baudlink.serial.v1.SerialService/StopPassthrough"
+	SerialService_ProxyPorts_FullMethodName          = "/baudlink.serial.v1.SerialService/ProxyPorts"
 	SerialService_Ping_FullMethodName                = "/baudlink.serial.v1.SerialService/Ping"
 	SerialService_GetAgentInfo_FullMethodName        = "/baudlink.serial.v1.SerialService/GetAgentInfo"
+	SerialService_TestPort_FullMethodName            = "/baudlink.serial.v1.SerialService/TestPort"
+	SerialService_IdentifyDevice_FullMethodName      = "/baudlink.serial.v1.SerialService/IdentifyDevice"
+	SerialService_GetStatistics_FullMethodName       = "/baudlink.serial.v1.SerialService/GetStatistics"
+	SerialService_CreateAccessLink_FullMethodName    = "/baudlink.serial.v1.SerialService/CreateAccessLink"
+	SerialService_ListSessions_FullMethodName        = "/baudlink.serial.v1.SerialService/ListSessions"
+	SerialService_ListClients_FullMethodName         = "/baudlink.serial.v1.SerialService/ListClients"
+	SerialService_ForceClose_FullMethodName          = "/baudlink.serial.v1.SerialService/ForceClose"
+	SerialService_ResetDevice_FullMethodName         = "/baudlink.serial.v1.SerialService/ResetDevice"
+	SerialService_GetAuditLog_FullMethodName         = "/baudlink.serial.v1.SerialService/GetAuditLog"
+	SerialService_GetSessionHistory_FullMethodName   = "/baudlink.serial.v1.SerialService/GetSessionHistory"
+	SerialService_GetCaptureIndex_FullMethodName     = "/baudlink.serial.v1.SerialService/GetCaptureIndex"
 )
 
 // SerialServiceClient is the client API for SerialService service.
@@ -62,19 +105,66 @@ type SerialServiceClient interface {
 	OpenPort(ctx context.Context, in *OpenPortRequest, opts ...grpc.CallOption) (*OpenPortResponse, error)
 	ClosePort(ctx context.Context, in *ClosePortRequest, opts ...grpc.CallOption) (*ClosePortResponse, error)
 	GetPortStatus(ctx context.Context, in *GetPortStatusRequest, opts ...grpc.CallOption) (*PortStatus, error)
+	AttachSession(ctx context.Context, in *AttachSessionRequest, opts ...grpc.CallOption) (*AttachSessionResponse, error)
+	DetachSession(ctx context.Context, in *DetachSessionRequest, opts ...grpc.CallOption) (*DetachSessionResponse, error)
+	TakeOver(ctx context.Context, in *TakeOverRequest, opts ...grpc.CallOption) (*TakeOverResponse, error)
+	GetPortHolders(ctx context.Context, in *GetPortHoldersRequest, opts ...grpc.CallOption) (*GetPortHoldersResponse, error)
 	// Data Transfer
 	Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
 	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	QueueWrite(ctx context.Context, in *QueueWriteRequest, opts ...grpc.CallOption) (*QueueWriteResponse, error)
+	Transact(ctx context.Context, in *TransactRequest, opts ...grpc.CallOption) (*TransactResponse, error)
+	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
+	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
+	GetBufferStatus(ctx context.Context, in *GetBufferStatusRequest, opts ...grpc.CallOption) (*BufferStatus, error)
+	SCPIQuery(ctx context.Context, in *SCPIQueryRequest, opts ...grpc.CallOption) (*SCPIQueryResponse, error)
+	SCPIErrors(ctx context.Context, in *SCPIErrorsRequest, opts ...grpc.CallOption) (*SCPIErrorsResponse, error)
+	SendAT(ctx context.Context, in *SendATRequest, opts ...grpc.CallOption) (*SendATResponse, error)
 	// Streaming
 	StreamRead(ctx context.Context, in *StreamReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error)
+	AckStream(ctx context.Context, in *AckStreamRequest, opts ...grpc.CallOption) (*AckStreamResponse, error)
 	StreamWrite(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DataChunk, StreamWriteResponse], error)
 	BiDirectionalStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DataChunk, DataChunk], error)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SessionEvent], error)
+	SubscribeURC(ctx context.Context, in *SubscribeURCRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[URCEvent], error)
+	// Scripting
+	RunScript(ctx context.Context, in *RunScriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScriptEvent], error)
+	Emulate(ctx context.Context, in *EmulateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmulatorEvent], error)
+	// Scheduled Jobs
+	CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*CreateJobResponse, error)
+	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	GetJobResults(ctx context.Context, in *GetJobResultsRequest, opts ...grpc.CallOption) (*GetJobResultsResponse, error)
+	// Port Groups
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	OpenGroup(ctx context.Context, in *OpenGroupRequest, opts ...grpc.CallOption) (*OpenGroupResponse, error)
+	CloseGroup(ctx context.Context, in *CloseGroupRequest, opts ...grpc.CallOption) (*CloseGroupResponse, error)
+	WriteGroup(ctx context.Context, in *WriteGroupRequest, opts ...grpc.CallOption) (*WriteGroupResponse, error)
+	StreamGroup(ctx context.Context, in *StreamGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error)
 	// Port Configuration
 	ConfigurePort(ctx context.Context, in *ConfigurePortRequest, opts ...grpc.CallOption) (*ConfigurePortResponse, error)
 	GetPortConfig(ctx context.Context, in *GetPortConfigRequest, opts ...grpc.CallOption) (*PortConfig, error)
+	AddTap(ctx context.Context, in *AddTapRequest, opts ...grpc.CallOption) (*AddTapResponse, error)
+	RemoveTap(ctx context.Context, in *RemoveTapRequest, opts ...grpc.CallOption) (*RemoveTapResponse, error)
+	StartPassthrough(ctx context.Context, in *StartPassthroughRequest, opts ...grpc.CallOption) (*StartPassthroughResponse, error)
+	StopPassthrough(ctx context.Context, in *StopPassthroughRequest, opts ...grpc.CallOption) (*StopPassthroughResponse, error)
+	ProxyPorts(ctx context.Context, in *ProxyPortsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProxyEvent], error)
 	// Health & Diagnostics
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	GetAgentInfo(ctx context.Context, in *GetAgentInfoRequest, opts ...grpc.CallOption) (*AgentInfo, error)
+	TestPort(ctx context.Context, in *TestPortRequest, opts ...grpc.CallOption) (*TestPortReport, error)
+	IdentifyDevice(ctx context.Context, in *IdentifyDeviceRequest, opts ...grpc.CallOption) (*IdentifyDeviceResponse, error)
+	GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error)
+	// Administration
+	CreateAccessLink(ctx context.Context, in *CreateAccessLinkRequest, opts ...grpc.CallOption) (*AccessLink, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error)
+	ForceClose(ctx context.Context, in *ForceCloseRequest, opts ...grpc.CallOption) (*ForceCloseResponse, error)
+	ResetDevice(ctx context.Context, in *ResetDeviceRequest, opts ...grpc.CallOption) (*ResetDeviceResponse, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error)
+	GetSessionHistory(ctx context.Context, in *GetSessionHistoryRequest, opts ...grpc.CallOption) (*GetSessionHistoryResponse, error)
+	GetCaptureIndex(ctx context.Context, in *GetCaptureIndexRequest, opts ...grpc.CallOption) (*GetCaptureIndexResponse, error)
 }
 
 type serialServiceClient struct {
@@ -135,6 +225,46 @@ func (c *serialServiceClient) GetPortStatus(ctx context.Context, in *GetPortStat
 	return out, nil
 }
 
+func (c *serialServiceClient) AttachSession(ctx context.Context, in *AttachSessionRequest, opts ...grpc.CallOption) (*AttachSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachSessionResponse)
+	err := c.cc.Invoke(ctx, SerialService_AttachSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) DetachSession(ctx context.Context, in *DetachSessionRequest, opts ...grpc.CallOption) (*DetachSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetachSessionResponse)
+	err := c.cc.Invoke(ctx, SerialService_DetachSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) TakeOver(ctx context.Context, in *TakeOverRequest, opts ...grpc.CallOption) (*TakeOverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TakeOverResponse)
+	err := c.cc.Invoke(ctx, SerialService_TakeOver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetPortHolders(ctx context.Context, in *GetPortHoldersRequest, opts ...grpc.CallOption) (*GetPortHoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPortHoldersResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetPortHolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serialServiceClient) Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WriteResponse)
@@ -155,6 +285,86 @@ func (c *serialServiceClient) Read(ctx context.Context, in *ReadRequest, opts ..
 	return out, nil
 }
 
+func (c *serialServiceClient) QueueWrite(ctx context.Context, in *QueueWriteRequest, opts ...grpc.CallOption) (*QueueWriteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueWriteResponse)
+	err := c.cc.Invoke(ctx, SerialService_QueueWrite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) Transact(ctx context.Context, in *TransactRequest, opts ...grpc.CallOption) (*TransactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactResponse)
+	err := c.cc.Invoke(ctx, SerialService_Transact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteBatchResponse)
+	err := c.cc.Invoke(ctx, SerialService_WriteBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlushResponse)
+	err := c.cc.Invoke(ctx, SerialService_Flush_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetBufferStatus(ctx context.Context, in *GetBufferStatusRequest, opts ...grpc.CallOption) (*BufferStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BufferStatus)
+	err := c.cc.Invoke(ctx, SerialService_GetBufferStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) SCPIQuery(ctx context.Context, in *SCPIQueryRequest, opts ...grpc.CallOption) (*SCPIQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SCPIQueryResponse)
+	err := c.cc.Invoke(ctx, SerialService_SCPIQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) SCPIErrors(ctx context.Context, in *SCPIErrorsRequest, opts ...grpc.CallOption) (*SCPIErrorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SCPIErrorsResponse)
+	err := c.cc.Invoke(ctx, SerialService_SCPIErrors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) SendAT(ctx context.Context, in *SendATRequest, opts ...grpc.CallOption) (*SendATResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendATResponse)
+	err := c.cc.Invoke(ctx, SerialService_SendAT_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serialServiceClient) StreamRead(ctx context.Context, in *StreamReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[0], SerialService_StreamRead_FullMethodName, cOpts...)
@@ -174,6 +384,16 @@ func (c *serialServiceClient) StreamRead(ctx context.Context, in *StreamReadRequ
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SerialService_StreamReadClient = grpc.ServerStreamingClient[DataChunk]
 
+func (c *serialServiceClient) AckStream(ctx context.Context, in *AckStreamRequest, opts ...grpc.CallOption) (*AckStreamResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckStreamResponse)
+	err := c.cc.Invoke(ctx, SerialService_AckStream_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serialServiceClient) StreamWrite(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DataChunk, StreamWriteResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[1], SerialService_StreamWrite_FullMethodName, cOpts...)
@@ -200,105 +420,545 @@ func (c *serialServiceClient) BiDirectionalStream(ctx context.Context, opts ...g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SerialService_BiDirectionalStreamClient = grpc.BidiStreamingClient[DataChunk, DataChunk]
 
-func (c *serialServiceClient) ConfigurePort(ctx context.Context, in *ConfigurePortRequest, opts ...grpc.CallOption) (*ConfigurePortResponse, error) {
+func (c *serialServiceClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SessionEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ConfigurePortResponse)
-	err := c.cc.Invoke(ctx, SerialService_ConfigurePort_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[3], SerialService_StreamEvents_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[StreamEventsRequest, SessionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *serialServiceClient) GetPortConfig(ctx context.Context, in *GetPortConfigRequest, opts ...grpc.CallOption) (*PortConfig, error) {
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamEventsClient = grpc.ServerStreamingClient[SessionEvent]
+
+func (c *serialServiceClient) SubscribeURC(ctx context.Context, in *SubscribeURCRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[URCEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PortConfig)
-	err := c.cc.Invoke(ctx, SerialService_GetPortConfig_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[4], SerialService_SubscribeURC_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[SubscribeURCRequest, URCEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *serialServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_SubscribeURCClient = grpc.ServerStreamingClient[URCEvent]
+
+func (c *serialServiceClient) RunScript(ctx context.Context, in *RunScriptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScriptEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PingResponse)
-	err := c.cc.Invoke(ctx, SerialService_Ping_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[5], SerialService_RunScript_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[RunScriptRequest, ScriptEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *serialServiceClient) GetAgentInfo(ctx context.Context, in *GetAgentInfoRequest, opts ...grpc.CallOption) (*AgentInfo, error) {
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_RunScriptClient = grpc.ServerStreamingClient[ScriptEvent]
+
+func (c *serialServiceClient) Emulate(ctx context.Context, in *EmulateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EmulatorEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AgentInfo)
-	err := c.cc.Invoke(ctx, SerialService_GetAgentInfo_FullMethodName, in, out, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[6], SerialService_Emulate_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &grpc.GenericClientStream[EmulateRequest, EmulatorEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// SerialServiceServer is the server API for SerialService service.
-// All implementations must embed UnimplementedSerialServiceServer
-// for forward compatibility.
-//
-// SerialService provides serial port management and communication operations
-type SerialServiceServer interface {
-	// Port Discovery
-	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
-	GetPortInfo(context.Context, *GetPortInfoRequest) (*PortInfo, error)
-	// Port Management
-	OpenPort(context.Context, *OpenPortRequest) (*OpenPortResponse, error)
-	ClosePort(context.Context, *ClosePortRequest) (*ClosePortResponse, error)
-	GetPortStatus(context.Context, *GetPortStatusRequest) (*PortStatus, error)
-	// Data Transfer
-	Write(context.Context, *WriteRequest) (*WriteResponse, error)
-	Read(context.Context, *ReadRequest) (*ReadResponse, error)
-	// Streaming
-	StreamRead(*StreamReadRequest, grpc.ServerStreamingServer[DataChunk]) error
-	StreamWrite(grpc.ClientStreamingServer[DataChunk, StreamWriteResponse]) error
-	BiDirectionalStream(grpc.BidiStreamingServer[DataChunk, DataChunk]) error
-	// Port Configuration
-	ConfigurePort(context.Context, *ConfigurePortRequest) (*ConfigurePortResponse, error)
-	GetPortConfig(context.Context, *GetPortConfigRequest) (*PortConfig, error)
-	// Health & Diagnostics
-	Ping(context.Context, *PingRequest) (*PingResponse, error)
-	GetAgentInfo(context.Context, *GetAgentInfoRequest) (*AgentInfo, error)
-	mustEmbedUnimplementedSerialServiceServer()
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_EmulateClient = grpc.ServerStreamingClient[EmulatorEvent]
+
+func (c *serialServiceClient) CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*CreateJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateJobResponse)
+	err := c.cc.Invoke(ctx, SerialService_CreateJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedSerialServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedSerialServiceServer struct{}
+func (c *serialServiceClient) DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteJobResponse)
+	err := c.cc.Invoke(ctx, SerialService_DeleteJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedSerialServiceServer) ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListPorts not implemented")
+func (c *serialServiceClient) GetJobResults(ctx context.Context, in *GetJobResultsRequest, opts ...grpc.CallOption) (*GetJobResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobResultsResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetJobResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) GetPortInfo(context.Context, *GetPortInfoRequest) (*PortInfo, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPortInfo not implemented")
+
+func (c *serialServiceClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, SerialService_ListGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) OpenPort(context.Context, *OpenPortRequest) (*OpenPortResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method OpenPort not implemented")
+
+func (c *serialServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGroupResponse)
+	err := c.cc.Invoke(ctx, SerialService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) ClosePort(context.Context, *ClosePortRequest) (*ClosePortResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ClosePort not implemented")
+
+func (c *serialServiceClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGroupResponse)
+	err := c.cc.Invoke(ctx, SerialService_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) GetPortStatus(context.Context, *GetPortStatusRequest) (*PortStatus, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPortStatus not implemented")
+
+func (c *serialServiceClient) OpenGroup(ctx context.Context, in *OpenGroupRequest, opts ...grpc.CallOption) (*OpenGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenGroupResponse)
+	err := c.cc.Invoke(ctx, SerialService_OpenGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) Write(context.Context, *WriteRequest) (*WriteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Write not implemented")
+
+func (c *serialServiceClient) CloseGroup(ctx context.Context, in *CloseGroupRequest, opts ...grpc.CallOption) (*CloseGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseGroupResponse)
+	err := c.cc.Invoke(ctx, SerialService_CloseGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+
+func (c *serialServiceClient) WriteGroup(ctx context.Context, in *WriteGroupRequest, opts ...grpc.CallOption) (*WriteGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteGroupResponse)
+	err := c.cc.Invoke(ctx, SerialService_WriteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedSerialServiceServer) StreamRead(*StreamReadRequest, grpc.ServerStreamingServer[DataChunk]) error {
-	return status.Errorf(codes.Unimplemented, "method StreamRead not implemented")
+
+func (c *serialServiceClient) StreamGroup(ctx context.Context, in *StreamGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[7], SerialService_StreamGroup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamGroupRequest, DataChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamGroupClient = grpc.ServerStreamingClient[DataChunk]
+
+func (c *serialServiceClient) ConfigurePort(ctx context.Context, in *ConfigurePortRequest, opts ...grpc.CallOption) (*ConfigurePortResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigurePortResponse)
+	err := c.cc.Invoke(ctx, SerialService_ConfigurePort_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetPortConfig(ctx context.Context, in *GetPortConfigRequest, opts ...grpc.CallOption) (*PortConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PortConfig)
+	err := c.cc.Invoke(ctx, SerialService_GetPortConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) AddTap(ctx context.Context, in *AddTapRequest, opts ...grpc.CallOption) (*AddTapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTapResponse)
+	err := c.cc.Invoke(ctx, SerialService_AddTap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) RemoveTap(ctx context.Context, in *RemoveTapRequest, opts ...grpc.CallOption) (*RemoveTapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTapResponse)
+	err := c.cc.Invoke(ctx, SerialService_RemoveTap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) StartPassthrough(ctx context.Context, in *StartPassthroughRequest, opts ...grpc.CallOption) (*StartPassthroughResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPassthroughResponse)
+	err := c.cc.Invoke(ctx, SerialService_StartPassthrough_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) StopPassthrough(ctx context.Context, in *StopPassthroughRequest, opts ...grpc.CallOption) (*StopPassthroughResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopPassthroughResponse)
+	err := c.cc.Invoke(ctx, SerialService_StopPassthrough_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ProxyPorts(ctx context.Context, in *ProxyPortsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProxyEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SerialService_ServiceDesc.Streams[8], SerialService_ProxyPorts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProxyPortsRequest, ProxyEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_ProxyPortsClient = grpc.ServerStreamingClient[ProxyEvent]
+
+func (c *serialServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, SerialService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetAgentInfo(ctx context.Context, in *GetAgentInfoRequest, opts ...grpc.CallOption) (*AgentInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AgentInfo)
+	err := c.cc.Invoke(ctx, SerialService_GetAgentInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) TestPort(ctx context.Context, in *TestPortRequest, opts ...grpc.CallOption) (*TestPortReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestPortReport)
+	err := c.cc.Invoke(ctx, SerialService_TestPort_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) IdentifyDevice(ctx context.Context, in *IdentifyDeviceRequest, opts ...grpc.CallOption) (*IdentifyDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IdentifyDeviceResponse)
+	err := c.cc.Invoke(ctx, SerialService_IdentifyDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetStatistics(ctx context.Context, in *GetStatisticsRequest, opts ...grpc.CallOption) (*GetStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatisticsResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) CreateAccessLink(ctx context.Context, in *CreateAccessLinkRequest, opts ...grpc.CallOption) (*AccessLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccessLink)
+	err := c.cc.Invoke(ctx, SerialService_CreateAccessLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, SerialService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ListClients(ctx context.Context, in *ListClientsRequest, opts ...grpc.CallOption) (*ListClientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClientsResponse)
+	err := c.cc.Invoke(ctx, SerialService_ListClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ForceClose(ctx context.Context, in *ForceCloseRequest, opts ...grpc.CallOption) (*ForceCloseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForceCloseResponse)
+	err := c.cc.Invoke(ctx, SerialService_ForceClose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) ResetDevice(ctx context.Context, in *ResetDeviceRequest, opts ...grpc.CallOption) (*ResetDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetDeviceResponse)
+	err := c.cc.Invoke(ctx, SerialService_ResetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuditLogResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetSessionHistory(ctx context.Context, in *GetSessionHistoryRequest, opts ...grpc.CallOption) (*GetSessionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSessionHistoryResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetSessionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serialServiceClient) GetCaptureIndex(ctx context.Context, in *GetCaptureIndexRequest, opts ...grpc.CallOption) (*GetCaptureIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCaptureIndexResponse)
+	err := c.cc.Invoke(ctx, SerialService_GetCaptureIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SerialServiceServer is the server API for SerialService service.
+// All implementations must embed UnimplementedSerialServiceServer
+// for forward compatibility.
+//
+// SerialService provides serial port management and communication operations
+type SerialServiceServer interface {
+	// Port Discovery
+	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
+	GetPortInfo(context.Context, *GetPortInfoRequest) (*PortInfo, error)
+	// Port Management
+	OpenPort(context.Context, *OpenPortRequest) (*OpenPortResponse, error)
+	ClosePort(context.Context, *ClosePortRequest) (*ClosePortResponse, error)
+	GetPortStatus(context.Context, *GetPortStatusRequest) (*PortStatus, error)
+	AttachSession(context.Context, *AttachSessionRequest) (*AttachSessionResponse, error)
+	DetachSession(context.Context, *DetachSessionRequest) (*DetachSessionResponse, error)
+	TakeOver(context.Context, *TakeOverRequest) (*TakeOverResponse, error)
+	GetPortHolders(context.Context, *GetPortHoldersRequest) (*GetPortHoldersResponse, error)
+	// Data Transfer
+	Write(context.Context, *WriteRequest) (*WriteResponse, error)
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	QueueWrite(context.Context, *QueueWriteRequest) (*QueueWriteResponse, error)
+	Transact(context.Context, *TransactRequest) (*TransactResponse, error)
+	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
+	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
+	GetBufferStatus(context.Context, *GetBufferStatusRequest) (*BufferStatus, error)
+	SCPIQuery(context.Context, *SCPIQueryRequest) (*SCPIQueryResponse, error)
+	SCPIErrors(context.Context, *SCPIErrorsRequest) (*SCPIErrorsResponse, error)
+	SendAT(context.Context, *SendATRequest) (*SendATResponse, error)
+	// Streaming
+	StreamRead(*StreamReadRequest, grpc.ServerStreamingServer[DataChunk]) error
+	AckStream(context.Context, *AckStreamRequest) (*AckStreamResponse, error)
+	StreamWrite(grpc.ClientStreamingServer[DataChunk, StreamWriteResponse]) error
+	BiDirectionalStream(grpc.BidiStreamingServer[DataChunk, DataChunk]) error
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[SessionEvent]) error
+	SubscribeURC(*SubscribeURCRequest, grpc.ServerStreamingServer[URCEvent]) error
+	// Scripting
+	RunScript(*RunScriptRequest, grpc.ServerStreamingServer[ScriptEvent]) error
+	Emulate(*EmulateRequest, grpc.ServerStreamingServer[EmulatorEvent]) error
+	// Scheduled Jobs
+	CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error)
+	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	GetJobResults(context.Context, *GetJobResultsRequest) (*GetJobResultsResponse, error)
+	// Port Groups
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	OpenGroup(context.Context, *OpenGroupRequest) (*OpenGroupResponse, error)
+	CloseGroup(context.Context, *CloseGroupRequest) (*CloseGroupResponse, error)
+	WriteGroup(context.Context, *WriteGroupRequest) (*WriteGroupResponse, error)
+	StreamGroup(*StreamGroupRequest, grpc.ServerStreamingServer[DataChunk]) error
+	// Port Configuration
+	ConfigurePort(context.Context, *ConfigurePortRequest) (*ConfigurePortResponse, error)
+	GetPortConfig(context.Context, *GetPortConfigRequest) (*PortConfig, error)
+	AddTap(context.Context, *AddTapRequest) (*AddTapResponse, error)
+	RemoveTap(context.Context, *RemoveTapRequest) (*RemoveTapResponse, error)
+	StartPassthrough(context.Context, *StartPassthroughRequest) (*StartPassthroughResponse, error)
+	StopPassthrough(context.Context, *StopPassthroughRequest) (*StopPassthroughResponse, error)
+	ProxyPorts(*ProxyPortsRequest, grpc.ServerStreamingServer[ProxyEvent]) error
+	// Health & Diagnostics
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	GetAgentInfo(context.Context, *GetAgentInfoRequest) (*AgentInfo, error)
+	TestPort(context.Context, *TestPortRequest) (*TestPortReport, error)
+	IdentifyDevice(context.Context, *IdentifyDeviceRequest) (*IdentifyDeviceResponse, error)
+	GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error)
+	// Administration
+	CreateAccessLink(context.Context, *CreateAccessLinkRequest) (*AccessLink, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error)
+	ForceClose(context.Context, *ForceCloseRequest) (*ForceCloseResponse, error)
+	ResetDevice(context.Context, *ResetDeviceRequest) (*ResetDeviceResponse, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error)
+	GetSessionHistory(context.Context, *GetSessionHistoryRequest) (*GetSessionHistoryResponse, error)
+	GetCaptureIndex(context.Context, *GetCaptureIndexRequest) (*GetCaptureIndexResponse, error)
+	mustEmbedUnimplementedSerialServiceServer()
+}
+
+// UnimplementedSerialServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSerialServiceServer struct{}
+
+func (UnimplementedSerialServiceServer) ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPorts not implemented")
+}
+func (UnimplementedSerialServiceServer) GetPortInfo(context.Context, *GetPortInfoRequest) (*PortInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortInfo not implemented")
+}
+func (UnimplementedSerialServiceServer) OpenPort(context.Context, *OpenPortRequest) (*OpenPortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenPort not implemented")
+}
+func (UnimplementedSerialServiceServer) ClosePort(context.Context, *ClosePortRequest) (*ClosePortResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosePort not implemented")
+}
+func (UnimplementedSerialServiceServer) GetPortStatus(context.Context, *GetPortStatusRequest) (*PortStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortStatus not implemented")
+}
+func (UnimplementedSerialServiceServer) AttachSession(context.Context, *AttachSessionRequest) (*AttachSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachSession not implemented")
+}
+func (UnimplementedSerialServiceServer) DetachSession(context.Context, *DetachSessionRequest) (*DetachSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachSession not implemented")
+}
+func (UnimplementedSerialServiceServer) TakeOver(context.Context, *TakeOverRequest) (*TakeOverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeOver not implemented")
+}
+func (UnimplementedSerialServiceServer) GetPortHolders(context.Context, *GetPortHoldersRequest) (*GetPortHoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortHolders not implemented")
+}
+func (UnimplementedSerialServiceServer) Write(context.Context, *WriteRequest) (*WriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Write not implemented")
+}
+func (UnimplementedSerialServiceServer) Read(context.Context, *ReadRequest) (*ReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (UnimplementedSerialServiceServer) QueueWrite(context.Context, *QueueWriteRequest) (*QueueWriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueueWrite not implemented")
+}
+func (UnimplementedSerialServiceServer) Transact(context.Context, *TransactRequest) (*TransactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transact not implemented")
+}
+func (UnimplementedSerialServiceServer) WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteBatch not implemented")
+}
+func (UnimplementedSerialServiceServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
+}
+func (UnimplementedSerialServiceServer) GetBufferStatus(context.Context, *GetBufferStatusRequest) (*BufferStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBufferStatus not implemented")
+}
+func (UnimplementedSerialServiceServer) SCPIQuery(context.Context, *SCPIQueryRequest) (*SCPIQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SCPIQuery not implemented")
+}
+func (UnimplementedSerialServiceServer) SCPIErrors(context.Context, *SCPIErrorsRequest) (*SCPIErrorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SCPIErrors not implemented")
+}
+func (UnimplementedSerialServiceServer) SendAT(context.Context, *SendATRequest) (*SendATResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendAT not implemented")
+}
+func (UnimplementedSerialServiceServer) StreamRead(*StreamReadRequest, grpc.ServerStreamingServer[DataChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRead not implemented")
+}
+func (UnimplementedSerialServiceServer) AckStream(context.Context, *AckStreamRequest) (*AckStreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckStream not implemented")
 }
 func (UnimplementedSerialServiceServer) StreamWrite(grpc.ClientStreamingServer[DataChunk, StreamWriteResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamWrite not implemented")
@@ -306,19 +966,109 @@ func (UnimplementedSerialServiceServer) StreamWrite(grpc.ClientStreamingServer[D
 func (UnimplementedSerialServiceServer) BiDirectionalStream(grpc.BidiStreamingServer[DataChunk, DataChunk]) error {
 	return status.Errorf(codes.Unimplemented, "method BiDirectionalStream not implemented")
 }
+func (UnimplementedSerialServiceServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[SessionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedSerialServiceServer) SubscribeURC(*SubscribeURCRequest, grpc.ServerStreamingServer[URCEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeURC not implemented")
+}
+func (UnimplementedSerialServiceServer) RunScript(*RunScriptRequest, grpc.ServerStreamingServer[ScriptEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method RunScript not implemented")
+}
+func (UnimplementedSerialServiceServer) Emulate(*EmulateRequest, grpc.ServerStreamingServer[EmulatorEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Emulate not implemented")
+}
+func (UnimplementedSerialServiceServer) CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJob not implemented")
+}
+func (UnimplementedSerialServiceServer) DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (UnimplementedSerialServiceServer) GetJobResults(context.Context, *GetJobResultsRequest) (*GetJobResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobResults not implemented")
+}
+func (UnimplementedSerialServiceServer) ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedSerialServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedSerialServiceServer) DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedSerialServiceServer) OpenGroup(context.Context, *OpenGroupRequest) (*OpenGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenGroup not implemented")
+}
+func (UnimplementedSerialServiceServer) CloseGroup(context.Context, *CloseGroupRequest) (*CloseGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseGroup not implemented")
+}
+func (UnimplementedSerialServiceServer) WriteGroup(context.Context, *WriteGroupRequest) (*WriteGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteGroup not implemented")
+}
+func (UnimplementedSerialServiceServer) StreamGroup(*StreamGroupRequest, grpc.ServerStreamingServer[DataChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamGroup not implemented")
+}
 func (UnimplementedSerialServiceServer) ConfigurePort(context.Context, *ConfigurePortRequest) (*ConfigurePortResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfigurePort not implemented")
 }
 func (UnimplementedSerialServiceServer) GetPortConfig(context.Context, *GetPortConfigRequest) (*PortConfig, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPortConfig not implemented")
 }
+func (UnimplementedSerialServiceServer) AddTap(context.Context, *AddTapRequest) (*AddTapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTap not implemented")
+}
+func (UnimplementedSerialServiceServer) RemoveTap(context.Context, *RemoveTapRequest) (*RemoveTapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTap not implemented")
+}
+func (UnimplementedSerialServiceServer) StartPassthrough(context.Context, *StartPassthroughRequest) (*StartPassthroughResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPassthrough not implemented")
+}
+func (UnimplementedSerialServiceServer) StopPassthrough(context.Context, *StopPassthroughRequest) (*StopPassthroughResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopPassthrough not implemented")
+}
+func (UnimplementedSerialServiceServer) ProxyPorts(*ProxyPortsRequest, grpc.ServerStreamingServer[ProxyEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method ProxyPorts not implemented")
+}
 func (UnimplementedSerialServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
 func (UnimplementedSerialServiceServer) GetAgentInfo(context.Context, *GetAgentInfoRequest) (*AgentInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAgentInfo not implemented")
 }
-func (UnimplementedSerialServiceServer) mustEmbedUnimplementedSerialServiceServer() {}
+func (UnimplementedSerialServiceServer) TestPort(context.Context, *TestPortRequest) (*TestPortReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPort not implemented")
+}
+func (UnimplementedSerialServiceServer) IdentifyDevice(context.Context, *IdentifyDeviceRequest) (*IdentifyDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentifyDevice not implemented")
+}
+func (UnimplementedSerialServiceServer) GetStatistics(context.Context, *GetStatisticsRequest) (*GetStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatistics not implemented")
+}
+func (UnimplementedSerialServiceServer) CreateAccessLink(context.Context, *CreateAccessLinkRequest) (*AccessLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccessLink not implemented")
+}
+func (UnimplementedSerialServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedSerialServiceServer) ListClients(context.Context, *ListClientsRequest) (*ListClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClients not implemented")
+}
+func (UnimplementedSerialServiceServer) ForceClose(context.Context, *ForceCloseRequest) (*ForceCloseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceClose not implemented")
+}
+func (UnimplementedSerialServiceServer) ResetDevice(context.Context, *ResetDeviceRequest) (*ResetDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetDevice not implemented")
+}
+func (UnimplementedSerialServiceServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedSerialServiceServer) GetSessionHistory(context.Context, *GetSessionHistoryRequest) (*GetSessionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionHistory not implemented")
+}
+func (UnimplementedSerialServiceServer) GetCaptureIndex(context.Context, *GetCaptureIndexRequest) (*GetCaptureIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCaptureIndex not implemented")
+}
+func (UnimplementedSerialServiceServer) mustEmbedUnimplementedSerialServiceServer() {}
 func (UnimplementedSerialServiceServer) testEmbeddedByValue()                       {}
 
 // UnsafeSerialServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -328,236 +1078,968 @@ type UnsafeSerialServiceServer interface {
 	mustEmbedUnimplementedSerialServiceServer()
 }
 
-func RegisterSerialServiceServer(s grpc.ServiceRegistrar, srv SerialServiceServer) {
-	// If the following call pancis, it indicates UnimplementedSerialServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func RegisterSerialServiceServer(s grpc.ServiceRegistrar, srv SerialServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSerialServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SerialService_ServiceDesc, srv)
+}
+
+func _SerialService_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ListPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ListPorts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ListPorts(ctx, req.(*ListPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetPortInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetPortInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetPortInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetPortInfo(ctx, req.(*GetPortInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_OpenPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenPortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).OpenPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_OpenPort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).OpenPort(ctx, req.(*OpenPortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_ClosePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ClosePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ClosePort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ClosePort(ctx, req.(*ClosePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetPortStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetPortStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetPortStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetPortStatus(ctx, req.(*GetPortStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_AttachSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).AttachSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_AttachSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).AttachSession(ctx, req.(*AttachSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_DetachSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).DetachSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_DetachSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).DetachSession(ctx, req.(*DetachSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_TakeOver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeOverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).TakeOver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_TakeOver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).TakeOver(ctx, req.(*TakeOverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetPortHolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortHoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetPortHolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetPortHolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetPortHolders(ctx, req.(*GetPortHoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_Write_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).Write(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_Write_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).Write(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_Read_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_QueueWrite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueWriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).QueueWrite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_QueueWrite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).QueueWrite(ctx, req.(*QueueWriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_Transact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).Transact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_Transact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).Transact(ctx, req.(*TransactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_WriteBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).WriteBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_WriteBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).WriteBatch(ctx, req.(*WriteBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).Flush(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_Flush_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).Flush(ctx, req.(*FlushRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetBufferStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBufferStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetBufferStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetBufferStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetBufferStatus(ctx, req.(*GetBufferStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_SCPIQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SCPIQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).SCPIQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_SCPIQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).SCPIQuery(ctx, req.(*SCPIQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_SCPIErrors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SCPIErrorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).SCPIErrors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_SCPIErrors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).SCPIErrors(ctx, req.(*SCPIErrorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_SendAT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendATRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).SendAT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_SendAT_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).SendAT(ctx, req.(*SendATRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_StreamRead_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).StreamRead(m, &grpc.GenericServerStream[StreamReadRequest, DataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamReadServer = grpc.ServerStreamingServer[DataChunk]
+
+func _SerialService_AckStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).AckStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_AckStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).AckStream(ctx, req.(*AckStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_StreamWrite_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SerialServiceServer).StreamWrite(&grpc.GenericServerStream[DataChunk, StreamWriteResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamWriteServer = grpc.ClientStreamingServer[DataChunk, StreamWriteResponse]
+
+func _SerialService_BiDirectionalStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SerialServiceServer).BiDirectionalStream(&grpc.GenericServerStream[DataChunk, DataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_BiDirectionalStreamServer = grpc.BidiStreamingServer[DataChunk, DataChunk]
+
+func _SerialService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, SessionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamEventsServer = grpc.ServerStreamingServer[SessionEvent]
+
+func _SerialService_SubscribeURC_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeURCRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).SubscribeURC(m, &grpc.GenericServerStream[SubscribeURCRequest, URCEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_SubscribeURCServer = grpc.ServerStreamingServer[URCEvent]
+
+func _SerialService_RunScript_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunScriptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).RunScript(m, &grpc.GenericServerStream[RunScriptRequest, ScriptEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_RunScriptServer = grpc.ServerStreamingServer[ScriptEvent]
+
+func _SerialService_Emulate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EmulateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).Emulate(m, &grpc.GenericServerStream[EmulateRequest, EmulatorEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_EmulateServer = grpc.ServerStreamingServer[EmulatorEvent]
+
+func _SerialService_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).CreateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_CreateJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).CreateJob(ctx, req.(*CreateJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_DeleteJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).DeleteJob(ctx, req.(*DeleteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetJobResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetJobResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetJobResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetJobResults(ctx, req.(*GetJobResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_DeleteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_OpenGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).OpenGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_OpenGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).OpenGroup(ctx, req.(*OpenGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_CloseGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).CloseGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_CloseGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).CloseGroup(ctx, req.(*CloseGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_WriteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).WriteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_WriteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).WriteGroup(ctx, req.(*WriteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_StreamGroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamGroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).StreamGroup(m, &grpc.GenericServerStream[StreamGroupRequest, DataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_StreamGroupServer = grpc.ServerStreamingServer[DataChunk]
+
+func _SerialService_ConfigurePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigurePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ConfigurePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ConfigurePort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ConfigurePort(ctx, req.(*ConfigurePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_GetPortConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetPortConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetPortConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetPortConfig(ctx, req.(*GetPortConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SerialService_AddTap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&SerialService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(SerialServiceServer).AddTap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_AddTap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).AddTap(ctx, req.(*AddTapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPortsRequest)
+func _SerialService_RemoveTap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTapRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).ListPorts(ctx, in)
+		return srv.(SerialServiceServer).RemoveTap(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_ListPorts_FullMethodName,
+		FullMethod: SerialService_RemoveTap_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).ListPorts(ctx, req.(*ListPortsRequest))
+		return srv.(SerialServiceServer).RemoveTap(ctx, req.(*RemoveTapRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_GetPortInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPortInfoRequest)
+func _SerialService_StartPassthrough_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPassthroughRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).GetPortInfo(ctx, in)
+		return srv.(SerialServiceServer).StartPassthrough(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_GetPortInfo_FullMethodName,
+		FullMethod: SerialService_StartPassthrough_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).GetPortInfo(ctx, req.(*GetPortInfoRequest))
+		return srv.(SerialServiceServer).StartPassthrough(ctx, req.(*StartPassthroughRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_OpenPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenPortRequest)
+func _SerialService_StopPassthrough_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopPassthroughRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).OpenPort(ctx, in)
+		return srv.(SerialServiceServer).StopPassthrough(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_OpenPort_FullMethodName,
+		FullMethod: SerialService_StopPassthrough_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).OpenPort(ctx, req.(*OpenPortRequest))
+		return srv.(SerialServiceServer).StopPassthrough(ctx, req.(*StopPassthroughRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_ClosePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClosePortRequest)
+func _SerialService_ProxyPorts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProxyPortsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SerialServiceServer).ProxyPorts(m, &grpc.GenericServerStream[ProxyPortsRequest, ProxyEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SerialService_ProxyPortsServer = grpc.ServerStreamingServer[ProxyEvent]
+
+func _SerialService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).ClosePort(ctx, in)
+		return srv.(SerialServiceServer).Ping(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_ClosePort_FullMethodName,
+		FullMethod: SerialService_Ping_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).ClosePort(ctx, req.(*ClosePortRequest))
+		return srv.(SerialServiceServer).Ping(ctx, req.(*PingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_GetPortStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPortStatusRequest)
+func _SerialService_GetAgentInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).GetPortStatus(ctx, in)
+		return srv.(SerialServiceServer).GetAgentInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_GetPortStatus_FullMethodName,
+		FullMethod: SerialService_GetAgentInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).GetPortStatus(ctx, req.(*GetPortStatusRequest))
+		return srv.(SerialServiceServer).GetAgentInfo(ctx, req.(*GetAgentInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_Write_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteRequest)
+func _SerialService_TestPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPortRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).Write(ctx, in)
+		return srv.(SerialServiceServer).TestPort(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_Write_FullMethodName,
+		FullMethod: SerialService_TestPort_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).Write(ctx, req.(*WriteRequest))
+		return srv.(SerialServiceServer).TestPort(ctx, req.(*TestPortRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadRequest)
+func _SerialService_IdentifyDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentifyDeviceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).Read(ctx, in)
+		return srv.(SerialServiceServer).IdentifyDevice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_Read_FullMethodName,
+		FullMethod: SerialService_IdentifyDevice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).Read(ctx, req.(*ReadRequest))
+		return srv.(SerialServiceServer).IdentifyDevice(ctx, req.(*IdentifyDeviceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_StreamRead_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StreamReadRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SerialService_GetStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatisticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SerialServiceServer).StreamRead(m, &grpc.GenericServerStream[StreamReadRequest, DataChunk]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(SerialServiceServer).GetStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_GetStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).GetStatistics(ctx, req.(*GetStatisticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SerialService_StreamReadServer = grpc.ServerStreamingServer[DataChunk]
-
-func _SerialService_StreamWrite_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SerialServiceServer).StreamWrite(&grpc.GenericServerStream[DataChunk, StreamWriteResponse]{ServerStream: stream})
+func _SerialService_CreateAccessLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccessLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).CreateAccessLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_CreateAccessLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).CreateAccessLink(ctx, req.(*CreateAccessLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SerialService_StreamWriteServer = grpc.ClientStreamingServer[DataChunk, StreamWriteResponse]
+func _SerialService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func _SerialService_BiDirectionalStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SerialServiceServer).BiDirectionalStream(&grpc.GenericServerStream[DataChunk, DataChunk]{ServerStream: stream})
+func _SerialService_ListClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ListClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ListClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ListClients(ctx, req.(*ListClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SerialService_BiDirectionalStreamServer = grpc.BidiStreamingServer[DataChunk, DataChunk]
+func _SerialService_ForceClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceCloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SerialServiceServer).ForceClose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SerialService_ForceClose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SerialServiceServer).ForceClose(ctx, req.(*ForceCloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func _SerialService_ConfigurePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigurePortRequest)
+func _SerialService_ResetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetDeviceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).ConfigurePort(ctx, in)
+		return srv.(SerialServiceServer).ResetDevice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_ConfigurePort_FullMethodName,
+		FullMethod: SerialService_ResetDevice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).ConfigurePort(ctx, req.(*ConfigurePortRequest))
+		return srv.(SerialServiceServer).ResetDevice(ctx, req.(*ResetDeviceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_GetPortConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPortConfigRequest)
+func _SerialService_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).GetPortConfig(ctx, in)
+		return srv.(SerialServiceServer).GetAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_GetPortConfig_FullMethodName,
+		FullMethod: SerialService_GetAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).GetPortConfig(ctx, req.(*GetPortConfigRequest))
+		return srv.(SerialServiceServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PingRequest)
+func _SerialService_GetSessionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).Ping(ctx, in)
+		return srv.(SerialServiceServer).GetSessionHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_Ping_FullMethodName,
+		FullMethod: SerialService_GetSessionHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).Ping(ctx, req.(*PingRequest))
+		return srv.(SerialServiceServer).GetSessionHistory(ctx, req.(*GetSessionHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SerialService_GetAgentInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAgentInfoRequest)
+func _SerialService_GetCaptureIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCaptureIndexRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SerialServiceServer).GetAgentInfo(ctx, in)
+		return srv.(SerialServiceServer).GetCaptureIndex(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SerialService_GetAgentInfo_FullMethodName,
+		FullMethod: SerialService_GetCaptureIndex_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SerialServiceServer).GetAgentInfo(ctx, req.(*GetAgentInfoRequest))
+		return srv.(SerialServiceServer).GetCaptureIndex(ctx, req.(*GetCaptureIndexRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -589,6 +2071,22 @@ var SerialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPortStatus",
 			Handler:    _SerialService_GetPortStatus_Handler,
 		},
+		{
+			MethodName: "AttachSession",
+			Handler:    _SerialService_AttachSession_Handler,
+		},
+		{
+			MethodName: "DetachSession",
+			Handler:    _SerialService_DetachSession_Handler,
+		},
+		{
+			MethodName: "TakeOver",
+			Handler:    _SerialService_TakeOver_Handler,
+		},
+		{
+			MethodName: "GetPortHolders",
+			Handler:    _SerialService_GetPortHolders_Handler,
+		},
 		{
 			MethodName: "Write",
 			Handler:    _SerialService_Write_Handler,
@@ -597,6 +2095,78 @@ var SerialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Read",
 			Handler:    _SerialService_Read_Handler,
 		},
+		{
+			MethodName: "QueueWrite",
+			Handler:    _SerialService_QueueWrite_Handler,
+		},
+		{
+			MethodName: "Transact",
+			Handler:    _SerialService_Transact_Handler,
+		},
+		{
+			MethodName: "WriteBatch",
+			Handler:    _SerialService_WriteBatch_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _SerialService_Flush_Handler,
+		},
+		{
+			MethodName: "GetBufferStatus",
+			Handler:    _SerialService_GetBufferStatus_Handler,
+		},
+		{
+			MethodName: "SCPIQuery",
+			Handler:    _SerialService_SCPIQuery_Handler,
+		},
+		{
+			MethodName: "SCPIErrors",
+			Handler:    _SerialService_SCPIErrors_Handler,
+		},
+		{
+			MethodName: "SendAT",
+			Handler:    _SerialService_SendAT_Handler,
+		},
+		{
+			MethodName: "AckStream",
+			Handler:    _SerialService_AckStream_Handler,
+		},
+		{
+			MethodName: "CreateJob",
+			Handler:    _SerialService_CreateJob_Handler,
+		},
+		{
+			MethodName: "DeleteJob",
+			Handler:    _SerialService_DeleteJob_Handler,
+		},
+		{
+			MethodName: "GetJobResults",
+			Handler:    _SerialService_GetJobResults_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _SerialService_ListGroups_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _SerialService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _SerialService_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "OpenGroup",
+			Handler:    _SerialService_OpenGroup_Handler,
+		},
+		{
+			MethodName: "CloseGroup",
+			Handler:    _SerialService_CloseGroup_Handler,
+		},
+		{
+			MethodName: "WriteGroup",
+			Handler:    _SerialService_WriteGroup_Handler,
+		},
 		{
 			MethodName: "ConfigurePort",
 			Handler:    _SerialService_ConfigurePort_Handler,
@@ -605,6 +2175,22 @@ var SerialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPortConfig",
 			Handler:    _SerialService_GetPortConfig_Handler,
 		},
+		{
+			MethodName: "AddTap",
+			Handler:    _SerialService_AddTap_Handler,
+		},
+		{
+			MethodName: "RemoveTap",
+			Handler:    _SerialService_RemoveTap_Handler,
+		},
+		{
+			MethodName: "StartPassthrough",
+			Handler:    _SerialService_StartPassthrough_Handler,
+		},
+		{
+			MethodName: "StopPassthrough",
+			Handler:    _SerialService_StopPassthrough_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _SerialService_Ping_Handler,
@@ -613,6 +2199,50 @@ var SerialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAgentInfo",
 			Handler:    _SerialService_GetAgentInfo_Handler,
 		},
+		{
+			MethodName: "TestPort",
+			Handler:    _SerialService_TestPort_Handler,
+		},
+		{
+			MethodName: "IdentifyDevice",
+			Handler:    _SerialService_IdentifyDevice_Handler,
+		},
+		{
+			MethodName: "GetStatistics",
+			Handler:    _SerialService_GetStatistics_Handler,
+		},
+		{
+			MethodName: "CreateAccessLink",
+			Handler:    _SerialService_CreateAccessLink_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _SerialService_ListSessions_Handler,
+		},
+		{
+			MethodName: "ListClients",
+			Handler:    _SerialService_ListClients_Handler,
+		},
+		{
+			MethodName: "ForceClose",
+			Handler:    _SerialService_ForceClose_Handler,
+		},
+		{
+			MethodName: "ResetDevice",
+			Handler:    _SerialService_ResetDevice_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _SerialService_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetSessionHistory",
+			Handler:    _SerialService_GetSessionHistory_Handler,
+		},
+		{
+			MethodName: "GetCaptureIndex",
+			Handler:    _SerialService_GetCaptureIndex_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -631,6 +2261,36 @@ var SerialService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _SerialService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeURC",
+			Handler:       _SerialService_SubscribeURC_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RunScript",
+			Handler:       _SerialService_RunScript_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Emulate",
+			Handler:       _SerialService_Emulate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamGroup",
+			Handler:       _SerialService_StreamGroup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ProxyPorts",
+			Handler:       _SerialService_ProxyPorts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "serial.proto",
 }
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// ProxyPorts connects two ports back to back and streams the traffic
+// between them until the client cancels or either port fails
+func (s *SerialServer) ProxyPorts(req *pb.ProxyPortsRequest, stream pb.SerialService_ProxyPortsServer) error {
+	if req.PortName == "" || req.PeerPortName == "" {
+		return status.Error(codes.InvalidArgument, "port_name and peer_port_name are required")
+	}
+	if s.shuttingDown() {
+		return errShuttingDown
+	}
+	ctx := stream.Context()
+
+	// The alias and auth interceptors only see port_name
+	peer := req.PeerPortName
+	if s.scanner.IsAlias(peer) {
+		resolved, err := s.scanner.Resolve(peer)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "failed to resolve %s: %v", peer, err)
+		}
+		peer = resolved
+	}
+	if err := checkGroupAccess(ctx, peer, true); err != nil {
+		return err
+	}
+
+	clientID := req.ClientId
+	if clientID == "" {
+		clientID = "default-client"
+	}
+
+	entry := audit.Entry{Operation: "ProxyPorts", ClientID: clientID, PortName: req.PortName, Message: "proxy to " + peer}
+
+	p, err := s.manager.StartProxy(req.PortName, peer, serial.ProxyOptions{
+		ClientID:    clientID,
+		Config:      s.requestPortConfig(req.PortName, req.Config),
+		PeerConfig:  s.requestPortConfig(peer, req.PeerConfig),
+		FiltersAToB: req.FiltersAToB,
+		FiltersBToA: req.FiltersBToA,
+		DelayAToB:   time.Duration(req.DelayAToBMs) * time.Millisecond,
+		DelayBToA:   time.Duration(req.DelayBToAMs) * time.Millisecond,
+	})
+	if err != nil {
+		entry.Message = err.Error()
+		s.record(ctx, entry)
+		if errors.Is(err, serial.ErrProxySamePort) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serial.ErrPortLocked) {
+			return status.Errorf(codes.FailedPrecondition, "failed to start proxy: %v", err)
+		}
+		return status.Errorf(codes.Internal, "failed to start proxy: %v", err)
+	}
+	defer p.Stop()
+
+	for _, tap := range req.Taps {
+		if _, err := s.manager.AddTap(p.A.PortName, p.A.ID, convertTapConfig(tap)); err != nil {
+			entry.Message = "failed to start tap: " + err.Error()
+			s.record(ctx, entry)
+			return status.Errorf(codes.InvalidArgument, "failed to start tap: %v", err)
+		}
+	}
+
+	entry.SessionID = p.A.ID
+	entry.Success = true
+	s.record(ctx, entry)
+
+	if err := stream.Send(&pb.ProxyEvent{Started: true}); err != nil {
+		return err
+	}
+
+	shutdown := s.shutdown
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-shutdown:
+			shutdown = nil
+			if err := stream.Send(&pb.ProxyEvent{Shutdown: true}); err != nil {
+				return err
+			}
+		case <-p.Done():
+			return status.Errorf(codes.Aborted, "proxy stopped: %v", p.Err())
+		case event := <-p.Events():
+			if err := stream.Send(convertProxyEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// requestPortConfig returns the configuration for a port the agent opens on
+// a client's behalf: the given config, else the port's profile, else the
+// agent defaults
+func (s *SerialServer) requestPortConfig(portName string, cfg *pb.PortConfig) serial.PortConfig {
+	if cfg == nil {
+		if profile := s.profileForName(portName); profile != nil {
+			return s.settingsToPortConfig(profile.Settings)
+		}
+	}
+	return s.convertToSerialConfig(cfg)
+}
+
+func convertProxyEvent(event serial.ProxyEvent) *pb.ProxyEvent {
+	direction := pb.ProxyDirection_PROXY_DIRECTION_A_TO_B
+	if event.Direction == serial.ProxyBToA {
+		direction = pb.ProxyDirection_PROXY_DIRECTION_B_TO_A
+	}
+	result := &pb.ProxyEvent{
+		Direction: direction,
+		Data:      event.Data,
+		Forwarded: event.Forwarded,
+		Timestamp: event.Time.UnixNano(),
+		Sequence:  event.Sequence,
+		Lost:      event.Lost,
+	}
+	if event.FilterError != nil {
+		result.FilterError = event.FilterError.Error()
+	}
+	return result
+}
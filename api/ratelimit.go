@@ -0,0 +1,110 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/ratelimit"
+)
+
+// dataCarrier is implemented by request messages that carry data for the port
+type dataCarrier interface {
+	GetData() []byte
+}
+
+// RateLimitInterceptor limits the request and byte rate of each client.
+// Clients are identified by their token name, or by their address when
+// authentication is disabled.
+type RateLimitInterceptor struct {
+	limiter *ratelimit.Limiter
+}
+
+// NewRateLimitInterceptor creates a new rate limiting interceptor
+func NewRateLimitInterceptor(limiter *ratelimit.Limiter) *RateLimitInterceptor {
+	return &RateLimitInterceptor{limiter: limiter}
+}
+
+// Unary returns a unary server interceptor enforcing the client rate limit
+func (r *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.allow(clientKey(ctx), req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor enforcing the client rate
+// limit on stream creation and on every received message
+func (r *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		key := clientKey(ss.Context())
+		if err := r.allow(key, nil); err != nil {
+			return err
+		}
+		return handler(srv, &rateLimitServerStream{ServerStream: ss, limiter: r, key: key})
+	}
+}
+
+// allow checks a single request against the client's limit
+func (r *RateLimitInterceptor) allow(key string, req interface{}) error {
+	n := 0
+	if dc, ok := req.(dataCarrier); ok {
+		n = len(dc.GetData())
+	}
+
+	if !r.limiter.Allow(key, n) {
+		return status.Error(codes.ResourceExhausted, "client rate limit exceeded")
+	}
+	return nil
+}
+
+// clientKey identifies the client making a request
+func clientKey(ctx context.Context) string {
+	if id, ok := auth.FromContext(ctx); ok {
+		return id.Name
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// rateLimitServerStream wraps a ServerStream to rate limit received messages
+type rateLimitServerStream struct {
+	grpc.ServerStream
+	limiter *RateLimitInterceptor
+	key     string
+}
+
+func (s *rateLimitServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.limiter.allow(s.key, m)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/crash"
+)
+
+// RecoveryInterceptor turns a panic in a handler into an Internal error for
+// that request, instead of letting it take down the agent
+type RecoveryInterceptor struct{}
+
+// NewRecoveryInterceptor creates a new panic recovering interceptor
+func NewRecoveryInterceptor() *RecoveryInterceptor {
+	return &RecoveryInterceptor{}
+}
+
+// Unary returns the unary server interceptor
+func (r *RecoveryInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(ctx, info.FullMethod, v)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor
+func (r *RecoveryInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(ss.Context(), info.FullMethod, v)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered reports a panic in the handler of method and returns the error
+// the client receives
+func recovered(ctx context.Context, method string, v interface{}) error {
+	crash.Report("grpc", v, "method", method, "client", clientKey(ctx))
+	return status.Errorf(codes.Internal, "internal error handling %s; the agent logged the details", method)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/scpi"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// SCPIQuery sends SCPI commands in order, reading the response of each
+// query, and optionally drains the instrument's error queue afterwards
+func (s *SerialServer) SCPIQuery(ctx context.Context, req *pb.SCPIQueryRequest) (*pb.SCPIQueryResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if len(req.Commands) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "commands are required")
+	}
+
+	inst := s.newInstrument(req.PortName, req.SessionId, req.TimeoutMs, req.Terminator)
+	results := inst.Batch(req.Commands, req.StopOnError)
+
+	var sent, failed int
+	var firstErr error
+	resp := &pb.SCPIQueryResponse{Success: true}
+	for _, r := range results {
+		sent += r.Sent
+		result := &pb.SCPIResult{
+			Command:   r.Command,
+			Response:  r.Response,
+			Success:   r.Err == nil,
+			ElapsedMs: uint32(r.Elapsed.Milliseconds()),
+		}
+		if r.Err != nil {
+			result.Message = r.Err.Error()
+			resp.Success = false
+			failed++
+			if firstErr == nil {
+				firstErr = r.Err
+			}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	s.recordWrite(ctx, "SCPIQuery", req.PortName, req.SessionId, sent, firstErr)
+
+	if errors.Is(firstErr, serial.ErrRateLimited) {
+		return nil, status.Error(codes.ResourceExhausted, firstErr.Error())
+	}
+
+	if req.CheckErrors {
+		errs, err := inst.Errors(0)
+		resp.Errors = convertSCPIErrors(errs)
+		if err != nil {
+			resp.Success = false
+			resp.Message = "failed to read error queue: " + err.Error()
+			return resp, nil
+		}
+		if len(errs) > 0 {
+			resp.Success = false
+		}
+	}
+
+	switch {
+	case failed > 0:
+		resp.Message = fmt.Sprintf("%d of %d commands failed: %v", failed, len(results), firstErr)
+	case len(resp.Errors) > 0:
+		resp.Message = fmt.Sprintf("instrument reported %d errors", len(resp.Errors))
+	default:
+		resp.Message = fmt.Sprintf("%d commands completed", len(results))
+	}
+
+	return resp, nil
+}
+
+// SCPIErrors drains the instrument's error queue with SYST:ERR?
+func (s *SerialServer) SCPIErrors(ctx context.Context, req *pb.SCPIErrorsRequest) (*pb.SCPIErrorsResponse, error) {
+	if req.PortName == "" {
+		return nil, status.Error(codes.InvalidArgument, "port_name is required")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	inst := s.newInstrument(req.PortName, req.SessionId, req.TimeoutMs, req.Terminator)
+	errs, err := inst.Errors(int(req.MaxErrors))
+	if errors.Is(err, serial.ErrRateLimited) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return &pb.SCPIErrorsResponse{
+			Success: false,
+			Message: err.Error(),
+			Errors:  convertSCPIErrors(errs),
+		}, nil
+	}
+
+	message := "error queue empty"
+	if len(errs) > 0 {
+		message = fmt.Sprintf("%d errors read", len(errs))
+	}
+
+	return &pb.SCPIErrorsResponse{
+		Success: true,
+		Message: message,
+		Errors:  convertSCPIErrors(errs),
+	}, nil
+}
+
+// newInstrument creates an SCPI instrument for a session
+func (s *SerialServer) newInstrument(portName, sessionID string, timeoutMs uint32, terminator string) *scpi.Instrument {
+	inst := scpi.New(s.manager, portName, sessionID)
+	inst.Timeout = time.Duration(timeoutMs) * time.Millisecond
+	if terminator != "" {
+		inst.Terminator = terminator
+	}
+	return inst
+}
+
+func convertSCPIErrors(errs []scpi.Error) []*pb.SCPIError {
+	result := make([]*pb.SCPIError, len(errs))
+	for i, e := range errs {
+		result[i] = &pb.SCPIError{Code: int32(e.Code), Message: e.Message}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// errShuttingDown refuses new sessions while the agent shuts down
+var errShuttingDown = status.Error(codes.Unavailable, "agent is shutting down")
+
+// NotifyShutdown tells clients that the agent is shutting down, so they can
+// tell the end of their streams apart from a device failure. StreamRead and
+// BiDirectionalStream streams receive a chunk marked shutdown, StreamEvents
+// subscribers an AGENT_SHUTDOWN event, and new sessions are refused. Streams
+// keep running until the server stops.
+func (s *SerialServer) NotifyShutdown(grace time.Duration) {
+	s.shutdownOnce.Do(func() {
+		close(s.shutdown)
+		s.manager.Events().Publish(serial.Event{
+			Type:    serial.EventAgentShutdown,
+			Message: fmt.Sprintf("agent shutting down; streams end within %s", grace),
+		})
+	})
+}
+
+// shuttingDown reports whether NotifyShutdown has been called
+func (s *SerialServer) shuttingDown() bool {
+	select {
+	case <-s.shutdown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// v1Server serves baudlink.serial.v1. Calls whose messages have not moved
+// away from v1 go straight to the embedded SerialServer; the methods below
+// map the version-neutral core onto v1 messages.
+type v1Server struct {
+	*SerialServer
+}
+
+// registerV1 registers the v1 serial service
+func registerV1(server *grpc.Server, s *SerialServer) {
+	pb.RegisterSerialServiceServer(server, v1Server{s})
+}
+
+// v1SupportedFeatures are the feature names of the deprecated v1
+// supported_features field, which predates capabilities
+var v1SupportedFeatures = []string{
+	"grpc",
+	"port-scan",
+	"port-lock",
+	"streaming",
+	"scripting",
+	"loopback-test",
+	"health-check",
+	"session-reconnect",
+}
+
+// GetAgentInfo returns information about the agent
+func (v v1Server) GetAgentInfo(ctx context.Context, req *pb.GetAgentInfoRequest) (*pb.AgentInfo, error) {
+	info := v.agentInfo()
+
+	caps := make([]*pb.Capability, 0, len(info.Capabilities))
+	for _, c := range info.Capabilities {
+		caps = append(caps, &pb.Capability{
+			Category: c.Category,
+			Name:     c.Name,
+			Version:  c.Version,
+			Enabled:  c.Enabled,
+		})
+	}
+
+	return &pb.AgentInfo{
+		Version:           info.Version,
+		BuildCommit:       info.BuildCommit,
+		BuildDate:         info.BuildDate,
+		Os:                info.OS,
+		Arch:              info.Arch,
+		UptimeSeconds:     int64(info.Uptime.Seconds()),
+		SupportedFeatures: v1SupportedFeatures,
+		Config: &pb.AgentConfig{
+			GrpcAddress:    info.GRPCAddress,
+			TlsEnabled:     info.TLSEnabled,
+			MaxConnections: uint32(info.Limits.MaxConnections),
+		},
+		Capabilities: caps,
+		Limits: &pb.AgentLimits{
+			MaxWriteBytes:  uint32(info.Limits.MaxWriteBytes),
+			MaxChunkSize:   uint32(info.Limits.MaxChunkSize),
+			MaxConnections: uint32(info.Limits.MaxConnections),
+		},
+		ApiVersions: info.APIVersions,
+	}, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// Bounds on request fields, beyond what the port itself would reject
+const (
+	maxPortNameLength = 256
+	maxBaudRate       = 20_000_000
+	maxTimeoutMs      = 3_600_000
+	maxDelayMs        = 60_000
+	maxFilters        = 32
+)
+
+// Request fields checked wherever they appear
+const (
+	dataField     = protoreflect.Name("data")
+	portsField    = protoreflect.Name("ports")
+	peerPortField = protoreflect.Name("peer_port_name")
+	chunkField    = protoreflect.Name("chunk_size")
+	maxBytesField = protoreflect.Name("max_bytes")
+)
+
+// ValidationInterceptor rejects malformed requests with InvalidArgument
+// before aliases are resolved or any handler runs: oversized data, chunk
+// and read sizes over the limit, port names with control characters or
+// path traversal, enum values the API does not define, and port
+// configurations out of bounds.
+type ValidationInterceptor struct {
+	maxWrite int
+	maxChunk int
+}
+
+// NewValidationInterceptor creates a new request validating interceptor
+func NewValidationInterceptor(maxWriteBytes, maxChunkSize int) *ValidationInterceptor {
+	return &ValidationInterceptor{maxWrite: maxWriteBytes, maxChunk: maxChunkSize}
+}
+
+// Unary returns a unary server interceptor validating requests
+func (v *ValidationInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := v.validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor validating every received
+// message
+func (v *ValidationInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validationServerStream{ServerStream: ss, validator: v})
+	}
+}
+
+// validate checks a request message
+func (v *ValidationInterceptor) validate(req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if msg := v.check(msg.ProtoReflect()); msg != "" {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// check walks the set fields of m and its nested messages, returning why
+// the message is invalid or an empty string
+func (v *ValidationInterceptor) check(m protoreflect.Message) string {
+	if cfg, ok := m.Interface().(*pb.PortConfig); ok {
+		if msg := checkPortConfig(cfg, v.maxWrite); msg != "" {
+			return msg
+		}
+	}
+
+	var problem string
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		problem = v.checkField(fd, value)
+		return problem == ""
+	})
+	return problem
+}
+
+// checkField checks a single set field
+func (v *ValidationInterceptor) checkField(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if fd.IsMap() {
+		return ""
+	}
+
+	if fd.IsList() {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			if msg := v.checkValue(fd, list.Get(i)); msg != "" {
+				return msg
+			}
+		}
+		return ""
+	}
+	return v.checkValue(fd, value)
+}
+
+// checkValue checks one value of a field
+func (v *ValidationInterceptor) checkValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if fd.Name() == portNameField || fd.Name() == portsField || fd.Name() == peerPortField {
+			return checkPortName(value.String())
+		}
+	case protoreflect.BytesKind:
+		if fd.Name() == dataField && len(value.Bytes()) > v.maxWrite {
+			return fmt.Sprintf("data exceeds the %d byte write limit", v.maxWrite)
+		}
+	case protoreflect.Uint32Kind:
+		if (fd.Name() == chunkField || fd.Name() == maxBytesField) && value.Uint() > uint64(v.maxChunk) {
+			return fmt.Sprintf("%s exceeds the limit of %d bytes", fd.Name(), v.maxChunk)
+		}
+	case protoreflect.EnumKind:
+		if fd.Enum().Values().ByNumber(value.Enum()) == nil {
+			return fmt.Sprintf("%s: undefined value %d", fd.Name(), value.Enum())
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v.check(value.Message())
+	}
+	return ""
+}
+
+// checkPortName rejects port names the OS should never be handed
+func checkPortName(name string) string {
+	if len(name) > maxPortNameLength {
+		return fmt.Sprintf("port name exceeds %d characters", maxPortNameLength)
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return "port name contains control characters"
+		}
+	}
+	// Windows device namespace names (\\.\COM10) keep their leading dot
+	trimmed := strings.TrimPrefix(name, `\\.\`)
+	for _, segment := range strings.FieldsFunc(trimmed, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == "." || segment == ".." {
+			return "port name must not contain . or .. path segments"
+		}
+	}
+	return ""
+}
+
+// checkPortConfig bounds the numeric settings of a port configuration
+func checkPortConfig(cfg *pb.PortConfig, maxWrite int) string {
+	switch {
+	case cfg.BaudRate > maxBaudRate:
+		return fmt.Sprintf("baud_rate exceeds %d", maxBaudRate)
+	case cfg.ReadTimeoutMs > maxTimeoutMs || cfg.WriteTimeoutMs > maxTimeoutMs:
+		return fmt.Sprintf("timeouts must not exceed %d ms", maxTimeoutMs)
+	case cfg.WriteChunkSize > uint32(maxWrite):
+		return fmt.Sprintf("write_chunk_size exceeds the %d byte write limit", maxWrite)
+	case cfg.WriteChunkDelayMs > maxDelayMs || cfg.WriteLineDelayMs > maxDelayMs:
+		return fmt.Sprintf("write delays must not exceed %d ms", maxDelayMs)
+	case cfg.Rs485.GetDelayBeforeSendMs() > maxDelayMs || cfg.Rs485.GetDelayAfterSendMs() > maxDelayMs:
+		return fmt.Sprintf("rs485 delays must not exceed %d ms", maxDelayMs)
+	case cfg.XonChar > 0xff || cfg.XoffChar > 0xff:
+		return "xon_char and xoff_char must be single bytes"
+	case len(cfg.ReadFilters) > maxFilters || len(cfg.WriteFilters) > maxFilters:
+		return fmt.Sprintf("at most %d read and %d write filters are allowed", maxFilters, maxFilters)
+	}
+	return ""
+}
+
+// validationServerStream validates messages received on a stream
+type validationServerStream struct {
+	grpc.ServerStream
+	validator *ValidationInterceptor
+}
+
+func (s *validationServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.validator.validate(m)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// Response metadata describing the API version of a call
+const (
+	// APIVersionHeader names the API version that served the call
+	APIVersionHeader = "baudlink-api-version"
+	// DeprecationHeader carries one notice per deprecated method or field
+	// the request used
+	DeprecationHeader = "baudlink-deprecation"
+)
+
+// apiPackagePrefix is the prefix of the versioned proto packages of the API
+const apiPackagePrefix = "baudlink."
+
+// servedVersion is a version of the serial service the agent serves
+type servedVersion struct {
+	service  string // Full service name, e.g. baudlink.serial.v1.SerialService
+	register func(*grpc.Server, *SerialServer)
+}
+
+// servedVersions lists the served versions of the serial service, oldest
+// first. Compatible changes are made within a version; a breaking change
+// ships as a new proto package (baudlink.serial.v2) with an adapter like
+// v1Server that maps the version-neutral SerialServer onto its messages,
+// added here next to the older versions so their clients keep working
+// against the same agent.
+func servedVersions() []servedVersion {
+	return []servedVersion{
+		{service: pb.SerialService_ServiceDesc.ServiceName, register: registerV1},
+	}
+}
+
+// RegisterServices registers every served version of the serial service
+func RegisterServices(server *grpc.Server, s *SerialServer) {
+	for _, v := range servedVersions() {
+		v.register(server, s)
+	}
+}
+
+// APIVersions lists the API versions the agent serves, oldest first
+func APIVersions() []string {
+	var versions []string
+	for _, v := range servedVersions() {
+		versions = append(versions, serviceVersion(v.service))
+	}
+	return versions
+}
+
+// serviceVersion returns the version of a service of the API, the last
+// element of its package such as v1, or an empty string for other services
+func serviceVersion(service string) string {
+	if !strings.HasPrefix(service, apiPackagePrefix) {
+		return ""
+	}
+	parts := strings.Split(service, ".")
+	return parts[len(parts)-2]
+}
+
+// splitMethod splits a full method name, /package.Service/Method, into its
+// service and method
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// VersionInterceptor tells clients which API version served each call and
+// warns them, and the agent's log, about deprecated methods and fields they
+// still use. Deprecations are read from the deprecated options in the proto
+// definitions, so marking a method or field deprecated there is all it
+// takes.
+type VersionInterceptor struct {
+	mu     sync.Mutex
+	logged map[string]bool // Deprecations already logged, by client and notice
+}
+
+// NewVersionInterceptor creates a new API versioning interceptor
+func NewVersionInterceptor() *VersionInterceptor {
+	return &VersionInterceptor{logged: make(map[string]bool)}
+}
+
+// Unary returns the unary server interceptor
+func (v *VersionInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, _ := splitMethod(info.FullMethod)
+		version := serviceVersion(service)
+		if version == "" {
+			return handler(ctx, req)
+		}
+
+		notices := methodDeprecations(info.FullMethod)
+		if msg, ok := req.(proto.Message); ok {
+			notices = append(notices, fieldDeprecations(msg.ProtoReflect())...)
+		}
+		grpc.SetHeader(ctx, versionHeader(version, notices))
+		v.logDeprecations(ctx, notices)
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the stream server interceptor. Deprecated fields in
+// messages received after the response headers were sent are only logged.
+func (v *VersionInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		service, _ := splitMethod(info.FullMethod)
+		version := serviceVersion(service)
+		if version == "" {
+			return handler(srv, ss)
+		}
+
+		notices := methodDeprecations(info.FullMethod)
+		ss.SetHeader(versionHeader(version, notices))
+		v.logDeprecations(ss.Context(), notices)
+
+		return handler(srv, &versionServerStream{ServerStream: ss, interceptor: v})
+	}
+}
+
+// versionHeader builds the response metadata of a call
+func versionHeader(version string, notices []string) metadata.MD {
+	md := metadata.Pairs(APIVersionHeader, version)
+	for _, notice := range notices {
+		md.Append(DeprecationHeader, notice)
+	}
+	return md
+}
+
+// logDeprecations logs each deprecation once per client
+func (v *VersionInterceptor) logDeprecations(ctx context.Context, notices []string) {
+	if len(notices) == 0 {
+		return
+	}
+	client := clientKey(ctx)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, notice := range notices {
+		key := client + "\x00" + notice
+		if v.logged[key] {
+			continue
+		}
+		v.logged[key] = true
+		log.Printf("Warning: client %s uses %s", client, notice)
+	}
+}
+
+// methodDeprecations returns a notice if the method is deprecated
+func methodDeprecations(fullMethod string) []string {
+	service, method := splitMethod(fullMethod)
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service + "." + method))
+	if err != nil {
+		return nil
+	}
+	if opts, ok := d.Options().(*descriptorpb.MethodOptions); ok && opts.GetDeprecated() {
+		return []string{fmt.Sprintf("deprecated method %s", method)}
+	}
+	return nil
+}
+
+// fieldDeprecations returns a notice for each deprecated field set in m or
+// its nested messages
+func fieldDeprecations(m protoreflect.Message) []string {
+	var notices []string
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDeprecated() {
+			notices = append(notices, fmt.Sprintf("deprecated field %s", fd.FullName()))
+		}
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				notices = append(notices, fieldDeprecations(list.Get(i).Message())...)
+			}
+			return true
+		}
+		notices = append(notices, fieldDeprecations(value.Message())...)
+		return true
+	})
+	return notices
+}
+
+// versionServerStream checks messages received on a stream for deprecated
+// fields
+type versionServerStream struct {
+	grpc.ServerStream
+	interceptor *VersionInterceptor
+}
+
+func (s *versionServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if notices := fieldDeprecations(msg.ProtoReflect()); len(notices) > 0 {
+			// Fails once the headers were sent with the first response
+			s.ServerStream.SetHeader(metadata.MD{DeprecationHeader: notices})
+			s.interceptor.logDeprecations(s.Context(), notices)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+	"github.com/Shoaibashk/BaudLink/pkg/client"
+)
+
+// defaultAgentAddress is the agent commands talk to when none is selected
+const defaultAgentAddress = "localhost:50051"
+
+// Environment variables selecting the agent when the flags are not given
+const (
+	agentEnv = "BAUDLINK_AGENT"
+	tokenEnv = "BAUDLINK_TOKEN"
+)
+
+func init() {
+	flags := rootCmd.PersistentFlags()
+	flags.String("agent", "", "agent address, unix:// socket, or baudlink:// connection string (default $"+agentEnv+" or "+defaultAgentAddress+")")
+	flags.String("token", "", "access token for agents with authentication enabled (default $"+tokenEnv+")")
+	flags.Bool("tls", false, "connect to the agent over TLS")
+	flags.String("tls-ca", "", "CA certificate (PEM) the agent's certificate is verified with (default: system roots)")
+	flags.Bool("tls-insecure", false, "do not verify the agent's certificate")
+}
+
+// remoteAgent returns the agent selected by --agent, $BAUDLINK_AGENT, or a
+// context, and false if none is. Commands that also work on local ports use
+// it to decide where to run.
+func remoteAgent(cmd *cobra.Command) (string, bool) {
+	if address, _ := cmd.Flags().GetString("agent"); address != "" {
+		return address, true
+	}
+	if address := os.Getenv(agentEnv); address != "" {
+		return address, true
+	}
+	ac, err := selectedContext(cmd)
+	if err != nil {
+		// Report the broken context when dialing instead of running locally
+		return "", true
+	}
+	if ac != nil {
+		return ac.Agent, true
+	}
+	return "", false
+}
+
+// dialAgent connects to the agent selected by the global flags
+func dialAgent(cmd *cobra.Command) (*grpc.ClientConn, pb.SerialServiceClient, error) {
+	c, err := dialClient(cmd, client.DefaultClientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.Conn(), c.Service(), nil
+}
+
+// dialClient connects to the agent selected by the global flags through the
+// client library, for commands using port handles
+func dialClient(cmd *cobra.Command, clientID string) (*client.Client, error) {
+	ac, err := selectedContext(cmd)
+	if err != nil {
+		return nil, err
+	}
+	address, ok := remoteAgent(cmd)
+	if !ok {
+		address = defaultAgentAddress
+	}
+
+	opts := []client.Option{client.WithClientID(clientID)}
+
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv(tokenEnv)
+	}
+	if token == "" && ac != nil {
+		token = ac.Token
+	}
+	if token != "" {
+		opts = append(opts, client.WithToken(token))
+	}
+
+	tlsConfig, err := agentTLSConfig(cmd, ac)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, client.WithTLS(tlsConfig))
+	}
+
+	return client.Dial(address, opts...)
+}
+
+// agentTLSConfig returns the TLS settings selected by the flags, falling
+// back to those of the context ac if it is not nil, or nil to connect
+// without TLS
+func agentTLSConfig(cmd *cobra.Command, ac *agentContext) (*tls.Config, error) {
+	useTLS, _ := cmd.Flags().GetBool("tls")
+	caFile, _ := cmd.Flags().GetString("tls-ca")
+	insecure, _ := cmd.Flags().GetBool("tls-insecure")
+	if ac != nil {
+		if !cmd.Flags().Changed("tls") {
+			useTLS = ac.TLS
+		}
+		if caFile == "" {
+			caFile = ac.TLSCA
+		}
+		if !cmd.Flags().Changed("tls-insecure") {
+			insecure = ac.TLSInsecure
+		}
+	}
+	if !useTLS && caFile == "" && !insecure {
+		return nil, nil
+	}
+
+	config := &tls.Config{InsecureSkipVerify: insecure}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+	}
+	return config, nil
+}
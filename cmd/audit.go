@@ -0,0 +1,132 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of a running agent",
+	Long: `Show recorded port operations from a running agent's audit log: who opened,
+closed, wrote to, configured, or took over which port, when, and with what
+result.
+
+The agent must have auditing enabled in its configuration. Requires an admin
+token when the agent has authentication enabled.
+
+Example:
+  baudlink audit
+  baudlink audit --since 24h --port /dev/ttyUSB0
+  baudlink audit --identity alice --operation Write --limit 50
+  baudlink audit --json > audit.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().Duration("since", 0, "only show entries newer than this, e.g. 24h")
+	auditCmd.Flags().String("port", "", "only show entries for this port")
+	auditCmd.RegisterFlagCompletionFunc("port", completePorts)
+	auditCmd.Flags().String("identity", "", "only show entries by this token identity")
+	auditCmd.Flags().String("operation", "", "only show this operation, e.g. OpenPort or Write")
+	auditCmd.Flags().Uint32("limit", 100, "number of most recent entries to show")
+	auditCmd.Flags().Bool("json", false, "output entries as JSON lines")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetDuration("since")
+	portName, _ := cmd.Flags().GetString("port")
+	identity, _ := cmd.Flags().GetString("identity")
+	operation, _ := cmd.Flags().GetString("operation")
+	limit, _ := cmd.Flags().GetUint32("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	req := &pb.GetAuditLogRequest{
+		PortName:  portName,
+		Identity:  identity,
+		Operation: operation,
+		Limit:     limit,
+	}
+	if since > 0 {
+		req.Since = time.Now().Add(-since).Unix()
+	}
+
+	resp, err := client.GetAuditLog(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	if jsonOutput {
+		for _, e := range resp.Entries {
+			line, err := protojson.Marshal(e)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(line))
+		}
+		return nil
+	}
+
+	if len(resp.Entries) == 0 {
+		fmt.Println("No audit entries.")
+		return nil
+	}
+
+	for _, e := range resp.Entries {
+		result := "ok"
+		if !e.Success {
+			result = "FAILED"
+		}
+
+		fmt.Printf("%s  %-19s %-6s %s  %s",
+			time.Unix(0, e.Timestamp).Format("2006-01-02 15:04:05"),
+			e.Operation, result, e.Identity, e.PortName)
+		if e.ClientId != "" {
+			fmt.Printf("  client=%s", e.ClientId)
+		}
+		if e.Bytes > 0 {
+			fmt.Printf("  bytes=%d", e.Bytes)
+		}
+		if e.Message != "" {
+			fmt.Printf("  %q", e.Message)
+		}
+		if e.Reason != "" {
+			fmt.Printf("  reason=%q", e.Reason)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+	goserial "go.bug.st/serial"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench <port>",
+	Short: "Measure throughput through a port",
+	Long: `Write a pseudorandom pattern through a port and read it back, measuring
+effective throughput, dropped bytes, and corrupted bytes.
+
+The pattern is read back on the same port, which needs a TX-RX loopback
+jumper, or with --rx on a second port, such as the other end of a null
+modem cable or a pair of virtual ports.
+
+With --direct the run is repeated with the ports opened directly instead of
+through the agent, and the agent's overhead is reported. The agent must run
+on this machine and the ports must be free. --json prints the report for CI
+regression tracking. The command fails if any byte was dropped or corrupted.
+
+Example:
+  baudlink bench /dev/ttyUSB0 --baud 115200
+  baudlink bench /dev/pts/3 --rx /dev/pts/4 --bytes 1048576 --direct --json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runBench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().Uint32("baud", 115200, "baud rate")
+	benchCmd.Flags().String("rx", "", "read the pattern back on this port instead")
+	benchCmd.RegisterFlagCompletionFunc("rx", completePorts)
+	benchCmd.Flags().Int("bytes", 64*1024, "pattern size in bytes")
+	benchCmd.Flags().Int("chunk", 256, "bytes per write")
+	benchCmd.Flags().Uint64("seed", 1, "seed of the pseudorandom pattern")
+	benchCmd.Flags().Duration("timeout", 2*time.Second, "how long to wait for outstanding bytes after the last write")
+	benchCmd.Flags().Bool("direct", false, "also run without the agent and compare")
+	benchCmd.Flags().Bool("json", false, "output the report as JSON")
+}
+
+// benchResult is the outcome of one run
+type benchResult struct {
+	BytesSent     int     `json:"bytes_sent"`
+	BytesReceived int     `json:"bytes_received"`
+	BytesDropped  int     `json:"bytes_dropped"`
+	ByteErrors    int     `json:"byte_errors"`
+	DurationMs    float64 `json:"duration_ms"`
+	ThroughputBps float64 `json:"throughput_bps"`
+	Efficiency    float64 `json:"efficiency"` // Fraction of the line maximum
+}
+
+// benchReport is the outcome of a benchmark
+type benchReport struct {
+	TXPort    string       `json:"tx_port"`
+	RXPort    string       `json:"rx_port"`
+	BaudRate  uint32       `json:"baud_rate"`
+	Bytes     int          `json:"bytes"`
+	ChunkSize int          `json:"chunk_size"`
+	Seed      uint64       `json:"seed"`
+	LineBps   float64      `json:"line_max_bps"` // 8N1: ten bits per byte
+	Agent     *benchResult `json:"agent"`
+	Direct    *benchResult `json:"direct,omitempty"`
+	// AgentOverhead is the extra time the agent run took relative to the
+	// direct run, as a fraction
+	AgentOverhead *float64 `json:"agent_overhead,omitempty"`
+}
+
+// benchIO moves the pattern through one access path. read returns 0 bytes
+// and no error when nothing arrived within its poll interval.
+type benchIO struct {
+	write func(p []byte) error
+	read  func(p []byte) (int, error)
+}
+
+// benchPollInterval is how long a single read waits for data
+const benchPollInterval = 100 * time.Millisecond
+
+func runBench(cmd *cobra.Command, args []string) error {
+	txPort, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	rxPort, _ := cmd.Flags().GetString("rx")
+	baud, _ := cmd.Flags().GetUint32("baud")
+	size, _ := cmd.Flags().GetInt("bytes")
+	chunk, _ := cmd.Flags().GetInt("chunk")
+	seed, _ := cmd.Flags().GetUint64("seed")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	direct, _ := cmd.Flags().GetBool("direct")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	if rxPort == "" {
+		rxPort = txPort
+	}
+	if baud == 0 {
+		return fmt.Errorf("baud must be at least 1")
+	}
+	if size <= 0 || chunk <= 0 {
+		return fmt.Errorf("bytes and chunk must be at least 1")
+	}
+
+	pattern := make([]byte, size)
+	rng := rand.New(rand.NewPCG(seed, seed))
+	for i := range pattern {
+		pattern[i] = byte(rng.Uint32())
+	}
+
+	report := benchReport{
+		TXPort:    txPort,
+		RXPort:    rxPort,
+		BaudRate:  baud,
+		Bytes:     size,
+		ChunkSize: chunk,
+		Seed:      seed,
+		LineBps:   float64(baud) / 10,
+	}
+
+	if !jsonOutput {
+		fmt.Printf("Benchmarking %s -> %s at %d baud (%d bytes in %d-byte writes)...\n\n", txPort, rxPort, baud, size, chunk)
+	}
+
+	agent, err := benchAgent(cmd, txPort, rxPort, baud, pattern, chunk, timeout)
+	if err != nil {
+		return err
+	}
+	agent.finish(report.LineBps)
+	report.Agent = agent
+
+	if direct {
+		result, err := benchDirect(txPort, rxPort, baud, pattern, chunk, timeout)
+		if err != nil {
+			return err
+		}
+		result.finish(report.LineBps)
+		report.Direct = result
+		if result.DurationMs > 0 {
+			overhead := agent.DurationMs/result.DurationMs - 1
+			report.AgentOverhead = &overhead
+		}
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printBenchResult("Agent", report.Agent)
+		if report.Direct != nil {
+			printBenchResult("Direct", report.Direct)
+		}
+		if report.AgentOverhead != nil {
+			fmt.Printf("Agent overhead: %+.1f%%\n\n", *report.AgentOverhead*100)
+		}
+	}
+
+	for _, r := range []*benchResult{report.Agent, report.Direct} {
+		if r != nil && (r.BytesDropped > 0 || r.ByteErrors > 0) {
+			return fmt.Errorf("benchmark lost or corrupted data")
+		}
+	}
+	return nil
+}
+
+// printBenchResult prints one run of a benchmark
+func printBenchResult(name string, r *benchResult) {
+	fmt.Printf("%s:\n", name)
+	fmt.Printf("  Bytes:        %d sent, %d received, %d dropped, %d corrupted\n", r.BytesSent, r.BytesReceived, r.BytesDropped, r.ByteErrors)
+	fmt.Printf("  Duration:     %s\n", time.Duration(r.DurationMs*float64(time.Millisecond)).Round(time.Millisecond))
+	fmt.Printf("  Throughput:   %.0f B/s (%.1f%% of line maximum)\n", r.ThroughputBps, r.Efficiency*100)
+	fmt.Println()
+}
+
+// benchAgent runs the benchmark through the agent
+func benchAgent(cmd *cobra.Command, txPort, rxPort string, baud uint32, pattern []byte, chunk int, timeout time.Duration) (*benchResult, error) {
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	open := func(name string) (string, error) {
+		resp, err := client.OpenPort(ctx, &pb.OpenPortRequest{
+			PortName:  name,
+			ClientId:  "baudlink-bench",
+			Exclusive: true,
+			Config: &pb.PortConfig{
+				BaudRate:      baud,
+				DataBits:      pb.DataBits_DATA_BITS_8,
+				StopBits:      pb.StopBits_STOP_BITS_1,
+				Parity:        pb.Parity_PARITY_NONE,
+				ReadTimeoutMs: uint32(benchPollInterval.Milliseconds()),
+			},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		if !resp.Success {
+			return "", fmt.Errorf("failed to open %s: %s", name, resp.Message)
+		}
+		return resp.SessionId, nil
+	}
+
+	txSession, err := open(txPort)
+	if err != nil {
+		return nil, err
+	}
+	defer client.ClosePort(ctx, &pb.ClosePortRequest{PortName: txPort, SessionId: txSession})
+
+	rxSession := txSession
+	if rxPort != txPort {
+		if rxSession, err = open(rxPort); err != nil {
+			return nil, err
+		}
+		defer client.ClosePort(ctx, &pb.ClosePortRequest{PortName: rxPort, SessionId: rxSession})
+	}
+
+	return runBenchIO(benchIO{
+		write: func(p []byte) error {
+			resp, err := client.Write(ctx, &pb.WriteRequest{PortName: txPort, SessionId: txSession, Data: p})
+			if err != nil {
+				return err
+			}
+			if !resp.Success {
+				return fmt.Errorf("%s", resp.Message)
+			}
+			return nil
+		},
+		read: func(p []byte) (int, error) {
+			resp, err := client.Read(ctx, &pb.ReadRequest{PortName: rxPort, SessionId: rxSession, MaxBytes: uint32(len(p))})
+			if err != nil {
+				return 0, err
+			}
+			if !resp.Success {
+				return 0, fmt.Errorf("%s", resp.Message)
+			}
+			return copy(p, resp.Data), nil
+		},
+	}, pattern, chunk, timeout)
+}
+
+// benchDirect runs the benchmark with the ports opened directly
+func benchDirect(txPort, rxPort string, baud uint32, pattern []byte, chunk int, timeout time.Duration) (*benchResult, error) {
+	open := func(name string) (goserial.Port, error) {
+		// The library adds the \\.\ device prefix on Windows itself
+		mode := &goserial.Mode{BaudRate: int(baud), DataBits: 8, Parity: goserial.NoParity, StopBits: goserial.OneStopBit}
+		port, err := goserial.Open(serial.CanonicalPortName(name), mode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s directly: %w", name, err)
+		}
+		if err := port.SetReadTimeout(benchPollInterval); err != nil {
+			port.Close()
+			return nil, err
+		}
+		return port, nil
+	}
+
+	tx, err := open(txPort)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Close()
+
+	rx := tx
+	if rxPort != txPort {
+		if rx, err = open(rxPort); err != nil {
+			return nil, err
+		}
+		defer rx.Close()
+	}
+
+	return runBenchIO(benchIO{
+		write: func(p []byte) error {
+			_, err := tx.Write(p)
+			return err
+		},
+		read: rx.Read,
+	}, pattern, chunk, timeout)
+}
+
+// runBenchIO writes the pattern in chunks while reading it back, until every
+// byte has arrived or nothing has for timeout after the last write
+func runBenchIO(bio benchIO, pattern []byte, chunk int, timeout time.Duration) (*benchResult, error) {
+	var writeErr error
+	var writtenAt atomic.Int64 // Unix nanoseconds the last write finished
+	done := make(chan struct{})
+
+	start := time.Now()
+	go func() {
+		defer close(done)
+		for off := 0; off < len(pattern); off += chunk {
+			end := min(off+chunk, len(pattern))
+			if writeErr = bio.write(pattern[off:end]); writeErr != nil {
+				return
+			}
+		}
+		writtenAt.Store(time.Now().UnixNano())
+	}()
+
+	received := make([]byte, 0, len(pattern))
+	buf := make([]byte, 4096)
+	last := start
+	for len(received) < len(pattern) {
+		n, err := bio.read(buf)
+		if err != nil {
+			<-done
+			return nil, fmt.Errorf("read failed: %w", err)
+		}
+		if n > 0 {
+			received = append(received, buf[:n]...)
+			last = time.Now()
+			continue
+		}
+
+		select {
+		case <-done:
+			if writeErr != nil {
+				return nil, fmt.Errorf("write failed: %w", writeErr)
+			}
+			idleSince := max(last.UnixNano(), writtenAt.Load())
+			if time.Since(time.Unix(0, idleSince)) >= timeout {
+				return benchOutcome(pattern, received, last.Sub(start)), nil
+			}
+		default:
+		}
+	}
+	<-done
+	if writeErr != nil {
+		return nil, fmt.Errorf("write failed: %w", writeErr)
+	}
+	return benchOutcome(pattern, received, last.Sub(start)), nil
+}
+
+// benchOutcome compares what arrived with the pattern, position by position
+func benchOutcome(pattern, received []byte, elapsed time.Duration) *benchResult {
+	r := &benchResult{
+		BytesSent:     len(pattern),
+		BytesReceived: len(received),
+		BytesDropped:  max(len(pattern)-len(received), 0),
+		DurationMs:    float64(elapsed) / float64(time.Millisecond),
+	}
+	for i := range min(len(pattern), len(received)) {
+		if received[i] != pattern[i] {
+			r.ByteErrors++
+		}
+	}
+	return r
+}
+
+// finish fills in the rates derived from the counts
+func (r *benchResult) finish(lineBps float64) {
+	if r.DurationMs > 0 {
+		r.ThroughputBps = float64(r.BytesReceived) / (r.DurationMs / 1000)
+	}
+	if lineBps > 0 {
+		r.Efficiency = r.ThroughputBps / lineBps
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/certs"
+)
+
+// selfSignedValidity is the lifetime of generated self-signed certificates
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// certCmd represents the cert command
+var certCmd = &cobra.Command{
+	Use:   "cert",
+	Short: "Manage the agent's TLS certificate",
+	Long: `Manage the TLS certificate configured under tls in the agent configuration.
+
+Subcommands:
+  generate  - Create a self-signed certificate and key
+  show      - Display the current certificate
+  renew     - Obtain or renew the certificate from an ACME CA
+
+A running agent picks up a changed certificate without restarting.`,
+}
+
+var certGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Create a self-signed certificate and key",
+	Long: `Create a self-signed certificate and key at the configured cert_file and
+key_file. Clients trust it by using the certificate as their CA file.
+
+Example:
+  baudlink cert generate
+  baudlink cert generate --host pi.local --host 192.168.1.20 --days 730
+  baudlink cert generate --force`,
+	Args: cobra.NoArgs,
+	RunE: runCertGenerate,
+}
+
+var certShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Display the current certificate",
+	Args:  cobra.NoArgs,
+	RunE:  runCertShow,
+}
+
+var certRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "Obtain or renew the certificate from an ACME CA",
+	Long: `Obtain a certificate for the configured tls.acme domains, answering the
+configured http-01 or dns-01 challenge. The certificate is only requested
+when it is missing or due for renewal unless --force is given.
+
+Example:
+  sudo baudlink cert renew
+  sudo baudlink cert renew --force`,
+	Args: cobra.NoArgs,
+	RunE: runCertRenew,
+}
+
+func init() {
+	rootCmd.AddCommand(certCmd)
+	certCmd.AddCommand(certGenerateCmd)
+	certCmd.AddCommand(certShowCmd)
+	certCmd.AddCommand(certRenewCmd)
+
+	certCmd.PersistentFlags().StringP("config", "c", "", "config file path")
+
+	certGenerateCmd.Flags().StringSlice("host", nil, "host name or IP address to include (default: tls.hosts, or this host and localhost)")
+	certGenerateCmd.Flags().Int("days", 365, "validity in days")
+	certGenerateCmd.Flags().Bool("force", false, "replace an existing certificate")
+
+	certRenewCmd.Flags().Bool("force", false, "renew even if the certificate is not due")
+}
+
+// loadCertConfig loads the configuration named by the --config flag
+func loadCertConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = config.DefaultConfigPath()
+	}
+
+	cfg, err := config.LoadOrDefault(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
+
+func runCertGenerate(cmd *cobra.Command, args []string) error {
+	hosts, _ := cmd.Flags().GetStringSlice("host")
+	days, _ := cmd.Flags().GetInt("days")
+	force, _ := cmd.Flags().GetBool("force")
+
+	cfg, err := loadCertConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		hosts = cfg.TLS.Hosts
+	}
+	if days < 1 {
+		return fmt.Errorf("days must be at least 1")
+	}
+
+	if _, err := os.Stat(cfg.TLS.CertFile); err == nil && !force {
+		return fmt.Errorf("%s already exists, use --force to replace it", cfg.TLS.CertFile)
+	}
+
+	if err := certs.GenerateSelfSigned(cfg.TLS.CertFile, cfg.TLS.KeyFile, hosts, time.Duration(days)*24*time.Hour); err != nil {
+		return fmt.Errorf("failed to generate certificate: %w", err)
+	}
+
+	fmt.Printf("Certificate: %s\n", cfg.TLS.CertFile)
+	fmt.Printf("Key:         %s\n", cfg.TLS.KeyFile)
+	return printCertificate(cfg.TLS.CertFile)
+}
+
+func runCertShow(cmd *cobra.Command, args []string) error {
+	cfg, err := loadCertConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Certificate: %s\n", cfg.TLS.CertFile)
+	return printCertificate(cfg.TLS.CertFile)
+}
+
+func runCertRenew(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	cfg, err := loadCertConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if !cfg.TLS.ACME.Enabled {
+		return fmt.Errorf("ACME is not enabled in the configuration (tls.acme.enabled)")
+	}
+
+	if !force {
+		due, err := certs.NeedsRenewal(cfg.TLS.CertFile, acmeRenewBefore(cfg))
+		if err != nil {
+			return err
+		}
+		if !due {
+			fmt.Println("Certificate is not due for renewal (use --force to renew anyway).")
+			return printCertificate(cfg.TLS.CertFile)
+		}
+	}
+
+	fmt.Printf("Requesting certificate for %s using %s...\n", strings.Join(cfg.TLS.ACME.Domains, ", "), cfg.TLS.ACME.Challenge)
+	if err := certs.Obtain(context.Background(), acmeOptions(cfg), cfg.TLS.CertFile, cfg.TLS.KeyFile); err != nil {
+		return fmt.Errorf("failed to obtain certificate: %w", err)
+	}
+
+	fmt.Printf("Certificate: %s\n", cfg.TLS.CertFile)
+	return printCertificate(cfg.TLS.CertFile)
+}
+
+// printCertificate prints a summary of the certificate in certFile
+func printCertificate(certFile string) error {
+	leaf, err := certs.LoadLeaf(certFile)
+	if err != nil {
+		return err
+	}
+
+	names := append([]string{}, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		names = append(names, ip.String())
+	}
+	fingerprint := sha256.Sum256(leaf.Raw)
+
+	fmt.Printf("  Subject:     %s\n", leaf.Subject)
+	fmt.Printf("  Issuer:      %s\n", leaf.Issuer)
+	if isSelfSigned(leaf) {
+		fmt.Printf("  Self-signed: yes\n")
+	}
+	fmt.Printf("  Names:       %s\n", strings.Join(names, ", "))
+	fmt.Printf("  Valid from:  %s\n", leaf.NotBefore.Local().Format(time.RFC3339))
+	fmt.Printf("  Valid until: %s (%s)\n", leaf.NotAfter.Local().Format(time.RFC3339), expiresIn(leaf.NotAfter))
+	fmt.Printf("  SHA-256:     %s\n", strings.ToUpper(hex.EncodeToString(fingerprint[:])))
+	return nil
+}
+
+// expiresIn describes the time left until a certificate expires
+func expiresIn(notAfter time.Time) string {
+	left := time.Until(notAfter)
+	if left <= 0 {
+		return "expired"
+	}
+	return fmt.Sprintf("expires in %d days", int(left.Hours()/24))
+}
+
+// isSelfSigned reports whether a certificate was signed by its own key
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(cert) == nil
+}
+
+// acmeOptions converts the ACME configuration
+func acmeOptions(cfg *config.Config) certs.ACMEOptions {
+	acme := cfg.TLS.ACME
+	return certs.ACMEOptions{
+		DirectoryURL:   acme.Directory,
+		Email:          acme.Email,
+		Domains:        acme.Domains,
+		Challenge:      acme.Challenge,
+		HTTPAddress:    acme.HTTPAddress,
+		DNSHook:        acme.DNSHook,
+		DNSWait:        time.Duration(acme.DNSWait) * time.Second,
+		AccountKeyFile: acme.AccountKey,
+	}
+}
+
+// acmeRenewBefore returns how long before expiry ACME certificates are renewed
+func acmeRenewBefore(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.TLS.ACME.RenewBefore) * 24 * time.Hour
+}
+
+// ensureCertificate makes sure a certificate exists before the server
+// starts: it is obtained over ACME when configured and due, and otherwise a
+// self-signed certificate is generated if none exists or a previously
+// generated one has expired
+func ensureCertificate(ctx context.Context, cfg *config.Config) error {
+	tlsCfg := cfg.TLS
+
+	if tlsCfg.ACME.Enabled {
+		due, err := certs.NeedsRenewal(tlsCfg.CertFile, acmeRenewBefore(cfg))
+		if err != nil {
+			return err
+		}
+		if !due {
+			return nil
+		}
+
+		log.Printf("Requesting TLS certificate for %s using %s", strings.Join(tlsCfg.ACME.Domains, ", "), tlsCfg.ACME.Challenge)
+		err = certs.Obtain(ctx, acmeOptions(cfg), tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err == nil {
+			return nil
+		}
+		if _, statErr := os.Stat(tlsCfg.CertFile); statErr == nil {
+			log.Printf("Warning: failed to renew TLS certificate, using the existing one: %v", err)
+			return nil
+		}
+		if !tlsCfg.AutoGenerate {
+			return fmt.Errorf("failed to obtain certificate: %w", err)
+		}
+		log.Printf("Warning: failed to obtain TLS certificate, using a self-signed one: %v", err)
+	}
+
+	if !tlsCfg.AutoGenerate {
+		return nil
+	}
+
+	leaf, err := certs.LoadLeaf(tlsCfg.CertFile)
+	switch {
+	case err == nil && (time.Now().Before(leaf.NotAfter) || !isSelfSigned(leaf)):
+		return nil
+	case err != nil && !os.IsNotExist(err):
+		return err
+	}
+
+	if err := certs.GenerateSelfSigned(tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.Hosts, selfSignedValidity); err != nil {
+		return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+	}
+	log.Printf("Generated self-signed TLS certificate %s", tlsCfg.CertFile)
+	return nil
+}
+
+// renewCertificates renews the ACME certificate when it is due, checking
+// twice a day until ctx is done, and loads renewed certificates into reloader
+func renewCertificates(ctx context.Context, cfg *config.Config, reloader *certs.Reloader) {
+	ticker := time.NewTicker(12 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		due, err := certs.NeedsRenewal(cfg.TLS.CertFile, acmeRenewBefore(cfg))
+		if err != nil {
+			log.Printf("Warning: failed to check TLS certificate: %v", err)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		log.Printf("Renewing TLS certificate for %s", strings.Join(cfg.TLS.ACME.Domains, ", "))
+		if err := certs.Obtain(ctx, acmeOptions(cfg), cfg.TLS.CertFile, cfg.TLS.KeyFile); err != nil {
+			log.Printf("Warning: failed to renew TLS certificate: %v", err)
+			continue
+		}
+		if err := reloader.Reload(); err != nil {
+			log.Printf("Warning: failed to load renewed TLS certificate: %v", err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// completePorts completes port names from the selected agent, or from a
+// scan of this system when no agent is selected
+func completePorts(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ports, err := scanPorts(cmd)
+	if err != nil {
+		cobra.CompDebugln(err.Error(), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]cobra.Completion, 0, len(ports))
+	for _, p := range ports {
+		completions = append(completions, cobra.CompletionWithDesc(p.Name, p.Description))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePortArgs completes the first n positional arguments as port
+// names and nothing after them
+func completePortArgs(n int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completePorts(cmd, args, toComplete)
+	}
+}
+
+// portArg returns args[i], or lets the user pick a port when the argument
+// was omitted
+func portArg(cmd *cobra.Command, args []string, i int) (string, error) {
+	if i < len(args) {
+		return args[i], nil
+	}
+	return pickPort(cmd)
+}
+
+// pickPort asks the user to choose one of the available ports by number,
+// or to narrow the list by typing part of a name or description. It fails
+// when stdin is not a terminal, so scripts get an error instead of a
+// prompt.
+func pickPort(cmd *cobra.Command) (string, error) {
+	if !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("a port is required")
+	}
+
+	ports, err := scanPorts(cmd)
+	if err != nil {
+		return "", err
+	}
+	if len(ports) == 0 {
+		return "", fmt.Errorf("no serial ports found")
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	candidates := ports
+	for {
+		for i, p := range candidates {
+			fmt.Fprintf(os.Stderr, "  %2d) %s - %s\n", i+1, p.Name, p.Description)
+		}
+		fmt.Fprintf(os.Stderr, "Select a port [1-%d, or type to filter]: ", len(candidates))
+
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("no port selected")
+		}
+		query := strings.TrimSpace(line)
+
+		switch n, err := strconv.Atoi(query); {
+		case err == nil && n >= 1 && n <= len(candidates):
+			return candidates[n-1].Name, nil
+		case query == "" && len(candidates) == 1:
+			return candidates[0].Name, nil
+		}
+
+		matches := filterPorts(ports, query)
+		if len(matches) == 1 {
+			return matches[0].Name, nil
+		}
+		if len(matches) == 0 {
+			fmt.Fprintf(os.Stderr, "No port matches %q.\n", query)
+			matches = ports
+		}
+		candidates = matches
+	}
+}
+
+// filterPorts returns the ports whose name or description contains the
+// characters of query in order, ignoring case
+func filterPorts(ports []serial.PortInfo, query string) []serial.PortInfo {
+	var matches []serial.PortInfo
+	for _, p := range ports {
+		if fuzzyMatch(strings.ToLower(p.Name+" "+p.Description), strings.ToLower(query)) {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in s in order
+func fuzzyMatch(s, pattern string) bool {
+	for _, r := range pattern {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables overriding the CLI config file and its current context
+const (
+	cliConfigEnv = "BAUDLINK_CLI_CONFIG"
+	contextEnv   = "BAUDLINK_CONTEXT"
+)
+
+// cliConfig is the CLI's own configuration, separate from the agent's,
+// holding named agent contexts
+type cliConfig struct {
+	CurrentContext string         `yaml:"current_context,omitempty"`
+	Contexts       []agentContext `yaml:"contexts"`
+}
+
+// agentContext is a named agent with the credentials used to reach it
+type agentContext struct {
+	Name        string `yaml:"name"`
+	Agent       string `yaml:"agent"`
+	Token       string `yaml:"token,omitempty"`
+	TLS         bool   `yaml:"tls,omitempty"`
+	TLSCA       string `yaml:"tls_ca,omitempty"`
+	TLSInsecure bool   `yaml:"tls_insecure,omitempty"`
+}
+
+// cliConfigPath returns the CLI config file location, by default
+// ~/.config/baudlink/cli.yaml, or under $XDG_CONFIG_HOME when it is set
+func cliConfigPath() (string, error) {
+	if path := os.Getenv(cliConfigEnv); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate CLI config: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "baudlink", "cli.yaml"), nil
+}
+
+// loadCLIConfig reads the CLI config file; a missing file is an empty config
+func loadCLIConfig() (*cliConfig, string, error) {
+	path, err := cliConfigPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg := &cliConfig{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, path, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read CLI config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, path, nil
+}
+
+// save writes the config to path, readable only by the user since
+// contexts hold access tokens
+func (c *cliConfig) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write CLI config: %w", err)
+	}
+	return nil
+}
+
+// find returns the context with the given name, or nil
+func (c *cliConfig) find(name string) *agentContext {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i]
+		}
+	}
+	return nil
+}
+
+// selectedContext returns the context chosen by --context, $BAUDLINK_CONTEXT,
+// or the config's current context. Contexts are not used when an agent is
+// given by --agent or $BAUDLINK_AGENT, so their tokens are never sent to
+// another agent; it returns nil then, and when no context is selected.
+func selectedContext(cmd *cobra.Command) (*agentContext, error) {
+	if address, _ := cmd.Flags().GetString("agent"); address != "" {
+		return nil, nil
+	}
+	if os.Getenv(agentEnv) != "" {
+		return nil, nil
+	}
+
+	cfg, _, err := loadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	name, _ := cmd.Flags().GetString("context")
+	if name == "" {
+		name = os.Getenv(contextEnv)
+	}
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	ac := cfg.find(name)
+	if ac == nil {
+		return nil, fmt.Errorf("context %q not found; see baudlink context list", name)
+	}
+	return ac, nil
+}
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage named agent contexts",
+	Long: `Manage named agents in the CLI config, so commands reach an agent
+without passing --agent, --token, and TLS flags every time.
+
+The current context is used by every command that talks to an agent, unless
+--agent or BAUDLINK_AGENT selects one; --context or BAUDLINK_CONTEXT picks
+another context for a single command. Flags given alongside a context
+override its settings.
+
+Contexts are stored in ~/.config/baudlink/cli.yaml (or BAUDLINK_CLI_CONFIG),
+readable only by the user since they hold access tokens.
+
+Example:
+  baudlink context set plant-a --agent gw-a.local:50051 --token <token> --tls --tls-ca ca.pem
+  baudlink context set lab --agent unix:///run/baudlink/agent.sock
+  baudlink context use plant-a
+  baudlink context list
+  baudlink sessions --context lab`,
+}
+
+var contextListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List contexts, marking the current one",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		if len(cfg.Contexts) == 0 {
+			fmt.Printf("No contexts in %s.\n", path)
+			return nil
+		}
+
+		for _, ac := range cfg.Contexts {
+			marker := " "
+			if ac.Name == cfg.CurrentContext {
+				marker = "*"
+			}
+			details := ""
+			if ac.TLS || ac.TLSCA != "" || ac.TLSInsecure {
+				details += " [TLS]"
+			}
+			if ac.Token != "" {
+				details += " [TOKEN]"
+			}
+			fmt.Printf("%s %-20s %s%s\n", marker, ac.Name, ac.Agent, details)
+		}
+		return nil
+	},
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:               "use <name>",
+	Short:             "Make a context the current one",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeContexts,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.find(args[0]) == nil {
+			return fmt.Errorf("context %q not found", args[0])
+		}
+
+		cfg.CurrentContext = args[0]
+		if err := cfg.save(path); err != nil {
+			return err
+		}
+
+		fmt.Printf("Switched to context %s\n", args[0])
+		return nil
+	},
+}
+
+var contextSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Create or update a context from the agent flags",
+	Long: `Create a context, or update an existing one, from --agent, --token,
+--tls, --tls-ca, and --tls-insecure. Settings not given keep their current
+values; --tls=false turns TLS off again. The first context created becomes
+the current one.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeContexts,
+	RunE:              runContextSet,
+}
+
+var contextDeleteCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Short:             "Remove a context",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeContexts,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := loadCLIConfig()
+		if err != nil {
+			return err
+		}
+
+		kept := cfg.Contexts[:0]
+		for _, ac := range cfg.Contexts {
+			if ac.Name != args[0] {
+				kept = append(kept, ac)
+			}
+		}
+		if len(kept) == len(cfg.Contexts) {
+			return fmt.Errorf("context %q not found", args[0])
+		}
+		cfg.Contexts = kept
+		if cfg.CurrentContext == args[0] {
+			cfg.CurrentContext = ""
+		}
+		if err := cfg.save(path); err != nil {
+			return err
+		}
+
+		fmt.Printf("Deleted context %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("context", "", "agent context from the CLI config (default $"+contextEnv+" or the current context)")
+	rootCmd.RegisterFlagCompletionFunc("context", completeContexts)
+
+	rootCmd.AddCommand(contextCmd)
+	contextCmd.AddCommand(contextListCmd)
+	contextCmd.AddCommand(contextUseCmd)
+	contextCmd.AddCommand(contextSetCmd)
+	contextCmd.AddCommand(contextDeleteCmd)
+}
+
+func runContextSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, path, err := loadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	ac := cfg.find(name)
+	if ac == nil {
+		if !cmd.Flags().Changed("agent") {
+			return fmt.Errorf("--agent is required for a new context")
+		}
+		cfg.Contexts = append(cfg.Contexts, agentContext{Name: name})
+		ac = &cfg.Contexts[len(cfg.Contexts)-1]
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("agent") {
+		ac.Agent, _ = flags.GetString("agent")
+	}
+	if flags.Changed("token") {
+		ac.Token, _ = flags.GetString("token")
+	}
+	if flags.Changed("tls") {
+		ac.TLS, _ = flags.GetBool("tls")
+	}
+	if flags.Changed("tls-ca") {
+		caFile, _ := flags.GetString("tls-ca")
+		// Store an absolute path so the context works from any directory
+		if caFile != "" {
+			if caFile, err = filepath.Abs(caFile); err != nil {
+				return err
+			}
+		}
+		ac.TLSCA = caFile
+	}
+	if flags.Changed("tls-insecure") {
+		ac.TLSInsecure, _ = flags.GetBool("tls-insecure")
+	}
+
+	if cfg.CurrentContext == "" {
+		cfg.CurrentContext = name
+	}
+	if err := cfg.save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Context %s saved to %s\n", name, path)
+	return nil
+}
+
+// completeContexts completes context names from the CLI config
+func completeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, _, err := loadCLIConfig()
+	if err != nil || len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, ac := range cfg.Contexts {
+		names = append(names, ac.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/pkg/client"
+)
+
+// discoverCmd represents the discover command
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find agents on the local network",
+	Long: `Find BaudLink agents advertising themselves over mDNS on the local network.
+
+Agents advertise by default unless discovery is disabled in their
+configuration or they only listen on a loopback address. The address shown
+can be passed to --agent.
+
+Example:
+  baudlink discover
+  baudlink discover --timeout 5s`,
+	RunE: runDiscover,
+}
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+
+	discoverCmd.Flags().Duration("timeout", 2*time.Second, "how long to wait for answers")
+}
+
+func runDiscover(cmd *cobra.Command, args []string) error {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	agents, err := client.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("discovery failed: %w", err)
+	}
+
+	if len(agents) == 0 {
+		fmt.Println("No agents found")
+		return nil
+	}
+
+	fmt.Printf("Found %d agent(s):\n\n", len(agents))
+	for _, agent := range agents {
+		var requires []string
+		if agent.TLS {
+			requires = append(requires, "TLS")
+		}
+		if agent.Auth {
+			requires = append(requires, "token")
+		}
+
+		fmt.Printf("  %s\n", agent.Name)
+		fmt.Printf("    Address:  %s\n", agent.Address)
+		if agent.Version != "" {
+			fmt.Printf("    Version:  %s\n", agent.Version)
+		}
+		if len(requires) > 0 {
+			fmt.Printf("    Requires: %s\n", strings.Join(requires, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+	"github.com/Shoaibashk/BaudLink/internal/doctor"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+	"github.com/Shoaibashk/BaudLink/service"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check this system for common serial port problems",
+	Long: `Check this system for common causes of serial port problems and print
+a fix for each one found.
+
+The checks cover port enumeration, permissions on the serial devices and
+membership of the dialout group, login prompts (getty) and ModemManager
+grabbing ports, brltty claiming CH340 adapters, other programs holding
+ports open, and whether the service is installed and the agent responds.
+Run it as root to also see processes of other users holding ports.
+
+Example:
+  baudlink doctor
+  sudo baudlink doctor --config /etc/baudlink/agent.yaml`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringP("config", "c", "", "config file path of the service")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	var results []doctor.Result
+
+	ports, result := doctorEnumeration()
+	results = append(results, result)
+
+	var devices []string
+	for _, p := range ports {
+		if strings.HasPrefix(p.Name, "/dev/") {
+			devices = append(devices, p.Name)
+		}
+	}
+	results = append(results, doctor.System(devices)...)
+	results = append(results, doctorService(cmd), doctorAgent(cmd))
+
+	errs, warnings := 0, 0
+	for _, r := range results {
+		fmt.Printf("  [%-7s] %-13s %s\n", r.Status, r.Check, r.Message)
+		if r.Fix != "" {
+			fmt.Printf("  %23s fix: %s\n", "", r.Fix)
+		}
+		switch r.Status {
+		case doctor.StatusError:
+			errs++
+		case doctor.StatusWarning:
+			warnings++
+		}
+	}
+	fmt.Println()
+
+	if errs > 0 {
+		return fmt.Errorf("%d problem(s) and %d warning(s) found", errs, warnings)
+	}
+	if warnings > 0 {
+		fmt.Printf("No problems found, %d warning(s).\n", warnings)
+		return nil
+	}
+	fmt.Println("No problems found.")
+	return nil
+}
+
+// doctorEnumeration lists the ports of this system
+func doctorEnumeration() ([]serial.PortInfo, doctor.Result) {
+	const check = "ports"
+
+	scanner, err := serial.NewScanner(nil, nil)
+	if err == nil {
+		var ports []serial.PortInfo
+		if ports, err = scanner.Scan(); err == nil {
+			if len(ports) == 0 {
+				return nil, doctor.Result{
+					Check:   check,
+					Status:  doctor.StatusWarning,
+					Message: "no serial ports found",
+					Fix:     "check the cable, and that the adapter's driver loaded (dmesg on Linux, Device Manager on Windows)",
+				}
+			}
+			names := make([]string, len(ports))
+			for i, p := range ports {
+				names[i] = p.Name
+			}
+			return ports, doctor.Result{
+				Check:   check,
+				Status:  doctor.StatusOK,
+				Message: fmt.Sprintf("found %d serial port(s): %s", len(ports), strings.Join(names, ", ")),
+			}
+		}
+	}
+	return nil, doctor.Result{Check: check, Status: doctor.StatusError, Message: fmt.Sprintf("failed to enumerate ports: %v", err)}
+}
+
+// doctorService checks that the system service is installed and running
+func doctorService(cmd *cobra.Command) doctor.Result {
+	const check = "service"
+
+	cfg, err := loadServiceConfig(cmd)
+	if err != nil {
+		return doctor.Result{Check: check, Status: doctor.StatusError, Message: err.Error(), Fix: "baudlink config validate"}
+	}
+	if service.Running(cfg) {
+		return doctor.Result{Check: check, Status: doctor.StatusOK, Message: fmt.Sprintf("service %s is running", cfg.Service.Name)}
+	}
+
+	status, err := service.Status(cfg)
+	if err != nil {
+		status = err.Error()
+	}
+	return doctor.Result{
+		Check:   check,
+		Status:  doctor.StatusWarning,
+		Message: fmt.Sprintf("service %s is not running: %s", cfg.Service.Name, status),
+		Fix:     "sudo baudlink service install && sudo baudlink service start, unless the agent is run another way",
+	}
+}
+
+// doctorAgent checks that the agent answers
+func doctorAgent(cmd *cobra.Command) doctor.Result {
+	const check = "agent"
+
+	address, ok := remoteAgent(cmd)
+	if !ok {
+		address = defaultAgentAddress
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return doctor.Result{Check: check, Status: doctor.StatusError, Message: err.Error()}
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	info, err := client.GetAgentInfo(ctx, &pb.GetAgentInfoRequest{})
+	if err != nil {
+		return doctor.Result{
+			Check:   check,
+			Status:  doctor.StatusError,
+			Message: fmt.Sprintf("agent at %s does not respond: %v", address, err),
+			Fix:     "start the agent with baudlink serve or the service, or select it with --agent",
+		}
+	}
+	return doctor.Result{Check: check, Status: doctor.StatusOK, Message: fmt.Sprintf("agent %s responds at %s", info.Version, address)}
+}
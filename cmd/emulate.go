@@ -0,0 +1,162 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/emulator"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// emulateCmd represents the emulate command
+var emulateCmd = &cobra.Command{
+	Use:   "emulate <file>",
+	Short: "Impersonate a device with an emulator script",
+	Long: `Run an emulator script on the agent so it answers like a device, for
+developing and testing clients without the real hardware.
+
+Without --port the agent creates a virtual port (a pseudo-terminal, or a
+named pipe on Windows) and prints its path for the client to open. With
+--port the script answers on a physical port, e.g. one end of a null-modem
+cable. The emulator runs until this command is stopped.
+
+Scripts are YAML. Rules answer received data matching a regular expression
+(or match_hex bytes), optionally after a delay, and may move to another
+state; periodic messages are sent at a fixed interval:
+
+  name: modem
+  initial: command
+  rules:                          # apply in every state
+    - match: "AT\r"
+      respond: "\r\nOK\r\n"
+  states:
+    command:
+      rules:
+        - match: "ATD(\\d+)\r"
+          respond: "\r\nCONNECT 9600\r\n"
+          delay: 2s
+          goto: online
+    online:
+      on_enter:
+        - send: "welcome\r\n"
+      periodic:
+        - every: 1s
+          send: "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n"
+      rules:
+        - match: "\\+\\+\\+"
+          respond: "\r\nOK\r\n"
+          goto: command
+
+Responses may refer to capture groups as $1; write $$ for a literal dollar
+sign in responses (periodic and on_enter data is sent as written).
+
+Example:
+  baudlink emulate modem.yaml
+  baudlink emulate gps.yaml --port /dev/ttyUSB1 --baud 4800`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEmulate,
+}
+
+func init() {
+	rootCmd.AddCommand(emulateCmd)
+
+	emulateCmd.Flags().StringP("port", "p", "", "physical port to answer on (default: create a virtual port)")
+	emulateCmd.RegisterFlagCompletionFunc("port", completePorts)
+	emulateCmd.Flags().Uint32("baud", 0, "baud rate of --port (default: agent default or matching profile)")
+	emulateCmd.Flags().BoolP("quiet", "q", false, "do not print the transcript")
+}
+
+func runEmulate(cmd *cobra.Command, args []string) error {
+	portName, _ := cmd.Flags().GetString("port")
+	baud, _ := cmd.Flags().GetUint32("baud")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read script: %w", err)
+	}
+
+	// Validate locally so syntax errors are reported before touching the agent
+	if _, err := emulator.Parse(data); err != nil {
+		return err
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	req := &pb.EmulateRequest{Script: data, PortName: portName, ClientId: "baudlink-emulate"}
+	if portName != "" {
+		req.Config = monitorOpenRequest(portName, baud, false).Config
+	}
+
+	stream, err := client.Emulate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to start emulator: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("emulator ended: %w", err)
+		}
+
+		switch event.Type {
+		case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_STARTED:
+			fmt.Fprintf(os.Stderr, "Emulating %s on %s, press Ctrl+C to stop\n", event.Message, event.Address)
+		case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_SHUTDOWN:
+			fmt.Fprintln(os.Stderr, "Agent is shutting down")
+		default:
+			if !quiet {
+				printEmulatorEvent(event)
+			}
+		}
+	}
+}
+
+func printEmulatorEvent(event *pb.EmulatorEvent) {
+	at := time.Unix(0, event.Timestamp).Format("15:04:05.000")
+	switch event.Type {
+	case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_RECEIVED:
+		fmt.Printf("%s << %q\n", at, event.Data)
+	case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_MATCHED:
+		fmt.Printf("%s matched %s\n", at, event.Rule)
+	case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_SENT:
+		fmt.Printf("%s >> %q\n", at, event.Data)
+	case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_STATE:
+		fmt.Printf("%s state %s\n", at, event.State)
+	case pb.EmulatorEventType_EMULATOR_EVENT_TYPE_FAILED:
+		fmt.Printf("%s FAILED: %s\n", at, event.Message)
+	}
+}
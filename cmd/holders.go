@@ -0,0 +1,98 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// holdersCmd represents the holders command
+var holdersCmd = &cobra.Command{
+	Use:   "holders <port>",
+	Short: "List the clients holding a port",
+	Long: `List the clients holding a port on a running agent: the client that
+opened it and, when the agent allows shared access, every client sharing it,
+with their byte counters, queued writes, and writes refused by the shared
+write quota.
+
+Example:
+  baudlink holders /dev/ttyUSB0
+  baudlink holders COM3 --agent 10.0.0.5:50051`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runHolders,
+}
+
+func init() {
+	rootCmd.AddCommand(holdersCmd)
+}
+
+func runHolders(cmd *cobra.Command, args []string) error {
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := client.GetPortHolders(context.Background(), &pb.GetPortHoldersRequest{PortName: portName})
+	if err != nil {
+		return fmt.Errorf("failed to get port holders: %w", err)
+	}
+
+	if len(resp.Holders) == 0 {
+		fmt.Printf("%s is not open.\n", portName)
+		return nil
+	}
+
+	fmt.Printf("%s is held by %d client(s):\n\n", portName, len(resp.Holders))
+
+	for _, h := range resp.Holders {
+		flags := ""
+		if h.Opener {
+			flags += " [OPENER]"
+		}
+		if h.Role == pb.SessionRole_SESSION_ROLE_READ_ONLY {
+			flags += " [READ-ONLY]"
+		}
+
+		fmt.Printf("  %s%s\n", h.ClientId, flags)
+		fmt.Printf("    ID:           %s\n", h.Id)
+		fmt.Printf("    Held for:     %s\n", time.Since(time.Unix(h.Since, 0)).Truncate(time.Second))
+		fmt.Printf("    Sent:         %d bytes\n", h.BytesSent)
+		fmt.Printf("    Received:     %d bytes\n", h.BytesReceived)
+		if h.QueuedWrites > 0 {
+			fmt.Printf("    Queued:       %d write(s)\n", h.QueuedWrites)
+		}
+		if h.QuotaRejections > 0 {
+			fmt.Printf("    Over quota:   %d write(s) refused\n", h.QuotaRejections)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
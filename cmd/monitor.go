@@ -0,0 +1,283 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/display"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// monitorCmd represents the monitor command
+var monitorCmd = &cobra.Command{
+	Use:   "monitor [port]",
+	Short: "Print data received on a port",
+	Long: `Open a port through the agent and print received data until interrupted.
+
+Data is written to stdout as received. With --hex the agent formats each
+chunk as a hex+ASCII dump with stream offsets.
+
+With --ports several ports are opened at once and their output is
+interleaved line by line, each line prefixed with its receive time and
+the port it came from, colored when stdout is a terminal.
+
+Display options switch the monitor to line mode and compose in order:
+--timestamps absolute|relative, --delta for the time since the previous
+line, --escape to show non-printable bytes as \r, \n, \t, and \xNN
+escapes. With --input, lines typed on stdin are sent to the port and shown
+labelled TX, received lines RX, colored by direction.
+
+--log writes a timestamped RX/TX transcript to a local file as well,
+rotated once it reaches --log-max-size megabytes. It is independent of the
+agent's capture and tap features.
+
+--sniff observes a port read-only. If another client has the port open,
+even exclusively, the monitor attaches to its session and sees the data it
+receives; otherwise the port is opened without asserting DTR or RTS and
+writes are refused until the monitor exits.
+
+Example:
+  baudlink monitor /dev/ttyUSB0
+  baudlink monitor COM3 --baud 115200 --hex
+  baudlink monitor --ports COM3,COM4,/dev/ttyUSB0
+  baudlink monitor COM3 --timestamps relative --delta --escape
+  baudlink monitor /dev/ttyUSB2 --input --eol cr
+  baudlink monitor COM3 --input --log session.log
+  baudlink monitor /dev/ttyUSB0 --sniff`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runMonitor,
+}
+
+func init() {
+	rootCmd.AddCommand(monitorCmd)
+
+	monitorCmd.Flags().Uint32("baud", 0, "baud rate (default: agent default or matching profile)")
+	monitorCmd.Flags().Bool("hex", false, "show a hex+ASCII dump instead of raw data")
+	monitorCmd.Flags().StringSlice("ports", nil, "monitor several ports and merge their output")
+	monitorCmd.RegisterFlagCompletionFunc("ports", completePorts)
+	monitorCmd.Flags().String("timestamps", "", "prefix lines with absolute or relative times, or none (default: absolute with --ports)")
+	monitorCmd.Flags().Bool("delta", false, "prefix lines with the time since the previous line")
+	monitorCmd.Flags().Bool("escape", false, "show non-printable bytes as escapes")
+	monitorCmd.Flags().Bool("input", false, "send lines typed on stdin to the port")
+	monitorCmd.Flags().String("eol", "crlf", "line ending appended to --input lines: crlf, cr, lf, or none")
+	monitorCmd.Flags().String("log", "", "also write a timestamped transcript to this file")
+	monitorCmd.Flags().Int("log-max-size", 10, "megabytes before the transcript is rotated (0 disables rotation)")
+	monitorCmd.Flags().Int("log-backups", 5, "rotated transcripts to keep")
+	monitorCmd.Flags().Bool("no-color", false, "do not color output (also set by NO_COLOR)")
+	monitorCmd.Flags().Bool("sniff", false, "observe the port read-only, alongside the client that has it open")
+}
+
+func runMonitor(cmd *cobra.Command, args []string) error {
+	baud, _ := cmd.Flags().GetUint32("baud")
+	hexDump, _ := cmd.Flags().GetBool("hex")
+	ports, _ := cmd.Flags().GetStringSlice("ports")
+	sniff, _ := cmd.Flags().GetBool("sniff")
+
+	if len(args) == 1 && len(ports) > 0 {
+		return fmt.Errorf("give either a port or --ports, not both")
+	}
+	if len(args) == 0 && len(ports) == 0 {
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("a port or --ports is required")
+		}
+		portName, err := pickPort(cmd)
+		if err != nil {
+			return err
+		}
+		args = []string{portName}
+	}
+
+	names := ports
+	if len(names) == 0 {
+		names = args
+	}
+	opts, lineMode, err := monitorDisplayOptions(cmd, names)
+	if err != nil {
+		return err
+	}
+	if sniff && opts.input {
+		return fmt.Errorf("--input cannot be combined with --sniff")
+	}
+	opts.baud = baud
+	opts.hexDump = hexDump
+	opts.sniff = sniff
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if logPath, _ := cmd.Flags().GetString("log"); logPath != "" {
+		maxSize, _ := cmd.Flags().GetInt("log-max-size")
+		backups, _ := cmd.Flags().GetInt("log-backups")
+		if maxSize < 0 || backups < 0 {
+			return fmt.Errorf("--log-max-size and --log-backups must not be negative")
+		}
+		opts.log, opts.logPipeline, err = openTranscript(logPath, maxSize, backups, names)
+		if err != nil {
+			return err
+		}
+		defer opts.log.Close()
+		lineMode = true
+	}
+
+	if lineMode {
+		return monitorLines(ctx, client, names, opts)
+	}
+
+	portName := args[0]
+	resp, err := client.OpenPort(ctx, monitorOpenRequest(portName, baud, sniff))
+	if err != nil {
+		return fmt.Errorf("failed to open port: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to open port: %s", resp.Message)
+	}
+	defer releaseMonitorSession(client, portName, resp.SessionId, resp.Attached)
+
+	stream, err := client.StreamRead(ctx, &pb.StreamReadRequest{
+		PortName:  portName,
+		SessionId: resp.SessionId,
+		HexDump:   hexDump,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start stream: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Monitoring %s, press Ctrl+C to stop\n", portName)
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("stream ended: %w", err)
+		}
+
+		if chunk.Gap {
+			fmt.Fprintln(os.Stderr, "--- device reconnected, data may have been lost ---")
+			continue
+		}
+		if lost := chunk.Overflow; lost != nil {
+			fmt.Fprintf(os.Stderr, "--- monitor fell behind, %d chunks (%d bytes) lost ---\n", lost.LostChunks, lost.LostBytes)
+			continue
+		}
+
+		if hexDump {
+			fmt.Print(chunk.HexDump)
+		} else {
+			os.Stdout.Write(chunk.Data)
+		}
+	}
+}
+
+// monitorOpenRequest builds the request opening a port for monitoring, with
+// the agent's defaults unless a baud rate is given
+func monitorOpenRequest(portName string, baud uint32, sniff bool) *pb.OpenPortRequest {
+	req := &pb.OpenPortRequest{PortName: portName, ClientId: "baudlink-monitor"}
+	if sniff {
+		req.Mode = pb.OpenMode_OPEN_MODE_SNIFF
+	}
+	if baud > 0 {
+		req.Config = &pb.PortConfig{
+			BaudRate:      baud,
+			DataBits:      pb.DataBits_DATA_BITS_8,
+			StopBits:      pb.StopBits_STOP_BITS_1,
+			Parity:        pb.Parity_PARITY_NONE,
+			ReadTimeoutMs: 1000,
+		}
+	}
+	return req
+}
+
+// releaseMonitorSession closes the session the monitor opened, or detaches
+// from the session it attached to when sniffing
+func releaseMonitorSession(client pb.SerialServiceClient, portName string, sessionID string, attached bool) {
+	if attached {
+		client.DetachSession(context.Background(), &pb.DetachSessionRequest{PortName: portName, AttachmentId: sessionID})
+		return
+	}
+	client.ClosePort(context.Background(), &pb.ClosePortRequest{PortName: portName, SessionId: sessionID})
+}
+
+// eolSequences maps --eol values to the bytes they append
+var eolSequences = map[string]string{
+	"crlf": "\r\n",
+	"cr":   "\r",
+	"lf":   "\n",
+	"none": "",
+}
+
+// monitorDisplayOptions builds the display pipeline from the command's flags
+// and reports whether any of them needs line mode
+func monitorDisplayOptions(cmd *cobra.Command, names []string) (lineMonitorOptions, bool, error) {
+	timestamps, _ := cmd.Flags().GetString("timestamps")
+	delta, _ := cmd.Flags().GetBool("delta")
+	escape, _ := cmd.Flags().GetBool("escape")
+	input, _ := cmd.Flags().GetBool("input")
+	eolName, _ := cmd.Flags().GetString("eol")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+
+	color := !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	multi := len(names) > 1 || cmd.Flags().Changed("ports")
+
+	eol, ok := eolSequences[eolName]
+	if !ok {
+		return lineMonitorOptions{}, false, fmt.Errorf("invalid --eol %q: use crlf, cr, lf, or none", eolName)
+	}
+	opts := lineMonitorOptions{keepCR: escape, input: input, eol: eol}
+
+	if timestamps == "" && multi {
+		timestamps = "absolute"
+	}
+	switch timestamps {
+	case "", "none":
+	case "absolute":
+		opts.pipeline = append(opts.pipeline, display.AbsoluteTime("15:04:05.000"))
+	case "relative":
+		opts.pipeline = append(opts.pipeline, display.RelativeTime())
+	default:
+		return lineMonitorOptions{}, false, fmt.Errorf("invalid --timestamps %q: use absolute, relative, or none", timestamps)
+	}
+	if delta {
+		opts.pipeline = append(opts.pipeline, display.Delta())
+	}
+	if multi {
+		opts.pipeline = append(opts.pipeline, display.PortLabel(names, color))
+	}
+	if escape {
+		opts.pipeline = append(opts.pipeline, display.Escape())
+	}
+	if input {
+		opts.pipeline = append(opts.pipeline, display.DirectionLabel(color))
+	}
+
+	return opts, len(opts.pipeline) > 0 || multi, nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/display"
+	"github.com/Shoaibashk/BaudLink/internal/rotate"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// lineFlushDelay is how long an unterminated line waits for its newline
+// before it is printed anyway
+const lineFlushDelay = 500 * time.Millisecond
+
+// lineMonitorOptions configures a line-oriented monitor
+type lineMonitorOptions struct {
+	baud     uint32
+	hexDump  bool
+	pipeline display.Pipeline
+	keepCR   bool
+	input    bool
+	eol      string
+	sniff    bool
+
+	// log, when set, receives a transcript rendered by logPipeline
+	log         *rotate.File
+	logPipeline display.Pipeline
+}
+
+// monitoredPort is one port of a line-oriented monitor
+type monitoredPort struct {
+	name      string
+	sessionID string
+	attached  bool // sessionID is a sniffing attachment
+	pending   []byte
+	pendingAt time.Time
+}
+
+// portChunk is a chunk or stream error from one of the monitored ports
+type portChunk struct {
+	port  *monitoredPort
+	chunk *pb.DataChunk
+	err   error
+}
+
+// monitorLines opens every port and prints their output interleaved, one
+// line at a time through the display pipeline, until interrupted or every
+// stream has ended. With input set, lines read from stdin are sent to every
+// port and shown as TX.
+func monitorLines(ctx context.Context, client pb.SerialServiceClient, names []string, opts lineMonitorOptions) error {
+	var ports []*monitoredPort
+	defer func() {
+		for _, p := range ports {
+			releaseMonitorSession(client, p.name, p.sessionID, p.attached)
+		}
+	}()
+
+	for _, name := range names {
+		resp, err := client.OpenPort(ctx, monitorOpenRequest(name, opts.baud, opts.sniff))
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		if !resp.Success {
+			return fmt.Errorf("failed to open %s: %s", name, resp.Message)
+		}
+		ports = append(ports, &monitoredPort{name: name, sessionID: resp.SessionId, attached: resp.Attached})
+	}
+
+	chunks := make(chan portChunk)
+	for _, p := range ports {
+		stream, err := client.StreamRead(ctx, &pb.StreamReadRequest{
+			PortName:          p.name,
+			SessionId:         p.sessionID,
+			HexDump:           opts.hexDump,
+			IncludeTimestamps: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start stream on %s: %w", p.name, err)
+		}
+
+		go func(p *monitoredPort) {
+			for {
+				chunk, err := stream.Recv()
+				select {
+				case chunks <- portChunk{port: p, chunk: chunk, err: err}:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(p)
+	}
+
+	var typed chan string
+	if opts.input {
+		typed = make(chan string)
+		go readInput(ctx, typed)
+	}
+
+	fmt.Fprintf(os.Stderr, "Monitoring %s, press Ctrl+C to stop\n", strings.Join(names, ", "))
+
+	ticker := time.NewTicker(lineFlushDelay / 2)
+	defer ticker.Stop()
+
+	show := func(port string, dir display.Direction, at time.Time, data []byte) {
+		if opts.log != nil {
+			line := display.Line{Port: port, Direction: dir, Time: at, Data: data}
+			fmt.Fprintln(opts.log, opts.logPipeline.Render(line))
+		}
+		if !opts.keepCR {
+			data = bytes.TrimRight(data, "\r")
+		}
+		fmt.Println(opts.pipeline.Render(display.Line{Port: port, Direction: dir, Time: at, Data: data}))
+	}
+	note := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		if opts.log != nil {
+			fmt.Fprintf(opts.log, "%s --- %s ---\n", time.Now().Format(transcriptTimeLayout), msg)
+		}
+		fmt.Fprintf(os.Stderr, "--- %s ---\n", msg)
+	}
+	flush := func(p *monitoredPort) {
+		if len(p.pending) > 0 {
+			show(p.name, display.RX, p.pendingAt, p.pending)
+			p.pending = p.pending[:0]
+		}
+	}
+
+	active := len(ports)
+	for {
+		select {
+		case <-ctx.Done():
+			for _, p := range ports {
+				flush(p)
+			}
+			return nil
+
+		case now := <-ticker.C:
+			for _, p := range ports {
+				if len(p.pending) > 0 && now.Sub(p.pendingAt) >= lineFlushDelay {
+					flush(p)
+				}
+			}
+
+		case line, ok := <-typed:
+			if !ok {
+				typed = nil
+				continue
+			}
+			for _, p := range ports {
+				resp, err := client.Write(ctx, &pb.WriteRequest{PortName: p.name, SessionId: p.sessionID, Data: []byte(line + opts.eol)})
+				if err != nil {
+					note("%s: write failed: %v", p.name, err)
+					continue
+				}
+				if !resp.Success {
+					note("%s: write failed: %s", p.name, resp.Message)
+					continue
+				}
+				flush(p)
+				show(p.name, display.TX, time.Now(), []byte(line))
+			}
+
+		case pc := <-chunks:
+			p, chunk := pc.port, pc.chunk
+			if pc.err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				flush(p)
+				note("%s: stream ended: %v", p.name, pc.err)
+				if active--; active == 0 {
+					return fmt.Errorf("all streams ended")
+				}
+				continue
+			}
+
+			if chunk.Gap {
+				flush(p)
+				note("%s: device reconnected, data may have been lost", p.name)
+				continue
+			}
+			if lost := chunk.Overflow; lost != nil {
+				flush(p)
+				note("%s: monitor fell behind, %d chunks (%d bytes) lost", p.name, lost.LostChunks, lost.LostBytes)
+				continue
+			}
+
+			data := chunk.Data
+			if opts.hexDump {
+				data = []byte(chunk.HexDump)
+			}
+			at := chunkTime(chunk)
+			for len(data) > 0 {
+				if len(p.pending) == 0 {
+					p.pendingAt = at
+				}
+				i := bytes.IndexByte(data, '\n')
+				if i < 0 {
+					p.pending = append(p.pending, data...)
+					break
+				}
+				p.pending = append(p.pending, data[:i]...)
+				data = data[i+1:]
+				flush(p)
+			}
+		}
+	}
+}
+
+// transcriptTimeLayout is the time format of --log transcripts
+const transcriptTimeLayout = "2006-01-02 15:04:05.000"
+
+// openTranscript opens a rotated --log transcript. Lines carry the full
+// receive time, the port, and the direction, with non-printable bytes
+// escaped so the file stays plain text.
+func openTranscript(path string, maxSizeMB, backups int, names []string) (*rotate.File, display.Pipeline, error) {
+	file, err := rotate.Open(path, int64(maxSizeMB)*1024*1024, backups)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log: %w", err)
+	}
+	pipeline := display.Pipeline{
+		display.AbsoluteTime(transcriptTimeLayout),
+		display.PortLabel(names, false),
+		display.DirectionLabel(false),
+		display.Escape(),
+	}
+	return file, pipeline, nil
+}
+
+// readInput sends each line read from stdin until EOF, then closes lines
+func readInput(ctx context.Context, lines chan<- string) {
+	defer close(lines)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		select {
+		case lines <- scanner.Text():
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// chunkTime returns when the agent received a chunk, falling back to the
+// local time for agents that do not report it
+func chunkTime(chunk *pb.DataChunk) time.Time {
+	if chunk.FirstByteTime != 0 {
+		return time.Unix(0, chunk.FirstByteTime)
+	}
+	if chunk.Timestamp != 0 {
+		return time.Unix(0, chunk.Timestamp)
+	}
+	return time.Now()
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// passthroughCmd represents the passthrough command
+var passthroughCmd = &cobra.Command{
+	Use:   "passthrough <port>",
+	Short: "Hand a port to a program on the agent as a raw byte stream",
+	Long: `Open a port exclusively and expose it on the agent's machine as a
+pseudo-terminal (a named pipe on Windows) or a TCP listener, so pppd, SLIP
+daemons, and similar programs can use a modem managed by BaudLink.
+
+While the passthrough runs, framing, write pacing, and rate limits are
+bypassed, and the agent refuses other reads and writes on the port so the
+program is its only reader. The passthrough ends when this command is
+stopped. Passthrough must be enabled in the agent's configuration.
+
+Example:
+  baudlink passthrough /dev/ttyUSB2 --baud 115200 --pty
+  pppd /dev/pts/4 115200 noauth defaultroute
+
+  baudlink passthrough /dev/ttyUSB2 --tcp 127.0.0.1:2000
+  pppd pty "socat - TCP:127.0.0.1:2000" noauth`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runPassthrough,
+}
+
+func init() {
+	rootCmd.AddCommand(passthroughCmd)
+
+	passthroughCmd.Flags().Uint32("baud", 0, "baud rate (default: agent default or matching profile)")
+	passthroughCmd.Flags().Bool("pty", false, "expose the port as a pseudo-terminal on the agent")
+	passthroughCmd.Flags().String("tcp", "", "expose the port on a TCP listener of the agent at host:port")
+}
+
+func runPassthrough(cmd *cobra.Command, args []string) error {
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	baud, _ := cmd.Flags().GetUint32("baud")
+	usePty, _ := cmd.Flags().GetBool("pty")
+	tcpAddress, _ := cmd.Flags().GetString("tcp")
+
+	if usePty == (tcpAddress != "") {
+		return fmt.Errorf("exactly one of --pty and --tcp is required")
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	req := &pb.OpenPortRequest{PortName: portName, ClientId: "baudlink-passthrough", Exclusive: true}
+	if baud > 0 {
+		req.Config = &pb.PortConfig{
+			BaudRate:      baud,
+			DataBits:      pb.DataBits_DATA_BITS_8,
+			StopBits:      pb.StopBits_STOP_BITS_1,
+			Parity:        pb.Parity_PARITY_NONE,
+			ReadTimeoutMs: 1000,
+		}
+	}
+
+	resp, err := client.OpenPort(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to open port: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to open port: %s", resp.Message)
+	}
+
+	// ctx is cancelled by Ctrl+C, so cleanup calls get their own deadline
+	cleanup := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 5*time.Second)
+	}
+	defer func() {
+		ctx, cancel := cleanup()
+		defer cancel()
+		client.ClosePort(ctx, &pb.ClosePortRequest{PortName: portName, SessionId: resp.SessionId})
+	}()
+
+	started, err := client.StartPassthrough(ctx, &pb.StartPassthroughRequest{
+		PortName:   portName,
+		SessionId:  resp.SessionId,
+		Pty:        usePty,
+		TcpAddress: tcpAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start passthrough: %w", err)
+	}
+	if !started.Success {
+		return fmt.Errorf("failed to start passthrough: %s", started.Message)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is passed through at %s on the agent, press Ctrl+C to stop\n", portName, started.Address)
+
+	<-ctx.Done()
+
+	stopCtx, cancel := cleanup()
+	defer cancel()
+
+	stopped, err := client.StopPassthrough(stopCtx, &pb.StopPassthroughRequest{PortName: portName, SessionId: resp.SessionId})
+	if err != nil {
+		return fmt.Errorf("failed to stop passthrough: %w", err)
+	}
+	if stopped.Success {
+		fmt.Fprintf(os.Stderr, "Passthrough stopped: %d bytes in, %d bytes out\n", stopped.BytesIn, stopped.BytesOut)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// probeCmd represents the probe command
+var probeCmd = &cobra.Command{
+	Use:   "probe <port>",
+	Short: "Identify the device connected to a port",
+	Long: `Identify the device connected to a port through the agent.
+
+The port is opened exclusively and a set of well-known probes is tried at
+each baud rate in turn:
+
+  nmea     listen for NMEA 0183 sentences with valid checksums
+  at       send AT and ATI, expecting a modem result code
+  scpi     send *IDN? and expect an instrument identification
+  modbus   read holding register 0 from Modbus RTU slave addresses
+
+Probing stops at the first baud rate giving a confident match unless --all
+is set. Probes send data to the device, so only probe ports whose device
+tolerates unexpected input.
+
+Example:
+  baudlink probe /dev/ttyUSB0
+  baudlink probe COM3 --baud 9600,19200 --probe modbus --modbus-max 32`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runProbe,
+}
+
+func init() {
+	rootCmd.AddCommand(probeCmd)
+
+	probeCmd.Flags().StringSlice("baud", nil, "baud rates to try in order (default: common rates)")
+	probeCmd.Flags().StringSlice("probe", nil, "probes to run: nmea, at, scpi, modbus (default: all)")
+	probeCmd.Flags().Duration("timeout", 0, "response timeout of each request (default: 500ms)")
+	probeCmd.Flags().Duration("listen", 0, "time spent listening for unsolicited data (default: 1.5s)")
+	probeCmd.Flags().Uint32("modbus-max", 0, "highest Modbus slave address scanned (default: 10)")
+	probeCmd.Flags().Bool("all", false, "try every baud rate even after a confident match")
+}
+
+func runProbe(cmd *cobra.Command, args []string) error {
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	bauds, _ := cmd.Flags().GetStringSlice("baud")
+	probes, _ := cmd.Flags().GetStringSlice("probe")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	listen, _ := cmd.Flags().GetDuration("listen")
+	modbusMax, _ := cmd.Flags().GetUint32("modbus-max")
+	all, _ := cmd.Flags().GetBool("all")
+
+	var rates []uint32
+	for _, b := range bauds {
+		baud, err := strconv.ParseUint(b, 10, 32)
+		if err != nil || baud == 0 {
+			return fmt.Errorf("invalid baud rate: %s", b)
+		}
+		rates = append(rates, uint32(baud))
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	req := &pb.IdentifyDeviceRequest{
+		PortName:         portName,
+		ClientId:         "baudlink-probe",
+		BaudRates:        rates,
+		Probes:           probes,
+		TimeoutMs:        uint32(timeout.Milliseconds()),
+		ListenMs:         uint32(listen.Milliseconds()),
+		ModbusMaxAddress: modbusMax,
+		Exhaustive:       all,
+	}
+
+	fmt.Printf("Probing %s...\n\n", portName)
+
+	resp, err := client.IdentifyDevice(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("probe failed: %w", err)
+	}
+
+	if len(resp.Candidates) > 0 {
+		fmt.Printf("  %-8s %-8s %-11s %s\n", "PROTOCOL", "BAUD", "CONFIDENCE", "DETAIL")
+		for _, c := range resp.Candidates {
+			fmt.Printf("  %-8s %-8d %-11s %s\n", c.Protocol, c.BaudRate, fmt.Sprintf("%.0f%%", c.Confidence*100), c.Detail)
+		}
+		fmt.Println()
+	}
+
+	tried := make([]string, len(resp.BaudRates))
+	for i, r := range resp.BaudRates {
+		tried[i] = fmt.Sprint(r)
+	}
+	if len(tried) > 0 {
+		fmt.Printf("Baud rates tried: %s\n", strings.Join(tried, ", "))
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+
+	fmt.Printf("Most likely: %s\n", resp.Message)
+	return nil
+}
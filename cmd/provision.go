@@ -0,0 +1,222 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/service"
+)
+
+// provisionCmd represents the provision command
+var provisionCmd = &cobra.Command{
+	Use:   "provision",
+	Short: "Apply a declared agent state without prompts",
+	Long: `Bring this machine's agent to the state declared in a provisioning file,
+for configuration management tools such as Ansible or Terraform.
+
+The file declares the agent configuration, merged over the defaults, the
+access tokens, and whether the system service is installed and running:
+
+  config:
+    server:
+      grpc_address: 0.0.0.0:50051
+    auth:
+      enabled: true
+  tokens:
+    - name: ci
+      roles: [lab]
+    - name: ops
+      admin: true
+  service:
+    install: true
+    start: true
+
+Tokens without a value keep the value they already have in the
+configuration file, or are given a random one; tokens no longer declared
+are removed. Running provision again with the same file changes nothing.
+The configuration file is rewritten only when its settings differ, without
+its comments, and a running service is restarted to apply it.
+
+The result is printed to stdout as JSON, listing each step as "changed" or
+"unchanged" with the values of newly created tokens, and the command exits
+non-zero if a step failed. --check reports what would change without
+changing anything.
+
+Example:
+  sudo baudlink provision --from gateway.yaml
+  baudlink provision --from gateway.yaml --check`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runProvision,
+}
+
+func init() {
+	rootCmd.AddCommand(provisionCmd)
+
+	provisionCmd.Flags().String("from", "", "provisioning file declaring the agent's state")
+	provisionCmd.MarkFlagRequired("from")
+	provisionCmd.Flags().StringP("config", "c", "", "config file path (default: the service's config file)")
+	provisionCmd.Flags().Bool("check", false, "report what would change without changing anything")
+}
+
+// provisionResult is the JSON document provision prints
+type provisionResult struct {
+	Changed bool            `json:"changed"`
+	Check   bool            `json:"check,omitempty"`
+	Config  string          `json:"config"`
+	Steps   []provisionStep `json:"steps"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// provisionStep is the outcome of one part of a provision
+type provisionStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // changed, unchanged, or failed
+	Detail string `json:"detail,omitempty"`
+	Token  string `json:"token,omitempty"` // Value of a newly created token
+}
+
+func runProvision(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	check, _ := cmd.Flags().GetBool("check")
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = service.GetConfigPath()
+	}
+
+	result := &provisionResult{Check: check, Config: path, Steps: []provisionStep{}}
+	err := provision(result, from, path, check)
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(result); encErr != nil {
+		return encErr
+	}
+	return err
+}
+
+// provision applies the provisioning file at from, recording each step in
+// result
+func provision(result *provisionResult, from string, path string, check bool) error {
+	p, err := config.LoadProvision(from)
+	if err != nil {
+		return err
+	}
+	plan, err := p.Plan(path)
+	if err != nil {
+		return err
+	}
+
+	// step records a step; failed marks the latest one as failed
+	step := func(name string, changed bool, detail string) *provisionStep {
+		s := provisionStep{Name: name, Status: "unchanged", Detail: detail}
+		if changed {
+			s.Status = "changed"
+			result.Changed = true
+		}
+		result.Steps = append(result.Steps, s)
+		return &result.Steps[len(result.Steps)-1]
+	}
+	failed := func(err error) error {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		return err
+	}
+
+	created := make(map[string]bool)
+	for _, name := range plan.Created {
+		created[name] = true
+	}
+	for _, t := range plan.Config.Auth.Tokens {
+		if !created[t.Name] {
+			step("token/"+t.Name, false, "")
+			continue
+		}
+		if s := step("token/"+t.Name, true, "created"); !check {
+			s.Token = t.Token
+		}
+	}
+	for _, name := range plan.Removed {
+		step("token/"+name, true, "removed")
+	}
+
+	step("config", plan.Changed, path)
+	if plan.Changed && !check {
+		if err := writeProvisionedConfig(plan, path); err != nil {
+			return failed(err)
+		}
+	}
+
+	cfg := plan.Config
+	if p.Service.Install || p.Service.Start {
+		installed := service.Installed(cfg)
+		step("service/install", !installed, cfg.Service.Name)
+		if !installed && !check {
+			if err := toStderr(func() error { return service.Install(cfg) }); err != nil {
+				return failed(err)
+			}
+		}
+	}
+
+	running := service.Running(cfg)
+	switch {
+	case p.Service.Start && !running:
+		step("service/start", true, cfg.Service.Name)
+		if !check {
+			if err := toStderr(func() error { return service.Start(cfg) }); err != nil {
+				return failed(err)
+			}
+		}
+	case running && plan.Changed:
+		step("service/restart", true, "restarted to apply the configuration")
+		if !check {
+			if err := toStderr(func() error { return service.Restart(cfg) }); err != nil {
+				return failed(err)
+			}
+		}
+	case p.Service.Start:
+		step("service/start", false, cfg.Service.Name)
+	}
+
+	return nil
+}
+
+// writeProvisionedConfig writes a planned configuration file. A file
+// holding credentials is replaced without ever being readable by other
+// users, keeping the owner and group the service install may have set.
+func writeProvisionedConfig(plan *config.ProvisionPlan, path string) error {
+	if plan.Secrets {
+		return plan.Config.SavePrivate(path)
+	}
+	return plan.Config.Save(path)
+}
+
+// toStderr runs fn with standard output sent to standard error, keeping
+// stdout for the JSON result
+func toStderr(fn func() error) error {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = stdout }()
+	return fn()
+}
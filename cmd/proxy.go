@@ -0,0 +1,179 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/display"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// proxyCmd represents the proxy command
+var proxyCmd = &cobra.Command{
+	Use:   "proxy <port-a> <port-b>",
+	Short: "Forward traffic between two ports and print it",
+	Long: `Connect two ports back to back through the agent, as a man in the middle
+between two devices. Everything one port receives is written to the other,
+and each chunk is printed with its receive time and direction.
+
+--filter-ab and --filter-ba run the agent's filters on data before it is
+forwarded, for example replace:0D=0D0A, so traffic can be modified in
+flight; chunks the filters changed are printed with what was forwarded.
+--delay-ab and --delay-ba add latency to a direction. Both ports are opened
+exclusively until this command is stopped; other clients can still watch
+them with monitor --sniff.
+
+Example:
+  baudlink proxy /dev/ttyUSB0 /dev/ttyUSB1 --baud 9600
+  baudlink proxy COM3 COM4 --baud 19200 --hex --delay-ba 50ms
+  baudlink proxy COM3 COM4 --filter-ab replace:02=03`,
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completePortArgs(2),
+	RunE:              runProxy,
+}
+
+func init() {
+	rootCmd.AddCommand(proxyCmd)
+
+	proxyCmd.Flags().Uint32("baud", 0, "baud rate of both ports (default: agent default or matching profile)")
+	proxyCmd.Flags().Uint32("peer-baud", 0, "baud rate of port B when it differs from --baud")
+	proxyCmd.Flags().StringSlice("filter-ab", nil, "filters applied to data from A before it is written to B")
+	proxyCmd.Flags().StringSlice("filter-ba", nil, "filters applied to data from B before it is written to A")
+	proxyCmd.Flags().Duration("delay-ab", 0, "latency added to data from A to B")
+	proxyCmd.Flags().Duration("delay-ba", 0, "latency added to data from B to A")
+	proxyCmd.Flags().Bool("hex", false, "print data as hex bytes instead of escaped text")
+	proxyCmd.Flags().Bool("no-color", false, "do not color output (also set by NO_COLOR)")
+}
+
+func runProxy(cmd *cobra.Command, args []string) error {
+	portA, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	portB, err := portArg(cmd, args, 1)
+	if err != nil {
+		return err
+	}
+	baud, _ := cmd.Flags().GetUint32("baud")
+	peerBaud, _ := cmd.Flags().GetUint32("peer-baud")
+	filtersAToB, _ := cmd.Flags().GetStringSlice("filter-ab")
+	filtersBToA, _ := cmd.Flags().GetStringSlice("filter-ba")
+	delayAToB, _ := cmd.Flags().GetDuration("delay-ab")
+	delayBToA, _ := cmd.Flags().GetDuration("delay-ba")
+	hexData, _ := cmd.Flags().GetBool("hex")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+
+	if delayAToB < 0 || delayBToA < 0 {
+		return fmt.Errorf("--delay-ab and --delay-ba must not be negative")
+	}
+	if peerBaud == 0 {
+		peerBaud = baud
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stream, err := client.ProxyPorts(ctx, &pb.ProxyPortsRequest{
+		PortName:     portA,
+		PeerPortName: portB,
+		Config:       monitorOpenRequest(portA, baud, false).Config,
+		PeerConfig:   monitorOpenRequest(portB, peerBaud, false).Config,
+		ClientId:     "baudlink-proxy",
+		FiltersAToB:  filtersAToB,
+		FiltersBToA:  filtersBToA,
+		DelayAToBMs:  uint32(delayAToB / time.Millisecond),
+		DelayBToAMs:  uint32(delayBToA / time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start proxy: %w", err)
+	}
+
+	labels := map[pb.ProxyDirection]string{
+		pb.ProxyDirection_PROXY_DIRECTION_A_TO_B: portA + " > " + portB,
+		pb.ProxyDirection_PROXY_DIRECTION_B_TO_A: portB + " > " + portA,
+	}
+	color := !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	data := display.Escape()
+	if hexData {
+		data = display.Hex()
+	}
+	pipeline := display.Pipeline{
+		display.AbsoluteTime("15:04:05.000"),
+		display.PortLabel([]string{labels[pb.ProxyDirection_PROXY_DIRECTION_A_TO_B], labels[pb.ProxyDirection_PROXY_DIRECTION_B_TO_A]}, color),
+		data,
+	}
+
+	var bytesAToB, bytesBToA int
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Fprintf(os.Stderr, "Proxy stopped: %d bytes %s, %d bytes %s\n",
+					bytesAToB, labels[pb.ProxyDirection_PROXY_DIRECTION_A_TO_B], bytesBToA, labels[pb.ProxyDirection_PROXY_DIRECTION_B_TO_A])
+				return nil
+			}
+			return fmt.Errorf("proxy ended: %w", err)
+		}
+
+		switch {
+		case event.Started:
+			fmt.Fprintf(os.Stderr, "Proxying %s <-> %s, press Ctrl+C to stop\n", portA, portB)
+			continue
+		case event.Shutdown:
+			fmt.Fprintln(os.Stderr, "--- agent is shutting down ---")
+			continue
+		}
+
+		if event.Lost > 0 {
+			fmt.Fprintf(os.Stderr, "--- proxy output fell behind, %d chunks not shown ---\n", event.Lost)
+		}
+
+		line := pipeline.Render(display.Line{
+			Port: labels[event.Direction],
+			Time: time.Unix(0, event.Timestamp),
+			Data: event.Data,
+		})
+		switch {
+		case event.FilterError != "":
+			line += fmt.Sprintf("  (dropped: %s)", event.FilterError)
+		case event.Forwarded != nil:
+			line += "  => " + display.Pipeline{data}.Render(display.Line{Data: event.Forwarded})
+		}
+		fmt.Println(line)
+
+		if event.Direction == pb.ProxyDirection_PROXY_DIRECTION_A_TO_B {
+			bytesAToB += len(event.Data)
+		} else {
+			bytesBToA += len(event.Data)
+		}
+	}
+}
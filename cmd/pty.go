@@ -0,0 +1,155 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/pty"
+	"github.com/Shoaibashk/BaudLink/pkg/client"
+)
+
+// ptyCmd represents the pty command
+var ptyCmd = &cobra.Command{
+	Use:   "pty <port>",
+	Short: "Expose a remote port as a local terminal",
+	Long: `Open a port through the agent and bridge it to a local terminal, so
+programs on this machine can use the remote device without modification.
+
+On Linux and macOS a pseudo-terminal is created (e.g. /dev/pts/3) and --link
+can add a stable symlink to it. On Windows a named pipe is created instead,
+for programs able to open \\.\pipe\ paths.
+
+The port's line settings are chosen with --baud and are not changed by the
+local program, so tools that switch baud rates mid-session need to be told
+to keep the initial rate.
+
+Example:
+  baudlink pty /dev/ttyUSB0 --agent pi.local:50051 --baud 115200
+  baudlink pty /dev/ttyUSB0 --agent pi.local:50051 --link /tmp/ttyREMOTE
+  minicom -D /tmp/ttyREMOTE`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runPty,
+}
+
+func init() {
+	rootCmd.AddCommand(ptyCmd)
+
+	ptyCmd.Flags().Uint32("baud", 0, "baud rate (default: agent default or matching profile)")
+	ptyCmd.Flags().String("link", "", "create a symlink to the terminal at this path (Linux/macOS)")
+}
+
+func runPty(cmd *cobra.Command, args []string) error {
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	baud, _ := cmd.Flags().GetUint32("baud")
+	link, _ := cmd.Flags().GetString("link")
+
+	c, err := dialClient(cmd, "baudlink-pty")
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := []client.PortOption{client.WithExclusive()}
+	if baud > 0 {
+		opts = append(opts, client.WithBaudRate(baud))
+	}
+
+	port, err := c.Open(ctx, portName, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to open port: %w", err)
+	}
+	defer port.Close()
+
+	term, err := pty.Open(ptyPipeName(portName))
+	if err != nil {
+		return err
+	}
+	defer term.Close()
+
+	path := term.Name()
+	if link != "" {
+		if err := replaceSymlink(term.Name(), link); err != nil {
+			return fmt.Errorf("failed to create link: %w", err)
+		}
+		defer os.Remove(link)
+		path = link
+	}
+
+	fmt.Fprintf(os.Stderr, "%s is available at %s, press Ctrl+C to stop\n", portName, path)
+
+	errc := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(port, term)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(term, port)
+		errc <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errc:
+		if err == nil {
+			return fmt.Errorf("port closed by the agent")
+		}
+		return fmt.Errorf("bridge stopped: %w", err)
+	}
+}
+
+// ptyPipeName derives a named pipe name from a port name
+func ptyPipeName(portName string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, portName)
+	return "baudlink-" + strings.Trim(name, "-")
+}
+
+// replaceSymlink creates a symlink at link pointing to target, replacing an
+// existing symlink but never another kind of file
+func replaceSymlink(target, link string) error {
+	if info, err := os.Lstat(link); err == nil {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return fmt.Errorf("%s exists and is not a symlink", link)
+		}
+		if err := os.Remove(link); err != nil {
+			return err
+		}
+	}
+	return os.Symlink(target, link)
+}
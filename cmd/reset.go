@@ -0,0 +1,79 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// resetCmd represents the reset command
+var resetCmd = &cobra.Command{
+	Use:   "reset <port>",
+	Short: "Reset the USB adapter behind a port",
+	Long: `Reset the USB serial adapter behind a port on a running agent, as if it
+had been unplugged and plugged back in, to recover a wedged adapter.
+
+Linux issues a USB port reset; Windows restarts the device's driver.
+Sessions on the port lose the device until it has enumerated again.
+Requires an admin token when the agent has authentication enabled.
+
+Example:
+  baudlink reset /dev/ttyUSB0 --reason "adapter stopped responding"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runReset,
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+
+	resetCmd.Flags().String("reason", "", "reason recorded in the agent's audit log")
+}
+
+func runReset(cmd *cobra.Command, args []string) error {
+	reason, _ := cmd.Flags().GetString("reason")
+
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := client.ResetDevice(context.Background(), &pb.ResetDeviceRequest{
+		PortName: portName,
+		Reason:   reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset device: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to reset device: %s", resp.Message)
+	}
+
+	fmt.Printf("Reset the USB device behind %s\n", portName)
+	return nil
+}
@@ -27,6 +27,10 @@ var (
 	version = "dev"
 	commit  = "none"
 	date    = "unknown"
+
+	// updatePublicKey is the base64 ed25519 key release checksums are
+	// signed with
+	updatePublicKey = ""
 )
 
 // rootCmd represents the base command when called without any subcommands
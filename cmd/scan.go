@@ -17,10 +17,15 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
 	"github.com/Shoaibashk/BaudLink/config"
 	"github.com/Shoaibashk/BaudLink/internal/serial"
 )
@@ -32,11 +37,13 @@ var scanCmd = &cobra.Command{
 	Long: `Scan and list all available serial ports on this system.
 
 This command discovers serial ports including USB devices, native ports,
-Bluetooth serial ports, and virtual ports.
+Bluetooth serial ports, and virtual ports. With --agent (or BAUDLINK_AGENT)
+the ports of that agent are listed instead.
 
 Example:
   baudlink scan
-  baudlink scan --json`,
+  baudlink scan --json
+  baudlink scan --agent pi4:50051`,
 	RunE: runScan,
 }
 
@@ -51,14 +58,9 @@ func runScan(cmd *cobra.Command, args []string) error {
 	jsonOutput, _ := cmd.Flags().GetBool("json")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
-	scanner, err := serial.NewScanner(nil, nil)
+	ports, err := scanPorts(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to create scanner: %w", err)
-	}
-
-	ports, err := scanner.Scan()
-	if err != nil {
-		return fmt.Errorf("failed to scan ports: %w", err)
+		return err
 	}
 
 	if jsonOutput {
@@ -83,11 +85,83 @@ func runScan(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// scanPorts lists the ports of the selected agent, or of this system when
+// no agent is selected
+func scanPorts(cmd *cobra.Command) ([]serial.PortInfo, error) {
+	if _, ok := remoteAgent(cmd); !ok {
+		scanner, err := serial.NewScanner(nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scanner: %w", err)
+		}
+		ports, err := scanner.Scan()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ports: %w", err)
+		}
+		return ports, nil
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := client.ListPorts(ctx, &pb.ListPortsRequest{Refresh: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports: %w", err)
+	}
+
+	ports := make([]serial.PortInfo, 0, len(resp.Ports))
+	for _, p := range resp.Ports {
+		ports = append(ports, portInfoFromProto(p))
+	}
+	return ports, nil
+}
+
+// portInfoFromProto converts a port reported by an agent for printing
+func portInfoFromProto(p *pb.PortInfo) serial.PortInfo {
+	info := serial.PortInfo{
+		Name:         p.Name,
+		Description:  p.Description,
+		HardwareID:   p.HardwareId,
+		Manufacturer: p.Manufacturer,
+		Product:      p.Product,
+		SerialNumber: p.SerialNumber,
+		IsOpen:       p.IsOpen,
+		LockedBy:     p.LockedBy,
+		Driver:       p.Driver,
+		BusPath:      p.BusPath,
+		USBInterface: p.UsbInterface,
+		DevicePath:   p.DevicePath,
+		FriendlyName: p.FriendlyName,
+		Properties:   p.Properties,
+		Claimants:    p.Claimants,
+	}
+	switch p.PortType {
+	case pb.PortType_PORT_TYPE_USB:
+		info.PortType = serial.PortTypeUSB
+	case pb.PortType_PORT_TYPE_NATIVE:
+		info.PortType = serial.PortTypeNative
+	case pb.PortType_PORT_TYPE_BLUETOOTH:
+		info.PortType = serial.PortTypeBluetooth
+	case pb.PortType_PORT_TYPE_VIRTUAL:
+		info.PortType = serial.PortTypeVirtual
+	case pb.PortType_PORT_TYPE_NETWORK:
+		info.PortType = serial.PortTypeNetwork
+	}
+	return info
+}
+
 func printPortSimple(port serial.PortInfo) {
 	status := ""
 	if port.IsOpen {
 		status = " [OPEN]"
 	}
+	if len(port.Claimants) > 0 {
+		status += " [CLAIMED by " + strings.Join(port.Claimants, ", ") + "]"
+	}
 	fmt.Printf("  %s - %s%s\n", port.Name, port.Description, status)
 }
 
@@ -110,6 +184,35 @@ func printPortVerbose(port serial.PortInfo) {
 	if port.VID != "" && port.PID != "" {
 		fmt.Printf("    VID/PID:      %s:%s\n", port.VID, port.PID)
 	}
+	if port.FriendlyName != "" {
+		fmt.Printf("    Name:         %s\n", port.FriendlyName)
+	}
+	if port.Driver != "" {
+		fmt.Printf("    Driver:       %s\n", port.Driver)
+	}
+	if port.BusPath != "" {
+		fmt.Printf("    Bus Path:     %s\n", port.BusPath)
+	}
+	if port.USBInterface != "" {
+		fmt.Printf("    Interface:    %s\n", port.USBInterface)
+	}
+	if port.DevicePath != "" {
+		fmt.Printf("    Device:       %s\n", port.DevicePath)
+	}
+	if len(port.Properties) > 0 {
+		keys := make([]string, 0, len(port.Properties))
+		for k := range port.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Printf("    Properties:\n")
+		for _, k := range keys {
+			fmt.Printf("      %s=%s\n", k, port.Properties[k])
+		}
+	}
+	if len(port.Claimants) > 0 {
+		fmt.Printf("    Claimed by:   %s (see baudlink service protect)\n", strings.Join(port.Claimants, ", "))
+	}
 	if port.IsOpen {
 		fmt.Printf("    Status:       OPEN (locked by %s)\n", port.LockedBy)
 	} else {
@@ -126,8 +229,12 @@ func printPortsJSON(ports []serial.PortInfo) error {
 		if i == len(ports)-1 {
 			comma = ""
 		}
-		fmt.Printf(`  {"name": "%s", "description": "%s", "type": "%s", "hardware_id": "%s", "vid": "%s", "pid": "%s", "is_open": %t}%s`+"\n",
-			port.Name, port.Description, port.PortType.String(), port.HardwareID, port.VID, port.PID, port.IsOpen, comma)
+		claimants := make([]string, len(port.Claimants))
+		for i, c := range port.Claimants {
+			claimants[i] = `"` + c + `"`
+		}
+		fmt.Printf(`  {"name": "%s", "description": "%s", "type": "%s", "hardware_id": "%s", "vid": "%s", "pid": "%s", "is_open": %t, "claimants": [%s]}%s`+"\n",
+			port.Name, port.Description, port.PortType.String(), port.HardwareID, port.VID, port.PID, port.IsOpen, strings.Join(claimants, ", "), comma)
 	}
 	fmt.Println("]")
 	return nil
@@ -140,9 +247,10 @@ var configCmd = &cobra.Command{
 	Long: `Manage the BaudLink agent configuration.
 
 Subcommands:
-  init    - Create a default configuration file
-  show    - Display current configuration
-  path    - Show the default configuration file path`,
+  init     - Create a default configuration file
+  show     - Display current configuration
+  path     - Show the default configuration file path
+  validate - Check a configuration file for problems`,
 }
 
 var configInitCmd = &cobra.Command{
@@ -167,7 +275,13 @@ var configInitCmd = &cobra.Command{
 var configShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Display current configuration",
+	Long: `Display the configuration loaded from a file, or with --agent (or
+BAUDLINK_AGENT) the settings a running agent reports.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, ok := remoteAgent(cmd); ok {
+			return showAgentConfig(cmd)
+		}
+
 		path, _ := cmd.Flags().GetString("config")
 		if path == "" {
 			path = config.DefaultConfigPath()
@@ -192,6 +306,7 @@ var configShowCmd = &cobra.Command{
 		fmt.Printf("  Data Bits:        %d\n", cfg.Serial.Defaults.DataBits)
 		fmt.Printf("  Stop Bits:        %d\n", cfg.Serial.Defaults.StopBits)
 		fmt.Printf("  Scan Interval:    %ds\n", cfg.Serial.ScanInterval)
+		fmt.Printf("  Hot-plug:         %v\n", cfg.Serial.Hotplug)
 		fmt.Println()
 		fmt.Printf("Logging:\n")
 		fmt.Printf("  Level:  %s\n", cfg.Logging.Level)
@@ -201,6 +316,46 @@ var configShowCmd = &cobra.Command{
 	},
 }
 
+// showAgentConfig prints the settings reported by the selected agent
+func showAgentConfig(cmd *cobra.Command) error {
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	info, err := client.GetAgentInfo(ctx, &pb.GetAgentInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get agent info: %w", err)
+	}
+
+	address, _ := remoteAgent(cmd)
+	fmt.Printf("Configuration of agent: %s\n\n", address)
+	fmt.Printf("Agent:\n")
+	fmt.Printf("  Version:          %s (%s/%s)\n", info.Version, info.Os, info.Arch)
+	fmt.Printf("  Uptime:           %s\n", time.Duration(info.UptimeSeconds)*time.Second)
+	fmt.Println()
+	fmt.Printf("Server:\n")
+	fmt.Printf("  gRPC Address:     %s\n", info.GetConfig().GetGrpcAddress())
+	fmt.Printf("  Max Connections:  %d\n", info.GetConfig().GetMaxConnections())
+	fmt.Printf("  TLS:              %v\n", info.GetConfig().GetTlsEnabled())
+	fmt.Println()
+	fmt.Printf("Limits:\n")
+	fmt.Printf("  Max Write:        %d bytes\n", info.GetLimits().GetMaxWriteBytes())
+	fmt.Printf("  Max Chunk:        %d bytes\n", info.GetLimits().GetMaxChunkSize())
+	fmt.Println()
+	fmt.Printf("Features:\n")
+	for _, c := range info.Capabilities {
+		if c.Category == "feature" {
+			fmt.Printf("  %-19s %v\n", c.Name+":", c.Enabled)
+		}
+	}
+
+	return nil
+}
+
 var configPathCmd = &cobra.Command{
 	Use:   "path",
 	Short: "Show the default configuration file path",
@@ -209,12 +364,72 @@ var configPathCmd = &cobra.Command{
 	},
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check a configuration file for problems",
+	Long: `Check a configuration file for problems before starting the agent.
+
+Besides the checks performed at startup, validate reports unknown keys,
+listeners that collide on the same port, missing or mismatched TLS
+certificate and key files, exclude_patterns that are not valid regular
+expressions, and profiles that can never apply. Each problem is printed
+with its line number. The default configuration path is used when no file
+is given.
+
+Example:
+  baudlink config validate
+  baudlink config validate ./agent.yaml --strict`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runConfigValidate,
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	path := config.DefaultConfigPath()
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	findings, err := config.Lint(path)
+	if err != nil {
+		return err
+	}
+
+	errs, warnings := 0, 0
+	for _, f := range findings {
+		if f.Line > 0 {
+			fmt.Printf("%s:%d: %s\n", path, f.Line, f)
+		} else {
+			fmt.Printf("%s: %s\n", path, f)
+		}
+		if f.Severity == config.SeverityError {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+
+	if errs > 0 || (strict && warnings > 0) {
+		return fmt.Errorf("%s: %d error(s), %d warning(s)", path, errs, warnings)
+	}
+	if warnings > 0 {
+		fmt.Printf("%s: valid with %d warning(s)\n", path, warnings)
+		return nil
+	}
+	fmt.Printf("%s: valid\n", path)
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configInitCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configValidateCmd)
 
-	configInitCmd.Flags().StringP("output", "o", "", "output path for config file")
+	configInitCmd.Flags().StringP("output", "o", "", "output path for config file (.yaml, .json, or .toml)")
 	configShowCmd.Flags().StringP("config", "c", "", "config file path")
+	configValidateCmd.Flags().Bool("strict", false, "treat warnings as errors")
 }
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+	"github.com/Shoaibashk/BaudLink/internal/script"
+)
+
+// scriptCmd represents the script command
+var scriptCmd = &cobra.Command{
+	Use:   "script",
+	Short: "Run expect-style scripts on the agent",
+	Long: `Run send/expect scripts server-side against a serial port.
+
+Scripts are YAML files with a list of steps. Each step either sends data,
+waits for a regular expression to match the received data, or sleeps:
+
+  name: modem-init
+  timeout: 5s
+  steps:
+    - send: "AT\r"
+    - expect: "OK"
+    - send: "ATDT5551234\r"
+    - expect: "CONNECT \\d+"
+      timeout: 30s
+    - sleep: 1s`,
+}
+
+// scriptRunCmd represents the script run command
+var scriptRunCmd = &cobra.Command{
+	Use:   "run <file>",
+	Short: "Run a script against a port",
+	Long: `Run a script against a port and print the transcript.
+
+If no session is given, the port is opened for the duration of the script
+and closed afterwards.
+
+Example:
+  baudlink script run modem.yaml --port /dev/ttyUSB0
+  baudlink script run modem.yaml --port COM3 --baud 115200
+  baudlink script run modem.yaml --port COM3 --session <id> --agent 10.0.0.5:50051`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScript,
+}
+
+func init() {
+	rootCmd.AddCommand(scriptCmd)
+	scriptCmd.AddCommand(scriptRunCmd)
+
+	scriptRunCmd.Flags().StringP("port", "p", "", "serial port to run the script on (required)")
+	scriptRunCmd.Flags().String("session", "", "existing session ID (default: open the port)")
+	scriptRunCmd.Flags().Uint32("baud", 0, "baud rate when opening the port (default: agent default)")
+	scriptRunCmd.MarkFlagRequired("port")
+	scriptRunCmd.RegisterFlagCompletionFunc("port", completePorts)
+}
+
+func runScript(cmd *cobra.Command, args []string) error {
+	portName, _ := cmd.Flags().GetString("port")
+	sessionID, _ := cmd.Flags().GetString("session")
+	baud, _ := cmd.Flags().GetUint32("baud")
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read script: %w", err)
+	}
+
+	// Validate locally so syntax errors are reported before touching the port
+	if _, err := script.Parse(data); err != nil {
+		return err
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	if sessionID == "" {
+		req := &pb.OpenPortRequest{PortName: portName, ClientId: "baudlink-script"}
+		if baud > 0 {
+			req.Config = &pb.PortConfig{
+				BaudRate:      baud,
+				DataBits:      pb.DataBits_DATA_BITS_8,
+				StopBits:      pb.StopBits_STOP_BITS_1,
+				Parity:        pb.Parity_PARITY_NONE,
+				ReadTimeoutMs: 1000,
+			}
+		}
+
+		resp, err := client.OpenPort(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to open port: %w", err)
+		}
+		if !resp.Success {
+			return fmt.Errorf("failed to open port: %s", resp.Message)
+		}
+		sessionID = resp.SessionId
+
+		defer client.ClosePort(ctx, &pb.ClosePortRequest{PortName: portName, SessionId: sessionID})
+	}
+
+	stream, err := client.RunScript(ctx, &pb.RunScriptRequest{
+		PortName:  portName,
+		SessionId: sessionID,
+		Script:    data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run script: %w", err)
+	}
+
+	var failed error
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("script stream failed: %w", err)
+		}
+
+		printScriptEvent(event)
+
+		if event.Type == pb.ScriptEventType_SCRIPT_EVENT_TYPE_FAILED {
+			failed = fmt.Errorf("script failed at step %d: %s", event.Step, event.Message)
+		}
+	}
+
+	return failed
+}
+
+func printScriptEvent(event *pb.ScriptEvent) {
+	switch event.Type {
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_SENT:
+		fmt.Printf("[%d] >> %q\n", event.Step, event.Data)
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_RECEIVED:
+		fmt.Printf("[%d] << %q\n", event.Step, event.Data)
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_MATCHED:
+		fmt.Printf("[%d] matched %q\n", event.Step, event.Data)
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_SLEPT:
+		fmt.Printf("[%d] slept %s\n", event.Step, event.Message)
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_FAILED:
+		fmt.Printf("[%d] FAILED: %s\n", event.Step, event.Message)
+	case pb.ScriptEventType_SCRIPT_EVENT_TYPE_COMPLETED:
+		fmt.Printf("Script completed: %s\n", event.Message)
+	}
+}
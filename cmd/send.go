@@ -0,0 +1,130 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/pkg/client"
+)
+
+// sendCmd represents the send command
+var sendCmd = &cobra.Command{
+	Use:   "send [port] <data>",
+	Short: "Write data to a port",
+	Long: `Open a port through the agent, write data to it, and optionally print
+what the device answers within --wait.
+
+Example:
+  baudlink send /dev/ttyUSB0 AT --eol cr --wait 1s
+  baudlink send COM3 "01 03 00 00 00 01 84 0a" --hex --wait 500ms
+  baudlink send /dev/ttyUSB0 "*RST" --agent pi4:50051 --eol lf`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runSend,
+}
+
+func init() {
+	rootCmd.AddCommand(sendCmd)
+
+	sendCmd.Flags().Uint32("baud", 0, "baud rate (default: agent default or matching profile)")
+	sendCmd.Flags().Bool("hex", false, "data is hex bytes, optionally separated by spaces")
+	sendCmd.Flags().String("eol", "none", "line ending appended to the data: crlf, cr, lf, or none")
+	sendCmd.Flags().Duration("wait", 0, "print data received for this long after writing")
+}
+
+func runSend(cmd *cobra.Command, args []string) error {
+	baud, _ := cmd.Flags().GetUint32("baud")
+	hexData, _ := cmd.Flags().GetBool("hex")
+	eolName, _ := cmd.Flags().GetString("eol")
+	wait, _ := cmd.Flags().GetDuration("wait")
+
+	eol, ok := eolSequences[eolName]
+	if !ok {
+		return fmt.Errorf("invalid --eol %q: use crlf, cr, lf, or none", eolName)
+	}
+
+	// With one argument it is the data and the port is picked
+	portName, err := portArg(cmd, args[:len(args)-1], 0)
+	if err != nil {
+		return err
+	}
+	text := args[len(args)-1]
+
+	data := []byte(text)
+	if hexData {
+		decoded, err := hex.DecodeString(strings.ReplaceAll(text, " ", ""))
+		if err != nil {
+			return fmt.Errorf("invalid hex data: %w", err)
+		}
+		data = decoded
+	}
+	data = append(data, eol...)
+
+	c, err := dialClient(cmd, "baudlink-send")
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	var opts []client.PortOption
+	if baud > 0 {
+		opts = append(opts, client.WithBaudRate(baud))
+	}
+
+	port, err := c.Open(context.Background(), portName, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to open port: %w", err)
+	}
+	defer port.Close()
+
+	if _, err := port.Write(data); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	var out io.Writer = os.Stdout
+	if hexData {
+		dumper := hex.Dumper(os.Stdout)
+		defer dumper.Close()
+		out = dumper
+	}
+
+	port.SetReadDeadline(time.Now().Add(wait))
+	buf := make([]byte, 4096)
+	for {
+		n, err := port.Read(buf)
+		out.Write(buf[:n])
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read: %w", err)
+		}
+	}
+}
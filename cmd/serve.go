@@ -19,22 +19,50 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/Shoaibashk/BaudLink/api"
-	pb "github.com/Shoaibashk/BaudLink/api/proto"
 	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+	"github.com/Shoaibashk/BaudLink/internal/certs"
+	"github.com/Shoaibashk/BaudLink/internal/crash"
+	"github.com/Shoaibashk/BaudLink/internal/datalog"
+	"github.com/Shoaibashk/BaudLink/internal/deviceplugin"
+	"github.com/Shoaibashk/BaudLink/internal/gpsd"
+	"github.com/Shoaibashk/BaudLink/internal/history"
+	"github.com/Shoaibashk/BaudLink/internal/jobs"
+	"github.com/Shoaibashk/BaudLink/internal/logging"
+	"github.com/Shoaibashk/BaudLink/internal/mdns"
+	"github.com/Shoaibashk/BaudLink/internal/metrics"
+	"github.com/Shoaibashk/BaudLink/internal/ratelimit"
+	"github.com/Shoaibashk/BaudLink/internal/rules"
 	"github.com/Shoaibashk/BaudLink/internal/serial"
+	"github.com/Shoaibashk/BaudLink/internal/telemetry"
+	"github.com/Shoaibashk/BaudLink/pkg/client"
 )
 
 var (
@@ -52,10 +80,18 @@ The agent exposes a gRPC API for managing serial ports, allowing
 remote clients to discover, open, configure, read from, and write to
 serial ports on this machine.
 
+In container mode, chosen with --container or detected from the container
+runtime, logs are written to stdout as JSON and never to a file, new ports
+are found by watching /dev, and a second SIGTERM ends the shutdown drain at
+once. Set ` + config.ContainerEnv + `=0 to turn detection off, or 1 to also
+use container defaults for settings the config file leaves out.
+
 Example:
   baudlink serve
   baudlink serve --config /etc/baudlink/agent.yaml
-  baudlink serve --address 0.0.0.0:50051`,
+  baudlink serve --address 0.0.0.0:50051
+  baudlink serve --socket /run/baudlink/baudlink.sock
+  baudlink serve --container`,
 	RunE: runServe,
 }
 
@@ -64,7 +100,9 @@ func init() {
 
 	serveCmd.Flags().StringVarP(&configFile, "config", "c", "", "config file path")
 	serveCmd.Flags().String("address", "", "gRPC server address (overrides config)")
+	serveCmd.Flags().String("socket", "", "unix socket path to also serve on (overrides config)")
 	serveCmd.Flags().Bool("debug", false, "enable debug logging")
+	serveCmd.Flags().Bool("container", false, "run in container mode (default: detected)")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
@@ -83,16 +121,54 @@ func runServe(cmd *cobra.Command, args []string) error {
 	if addr, _ := cmd.Flags().GetString("address"); addr != "" {
 		cfg.Server.GRPCAddress = addr
 	}
+	if socket, _ := cmd.Flags().GetString("socket"); socket != "" {
+		cfg.Server.UnixSocket = socket
+	}
 	if debug, _ := cmd.Flags().GetBool("debug"); debug {
 		cfg.Logging.Level = "debug"
 	}
 
+	container, _ := cmd.Flags().GetBool("container")
+	container = container || config.InContainer()
+
 	// Setup logging
-	setupLogging(cfg)
+	setupLogging(cfg, container)
 
 	log.Printf("Starting BaudLink agent v%s", version)
 	log.Printf("gRPC address: %s", cfg.Server.GRPCAddress)
 	log.Printf("TLS enabled: %v", cfg.TLS.Enabled)
+	if container {
+		log.Println("Container mode: logging to stdout, watching /dev for ports")
+	}
+
+	if cfg.Logging.CrashDumps.Enabled {
+		crash.Configure(crash.Settings{Directory: cfg.Logging.CrashDumps.Directory, Version: version})
+		log.Printf("Crash dumps: %s", cfg.Logging.CrashDumps.Directory)
+	}
+
+	// Export traces before anything is instrumented, so the first spans are
+	// not lost to the no-op provider
+	if cfg.Telemetry.Enabled {
+		shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Options{
+			Endpoint:       cfg.Telemetry.Endpoint,
+			Insecure:       cfg.Telemetry.Insecure,
+			Headers:        cfg.Telemetry.Headers,
+			ServiceName:    cfg.Telemetry.ServiceName,
+			ServiceVersion: version,
+			SampleRatio:    cfg.Telemetry.SampleRatio,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to setup telemetry: %w", err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				log.Printf("Failed to flush traces: %v", err)
+			}
+		}()
+		log.Printf("Exporting traces to %s", cfg.Telemetry.Endpoint)
+	}
 
 	// Create serial manager
 	serialConfig := serial.PortConfig{
@@ -105,12 +181,67 @@ func runServe(cmd *cobra.Command, args []string) error {
 		WriteTimeoutMs: cfg.Serial.Defaults.WriteTimeoutMs,
 	}
 	manager := serial.NewManager(cfg.Serial.AllowSharedAccess, serialConfig)
+	manager.SetWriteQueueDepth(cfg.Serial.WriteQueueDepth)
+	manager.SetIdleTimeout(time.Duration(cfg.Serial.IdleTimeout) * time.Minute)
+	manager.SetPortRateLimit(ratelimit.Limit{
+		RequestsPerSecond: cfg.RateLimits.Port.RequestsPerSecond,
+		BytesPerSecond:    cfg.RateLimits.Port.BytesPerSecond,
+	})
+	manager.SetSharedWriteQuota(ratelimit.Limit{
+		RequestsPerSecond: cfg.RateLimits.SharedWriter.RequestsPerSecond,
+		BytesPerSecond:    cfg.RateLimits.SharedWriter.BytesPerSecond,
+	})
+	manager.SetOpenRetry(serial.RetryPolicy{
+		Attempts: cfg.Serial.OpenRetry.Attempts,
+		Delay:    time.Duration(cfg.Serial.OpenRetry.DelayMs) * time.Millisecond,
+		MaxDelay: time.Duration(cfg.Serial.OpenRetry.MaxDelayMs) * time.Millisecond,
+		Jitter:   cfg.Serial.OpenRetry.Jitter,
+	})
+	manager.SetTapSettings(serial.TapSettings{
+		Enabled:     cfg.Taps.Enabled,
+		Directory:   cfg.Taps.Directory,
+		MaxFileSize: int64(cfg.Taps.MaxFileSize) * 1024 * 1024,
+		MaxBackups:  cfg.Taps.MaxBackups,
+		AllowTCP:    cfg.Taps.AllowTCP,
+	})
+	manager.SetPassthroughSettings(serial.PassthroughSettings{
+		Enabled:  cfg.Passthrough.Enabled,
+		AllowTCP: cfg.Passthrough.AllowTCP,
+	})
+	manager.SetSystemLockSettings(serial.SystemLockSettings{
+		Enabled: cfg.Serial.SystemLock.Enabled,
+		LockDir: cfg.Serial.SystemLock.LockDir,
+	})
+
+	// Record sessions from the first one opened. The store closes after
+	// the manager's sessions so their final counters are kept.
+	var historyStore *history.Store
+	if cfg.History.Enabled {
+		historyStore, err = history.Open(cfg.History.File, time.Duration(cfg.History.RetentionDays)*24*time.Hour)
+		if err != nil {
+			return fmt.Errorf("failed to open history database: %w", err)
+		}
+		defer historyStore.Close()
+		manager.SetSessionObserver(historyStore)
+		log.Printf("History database: %s", cfg.History.File)
+	}
 
 	// Create scanner
 	scanner, err := serial.NewScanner(cfg.Serial.ExcludePatterns, manager)
 	if err != nil {
 		return fmt.Errorf("failed to create scanner: %w", err)
 	}
+	scanner.SetAliases(buildAliases(cfg))
+	include, err := buildPortFilters(cfg.Serial.Include)
+	if err != nil {
+		return fmt.Errorf("invalid include rule: %w", err)
+	}
+	exclude, err := buildPortFilters(cfg.Serial.Exclude)
+	if err != nil {
+		return fmt.Errorf("invalid exclude rule: %w", err)
+	}
+	scanner.SetFilters(include, exclude)
+	scanner.SetNetworkPorts(buildNetworkPorts(cfg))
 
 	// Do initial port scan
 	ports, err := scanner.Scan()
@@ -123,55 +254,313 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Start port watching
-	if cfg.Serial.ScanInterval > 0 {
-		stopWatch := scanner.WatchPorts(cfg.Serial.ScanInterval, func(ports []serial.PortInfo) {
+	portAlias := func(portName string) string {
+		if port, err := scanner.GetPort(portName); err == nil {
+			return port.Alias
+		}
+		return ""
+	}
+
+	// Start the rules engine before managed ports open so no data is missed
+	var engine *rules.Engine
+	if len(cfg.Rules) > 0 {
+		engine, err = rules.New(manager, rules.Options{
+			Rules: buildRules(cfg),
+			MQTT: rules.MQTTOptions{
+				Broker:   cfg.MQTT.Broker,
+				ClientID: cfg.MQTT.ClientID,
+				Username: cfg.MQTT.Username,
+				Password: cfg.MQTT.Password,
+			},
+			Alias: portAlias,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid rule: %w", err)
+		}
+		engine.Start()
+		log.Printf("Rules engine started (%d rules)", len(cfg.Rules))
+	}
+
+	// Start the data logger, also before managed ports open
+	var dataLogger *datalog.Logger
+	if len(cfg.DataLog.Ports) > 0 {
+		dataLogger, err = datalog.New(manager, buildDataLog(cfg, portAlias))
+		if err != nil {
+			return fmt.Errorf("invalid datalog: %w", err)
+		}
+		dataLogger.Start()
+		log.Printf("Data logger started (%d ports)", len(cfg.DataLog.Ports))
+	}
+
+	// Serve GPS receivers to gpsd clients, also before managed ports open
+	var gpsServer *gpsd.Server
+	if cfg.GPSD.Enabled {
+		gpsServer, err = gpsd.New(manager, gpsd.Options{
+			Address: cfg.GPSD.Address,
+			Ports:   cfg.GPSD.Ports,
+			Version: version,
+			Alias:   portAlias,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid gpsd: %w", err)
+		}
+		if err := gpsServer.Start(); err != nil {
+			return fmt.Errorf("failed to start gpsd listener: %w", err)
+		}
+		log.Printf("gpsd listener on %s (%d ports)", gpsServer.Addr(), len(cfg.GPSD.Ports))
+	}
+
+	// Open managed ports and keep them open
+	var supervisor *serial.Supervisor
+	if len(cfg.Serial.ManagedPorts) > 0 {
+		supervisor = serial.NewSupervisor(manager, scanner, buildManagedPorts(cfg), time.Duration(cfg.Serial.ScanInterval)*time.Second)
+		supervisor.Start()
+	}
+
+	// Schedule configured jobs; clients may add more through CreateJob
+	scheduler := jobs.NewScheduler(manager, scanner.Resolve)
+	for _, job := range buildJobs(cfg) {
+		if _, err := scheduler.Add(job); err != nil {
+			scheduler.Stop()
+			return fmt.Errorf("invalid job: %w", err)
+		}
+	}
+	if len(cfg.Jobs) > 0 {
+		log.Printf("Scheduled %d jobs", len(cfg.Jobs))
+	}
+
+	// Start port watching. Kernel device events rarely reach containers, but
+	// the host's /dev mounted into a privileged one shows devices coming
+	// and going.
+	if container {
+		scanner.SetDeviceDir("/dev")
+	}
+	if cfg.Serial.ScanInterval > 0 || cfg.Serial.Hotplug {
+		stopWatch := scanner.WatchPorts(cfg.Serial.ScanInterval, cfg.Serial.Hotplug, func(ports []serial.PortInfo) {
 			log.Printf("Port change detected, %d ports available", len(ports))
 		})
 		defer close(stopWatch)
 	}
 
-	// Create gRPC server options
-	var opts []grpc.ServerOption
+	// Advertise the node's ports to the kubelet so pods can request them
+	if dp := cfg.Kubernetes.DevicePlugin; dp.Enabled {
+		plugin, err := deviceplugin.New(scanner, deviceplugin.Options{
+			ResourceName: dp.ResourceName,
+			SocketDir:    dp.SocketDir,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid kubernetes device plugin: %w", err)
+		}
+		if err := plugin.Start(); err != nil {
+			return fmt.Errorf("failed to start kubernetes device plugin: %w", err)
+		}
+		defer plugin.Close()
+		log.Printf("Kubernetes device plugin advertising %s", plugin.ResourceName())
+	}
+
+	// Create gRPC server options. Clients may ping idle connections to
+	// detect dead links, so allow pings more often than the gRPC default.
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	// Trace every RPC, continuing traces propagated by the client
+	if cfg.Telemetry.Enabled {
+		opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
 
-	// Setup TLS if enabled
+	// Setup TLS if enabled. Certificates are renewed and reloaded in the
+	// background until the server stops.
+	tlsCtx, stopTLS := context.WithCancel(context.Background())
+	defer stopTLS()
+	var tlsConfig *tls.Config
 	if cfg.TLS.Enabled {
-		creds, err := loadTLSCredentials(cfg)
+		tlsConfig, err = loadTLSConfig(tlsCtx, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to load TLS credentials: %w", err)
 		}
-		opts = append(opts, grpc.Creds(creds))
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 		log.Println("TLS enabled")
 	}
 
+	// Open the audit log first so denied requests can be recorded
+	var auditLog *audit.Logger
+	if cfg.Audit.Enabled {
+		auditLog, err = audit.Open(cfg.Audit.File, int64(cfg.Audit.MaxFileSize)*1024*1024, cfg.Audit.MaxBackups)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer auditLog.Close()
+		if historyStore != nil {
+			auditLog.SetStore(historyStore)
+		}
+		log.Printf("Audit log: %s", cfg.Audit.File)
+	}
+
+	// Recover panics in handlers outermost, so a bug in any interceptor or
+	// handler fails only its request
+	recovery := api.NewRecoveryInterceptor()
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(recovery.Unary()),
+		grpc.ChainStreamInterceptor(recovery.Stream()),
+	)
+
+	// Report the API version of each call and warn about deprecated usage
+	versions := api.NewVersionInterceptor()
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(versions.Unary()),
+		grpc.ChainStreamInterceptor(versions.Stream()),
+	)
+
+	// Reject malformed requests before anything else looks at them
+	validation := api.NewValidationInterceptor(cfg.Server.MaxWriteBytes, cfg.Server.MaxChunkSize)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(validation.Unary()),
+		grpc.ChainStreamInterceptor(validation.Stream()),
+	)
+
+	// Resolve port aliases before any other request processing
+	aliases := api.NewAliasInterceptor(scanner)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(aliases.Unary()),
+		grpc.ChainStreamInterceptor(aliases.Stream()),
+	)
+
+	// Setup token authentication if enabled
+	var authn *auth.Authenticator
+	if cfg.Auth.Enabled {
+		authn, err = newAuthenticator(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to setup authentication: %w", err)
+		}
+		interceptor := api.NewAuthInterceptor(authn, auditLog, manager)
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(interceptor.Unary()),
+			grpc.ChainStreamInterceptor(interceptor.Stream()),
+		)
+		log.Printf("Token authentication enabled (%d tokens)", len(cfg.Auth.Tokens))
+	}
+
+	// Count connections against max_connections, attributing requests to
+	// tokens once they are authenticated
+	connections := api.NewConnectionTracker(cfg.Server.MaxConnections)
+	opts = append(opts,
+		grpc.StatsHandler(connections),
+		grpc.ChainUnaryInterceptor(connections.Unary()),
+		grpc.ChainStreamInterceptor(connections.Stream()),
+	)
+
+	// Apply client rate limits after authentication so tokens can be told apart
+	clientLimiter := ratelimit.NewLimiter(ratelimit.Limit{
+		RequestsPerSecond: cfg.RateLimits.Client.RequestsPerSecond,
+		BytesPerSecond:    cfg.RateLimits.Client.BytesPerSecond,
+	})
+	limits := api.NewRateLimitInterceptor(clientLimiter)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(limits.Unary()),
+		grpc.ChainStreamInterceptor(limits.Stream()),
+	)
+
+	// Forward requests for remote agents' ports last, once the caller has
+	// been authorized and rate limited
+	if len(cfg.Federation.Remotes) > 0 {
+		federation, err := api.NewFederationInterceptor(cfg.Federation.Remotes)
+		if err != nil {
+			return fmt.Errorf("failed to setup federation: %w", err)
+		}
+		defer federation.Close()
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(federation.Unary()),
+			grpc.ChainStreamInterceptor(federation.Stream()),
+		)
+		log.Printf("Federation enabled (%d remote agents)", len(cfg.Federation.Remotes))
+	}
+
 	// Create gRPC server
 	grpcServer := grpc.NewServer(opts...)
 
 	// Register services
-	serialServer := api.NewSerialServer(manager, scanner, cfg)
-	pb.RegisterSerialServiceServer(grpcServer, serialServer)
-	
+	serialServer := api.NewSerialServer(manager, scanner, cfg, authn)
+	api.RegisterServices(grpcServer, serialServer)
+	serialServer.SetAuditLog(auditLog)
+	serialServer.SetHistory(historyStore)
+	serialServer.SetScheduler(scheduler)
+	serialServer.SetConnectionTracker(connections)
+
+	// Register the standard gRPC health service
+	healthServer := api.NewHealthServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	// Enable reflection for development/debugging tools like grpcurl
 	reflection.Register(grpcServer)
 
-	// Create listener
-	listener, err := net.Listen("tcp", cfg.Server.GRPCAddress)
-	if err != nil {
-		return fmt.Errorf("failed to listen: %w", err)
+	// Start the file download endpoint
+	if cfg.Files.Enabled {
+		httpServer, err := startFileServer(cfg, authn, tlsConfig)
+		if err != nil {
+			return fmt.Errorf("failed to start file server: %w", err)
+		}
+		defer httpServer.Close()
+	}
+
+	// Start the Prometheus metrics and the /healthz and /readyz endpoints
+	if cfg.Metrics.Enabled {
+		healthz := api.HealthHandler(healthServer, manager, scanner)
+		readyz := api.ReadinessHandler(healthServer, supervisor)
+		metricsServer, err := startMetricsServer(cfg, manager, clientLimiter, healthz, readyz)
+		if err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		defer metricsServer.Close()
+	}
+
+	// Create listeners
+	var listeners []net.Listener
+	if cfg.Server.GRPCAddress != "" {
+		listener, err := net.Listen("tcp", cfg.Server.GRPCAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+		listeners = append(listeners, listener)
+
+		// Advertise the agent on the local network
+		if cfg.Discovery.Enabled {
+			responder, err := advertiseAgent(cfg, listener.Addr().(*net.TCPAddr))
+			if err != nil {
+				log.Printf("Warning: mDNS advertisement disabled: %v", err)
+			} else if responder != nil {
+				defer responder.Close()
+			}
+		}
+	}
+	if cfg.Server.UnixSocket != "" {
+		listener, err := listenUnixSocket(cfg.Server)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", cfg.Server.UnixSocket, err)
+		}
+		listeners = append(listeners, listener)
 	}
 
 	// Handle graceful shutdown
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Start server in goroutine
-	errChan := make(chan error, 1)
-	go func() {
-		log.Printf("gRPC server listening on %s", cfg.Server.GRPCAddress)
-		if err := grpcServer.Serve(listener); err != nil {
-			errChan <- err
-		}
-	}()
+	// Start servers in goroutines
+	errChan := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			log.Printf("gRPC server listening on %s", listener.Addr())
+			if err := grpcServer.Serve(listener); err != nil {
+				errChan <- err
+			}
+		}(listener)
+	}
 
 	// Wait for shutdown signal or error
 	select {
@@ -181,40 +570,541 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("server error: %w", err)
 	}
 
-	// Graceful shutdown
+	// Container runtimes send SIGTERM again, or SIGKILL, when the agent
+	// takes too long to stop; a second signal skips the rest of the drain
+	if container {
+		forceExit(manager)
+	}
+
+	// Graceful shutdown: tell clients first so they can close their
+	// sessions, then stop the server, force-closing streams still open
+	// once the grace period ends
 	log.Println("Shutting down server...")
-	grpcServer.GracefulStop()
+	grace := time.Duration(cfg.Server.ShutdownGrace) * time.Second
+	healthServer.Shutdown()
+	serialServer.NotifyShutdown(grace)
+	stopServer(grpcServer, manager, grace)
+	scheduler.Stop()
+	if supervisor != nil {
+		supervisor.Stop()
+	}
+	if engine != nil {
+		engine.Stop()
+	}
+	if dataLogger != nil {
+		dataLogger.Stop()
+	}
+	if gpsServer != nil {
+		gpsServer.Stop()
+	}
 	manager.CloseAll()
 	log.Println("Server stopped")
 
 	return nil
 }
 
-func loadTLSCredentials(cfg *config.Config) (credentials.TransportCredentials, error) {
-	cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+// forceExit exits as soon as another shutdown signal arrives, closing the
+// ports first so devices are left in a clean state
+func forceExit(manager *serial.Manager) {
+	force := make(chan os.Signal, 1)
+	signal.Notify(force, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-force
+		log.Println("Second shutdown signal received, exiting without draining")
+		manager.CloseAll()
+		os.Exit(1)
+	}()
+}
+
+// shutdownPollInterval is how often the shutdown grace period checks for
+// remaining client sessions
+const shutdownPollInterval = 100 * time.Millisecond
+
+// stopServer waits up to grace for clients to close their sessions, then
+// stops the server gracefully. Streams that outlive the grace period are
+// force-closed.
+func stopServer(grpcServer *grpc.Server, manager *serial.Manager, grace time.Duration) {
+	deadline := time.Now().Add(grace)
+	for time.Now().Before(deadline) && clientSessions(manager) > 0 {
+		time.Sleep(shutdownPollInterval)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Until(deadline)):
+		log.Println("Grace period expired, closing remaining streams")
+		grpcServer.Stop()
+		<-stopped
+	}
+}
+
+// clientSessions returns the number of open sessions owned by clients
+func clientSessions(manager *serial.Manager) int {
+	n := 0
+	for _, session := range manager.Sessions() {
+		if !session.Managed {
+			n++
+		}
+	}
+	return n
+}
+
+// advertiseAgent advertises the agent over mDNS with its version and
+// security requirements. Agents listening on a loopback address are not
+// advertised.
+func advertiseAgent(cfg *config.Config, addr *net.TCPAddr) (*mdns.Responder, error) {
+	if addr.IP.IsLoopback() {
+		return nil, nil
+	}
+
+	name := cfg.Discovery.Name
+	if name == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		name = hostname
+	}
+
+	service := mdns.Service{
+		Instance: name,
+		Type:     client.DiscoveryServiceType,
+		Port:     addr.Port,
+		Text: map[string]string{
+			"version": version,
+			"tls":     strconv.FormatBool(cfg.TLS.Enabled),
+			"auth":    strconv.FormatBool(cfg.Auth.Enabled),
+		},
+	}
+	if !addr.IP.IsUnspecified() {
+		service.Addrs = []net.IP{addr.IP}
+	}
+
+	responder, err := mdns.Advertise(service)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Advertising agent as %q over mDNS", name)
+	return responder, nil
+}
+
+// listenUnixSocket listens on the configured unix socket, restricting who may
+// connect through the socket file's permissions. A socket left behind by an
+// agent that did not shut down cleanly is replaced.
+func listenUnixSocket(server config.ServerConfig) (net.Listener, error) {
+	mode, err := server.SocketMode()
 	if err != nil {
 		return nil, err
 	}
+	path := server.UnixSocket
 
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS12,
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket is in use by another process")
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	if server.UnixSocketGroup != "" {
+		if runtime.GOOS == "windows" {
+			listener.Close()
+			return nil, fmt.Errorf("unix_socket_group is not supported on Windows")
+		}
+		group, err := user.LookupGroup(server.UnixSocketGroup)
+		if err != nil {
+			listener.Close()
+			return nil, err
+		}
+		gid, _ := strconv.Atoi(group.Gid)
+		if err := os.Chown(path, -1, gid); err != nil {
+			listener.Close()
+			return nil, err
+		}
+	}
+
+	return listener, nil
+}
+
+// loadTLSConfig prepares the server certificate and returns a TLS
+// configuration that serves renewed certificates to new connections without
+// a restart
+func loadTLSConfig(ctx context.Context, cfg *config.Config) (*tls.Config, error) {
+	if err := ensureCertificate(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	reloader, err := certs.NewReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TLS.ReloadInterval > 0 {
+		go reloader.Watch(ctx, time.Duration(cfg.TLS.ReloadInterval)*time.Second)
+	}
+	if cfg.TLS.ACME.Enabled {
+		go renewCertificates(ctx, cfg, reloader)
 	}
 
-	return credentials.NewTLS(tlsConfig), nil
+	return &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}, nil
 }
 
-func setupLogging(cfg *config.Config) {
+// buildManagedPorts converts the configured managed ports
+func buildManagedPorts(cfg *config.Config) []serial.ManagedPort {
+	var ports []serial.ManagedPort
+	for _, mp := range cfg.Serial.ManagedPorts {
+		ports = append(ports, serial.ManagedPort{
+			Name:       mp.Port,
+			Config:     api.PortConfigFromSettings(mp.Settings, cfg.Serial.Defaults),
+			BufferSize: mp.BufferSize,
+		})
+	}
+	return ports
+}
+
+// buildRules converts the configured rules into engine rules. The
+// configuration has been validated, so patterns and hex data parse.
+func buildRules(cfg *config.Config) []rules.Rule {
+	var result []rules.Rule
+	for _, r := range cfg.Rules {
+		rule := rules.Rule{
+			Name:     r.Name,
+			Port:     r.Port,
+			Cooldown: time.Duration(r.CooldownMs) * time.Millisecond,
+		}
+		if r.Match != "" {
+			rule.Pattern = regexp.MustCompile(r.Match)
+		} else {
+			rule.Bytes, _ = hex.DecodeString(r.MatchHex)
+		}
+
+		for _, a := range r.Actions {
+			action := rules.Action{
+				Type:    a.Type,
+				Text:    a.Data,
+				Topic:   a.Topic,
+				Retain:  a.Retain,
+				URL:     a.URL,
+				Payload: a.Payload,
+			}
+			if a.DataHex != "" {
+				action.Data, _ = hex.DecodeString(a.DataHex)
+			}
+			rule.Actions = append(rule.Actions, action)
+		}
+		result = append(result, rule)
+	}
+	return result
+}
+
+// buildJobs converts the configured jobs into scheduler jobs. The
+// configuration has been validated, so patterns and hex data parse.
+func buildJobs(cfg *config.Config) []jobs.Job {
+	var result []jobs.Job
+	for _, j := range cfg.Jobs {
+		job := jobs.Job{
+			Name:          j.Name,
+			Port:          j.Port,
+			Schedule:      j.Schedule,
+			Request:       []byte(j.Request),
+			Terminator:    []byte(j.Terminator),
+			ExpectedBytes: j.ExpectedBytes,
+			Timeout:       time.Duration(j.TimeoutMs) * time.Millisecond,
+			FlushInput:    j.FlushInput,
+			Config:        api.PortConfigFromSettings(j.Settings, cfg.Serial.Defaults),
+		}
+		if j.RequestHex != "" {
+			job.Request, _ = hex.DecodeString(j.RequestHex)
+		}
+		if j.Pattern != "" {
+			job.Pattern = regexp.MustCompile(j.Pattern)
+		}
+		result = append(result, job)
+	}
+	return result
+}
+
+// buildDataLog converts the data log configuration into logger options.
+// The configuration has been validated, so patterns parse.
+func buildDataLog(cfg *config.Config, alias func(string) string) datalog.Options {
+	opts := datalog.Options{
+		FlushInterval: time.Duration(cfg.DataLog.FlushIntervalMs) * time.Millisecond,
+		Alias:         alias,
+	}
+	for _, s := range cfg.DataLog.Sinks {
+		opts.Sinks = append(opts.Sinks, datalog.SinkConfig{
+			Name:  s.Name,
+			Type:  s.Type,
+			URL:   s.URL,
+			Token: s.Token,
+		})
+	}
+	for _, p := range cfg.DataLog.Ports {
+		src := datalog.Source{
+			Port:          p.Port,
+			Measurement:   p.Measurement,
+			Tags:          p.Tags,
+			Sinks:         p.Sinks,
+			Modbus:        p.Modbus,
+			ModbusAddress: p.ModbusAddress,
+		}
+		if p.Match != "" {
+			src.Pattern = regexp.MustCompile(p.Match)
+		}
+		for _, f := range p.Fields {
+			src.Fields = append(src.Fields, datalog.Field{
+				Name:     f.Name,
+				Group:    f.Group,
+				Register: f.Register,
+				Type:     f.Type,
+				WordSwap: f.WordSwap,
+				Scale:    f.Scale,
+			})
+		}
+		opts.Sources = append(opts.Sources, src)
+	}
+	return opts
+}
+
+// buildAliases converts the configured aliases into scanner aliases
+func buildAliases(cfg *config.Config) []serial.Alias {
+	var aliases []serial.Alias
+	for _, a := range cfg.AllAliases() {
+		match := a.Match
+		aliases = append(aliases, serial.Alias{
+			Name: a.Name,
+			Matches: func(p serial.PortInfo) bool {
+				return match.Matches(config.DeviceIdentity{
+					Name:         p.Name,
+					VID:          p.VID,
+					PID:          p.PID,
+					SerialNumber: p.SerialNumber,
+				})
+			},
+		})
+	}
+	return aliases
+}
+
+// buildNetworkPorts converts the configured device servers into scanner
+// network ports
+func buildNetworkPorts(cfg *config.Config) []serial.NetworkPort {
+	var ports []serial.NetworkPort
+	for _, np := range cfg.Serial.NetworkPorts {
+		ports = append(ports, serial.NetworkPort{Address: np.Address, Description: np.Description})
+	}
+	return ports
+}
+
+// buildPortFilters converts configured include or exclude rules into
+// scanner filters
+func buildPortFilters(rules []config.PortFilterConfig) ([]serial.PortFilter, error) {
+	var filters []serial.PortFilter
+	for _, r := range rules {
+		f := serial.PortFilter{VID: r.VID, PID: r.PID}
+		if r.Type != "" {
+			t, err := serial.ParsePortType(r.Type)
+			if err != nil {
+				return nil, err
+			}
+			f.Type = &t
+		}
+		if r.Name != "" {
+			re, err := regexp.Compile(r.Name)
+			if err != nil {
+				return nil, err
+			}
+			f.Name = re
+		}
+		if r.Description != "" {
+			re, err := regexp.Compile(r.Description)
+			if err != nil {
+				return nil, err
+			}
+			f.Description = re
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+func startMetricsServer(cfg *config.Config, manager *serial.Manager, clientLimiter *ratelimit.Limiter, healthz, readyz http.Handler) (*http.Server, error) {
+	path := cfg.Metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, metrics.Handler(manager, clientLimiter))
+	mux.Handle("/healthz", healthz)
+	mux.Handle("/readyz", readyz)
+
+	listener, err := net.Listen("tcp", cfg.Metrics.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		log.Printf("Metrics server listening on %s%s", cfg.Metrics.Address, path)
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("Metrics server error: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+// startFileServer serves the file endpoint, over TLS with the gRPC server's
+// certificate when tlsConfig is set. Without TLS the configuration only
+// allows a loopback address, as requests carry bearer tokens.
+func startFileServer(cfg *config.Config, authn *auth.Authenticator, tlsConfig *tls.Config) (*http.Server, error) {
+	handler, err := api.NewFileHandler(cfg.Files.Directory, authn)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/files/", http.StripPrefix("/files", handler))
+
+	listener, err := net.Listen("tcp", cfg.Files.Address)
+	if err != nil {
+		handler.Close()
+		return nil, err
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig.Clone())
+		scheme = "https"
+	}
+
+	server := &http.Server{Handler: mux}
+	server.RegisterOnShutdown(func() { handler.Close() })
+
+	go func() {
+		log.Printf("File server listening on %s://%s (serving %s)", scheme, cfg.Files.Address, cfg.Files.Directory)
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("File server error: %v", err)
+		}
+	}()
+
+	return server, nil
+}
+
+func newAuthenticator(cfg *config.Config) (*auth.Authenticator, error) {
+	tokens := make([]auth.Token, 0, len(cfg.Auth.Tokens))
+	for _, t := range cfg.Auth.Tokens {
+		token := auth.Token{
+			Name:   t.Name,
+			Secret: t.Token,
+			Admin:  t.Admin,
+		}
+
+		// A token's roles combine: it gets every port and right any of them grants
+		for _, name := range t.Roles {
+			role := cfg.Auth.Role(name)
+			if role == nil {
+				return nil, fmt.Errorf("token %s references unknown role: %s", t.Name, name)
+			}
+			token.Admin = token.Admin || role.Admin
+
+			if len(role.Ports) == 0 {
+				token.Grants = append(token.Grants, auth.Grant{Pattern: "*", Write: true})
+			}
+			for _, p := range role.Ports {
+				token.Grants = append(token.Grants, auth.Grant{Pattern: serial.CanonicalPortName(p.Name), Write: !p.ReadOnly()})
+			}
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	if cfg.Auth.SigningKey == "" {
+		log.Println("Warning: auth.signing_key not set, access links will not survive restarts")
+	}
+
+	return auth.NewAuthenticator(tokens, []byte(cfg.Auth.SigningKey))
+}
+
+func setupLogging(cfg *config.Config, container bool) {
 	// Basic logging setup
 	// In production, you'd use a more sophisticated logging library
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 
-	if cfg.Logging.File != "" {
+	// Containers log to stdout, where the runtime collects it, as JSON
+	var output io.Writer = os.Stderr
+	if container {
+		output = os.Stdout
+	} else if cfg.Logging.File != "" {
 		f, err := os.OpenFile(cfg.Logging.File, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
 		if err != nil {
 			log.Printf("Warning: failed to open log file: %v", err)
 		} else {
-			log.SetOutput(f)
+			output = f
+		}
+	}
+
+	if container || strings.EqualFold(cfg.Logging.Format, "json") {
+		output = logging.NewJSONWriter(output)
+	}
+
+	// Additional sinks receive the same lines; a sink that cannot be opened
+	// is reported on the primary output and skipped
+	writers := []io.Writer{output}
+	if sl := cfg.Logging.Syslog; sl.Enabled {
+		sink, err := logging.OpenSyslog(logging.SyslogOptions{
+			Network:  sl.Network,
+			Address:  sl.Address,
+			Facility: sl.Facility,
+			Tag:      sl.Tag,
+			Format:   strings.ToLower(sl.Format),
+		})
+		if err != nil {
+			log.Printf("Warning: failed to open syslog: %v", err)
+		} else {
+			writers = append(writers, sink)
+		}
+	}
+	if cfg.Logging.EventLog.Enabled {
+		source := cfg.Logging.EventLog.Source
+		if source == "" {
+			source = cfg.Service.Name
+		}
+		sink, err := logging.OpenEventLog(source)
+		if err != nil {
+			log.Printf("Warning: failed to open event log: %v", err)
+		} else {
+			writers = append(writers, sink)
 		}
 	}
+
+	log.SetOutput(io.MultiWriter(writers...))
 }
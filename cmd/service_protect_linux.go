@@ -0,0 +1,146 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+	"github.com/Shoaibashk/BaudLink/service"
+)
+
+var serviceProtectCmd = &cobra.Command{
+	Use:   "protect <port>...",
+	Short: "Keep ModemManager and login prompts away from ports",
+	Long: `Keep ModemManager and login prompts (getty) from grabbing ports.
+
+A udev rule tells ModemManager to ignore each port, so it no longer sends AT
+commands to new devices, and the systemd login prompt of the port's tty is
+masked. USB adapters are matched by their serial ID, so the rule follows
+them to other USB ports; --by-name matches the device name instead.
+baudlink scan shows which ports are claimed.
+
+Example:
+  sudo baudlink service protect /dev/ttyUSB0 /dev/ttyACM0
+  sudo baudlink service unprotect /dev/ttyUSB0`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePorts,
+	RunE:              runServiceProtect,
+}
+
+var serviceUnprotectCmd = &cobra.Command{
+	Use:   "unprotect [port]...",
+	Short: "Remove the protection added by protect",
+	Long: `Remove the udev rules added by protect and unmask the login prompts.
+Without arguments the protected ports are listed.`,
+	RunE: runServiceUnprotect,
+}
+
+func init() {
+	serviceCmd.AddCommand(serviceProtectCmd)
+	serviceCmd.AddCommand(serviceUnprotectCmd)
+
+	serviceProtectCmd.Flags().Bool("by-name", false, "match the device name instead of the adapter's serial ID")
+}
+
+func runServiceProtect(cmd *cobra.Command, args []string) error {
+	byName, _ := cmd.Flags().GetBool("by-name")
+
+	cfg, err := loadServiceConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	var ports []serial.PortInfo
+	if !byName {
+		if scanner, err := serial.NewScanner(nil, nil); err == nil {
+			ports, _ = scanner.Scan()
+		}
+	}
+
+	for _, device := range args {
+		port := service.ProtectedPort{Device: device}
+		if !byName {
+			port.Serial = adapterSerial(ports, device)
+		}
+		if err := service.Protect(cfg, port); err != nil {
+			return fmt.Errorf("failed to protect %s: %w", device, err)
+		}
+
+		if port.Serial != "" {
+			fmt.Printf("Protected %s (adapter %s)\n", device, port.Serial)
+		} else {
+			fmt.Printf("Protected %s\n", device)
+		}
+	}
+	fmt.Println("Replug the devices or restart ModemManager if it has already claimed them.")
+	return nil
+}
+
+// adapterSerial returns the udev ID_SERIAL of a USB port, or "" for other
+// ports
+func adapterSerial(ports []serial.PortInfo, device string) string {
+	resolved, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		resolved = device
+	}
+	for _, p := range ports {
+		if p.Name == device || p.Name == resolved {
+			if p.Properties["ID_BUS"] == "usb" {
+				return p.Properties["ID_SERIAL"]
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+func runServiceUnprotect(cmd *cobra.Command, args []string) error {
+	cfg, err := loadServiceConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		devices, err := service.ProtectedPorts(cfg)
+		if err != nil {
+			return err
+		}
+		if len(devices) == 0 {
+			fmt.Println("No ports are protected.")
+			return nil
+		}
+		fmt.Println("Protected ports:")
+		for _, device := range devices {
+			fmt.Printf("  %s\n", device)
+		}
+		return nil
+	}
+
+	for _, device := range args {
+		if err := service.Unprotect(cfg, device); err != nil {
+			return fmt.Errorf("failed to unprotect %s: %w", device, err)
+		}
+		fmt.Printf("Unprotected %s\n", device)
+	}
+	return nil
+}
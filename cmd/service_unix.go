@@ -30,25 +30,29 @@ import (
 // serviceCmd represents the service command
 var serviceCmd = &cobra.Command{
 	Use:   "service",
-	Short: "Manage the BaudLink systemd service",
-	Long: `Manage the BaudLink agent as a systemd service.
+	Short: "Manage the BaudLink system service",
+	Long: `Manage the BaudLink agent as a system service.
 
 This command allows you to install, uninstall, start, stop, and check the
-status of the BaudLink agent running as a systemd service.
+status of the BaudLink agent running as a system service. systemd is used on
+Linux and launchd on macOS.
 
 Subcommands:
-  install   - Install the systemd service
-  uninstall - Remove the systemd service
-  start     - Start the systemd service
-  stop      - Stop the systemd service
-  status    - Check the systemd service status
+  install   - Install the system service
+  uninstall - Remove the system service
+  start     - Start the system service
+  stop      - Stop the system service
+  restart   - Restart the system service
+  status    - Check the system service status
+  protect   - Keep ModemManager and login prompts away from ports (Linux)
+  unprotect - Remove that protection (Linux)
 
 Note: Most operations require root privileges (sudo).`,
 }
 
 var serviceInstallCmd = &cobra.Command{
 	Use:   "install",
-	Short: "Install the systemd service",
+	Short: "Install the system service",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadServiceConfig(cmd)
 		if err != nil {
@@ -60,7 +64,7 @@ var serviceInstallCmd = &cobra.Command{
 
 var serviceUninstallCmd = &cobra.Command{
 	Use:   "uninstall",
-	Short: "Remove the systemd service",
+	Short: "Remove the system service",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadServiceConfig(cmd)
 		if err != nil {
@@ -72,7 +76,7 @@ var serviceUninstallCmd = &cobra.Command{
 
 var serviceStartCmd = &cobra.Command{
 	Use:   "start",
-	Short: "Start the systemd service",
+	Short: "Start the system service",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadServiceConfig(cmd)
 		if err != nil {
@@ -84,7 +88,7 @@ var serviceStartCmd = &cobra.Command{
 
 var serviceStopCmd = &cobra.Command{
 	Use:   "stop",
-	Short: "Stop the systemd service",
+	Short: "Stop the system service",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadServiceConfig(cmd)
 		if err != nil {
@@ -94,9 +98,21 @@ var serviceStopCmd = &cobra.Command{
 	},
 }
 
+var serviceRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the system service",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadServiceConfig(cmd)
+		if err != nil {
+			return err
+		}
+		return service.Restart(cfg)
+	},
+}
+
 var serviceStatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Check the systemd service status",
+	Short: "Check the system service status",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := loadServiceConfig(cmd)
 		if err != nil {
@@ -117,6 +133,7 @@ func init() {
 	serviceCmd.AddCommand(serviceUninstallCmd)
 	serviceCmd.AddCommand(serviceStartCmd)
 	serviceCmd.AddCommand(serviceStopCmd)
+	serviceCmd.AddCommand(serviceRestartCmd)
 	serviceCmd.AddCommand(serviceStatusCmd)
 
 	serviceCmd.PersistentFlags().StringP("config", "c", "", "config file path")
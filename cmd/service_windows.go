@@ -41,6 +41,7 @@ Subcommands:
   uninstall - Remove the Windows service
   start     - Start the Windows service
   stop      - Stop the Windows service
+  restart   - Restart the Windows service
   status    - Check the Windows service status`,
 }
 
@@ -92,6 +93,18 @@ var serviceStopCmd = &cobra.Command{
 	},
 }
 
+var serviceRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the Windows service",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadServiceConfig(cmd)
+		if err != nil {
+			return err
+		}
+		return service.Restart(cfg)
+	},
+}
+
 var serviceStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check the Windows service status",
@@ -115,6 +128,7 @@ func init() {
 	serviceCmd.AddCommand(serviceUninstallCmd)
 	serviceCmd.AddCommand(serviceStartCmd)
 	serviceCmd.AddCommand(serviceStopCmd)
+	serviceCmd.AddCommand(serviceRestartCmd)
 	serviceCmd.AddCommand(serviceStatusCmd)
 
 	serviceCmd.PersistentFlags().StringP("config", "c", "", "config file path")
@@ -0,0 +1,321 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// sessionsCmd represents the sessions command
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List active sessions on a running agent",
+	Long: `List all active sessions on a running agent with their client ID, uptime,
+byte counters, and subscriber counts.
+
+Requires an admin token when the agent has authentication enabled.
+
+Example:
+  baudlink sessions
+  baudlink sessions --agent 10.0.0.5:50051 --token <admin-token>
+  baudlink sessions close <session-id> --reason "stuck client"
+  baudlink sessions history --since 24h --port /dev/ttyUSB0`,
+	Args: cobra.NoArgs,
+	RunE: runSessions,
+}
+
+// sessionsCloseCmd represents the sessions close command
+var sessionsCloseCmd = &cobra.Command{
+	Use:   "close <session-id>",
+	Short: "Force-close a session",
+	Long: `Force-close a session regardless of which client owns it.
+
+Managed ports are closed too; the agent reopens them with a new session.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionsClose,
+}
+
+// sessionsHistoryCmd represents the sessions history command
+var sessionsHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show past sessions",
+	Long: `Show sessions a running agent has had open, including those from before it
+last restarted, with their byte counters and the totals over every matching
+session.
+
+The agent must have history enabled in its configuration. Requires an admin
+token when the agent has authentication enabled.
+
+Example:
+  baudlink sessions history
+  baudlink sessions history --since 168h --port /dev/ttyUSB0
+  baudlink sessions history --client logger --json`,
+	Args: cobra.NoArgs,
+	RunE: runSessionsHistory,
+}
+
+// sessionsCapturesCmd represents the sessions captures command
+var sessionsCapturesCmd = &cobra.Command{
+	Use:   "captures",
+	Short: "Show the captures taps wrote",
+	Long: `Show the traffic captures taps wrote on a running agent: which session and
+port each covered, where it was written, when, and how much.
+
+The agent must have history enabled in its configuration. Requires an admin
+token when the agent has authentication enabled.
+
+Example:
+  baudlink sessions captures
+  baudlink sessions captures --session <session-id>`,
+	Args: cobra.NoArgs,
+	RunE: runSessionsCaptures,
+}
+
+func init() {
+	rootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(sessionsCloseCmd)
+	sessionsCmd.AddCommand(sessionsHistoryCmd)
+	sessionsCmd.AddCommand(sessionsCapturesCmd)
+
+	sessionsCloseCmd.Flags().String("reason", "", "reason recorded in the agent's audit log")
+
+	sessionsHistoryCmd.Flags().Duration("since", 0, "only show sessions open within this long, e.g. 24h")
+	sessionsHistoryCmd.Flags().String("port", "", "only show sessions on this port")
+	sessionsHistoryCmd.RegisterFlagCompletionFunc("port", completePorts)
+	sessionsHistoryCmd.Flags().String("client", "", "only show sessions owned by this client ID")
+	sessionsHistoryCmd.Flags().Uint32("limit", 100, "number of most recent sessions to show")
+	sessionsHistoryCmd.Flags().Bool("json", false, "output sessions as JSON lines")
+
+	sessionsCapturesCmd.Flags().Duration("since", 0, "only show captures running within this long, e.g. 24h")
+	sessionsCapturesCmd.Flags().String("port", "", "only show captures of this port")
+	sessionsCapturesCmd.RegisterFlagCompletionFunc("port", completePorts)
+	sessionsCapturesCmd.Flags().String("session", "", "only show captures of this session")
+	sessionsCapturesCmd.Flags().Uint32("limit", 100, "number of most recent captures to show")
+	sessionsCapturesCmd.Flags().Bool("json", false, "output captures as JSON lines")
+}
+
+func runSessions(cmd *cobra.Command, args []string) error {
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := client.ListSessions(context.Background(), &pb.ListSessionsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	if len(resp.Sessions) == 0 {
+		fmt.Println("No active sessions.")
+		return nil
+	}
+
+	fmt.Printf("Found %d active session(s):\n\n", len(resp.Sessions))
+
+	for _, s := range resp.Sessions {
+		flags := ""
+		if s.Exclusive {
+			flags += " [EXCLUSIVE]"
+		}
+		if s.Managed {
+			flags += " [MANAGED]"
+		}
+
+		fmt.Printf("  %s%s\n", s.PortName, flags)
+		fmt.Printf("    Session:      %s\n", s.SessionId)
+		fmt.Printf("    Client:       %s\n", s.ClientId)
+		fmt.Printf("    Uptime:       %s\n", time.Duration(s.UptimeSeconds)*time.Second)
+		fmt.Printf("    Sent:         %d bytes\n", s.BytesSent)
+		fmt.Printf("    Received:     %d bytes\n", s.BytesReceived)
+		if s.Errors > 0 {
+			fmt.Printf("    Errors:       %d\n", s.Errors)
+		}
+		fmt.Printf("    Subscribers:  %d\n", s.Subscribers)
+		if s.Attachments > 0 {
+			fmt.Printf("    Attachments:  %d\n", s.Attachments)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func runSessionsClose(cmd *cobra.Command, args []string) error {
+	reason, _ := cmd.Flags().GetString("reason")
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := client.ForceClose(context.Background(), &pb.ForceCloseRequest{
+		SessionId: args[0],
+		Reason:    reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to close session: %s", resp.Message)
+	}
+
+	fmt.Printf("Closed session %s on %s\n", args[0], resp.PortName)
+	return nil
+}
+
+func runSessionsHistory(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetDuration("since")
+	portName, _ := cmd.Flags().GetString("port")
+	clientID, _ := cmd.Flags().GetString("client")
+	limit, _ := cmd.Flags().GetUint32("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	req := &pb.GetSessionHistoryRequest{
+		PortName: portName,
+		ClientId: clientID,
+		Limit:    limit,
+	}
+	if since > 0 {
+		req.Since = time.Now().Add(-since).Unix()
+	}
+
+	resp, err := client.GetSessionHistory(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to get session history: %w", err)
+	}
+
+	if jsonOutput {
+		for _, s := range resp.Sessions {
+			line, err := protojson.Marshal(s)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(line))
+		}
+		return nil
+	}
+
+	if len(resp.Sessions) == 0 {
+		fmt.Println("No sessions recorded.")
+		return nil
+	}
+
+	for _, s := range resp.Sessions {
+		closed := "open"
+		if s.ClosedAt > 0 {
+			closed = time.Duration(s.ClosedAt - s.OpenedAt).Round(time.Second).String()
+		}
+		if s.Interrupted {
+			closed += " (interrupted)"
+		}
+
+		fmt.Printf("%s  %-20s %s  client=%s  sent=%d  received=%d",
+			time.Unix(0, s.OpenedAt).Format("2006-01-02 15:04:05"),
+			closed, s.PortName, s.ClientId, s.BytesSent, s.BytesReceived)
+		if s.Errors > 0 {
+			fmt.Printf("  errors=%d", s.Errors)
+		}
+		fmt.Printf("  session=%s\n", s.SessionId)
+	}
+
+	t := resp.Totals
+	fmt.Printf("\nTotal: %d session(s), %d bytes sent, %d bytes received\n", t.Sessions, t.BytesSent, t.BytesReceived)
+	return nil
+}
+
+func runSessionsCaptures(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetDuration("since")
+	portName, _ := cmd.Flags().GetString("port")
+	sessionID, _ := cmd.Flags().GetString("session")
+	limit, _ := cmd.Flags().GetUint32("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	req := &pb.GetCaptureIndexRequest{
+		PortName:  portName,
+		SessionId: sessionID,
+		Limit:     limit,
+	}
+	if since > 0 {
+		req.Since = time.Now().Add(-since).Unix()
+	}
+
+	resp, err := client.GetCaptureIndex(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to get capture index: %w", err)
+	}
+
+	if jsonOutput {
+		for _, c := range resp.Captures {
+			line, err := protojson.Marshal(c)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(line))
+		}
+		return nil
+	}
+
+	if len(resp.Captures) == 0 {
+		fmt.Println("No captures recorded.")
+		return nil
+	}
+
+	for _, c := range resp.Captures {
+		destination := c.Config.GetMirrorToFile()
+		if destination == "" {
+			destination = "tcp://" + c.Config.GetMirrorToTcp()
+		}
+		stopped := "running"
+		if c.StoppedAt > 0 {
+			stopped = time.Duration(c.StoppedAt - c.StartedAt).Round(time.Second).String()
+		}
+		if c.Interrupted {
+			stopped += " (interrupted)"
+		}
+
+		fmt.Printf("%s  %-20s %s  %s  bytes=%d",
+			time.Unix(0, c.StartedAt).Format("2006-01-02 15:04:05"),
+			stopped, c.PortName, destination, c.BytesWritten)
+		if c.RecordsDropped > 0 {
+			fmt.Printf("  dropped=%d", c.RecordsDropped)
+		}
+		fmt.Printf("  session=%s\n", c.SessionId)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+// passphraseEnv holds the passphrase sealing a bundle's secrets when
+// --passphrase-file is not given
+const passphraseEnv = "BAUDLINK_BUNDLE_PASSPHRASE"
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the agent's configuration as a state bundle",
+	Long: `Export the agent configuration as a gzipped tar bundle that can be
+imported on another machine with "baudlink import", to clone a configured
+gateway.
+
+The bundle holds the whole configuration, including profiles, aliases,
+groups, rules, and jobs. Access tokens, the link signing key, and the
+credentials of federation remotes, the MQTT broker, and data log sinks are
+encrypted with a passphrase, read from --passphrase-file or
+` + passphraseEnv + `. Comments in the configuration file are not kept, and
+TLS certificates and other files the configuration refers to are not
+included.
+
+Example:
+  baudlink export -o gateway.tar.gz --passphrase-file pass.txt
+  ` + passphraseEnv + `=secret baudlink export -c ./agent.yaml`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runExport,
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Apply a state bundle to this machine's configuration",
+	Long: `Validate a bundle written by "baudlink export" and write its
+configuration, replacing the configuration file. The previous file is kept
+with a .bak suffix. The passphrase the bundle was exported with is read
+from --passphrase-file or ` + passphraseEnv + `.
+
+Restart the agent afterwards to apply the configuration.
+
+Example:
+  baudlink import gateway.tar.gz --passphrase-file pass.txt
+  baudlink import gateway.tar.gz --dry-run`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+
+	exportCmd.Flags().StringP("config", "c", "", "config file path")
+	exportCmd.Flags().StringP("output", "o", "baudlink-state.tar.gz", "bundle file to write")
+	exportCmd.Flags().String("passphrase-file", "", "file holding the passphrase secrets are encrypted with (default $"+passphraseEnv+")")
+
+	importCmd.Flags().StringP("config", "c", "", "config file path to write")
+	importCmd.Flags().String("passphrase-file", "", "file holding the passphrase the bundle was exported with (default $"+passphraseEnv+")")
+	importCmd.Flags().Bool("dry-run", false, "validate the bundle without writing the configuration")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = config.DefaultConfigPath()
+	}
+	output, _ := cmd.Flags().GetString("output")
+
+	passphrase, err := bundlePassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	manifest, err := config.ExportState(&buf, path, version, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", path, err)
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("Exported %s to %s\n", path, output)
+	printManifest(manifest)
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = config.DefaultConfigPath()
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	passphrase, err := bundlePassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cfg, manifest, err := config.ImportState(f, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", args[0], err)
+	}
+
+	fmt.Printf("Bundle exported from %s on %s (agent %s)\n",
+		manifest.Hostname, manifest.CreatedAt.Local().Format("2006-01-02 15:04"), manifest.AgentVersion)
+	printManifest(manifest)
+
+	if dryRun {
+		fmt.Println("\nThe bundle is valid; nothing was written (--dry-run).")
+		return nil
+	}
+
+	// The previous configuration may hold credentials too, so its backup
+	// is readable by its owner only. It is copied rather than moved so the
+	// new file takes over the original's owner and group.
+	if old, err := os.ReadFile(path); err == nil {
+		if err := writeBackup(path+".bak", old); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		fmt.Printf("\nPrevious configuration saved to %s.bak\n", path)
+	}
+	// The configuration holds access tokens
+	save := cfg.Save
+	if manifest.Secrets {
+		save = cfg.SavePrivate
+	}
+	if err := save(path); err != nil {
+		return err
+	}
+	fmt.Printf("Configuration written to %s\n", path)
+
+	// Files the configuration refers to may not exist on this machine
+	if findings, err := config.Lint(path); err == nil {
+		for _, f := range findings {
+			fmt.Printf("  %s\n", f)
+		}
+	}
+
+	fmt.Println("Restart the agent to apply it, e.g. baudlink service restart.")
+	return nil
+}
+
+// writeBackup writes a backup readable by its owner only, also tightening
+// the permissions of a backup left by an earlier import
+func writeBackup(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0600)
+}
+
+// bundlePassphrase returns the passphrase from --passphrase-file or the
+// environment, or "" if neither is set
+func bundlePassphrase(cmd *cobra.Command) (string, error) {
+	file, _ := cmd.Flags().GetString("passphrase-file")
+	if file == "" {
+		return os.Getenv(passphraseEnv), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// printManifest prints what a bundle holds
+func printManifest(m *config.StateManifest) {
+	fmt.Printf("  Profiles: %d, aliases: %d, groups: %d, rules: %d, jobs: %d\n",
+		m.Profiles, m.Aliases, m.Groups, m.Rules, m.Jobs)
+	secrets := "none"
+	if m.Secrets {
+		secrets = "encrypted"
+	}
+	fmt.Printf("  Tokens: %d, secrets: %s\n", m.Tokens, secrets)
+}
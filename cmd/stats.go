@@ -0,0 +1,147 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats [port]",
+	Short: "Show throughput and error statistics for open ports",
+	Long: `Show statistics for open ports on a running agent: byte counters, rolling
+throughput over 1s, 10s, and 60s windows, errors by type, queue depths, and
+last-activity times.
+
+Example:
+  baudlink stats
+  baudlink stats /dev/ttyUSB0 --watch 2s`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().Duration("watch", 0, "refresh at this interval until interrupted")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	watch, _ := cmd.Flags().GetDuration("watch")
+
+	req := &pb.GetStatisticsRequest{}
+	if len(args) > 0 {
+		req.PortName = args[0]
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	for {
+		resp, err := client.GetStatistics(context.Background(), req)
+		if err != nil {
+			return fmt.Errorf("failed to get statistics: %w", err)
+		}
+
+		if watch > 0 {
+			// Clear the screen between refreshes
+			fmt.Print("\033[H\033[2J")
+		}
+		printStatistics(resp.Sessions)
+
+		if watch <= 0 {
+			return nil
+		}
+		time.Sleep(watch)
+	}
+}
+
+// printStatistics prints the statistics of each session
+func printStatistics(sessions []*pb.SessionStatistics) {
+	if len(sessions) == 0 {
+		fmt.Println("No open ports.")
+		return
+	}
+
+	for _, s := range sessions {
+		fmt.Printf("  %s\n", s.PortName)
+		fmt.Printf("    Session:      %s\n", s.SessionId)
+		fmt.Printf("    Sent:         %d bytes\n", s.BytesSent)
+		fmt.Printf("    Received:     %d bytes\n", s.BytesReceived)
+
+		fmt.Printf("    Throughput:  ")
+		for _, r := range s.Rates {
+			fmt.Printf(" %ds: %s/%s", r.WindowSeconds, formatRate(r.SentBps), formatRate(r.ReceivedBps))
+		}
+		fmt.Println("  (tx/rx)")
+
+		if e := s.Errors; e != nil {
+			fmt.Printf("    Errors:       %d I/O, %d framing, %d checksum, %d rate limited\n",
+				e.Io, e.Framing, e.Checksum, e.RateLimited)
+		}
+		fmt.Printf("    Queued:       %d writes, %d bytes buffered\n", s.WriteQueueDepth, s.BufferedBytes)
+		fmt.Printf("    Subscribers:  %d\n", s.Subscribers)
+		fmt.Printf("    Read latency: %s\n", formatLatency(s.ReadLatency))
+		fmt.Printf("    Write time:   %s\n", formatLatency(s.WriteDuration))
+		fmt.Printf("    Last sent:    %s\n", formatLastActivity(s.LastSent))
+		fmt.Printf("    Last rcvd:    %s\n", formatLastActivity(s.LastReceived))
+		fmt.Println()
+	}
+}
+
+// formatRate formats a byte rate with a binary unit
+func formatRate(bps float64) string {
+	switch {
+	case bps >= 1024*1024:
+		return fmt.Sprintf("%.1fMB/s", bps/(1024*1024))
+	case bps >= 1024:
+		return fmt.Sprintf("%.1fKB/s", bps/1024)
+	default:
+		return fmt.Sprintf("%.0fB/s", bps)
+	}
+}
+
+// formatLatency formats latency percentiles and jitter
+func formatLatency(l *pb.LatencyStats) string {
+	if l.GetCount() == 0 {
+		return "no data"
+	}
+	us := func(v uint64) time.Duration {
+		return time.Duration(v) * time.Microsecond
+	}
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s, max %s, jitter %s",
+		us(l.P50Us), us(l.P90Us), us(l.P99Us), us(l.MaxUs), us(l.JitterUs))
+}
+
+// formatLastActivity formats a Unix timestamp as time elapsed
+func formatLastActivity(unix int64) string {
+	if unix == 0 {
+		return "never"
+	}
+	return time.Since(time.Unix(unix, 0)).Round(time.Second).String() + " ago"
+}
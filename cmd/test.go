@@ -0,0 +1,135 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// testCmd represents the test command
+var testCmd = &cobra.Command{
+	Use:   "test <port>",
+	Short: "Run a loopback test on a port",
+	Long: `Run a loopback test on a port through the agent.
+
+A pattern is sent repeatedly and read back, measuring round-trip latency,
+throughput, and error rate at the configured baud rate. The port's TX and RX
+pins must be jumpered, or the port connected to a device that echoes data.
+
+Example:
+  baudlink test /dev/ttyUSB0
+  baudlink test COM3 --baud 115200 --iterations 100 --size 256`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePortArgs(1),
+	RunE:              runTest,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().Uint32("baud", 0, "baud rate to test at (default: agent default)")
+	testCmd.Flags().Uint32("iterations", 10, "number of round trips")
+	testCmd.Flags().Uint32("size", 64, "pattern size in bytes")
+	testCmd.Flags().Duration("timeout", 0, "per-iteration timeout (default: derived from baud rate)")
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	portName, err := portArg(cmd, args, 0)
+	if err != nil {
+		return err
+	}
+	baud, _ := cmd.Flags().GetUint32("baud")
+	iterations, _ := cmd.Flags().GetUint32("iterations")
+	size, _ := cmd.Flags().GetUint32("size")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	if size == 0 {
+		return fmt.Errorf("size must be at least 1")
+	}
+
+	conn, client, err := dialAgent(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	req := &pb.OpenPortRequest{PortName: portName, ClientId: "baudlink-test", Exclusive: true}
+	if baud > 0 {
+		req.Config = &pb.PortConfig{
+			BaudRate:      baud,
+			DataBits:      pb.DataBits_DATA_BITS_8,
+			StopBits:      pb.StopBits_STOP_BITS_1,
+			Parity:        pb.Parity_PARITY_NONE,
+			ReadTimeoutMs: 1000,
+		}
+	}
+
+	resp, err := client.OpenPort(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to open port: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to open port: %s", resp.Message)
+	}
+	defer client.ClosePort(ctx, &pb.ClosePortRequest{PortName: portName, SessionId: resp.SessionId})
+
+	pattern := make([]byte, size)
+	for i := range pattern {
+		pattern[i] = byte(i)
+	}
+
+	fmt.Printf("Testing %s (%d x %d bytes)...\n\n", portName, iterations, size)
+
+	report, err := client.TestPort(ctx, &pb.TestPortRequest{
+		PortName:   portName,
+		SessionId:  resp.SessionId,
+		Pattern:    pattern,
+		Iterations: iterations,
+		TimeoutMs:  uint32(timeout.Milliseconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("loopback test failed: %w", err)
+	}
+	if report.Iterations == 0 {
+		return fmt.Errorf("loopback test failed: %s", report.Message)
+	}
+
+	us := func(v int64) time.Duration { return time.Duration(v) * time.Microsecond }
+
+	fmt.Printf("  Baud rate:    %d\n", report.BaudRate)
+	fmt.Printf("  Iterations:   %d (%d lost)\n", report.Iterations, report.PacketsLost)
+	fmt.Printf("  Bytes:        %d sent, %d received, %d corrupted\n", report.BytesSent, report.BytesReceived, report.ByteErrors)
+	fmt.Printf("  Error rate:   %.2f%%\n", report.ErrorRate*100)
+	fmt.Printf("  Latency:      min %s, avg %s, max %s\n", us(report.MinLatencyUs), us(report.AvgLatencyUs), us(report.MaxLatencyUs))
+	fmt.Printf("  Throughput:   %.0f B/s (line maximum %.0f B/s)\n", report.ThroughputBps, report.TheoreticalBps)
+	fmt.Println()
+
+	if !report.Success {
+		return fmt.Errorf("%s", report.Message)
+	}
+
+	fmt.Println("Loopback test passed.")
+	return nil
+}
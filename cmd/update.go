@@ -0,0 +1,164 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Shoaibashk/BaudLink/config"
+	"github.com/Shoaibashk/BaudLink/internal/update"
+	"github.com/Shoaibashk/BaudLink/service"
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update BaudLink to the latest release",
+	Long: `Download the latest BaudLink release from GitHub and replace this binary.
+
+The release archive for this platform is checked against the published
+checksums, whose signature is verified with the release signing key built
+into official binaries. A build without that key refuses to update unless
+--allow-unsigned is given, trusting the checksums alone. The binary is
+swapped atomically, and a running system service is restarted so it picks
+up the new version.
+
+Example:
+  baudlink update --check
+  sudo baudlink update
+  sudo baudlink update --version v1.4.0`,
+	Args: cobra.NoArgs,
+	RunE: runUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().Bool("check", false, "only report whether an update is available")
+	updateCmd.Flags().String("version", "", "install a specific release instead of the latest")
+	updateCmd.Flags().Bool("force", false, "reinstall even if already up to date")
+	updateCmd.Flags().Bool("no-restart", false, "do not restart the system service")
+	updateCmd.Flags().Bool("allow-unsigned", false, "install without verifying the release signature when this build has no signing key")
+	updateCmd.Flags().StringP("config", "c", "", "config file path used to find the service")
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	check, _ := cmd.Flags().GetBool("check")
+	target, _ := cmd.Flags().GetString("version")
+	force, _ := cmd.Flags().GetBool("force")
+	noRestart, _ := cmd.Flags().GetBool("no-restart")
+	allowUnsigned, _ := cmd.Flags().GetBool("allow-unsigned")
+
+	key, err := update.ParsePublicKey(updatePublicKey)
+	if err != nil {
+		return err
+	}
+	updater := update.New(key)
+	updater.AllowUnsigned = allowUnsigned
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	var rel *update.Release
+	if target != "" {
+		rel, err = updater.Tag(ctx, target)
+	} else {
+		rel, err = updater.Latest(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	newer := update.Newer(rel.Version(), version)
+	if check {
+		if newer {
+			fmt.Printf("Update available: %s -> %s\n", version, rel.Version())
+			fmt.Printf("  %s\n", rel.URL)
+		} else {
+			fmt.Printf("BaudLink %s is up to date\n", version)
+		}
+		return nil
+	}
+
+	if !newer && target == "" && !force {
+		fmt.Printf("BaudLink %s is up to date\n", version)
+		return nil
+	}
+
+	if key == nil {
+		if !allowUnsigned {
+			return fmt.Errorf("this build has no release signing key to verify the update with; " +
+				"reinstall from an official release or pass --allow-unsigned to trust checksums alone")
+		}
+		fmt.Println("Warning: this build has no release signing key; verifying checksums only")
+	}
+
+	exePath, err := update.Executable()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Downloading BaudLink %s for %s/%s...\n", rel.Version(), runtime.GOOS, runtime.GOARCH)
+	binary, err := updater.Download(ctx, rel)
+	if err != nil {
+		return err
+	}
+
+	if err := update.Replace(exePath, binary); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("%w (try running as root or administrator)", err)
+		}
+		return err
+	}
+	fmt.Printf("Updated %s to %s\n", exePath, rel.Version())
+
+	if noRestart {
+		return nil
+	}
+	return restartUpdatedService(cmd)
+}
+
+// restartUpdatedService restarts the system service if it is running so it
+// executes the new binary
+func restartUpdatedService(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = config.DefaultConfigPath()
+	}
+
+	cfg, err := config.LoadOrDefault(path)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !service.Running(cfg) {
+		return nil
+	}
+
+	fmt.Printf("Restarting service %s...\n", cfg.Service.Name)
+	if err := service.Restart(cfg); err != nil {
+		return fmt.Errorf("binary updated but the service failed to restart: %w", err)
+	}
+	return nil
+}
@@ -19,47 +19,225 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Config represents the complete agent configuration
 type Config struct {
-	Server  ServerConfig  `yaml:"server"`
-	TLS     TLSConfig     `yaml:"tls"`
-	Serial  SerialConfig  `yaml:"serial"`
-	Logging LoggingConfig `yaml:"logging"`
-	Service ServiceConfig `yaml:"service"`
-	Metrics MetricsConfig `yaml:"metrics"`
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Serial      SerialConfig      `yaml:"serial"`
+	Profiles    []ProfileConfig   `yaml:"profiles"`
+	Aliases     []AliasConfig     `yaml:"aliases"`
+	Groups      []GroupConfig     `yaml:"groups"`
+	Logging     LoggingConfig     `yaml:"logging"`
+	Service     ServiceConfig     `yaml:"service"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Telemetry   TelemetryConfig   `yaml:"telemetry"`
+	Files       FilesConfig       `yaml:"files"`
+	Taps        TapsConfig        `yaml:"taps"`
+	Passthrough PassthroughConfig `yaml:"passthrough"`
+	RateLimits  RateLimitsConfig  `yaml:"rate_limits"`
+	Federation  FederationConfig  `yaml:"federation"`
+	Discovery   DiscoveryConfig   `yaml:"discovery"`
+	Audit       AuditConfig       `yaml:"audit"`
+	History     HistoryConfig     `yaml:"history"`
+	MQTT        MQTTConfig        `yaml:"mqtt"`
+	Rules       []RuleConfig      `yaml:"rules"`
+	Jobs        []JobConfig       `yaml:"jobs"`
+	DataLog     DataLogConfig     `yaml:"datalog"`
+	GPSD        GPSDConfig        `yaml:"gpsd"`
+	Kubernetes  KubernetesConfig  `yaml:"kubernetes"`
+
+	// format is the syntax the configuration was loaded from
+	format Format
 }
 
+// maxGRPCMessage is gRPC's default limit on the size of received messages
+const maxGRPCMessage = 4 * 1024 * 1024
+
 // ServerConfig holds server-related settings
 type ServerConfig struct {
 	GRPCAddress       string `yaml:"grpc_address"`
+	UnixSocket        string `yaml:"unix_socket"`       // Local socket served in addition to, or instead of, TCP
+	UnixSocketMode    string `yaml:"unix_socket_mode"`  // Octal permissions of the socket file
+	UnixSocketGroup   string `yaml:"unix_socket_group"` // Group owning the socket file
 	WebSocketAddress  string `yaml:"websocket_address"`
 	WebSocketEnabled  bool   `yaml:"websocket_enabled"`
 	MaxConnections    int    `yaml:"max_connections"`
 	ConnectionTimeout int    `yaml:"connection_timeout"`
+	ShutdownGrace     int    `yaml:"shutdown_grace_period"` // Seconds clients get to close their sessions on shutdown
+	MaxWriteBytes     int    `yaml:"max_write_bytes"`       // Largest data field accepted in a request
+	MaxChunkSize      int    `yaml:"max_chunk_size"`        // Largest chunk or read size a client may ask for
 }
 
 // TLSConfig holds TLS/SSL settings
 type TLSConfig struct {
-	Enabled  bool   `yaml:"enabled"`
-	CertFile string `yaml:"cert_file"`
-	KeyFile  string `yaml:"key_file"`
-	CAFile   string `yaml:"ca_file"`
+	Enabled        bool       `yaml:"enabled"`
+	CertFile       string     `yaml:"cert_file"`
+	KeyFile        string     `yaml:"key_file"`
+	CAFile         string     `yaml:"ca_file"`
+	AutoGenerate   bool       `yaml:"auto_generate"`   // Create a self-signed certificate if cert_file does not exist
+	Hosts          []string   `yaml:"hosts"`           // Names and addresses of generated certificates
+	ReloadInterval int        `yaml:"reload_interval"` // Seconds between checks for changed certificate files (0 disables)
+	ACME           ACMEConfig `yaml:"acme"`
+}
+
+// ACMEConfig holds settings for obtaining certificates from an ACME CA such
+// as Let's Encrypt
+type ACMEConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	Directory   string   `yaml:"directory"` // ACME directory URL
+	Email       string   `yaml:"email"`
+	Domains     []string `yaml:"domains"`
+	Challenge   string   `yaml:"challenge"`    // http-01 or dns-01
+	HTTPAddress string   `yaml:"http_address"` // Address answering http-01 challenges
+	DNSHook     string   `yaml:"dns_hook"`     // Program run as: hook present|cleanup <domain> <record> <value>
+	DNSWait     int      `yaml:"dns_wait"`     // Seconds to wait for DNS records to propagate
+	AccountKey  string   `yaml:"account_key"`
+	RenewBefore int      `yaml:"renew_before"` // Days before expiry to renew
+}
+
+// AuthConfig holds token authentication settings
+type AuthConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	Tokens        []TokenConfig `yaml:"tokens"`
+	Roles         []RoleConfig  `yaml:"roles"`
+	SigningKey    string        `yaml:"signing_key"`
+	PublicAddress string        `yaml:"public_address"`
+	MaxLinkTTL    int           `yaml:"max_link_ttl"`
+}
+
+// TokenConfig defines a static access token
+type TokenConfig struct {
+	Name  string   `yaml:"name"`
+	Token string   `yaml:"token"`
+	Admin bool     `yaml:"admin"`
+	Roles []string `yaml:"roles"` // Roles granting port access; none grants every port
+}
+
+// RoleConfig defines a named set of permissions that tokens are assigned
+type RoleConfig struct {
+	Name  string          `yaml:"name"`
+	Admin bool            `yaml:"admin"`
+	Ports []PortACLConfig `yaml:"ports"` // Empty grants every port read-write
+}
+
+// PortACLConfig grants access to the ports matching a name or pattern
+type PortACLConfig struct {
+	Name   string `yaml:"name"`   // Port name or pattern such as /dev/ttyUSB*
+	Access string `yaml:"access"` // read-only or read-write (default)
+}
+
+// ReadOnly reports whether the entry grants read-only access
+func (p PortACLConfig) ReadOnly() bool {
+	return p.Access == "read-only"
+}
+
+// Role returns the role with the given name, or nil
+func (a AuthConfig) Role(name string) *RoleConfig {
+	for i := range a.Roles {
+		if a.Roles[i].Name == name {
+			return &a.Roles[i]
+		}
+	}
+	return nil
 }
 
 // SerialConfig holds serial port settings
 type SerialConfig struct {
-	Defaults          SerialDefaults `yaml:"defaults"`
-	ScanInterval      int            `yaml:"scan_interval"`
-	ExcludePatterns   []string       `yaml:"exclude_patterns"`
-	AllowSharedAccess bool           `yaml:"allow_shared_access"`
+	Defaults          SerialDefaults      `yaml:"defaults"`
+	ScanInterval      int                 `yaml:"scan_interval"`
+	Hotplug           bool                `yaml:"hotplug"`
+	ExcludePatterns   []string            `yaml:"exclude_patterns"`
+	Exclude           []PortFilterConfig  `yaml:"exclude"` // Ports hidden in addition to exclude_patterns
+	Include           []PortFilterConfig  `yaml:"include"` // When set, only ports matching one of these are listed
+	AllowSharedAccess bool                `yaml:"allow_shared_access"`
+	WriteQueueDepth   int                 `yaml:"write_queue_depth"`
+	IdleTimeout       int                 `yaml:"idle_timeout"` // Minutes before unused client sessions are closed (0 = never)
+	ManagedPorts      []ManagedPortConfig `yaml:"managed_ports"`
+	OpenRetry         OpenRetryConfig     `yaml:"open_retry"`
+	SystemLock        SystemLockConfig    `yaml:"system_lock"`
+	NetworkPorts      []NetworkPortConfig `yaml:"network_ports"`
+}
+
+// NetworkPortConfig describes a serial device server or UDP serial bridge
+// listed alongside local ports
+type NetworkPortConfig struct {
+	Address     string `yaml:"address"` // tcp://host:port or udp://host:port[?bind=:port&iface=name]
+	Description string `yaml:"description"`
+}
+
+// validate checks the port's address
+func (n NetworkPortConfig) validate() error {
+	u, err := url.Parse(n.Address)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "tcp" && u.Scheme != "udp") || u.Hostname() == "" || u.Port() == "" {
+		return fmt.Errorf("address must be of the form tcp://host:port or udp://host:port")
+	}
+	return nil
+}
+
+// PortFilterConfig selects ports for the include and exclude lists. All
+// non-empty fields must match.
+type PortFilterConfig struct {
+	VID         string `yaml:"vid"`
+	PID         string `yaml:"pid"`
+	Type        string `yaml:"type"`        // usb, native, bluetooth, virtual, network, or unknown
+	Name        string `yaml:"name"`        // Regex matched against the port name
+	Description string `yaml:"description"` // Regex matched against the port description
+}
+
+// validate checks the filter's type and regular expressions
+func (f PortFilterConfig) validate() error {
+	if f == (PortFilterConfig{}) {
+		return fmt.Errorf("requires at least one of vid, pid, type, name, or description")
+	}
+	switch strings.ToLower(f.Type) {
+	case "", "usb", "native", "bluetooth", "virtual", "network", "unknown":
+	default:
+		return fmt.Errorf("invalid type: %s (must be usb, native, bluetooth, virtual, network, or unknown)", f.Type)
+	}
+	for _, pattern := range []string{f.Name, f.Description} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// OpenRetryConfig holds the default retry policy for transient open failures
+type OpenRetryConfig struct {
+	Attempts   int     `yaml:"attempts"`     // Total attempts including the first
+	DelayMs    int     `yaml:"delay_ms"`     // Delay before the first retry, doubled after each
+	MaxDelayMs int     `yaml:"max_delay_ms"` // Upper bound on the delay (0 = no bound)
+	Jitter     float64 `yaml:"jitter"`       // Fraction of each delay that is randomized, 0-1
+}
+
+// SystemLockConfig controls OS-level locking of opened ports against other
+// programs on the machine
+type SystemLockConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	LockDir string `yaml:"lock_dir"` // Directory of UUCP lock files
+}
+
+// ManagedPortConfig describes a port the agent opens at startup and keeps
+// open, reopening it when the device re-enumerates
+type ManagedPortConfig struct {
+	Port       string         `yaml:"port"` // Port name or alias
+	Settings   SerialDefaults `yaml:"settings"`
+	BufferSize int            `yaml:"buffer_size"`
 }
 
 // SerialDefaults holds default serial port parameters
@@ -69,8 +247,34 @@ type SerialDefaults struct {
 	StopBits       int    `yaml:"stop_bits"`
 	Parity         string `yaml:"parity"`
 	FlowControl    string `yaml:"flow_control"`
+	XonChar        int    `yaml:"xon_char"` // Software flow control characters (0 = DC1/DC3)
+	XoffChar       int    `yaml:"xoff_char"`
 	ReadTimeoutMs  int    `yaml:"read_timeout_ms"`
 	WriteTimeoutMs int    `yaml:"write_timeout_ms"`
+	Framer         string `yaml:"framer"`
+
+	// Write pacing for devices that drop data arriving too fast
+	WriteChunkSize    int `yaml:"write_chunk_size"`
+	WriteChunkDelayMs int `yaml:"write_chunk_delay_ms"`
+	WriteLineDelayMs  int `yaml:"write_line_delay_ms"`
+
+	// Half-duplex RS-485 driver control through RTS
+	RS485                  bool `yaml:"rs485"`
+	RS485RTSActiveLow      bool `yaml:"rs485_rts_active_low"`
+	RS485DelayBeforeSendMs int  `yaml:"rs485_delay_before_send_ms"`
+	RS485DelayAfterSendMs  int  `yaml:"rs485_delay_after_send_ms"`
+
+	// Checksum appended to writes and verified on framed responses
+	Checksum           string `yaml:"checksum"`
+	ChecksumPolynomial uint32 `yaml:"checksum_polynomial"`
+	ChecksumPlacement  string `yaml:"checksum_placement"`
+	ChecksumTerminator string `yaml:"checksum_terminator"`
+	ChecksumBigEndian  bool   `yaml:"checksum_big_endian"`
+	ChecksumHex        bool   `yaml:"checksum_hex"`
+
+	// Filters applied in order to data read from and written to the port
+	ReadFilters  []string `yaml:"read_filters"`
+	WriteFilters []string `yaml:"write_filters"`
 }
 
 // LoggingConfig holds logging settings
@@ -82,6 +286,36 @@ type LoggingConfig struct {
 	MaxBackups int    `yaml:"max_backups"`
 	MaxAge     int    `yaml:"max_age"`
 	Compress   bool   `yaml:"compress"`
+
+	// Sinks receiving log messages in addition to the console or file
+	Syslog   SyslogConfig   `yaml:"syslog"`
+	EventLog EventLogConfig `yaml:"event_log"`
+
+	CrashDumps CrashDumpConfig `yaml:"crash_dumps"`
+}
+
+// SyslogConfig sends agent logs to the local syslog daemon or a remote
+// server
+type SyslogConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Network  string `yaml:"network"`  // udp or tcp for a remote server; empty for the local daemon
+	Address  string `yaml:"address"`  // host:port of a remote server
+	Facility string `yaml:"facility"` // default: daemon
+	Tag      string `yaml:"tag"`      // Application name (default: baudlink)
+	Format   string `yaml:"format"`   // rfc5424 (default) or rfc3164
+}
+
+// CrashDumpConfig writes a report with the stacks of all goroutines when a
+// panic is recovered, for attaching to bug reports
+type CrashDumpConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Directory string `yaml:"directory"`
+}
+
+// EventLogConfig writes agent logs to the Windows Event Log
+type EventLogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Source  string `yaml:"source"` // Event source (default: the service name)
 }
 
 // ServiceConfig holds system service settings
@@ -92,6 +326,8 @@ type ServiceConfig struct {
 	AutoStart     bool   `yaml:"auto_start"`
 	RestartPolicy string `yaml:"restart_policy"`
 	RestartDelay  int    `yaml:"restart_delay"`
+	User          string `yaml:"user"`  // Account the Linux service runs as
+	Group         string `yaml:"group"` // Primary group of the service account
 }
 
 // MetricsConfig holds metrics/monitoring settings
@@ -101,18 +337,168 @@ type MetricsConfig struct {
 	Path    string `yaml:"path"`
 }
 
-// DefaultConfig returns a configuration with sensible defaults
+// TelemetryConfig holds OpenTelemetry tracing settings
+type TelemetryConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	Endpoint    string            `yaml:"endpoint"`     // OTLP/gRPC collector address
+	Insecure    bool              `yaml:"insecure"`     // Export without TLS
+	Headers     map[string]string `yaml:"headers"`      // Sent with every export, e.g. API keys
+	ServiceName string            `yaml:"service_name"` // Reported service name
+	SampleRatio float64           `yaml:"sample_ratio"` // Fraction of new traces recorded (0-1)
+}
+
+// FilesConfig holds settings for the HTTP file download endpoint
+type FilesConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Address   string `yaml:"address"`
+	Directory string `yaml:"directory"`
+}
+
+// TapsConfig holds settings for mirroring session traffic to files or sockets
+type TapsConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Directory   string `yaml:"directory"`
+	MaxFileSize int    `yaml:"max_file_size"` // Megabytes before a tap file is rotated
+	MaxBackups  int    `yaml:"max_backups"`
+	AllowTCP    bool   `yaml:"allow_tcp"`
+}
+
+// PassthroughConfig holds settings for handing ports to local programs such
+// as pppd as raw byte streams
+type PassthroughConfig struct {
+	Enabled  bool `yaml:"enabled"`
+	AllowTCP bool `yaml:"allow_tcp"` // Allow exposing ports on TCP listeners as well as terminals
+}
+
+// SocketMode returns the permissions of the unix socket file
+func (s ServerConfig) SocketMode() (os.FileMode, error) {
+	if s.UnixSocketMode == "" {
+		return 0660, nil
+	}
+	mode, err := strconv.ParseUint(s.UnixSocketMode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid unix_socket_mode: %s", s.UnixSocketMode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// AuditConfig holds settings for the audit log of port operations
+type AuditConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	File        string `yaml:"file"`
+	MaxFileSize int    `yaml:"max_file_size"` // Megabytes before the audit file is rotated
+	MaxBackups  int    `yaml:"max_backups"`
+}
+
+// HistoryConfig holds settings for the database of past sessions, tap
+// captures, and audit entries
+type HistoryConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	File          string `yaml:"file"`
+	RetentionDays int    `yaml:"retention_days"` // Records older than this are deleted (0 = keep forever)
+}
+
+// DiscoveryConfig holds settings for advertising the agent over mDNS
+type DiscoveryConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Name    string `yaml:"name"` // Instance name; defaults to the host name
+}
+
+// GPSDConfig holds the gpsd-compatible listener serving GPS receivers to
+// gpsd clients
+type GPSDConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Address string   `yaml:"address"`
+	Ports   []string `yaml:"ports"` // GPS port names or aliases
+}
+
+// KubernetesConfig holds settings for running the agent on Kubernetes nodes
+type KubernetesConfig struct {
+	DevicePlugin DevicePluginConfig `yaml:"device_plugin"`
+}
+
+// DevicePluginConfig advertises the node's serial ports to the kubelet as
+// allocatable resources
+type DevicePluginConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	ResourceName string `yaml:"resource_name"` // Resource pods request, e.g. baudlink.io/serial
+	SocketDir    string `yaml:"socket_dir"`    // Kubelet's device plugin directory
+}
+
+// FederationConfig lists remote agents whose ports this agent exposes
+type FederationConfig struct {
+	Remotes []RemoteAgentConfig `yaml:"remotes"`
+}
+
+// RemoteAgentConfig is a remote agent whose ports appear as <name>:<port>
+type RemoteAgentConfig struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	Token   string `yaml:"token"`
+	TLS     bool   `yaml:"tls"`
+	CAFile  string `yaml:"ca_file"` // Verify the remote's certificate with this CA instead of the system roots
+}
+
+// RateLimitsConfig holds per-client and per-port rate limits
+type RateLimitsConfig struct {
+	Client       RateLimit `yaml:"client"`        // Per client token (or address without auth)
+	Port         RateLimit `yaml:"port"`          // Per serial port, across all clients
+	SharedWriter RateLimit `yaml:"shared_writer"` // Per client on a port shared with allow_shared_access
+}
+
+// RateLimit is a request and byte rate; zero means unlimited
+type RateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	BytesPerSecond    float64 `yaml:"bytes_per_second"`
+}
+
+// IsLoopbackAddress reports whether a host:port listen address only accepts
+// connections from this machine
+func IsLoopbackAddress(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// DefaultConfig returns a configuration with sensible defaults, adjusted
+// for containers when ContainerEnv is 1
 func DefaultConfig() *Config {
-	return &Config{
+	cfg := &Config{
 		Server: ServerConfig{
 			GRPCAddress:       "0.0.0.0:50051",
+			UnixSocketMode:    "0660",
 			WebSocketAddress:  "0.0.0.0:8080",
 			WebSocketEnabled:  false,
 			MaxConnections:    100,
 			ConnectionTimeout: 30,
+			ShutdownGrace:     10,
+			MaxWriteBytes:     1024 * 1024,
+			MaxChunkSize:      64 * 1024,
 		},
 		TLS: TLSConfig{
-			Enabled: false,
+			Enabled:        false,
+			CertFile:       filepath.Join(DefaultDataDir(), "tls", "server.crt"),
+			KeyFile:        filepath.Join(DefaultDataDir(), "tls", "server.key"),
+			AutoGenerate:   true,
+			ReloadInterval: 60,
+			ACME: ACMEConfig{
+				Directory:   "https://acme-v02.api.letsencrypt.org/directory",
+				Challenge:   "http-01",
+				HTTPAddress: ":80",
+				DNSWait:     60,
+				AccountKey:  filepath.Join(DefaultDataDir(), "tls", "acme-account.key"),
+				RenewBefore: 30,
+			},
+		},
+		Auth: AuthConfig{
+			Enabled:    false,
+			MaxLinkTTL: 86400,
 		},
 		Serial: SerialConfig{
 			Defaults: SerialDefaults{
@@ -125,7 +511,18 @@ func DefaultConfig() *Config {
 				WriteTimeoutMs: 1000,
 			},
 			ScanInterval:      5,
+			Hotplug:           true,
 			AllowSharedAccess: false,
+			WriteQueueDepth:   64,
+			OpenRetry: OpenRetryConfig{
+				Attempts:   3,
+				DelayMs:    250,
+				MaxDelayMs: 2000,
+				Jitter:     0.2,
+			},
+			SystemLock: SystemLockConfig{
+				LockDir: "/var/lock",
+			},
 		},
 		Logging: LoggingConfig{
 			Level:      "info",
@@ -134,6 +531,9 @@ func DefaultConfig() *Config {
 			MaxBackups: 3,
 			MaxAge:     30,
 			Compress:   true,
+			CrashDumps: CrashDumpConfig{
+				Directory: filepath.Join(DefaultDataDir(), "crash"),
+			},
 		},
 		Service: ServiceConfig{
 			Name:          "baudlink",
@@ -142,16 +542,66 @@ func DefaultConfig() *Config {
 			AutoStart:     true,
 			RestartPolicy: "on-failure",
 			RestartDelay:  5,
+			User:          "baudlink",
+			Group:         "baudlink",
 		},
 		Metrics: MetricsConfig{
 			Enabled: false,
 			Address: "0.0.0.0:9090",
 			Path:    "/metrics",
 		},
+		Telemetry: TelemetryConfig{
+			Enabled:     false,
+			Endpoint:    "localhost:4317",
+			ServiceName: "baudlink",
+			SampleRatio: 1,
+		},
+		Files: FilesConfig{
+			Enabled:   false,
+			Address:   "0.0.0.0:8081",
+			Directory: filepath.Join(DefaultDataDir(), "files"),
+		},
+		Discovery: DiscoveryConfig{
+			Enabled: true,
+		},
+		GPSD: GPSDConfig{
+			Address: "127.0.0.1:2947",
+		},
+		Kubernetes: KubernetesConfig{
+			DevicePlugin: DevicePluginConfig{
+				ResourceName: "baudlink.io/serial",
+				SocketDir:    "/var/lib/kubelet/device-plugins",
+			},
+		},
+		Taps: TapsConfig{
+			Enabled:     false,
+			Directory:   filepath.Join(DefaultDataDir(), "files", "taps"),
+			MaxFileSize: 10,
+			MaxBackups:  5,
+			AllowTCP:    true,
+		},
+		Audit: AuditConfig{
+			Enabled:     false,
+			File:        filepath.Join(DefaultDataDir(), "audit", "audit.jsonl"),
+			MaxFileSize: 50,
+			MaxBackups:  10,
+		},
+		History: HistoryConfig{
+			Enabled:       false,
+			File:          filepath.Join(DefaultDataDir(), "history", "history.db"),
+			RetentionDays: 90,
+		},
+	}
+
+	if containerEnabled() {
+		cfg.applyContainerDefaults()
 	}
+	return cfg
 }
 
-// Load reads configuration from a YAML file
+// Load reads configuration from a YAML, JSON, or TOML file. The format is
+// taken from the file extension, or detected from the contents for other
+// extensions.
 func Load(path string) (*Config, error) {
 	cfg := DefaultConfig()
 
@@ -160,7 +610,8 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
+	cfg.format = DetectFormat(path, data)
+	if err := cfg.decode(data, cfg.format); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
@@ -182,9 +633,36 @@ func LoadOrDefault(path string) (*Config, error) {
 	return Load(path)
 }
 
-// Save writes configuration to a YAML file
+// Format returns the syntax the configuration was loaded from, YAML for
+// configurations that were not loaded from a file
+func (c *Config) Format() Format {
+	return c.format
+}
+
+// Save writes configuration to a file in the format given by its
+// extension, or in the format it was loaded from for other extensions
 func (c *Config) Save(path string) error {
-	data, err := yaml.Marshal(c)
+	return c.save(path, false)
+}
+
+// SavePrivate is Save for configurations holding credentials. The file is
+// written to a temporary file readable by its owner only, then renamed into
+// place, so the credentials are never readable by other users. A new file
+// gets mode 0600; an existing one keeps its owner, group, and permissions,
+// minus any access for others, so a service account reading it through its
+// group still can.
+func (c *Config) SavePrivate(path string) error {
+	return c.save(path, true)
+}
+
+// save writes the configuration for Save and SavePrivate
+func (c *Config) save(path string, private bool) error {
+	format, ok := FormatFromPath(path)
+	if !ok {
+		format = c.format
+	}
+
+	data, err := c.encode(format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -194,37 +672,331 @@ func (c *Config) Save(path string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if private {
+		err = writePrivate(path, data)
+	} else {
+		err = os.WriteFile(path, data, 0644)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// writePrivate replaces path with data through a temporary file created
+// with mode 0600
+func writePrivate(path string, data []byte) error {
+	existing, statErr := os.Stat(path)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if statErr == nil {
+		copyOwner(tmp.Name(), existing)
+		if err := os.Chmod(tmp.Name(), existing.Mode().Perm()&^0007); err != nil {
+			return err
+		}
+	} else if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	if c.Server.GRPCAddress == "" {
-		return fmt.Errorf("grpc_address is required")
+	if c.Server.GRPCAddress == "" && c.Server.UnixSocket == "" {
+		return fmt.Errorf("grpc_address or unix_socket is required")
+	}
+	if c.Server.UnixSocket != "" {
+		if _, err := c.Server.SocketMode(); err != nil {
+			return err
+		}
 	}
 
 	if c.Server.MaxConnections < 1 {
 		return fmt.Errorf("max_connections must be at least 1")
 	}
+	if c.Server.ShutdownGrace < 0 {
+		return fmt.Errorf("shutdown_grace_period must not be negative")
+	}
+	if c.Server.MaxWriteBytes < 1 || c.Server.MaxWriteBytes > maxGRPCMessage {
+		return fmt.Errorf("max_write_bytes must be between 1 and %d", maxGRPCMessage)
+	}
+	if c.Server.MaxChunkSize < 1 {
+		return fmt.Errorf("max_chunk_size must be at least 1")
+	}
 
 	if c.TLS.Enabled {
 		if c.TLS.CertFile == "" || c.TLS.KeyFile == "" {
 			return fmt.Errorf("TLS cert_file and key_file are required when TLS is enabled")
 		}
 	}
+	if c.TLS.ReloadInterval < 0 {
+		return fmt.Errorf("TLS reload_interval must not be negative")
+	}
+	if c.TLS.ACME.Enabled {
+		if len(c.TLS.ACME.Domains) == 0 {
+			return fmt.Errorf("ACME requires at least one domain")
+		}
+		switch c.TLS.ACME.Challenge {
+		case "http-01":
+		case "dns-01":
+			if c.TLS.ACME.DNSHook == "" {
+				return fmt.Errorf("ACME dns-01 challenges require a dns_hook")
+			}
+		default:
+			return fmt.Errorf("invalid ACME challenge: %s (must be http-01 or dns-01)", c.TLS.ACME.Challenge)
+		}
+		if c.TLS.ACME.AccountKey == "" {
+			return fmt.Errorf("ACME account_key is required")
+		}
+		if c.TLS.ACME.RenewBefore < 1 {
+			return fmt.Errorf("ACME renew_before must be at least 1 day")
+		}
+	}
+
+	if c.Auth.Enabled {
+		if len(c.Auth.Tokens) == 0 {
+			return fmt.Errorf("at least one token is required when auth is enabled")
+		}
+		for i, t := range c.Auth.Tokens {
+			if t.Name == "" || t.Token == "" {
+				return fmt.Errorf("auth token %d requires a name and token", i)
+			}
+			for _, role := range t.Roles {
+				if c.Auth.Role(role) == nil {
+					return fmt.Errorf("auth token %s references unknown role: %s", t.Name, role)
+				}
+			}
+		}
+	}
+
+	roles := make(map[string]bool)
+	for i, r := range c.Auth.Roles {
+		if r.Name == "" {
+			return fmt.Errorf("auth role %d requires a name", i)
+		}
+		if roles[r.Name] {
+			return fmt.Errorf("duplicate auth role: %s", r.Name)
+		}
+		roles[r.Name] = true
+
+		for _, p := range r.Ports {
+			if p.Name == "" {
+				return fmt.Errorf("auth role %s has a port entry without a name", r.Name)
+			}
+			if _, err := path.Match(p.Name, ""); err != nil {
+				return fmt.Errorf("auth role %s has an invalid port pattern %s: %w", r.Name, p.Name, err)
+			}
+			if p.Access != "" && p.Access != "read-only" && p.Access != "read-write" {
+				return fmt.Errorf("auth role %s has invalid access %q for port %s (must be read-only or read-write)", r.Name, p.Access, p.Name)
+			}
+		}
+	}
 
 	if c.Serial.Defaults.BaudRate < 1 {
 		return fmt.Errorf("baud_rate must be positive")
 	}
 
+	if err := c.Serial.Defaults.validate(); err != nil {
+		return err
+	}
+
+	if c.Serial.IdleTimeout < 0 {
+		return fmt.Errorf("idle_timeout must not be negative")
+	}
+
+	if err := c.validateProfiles(); err != nil {
+		return err
+	}
+
+	if err := c.validateAliases(); err != nil {
+		return err
+	}
+
+	if err := c.validateGroups(); err != nil {
+		return err
+	}
+
+	if err := c.validateRules(); err != nil {
+		return err
+	}
+
+	if err := c.validateJobs(); err != nil {
+		return err
+	}
+
+	if err := c.validateDataLog(); err != nil {
+		return err
+	}
+
+	for i, f := range c.Serial.Exclude {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("serial exclude rule %d: %w", i, err)
+		}
+	}
+	for i, f := range c.Serial.Include {
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("serial include rule %d: %w", i, err)
+		}
+	}
+
+	managed := make(map[string]bool)
+	for i, mp := range c.Serial.ManagedPorts {
+		if mp.Port == "" {
+			return fmt.Errorf("managed port %d requires a port", i)
+		}
+		if managed[mp.Port] {
+			return fmt.Errorf("duplicate managed port: %s", mp.Port)
+		}
+		managed[mp.Port] = true
+
+		if err := mp.Settings.validate(); err != nil {
+			return fmt.Errorf("managed port %s: %w", mp.Port, err)
+		}
+	}
+
+	network := make(map[string]bool)
+	for i, np := range c.Serial.NetworkPorts {
+		if err := np.validate(); err != nil {
+			return fmt.Errorf("network port %d: %w", i, err)
+		}
+		if network[np.Address] {
+			return fmt.Errorf("duplicate network port: %s", np.Address)
+		}
+		network[np.Address] = true
+	}
+
+	retry := c.Serial.OpenRetry
+	if retry.Attempts < 0 || retry.DelayMs < 0 || retry.MaxDelayMs < 0 {
+		return fmt.Errorf("open_retry values must not be negative")
+	}
+	if retry.Jitter < 0 || retry.Jitter > 1 {
+		return fmt.Errorf("open_retry jitter must be between 0 and 1")
+	}
+
+	rateLimits := map[string]RateLimit{
+		"client":        c.RateLimits.Client,
+		"port":          c.RateLimits.Port,
+		"shared_writer": c.RateLimits.SharedWriter,
+	}
+	for name, rl := range rateLimits {
+		if rl.RequestsPerSecond < 0 || rl.BytesPerSecond < 0 {
+			return fmt.Errorf("%s rate limits must not be negative", name)
+		}
+	}
+
+	if c.Taps.Enabled && c.Taps.Directory == "" {
+		return fmt.Errorf("taps directory is required when taps are enabled")
+	}
+	if c.Taps.MaxFileSize < 0 || c.Taps.MaxBackups < 0 {
+		return fmt.Errorf("taps max_file_size and max_backups must not be negative")
+	}
+
+	remotes := make(map[string]bool)
+	for i, r := range c.Federation.Remotes {
+		if r.Name == "" || r.Address == "" {
+			return fmt.Errorf("federation remote %d requires a name and address", i)
+		}
+		if strings.ContainsAny(r.Name, ":/\\") {
+			return fmt.Errorf("federation remote name %s must not contain ':', '/', or '\\'", r.Name)
+		}
+		if remotes[r.Name] {
+			return fmt.Errorf("duplicate federation remote: %s", r.Name)
+		}
+		remotes[r.Name] = true
+	}
+
+	if c.Audit.Enabled && c.Audit.File == "" {
+		return fmt.Errorf("audit file is required when auditing is enabled")
+	}
+	if c.Audit.MaxFileSize < 0 || c.Audit.MaxBackups < 0 {
+		return fmt.Errorf("audit max_file_size and max_backups must not be negative")
+	}
+
+	if c.History.Enabled && c.History.File == "" {
+		return fmt.Errorf("history file is required when history is enabled")
+	}
+	if c.History.RetentionDays < 0 {
+		return fmt.Errorf("history retention_days must not be negative")
+	}
+
+	if c.Telemetry.Enabled && c.Telemetry.Endpoint == "" {
+		return fmt.Errorf("telemetry endpoint is required when telemetry is enabled")
+	}
+	if c.Telemetry.SampleRatio < 0 || c.Telemetry.SampleRatio > 1 {
+		return fmt.Errorf("telemetry sample_ratio must be between 0 and 1")
+	}
+
+	if c.GPSD.Enabled && len(c.GPSD.Ports) == 0 {
+		return fmt.Errorf("gpsd requires at least one port when enabled")
+	}
+
+	if dp := c.Kubernetes.DevicePlugin; dp.Enabled {
+		domain, name, ok := strings.Cut(dp.ResourceName, "/")
+		if !ok || name == "" || !strings.Contains(domain, ".") {
+			return fmt.Errorf("invalid kubernetes device_plugin resource_name: %s (must be like baudlink.io/serial)", dp.ResourceName)
+		}
+		if dp.SocketDir == "" {
+			return fmt.Errorf("kubernetes device_plugin socket_dir is required when the device plugin is enabled")
+		}
+	}
+
+	if c.Files.Enabled && c.Files.Directory == "" {
+		return fmt.Errorf("files directory is required when the file endpoint is enabled")
+	}
+	// Downloads carry bearer tokens and capture contents
+	if c.Files.Enabled && !c.TLS.Enabled && !IsLoopbackAddress(c.Files.Address) {
+		return fmt.Errorf("files address %s must be a loopback address when TLS is disabled", c.Files.Address)
+	}
+
+	if sl := c.Logging.Syslog; sl.Enabled {
+		switch sl.Network {
+		case "":
+		case "udp", "tcp":
+			if sl.Address == "" {
+				return fmt.Errorf("logging syslog address is required for network %s", sl.Network)
+			}
+		default:
+			return fmt.Errorf("invalid logging syslog network: %s (must be udp, tcp, or empty for local)", sl.Network)
+		}
+		switch strings.ToLower(sl.Format) {
+		case "", "rfc5424", "rfc3164":
+		default:
+			return fmt.Errorf("invalid logging syslog format: %s (must be rfc5424 or rfc3164)", sl.Format)
+		}
+	}
+
+	if c.Logging.CrashDumps.Enabled && c.Logging.CrashDumps.Directory == "" {
+		return fmt.Errorf("logging crash_dumps directory is required when crash dumps are enabled")
+	}
+
 	validLogLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !validLogLevels[strings.ToLower(c.Logging.Level)] {
 		return fmt.Errorf("invalid log level: %s", c.Logging.Level)
 	}
+	switch strings.ToLower(c.Logging.Format) {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("invalid log format: %s (must be text or json)", c.Logging.Format)
+	}
 
 	return nil
 }
@@ -259,3 +1031,16 @@ func DefaultConfigPath() string {
 		return "/etc/baudlink/agent.yaml"
 	}
 }
+
+// DefaultDataDir returns the default directory for agent data such as
+// captures and recordings for the current OS
+func DefaultDataDir() string {
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("ProgramData"), "BaudLink")
+	case "darwin":
+		return "/usr/local/var/baudlink"
+	default:
+		return "/var/lib/baudlink"
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// ContainerEnv selects container mode: 1 enables it and bakes the container
+// defaults into DefaultConfig, 0 turns off its detection
+const ContainerEnv = "BAUDLINK_CONTAINER"
+
+// containerMarkers are files container runtimes create in their containers
+var containerMarkers = []string{"/.dockerenv", "/run/.containerenv"}
+
+// InContainer reports whether the agent runs in a container: ContainerEnv
+// is 1, or it is unset and the container runtime or Kubernetes left its
+// marks on the environment
+func InContainer() bool {
+	switch strings.ToLower(os.Getenv(ContainerEnv)) {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	}
+
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" || os.Getenv("container") != "" {
+		return true
+	}
+	for _, path := range containerMarkers {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// containerEnabled reports whether ContainerEnv asks for container defaults
+func containerEnabled() bool {
+	v := strings.ToLower(os.Getenv(ContainerEnv))
+	return v == "1" || v == "true"
+}
+
+// applyContainerDefaults adjusts the defaults for running in a container:
+// JSON logs for the runtime's log collector, a short shutdown drain so the
+// agent exits well within the runtime's stop timeout, no mDNS, which does
+// not cross container networks, and faster polling for ports, as kernel
+// device events rarely reach containers
+func (c *Config) applyContainerDefaults() {
+	c.Logging.Format = "json"
+	c.Server.ShutdownGrace = 3
+	c.Discovery.Enabled = false
+	c.Serial.ScanInterval = 2
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// DataLogConfig holds the data logger writing values parsed from received
+// data to time-series databases
+type DataLogConfig struct {
+	FlushIntervalMs int                 `yaml:"flush_interval_ms"` // How often points are written (default 10000)
+	Sinks           []DataSinkConfig    `yaml:"sinks"`
+	Ports           []DataLogPortConfig `yaml:"ports"`
+}
+
+// DataSinkConfig is a time-series database points are written to
+type DataSinkConfig struct {
+	Name  string `yaml:"name"`
+	Type  string `yaml:"type"`  // influxdb or prometheus
+	URL   string `yaml:"url"`   // Write endpoint
+	Token string `yaml:"token"` // InfluxDB token or bearer token (optional)
+}
+
+// DataLogPortConfig parses the data received on a port into points.
+// Exactly one of Match and Modbus must be set.
+type DataLogPortConfig struct {
+	Port          string            `yaml:"port"` // Port name or alias
+	Measurement   string            `yaml:"measurement"`
+	Tags          map[string]string `yaml:"tags"`
+	Sinks         []string          `yaml:"sinks"`          // Sink names (empty = every sink)
+	Match         string            `yaml:"match"`          // Regular expression matched against each line
+	Modbus        bool              `yaml:"modbus"`         // Parse Modbus RTU read register responses
+	ModbusAddress int               `yaml:"modbus_address"` // Only parse responses from this slave (0 = any)
+	Fields        []DataFieldConfig `yaml:"fields"`         // match: default every named group
+}
+
+// DataFieldConfig extracts one value from a line or Modbus response
+type DataFieldConfig struct {
+	Name     string  `yaml:"name"`
+	Group    string  `yaml:"group"`     // match: capture group number or name (default: name)
+	Register int     `yaml:"register"`  // modbus: register offset within the response
+	Type     string  `yaml:"type"`      // modbus: uint16 (default), int16, uint32, int32, float32
+	WordSwap bool    `yaml:"word_swap"` // modbus: low word first for 32-bit types
+	Scale    float64 `yaml:"scale"`     // Multiplier (default 1)
+}
+
+// validateDataLog checks data log sinks and ports
+func (c *Config) validateDataLog() error {
+	d := c.DataLog
+	if d.FlushIntervalMs < 0 {
+		return fmt.Errorf("datalog flush_interval_ms must not be negative")
+	}
+
+	sinks := make(map[string]bool)
+	for i, s := range d.Sinks {
+		if s.Name == "" {
+			return fmt.Errorf("datalog sink %d requires a name", i)
+		}
+		if sinks[s.Name] {
+			return fmt.Errorf("duplicate datalog sink name: %s", s.Name)
+		}
+		sinks[s.Name] = true
+
+		if s.Type != "influxdb" && s.Type != "prometheus" {
+			return fmt.Errorf("datalog sink %s has unknown type: %q", s.Name, s.Type)
+		}
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("datalog sink %s requires an http or https url", s.Name)
+		}
+	}
+
+	if len(d.Ports) > 0 && len(d.Sinks) == 0 {
+		return fmt.Errorf("datalog ports require at least one sink")
+	}
+
+	for i, p := range d.Ports {
+		if p.Port == "" {
+			return fmt.Errorf("datalog port %d requires a port", i)
+		}
+		if p.Measurement == "" {
+			return fmt.Errorf("datalog port %s requires a measurement", p.Port)
+		}
+		for _, name := range p.Sinks {
+			if !sinks[name] {
+				return fmt.Errorf("datalog port %s has unknown sink: %s", p.Port, name)
+			}
+		}
+		if err := p.validateFields(); err != nil {
+			return fmt.Errorf("datalog port %s: %w", p.Port, err)
+		}
+	}
+
+	return nil
+}
+
+// validateFields checks that the fields can be read from a line or response
+func (p DataLogPortConfig) validateFields() error {
+	if (p.Match == "") == !p.Modbus {
+		return fmt.Errorf("exactly one of match or modbus is required")
+	}
+
+	if p.Match != "" {
+		re, err := regexp.Compile(p.Match)
+		if err != nil {
+			return fmt.Errorf("invalid match: %w", err)
+		}
+		if len(p.Fields) == 0 && !slices.ContainsFunc(re.SubexpNames(), func(name string) bool { return name != "" }) {
+			return fmt.Errorf("match has no named groups and no fields are given")
+		}
+		for _, f := range p.Fields {
+			group := f.Group
+			if group == "" {
+				group = f.Name
+			}
+			if n, err := strconv.Atoi(group); err == nil {
+				if n < 1 || n > re.NumSubexp() {
+					return fmt.Errorf("field %s: match has no group %d", f.Name, n)
+				}
+			} else if re.SubexpIndex(group) < 0 {
+				return fmt.Errorf("field %s: match has no group %s", f.Name, group)
+			}
+		}
+		return nil
+	}
+
+	if len(p.Fields) == 0 {
+		return fmt.Errorf("modbus requires fields")
+	}
+	if p.ModbusAddress < 0 || p.ModbusAddress > 247 {
+		return fmt.Errorf("modbus_address must be between 0 and 247")
+	}
+	for _, f := range p.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("field requires a name")
+		}
+		if f.Register < 0 || f.Register > 124 {
+			return fmt.Errorf("field %s register must be between 0 and 124", f.Name)
+		}
+		switch f.Type {
+		case "", "uint16", "int16", "uint32", "int32", "float32":
+		default:
+			return fmt.Errorf("field %s has unknown type: %q", f.Name, f.Type)
+		}
+	}
+	return nil
+}
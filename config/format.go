@@ -0,0 +1,201 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the syntax of a configuration file
+type Format int
+
+const (
+	// FormatYAML is the default configuration syntax
+	FormatYAML Format = iota
+	// FormatJSON is JSON, parsed as the YAML subset it is
+	FormatJSON
+	// FormatTOML is TOML, with tables named after the YAML sections
+	FormatTOML
+)
+
+// String returns the lowercase name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatTOML:
+		return "toml"
+	default:
+		return "yaml"
+	}
+}
+
+// tomlLine matches TOML table headers and key assignments
+var tomlLine = regexp.MustCompile(`^\s*(\[\[?[\w.\-" ]+\]\]?|[\w\-"]+\s*=)`)
+
+// FormatFromPath returns the format implied by a file extension
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".json":
+		return FormatJSON, true
+	case ".toml":
+		return FormatTOML, true
+	default:
+		return FormatYAML, false
+	}
+}
+
+// DetectFormat returns the format of a file from its extension or, for
+// unknown extensions, by sniffing its first significant line
+func DetectFormat(path string, data []byte) Format {
+	if format, ok := FormatFromPath(path); ok {
+		return format
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return FormatJSON
+		case tomlLine.MatchString(line):
+			return FormatTOML
+		}
+		break
+	}
+	return FormatYAML
+}
+
+// toYAML converts configuration data to YAML. YAML and JSON are returned
+// unchanged since JSON parses as YAML.
+func toYAML(data []byte, format Format) ([]byte, error) {
+	if format != FormatTOML {
+		return data, nil
+	}
+
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// decode parses configuration data in the given format into c
+func (c *Config) decode(data []byte, format Format) error {
+	data, err := toYAML(data, format)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, c)
+}
+
+// encode marshals the configuration in the given format
+func (c *Config) encode(format Format) ([]byte, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil || format == FormatYAML {
+		return data, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if format == FormatTOML {
+		var m map[string]interface{}
+		if err := doc.Decode(&m); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		enc := toml.NewEncoder(&buf)
+		enc.Indent = ""
+		if err := enc.Encode(m); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	// Write JSON from the YAML nodes so keys keep the struct's order
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, doc.Content[0]); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// writeJSON writes a YAML node as compact JSON
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	default:
+		return fmt.Errorf("cannot encode YAML node kind %d as JSON", node.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import "fmt"
+
+// GroupConfig names a set of ports that can be opened, written, and
+// streamed together
+type GroupConfig struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Ports       []string `yaml:"ports"` // Port names or aliases
+}
+
+// validateGroups checks group names and members
+func (c *Config) validateGroups() error {
+	seen := make(map[string]bool)
+
+	for i, g := range c.Groups {
+		if g.Name == "" {
+			return fmt.Errorf("group %d requires a name", i)
+		}
+		if seen[g.Name] {
+			return fmt.Errorf("duplicate group name: %s", g.Name)
+		}
+		seen[g.Name] = true
+
+		if len(g.Ports) == 0 {
+			return fmt.Errorf("group %s requires at least one port", g.Name)
+		}
+		members := make(map[string]bool)
+		for _, port := range g.Ports {
+			if port == "" {
+				return fmt.Errorf("group %s has an empty port name", g.Name)
+			}
+			if members[port] {
+				return fmt.Errorf("group %s lists port %s twice", g.Name, port)
+			}
+			members[port] = true
+		}
+	}
+
+	return nil
+}
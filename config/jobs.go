@@ -0,0 +1,83 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/robfig/cron/v3"
+)
+
+// JobConfig periodically runs a transaction against a port. Exactly one of
+// Request and RequestHex must be set.
+type JobConfig struct {
+	Name          string         `yaml:"name"`
+	Port          string         `yaml:"port"`        // Port name or alias
+	Schedule      string         `yaml:"schedule"`    // Cron expression, or e.g. @every 60s
+	Request       string         `yaml:"request"`     // Text written to the port
+	RequestHex    string         `yaml:"request_hex"` // Bytes written to the port, in hex
+	Terminator    string         `yaml:"terminator"`  // Response is complete once this is seen
+	Pattern       string         `yaml:"pattern"`     // Response is complete once this matches
+	ExpectedBytes int            `yaml:"expected_bytes"`
+	TimeoutMs     int            `yaml:"timeout_ms"`
+	FlushInput    bool           `yaml:"flush_input"`
+	Settings      SerialDefaults `yaml:"settings"` // Used when the job opens the port; unset fields fall back to serial.defaults
+}
+
+// validateJobs checks job schedules and requests
+func (c *Config) validateJobs() error {
+	seen := make(map[string]bool)
+
+	for i, j := range c.Jobs {
+		if j.Name == "" {
+			return fmt.Errorf("job %d requires a name", i)
+		}
+		if seen[j.Name] {
+			return fmt.Errorf("duplicate job name: %s", j.Name)
+		}
+		seen[j.Name] = true
+
+		if j.Port == "" {
+			return fmt.Errorf("job %s requires a port", j.Name)
+		}
+		if _, err := cron.ParseStandard(j.Schedule); err != nil {
+			return fmt.Errorf("job %s has an invalid schedule: %w", j.Name, err)
+		}
+		if (j.Request == "") == (j.RequestHex == "") {
+			return fmt.Errorf("job %s requires exactly one of request or request_hex", j.Name)
+		}
+		if _, err := hex.DecodeString(j.RequestHex); err != nil {
+			return fmt.Errorf("job %s has an invalid request_hex: %w", j.Name, err)
+		}
+		if _, err := regexp.Compile(j.Pattern); err != nil {
+			return fmt.Errorf("job %s has an invalid pattern: %w", j.Name, err)
+		}
+		if j.Terminator == "" && j.Pattern == "" && j.ExpectedBytes <= 0 {
+			return fmt.Errorf("job %s requires a terminator, pattern, or expected_bytes", j.Name)
+		}
+		if j.ExpectedBytes < 0 || j.TimeoutMs < 0 {
+			return fmt.Errorf("job %s expected_bytes and timeout_ms must not be negative", j.Name)
+		}
+		if err := j.Settings.validate(); err != nil {
+			return fmt.Errorf("job %s: %w", j.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies a lint finding
+type Severity int
+
+const (
+	// SeverityWarning marks a setting that loads but is probably wrong
+	SeverityWarning Severity = iota
+	// SeverityError marks a setting that stops the agent from starting
+	SeverityError
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Finding is a problem found while linting a configuration file
+type Finding struct {
+	Severity Severity
+	Line     int    // Line in the file, 0 if unknown
+	Key      string // Dotted path of the setting, e.g. serial.exclude_patterns[0]
+	Message  string
+}
+
+// String formats the finding as "severity: key: message"
+func (f Finding) String() string {
+	var b strings.Builder
+	b.WriteString(f.Severity.String())
+	b.WriteString(": ")
+	if f.Key != "" {
+		b.WriteString(f.Key)
+		b.WriteString(": ")
+	}
+	b.WriteString(f.Message)
+	return b.String()
+}
+
+var (
+	// yamlLine matches the line number in yaml.v3 error messages
+	yamlLine = regexp.MustCompile(`line (\d+): `)
+
+	// yamlUnknownField matches yaml.v3 errors for keys without a field
+	yamlUnknownField = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// linter collects findings for one file
+type linter struct {
+	root     *yaml.Node
+	findings []Finding
+
+	// noLines is set when root was converted from another format, so its
+	// line numbers do not match the file
+	noLines bool
+}
+
+// Lint reads a configuration file and reports problems with it, including
+// ones that Load accepts but that only surface once the agent starts, such
+// as address collisions, missing TLS files, and bad regular expressions.
+// Environment overrides are not applied.
+func Lint(path string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return LintBytes(data, DetectFormat(path, data)), nil
+}
+
+// LintBytes lints configuration file contents in the given format. Line
+// numbers are only reported for TOML syntax errors.
+func LintBytes(data []byte, format Format) []Finding {
+	l := &linter{root: &yaml.Node{}, noLines: format == FormatTOML}
+
+	data, err := toYAML(data, format)
+	if err != nil {
+		f := Finding{Severity: SeverityError, Message: err.Error()}
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			f.Line = parseErr.Position.Line
+			f.Message = parseErr.Message
+		}
+		l.findings = append(l.findings, f)
+		return l.findings
+	}
+
+	if err := yaml.Unmarshal(data, l.root); err != nil {
+		l.yamlError(err, SeverityError)
+		return l.findings
+	}
+
+	cfg := DefaultConfig()
+	if err := l.root.Decode(cfg); err != nil {
+		l.yamlError(err, SeverityError)
+		return l.findings
+	}
+
+	// Decode again rejecting unknown keys, which Load silently ignores
+	strict := yaml.NewDecoder(strings.NewReader(string(data)))
+	strict.KnownFields(true)
+	if err := strict.Decode(DefaultConfig()); err != nil && !errors.Is(err, io.EOF) {
+		l.yamlError(err, SeverityWarning)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		l.add(SeverityError, nil, "%v", err)
+	}
+
+	l.checkAddresses(cfg)
+	l.checkTLS(cfg)
+	l.checkAuth(cfg)
+	l.checkSerial(cfg)
+	l.checkProfiles(cfg)
+	l.checkFederation(cfg)
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return l.findings[i].Line < l.findings[j].Line
+	})
+	return l.findings
+}
+
+// add records a finding for the setting at key, a sequence of mapping keys
+// and sequence indexes
+func (l *linter) add(severity Severity, key []interface{}, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{
+		Severity: severity,
+		Line:     l.line(key),
+		Key:      formatKey(key),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// yamlError records the messages of a yaml decoding error
+func (l *linter) yamlError(err error, severity Severity) {
+	var typeErr *yaml.TypeError
+	msgs := []string{err.Error()}
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	}
+
+	for _, msg := range msgs {
+		msg = strings.TrimPrefix(msg, "yaml: ")
+		f := Finding{Severity: severity, Message: msg}
+		if m := yamlLine.FindStringSubmatchIndex(msg); m != nil {
+			if !l.noLines {
+				f.Line, _ = strconv.Atoi(msg[m[2]:m[3]])
+			}
+			f.Message = msg[:m[0]] + msg[m[1]:]
+		}
+		if m := yamlUnknownField.FindStringSubmatch(f.Message); m != nil {
+			f.Message = "unknown key " + m[1]
+		}
+		l.findings = append(l.findings, f)
+	}
+}
+
+// line returns the line of the deepest node along key that exists in the
+// file, or 0 if the file does not set any of it
+func (l *linter) line(key []interface{}) int {
+	if l.noLines {
+		return 0
+	}
+
+	node := l.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	line := 0
+	for _, k := range key {
+		var next *yaml.Node
+		switch k := k.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == k {
+						line = node.Content[i].Line
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && k < len(node.Content) {
+				next = node.Content[k]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
+
+// formatKey renders a key path as dotted notation with indexes
+func formatKey(key []interface{}) string {
+	var b strings.Builder
+	for _, k := range key {
+		switch k := k.(type) {
+		case string:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(k)
+		case int:
+			fmt.Fprintf(&b, "[%d]", k)
+		}
+	}
+	return b.String()
+}
+
+// listener is a TCP address the agent binds
+type listener struct {
+	key     []interface{}
+	address string
+}
+
+// checkAddresses reports malformed listen addresses and listeners that
+// would collide on the same port
+func (l *linter) checkAddresses(c *Config) {
+	var listeners []listener
+	if c.Server.GRPCAddress != "" {
+		listeners = append(listeners, listener{[]interface{}{"server", "grpc_address"}, c.Server.GRPCAddress})
+	}
+	if c.Metrics.Enabled {
+		listeners = append(listeners, listener{[]interface{}{"metrics", "address"}, c.Metrics.Address})
+	}
+	if c.Files.Enabled {
+		listeners = append(listeners, listener{[]interface{}{"files", "address"}, c.Files.Address})
+	}
+	if c.GPSD.Enabled {
+		listeners = append(listeners, listener{[]interface{}{"gpsd", "address"}, c.GPSD.Address})
+	}
+	if c.TLS.Enabled && c.TLS.ACME.Enabled && c.TLS.ACME.Challenge == "http-01" {
+		listeners = append(listeners, listener{[]interface{}{"tls", "acme", "http_address"}, c.TLS.ACME.HTTPAddress})
+	}
+
+	type bound struct {
+		listener
+		host string
+	}
+	ports := make(map[string][]bound)
+	for _, ln := range listeners {
+		host, port, err := net.SplitHostPort(ln.address)
+		if err != nil {
+			l.add(SeverityError, ln.key, "invalid address %q: %v", ln.address, err)
+			continue
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+			l.add(SeverityError, ln.key, "invalid port %q", port)
+			continue
+		}
+
+		for _, other := range ports[port] {
+			if hostsOverlap(host, other.host) {
+				l.add(SeverityError, ln.key, "address %s collides with %s (%s)", ln.address, formatKey(other.key), other.address)
+			}
+		}
+		ports[port] = append(ports[port], bound{ln, host})
+	}
+}
+
+// hostsOverlap reports whether listeners on two hosts would conflict on the
+// same port
+func hostsOverlap(a, b string) bool {
+	wildcard := func(h string) bool { return h == "" || h == "0.0.0.0" || h == "::" }
+	return wildcard(a) || wildcard(b) || strings.EqualFold(a, b)
+}
+
+// checkTLS reports missing certificate files, mismatched key pairs, and
+// expired certificates
+func (l *linter) checkTLS(c *Config) {
+	if !c.TLS.Enabled {
+		return
+	}
+
+	certKey := []interface{}{"tls", "cert_file"}
+	keyKey := []interface{}{"tls", "key_file"}
+	certMissing := !fileExists(c.TLS.CertFile)
+	keyMissing := !fileExists(c.TLS.KeyFile)
+
+	switch {
+	case certMissing && c.TLS.ACME.Enabled:
+		l.add(SeverityWarning, certKey, "%s does not exist; it will be obtained from the ACME CA at startup", c.TLS.CertFile)
+	case certMissing && c.TLS.AutoGenerate:
+		l.add(SeverityWarning, certKey, "%s does not exist; a self-signed certificate will be generated at startup", c.TLS.CertFile)
+	case certMissing:
+		l.add(SeverityError, certKey, "%s does not exist", c.TLS.CertFile)
+	case keyMissing:
+		l.add(SeverityError, keyKey, "%s does not exist", c.TLS.KeyFile)
+	default:
+		pair, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			l.add(SeverityError, certKey, "certificate and key do not load: %v", err)
+			break
+		}
+		leaf, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			l.add(SeverityError, certKey, "invalid certificate: %v", err)
+			break
+		}
+		if time.Now().After(leaf.NotAfter) {
+			l.add(SeverityWarning, certKey, "certificate expired on %s", leaf.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	if c.TLS.CAFile != "" && !fileExists(c.TLS.CAFile) {
+		l.add(SeverityError, []interface{}{"tls", "ca_file"}, "%s does not exist", c.TLS.CAFile)
+	}
+}
+
+// checkAuth reports risky authentication settings
+func (l *linter) checkAuth(c *Config) {
+	if !c.Auth.Enabled {
+		return
+	}
+
+	if !c.TLS.Enabled && c.Server.GRPCAddress != "" && !IsLoopbackAddress(c.Server.GRPCAddress) {
+		l.add(SeverityWarning, []interface{}{"auth", "enabled"}, "tokens are sent in plain text because TLS is disabled")
+	}
+
+	for i, t := range c.Auth.Tokens {
+		if t.Token != "" && len(t.Token) < 16 {
+			l.add(SeverityWarning, []interface{}{"auth", "tokens", i, "token"}, "token %s is shorter than 16 characters", t.Name)
+		}
+	}
+}
+
+// checkSerial reports exclude patterns that do not compile and managed
+// ports without settings that can open them
+func (l *linter) checkSerial(c *Config) {
+	for i, pattern := range c.Serial.ExcludePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			l.add(SeverityError, []interface{}{"serial", "exclude_patterns", i}, "invalid regular expression %q: %v", pattern, err)
+		}
+	}
+
+	l.checkLineSettings([]interface{}{"serial", "defaults"}, c.Serial.Defaults)
+	for i, mp := range c.Serial.ManagedPorts {
+		l.checkLineSettings([]interface{}{"serial", "managed_ports", i, "settings"}, mp.Settings)
+	}
+}
+
+// checkProfiles reports profiles that can never apply or have no effect
+func (l *linter) checkProfiles(c *Config) {
+	for i, p := range c.Profiles {
+		key := []interface{}{"profiles", i}
+		if p.Settings.IsEmpty() && p.Alias == "" {
+			l.add(SeverityWarning, key, "profile %s sets neither settings nor an alias", p.Name)
+		}
+		for j := 0; j < i; j++ {
+			if !p.Match.IsEmpty() && c.Profiles[j].Match == p.Match {
+				l.add(SeverityWarning, append(key, "match"), "profile %s is shadowed by profile %s, which has the same match", p.Name, c.Profiles[j].Name)
+				break
+			}
+		}
+		l.checkLineSettings(append(key, "settings"), p.Settings)
+	}
+}
+
+// checkLineSettings reports numeric line settings the serial driver rejects
+func (l *linter) checkLineSettings(key []interface{}, s SerialDefaults) {
+	if s.DataBits != 0 && (s.DataBits < 5 || s.DataBits > 8) {
+		l.add(SeverityError, append(key, "data_bits"), "data_bits must be between 5 and 8")
+	}
+	if s.StopBits != 0 && s.StopBits != 1 && s.StopBits != 2 {
+		l.add(SeverityError, append(key, "stop_bits"), "stop_bits must be 1 or 2")
+	}
+}
+
+// checkFederation reports remote CA files that do not exist
+func (l *linter) checkFederation(c *Config) {
+	for i, r := range c.Federation.Remotes {
+		if r.CAFile != "" && !fileExists(r.CAFile) {
+			l.add(SeverityError, []interface{}{"federation", "remotes", i, "ca_file"}, "%s does not exist", r.CAFile)
+		}
+	}
+}
+
+// fileExists reports whether path names an existing file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
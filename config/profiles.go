@@ -0,0 +1,222 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// ProfileConfig maps a device identity to port settings
+type ProfileConfig struct {
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Alias       string         `yaml:"alias"`
+	Match       DeviceMatch    `yaml:"match"`
+	Settings    SerialDefaults `yaml:"settings"` // Unset fields fall back to serial.defaults
+}
+
+// AliasConfig gives a device a stable friendly name that can be used
+// anywhere a port name is accepted
+type AliasConfig struct {
+	Name  string      `yaml:"name"`
+	Match DeviceMatch `yaml:"match"`
+}
+
+// DeviceMatch selects devices by USB identity or port name. All non-empty
+// fields must match.
+type DeviceMatch struct {
+	VID          string `yaml:"vid"`
+	PID          string `yaml:"pid"`
+	SerialNumber string `yaml:"serial_number"`
+	Name         string `yaml:"name"` // Glob matched against the port name, e.g. "/dev/ttyACM*"
+}
+
+// DeviceIdentity describes a discovered device for matching
+type DeviceIdentity struct {
+	Name         string
+	VID          string
+	PID          string
+	SerialNumber string
+}
+
+// IsEmpty reports whether the matcher has no criteria
+func (m DeviceMatch) IsEmpty() bool {
+	return m.VID == "" && m.PID == "" && m.SerialNumber == "" && m.Name == ""
+}
+
+// Matches reports whether a device satisfies every criterion of the matcher.
+// An empty matcher matches nothing.
+func (m DeviceMatch) Matches(d DeviceIdentity) bool {
+	if m.IsEmpty() {
+		return false
+	}
+	if m.VID != "" && !strings.EqualFold(m.VID, d.VID) {
+		return false
+	}
+	if m.PID != "" && !strings.EqualFold(m.PID, d.PID) {
+		return false
+	}
+	if m.SerialNumber != "" && m.SerialNumber != d.SerialNumber {
+		return false
+	}
+	if m.Name != "" {
+		if ok, _ := path.Match(m.Name, d.Name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks the matcher's glob pattern
+func (m DeviceMatch) validate() error {
+	if m.IsEmpty() {
+		return fmt.Errorf("match requires at least one of vid, pid, serial_number, or name")
+	}
+	if m.Name != "" {
+		if _, err := path.Match(m.Name, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// MatchProfile returns the first profile matching the device, or nil
+func (c *Config) MatchProfile(d DeviceIdentity) *ProfileConfig {
+	for i := range c.Profiles {
+		if c.Profiles[i].Match.Matches(d) {
+			return &c.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// AllAliases returns the configured aliases together with the aliases
+// declared by profiles
+func (c *Config) AllAliases() []AliasConfig {
+	aliases := append([]AliasConfig(nil), c.Aliases...)
+	for _, p := range c.Profiles {
+		if p.Alias != "" {
+			aliases = append(aliases, AliasConfig{Name: p.Alias, Match: p.Match})
+		}
+	}
+	return aliases
+}
+
+// validateAliases checks alias names and matchers
+func (c *Config) validateAliases() error {
+	seen := make(map[string]bool)
+
+	for i, a := range c.AllAliases() {
+		if a.Name == "" {
+			return fmt.Errorf("alias %d requires a name", i)
+		}
+		if seen[a.Name] {
+			return fmt.Errorf("duplicate alias: %s", a.Name)
+		}
+		seen[a.Name] = true
+
+		if err := a.Match.validate(); err != nil {
+			return fmt.Errorf("alias %s: %w", a.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateProfiles checks profile names, matchers, and settings
+func (c *Config) validateProfiles() error {
+	seen := make(map[string]bool)
+
+	for i, p := range c.Profiles {
+		if p.Name == "" {
+			return fmt.Errorf("profile %d requires a name", i)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate profile name: %s", p.Name)
+		}
+		seen[p.Name] = true
+
+		if err := p.Match.validate(); err != nil {
+			return fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+		if err := p.Settings.validate(); err != nil {
+			return fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether no setting is set
+func (d SerialDefaults) IsEmpty() bool {
+	return reflect.ValueOf(d).IsZero()
+}
+
+// validate checks string-valued serial settings; zero values are allowed
+func (d SerialDefaults) validate() error {
+	switch strings.ToLower(d.Parity) {
+	case "", "none", "odd", "even", "mark", "space":
+	default:
+		return fmt.Errorf("invalid parity: %s", d.Parity)
+	}
+
+	switch strings.ToLower(d.FlowControl) {
+	case "", "none", "hardware", "software":
+	default:
+		return fmt.Errorf("invalid flow_control: %s", d.FlowControl)
+	}
+
+	if d.XonChar < 0 || d.XonChar > 0xFF || d.XoffChar < 0 || d.XoffChar > 0xFF {
+		return fmt.Errorf("xon_char and xoff_char must be between 0 and 255")
+	}
+
+	if d.BaudRate < 0 {
+		return fmt.Errorf("baud_rate must be positive")
+	}
+
+	if d.WriteChunkSize < 0 || d.WriteChunkDelayMs < 0 || d.WriteLineDelayMs < 0 {
+		return fmt.Errorf("write pacing values must not be negative")
+	}
+
+	if d.RS485DelayBeforeSendMs < 0 || d.RS485DelayAfterSendMs < 0 {
+		return fmt.Errorf("RS-485 delays must not be negative")
+	}
+	if d.RS485 && strings.EqualFold(d.FlowControl, "hardware") {
+		return fmt.Errorf("rs485 cannot be combined with hardware flow control")
+	}
+
+	switch strings.ToLower(d.Checksum) {
+	case "", "crc16", "crc32", "lrc", "xor":
+	default:
+		return fmt.Errorf("invalid checksum: %s", d.Checksum)
+	}
+
+	switch strings.ToLower(d.ChecksumPlacement) {
+	case "", "end":
+	case "before_terminator":
+		if d.ChecksumTerminator == "" {
+			return fmt.Errorf("checksum_placement before_terminator requires checksum_terminator")
+		}
+	default:
+		return fmt.Errorf("invalid checksum_placement: %s", d.ChecksumPlacement)
+	}
+
+	return nil
+}
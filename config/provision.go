@@ -0,0 +1,193 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Provision declares the desired state of an agent, applied without prompts
+// by "baudlink provision" for configuration management tools
+type Provision struct {
+	Config  yaml.Node        `yaml:"config"` // Agent configuration, merged over the defaults
+	Tokens  []ProvisionToken `yaml:"tokens"` // Replaces auth.tokens when set
+	Service ProvisionService `yaml:"service"`
+}
+
+// ProvisionToken declares an access token. Tokens without a value keep the
+// value they already have in the configuration file, or get a random one.
+type ProvisionToken struct {
+	Name  string   `yaml:"name"`
+	Token string   `yaml:"token"`
+	Admin bool     `yaml:"admin"`
+	Roles []string `yaml:"roles"`
+}
+
+// ProvisionService declares the state of the system service
+type ProvisionService struct {
+	Install bool `yaml:"install"`
+	Start   bool `yaml:"start"` // Implies install
+}
+
+// ProvisionPlan is the configuration file a provision results in
+type ProvisionPlan struct {
+	Config  *Config
+	Data    []byte   // Contents of the configuration file
+	Changed bool     // Data differs from the current file, or there is none
+	Created []string // Names of tokens that were given a random value
+	Removed []string // Names of tokens in the current file the new one lacks
+	Secrets bool     // The configuration holds credentials
+}
+
+// LoadProvision reads a provisioning file
+func LoadProvision(path string) (*Provision, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provisioning file: %w", err)
+	}
+
+	p := &Provision{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse provisioning file: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, t := range p.Tokens {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tokens: every token needs a name")
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("tokens: %s is declared twice", t.Name)
+		}
+		names[t.Name] = true
+	}
+
+	return p, nil
+}
+
+// Plan computes the configuration file the provision results in, given the
+// configuration file at path, which may not exist yet. The file is compared
+// after both sides have been normalized, so formatting and comments in the
+// current file do not count as changes. Environment overrides are not
+// applied to either side.
+func (p *Provision) Plan(path string) (*ProvisionPlan, error) {
+	format, _ := FormatFromPath(path)
+
+	var current *Config
+	var currentData []byte
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		current = DefaultConfig()
+		if err := current.decode(data, DetectFormat(path, data)); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		format = DetectFormat(path, data)
+		if currentData, err = current.encode(format); err != nil {
+			return nil, err
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := DefaultConfig()
+	if !p.Config.IsZero() {
+		if err := p.Config.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+	}
+
+	plan := &ProvisionPlan{Config: cfg}
+	if p.Tokens != nil {
+		if len(cfg.Auth.Tokens) > 0 {
+			return nil, fmt.Errorf("declare tokens either under tokens or under config.auth.tokens, not both")
+		}
+
+		existing := make(map[string]string)
+		if current != nil {
+			for _, t := range current.Auth.Tokens {
+				existing[t.Name] = t.Token
+			}
+		}
+
+		for _, t := range p.Tokens {
+			value := t.Token
+			if value == "" {
+				value = existing[t.Name]
+			}
+			if value == "" {
+				if value, err = randomToken(); err != nil {
+					return nil, err
+				}
+				plan.Created = append(plan.Created, t.Name)
+			}
+			cfg.Auth.Tokens = append(cfg.Auth.Tokens, TokenConfig{
+				Name:  t.Name,
+				Token: value,
+				Admin: t.Admin,
+				Roles: t.Roles,
+			})
+		}
+	}
+
+	// Tokens declared nowhere, under tokens or config.auth.tokens, are
+	// removed from the file
+	if current != nil {
+		kept := make(map[string]bool)
+		for _, t := range cfg.Auth.Tokens {
+			kept[t.Name] = true
+		}
+		for _, t := range current.Auth.Tokens {
+			if !kept[t.Name] {
+				plan.Removed = append(plan.Removed, t.Name)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	cfg.format = format
+	if plan.Data, err = cfg.encode(format); err != nil {
+		return nil, err
+	}
+	plan.Changed = current == nil || !bytes.Equal(plan.Data, currentData)
+
+	copied := DefaultConfig()
+	if err := copied.decode(plan.Data, format); err != nil {
+		return nil, err
+	}
+	plan.Secrets = !copied.extractSecrets().empty()
+
+	return plan, nil
+}
+
+// randomToken returns a new access token
+func randomToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
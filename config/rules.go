@@ -0,0 +1,130 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// MQTTConfig holds the broker used by rule actions
+type MQTTConfig struct {
+	Broker   string `yaml:"broker"` // tcp://host:1883, or tls://host:8883 for TLS
+	ClientID string `yaml:"client_id"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// RuleConfig triggers actions when data received on a port matches a
+// pattern. Exactly one of Match and MatchHex must be set.
+type RuleConfig struct {
+	Name       string             `yaml:"name"`
+	Port       string             `yaml:"port"`      // Port name or alias (empty = every port)
+	Match      string             `yaml:"match"`     // Regular expression
+	MatchHex   string             `yaml:"match_hex"` // Byte pattern in hex
+	CooldownMs int                `yaml:"cooldown_ms"`
+	Actions    []RuleActionConfig `yaml:"actions"`
+}
+
+// RuleActionConfig is an action performed when a rule matches
+type RuleActionConfig struct {
+	Type    string `yaml:"type"`     // write, mqtt, webhook, event
+	Data    string `yaml:"data"`     // write: text sent back to the port
+	DataHex string `yaml:"data_hex"` // write: bytes sent back to the port, in hex
+	Topic   string `yaml:"topic"`    // mqtt: topic to publish to
+	Retain  bool   `yaml:"retain"`   // mqtt: publish as a retained message
+	URL     string `yaml:"url"`      // webhook: URL receiving a POST
+	Payload string `yaml:"payload"`  // mqtt, webhook: message body (default: JSON)
+}
+
+// validateRules checks rule patterns and actions
+func (c *Config) validateRules() error {
+	seen := make(map[string]bool)
+
+	for i, r := range c.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule %d requires a name", i)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate rule name: %s", r.Name)
+		}
+		seen[r.Name] = true
+
+		if (r.Match == "") == (r.MatchHex == "") {
+			return fmt.Errorf("rule %s requires exactly one of match or match_hex", r.Name)
+		}
+		if r.Match != "" {
+			re, err := regexp.Compile(r.Match)
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid match: %w", r.Name, err)
+			}
+			if re.MatchString("") {
+				return fmt.Errorf("rule %s match must not match empty input", r.Name)
+			}
+		}
+		if r.MatchHex != "" {
+			if _, err := hex.DecodeString(r.MatchHex); err != nil {
+				return fmt.Errorf("rule %s has an invalid match_hex: %w", r.Name, err)
+			}
+		}
+		if r.CooldownMs < 0 {
+			return fmt.Errorf("rule %s cooldown_ms must not be negative", r.Name)
+		}
+		if len(r.Actions) == 0 {
+			return fmt.Errorf("rule %s has no actions", r.Name)
+		}
+
+		for _, a := range r.Actions {
+			if err := a.validate(c); err != nil {
+				return fmt.Errorf("rule %s: %w", r.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks that an action has the settings its type needs
+func (a RuleActionConfig) validate(c *Config) error {
+	switch a.Type {
+	case "write":
+		if (a.Data == "") == (a.DataHex == "") {
+			return fmt.Errorf("write action requires exactly one of data or data_hex")
+		}
+		if _, err := hex.DecodeString(a.DataHex); err != nil {
+			return fmt.Errorf("write action has invalid data_hex: %w", err)
+		}
+	case "mqtt":
+		if a.Topic == "" {
+			return fmt.Errorf("mqtt action requires a topic")
+		}
+		if c.MQTT.Broker == "" {
+			return fmt.Errorf("mqtt action requires mqtt.broker")
+		}
+	case "webhook":
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("webhook action requires an http or https url")
+		}
+	case "event":
+	default:
+		return fmt.Errorf("unknown action type: %q", a.Type)
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// StateVersion is the format version of state bundles written by ExportState
+const StateVersion = 1
+
+// Entries of a state bundle
+const (
+	stateManifestEntry = "manifest.json"
+	stateConfigEntry   = "config.yaml"
+	stateSecretsEntry  = "secrets.enc"
+)
+
+// ErrPassphraseRequired is returned when a bundle's secrets cannot be sealed
+// or opened without a passphrase
+var ErrPassphraseRequired = errors.New("a passphrase is required for the bundle's secrets")
+
+// StateManifest describes the contents of a state bundle
+type StateManifest struct {
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	Hostname     string    `json:"hostname"`
+	AgentVersion string    `json:"agent_version"`
+	Profiles     int       `json:"profiles"`
+	Aliases      int       `json:"aliases"`
+	Groups       int       `json:"groups"`
+	Rules        int       `json:"rules"`
+	Jobs         int       `json:"jobs"`
+	Tokens       int       `json:"tokens"`
+	Secrets      bool      `json:"secrets"` // Secrets are sealed in secrets.enc
+}
+
+// stateSecrets holds the configuration's credentials, removed from the
+// bundled configuration and sealed separately. Lists follow the order of
+// the entries they belong to.
+type stateSecrets struct {
+	AuthTokens   []string `yaml:"auth_tokens"`
+	SigningKey   string   `yaml:"signing_key"`
+	RemoteTokens []string `yaml:"remote_tokens"`
+	MQTTPassword string   `yaml:"mqtt_password"`
+	SinkTokens   []string `yaml:"sink_tokens"`
+
+	TelemetryHeaders map[string]string `yaml:"telemetry_headers"`
+}
+
+// ExportState writes the configuration file at path as a gzipped tar
+// bundle, for cloning an agent onto another machine. Access tokens, the
+// link signing key, the credentials of federation remotes, the MQTT broker,
+// and data log sinks, and the headers sent to the telemetry collector are
+// sealed with passphrase instead of being stored with the rest of the
+// configuration, and bound to the manifest and configuration so neither can
+// be swapped. Environment overrides are not applied, so the bundle matches
+// the file.
+func ExportState(w io.Writer, path string, agentVersion string, passphrase string) (*StateManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := DefaultConfig()
+	if err := cfg.decode(data, DetectFormat(path, data)); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	secrets := cfg.extractSecrets()
+	manifest := &StateManifest{
+		Version:      StateVersion,
+		CreatedAt:    time.Now().UTC(),
+		AgentVersion: agentVersion,
+		Profiles:     len(cfg.Profiles),
+		Aliases:      len(cfg.Aliases),
+		Groups:       len(cfg.Groups),
+		Rules:        len(cfg.Rules),
+		Jobs:         len(cfg.Jobs),
+		Tokens:       len(cfg.Auth.Tokens),
+		Secrets:      !secrets.empty(),
+	}
+	manifest.Hostname, _ = os.Hostname()
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	configData, err := cfg.encode(FormatYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var sealed []byte
+	if manifest.Secrets {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		plain, err := yaml.Marshal(secrets)
+		if err != nil {
+			return nil, err
+		}
+		if sealed, err = sealSecrets(plain, passphrase, stateAdditionalData(manifestData, configData)); err != nil {
+			return nil, err
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	type entry struct {
+		name string
+		data []byte
+	}
+	entries := []entry{
+		{stateManifestEntry, manifestData},
+		{stateConfigEntry, configData},
+	}
+	if sealed != nil {
+		entries = append(entries, entry{stateSecretsEntry, sealed})
+	}
+
+	for _, entry := range entries {
+		hdr := &tar.Header{
+			Name:    entry.name,
+			Mode:    0600,
+			Size:    int64(len(entry.data)),
+			ModTime: manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(entry.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ImportState reads a bundle written by ExportState, restores its secrets
+// with passphrase, and returns the validated configuration ready to be
+// saved
+func ImportState(r io.Reader, passphrase string) (*Config, *StateManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a state bundle: %w", err)
+	}
+	defer gz.Close()
+
+	entries := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		// Bundles are small; refuse entries that could exhaust memory
+		if hdr.Size > maxStateEntry {
+			return nil, nil, fmt.Errorf("bundle entry %s is too large", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		entries[hdr.Name] = data
+	}
+
+	manifestData, ok := entries[stateManifestEntry]
+	if !ok {
+		return nil, nil, fmt.Errorf("not a state bundle: %s is missing", stateManifestEntry)
+	}
+	manifest := &StateManifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+	if manifest.Version < 1 || manifest.Version > StateVersion {
+		return nil, nil, fmt.Errorf("unsupported bundle version %d", manifest.Version)
+	}
+
+	configData, ok := entries[stateConfigEntry]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid bundle: %s is missing", stateConfigEntry)
+	}
+	cfg := DefaultConfig()
+	if err := cfg.decode(configData, FormatYAML); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse bundled config: %w", err)
+	}
+
+	if manifest.Secrets {
+		sealed, ok := entries[stateSecretsEntry]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid bundle: %s is missing", stateSecretsEntry)
+		}
+		if passphrase == "" {
+			return nil, nil, ErrPassphraseRequired
+		}
+		plain, err := openSecrets(sealed, passphrase, stateAdditionalData(manifestData, configData))
+		if err != nil {
+			return nil, nil, err
+		}
+		var secrets stateSecrets
+		if err := yaml.Unmarshal(plain, &secrets); err != nil {
+			return nil, nil, fmt.Errorf("invalid bundle secrets: %w", err)
+		}
+		if err := cfg.restoreSecrets(secrets); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid bundled configuration: %w", err)
+	}
+	return cfg, manifest, nil
+}
+
+// maxStateEntry is the largest bundle entry ImportState reads
+const maxStateEntry = 16 * 1024 * 1024
+
+// extractSecrets removes the configuration's credentials and returns them
+func (c *Config) extractSecrets() stateSecrets {
+	var s stateSecrets
+
+	for i := range c.Auth.Tokens {
+		s.AuthTokens = append(s.AuthTokens, c.Auth.Tokens[i].Token)
+		c.Auth.Tokens[i].Token = ""
+	}
+	s.SigningKey, c.Auth.SigningKey = c.Auth.SigningKey, ""
+	for i := range c.Federation.Remotes {
+		s.RemoteTokens = append(s.RemoteTokens, c.Federation.Remotes[i].Token)
+		c.Federation.Remotes[i].Token = ""
+	}
+	s.MQTTPassword, c.MQTT.Password = c.MQTT.Password, ""
+	for i := range c.DataLog.Sinks {
+		s.SinkTokens = append(s.SinkTokens, c.DataLog.Sinks[i].Token)
+		c.DataLog.Sinks[i].Token = ""
+	}
+	s.TelemetryHeaders, c.Telemetry.Headers = c.Telemetry.Headers, nil
+
+	return s
+}
+
+// restoreSecrets puts credentials removed by extractSecrets back
+func (c *Config) restoreSecrets(s stateSecrets) error {
+	if len(s.AuthTokens) != len(c.Auth.Tokens) ||
+		len(s.RemoteTokens) != len(c.Federation.Remotes) ||
+		len(s.SinkTokens) != len(c.DataLog.Sinks) {
+		return fmt.Errorf("bundle secrets do not match its configuration")
+	}
+
+	for i, token := range s.AuthTokens {
+		c.Auth.Tokens[i].Token = token
+	}
+	c.Auth.SigningKey = s.SigningKey
+	for i, token := range s.RemoteTokens {
+		c.Federation.Remotes[i].Token = token
+	}
+	c.MQTT.Password = s.MQTTPassword
+	for i, token := range s.SinkTokens {
+		c.DataLog.Sinks[i].Token = token
+	}
+	c.Telemetry.Headers = s.TelemetryHeaders
+	return nil
+}
+
+// empty reports whether no credential is set
+func (s stateSecrets) empty() bool {
+	for _, list := range [][]string{s.AuthTokens, s.RemoteTokens, s.SinkTokens} {
+		for _, v := range list {
+			if v != "" {
+				return false
+			}
+		}
+	}
+	return s.SigningKey == "" && s.MQTTPassword == "" && len(s.TelemetryHeaders) == 0
+}
+
+// scrypt parameters deriving the key secrets are sealed with
+const (
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+	stateSaltSize = 16
+)
+
+// stateAdditionalData returns the data sealed secrets are bound to: the
+// bundle's manifest and configuration, each prefixed with its length
+func stateAdditionalData(manifest []byte, config []byte) []byte {
+	ad := make([]byte, 0, 16+len(manifest)+len(config))
+	ad = binary.BigEndian.AppendUint64(ad, uint64(len(manifest)))
+	ad = append(ad, manifest...)
+	ad = binary.BigEndian.AppendUint64(ad, uint64(len(config)))
+	return append(ad, config...)
+}
+
+// sealSecrets encrypts data with AES-256-GCM under a key derived from
+// passphrase, authenticating additionalData with it. The result is the
+// salt, the nonce, and the ciphertext.
+func sealSecrets(data []byte, passphrase string, additionalData []byte) ([]byte, error) {
+	salt := make([]byte, stateSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := stateCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+	return aead.Seal(out, nonce, data, additionalData), nil
+}
+
+// openSecrets decrypts data sealed by sealSecrets with the same additional
+// data
+func openSecrets(data []byte, passphrase string, additionalData []byte) ([]byte, error) {
+	if len(data) < stateSaltSize {
+		return nil, fmt.Errorf("invalid bundle secrets")
+	}
+	aead, err := stateCipher(passphrase, data[:stateSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[stateSaltSize:]
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("invalid bundle secrets")
+	}
+
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted bundle")
+	}
+	return plain, nil
+}
+
+// stateCipher derives the AES-256-GCM cipher for a passphrase and salt
+func stateCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
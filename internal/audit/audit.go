@@ -0,0 +1,213 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records port operations to an append-only JSON Lines file
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/rotate"
+)
+
+// DefaultQueryLimit is the number of entries Query returns without a limit
+const DefaultQueryLimit = 1000
+
+// Entry is a single audited operation
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Identity  string    `json:"identity"`
+	ClientID  string    `json:"client_id,omitempty"`
+	Peer      string    `json:"peer,omitempty"`
+	PortName  string    `json:"port_name,omitempty"`
+	SessionID string    `json:"session_id,omitempty"`
+	Bytes     uint64    `json:"bytes,omitempty"`
+	Success   bool      `json:"success"`
+	Message   string    `json:"message,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// Filter selects entries returned by Query. Zero fields match everything.
+type Filter struct {
+	Since     time.Time
+	Until     time.Time
+	PortName  string
+	Identity  string
+	Operation string
+	Limit     int
+}
+
+// matches reports whether e passes the filter
+func (f Filter) matches(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+	if f.PortName != "" && e.PortName != f.PortName {
+		return false
+	}
+	if f.Identity != "" && e.Identity != f.Identity {
+		return false
+	}
+	if f.Operation != "" && e.Operation != f.Operation {
+		return false
+	}
+	return true
+}
+
+// Store keeps entries in addition to the audit file, such as the agent's
+// history database, under a retention of its own
+type Store interface {
+	RecordAudit(e Entry)
+	QueryAudit(filter Filter) ([]Entry, error)
+}
+
+// Logger appends entries to a rotated audit file. A nil Logger discards
+// entries, so callers need not check whether auditing is enabled.
+type Logger struct {
+	mu    sync.Mutex
+	file  *rotate.File
+	store Store
+}
+
+// Open opens the audit file at path, rotating it after maxSize bytes and
+// keeping maxBackups old files
+func Open(path string, maxSize int64, maxBackups int) (*Logger, error) {
+	file, err := rotate.Open(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{file: file}, nil
+}
+
+// SetStore makes the logger copy entries to store and answer queries from
+// it, which indexes them and may keep entries older than the rotated files.
+// It must be called before entries are recorded.
+func (l *Logger) SetStore(store Store) {
+	l.store = store
+}
+
+// Record appends an entry, stamping it with the current time if unset.
+// Failures are logged rather than returned so that auditing never fails the
+// operation being audited.
+func (l *Logger) Record(e Entry) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("audit: failed to encode entry: %v", err)
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(line); err != nil {
+		log.Printf("audit: failed to write entry: %v", err)
+	}
+	if l.store != nil {
+		l.store.RecordAudit(e)
+	}
+}
+
+// Query returns the most recent entries matching filter, oldest first,
+// from the store if one is set and otherwise searching the current file and
+// its backups
+func (l *Logger) Query(filter Filter) ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+	if l.store != nil {
+		return l.store.QueryAudit(filter)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entries []Entry
+	for _, path := range l.file.Paths() {
+		var err error
+		entries, err = readEntries(path, filter, entries)
+		if err != nil {
+			return nil, err
+		}
+		// Keep memory bounded by the limit while scanning large files
+		if len(entries) > 2*limit {
+			entries = append(entries[:0], entries[len(entries)-limit:]...)
+		}
+	}
+
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// Close closes the audit file
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// readEntries appends the entries in path matching filter to entries.
+// Lines that cannot be decoded, such as a line cut short by a crash, are
+// skipped.
+func readEntries(path string, filter Filter, entries []Entry) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if filter.matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
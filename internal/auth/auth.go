@@ -0,0 +1,290 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auth provides token-based authentication for BaudLink clients.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Common errors
+var (
+	ErrMissingToken = errors.New("missing access token")
+	ErrInvalidToken = errors.New("invalid access token")
+	ErrExpiredToken = errors.New("access token has expired")
+)
+
+// guestTokenPrefix marks tokens minted by MintGuestToken
+const guestTokenPrefix = "blg"
+
+// Token is a statically configured access token
+type Token struct {
+	Name   string
+	Secret string
+	Admin  bool
+	Grants []Grant // Port access granted by the token's roles (nil means all)
+}
+
+// Grant allows access to the ports matching a pattern
+type Grant struct {
+	Pattern string // Port name or path.Match pattern
+	Write   bool   // Allow writing to and configuring matching ports
+}
+
+// Matches reports whether the grant covers the given port
+func (g Grant) Matches(portName string) bool {
+	if g.Pattern == portName || g.Pattern == "*" {
+		return true
+	}
+	ok, _ := path.Match(g.Pattern, portName)
+	return ok
+}
+
+// Identity describes an authenticated client
+type Identity struct {
+	Name      string
+	Admin     bool
+	Guest     bool
+	Ports     []string  // Ports the identity may access (empty means all)
+	Grants    []Grant   // Port access control list (nil means all ports read-write)
+	ReadOnly  bool      // Identity may not write to or configure ports
+	ExpiresAt time.Time // Zero for tokens that never expire
+}
+
+// CanAccessPort reports whether the identity is allowed to use the given port
+func (id *Identity) CanAccessPort(portName string) bool {
+	if len(id.Ports) > 0 && !contains(id.Ports, portName) {
+		return false
+	}
+	if id.Grants == nil {
+		return true
+	}
+	for _, g := range id.Grants {
+		if g.Matches(portName) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanWritePort reports whether the identity is allowed to write to and
+// configure the given port
+func (id *Identity) CanWritePort(portName string) bool {
+	if id.ReadOnly || !id.CanAccessPort(portName) {
+		return false
+	}
+	if id.Grants == nil {
+		return true
+	}
+	for _, g := range id.Grants {
+		if g.Write && g.Matches(portName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scoped reports whether the identity is limited to a subset of ports
+func (id *Identity) Scoped() bool {
+	if len(id.Ports) > 0 {
+		return true
+	}
+	for _, g := range id.Grants {
+		if g.Pattern == "*" {
+			return false
+		}
+	}
+	return id.Grants != nil
+}
+
+// contains reports whether list holds s
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GuestClaims describes the scope of a temporary access token
+type GuestClaims struct {
+	Name      string    `json:"name"`
+	Ports     []string  `json:"ports,omitempty"`
+	ReadOnly  bool      `json:"read_only"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Authenticator validates access tokens
+type Authenticator struct {
+	tokens     []Token
+	signingKey []byte
+}
+
+// NewAuthenticator creates a new authenticator. If signingKey is empty a
+// random key is generated, which means guest tokens do not survive restarts.
+func NewAuthenticator(tokens []Token, signingKey []byte) (*Authenticator, error) {
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+	}
+
+	return &Authenticator{
+		tokens:     tokens,
+		signingKey: signingKey,
+	}, nil
+}
+
+// Authenticate returns the identity associated with a token
+func (a *Authenticator) Authenticate(token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	if strings.HasPrefix(token, guestTokenPrefix+".") {
+		return a.verifyGuestToken(token)
+	}
+
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Secret), []byte(token)) == 1 {
+			return &Identity{
+				Name:     t.Name,
+				Admin:    t.Admin,
+				Grants:   t.Grants,
+				ReadOnly: t.Grants != nil && !anyWrite(t.Grants),
+			}, nil
+		}
+	}
+
+	return nil, ErrInvalidToken
+}
+
+// anyWrite reports whether any grant allows writing
+func anyWrite(grants []Grant) bool {
+	for _, g := range grants {
+		if g.Write {
+			return true
+		}
+	}
+	return false
+}
+
+// MintGuestToken creates a signed, time-limited token for the given claims
+func (a *Authenticator) MintGuestToken(claims GuestClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature := a.sign(encoded)
+
+	return guestTokenPrefix + "." + encoded + "." + signature, nil
+}
+
+// verifyGuestToken checks the signature and expiry of a guest token
+func (a *Authenticator) verifyGuestToken(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	expected := a.sign(parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims GuestClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if time.Now().After(claims.ExpiresAt) {
+		return nil, ErrExpiredToken
+	}
+
+	return &Identity{
+		Name:      claims.Name,
+		Guest:     true,
+		Ports:     claims.Ports,
+		ReadOnly:  claims.ReadOnly,
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+// sign computes the HMAC-SHA256 signature of a payload
+func (a *Authenticator) sign(payload string) string {
+	mac := hmac.New(sha256.New, a.signingKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ConnectionString encodes an agent address and token as a single
+// baudlink:// URL that can be handed to a client
+func ConnectionString(address, token string) string {
+	u := url.URL{
+		Scheme:   "baudlink",
+		Host:     address,
+		RawQuery: url.Values{"token": {token}}.Encode(),
+	}
+	return u.String()
+}
+
+// ParseConnectionString extracts the agent address and token from a
+// baudlink:// URL
+func ParseConnectionString(s string) (address, token string, err error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid connection string: %w", err)
+	}
+	if u.Scheme != "baudlink" {
+		return "", "", fmt.Errorf("invalid connection string scheme: %s", u.Scheme)
+	}
+	return u.Host, u.Query().Get("token"), nil
+}
+
+type identityKey struct{}
+
+// NewContext returns a context carrying the given identity
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity stored in ctx, if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
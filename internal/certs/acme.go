@@ -0,0 +1,316 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// ACME challenge types
+const (
+	ChallengeHTTP = "http-01"
+	ChallengeDNS  = "dns-01"
+)
+
+// LetsEncryptURL is the default ACME directory
+const LetsEncryptURL = acme.LetsEncryptURL
+
+// ACMEOptions describes how to obtain a certificate from an ACME CA
+type ACMEOptions struct {
+	DirectoryURL   string
+	Email          string
+	Domains        []string
+	Challenge      string        // ChallengeHTTP or ChallengeDNS
+	HTTPAddress    string        // Address answering HTTP challenges, e.g. ":80"
+	DNSHook        string        // Program creating and removing DNS challenge records
+	DNSWait        time.Duration // Time allowed for DNS records to propagate
+	AccountKeyFile string        // ACME account key, created if missing
+}
+
+// Obtain requests a certificate for the configured domains and writes it and
+// a new private key to certFile and keyFile
+func Obtain(ctx context.Context, opts ACMEOptions, certFile, keyFile string) error {
+	if len(opts.Domains) == 0 {
+		return errors.New("at least one domain is required")
+	}
+
+	accountKey, err := loadAccountKey(opts.AccountKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load ACME account key: %w", err)
+	}
+
+	directory := opts.DirectoryURL
+	if directory == "" {
+		directory = LetsEncryptURL
+	}
+	client := &acme.Client{Key: accountKey, DirectoryURL: directory, UserAgent: "baudlink"}
+
+	account := &acme.Account{}
+	if opts.Email != "" {
+		account.Contact = []string{"mailto:" + opts.Email}
+	}
+	if _, err := client.Register(ctx, account, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("failed to register ACME account: %w", err)
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(opts.Domains...))
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+
+	solver, err := newSolver(client, opts)
+	if err != nil {
+		return err
+	}
+	defer solver.close()
+
+	for _, url := range order.AuthzURLs {
+		if err := authorize(ctx, client, solver, url); err != nil {
+			return err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return fmt.Errorf("order failed: %w", err)
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: opts.Domains}, key)
+	if err != nil {
+		return err
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("failed to finalize order: %w", err)
+	}
+
+	return writeKeyPair(certFile, keyFile, chain, key)
+}
+
+// authorize completes a single authorization of an order
+func authorize(ctx context.Context, client *acme.Client, solver *solver, url string) error {
+	authz, err := client.GetAuthorization(ctx, url)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == solver.challenge {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("CA offers no %s challenge for %s", solver.challenge, authz.Identifier.Value)
+	}
+
+	domain := authz.Identifier.Value
+	cleanup, err := solver.present(ctx, domain, chal)
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s challenge for %s: %w", solver.challenge, domain, err)
+	}
+	defer cleanup()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("failed to accept challenge for %s: %w", domain, err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("authorization for %s failed: %w", domain, err)
+	}
+	return nil
+}
+
+// solver answers challenges of a single type
+type solver struct {
+	client    *acme.Client
+	challenge string
+	dnsHook   string
+	dnsWait   time.Duration
+
+	mu        sync.Mutex
+	responses map[string]string // HTTP challenge paths and responses
+	server    *http.Server
+}
+
+// newSolver prepares to answer challenges, starting the HTTP challenge
+// server if needed
+func newSolver(client *acme.Client, opts ACMEOptions) (*solver, error) {
+	s := &solver{
+		client:    client,
+		challenge: opts.Challenge,
+		dnsHook:   opts.DNSHook,
+		dnsWait:   opts.DNSWait,
+		responses: make(map[string]string),
+	}
+
+	switch opts.Challenge {
+	case ChallengeHTTP:
+		address := opts.HTTPAddress
+		if address == "" {
+			address = ":80"
+		}
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen for HTTP challenges: %w", err)
+		}
+		s.server = &http.Server{Handler: http.HandlerFunc(s.serveHTTP), ReadHeaderTimeout: 10 * time.Second}
+		go s.server.Serve(listener)
+	case ChallengeDNS:
+		if opts.DNSHook == "" {
+			return nil, errors.New("dns-01 challenges require a DNS hook")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported ACME challenge: %s", opts.Challenge)
+	}
+
+	return s, nil
+}
+
+// present publishes the response to a challenge and returns a function
+// removing it again
+func (s *solver) present(ctx context.Context, domain string, chal *acme.Challenge) (func(), error) {
+	if s.challenge == ChallengeHTTP {
+		response, err := s.client.HTTP01ChallengeResponse(chal.Token)
+		if err != nil {
+			return nil, err
+		}
+		path := s.client.HTTP01ChallengePath(chal.Token)
+
+		s.mu.Lock()
+		s.responses[path] = response
+		s.mu.Unlock()
+
+		return func() {
+			s.mu.Lock()
+			delete(s.responses, path)
+			s.mu.Unlock()
+		}, nil
+	}
+
+	record, err := s.client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return nil, err
+	}
+	name := "_acme-challenge." + domain
+
+	if err := s.runHook(ctx, "present", domain, name, record); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-time.After(s.dnsWait):
+	case <-ctx.Done():
+	}
+
+	return func() {
+		// Cleanup runs even if the order was cancelled
+		if err := s.runHook(context.Background(), "cleanup", domain, name, record); err != nil {
+			log.Printf("Warning: DNS hook cleanup for %s failed: %v", domain, err)
+		}
+	}, nil
+}
+
+// runHook runs the DNS hook as: hook <present|cleanup> <domain> <record name> <value>
+func (s *solver) runHook(ctx context.Context, action, domain, name, value string) error {
+	cmd := exec.CommandContext(ctx, s.dnsHook, action, domain, name, value)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s: %w: %s", s.dnsHook, action, err, out)
+	}
+	return nil
+}
+
+// serveHTTP answers HTTP challenges
+func (s *solver) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	response, ok := s.responses[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte(response))
+}
+
+// close stops the HTTP challenge server
+func (s *solver) close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// loadAccountKey reads the ACME account key, generating and saving a new one
+// if the file does not exist
+func loadAccountKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no key found in %s", path)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type in %s", path)
+		}
+		return signer, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(path, keyPEM, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader serves a certificate that is reloaded from disk whenever its
+// files change, so renewed certificates are used for new connections
+// without restarting the server
+type Reloader struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+// NewReloader loads the certificate and key from the given files
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the certificate and key files. The current certificate is
+// kept if they cannot be loaded.
+func (r *Reloader) Reload() error {
+	modTimes := r.fileModTimes()
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate returns the current certificate, for use in tls.Config
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Watch reloads the certificate when its files change, checking at the
+// given interval until ctx is done
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mu.RLock()
+		changed := r.fileModTimes() != r.modTimes
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			log.Printf("Warning: failed to reload TLS certificate: %v", err)
+			continue
+		}
+		log.Printf("Reloaded TLS certificate from %s", r.certFile)
+	}
+}
+
+// fileModTimes returns the modification times of the certificate and key
+func (r *Reloader) fileModTimes() [2]time.Time {
+	var times [2]time.Time
+	for i, path := range []string{r.certFile, r.keyFile} {
+		if info, err := os.Stat(path); err == nil {
+			times[i] = info.ModTime()
+		}
+	}
+	return times
+}
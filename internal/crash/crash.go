@@ -0,0 +1,150 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crash recovers from panics in agent goroutines, so a bug handling
+// one request or port fails only that request or session, and records what
+// happened for bug reports
+package crash
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Settings controls where crash dumps are written
+type Settings struct {
+	Directory string // Empty disables crash dumps
+	Version   string // Agent version recorded in dumps
+}
+
+var (
+	mu       sync.Mutex
+	settings Settings
+	counts   = make(map[string]uint64)
+)
+
+// Configure sets where crash dumps are written
+func Configure(s Settings) {
+	mu.Lock()
+	defer mu.Unlock()
+	settings = s
+}
+
+// Count is the number of panics recovered in one component
+type Count struct {
+	Component string
+	Count     uint64
+}
+
+// Counts returns the number of recovered panics per component, sorted by
+// component
+func Counts() []Count {
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := make([]Count, 0, len(counts))
+	for component, n := range counts {
+		result = append(result, Count{Component: component, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Component < result[j].Component })
+	return result
+}
+
+// Report records a panic recovered in component: it logs the panic value and
+// the stack of the panicking goroutine, counts it, and writes a crash dump if
+// a dump directory is configured. details are key/value pairs describing
+// what was being done, such as the RPC method or port. Report must be called
+// from the deferred function that recovered, so the stack still shows where
+// the panic happened. It returns the error to surface to the caller.
+func Report(component string, value any, details ...string) error {
+	stack := debug.Stack()
+
+	mu.Lock()
+	counts[component]++
+	s := settings
+	mu.Unlock()
+
+	log.Printf("error: panic in %s%s: %v\n%s", component, formatDetails(details), value, stack)
+
+	if s.Directory != "" {
+		path, err := writeDump(s, component, value, details, stack)
+		if err != nil {
+			log.Printf("error: failed to write crash dump: %v", err)
+		} else {
+			log.Printf("Crash dump written to %s", path)
+		}
+	}
+
+	return fmt.Errorf("internal error in %s: %v", component, value)
+}
+
+// formatDetails formats key/value pairs for a log line
+func formatDetails(details []string) string {
+	var s string
+	for i := 0; i+1 < len(details); i += 2 {
+		s += fmt.Sprintf(" %s=%s", details[i], details[i+1])
+	}
+	return s
+}
+
+// writeDump writes a crash dump with the panic, its stack, and the stacks of
+// all goroutines, for attaching to bug reports
+func writeDump(s Settings, component string, value any, details []string, stack []byte) (string, error) {
+	if err := os.MkdirAll(s.Directory, 0700); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	path := filepath.Join(s.Directory, fmt.Sprintf("crash-%s-%s.txt", now.Format("20060102-150405.000"), component))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "BaudLink crash report\n\n")
+	fmt.Fprintf(f, "Time:      %s\n", now.Format(time.RFC3339Nano))
+	fmt.Fprintf(f, "Version:   %s\n", s.Version)
+	fmt.Fprintf(f, "Platform:  %s/%s %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(f, "Component: %s\n", component)
+	for i := 0; i+1 < len(details); i += 2 {
+		fmt.Fprintf(f, "%s: %s\n", details[i], details[i+1])
+	}
+	fmt.Fprintf(f, "Panic:     %v\n\n", value)
+	fmt.Fprintf(f, "Stack:\n%s\n", stack)
+	fmt.Fprintf(f, "All goroutines:\n%s", allStacks())
+
+	return path, f.Close()
+}
+
+// allStacks returns the stacks of all goroutines
+func allStacks() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= 16<<20 {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,494 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package datalog parses numeric values from data received on ports and
+// writes them to time-series databases, so sensors can be graphed without
+// any code of their own
+package datalog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// Register types of Modbus fields
+const (
+	TypeUint16  = "uint16"
+	TypeInt16   = "int16"
+	TypeUint32  = "uint32"
+	TypeInt32   = "int32"
+	TypeFloat32 = "float32"
+)
+
+// DefaultFlushInterval is how often points are written when no interval is
+// configured
+const DefaultFlushInterval = 10 * time.Second
+
+// maxModbusBuffer bounds the unparsed input kept per Modbus source and port.
+// The largest read response is 256 bytes.
+const maxModbusBuffer = 512
+
+// Field extracts one value from a frame
+type Field struct {
+	Name     string
+	Group    string  // Pattern: capture group number or name (default: Name)
+	Register int     // Modbus: register offset within the response
+	Type     string  // Modbus: register type (default: uint16)
+	WordSwap bool    // Modbus: low word first for 32-bit types
+	Scale    float64 // Multiplier applied to the value (0 = 1)
+}
+
+// Source parses the data received on a port into points. Exactly one of
+// Pattern and Modbus is set.
+type Source struct {
+	Port          string // Port name or alias
+	Measurement   string
+	Tags          map[string]string
+	Sinks         []string       // Names of the sinks written to (empty = every sink)
+	Pattern       *regexp.Regexp // Matched against each received line
+	Modbus        bool           // Parse Modbus RTU read register responses
+	ModbusAddress int            // Only parse responses from this slave (0 = any)
+	Fields        []Field        // Pattern: default every named group
+}
+
+// Point is a set of values measured at the same time
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Options configures the logger
+type Options struct {
+	Sources       []Source
+	Sinks         []SinkConfig
+	FlushInterval time.Duration
+	// Alias returns the alias of a port, if any, so sources can name ports by alias
+	Alias func(portName string) string
+}
+
+// parser holds the framing state of a source on one port
+type parser struct {
+	lines  serial.Framer
+	modbus []byte
+}
+
+// Logger parses received data and periodically writes the points to sinks
+type Logger struct {
+	manager  *serial.Manager
+	sources  []*Source
+	sinks    map[string]*sink
+	alias    func(string) string
+	interval time.Duration
+	crc      *serial.Checksum
+	parsers  map[string]*parser // key: source index and port
+
+	cancel func()
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New validates the sources and sinks and creates a logger
+func New(manager *serial.Manager, opts Options) (*Logger, error) {
+	l := &Logger{
+		manager:  manager,
+		sinks:    make(map[string]*sink),
+		alias:    opts.Alias,
+		interval: opts.FlushInterval,
+		parsers:  make(map[string]*parser),
+		stop:     make(chan struct{}),
+	}
+	if l.alias == nil {
+		l.alias = func(string) string { return "" }
+	}
+	if l.interval <= 0 {
+		l.interval = DefaultFlushInterval
+	}
+	l.crc, _ = serial.NewChecksum(serial.ChecksumConfig{Algorithm: serial.ChecksumCRC16})
+
+	for _, cfg := range opts.Sinks {
+		if _, exists := l.sinks[cfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate sink name: %s", cfg.Name)
+		}
+		s, err := newSink(cfg)
+		if err != nil {
+			return nil, err
+		}
+		l.sinks[cfg.Name] = s
+	}
+
+	for i := range opts.Sources {
+		src := &opts.Sources[i]
+		if err := src.validate(); err != nil {
+			return nil, fmt.Errorf("data log for %s: %w", src.Port, err)
+		}
+		for _, name := range src.Sinks {
+			if l.sinks[name] == nil {
+				return nil, fmt.Errorf("data log for %s: unknown sink: %s", src.Port, name)
+			}
+		}
+		l.sources = append(l.sources, src)
+	}
+
+	return l, nil
+}
+
+// validate checks a source's parser and fields
+func (s *Source) validate() error {
+	if s.Port == "" {
+		return fmt.Errorf("port is required")
+	}
+	if s.Measurement == "" {
+		return fmt.Errorf("measurement is required")
+	}
+	if (s.Pattern == nil) == !s.Modbus {
+		return fmt.Errorf("exactly one of a pattern or modbus is required")
+	}
+
+	if s.Pattern != nil {
+		if len(s.Fields) == 0 {
+			for _, name := range s.Pattern.SubexpNames() {
+				if name != "" {
+					s.Fields = append(s.Fields, Field{Name: name})
+				}
+			}
+			if len(s.Fields) == 0 {
+				return fmt.Errorf("pattern has no named groups and no fields are given")
+			}
+		}
+		for _, f := range s.Fields {
+			if s.groupIndex(f) < 0 {
+				return fmt.Errorf("field %s: pattern has no group %s", f.Name, f.group())
+			}
+		}
+		return nil
+	}
+
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("modbus requires fields")
+	}
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("field requires a name")
+		}
+		if f.Register < 0 || f.Register > 124 {
+			return fmt.Errorf("field %s register offset out of range: %d", f.Name, f.Register)
+		}
+		if _, err := registerCount(f.Type); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// group returns the capture group a field is read from
+func (f Field) group() string {
+	if f.Group != "" {
+		return f.Group
+	}
+	return f.Name
+}
+
+// groupIndex returns the index of a field's capture group, or -1
+func (s *Source) groupIndex(f Field) int {
+	g := f.group()
+	if n, err := strconv.Atoi(g); err == nil {
+		if n < 1 || n > s.Pattern.NumSubexp() {
+			return -1
+		}
+		return n
+	}
+	return s.Pattern.SubexpIndex(g)
+}
+
+// registerCount returns the number of registers a field type occupies
+func registerCount(typ string) (int, error) {
+	switch typ {
+	case "", TypeUint16, TypeInt16:
+		return 1, nil
+	case TypeUint32, TypeInt32, TypeFloat32:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("unknown register type: %s", typ)
+	}
+}
+
+// Start begins parsing received data and writing points
+func (l *Logger) Start() {
+	data, cancel := l.manager.Monitor().Subscribe()
+	l.cancel = cancel
+
+	l.wg.Add(2)
+	go func() {
+		defer l.wg.Done()
+		for chunk := range data {
+			l.feed(chunk)
+		}
+	}()
+	go func() {
+		defer l.wg.Done()
+
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				l.flush()
+			}
+		}
+	}()
+}
+
+// Stop stops parsing and writes the points still pending
+func (l *Logger) Stop() {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	close(l.stop)
+	l.wg.Wait()
+	l.flush()
+}
+
+// flush writes the pending points of every sink
+func (l *Logger) flush() {
+	for _, s := range l.sinks {
+		if err := s.flush(); err != nil {
+			log.Printf("Data log sink %s: %v", s.config.Name, err)
+		}
+	}
+}
+
+// applies reports whether a source watches a port
+func (l *Logger) applies(s *Source, portName string) bool {
+	return s.Port == portName || s.Port == l.alias(portName)
+}
+
+// feed parses a chunk of received data with every source watching its port
+func (l *Logger) feed(chunk serial.PortData) {
+	for i, src := range l.sources {
+		if !l.applies(src, chunk.PortName) {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", i, chunk.PortName)
+		p := l.parsers[key]
+		if p == nil {
+			p = &parser{}
+			if src.Pattern != nil {
+				p.lines, _ = serial.NewFramer(serial.FramerLine)
+			}
+			l.parsers[key] = p
+		}
+
+		var values []map[string]float64
+		if src.Pattern != nil {
+			for _, frame := range p.lines.Feed(chunk.Data) {
+				if frame.Status != serial.FrameOK {
+					continue
+				}
+				if v := src.parseLine(frame.Data); v != nil {
+					values = append(values, v)
+				}
+			}
+		} else {
+			values = l.parseModbus(src, p, chunk.Data)
+		}
+
+		for _, v := range values {
+			l.record(src, chunk, v)
+		}
+	}
+}
+
+// record queues a point on the source's sinks
+func (l *Logger) record(src *Source, chunk serial.PortData, values map[string]float64) {
+	port := l.alias(chunk.PortName)
+	if port == "" {
+		port = chunk.PortName
+	}
+
+	tags := map[string]string{"port": port}
+	for k, v := range src.Tags {
+		tags[k] = v
+	}
+
+	point := Point{
+		Measurement: src.Measurement,
+		Tags:        tags,
+		Fields:      values,
+		Time:        chunk.Timestamp,
+	}
+
+	if len(src.Sinks) == 0 {
+		for _, s := range l.sinks {
+			s.add(point)
+		}
+		return
+	}
+	for _, name := range src.Sinks {
+		l.sinks[name].add(point)
+	}
+}
+
+// parseLine extracts the fields of a line matching the pattern
+func (s *Source) parseLine(line []byte) map[string]float64 {
+	match := s.Pattern.FindSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	values := make(map[string]float64)
+	for _, f := range s.Fields {
+		text := strings.TrimSpace(string(match[s.groupIndex(f)]))
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		values[f.Name] = f.scale(v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// parseModbus extracts the fields of every complete read register response
+// in the buffered data
+func (l *Logger) parseModbus(src *Source, p *parser, data []byte) []map[string]float64 {
+	p.modbus = append(p.modbus, data...)
+
+	var result []map[string]float64
+	for {
+		addr, regs, rest, ok := l.nextModbusResponse(p.modbus)
+		p.modbus = rest
+		if !ok {
+			break
+		}
+		if src.ModbusAddress != 0 && int(addr) != src.ModbusAddress {
+			continue
+		}
+		if v := src.parseRegisters(regs); v != nil {
+			result = append(result, v)
+		}
+	}
+
+	if len(p.modbus) > maxModbusBuffer {
+		p.modbus = p.modbus[len(p.modbus)-maxModbusBuffer:]
+	}
+	p.modbus = append([]byte(nil), p.modbus...)
+	return result
+}
+
+// nextModbusResponse finds the first read holding or input registers
+// response with a valid CRC. It returns the slave address, the register
+// data, and the input following the response. When no complete response is
+// buffered, ok is false and rest holds the input worth keeping.
+func (l *Logger) nextModbusResponse(buf []byte) (addr byte, regs []byte, rest []byte, ok bool) {
+	pending := -1
+	for i := 0; i+3 <= len(buf); i++ {
+		fn, count := buf[i+1], int(buf[i+2])
+		if (fn != 0x03 && fn != 0x04) || count == 0 || count%2 != 0 {
+			continue
+		}
+		size := 3 + count + 2
+		if len(buf)-i < size {
+			// Possibly the start of a response still arriving
+			if pending < 0 {
+				pending = i
+			}
+			continue
+		}
+		if _, valid := l.crc.Verify(buf[i : i+size]); !valid {
+			continue
+		}
+		return buf[i], buf[i+3 : i+3+count], buf[i+size:], true
+	}
+
+	if pending >= 0 {
+		return 0, nil, buf[pending:], false
+	}
+	// Keep a trailing partial header
+	if len(buf) > 2 {
+		buf = buf[len(buf)-2:]
+	}
+	return 0, nil, buf, false
+}
+
+// parseRegisters extracts the fields of a response's register data.
+// Fields beyond the end of the response are skipped.
+func (s *Source) parseRegisters(regs []byte) map[string]float64 {
+	values := make(map[string]float64)
+
+	for _, f := range s.Fields {
+		n, _ := registerCount(f.Type)
+		start := f.Register * 2
+		if start+n*2 > len(regs) {
+			continue
+		}
+		raw := regs[start : start+n*2]
+
+		var v float64
+		switch f.Type {
+		case "", TypeUint16:
+			v = float64(binary.BigEndian.Uint16(raw))
+		case TypeInt16:
+			v = float64(int16(binary.BigEndian.Uint16(raw)))
+		default:
+			word := binary.BigEndian.Uint32(raw)
+			if f.WordSwap {
+				word = word<<16 | word>>16
+			}
+			switch f.Type {
+			case TypeUint32:
+				v = float64(word)
+			case TypeInt32:
+				v = float64(int32(word))
+			case TypeFloat32:
+				v = float64(math.Float32frombits(word))
+			}
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		values[f.Name] = f.scale(v)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// scale applies a field's multiplier
+func (f Field) scale(v float64) float64 {
+	if f.Scale == 0 {
+		return v
+	}
+	return v * f.Scale
+}
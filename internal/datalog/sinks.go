@@ -0,0 +1,299 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Sink types
+const (
+	SinkInfluxDB   = "influxdb"
+	SinkPrometheus = "prometheus"
+)
+
+// maxPending bounds the points kept for a sink that cannot be reached; the
+// oldest points are dropped first
+const maxPending = 10000
+
+// writeTimeout bounds each write to a sink
+const writeTimeout = 10 * time.Second
+
+// SinkConfig describes a time-series database points are written to
+type SinkConfig struct {
+	Name string
+	Type string
+	// URL of the write endpoint, e.g.
+	// http://localhost:8086/api/v2/write?org=acme&bucket=sensors for InfluxDB
+	// or http://localhost:9090/api/v1/write for Prometheus
+	URL   string
+	Token string // Sent as an InfluxDB token or a bearer token (optional)
+}
+
+// sink batches points and writes them to a database
+type sink struct {
+	config SinkConfig
+	encode func(points []Point) []byte
+	header http.Header
+	client *http.Client
+
+	mu      sync.Mutex
+	pending []Point
+	dropped int
+}
+
+// newSink creates a sink from its configuration
+func newSink(cfg SinkConfig) (*sink, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("sink requires a name")
+	}
+	if !strings.HasPrefix(cfg.URL, "http://") && !strings.HasPrefix(cfg.URL, "https://") {
+		return nil, fmt.Errorf("sink %s requires an http or https url", cfg.Name)
+	}
+
+	s := &sink{
+		config: cfg,
+		header: make(http.Header),
+		client: &http.Client{Timeout: writeTimeout},
+	}
+
+	switch cfg.Type {
+	case SinkInfluxDB:
+		s.encode = encodeLineProtocol
+		s.header.Set("Content-Type", "text/plain; charset=utf-8")
+		if cfg.Token != "" {
+			s.header.Set("Authorization", "Token "+cfg.Token)
+		}
+	case SinkPrometheus:
+		s.encode = encodeRemoteWrite
+		s.header.Set("Content-Type", "application/x-protobuf")
+		s.header.Set("Content-Encoding", "snappy")
+		s.header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		if cfg.Token != "" {
+			s.header.Set("Authorization", "Bearer "+cfg.Token)
+		}
+	default:
+		return nil, fmt.Errorf("sink %s has unknown type: %s", cfg.Name, cfg.Type)
+	}
+
+	return s, nil
+}
+
+// add queues a point for the next flush
+func (s *sink) add(p Point) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) >= maxPending {
+		s.pending = s.pending[1:]
+		s.dropped++
+	}
+	s.pending = append(s.pending, p)
+}
+
+// flush writes the pending points. Points are kept for the next flush when
+// the write fails.
+func (s *sink) flush() error {
+	s.mu.Lock()
+	points := s.pending
+	dropped := s.dropped
+	s.pending = nil
+	s.dropped = 0
+	s.mu.Unlock()
+
+	if len(points) == 0 {
+		return nil
+	}
+
+	err := s.write(s.encode(points))
+	if err != nil {
+		s.mu.Lock()
+		s.pending = append(points, s.pending...)
+		if n := len(s.pending) - maxPending; n > 0 {
+			s.pending = s.pending[n:]
+			s.dropped += n
+		}
+		s.dropped += dropped
+		s.mu.Unlock()
+		return err
+	}
+
+	if dropped > 0 {
+		return fmt.Errorf("%d points were dropped while the sink was unavailable", dropped)
+	}
+	return nil
+}
+
+// write posts an encoded batch of points
+func (s *sink) write(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = s.header.Clone()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if len(msg) == 0 {
+			return errors.New(resp.Status)
+		}
+		return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Escapers for the InfluxDB line protocol
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// encodeLineProtocol encodes points in the InfluxDB line protocol with
+// nanosecond timestamps
+func encodeLineProtocol(points []Point) []byte {
+	var b bytes.Buffer
+	for _, p := range points {
+		b.WriteString(measurementEscaper.Replace(p.Measurement))
+		for _, k := range sortedKeys(p.Tags) {
+			if p.Tags[k] == "" {
+				continue
+			}
+			b.WriteByte(',')
+			b.WriteString(keyEscaper.Replace(k))
+			b.WriteByte('=')
+			b.WriteString(keyEscaper.Replace(p.Tags[k]))
+		}
+		for i, k := range sortedKeys(p.Fields) {
+			if i == 0 {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(keyEscaper.Replace(k))
+			b.WriteByte('=')
+			b.WriteString(strconv.FormatFloat(p.Fields[k], 'g', -1, 64))
+		}
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatInt(p.Time.UnixNano(), 10))
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
+
+// metricName replaces characters not allowed in Prometheus metric and label
+// names
+func metricName(name string, colons bool) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', colons && r == ':':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// encodeRemoteWrite encodes points as a snappy-compressed Prometheus remote
+// write request. Each field becomes a series named measurement_field.
+func encodeRemoteWrite(points []Point) []byte {
+	type series struct {
+		labels  []byte
+		samples []byte
+	}
+	var order []string
+	all := make(map[string]*series)
+
+	for _, p := range points {
+		for _, field := range sortedKeys(p.Fields) {
+			labels := map[string]string{"__name__": metricName(p.Measurement+"_"+field, true)}
+			for k, v := range p.Tags {
+				if v != "" {
+					labels[metricName(k, false)] = v
+				}
+			}
+
+			var key strings.Builder
+			var encoded []byte
+			for _, name := range sortedKeys(labels) {
+				key.WriteString(name + "=" + labels[name] + "\x00")
+				var l []byte
+				l = protowire.AppendTag(l, 1, protowire.BytesType)
+				l = protowire.AppendString(l, name)
+				l = protowire.AppendTag(l, 2, protowire.BytesType)
+				l = protowire.AppendString(l, labels[name])
+				encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+				encoded = protowire.AppendBytes(encoded, l)
+			}
+
+			s := all[key.String()]
+			if s == nil {
+				s = &series{labels: encoded}
+				all[key.String()] = s
+				order = append(order, key.String())
+			}
+
+			var sample []byte
+			sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+			sample = protowire.AppendFixed64(sample, math.Float64bits(p.Fields[field]))
+			sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+			sample = protowire.AppendVarint(sample, uint64(p.Time.UnixMilli()))
+			s.samples = protowire.AppendTag(s.samples, 2, protowire.BytesType)
+			s.samples = protowire.AppendBytes(s.samples, sample)
+		}
+	}
+
+	var req []byte
+	for _, key := range order {
+		s := all[key]
+		ts := append(append([]byte(nil), s.labels...), s.samples...)
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+	return snappy.Encode(nil, req)
+}
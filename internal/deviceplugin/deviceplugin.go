@@ -0,0 +1,360 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deviceplugin advertises the node's serial ports to the kubelet
+// as a Kubernetes extended resource. Pods request ports like any other
+// resource, e.g. "baudlink.io/serial: 1", and the kubelet mounts the device
+// nodes allocated to them into their containers.
+package deviceplugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	pluginapi "k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// DefaultResourceName is the resource pods request serial ports as
+const DefaultResourceName = "baudlink.io/serial"
+
+// PortsEnv lists the device paths allocated to a container, comma separated
+const PortsEnv = "BAUDLINK_SERIAL_PORTS"
+
+// Sockets in the kubelet's device plugin directory
+const (
+	socketName        = "baudlink-serial.sock"
+	kubeletSocketName = "kubelet.sock"
+)
+
+// updateInterval is how often the port list is refreshed and the plugin
+// socket checked for a kubelet restart
+const updateInterval = 5 * time.Second
+
+// registerTimeout bounds registration with the kubelet
+const registerTimeout = 10 * time.Second
+
+// Options configures the plugin
+type Options struct {
+	ResourceName string // Extended resource name (default: DefaultResourceName)
+	SocketDir    string // Kubelet's device plugin directory (default: pluginapi.DevicePluginPath)
+}
+
+// Plugin serves the kubelet's device plugin API for the serial ports the
+// scanner finds. Ports the agent itself has open are reported unhealthy so
+// they are not handed to pods.
+type Plugin struct {
+	pluginapi.UnimplementedDevicePluginServer
+
+	scanner      *serial.Scanner
+	resourceName string
+	socketDir    string
+
+	// mu guards the device list and the server
+	mu      sync.Mutex
+	devices []*pluginapi.Device
+	paths   map[string]string // key: device ID
+	changed chan struct{}     // closed when the device list changes
+	server  *grpc.Server
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a plugin advertising the ports found by scanner
+func New(scanner *serial.Scanner, opts Options) (*Plugin, error) {
+	if opts.ResourceName == "" {
+		opts.ResourceName = DefaultResourceName
+	}
+	if opts.SocketDir == "" {
+		opts.SocketDir = pluginapi.DevicePluginPath
+	}
+	if err := ValidateResourceName(opts.ResourceName); err != nil {
+		return nil, err
+	}
+
+	return &Plugin{
+		scanner:      scanner,
+		resourceName: opts.ResourceName,
+		socketDir:    opts.SocketDir,
+		paths:        make(map[string]string),
+		changed:      make(chan struct{}),
+		stop:         make(chan struct{}),
+	}, nil
+}
+
+// ValidateResourceName checks that name is a fully qualified extended
+// resource name, a domain and a name separated by a slash, outside the
+// domains Kubernetes reserves
+func ValidateResourceName(name string) error {
+	domain, resource, ok := strings.Cut(name, "/")
+	if !ok || resource == "" || !strings.Contains(domain, ".") || strings.Contains(resource, "/") {
+		return fmt.Errorf("invalid resource name %q (must be like %s)", name, DefaultResourceName)
+	}
+	if domain == "kubernetes.io" || strings.HasSuffix(domain, ".kubernetes.io") {
+		return fmt.Errorf("invalid resource name %q: the kubernetes.io domain is reserved", name)
+	}
+	return nil
+}
+
+// ResourceName returns the resource the plugin advertises
+func (p *Plugin) ResourceName() string {
+	return p.resourceName
+}
+
+// Start serves the plugin API and registers it with the kubelet. When the
+// kubelet restarts, it clears its device plugin directory; the plugin then
+// serves and registers again.
+func (p *Plugin) Start() error {
+	p.refresh()
+	if err := p.serve(); err != nil {
+		return err
+	}
+	if err := p.register(); err != nil {
+		p.stopServer()
+		return err
+	}
+
+	p.wg.Add(1)
+	go p.run()
+
+	return nil
+}
+
+// Close unregisters the plugin by removing its socket and stops serving
+func (p *Plugin) Close() {
+	close(p.stop)
+	p.wg.Wait()
+	p.stopServer()
+}
+
+// run refreshes the device list and watches for kubelet restarts
+func (p *Plugin) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.refresh()
+
+		if _, err := os.Stat(p.socketPath()); !os.IsNotExist(err) {
+			continue
+		}
+		log.Printf("Device plugin socket removed, registering with the kubelet again")
+		p.stopServer()
+		if err := p.serve(); err != nil {
+			log.Printf("Device plugin failed to serve: %v", err)
+			continue
+		}
+		if err := p.register(); err != nil {
+			log.Printf("Device plugin failed to register: %v", err)
+			// Try again on the next tick
+			p.stopServer()
+		}
+	}
+}
+
+// socketPath returns the path of the plugin's socket
+func (p *Plugin) socketPath() string {
+	return filepath.Join(p.socketDir, socketName)
+}
+
+// serve listens on the plugin socket, replacing one left behind
+func (p *Plugin) serve() error {
+	path := p.socketPath()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	pluginapi.RegisterDevicePluginServer(server, p)
+	go server.Serve(listener)
+
+	p.mu.Lock()
+	p.server = server
+	p.mu.Unlock()
+
+	return nil
+}
+
+// stopServer stops serving and removes the socket
+func (p *Plugin) stopServer() {
+	p.mu.Lock()
+	server := p.server
+	p.server = nil
+	p.mu.Unlock()
+
+	if server != nil {
+		// Stop rather than GracefulStop: ListAndWatch streams last until
+		// the kubelet disconnects
+		server.Stop()
+	}
+	os.Remove(p.socketPath())
+}
+
+// register announces the plugin to the kubelet
+func (p *Plugin) register() error {
+	target := "unix://" + filepath.Join(p.socketDir, kubeletSocketName)
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), registerTimeout)
+	defer cancel()
+
+	_, err = pluginapi.NewRegistrationClient(conn).Register(ctx, &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     socketName,
+		ResourceName: p.resourceName,
+		Options:      &pluginapi.DevicePluginOptions{},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register with the kubelet: %w", err)
+	}
+	return nil
+}
+
+// refresh rebuilds the device list from the scanner, waking ListAndWatch
+// streams when it changed. Only ports with a device node can be mounted
+// into containers; network and Bluetooth ports are left out.
+func (p *Plugin) refresh() {
+	ports, err := p.scanner.Ports(false)
+	if err != nil {
+		log.Printf("Device plugin failed to list ports: %v", err)
+		return
+	}
+
+	devices := make([]*pluginapi.Device, 0, len(ports))
+	paths := make(map[string]string, len(ports))
+	for _, port := range ports {
+		if serial.IsNetworkPort(port.Name) || serial.IsBluetoothPort(port.Name) || !filepath.IsAbs(port.Name) {
+			continue
+		}
+		id := filepath.Base(port.Name)
+		health := pluginapi.Healthy
+		if port.IsOpen {
+			health = pluginapi.Unhealthy
+		}
+		devices = append(devices, &pluginapi.Device{ID: id, Health: health})
+		paths[id] = port.Name
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].ID < devices[j].ID })
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if devicesEqual(p.devices, devices) {
+		return
+	}
+	p.devices = devices
+	p.paths = paths
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// devicesEqual reports whether two sorted device lists are the same
+func devicesEqual(a, b []*pluginapi.Device) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || a[i].Health != b[i].Health {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDevicePluginOptions reports that the plugin needs no pre-start hook
+// and has no allocation preferences
+func (p *Plugin) GetDevicePluginOptions(ctx context.Context, _ *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	return &pluginapi.DevicePluginOptions{}, nil
+}
+
+// ListAndWatch sends the device list, and again whenever it changes
+func (p *Plugin) ListAndWatch(_ *pluginapi.Empty, stream grpc.ServerStreamingServer[pluginapi.ListAndWatchResponse]) error {
+	for {
+		p.mu.Lock()
+		devices := p.devices
+		changed := p.changed
+		p.mu.Unlock()
+
+		if err := stream.Send(&pluginapi.ListAndWatchResponse{Devices: devices}); err != nil {
+			return err
+		}
+
+		select {
+		case <-changed:
+		case <-p.stop:
+			return nil
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Allocate gives containers access to the device nodes of their ports and
+// lists the paths in PortsEnv
+func (p *Plugin) Allocate(ctx context.Context, req *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	resp := &pluginapi.AllocateResponse{}
+	for _, creq := range req.ContainerRequests {
+		cresp := &pluginapi.ContainerAllocateResponse{}
+		var paths []string
+		for _, id := range creq.DevicesIds {
+			path, ok := p.paths[id]
+			if !ok {
+				return nil, fmt.Errorf("unknown serial device: %s", id)
+			}
+			cresp.Devices = append(cresp.Devices, &pluginapi.DeviceSpec{
+				ContainerPath: path,
+				HostPath:      path,
+				Permissions:   "rw",
+			})
+			paths = append(paths, path)
+		}
+		cresp.Envs = map[string]string{PortsEnv: strings.Join(paths, ",")}
+		resp.ContainerResponses = append(resp.ContainerResponses, cresp)
+	}
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package display renders serial traffic as text lines through a pipeline of
+// composable filters, so every terminal-style view of a port formats
+// timestamps, labels, and non-printable bytes the same way
+package display
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Direction is the direction of displayed traffic
+type Direction int
+
+const (
+	// RX is data received from the device
+	RX Direction = iota
+	// TX is data sent to the device
+	TX
+)
+
+// String returns the direction's label
+func (d Direction) String() string {
+	if d == TX {
+		return "TX"
+	}
+	return "RX"
+}
+
+// Line is one line of traffic passing through a pipeline
+type Line struct {
+	Port      string
+	Direction Direction
+	Time      time.Time
+	Data      []byte
+
+	fields []string
+}
+
+// AddField appends a prefix field shown before the data
+func (l *Line) AddField(field string) {
+	l.fields = append(l.fields, field)
+}
+
+// Filter is one stage of a pipeline. Filters may keep state between lines
+// and are not safe for concurrent use.
+type Filter interface {
+	Apply(l *Line)
+}
+
+// FilterFunc adapts a function to a Filter
+type FilterFunc func(l *Line)
+
+// Apply calls f(l)
+func (f FilterFunc) Apply(l *Line) {
+	f(l)
+}
+
+// Pipeline applies its filters in order
+type Pipeline []Filter
+
+// Render runs a line through the pipeline and returns it as text, prefix
+// fields first, without a trailing newline
+func (p Pipeline) Render(l Line) string {
+	l.fields = nil
+	for _, f := range p {
+		f.Apply(&l)
+	}
+	return strings.Join(append(l.fields, string(l.Data)), " ")
+}
+
+// ANSI colors used by the filters
+const (
+	colorReset = "\033[0m"
+	colorRX    = "\033[32m"
+	colorTX    = "\033[33m"
+)
+
+// portColors are cycled through for port labels
+var portColors = []string{"\033[36m", "\033[33m", "\033[35m", "\033[32m", "\033[34m", "\033[31m"}
+
+// colorize wraps s in an ANSI color
+func colorize(s, color string) string {
+	return color + s + colorReset
+}
+
+// AbsoluteTime prefixes each line with the wall-clock time it was received
+func AbsoluteTime(layout string) Filter {
+	return FilterFunc(func(l *Line) {
+		l.AddField(l.Time.Format(layout))
+	})
+}
+
+// RelativeTime prefixes each line with the seconds since the first line
+func RelativeTime() Filter {
+	var start time.Time
+	return FilterFunc(func(l *Line) {
+		if start.IsZero() {
+			start = l.Time
+		}
+		l.AddField(fmt.Sprintf("%10.3f", l.Time.Sub(start).Seconds()))
+	})
+}
+
+// Delta prefixes each line with the time elapsed since the previous line
+func Delta() Filter {
+	var last time.Time
+	return FilterFunc(func(l *Line) {
+		var d time.Duration
+		if !last.IsZero() {
+			d = l.Time.Sub(last)
+		}
+		last = l.Time
+		l.AddField(fmt.Sprintf("(+%.3fs)", d.Seconds()))
+	})
+}
+
+// PortLabel prefixes each line with its port name, padded to the longest of
+// ports and colored per port when color is set
+func PortLabel(ports []string, color bool) Filter {
+	width := 0
+	colors := make(map[string]string, len(ports))
+	for i, port := range ports {
+		width = max(width, len(port))
+		colors[port] = portColors[i%len(portColors)]
+	}
+	return FilterFunc(func(l *Line) {
+		label := fmt.Sprintf("[%-*s]", width, l.Port)
+		if c, ok := colors[l.Port]; ok && color {
+			label = colorize(label, c)
+		}
+		l.AddField(label)
+	})
+}
+
+// DirectionLabel prefixes each line with RX or TX and, when color is set,
+// colors the line's data by direction
+func DirectionLabel(color bool) Filter {
+	return FilterFunc(func(l *Line) {
+		if !color {
+			l.AddField(l.Direction.String())
+			return
+		}
+		c := colorRX
+		if l.Direction == TX {
+			c = colorTX
+		}
+		l.AddField(colorize(l.Direction.String(), c))
+		l.Data = []byte(colorize(string(l.Data), c))
+	})
+}
+
+// Hex shows the data as space-separated hex bytes
+func Hex() Filter {
+	return FilterFunc(func(l *Line) {
+		l.Data = []byte(fmt.Sprintf("% X", l.Data))
+	})
+}
+
+// Escape shows control characters and other non-printable bytes as escapes,
+// \r, \n, and \t by name and the rest as \xNN
+func Escape() Filter {
+	return FilterFunc(func(l *Line) {
+		var b strings.Builder
+		for _, c := range l.Data {
+			switch {
+			case c == '\r':
+				b.WriteString(`\r`)
+			case c == '\n':
+				b.WriteString(`\n`)
+			case c == '\t':
+				b.WriteString(`\t`)
+			case c == '\\':
+				b.WriteString(`\\`)
+			case c < 0x20 || c >= 0x7f:
+				fmt.Fprintf(&b, `\x%02x`, c)
+			default:
+				b.WriteByte(c)
+			}
+		}
+		l.Data = []byte(b.String())
+	})
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doctor checks the host for common causes of serial port
+// problems, such as missing permissions and other programs grabbing ports,
+// and suggests fixes
+package doctor
+
+// Status is the outcome of a check
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusWarning
+	StatusError
+)
+
+// String returns the status as shown in reports
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// Result is the outcome of one check. Fix suggests how to resolve a
+// warning or error.
+type Result struct {
+	Check   string
+	Status  Status
+	Message string
+	Fix     string
+}
+
+// System checks how the operating system treats the given serial devices:
+// access permissions, processes that grab ports, and conflicting drivers.
+// Platforms without such checks return no results.
+func System(devices []string) []Result {
+	return systemChecks(devices)
+}
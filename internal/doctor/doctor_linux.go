@@ -0,0 +1,295 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// serialGroups are the groups owning serial devices on common distributions
+var serialGroups = []string{"dialout", "uucp"}
+
+// ch340 is the USB ID of the CH340/CH341 adapters brltty mistakes for
+// braille displays
+const ch340 = "1a86:7523"
+
+// deviceGlobs match USB, Bluetooth, and on-board UART devices. They are
+// checked besides the enumerated ports because a device the user cannot
+// open may not be enumerated at all. ttyS* is left out as most PCs have
+// dozens without hardware behind them.
+var deviceGlobs = []string{"/dev/ttyUSB*", "/dev/ttyACM*", "/dev/ttyAMA*", "/dev/rfcomm*"}
+
+// systemChecks runs the Linux checks
+func systemChecks(devices []string) []Result {
+	for _, pattern := range deviceGlobs {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if !slices.Contains(devices, m) {
+				devices = append(devices, m)
+			}
+		}
+	}
+	claimants := serial.PortClaimants(devices...)
+
+	var results []Result
+	results = append(results, checkPermissions(devices)...)
+	results = append(results, checkClaimants(devices, claimants)...)
+	results = append(results, checkBrltty())
+	results = append(results, checkHolders(devices, claimants)...)
+	return results
+}
+
+// checkPermissions checks that the user can open the devices, and explains
+// group membership when not
+func checkPermissions(devices []string) []Result {
+	const check = "permissions"
+
+	if os.Geteuid() == 0 {
+		return []Result{{Check: check, Status: StatusOK, Message: "running as root"}}
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return []Result{{Check: check, Status: StatusWarning, Message: fmt.Sprintf("failed to look up the current user: %v", err)}}
+	}
+	memberOf, _ := u.GroupIds()
+	active, _ := os.Getgroups()
+
+	var results []Result
+	denied := 0
+	for _, device := range devices {
+		if unix.Access(device, unix.R_OK|unix.W_OK) == nil {
+			continue
+		}
+		denied++
+
+		info, err := os.Stat(device)
+		if err != nil {
+			results = append(results, Result{Check: check, Status: StatusError, Message: fmt.Sprintf("%s: %v", device, err)})
+			continue
+		}
+		gid := info.Sys().(*syscall.Stat_t).Gid
+		group := strconv.Itoa(int(gid))
+		if g, err := user.LookupGroupId(group); err == nil {
+			group = g.Name
+		}
+
+		switch {
+		case !slices.Contains(memberOf, strconv.Itoa(int(gid))):
+			results = append(results, Result{
+				Check:   check,
+				Status:  StatusError,
+				Message: fmt.Sprintf("%s belongs to group %s, which %s is not a member of", device, group, u.Username),
+				Fix:     fmt.Sprintf("sudo usermod -aG %s %s, then log out and back in", group, u.Username),
+			})
+		case !slices.Contains(active, int(gid)):
+			results = append(results, Result{
+				Check:   check,
+				Status:  StatusError,
+				Message: fmt.Sprintf("%s was added to group %s after this session started", u.Username, group),
+				Fix:     fmt.Sprintf("log out and back in, or run newgrp %s", group),
+			})
+		default:
+			results = append(results, Result{
+				Check:   check,
+				Status:  StatusError,
+				Message: fmt.Sprintf("%s is not readable and writable (mode %s)", device, info.Mode().Perm()),
+				Fix:     fmt.Sprintf("check the device's ACLs with getfacl %s and any udev rules that set its mode", device),
+			})
+		}
+	}
+
+	if denied == 0 {
+		if len(devices) > 0 {
+			results = append(results, Result{Check: check, Status: StatusOK, Message: fmt.Sprintf("%s can open all %d serial device(s)", u.Username, len(devices))})
+		} else if group, ok := missingSerialGroup(memberOf); ok {
+			// Nothing to open yet, but the next adapter plugged in will fail
+			results = append(results, Result{
+				Check:   check,
+				Status:  StatusWarning,
+				Message: fmt.Sprintf("%s is not a member of group %s, which owns serial devices", u.Username, group),
+				Fix:     fmt.Sprintf("sudo usermod -aG %s %s, then log out and back in", group, u.Username),
+			})
+		}
+	}
+	return results
+}
+
+// missingSerialGroup returns the first existing serial group the user is
+// not a member of
+func missingSerialGroup(memberOf []string) (string, bool) {
+	for _, name := range serialGroups {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			continue
+		}
+		if !slices.Contains(memberOf, g.Gid) {
+			return name, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// checkClaimants reports login prompts running on the devices, which answer
+// everything received with a login prompt and echo, and ModemManager
+// probing them
+func checkClaimants(devices []string, claimants map[string][]serial.PortClaimant) []Result {
+	var results []Result
+	for _, device := range devices {
+		tty := filepath.Base(device)
+		for _, c := range claimants[device] {
+			switch c.Name {
+			case serial.ClaimantGetty:
+				fix := fmt.Sprintf("sudo baudlink service protect %s, or sudo systemctl disable --now serial-getty@%s.service", device, tty)
+				if strings.HasPrefix(tty, "ttyAMA") || strings.HasPrefix(tty, "ttyS") {
+					fix += "; on a Raspberry Pi, also disable the serial console with raspi-config"
+				}
+				results = append(results, Result{
+					Check:   "getty",
+					Status:  StatusError,
+					Message: fmt.Sprintf("%s runs a login prompt on %s", c, device),
+					Fix:     fix,
+				})
+			case serial.ClaimantModemManager:
+				results = append(results, Result{
+					Check:   "modemmanager",
+					Status:  StatusWarning,
+					Message: fmt.Sprintf("%s probes %s with AT commands for several seconds after it appears", c, device),
+					Fix:     fmt.Sprintf("sudo baudlink service protect %s, or sudo systemctl disable --now ModemManager", device),
+				})
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, Result{Check: "claimants", Status: StatusOK, Message: "no login prompts or ModemManager on serial devices"})
+	}
+	return results
+}
+
+// checkBrltty reports brltty, whose udev rules claim CH340/CH341 adapters
+// as braille displays so that no /dev/ttyUSB device appears for them
+func checkBrltty() Result {
+	const check = "brltty"
+	const fix = "sudo systemctl mask brltty-udev.service brltty.service, or remove the brltty package, then replug the adapter"
+
+	installed := false
+	for _, path := range []string{"/usr/bin/brltty", "/bin/brltty", "/usr/sbin/brltty"} {
+		if _, err := os.Stat(path); err == nil {
+			installed = true
+			break
+		}
+	}
+	running := processRunning("brltty")
+	if !installed && !running {
+		return Result{Check: check, Status: StatusOK, Message: "brltty is not installed"}
+	}
+
+	if usbDevicePresent(ch340) {
+		return Result{
+			Check:   check,
+			Status:  StatusError,
+			Message: "brltty is installed and a CH340/CH341 adapter (" + ch340 + ") is plugged in; brltty claims these adapters as braille displays",
+			Fix:     fix,
+		}
+	}
+	return Result{
+		Check:   check,
+		Status:  StatusWarning,
+		Message: "brltty is installed and will claim CH340/CH341 adapters (" + ch340 + ") as braille displays when they are plugged in",
+		Fix:     fix,
+	}
+}
+
+// usbDevicePresent reports whether a USB device with the vid:pid ID is
+// connected
+func usbDevicePresent(id string) bool {
+	dirs, _ := filepath.Glob("/sys/bus/usb/devices/*")
+	for _, dir := range dirs {
+		vid, err := os.ReadFile(filepath.Join(dir, "idVendor"))
+		if err != nil {
+			continue
+		}
+		pid, err := os.ReadFile(filepath.Join(dir, "idProduct"))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(vid))+":"+strings.TrimSpace(string(pid)) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHolders reports other programs that have the devices open, such as
+// a forgotten terminal program. Claimants are reported by checkClaimants,
+// and the agent holding its ports is expected. Only processes the user may
+// inspect are found.
+func checkHolders(devices []string, claimants map[string][]serial.PortClaimant) []Result {
+	var results []Result
+	for _, device := range devices {
+		holders, err := serial.PortHolders(device)
+		if err != nil {
+			continue
+		}
+		for _, h := range holders {
+			claimed := slices.ContainsFunc(claimants[device], func(c serial.PortClaimant) bool { return c.PID == h.PID })
+			if h.Name == "baudlink" || claimed {
+				continue
+			}
+			results = append(results, Result{
+				Check:   "holders",
+				Status:  StatusWarning,
+				Message: fmt.Sprintf("%s has %s open", h, device),
+				Fix:     fmt.Sprintf("close the program, or stop it with kill %d", h.PID),
+			})
+		}
+	}
+	return results
+}
+
+// processRunning reports whether a process with the command name runs
+func processRunning(name string) bool {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join("/proc", e.Name(), "comm"))
+		if err == nil && strings.TrimSpace(string(comm)) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emulator
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// pollInterval bounds each read from a session, so a cancelled emulator
+// stops reading promptly
+const pollInterval = 200 * time.Millisecond
+
+// SessionDevice runs an emulator on an open session of a physical port
+type SessionDevice struct {
+	ctx       context.Context
+	manager   *serial.Manager
+	portName  string
+	sessionID string
+}
+
+// NewSessionDevice creates a device reading and writing through a session.
+// Reads fail with ErrDeviceClosed once ctx is cancelled.
+func NewSessionDevice(ctx context.Context, manager *serial.Manager, portName, sessionID string) *SessionDevice {
+	return &SessionDevice{ctx: ctx, manager: manager, portName: portName, sessionID: sessionID}
+}
+
+// Read waits for data received on the session
+func (d *SessionDevice) Read(b []byte) (int, error) {
+	for {
+		if d.ctx.Err() != nil {
+			return 0, ErrDeviceClosed
+		}
+		data, err := d.manager.ReadWithin(d.portName, d.sessionID, len(b), pollInterval)
+		if err != nil {
+			return 0, err
+		}
+		if len(data) > 0 {
+			return copy(b, data), nil
+		}
+	}
+}
+
+// Write writes data to the session
+func (d *SessionDevice) Write(b []byte) (int, error) {
+	return d.manager.Write(d.portName, d.sessionID, b)
+}
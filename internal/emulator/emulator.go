@@ -0,0 +1,602 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package emulator impersonates serial devices. A script of pattern and
+// reply rules, optionally grouped into states, answers whatever a client
+// sends on a virtual terminal or physical port, so clients can be developed
+// and tested without the real GPS receiver, modem, or PLC.
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxBuffer bounds the unmatched input retained between rules
+const maxBuffer = 64 * 1024
+
+// readSize is the size of each read from the device
+const readSize = 4096
+
+// ErrDeviceClosed is returned when the device the emulator runs on closes
+var ErrDeviceClosed = errors.New("device closed")
+
+// Script describes an emulated device. Rules and periodic messages at the
+// top level apply in every state, after those of the current state.
+type Script struct {
+	Name     string           `yaml:"name"`
+	Initial  string           `yaml:"initial"` // State entered at start; required when states are defined
+	Rules    []Rule           `yaml:"rules"`
+	Periodic []Periodic       `yaml:"periodic"`
+	States   map[string]State `yaml:"states"`
+}
+
+// State is a named set of rules and periodic messages. OnEnter replies are
+// sent whenever the state is entered, including at start.
+type State struct {
+	OnEnter  []Reply    `yaml:"on_enter"`
+	Rules    []Rule     `yaml:"rules"`
+	Periodic []Periodic `yaml:"periodic"`
+}
+
+// Rule answers received data matching a pattern. Exactly one of Match and
+// MatchHex is set. Respond and Replies may refer to capture groups of Match
+// as $1 or ${name}, and to the whole match as $0; a literal dollar sign is
+// written $$.
+type Rule struct {
+	Name       string        `yaml:"name"`
+	Match      string        `yaml:"match"`       // Regular expression
+	MatchHex   string        `yaml:"match_hex"`   // Literal bytes, hex encoded
+	Respond    string        `yaml:"respond"`     // Sent after Delay
+	RespondHex string        `yaml:"respond_hex"` // Sent after Delay, hex encoded
+	Delay      time.Duration `yaml:"delay"`
+	Replies    []Reply       `yaml:"replies"` // Sent in order after Respond
+	Goto       string        `yaml:"goto"`    // State entered after replying
+
+	pattern *regexp.Regexp
+	literal []byte
+}
+
+// Reply is data sent after a delay. Exactly one of Send and SendHex is set.
+type Reply struct {
+	Send    string        `yaml:"send"`
+	SendHex string        `yaml:"send_hex"`
+	Delay   time.Duration `yaml:"delay"`
+
+	data []byte
+}
+
+// Periodic is data sent at a fixed interval, such as GPS sentences. Exactly
+// one of Send and SendHex is set.
+type Periodic struct {
+	Every   time.Duration `yaml:"every"`
+	Send    string        `yaml:"send"`
+	SendHex string        `yaml:"send_hex"`
+
+	data []byte
+}
+
+// Parse parses and validates a YAML script
+func Parse(data []byte) (*Script, error) {
+	var s Script
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse emulator script: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Validate checks the script and compiles its patterns
+func (s *Script) Validate() error {
+	if len(s.States) > 0 {
+		if s.Initial == "" {
+			return fmt.Errorf("initial state is required when states are defined")
+		}
+		if _, ok := s.States[s.Initial]; !ok {
+			return fmt.Errorf("initial state %q is not defined", s.Initial)
+		}
+	} else if s.Initial != "" {
+		return fmt.Errorf("initial state %q is not defined", s.Initial)
+	}
+
+	actions := len(s.Rules) + len(s.Periodic)
+	if err := s.validateSet("", s.Rules, s.Periodic); err != nil {
+		return err
+	}
+	for name, state := range s.States {
+		for i := range state.OnEnter {
+			if err := state.OnEnter[i].validate(); err != nil {
+				return fmt.Errorf("state %s: on_enter %d: %w", name, i+1, err)
+			}
+		}
+		if err := s.validateSet(name, state.Rules, state.Periodic); err != nil {
+			return err
+		}
+		actions += len(state.OnEnter) + len(state.Rules) + len(state.Periodic)
+	}
+
+	if actions == 0 {
+		return fmt.Errorf("emulator script has no rules or messages")
+	}
+	return nil
+}
+
+// validateSet checks the rules and periodic messages of a state, or the
+// top level when state is empty
+func (s *Script) validateSet(state string, rules []Rule, periodic []Periodic) error {
+	prefix := ""
+	if state != "" {
+		prefix = "state " + state + ": "
+	}
+
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return fmt.Errorf("%srule %d: %w", prefix, i+1, err)
+		}
+		if next := rules[i].Goto; next != "" {
+			if _, ok := s.States[next]; !ok {
+				return fmt.Errorf("%srule %d: goto state %q is not defined", prefix, i+1, next)
+			}
+		}
+	}
+	for i := range periodic {
+		if err := periodic[i].validate(); err != nil {
+			return fmt.Errorf("%speriodic %d: %w", prefix, i+1, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a rule and compiles its pattern
+func (r *Rule) validate() error {
+	if (r.Match == "") == (r.MatchHex == "") {
+		return fmt.Errorf("exactly one of match or match_hex is required")
+	}
+	if r.Respond != "" && r.RespondHex != "" {
+		return fmt.Errorf("respond and respond_hex are mutually exclusive")
+	}
+	if r.Respond == "" && r.RespondHex == "" && len(r.Replies) == 0 && r.Goto == "" {
+		return fmt.Errorf("a respond, replies, or goto is required")
+	}
+	if r.Delay < 0 {
+		return fmt.Errorf("delay must not be negative")
+	}
+
+	if r.Match != "" {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return fmt.Errorf("invalid match pattern: %w", err)
+		}
+		if re.MatchString("") {
+			return fmt.Errorf("match pattern matches empty input")
+		}
+		r.pattern = re
+	} else {
+		b, err := hex.DecodeString(r.MatchHex)
+		if err != nil {
+			return fmt.Errorf("invalid match_hex: %w", err)
+		}
+		r.literal = b
+	}
+
+	if r.RespondHex != "" {
+		if _, err := hex.DecodeString(r.RespondHex); err != nil {
+			return fmt.Errorf("invalid respond_hex: %w", err)
+		}
+	}
+	for i := range r.Replies {
+		if err := r.Replies[i].validate(); err != nil {
+			return fmt.Errorf("reply %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a reply and decodes its data
+func (r *Reply) validate() error {
+	data, err := decodeSend(r.Send, r.SendHex)
+	if err != nil {
+		return err
+	}
+	if r.Delay < 0 {
+		return fmt.Errorf("delay must not be negative")
+	}
+	r.data = data
+	return nil
+}
+
+// validate checks a periodic message and decodes its data
+func (p *Periodic) validate() error {
+	data, err := decodeSend(p.Send, p.SendHex)
+	if err != nil {
+		return err
+	}
+	if p.Every <= 0 {
+		return fmt.Errorf("every must be positive")
+	}
+	p.data = data
+	return nil
+}
+
+// decodeSend returns the data of a send or send_hex pair
+func decodeSend(send, sendHex string) ([]byte, error) {
+	if (send == "") == (sendHex == "") {
+		return nil, fmt.Errorf("exactly one of send or send_hex is required")
+	}
+	if send != "" {
+		return []byte(send), nil
+	}
+	b, err := hex.DecodeString(sendHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid send_hex: %w", err)
+	}
+	return b, nil
+}
+
+// label names a rule in the transcript
+func (r *Rule) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.Match != "" {
+		return strconv.Quote(r.Match)
+	}
+	return r.MatchHex
+}
+
+// find returns the submatch indexes of the first match in data
+func (r *Rule) find(data []byte) []int {
+	if r.pattern != nil {
+		return r.pattern.FindSubmatchIndex(data)
+	}
+	i := bytes.Index(data, r.literal)
+	if i < 0 {
+		return nil
+	}
+	return []int{i, i + len(r.literal)}
+}
+
+// expand substitutes capture group references in a reply template
+func (r *Rule) expand(template string, data []byte, match []int) []byte {
+	if r.pattern == nil {
+		return bytes.ReplaceAll([]byte(template), []byte("$0"), data[match[0]:match[1]])
+	}
+	return r.pattern.Expand(nil, []byte(template), data, match)
+}
+
+// EventType identifies a transcript entry
+type EventType int
+
+const (
+	EventUnknown EventType = iota
+	EventReceived
+	EventMatched
+	EventSent
+	EventState
+	EventFailed
+)
+
+// String returns the string representation of EventType
+func (t EventType) String() string {
+	switch t {
+	case EventReceived:
+		return "received"
+	case EventMatched:
+		return "matched"
+	case EventSent:
+		return "sent"
+	case EventState:
+		return "state"
+	case EventFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single transcript entry produced while emulating
+type Event struct {
+	Type      EventType
+	State     string // Current state, or the state entered for EventState
+	Rule      string // Rule that matched or replied
+	Data      []byte
+	Message   string
+	Timestamp time.Time
+}
+
+// Emulator runs a script against a device
+type Emulator struct {
+	script *Script
+	emit   func(Event)
+
+	device io.ReadWriter
+	state  string
+	buffer []byte
+
+	// Periodic messages of the current state and when each is next due
+	periodic []Periodic
+	due      []time.Time
+}
+
+// New creates an emulator for a validated script. emit receives the
+// transcript and may be nil.
+func New(s *Script, emit func(Event)) *Emulator {
+	if emit == nil {
+		emit = func(Event) {}
+	}
+	return &Emulator{script: s, emit: emit}
+}
+
+// Run answers data read from the device until ctx is cancelled or the
+// device fails. It returns nil when ctx is cancelled. The caller closes the
+// device afterwards, which ends the background read.
+func (e *Emulator) Run(ctx context.Context, device io.ReadWriter) error {
+	e.device = device
+
+	chunks := make(chan []byte, 64)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		buf := make([]byte, readSize)
+		for {
+			n, err := device.Read(buf)
+			if n > 0 {
+				select {
+				case chunks <- bytes.Clone(buf[:n]):
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = ErrDeviceClosed
+				}
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	err := e.run(ctx, chunks, readErr)
+	if err != nil && ctx.Err() == nil {
+		e.emit(Event{Type: EventFailed, State: e.state, Message: err.Error(), Timestamp: time.Now()})
+		return err
+	}
+	return nil
+}
+
+// run is the emulator's main loop
+func (e *Emulator) run(ctx context.Context, chunks <-chan []byte, readErr <-chan error) error {
+	if err := e.enter(ctx, e.script.Initial); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		var tick <-chan time.Time
+		if next, ok := e.nextDue(); ok {
+			timer.Reset(time.Until(next))
+			tick = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case data := <-chunks:
+			if err := e.receive(ctx, data); err != nil {
+				return err
+			}
+		case <-tick:
+			if err := e.sendDue(); err != nil {
+				return err
+			}
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+	}
+}
+
+// enter switches to a state, sends its on_enter replies, and restarts the
+// periodic messages
+func (e *Emulator) enter(ctx context.Context, name string) error {
+	e.state = name
+	var state State
+	if name != "" {
+		state = e.script.States[name]
+		e.emit(Event{Type: EventState, State: name, Timestamp: time.Now()})
+	}
+
+	e.periodic = append(append([]Periodic(nil), state.Periodic...), e.script.Periodic...)
+	now := time.Now()
+	e.due = make([]time.Time, len(e.periodic))
+	for i, p := range e.periodic {
+		e.due[i] = now.Add(p.Every)
+	}
+
+	for _, reply := range state.OnEnter {
+		if err := e.sleep(ctx, reply.Delay); err != nil {
+			return err
+		}
+		if err := e.send("", reply.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextDue returns when the next periodic message is due
+func (e *Emulator) nextDue() (time.Time, bool) {
+	if len(e.due) == 0 {
+		return time.Time{}, false
+	}
+	next := e.due[0]
+	for _, t := range e.due[1:] {
+		if t.Before(next) {
+			next = t
+		}
+	}
+	return next, true
+}
+
+// sendDue sends the periodic messages that are due, in script order
+func (e *Emulator) sendDue() error {
+	now := time.Now()
+	for i, p := range e.periodic {
+		if e.due[i].After(now) {
+			continue
+		}
+		if err := e.send("", p.data); err != nil {
+			return err
+		}
+		// Skip intervals missed while busy rather than sending a burst
+		for !e.due[i].After(now) {
+			e.due[i] = e.due[i].Add(p.Every)
+		}
+	}
+	return nil
+}
+
+// receive buffers received data and answers every rule it completes
+func (e *Emulator) receive(ctx context.Context, data []byte) error {
+	e.emit(Event{Type: EventReceived, State: e.state, Data: data, Timestamp: time.Now()})
+	e.buffer = append(e.buffer, data...)
+
+	for {
+		rule, match := e.find()
+		if rule == nil {
+			break
+		}
+		input := e.buffer[:match[1]]
+		e.emit(Event{Type: EventMatched, State: e.state, Rule: rule.label(), Data: bytes.Clone(input[match[0]:]), Timestamp: time.Now()})
+
+		replies := e.replies(rule, input, match)
+		e.buffer = e.buffer[match[1]:]
+
+		for _, reply := range replies {
+			if err := e.sleep(ctx, reply.Delay); err != nil {
+				return err
+			}
+			if err := e.send(rule.label(), reply.data); err != nil {
+				return err
+			}
+		}
+
+		if rule.Goto != "" {
+			if err := e.enter(ctx, rule.Goto); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(e.buffer) > maxBuffer {
+		e.buffer = e.buffer[len(e.buffer)-maxBuffer:]
+	}
+	e.buffer = bytes.Clone(e.buffer)
+	return nil
+}
+
+// find returns the rule matching earliest in the buffer. The current
+// state's rules win ties over the top-level rules, and earlier rules over
+// later ones.
+func (e *Emulator) find() (*Rule, []int) {
+	var rules []*Rule
+	if state, ok := e.script.States[e.state]; ok {
+		for i := range state.Rules {
+			rules = append(rules, &state.Rules[i])
+		}
+	}
+	for i := range e.script.Rules {
+		rules = append(rules, &e.script.Rules[i])
+	}
+
+	var best *Rule
+	var bestMatch []int
+	for _, r := range rules {
+		match := r.find(e.buffer)
+		if match != nil && (best == nil || match[0] < bestMatch[0]) {
+			best, bestMatch = r, match
+		}
+	}
+	return best, bestMatch
+}
+
+// replies expands a rule's responses for a match
+func (e *Emulator) replies(r *Rule, input []byte, match []int) []Reply {
+	var replies []Reply
+	if r.Respond != "" {
+		replies = append(replies, Reply{Delay: r.Delay, data: r.expand(r.Respond, input, match)})
+	}
+	if r.RespondHex != "" {
+		data, _ := hex.DecodeString(r.RespondHex)
+		replies = append(replies, Reply{Delay: r.Delay, data: data})
+	}
+	for _, reply := range r.Replies {
+		if reply.Send != "" {
+			reply.data = r.expand(reply.Send, input, match)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// send writes data to the device
+func (e *Emulator) send(rule string, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if _, err := e.device.Write(data); err != nil {
+		return err
+	}
+	e.emit(Event{Type: EventSent, State: e.state, Rule: rule, Data: data, Timestamp: time.Now()})
+	return nil
+}
+
+// sleep pauses for a reply delay
+func (e *Emulator) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
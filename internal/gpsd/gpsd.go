@@ -0,0 +1,462 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gpsd serves GPS receivers on managed ports to gpsd clients such
+// as gpspipe, cgps, and navigation apps. NMEA sentences received on the
+// configured ports are turned into gpsd's JSON reports and streamed to
+// clients over a TCP listener speaking the gpsd protocol.
+package gpsd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// DefaultAddress is gpsd's well-known listen address
+const DefaultAddress = "127.0.0.1:2947"
+
+// Protocol version reported to clients
+const (
+	protoMajor = 3
+	protoMinor = 14
+)
+
+// clientQueue is the number of reports waiting for a slow client before
+// further reports to it are dropped
+const clientQueue = 256
+
+// maxCommand bounds a client command
+const maxCommand = 4096
+
+// Options configures the server
+type Options struct {
+	Address string   // Listen address (default: DefaultAddress)
+	Ports   []string // GPS ports, by name or alias
+	Version string   // Agent version reported in VERSION
+	// Alias returns the alias of a port, if any, so ports can be named by alias
+	Alias func(portName string) string
+}
+
+// watch is a client's WATCH settings, reported back in the gpsd format
+type watch struct {
+	Class  string `json:"class"`
+	Enable bool   `json:"enable"`
+	JSON   bool   `json:"json"`
+	NMEA   bool   `json:"nmea"`
+	Device string `json:"device,omitempty"`
+}
+
+// client is a connected gpsd client
+type client struct {
+	conn net.Conn
+	out  chan []byte
+
+	// mu guards watch, changed by the client's commands
+	mu    sync.Mutex
+	watch watch
+}
+
+// Server turns NMEA sentences from GPS ports into gpsd reports
+type Server struct {
+	manager *serial.Manager
+	opts    Options
+
+	listener net.Listener
+
+	// mu guards receivers, framers, and clients
+	mu        sync.Mutex
+	receivers map[string]*receiver // key: port name
+	framers   map[string]serial.Framer
+	clients   map[*client]struct{}
+
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// New creates a server for the given ports
+func New(manager *serial.Manager, opts Options) (*Server, error) {
+	if len(opts.Ports) == 0 {
+		return nil, fmt.Errorf("at least one GPS port is required")
+	}
+	if opts.Address == "" {
+		opts.Address = DefaultAddress
+	}
+	if opts.Alias == nil {
+		opts.Alias = func(string) string { return "" }
+	}
+
+	return &Server{
+		manager:   manager,
+		opts:      opts,
+		receivers: make(map[string]*receiver),
+		framers:   make(map[string]serial.Framer),
+		clients:   make(map[*client]struct{}),
+	}, nil
+}
+
+// Start listens for clients and begins parsing received data
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.opts.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.opts.Address, err)
+	}
+	s.listener = listener
+
+	data, cancel := s.manager.Monitor().Subscribe()
+	s.cancel = cancel
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		for chunk := range data {
+			s.feed(chunk)
+		}
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.acceptLoop()
+	}()
+
+	return nil
+}
+
+// Addr returns the listening address
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Stop closes the listener and every client connection
+func (s *Server) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.listener != nil {
+		s.listener.Close()
+	}
+
+	s.mu.Lock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// applies reports whether a port is one of the GPS ports
+func (s *Server) applies(portName string) bool {
+	alias := s.opts.Alias(portName)
+	for _, p := range s.opts.Ports {
+		if p == portName || (alias != "" && p == alias) {
+			return true
+		}
+	}
+	return false
+}
+
+// feed parses a chunk of received data and sends the resulting reports
+func (s *Server) feed(chunk serial.PortData) {
+	if !s.applies(chunk.PortName) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	framer := s.framers[chunk.PortName]
+	if framer == nil {
+		framer, _ = serial.NewFramer(serial.FramerNMEA)
+		s.framers[chunk.PortName] = framer
+	}
+
+	for _, frame := range framer.Feed(chunk.Data) {
+		if frame.Status != serial.FrameOK {
+			continue
+		}
+
+		r := s.receivers[chunk.PortName]
+		if r == nil {
+			r = newReceiver(chunk.PortName)
+			s.receivers[chunk.PortName] = r
+			s.broadcastLocked(r.path, false, marshal(r.device()))
+		}
+
+		s.broadcastLocked(r.path, true, append(frame.Data, '\r', '\n'))
+		for _, report := range r.handle(frame.Data) {
+			s.broadcastLocked(r.path, false, marshal(report))
+		}
+	}
+}
+
+// broadcastLocked queues a line for every client watching the device in
+// the matching mode. Clients too slow to keep up miss the line.
+func (s *Server) broadcastLocked(device string, nmea bool, line []byte) {
+	for c := range s.clients {
+		c.mu.Lock()
+		w := c.watch
+		c.mu.Unlock()
+
+		if !w.Enable || (w.Device != "" && w.Device != device) {
+			continue
+		}
+		if (nmea && !w.NMEA) || (!nmea && !w.JSON) {
+			continue
+		}
+		c.send(line)
+	}
+}
+
+// acceptLoop serves clients until the listener closes
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &client{
+			conn:  conn,
+			out:   make(chan []byte, clientQueue),
+			watch: watch{Class: "WATCH"},
+		}
+
+		s.mu.Lock()
+		s.clients[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(2)
+		go func() {
+			defer s.wg.Done()
+			c.writeLoop()
+		}()
+		go func() {
+			defer s.wg.Done()
+			s.serve(c)
+
+			s.mu.Lock()
+			delete(s.clients, c)
+			s.mu.Unlock()
+			close(c.out)
+			conn.Close()
+		}()
+	}
+}
+
+// serve greets a client and answers its commands until it disconnects
+func (s *Server) serve(c *client) {
+	c.send(marshal(s.version()))
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 512), maxCommand)
+	scanner.Split(splitCommands)
+
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		if command == "" {
+			continue
+		}
+		for _, reply := range s.handle(c, command) {
+			c.send(marshal(reply))
+		}
+	}
+}
+
+// handle runs a client command and returns the replies
+func (s *Server) handle(c *client, command string) []interface{} {
+	name, arg, _ := strings.Cut(command, "=")
+
+	switch name {
+	case "?VERSION":
+		return []interface{}{s.version()}
+
+	case "?DEVICES":
+		return []interface{}{s.devices()}
+
+	case "?WATCH":
+		c.mu.Lock()
+		w := c.watch
+		c.mu.Unlock()
+
+		if arg != "" {
+			// Enabling is implied unless the client says otherwise
+			req := struct {
+				Enable *bool   `json:"enable"`
+				JSON   *bool   `json:"json"`
+				NMEA   *bool   `json:"nmea"`
+				Device *string `json:"device"`
+			}{}
+			if err := json.Unmarshal([]byte(arg), &req); err != nil {
+				return []interface{}{errorReport("Invalid WATCH: " + err.Error())}
+			}
+
+			w.Enable = req.Enable == nil || *req.Enable
+			if req.JSON != nil {
+				w.JSON = *req.JSON
+			}
+			if req.NMEA != nil {
+				w.NMEA = *req.NMEA
+			}
+			if req.Device != nil {
+				w.Device = *req.Device
+			}
+			if w.Enable && !w.JSON && !w.NMEA {
+				w.JSON = true
+			}
+
+			c.mu.Lock()
+			c.watch = w
+			c.mu.Unlock()
+		}
+
+		if w.Enable {
+			return []interface{}{s.devices(), w}
+		}
+		return []interface{}{w}
+
+	case "?POLL":
+		return []interface{}{s.poll()}
+
+	default:
+		return []interface{}{errorReport(fmt.Sprintf("Unrecognized request '%s'", name))}
+	}
+}
+
+// version returns the VERSION report
+func (s *Server) version() interface{} {
+	return struct {
+		Class      string `json:"class"`
+		Release    string `json:"release"`
+		Rev        string `json:"rev"`
+		ProtoMajor int    `json:"proto_major"`
+		ProtoMinor int    `json:"proto_minor"`
+	}{"VERSION", s.opts.Version, "baudlink " + s.opts.Version, protoMajor, protoMinor}
+}
+
+// device returns the DEVICE report of a receiver
+func (r *receiver) device() interface{} {
+	return struct {
+		Class     string `json:"class"`
+		Path      string `json:"path"`
+		Driver    string `json:"driver"`
+		Activated string `json:"activated"`
+		Flags     int    `json:"flags"`
+	}{"DEVICE", r.path, "NMEA0183", r.activated.UTC().Format(timeFormat), 1}
+}
+
+// devices returns the DEVICES report of the receivers seen so far
+func (s *Server) devices() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	devices := []interface{}{}
+	for _, r := range s.sortedReceiversLocked() {
+		devices = append(devices, r.device())
+	}
+	return struct {
+		Class   string        `json:"class"`
+		Devices []interface{} `json:"devices"`
+	}{"DEVICES", devices}
+}
+
+// poll returns the POLL report with the latest fix of every receiver
+func (s *Server) poll() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tpv := []TPV{}
+	sky := []SKY{}
+	for _, r := range s.sortedReceiversLocked() {
+		tpv = append(tpv, r.tpv)
+		sky = append(sky, r.sky)
+	}
+	return struct {
+		Class  string `json:"class"`
+		Time   string `json:"time"`
+		Active int    `json:"active"`
+		TPV    []TPV  `json:"tpv"`
+		SKY    []SKY  `json:"sky"`
+	}{"POLL", time.Now().UTC().Format(timeFormat), len(tpv), tpv, sky}
+}
+
+// sortedReceiversLocked returns the receivers ordered by port name
+func (s *Server) sortedReceiversLocked() []*receiver {
+	receivers := make([]*receiver, 0, len(s.receivers))
+	for _, r := range s.receivers {
+		receivers = append(receivers, r)
+	}
+	sort.Slice(receivers, func(i, j int) bool { return receivers[i].path < receivers[j].path })
+	return receivers
+}
+
+// send queues a line for the client, dropping it if the client is too slow
+func (c *client) send(line []byte) {
+	select {
+	case c.out <- line:
+	default:
+	}
+}
+
+// writeLoop writes queued lines until the queue closes
+func (c *client) writeLoop() {
+	for line := range c.out {
+		if _, err := c.conn.Write(line); err != nil {
+			c.conn.Close()
+			for range c.out {
+			}
+			return
+		}
+	}
+}
+
+// errorReport returns an ERROR report
+func errorReport(message string) interface{} {
+	return struct {
+		Class   string `json:"class"`
+		Message string `json:"message"`
+	}{"ERROR", message}
+}
+
+// marshal encodes a report as a JSON line
+func marshal(report interface{}) []byte {
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("gpsd: failed to encode report: %v", err)
+		return nil
+	}
+	return append(data, '\r', '\n')
+}
+
+// splitCommands splits client input into commands terminated by ';' or a
+// newline
+func splitCommands(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, ";\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,456 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpsd
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// knotsToMPS converts speeds over ground from knots to metres per second
+const knotsToMPS = 0.514444
+
+// timeFormat is the ISO 8601 form gpsd reports times in
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// Fix modes reported in TPV
+const (
+	ModeUnknown = 0
+	ModeNoFix   = 1
+	Mode2D      = 2
+	Mode3D      = 3
+)
+
+// TPV is a gpsd time-position-velocity report. Values the receiver has not
+// reported are omitted.
+type TPV struct {
+	Class    string   `json:"class"`
+	Device   string   `json:"device"`
+	Mode     int      `json:"mode"`
+	Status   int      `json:"status,omitempty"` // 2 = DGPS fix
+	Time     string   `json:"time,omitempty"`
+	Lat      *float64 `json:"lat,omitempty"`
+	Lon      *float64 `json:"lon,omitempty"`
+	Alt      *float64 `json:"alt,omitempty"` // Same as altMSL, for older clients
+	AltMSL   *float64 `json:"altMSL,omitempty"`
+	AltHAE   *float64 `json:"altHAE,omitempty"`
+	GeoidSep *float64 `json:"geoidSep,omitempty"`
+	Speed    *float64 `json:"speed,omitempty"` // Metres per second
+	Track    *float64 `json:"track,omitempty"` // Degrees from true north
+}
+
+// SKY is a gpsd sky view report
+type SKY struct {
+	Class      string      `json:"class"`
+	Device     string      `json:"device"`
+	Time       string      `json:"time,omitempty"`
+	HDOP       *float64    `json:"hdop,omitempty"`
+	VDOP       *float64    `json:"vdop,omitempty"`
+	PDOP       *float64    `json:"pdop,omitempty"`
+	NSat       int         `json:"nSat"`
+	USat       int         `json:"uSat"`
+	Satellites []Satellite `json:"satellites"`
+}
+
+// Satellite is a satellite in view
+type Satellite struct {
+	PRN  int      `json:"PRN"`
+	El   *float64 `json:"el,omitempty"`
+	Az   *float64 `json:"az,omitempty"`
+	SS   *float64 `json:"ss,omitempty"` // Signal to noise ratio in dB-Hz
+	Used bool     `json:"used"`
+}
+
+// receiver holds the state built up from one port's NMEA sentences
+type receiver struct {
+	path      string
+	activated time.Time
+
+	tpv     TPV
+	sky     SKY
+	date    time.Time // UTC date of the last RMC or ZDA sentence
+	sawRMC  bool      // Report TPV on RMC only once the receiver sends it
+	gsaMode int       // Fix mode of the last GSA sentence
+
+	// Satellites of GSV groups being received and of complete groups, by
+	// talker, and the PRNs used in the fix according to GSA
+	partial   map[string][]Satellite
+	inView    map[string][]Satellite
+	used      map[int]bool
+	usedStale bool
+}
+
+// newReceiver creates the state of a receiver on a port
+func newReceiver(path string) *receiver {
+	return &receiver{
+		path:      path,
+		activated: time.Now(),
+		tpv:       TPV{Class: "TPV", Device: path},
+		sky:       SKY{Class: "SKY", Device: path, Satellites: []Satellite{}},
+		partial:   make(map[string][]Satellite),
+		inView:    make(map[string][]Satellite),
+		used:      make(map[int]bool),
+	}
+}
+
+// handle updates the receiver from a checksum-verified sentence and returns
+// the reports it completes
+func (r *receiver) handle(sentence []byte) []interface{} {
+	talker, kind, fields, ok := splitSentence(string(sentence))
+	if !ok {
+		return nil
+	}
+
+	switch kind {
+	case "RMC":
+		r.sawRMC = true
+		r.handleRMC(fields)
+		return []interface{}{r.tpv}
+	case "GGA":
+		r.handleGGA(fields)
+		if !r.sawRMC {
+			return []interface{}{r.tpv}
+		}
+	case "GSA":
+		r.handleGSA(fields)
+	case "VTG":
+		r.handleVTG(fields)
+	case "ZDA":
+		r.handleZDA(fields)
+	case "GSV":
+		if r.handleGSV(talker, fields) {
+			return []interface{}{r.skyReport()}
+		}
+	}
+	return nil
+}
+
+// handleRMC reads time, date, position, speed, and track
+func (r *receiver) handleRMC(f []string) {
+	if len(f) < 9 {
+		return
+	}
+	if date, ok := parseDate(field(f, 8)); ok {
+		r.date = date
+	}
+	r.setTime(field(f, 0))
+
+	if field(f, 1) != "A" {
+		r.clearFix()
+		return
+	}
+	r.setPosition(f[2], f[3], f[4], f[5])
+	if r.tpv.Mode < Mode2D {
+		r.tpv.Mode = Mode2D
+	}
+	if r.gsaMode >= Mode2D {
+		r.tpv.Mode = r.gsaMode
+	}
+	if v, ok := parseFloat(f[6]); ok {
+		r.tpv.Speed = ptr(round(v*knotsToMPS, 3))
+	}
+	if v, ok := parseFloat(f[7]); ok {
+		r.tpv.Track = ptr(v)
+	}
+}
+
+// handleGGA reads time, position, fix quality, and altitude
+func (r *receiver) handleGGA(f []string) {
+	if len(f) < 11 {
+		return
+	}
+	r.setTime(f[0])
+
+	quality, _ := strconv.Atoi(f[5])
+	if quality == 0 {
+		r.clearFix()
+		return
+	}
+	r.setPosition(f[1], f[2], f[3], f[4])
+	r.tpv.Status = 0
+	if quality == 2 {
+		r.tpv.Status = 2
+	}
+
+	alt, hasAlt := parseFloat(f[8])
+	sep, hasSep := parseFloat(field(f, 10))
+	r.tpv.Alt, r.tpv.AltMSL, r.tpv.AltHAE, r.tpv.GeoidSep = nil, nil, nil, nil
+	if hasAlt {
+		r.tpv.Alt = ptr(alt)
+		r.tpv.AltMSL = ptr(alt)
+		if hasSep {
+			r.tpv.AltHAE = ptr(round(alt+sep, 3))
+			r.tpv.GeoidSep = ptr(sep)
+		}
+	}
+
+	switch {
+	case r.gsaMode >= Mode2D:
+		r.tpv.Mode = r.gsaMode
+	case hasAlt:
+		r.tpv.Mode = Mode3D
+	default:
+		r.tpv.Mode = Mode2D
+	}
+	if r.tpv.Mode < Mode3D {
+		r.tpv.Alt, r.tpv.AltMSL, r.tpv.AltHAE = nil, nil, nil
+	}
+}
+
+// handleGSA reads the fix mode, the satellites used, and the dilutions of
+// precision
+func (r *receiver) handleGSA(f []string) {
+	if len(f) < 17 {
+		return
+	}
+	if mode, err := strconv.Atoi(f[1]); err == nil && mode >= ModeNoFix && mode <= Mode3D {
+		r.gsaMode = mode
+	}
+
+	// A new epoch's GSA sentences replace the satellites used in the last
+	if r.usedStale {
+		r.used = make(map[int]bool)
+		r.usedStale = false
+	}
+	for _, s := range f[2:14] {
+		if prn, err := strconv.Atoi(s); err == nil {
+			r.used[prn] = true
+		}
+	}
+
+	r.sky.PDOP = optional(f[14])
+	r.sky.HDOP = optional(f[15])
+	r.sky.VDOP = optional(f[16])
+}
+
+// handleVTG reads track and speed
+func (r *receiver) handleVTG(f []string) {
+	if len(f) < 7 {
+		return
+	}
+	if v, ok := parseFloat(f[0]); ok {
+		r.tpv.Track = ptr(v)
+	}
+	if v, ok := parseFloat(f[4]); ok {
+		r.tpv.Speed = ptr(round(v*knotsToMPS, 3))
+	}
+}
+
+// handleZDA reads the date
+func (r *receiver) handleZDA(f []string) {
+	if len(f) < 4 {
+		return
+	}
+	day, err1 := strconv.Atoi(f[1])
+	month, err2 := strconv.Atoi(f[2])
+	year, err3 := strconv.Atoi(f[3])
+	if err1 == nil && err2 == nil && err3 == nil {
+		r.date = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+	r.setTime(f[0])
+}
+
+// handleGSV collects a talker's satellites in view and reports whether the
+// sentence completed its group
+func (r *receiver) handleGSV(talker string, f []string) bool {
+	if len(f) < 3 {
+		return false
+	}
+	total, err1 := strconv.Atoi(f[0])
+	number, err2 := strconv.Atoi(f[1])
+	if err1 != nil || err2 != nil || number < 1 || number > total {
+		return false
+	}
+	if number == 1 {
+		r.partial[talker] = nil
+	}
+
+	for i := 3; i+2 < len(f); i += 4 {
+		prn, err := strconv.Atoi(f[i])
+		if err != nil {
+			continue
+		}
+		sat := Satellite{PRN: prn, El: optional(f[i+1]), Az: optional(f[i+2])}
+		if i+3 < len(f) {
+			sat.SS = optional(f[i+3])
+		}
+		r.partial[talker] = append(r.partial[talker], sat)
+	}
+
+	if number != total {
+		return false
+	}
+	r.inView[talker] = r.partial[talker]
+	delete(r.partial, talker)
+	r.usedStale = true
+	return true
+}
+
+// skyReport returns the current sky view
+func (r *receiver) skyReport() SKY {
+	sky := r.sky
+	sky.Time = r.tpv.Time
+	sky.Satellites = []Satellite{}
+	for _, sats := range r.inView {
+		for _, s := range sats {
+			s.Used = r.used[s.PRN]
+			sky.Satellites = append(sky.Satellites, s)
+		}
+	}
+	sortSatellites(sky.Satellites)
+
+	sky.NSat = len(sky.Satellites)
+	sky.USat = 0
+	for _, s := range sky.Satellites {
+		if s.Used {
+			sky.USat++
+		}
+	}
+	r.sky = sky
+	return sky
+}
+
+// sortSatellites orders satellites by PRN
+func sortSatellites(sats []Satellite) {
+	sort.Slice(sats, func(i, j int) bool { return sats[i].PRN < sats[j].PRN })
+}
+
+// setTime sets the report time from an hhmmss.ss field and the last date.
+// Before a date is known, today's UTC date is assumed.
+func (r *receiver) setTime(value string) {
+	if len(value) < 6 {
+		return
+	}
+	h, err1 := strconv.Atoi(value[0:2])
+	m, err2 := strconv.Atoi(value[2:4])
+	s, err3 := strconv.ParseFloat(value[4:], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return
+	}
+
+	date := r.date
+	if date.IsZero() {
+		date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	whole := math.Floor(s)
+	t := time.Date(date.Year(), date.Month(), date.Day(), h, m, int(whole), int((s-whole)*1e9+0.5), time.UTC)
+	r.tpv.Time = t.Format(timeFormat)
+}
+
+// setPosition sets latitude and longitude from NMEA fields
+func (r *receiver) setPosition(lat, latHemi, lon, lonHemi string) {
+	if v, ok := parseCoordinate(lat, latHemi); ok {
+		r.tpv.Lat = ptr(v)
+	}
+	if v, ok := parseCoordinate(lon, lonHemi); ok {
+		r.tpv.Lon = ptr(v)
+	}
+}
+
+// clearFix resets the report to no fix, keeping its time
+func (r *receiver) clearFix() {
+	r.tpv = TPV{Class: "TPV", Device: r.path, Mode: ModeNoFix, Time: r.tpv.Time}
+}
+
+// splitSentence splits "$GPRMC,...*hh" into its talker, sentence type, and
+// fields. Proprietary and encapsulated sentences are not split.
+func splitSentence(s string) (talker, kind string, fields []string, ok bool) {
+	if len(s) < 7 || s[0] != '$' || s[1] == 'P' {
+		return "", "", nil, false
+	}
+	if star := strings.LastIndexByte(s, '*'); star >= 0 {
+		s = s[:star]
+	}
+	parts := strings.Split(s[1:], ",")
+	if len(parts[0]) != 5 {
+		return "", "", nil, false
+	}
+	return parts[0][:2], parts[0][2:], parts[1:], true
+}
+
+// parseCoordinate converts a ddmm.mmmm or dddmm.mmmm field and hemisphere to
+// signed decimal degrees
+func parseCoordinate(value, hemi string) (float64, bool) {
+	v, ok := parseFloat(value)
+	if !ok {
+		return 0, false
+	}
+	degrees := math.Floor(v / 100)
+	result := degrees + (v-degrees*100)/60
+	if hemi == "S" || hemi == "W" {
+		result = -result
+	}
+	return round(result, 9), true
+}
+
+// parseDate converts a ddmmyy field to a UTC date
+func parseDate(value string) (time.Time, bool) {
+	if len(value) != 6 {
+		return time.Time{}, false
+	}
+	d, err1 := strconv.Atoi(value[0:2])
+	m, err2 := strconv.Atoi(value[2:4])
+	y, err3 := strconv.Atoi(value[4:6])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return time.Time{}, false
+	}
+	if y < 80 {
+		y += 2000
+	} else {
+		y += 1900
+	}
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), true
+}
+
+// field returns the i-th field, or an empty string
+func field(f []string, i int) string {
+	if i < len(f) {
+		return f[i]
+	}
+	return ""
+}
+
+// parseFloat parses a numeric field, reporting false for empty fields
+func parseFloat(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	return v, err == nil
+}
+
+// optional returns a numeric field, or nil when it is empty
+func optional(value string) *float64 {
+	if v, ok := parseFloat(value); ok {
+		return ptr(v)
+	}
+	return nil
+}
+
+// ptr returns a pointer to v
+func ptr(v float64) *float64 {
+	return &v
+}
+
+// round rounds v to the given number of decimal places
+func round(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/audit"
+)
+
+// RecordAudit stores an audit entry
+func (s *Store) RecordAudit(e audit.Entry) {
+	if s == nil {
+		return
+	}
+	s.enqueue(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO audit
+			(time, operation, identity, client_id, peer, port_name, session_id, bytes, success, message, reason)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.Time.UnixNano(), e.Operation, e.Identity, e.ClientID, e.Peer, e.PortName, e.SessionID,
+			int64(e.Bytes), e.Success, e.Message, e.Reason)
+		return err
+	})
+}
+
+// QueryAudit returns the most recent audit entries matching filter, oldest
+// first
+func (s *Store) QueryAudit(filter audit.Filter) ([]audit.Entry, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var q query
+	if !filter.Since.IsZero() {
+		q.where("time >= ?", filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		q.where("time <= ?", filter.Until.UnixNano())
+	}
+	if filter.PortName != "" {
+		q.where("port_name = ?", filter.PortName)
+	}
+	if filter.Identity != "" {
+		q.where("identity = ?", filter.Identity)
+	}
+	if filter.Operation != "" {
+		q.where("operation = ?", filter.Operation)
+	}
+
+	rows, err := s.db.Query(`SELECT time, operation, identity, client_id, peer, port_name, session_id,
+		bytes, success, message, reason FROM audit`+q.clause()+
+		` ORDER BY time DESC LIMIT ?`, append(q.args, queryLimit(filter.Limit))...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []audit.Entry
+	for rows.Next() {
+		var e audit.Entry
+		var at, bytes int64
+		if err := rows.Scan(&at, &e.Operation, &e.Identity, &e.ClientID, &e.Peer, &e.PortName, &e.SessionID,
+			&bytes, &e.Success, &e.Message, &e.Reason); err != nil {
+			return nil, err
+		}
+		e.Time = time.Unix(0, at)
+		e.Bytes = uint64(bytes)
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.Reverse(entries)
+
+	return entries, nil
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history keeps past sessions with their byte counters, the
+// captures taps wrote, and audit entries in an embedded SQLite database, so
+// they survive agent restarts. The driver is pure Go, so builds without cgo
+// keep working.
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// DefaultQueryLimit is the number of records a query returns without a limit
+const DefaultQueryLimit = 1000
+
+// checkpointInterval is how often the counters of open sessions and taps
+// are saved, bounding what a crash loses
+const checkpointInterval = 30 * time.Second
+
+// retentionInterval is how often records past the retention are deleted
+const retentionInterval = time.Hour
+
+// Writes are queued so that callers holding the manager's lock never wait
+// on the disk; the writer commits whatever is queued in one transaction
+const (
+	writeQueueSize = 1024
+	maxBatch       = 256
+)
+
+// migrations create the schema. Each runs once, in order, and the number
+// applied is kept in the database's user_version.
+var migrations = []string{
+	`CREATE TABLE sessions (
+		id             TEXT PRIMARY KEY,
+		port_name      TEXT NOT NULL,
+		client_id      TEXT NOT NULL,
+		exclusive      INTEGER NOT NULL,
+		managed        INTEGER NOT NULL,
+		opened_at      INTEGER NOT NULL,
+		closed_at      INTEGER,
+		updated_at     INTEGER NOT NULL,
+		bytes_sent     INTEGER NOT NULL DEFAULT 0,
+		bytes_received INTEGER NOT NULL DEFAULT 0,
+		errors         INTEGER NOT NULL DEFAULT 0,
+		interrupted    INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX sessions_opened_at ON sessions (opened_at);
+	CREATE INDEX sessions_port_name ON sessions (port_name, opened_at);
+	CREATE TABLE captures (
+		tap_id          TEXT PRIMARY KEY,
+		session_id      TEXT NOT NULL,
+		port_name       TEXT NOT NULL,
+		file            TEXT NOT NULL,
+		tcp_address     TEXT NOT NULL,
+		direction       INTEGER NOT NULL,
+		started_at      INTEGER NOT NULL,
+		stopped_at      INTEGER,
+		updated_at      INTEGER NOT NULL,
+		bytes_written   INTEGER NOT NULL DEFAULT 0,
+		records_dropped INTEGER NOT NULL DEFAULT 0,
+		interrupted     INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX captures_started_at ON captures (started_at);
+	CREATE INDEX captures_session_id ON captures (session_id);
+	CREATE TABLE audit (
+		time       INTEGER NOT NULL,
+		operation  TEXT NOT NULL,
+		identity   TEXT NOT NULL,
+		client_id  TEXT NOT NULL,
+		peer       TEXT NOT NULL,
+		port_name  TEXT NOT NULL,
+		session_id TEXT NOT NULL,
+		bytes      INTEGER NOT NULL,
+		success    INTEGER NOT NULL,
+		message    TEXT NOT NULL,
+		reason     TEXT NOT NULL
+	);
+	CREATE INDEX audit_time ON audit (time);
+	CREATE INDEX audit_port_name ON audit (port_name, time);`,
+	`ALTER TABLE sessions ADD COLUMN sniff INTEGER NOT NULL DEFAULT 0;`,
+}
+
+// Store is the history database. It observes the manager's sessions and
+// taps and stores the audit log's entries; a nil Store records nothing.
+type Store struct {
+	db        *sql.DB
+	retention time.Duration
+
+	// mu guards the open records and the write queue
+	mu       sync.Mutex
+	sessions map[*serial.Session]*openSession
+	taps     map[*serial.Tap]*openTap
+	writes   chan func(*sql.Tx) error
+	closed   bool
+	dropping bool // Writes are being dropped because the queue is full
+
+	stop   chan struct{}
+	wg     sync.WaitGroup
+	writer sync.WaitGroup
+}
+
+// Open opens or creates the database at path. Records older than retention
+// are deleted; zero keeps them forever. Sessions and captures a previous
+// run left open, because the agent crashed or was killed, are closed as of
+// their last checkpoint and marked interrupted.
+func Open(path string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)")
+	if err != nil {
+		return nil, err
+	}
+	// One connection serializes the writer and queries, so SQLite never
+	// reports the database busy
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate history database: %w", err)
+	}
+	// Audit entries name identities and peers
+	if err := os.Chmod(path, 0600); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := closeInterrupted(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Store{
+		db:        db,
+		retention: retention,
+		sessions:  make(map[*serial.Session]*openSession),
+		taps:      make(map[*serial.Tap]*openTap),
+		writes:    make(chan func(*sql.Tx) error, writeQueueSize),
+		stop:      make(chan struct{}),
+	}
+	s.expire()
+
+	s.writer.Add(1)
+	go s.write()
+	s.wg.Add(1)
+	go s.maintain()
+
+	return s, nil
+}
+
+// migrate applies the migrations the database has not seen
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this agent supports (%d)", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+		// PRAGMA takes no parameters
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeInterrupted closes the records a previous run left open
+func closeInterrupted(db *sql.DB) error {
+	if _, err := db.Exec(`UPDATE sessions SET closed_at = updated_at, interrupted = 1 WHERE closed_at IS NULL`); err != nil {
+		return err
+	}
+	_, err := db.Exec(`UPDATE captures SET stopped_at = updated_at, interrupted = 1 WHERE stopped_at IS NULL`)
+	return err
+}
+
+// Close saves the counters of records still open and closes the database.
+// Sessions still open stay open in the database and are closed as
+// interrupted when it is next opened, so close the manager's sessions first.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+
+	close(s.stop)
+	s.wg.Wait()
+	s.checkpoint()
+
+	s.mu.Lock()
+	s.closed = true
+	close(s.writes)
+	s.mu.Unlock()
+
+	s.writer.Wait()
+	return s.db.Close()
+}
+
+// enqueueLocked queues a write without blocking, dropping it when the queue
+// is full (must be called with mu held)
+func (s *Store) enqueueLocked(op func(*sql.Tx) error) {
+	if s.closed {
+		return
+	}
+	select {
+	case s.writes <- op:
+		s.dropping = false
+	default:
+		if !s.dropping {
+			log.Printf("history: write queue full, dropping records")
+			s.dropping = true
+		}
+	}
+}
+
+// enqueue queues a write
+func (s *Store) enqueue(op func(*sql.Tx) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enqueueLocked(op)
+}
+
+// write commits queued writes until the queue is closed
+func (s *Store) write() {
+	defer s.writer.Done()
+
+	for op := range s.writes {
+		batch := []func(*sql.Tx) error{op}
+	drain:
+		for len(batch) < maxBatch {
+			select {
+			case op, ok := <-s.writes:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, op)
+			default:
+				break drain
+			}
+		}
+
+		if err := s.commit(batch); err != nil {
+			log.Printf("history: failed to write records: %v", err)
+		}
+	}
+}
+
+// commit runs a batch of writes in one transaction. A failing write is
+// logged and skipped rather than losing the rest of the batch.
+func (s *Store) commit(batch []func(*sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, op := range batch {
+		if err := op(tx); err != nil {
+			log.Printf("history: failed to write record: %v", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// maintain checkpoints counters and applies the retention until Close
+func (s *Store) maintain() {
+	defer s.wg.Done()
+
+	checkpoint := time.NewTicker(checkpointInterval)
+	defer checkpoint.Stop()
+	retention := time.NewTicker(retentionInterval)
+	defer retention.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-checkpoint.C:
+			s.checkpoint()
+		case <-retention.C:
+			s.expire()
+		}
+	}
+}
+
+// expire deletes records older than the retention
+func (s *Store) expire() {
+	if s.retention <= 0 {
+		return
+	}
+	cutoff := time.Now().Add(-s.retention).UnixNano()
+
+	s.enqueue(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM sessions WHERE closed_at IS NOT NULL AND closed_at < ?`, cutoff); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM captures WHERE stopped_at IS NOT NULL AND stopped_at < ?`, cutoff); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`DELETE FROM audit WHERE time < ?`, cutoff)
+		return err
+	})
+}
+
+// query builds the WHERE clause of a query from conditions whose argument
+// is set
+type query struct {
+	conds []string
+	args  []interface{}
+}
+
+// where adds a condition
+func (q *query) where(cond string, args ...interface{}) {
+	q.conds = append(q.conds, cond)
+	q.args = append(q.args, args...)
+}
+
+// clause returns the WHERE clause, or an empty string
+func (q *query) clause() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// queryLimit returns the number of records a query returns
+func queryLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultQueryLimit
+	}
+	return limit
+}
+
+// fromUnixNano converts a column value to a time, with the zero time for NULL
+func fromUnixNano(v sql.NullInt64) time.Time {
+	if !v.Valid {
+		return time.Time{}
+	}
+	return time.Unix(0, v.Int64)
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// SessionRecord is a session the agent had open
+type SessionRecord struct {
+	ID            string
+	PortName      string
+	ClientID      string
+	Exclusive     bool
+	Managed       bool
+	Sniff         bool
+	OpenedAt      time.Time
+	ClosedAt      time.Time // Zero while the session is open
+	BytesSent     uint64
+	BytesReceived uint64
+	Errors        uint64
+	Interrupted   bool // The agent stopped without closing the session
+}
+
+// SessionFilter selects sessions returned by Sessions. Zero fields match
+// everything.
+type SessionFilter struct {
+	Since    time.Time // Sessions open at or after this time
+	Until    time.Time // Sessions opened at or before this time
+	PortName string
+	ClientID string
+	Limit    int
+}
+
+// SessionTotals sums the counters of every session matching a filter
+type SessionTotals struct {
+	Sessions      uint64
+	BytesSent     uint64
+	BytesReceived uint64
+	Errors        uint64
+}
+
+// CaptureRecord is a tap's capture of a session's traffic
+type CaptureRecord struct {
+	TapID          string
+	SessionID      string
+	PortName       string
+	File           string // Path relative to the tap directory
+	TCPAddress     string
+	Direction      serial.TapDirection
+	StartedAt      time.Time
+	StoppedAt      time.Time // Zero while the tap is running
+	BytesWritten   uint64
+	RecordsDropped uint64
+	Interrupted    bool // The agent stopped without removing the tap
+}
+
+// CaptureFilter selects captures returned by Captures. Zero fields match
+// everything.
+type CaptureFilter struct {
+	Since     time.Time // Captures running at or after this time
+	Until     time.Time // Captures started at or before this time
+	PortName  string
+	SessionID string
+	Limit     int
+}
+
+// openSession is a session being recorded. Statistics carry over a
+// takeover, so counters are stored relative to their value when the
+// session started.
+type openSession struct {
+	id       string
+	portName string
+	clientID string
+	openedAt time.Time
+	base     serial.PortStatistics
+	saved    SessionRecord // Counters last written to the database
+}
+
+// openTap is a tap being recorded
+type openTap struct {
+	id string
+}
+
+// SessionOpened starts a session's record
+func (s *Store) SessionOpened(session *serial.Session) {
+	if s == nil {
+		return
+	}
+	rec := SessionRecord{
+		ID:        session.ID,
+		PortName:  session.PortName,
+		ClientID:  session.ClientID,
+		Exclusive: session.Exclusive,
+		Managed:   session.Managed,
+		Sniff:     session.IsSniff(),
+		OpenedAt:  time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session] = &openSession{
+		id:       rec.ID,
+		portName: rec.PortName,
+		clientID: rec.ClientID,
+		openedAt: rec.OpenedAt,
+		base:     session.Statistics.Snapshot(),
+	}
+	s.enqueueLocked(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO sessions
+			(id, port_name, client_id, exclusive, managed, sniff, opened_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			rec.ID, rec.PortName, rec.ClientID, rec.Exclusive, rec.Managed, rec.Sniff,
+			rec.OpenedAt.UnixNano(), rec.OpenedAt.UnixNano())
+		return err
+	})
+}
+
+// SessionClosed completes a session's record with its final counters
+func (s *Store) SessionClosed(session *serial.Session) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	open, ok := s.sessions[session]
+	if !ok {
+		return
+	}
+	delete(s.sessions, session)
+
+	rec := open.record(session)
+	s.enqueueLocked(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`UPDATE sessions SET closed_at = ?, updated_at = ?,
+			bytes_sent = ?, bytes_received = ?, errors = ? WHERE id = ?`,
+			now.UnixNano(), now.UnixNano(),
+			int64(rec.BytesSent), int64(rec.BytesReceived), int64(rec.Errors), rec.ID)
+		return err
+	})
+}
+
+// TapAdded starts a capture's record
+func (s *Store) TapAdded(session *serial.Session, tap *serial.Tap) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+	rec := CaptureRecord{
+		TapID:      tap.ID,
+		SessionID:  session.ID,
+		PortName:   session.PortName,
+		File:       tap.Options.File,
+		TCPAddress: tap.Options.TCPAddress,
+		Direction:  tap.Options.Direction,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taps[tap] = &openTap{id: tap.ID}
+	s.enqueueLocked(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO captures
+			(tap_id, session_id, port_name, file, tcp_address, direction, started_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			rec.TapID, rec.SessionID, rec.PortName, rec.File, rec.TCPAddress, int(rec.Direction),
+			now.UnixNano(), now.UnixNano())
+		return err
+	})
+}
+
+// TapRemoved completes a capture's record
+func (s *Store) TapRemoved(session *serial.Session, tap *serial.Tap) {
+	if s == nil {
+		return
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.taps[tap]; !ok {
+		return
+	}
+	delete(s.taps, tap)
+
+	written, dropped := tap.BytesWritten(), tap.RecordsDropped()
+	s.enqueueLocked(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`UPDATE captures SET stopped_at = ?, updated_at = ?,
+			bytes_written = ?, records_dropped = ? WHERE tap_id = ?`,
+			now.UnixNano(), now.UnixNano(), int64(written), int64(dropped), tap.ID)
+		return err
+	})
+}
+
+// record returns the counters of an open session
+func (o *openSession) record(session *serial.Session) SessionRecord {
+	stats := session.Statistics.Snapshot()
+	return SessionRecord{
+		ID:            o.id,
+		BytesSent:     stats.BytesSent - o.base.BytesSent,
+		BytesReceived: stats.BytesReceived - o.base.BytesReceived,
+		Errors:        stats.Errors - o.base.Errors,
+	}
+}
+
+// matches reports whether an open session passes the filter
+func (o *openSession) matches(filter SessionFilter) bool {
+	if !filter.Until.IsZero() && o.openedAt.After(filter.Until) {
+		return false
+	}
+	if filter.PortName != "" && o.portName != filter.PortName {
+		return false
+	}
+	return filter.ClientID == "" || o.clientID == filter.ClientID
+}
+
+// checkpoint saves the counters of open sessions and taps
+func (s *Store) checkpoint() {
+	now := time.Now().UnixNano()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.sessions) == 0 && len(s.taps) == 0 {
+		return
+	}
+
+	sessions := make([]SessionRecord, 0, len(s.sessions))
+	for session, open := range s.sessions {
+		open.saved = open.record(session)
+		sessions = append(sessions, open.saved)
+	}
+	captures := make([]CaptureRecord, 0, len(s.taps))
+	for tap, open := range s.taps {
+		captures = append(captures, CaptureRecord{
+			TapID:          open.id,
+			BytesWritten:   tap.BytesWritten(),
+			RecordsDropped: tap.RecordsDropped(),
+		})
+	}
+
+	s.enqueueLocked(func(tx *sql.Tx) error {
+		for _, rec := range sessions {
+			if _, err := tx.Exec(`UPDATE sessions SET updated_at = ?, bytes_sent = ?, bytes_received = ?, errors = ?
+				WHERE id = ? AND closed_at IS NULL`,
+				now, int64(rec.BytesSent), int64(rec.BytesReceived), int64(rec.Errors), rec.ID); err != nil {
+				return err
+			}
+		}
+		for _, rec := range captures {
+			if _, err := tx.Exec(`UPDATE captures SET updated_at = ?, bytes_written = ?, records_dropped = ?
+				WHERE tap_id = ? AND stopped_at IS NULL`,
+				now, int64(rec.BytesWritten), int64(rec.RecordsDropped), rec.TapID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Sessions returns the most recently opened sessions matching filter,
+// oldest first, and the totals of every matching session. Open sessions
+// report their current counters.
+func (s *Store) Sessions(filter SessionFilter) ([]SessionRecord, SessionTotals, error) {
+	var totals SessionTotals
+	if s == nil {
+		return nil, totals, nil
+	}
+
+	var q query
+	if !filter.Since.IsZero() {
+		q.where("(closed_at IS NULL OR closed_at >= ?)", filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		q.where("opened_at <= ?", filter.Until.UnixNano())
+	}
+	if filter.PortName != "" {
+		q.where("port_name = ?", filter.PortName)
+	}
+	if filter.ClientID != "" {
+		q.where("client_id = ?", filter.ClientID)
+	}
+
+	rows, err := s.db.Query(`SELECT id, port_name, client_id, exclusive, managed, sniff, opened_at, closed_at,
+		bytes_sent, bytes_received, errors, interrupted FROM sessions`+q.clause()+
+		` ORDER BY opened_at DESC LIMIT ?`, append(q.args, queryLimit(filter.Limit))...)
+	if err != nil {
+		return nil, totals, err
+	}
+	defer rows.Close()
+
+	var records []SessionRecord
+	for rows.Next() {
+		var rec SessionRecord
+		var openedAt int64
+		var closedAt sql.NullInt64
+		var sent, received, errs int64
+		if err := rows.Scan(&rec.ID, &rec.PortName, &rec.ClientID, &rec.Exclusive, &rec.Managed, &rec.Sniff,
+			&openedAt, &closedAt, &sent, &received, &errs, &rec.Interrupted); err != nil {
+			return nil, totals, err
+		}
+		rec.OpenedAt = time.Unix(0, openedAt)
+		rec.ClosedAt = fromUnixNano(closedAt)
+		rec.BytesSent, rec.BytesReceived, rec.Errors = uint64(sent), uint64(received), uint64(errs)
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, totals, err
+	}
+	slices.Reverse(records)
+
+	var sent, received, errs int64
+	if err := s.db.QueryRow(`SELECT COUNT(*), COALESCE(SUM(bytes_sent), 0), COALESCE(SUM(bytes_received), 0),
+		COALESCE(SUM(errors), 0) FROM sessions`+q.clause(), q.args...).Scan(&totals.Sessions, &sent, &received, &errs); err != nil {
+		return nil, totals, err
+	}
+	totals.BytesSent, totals.BytesReceived, totals.Errors = uint64(sent), uint64(received), uint64(errs)
+
+	// The database lags open sessions by up to a checkpoint
+	live := make(map[string]SessionRecord)
+	s.mu.Lock()
+	for session, open := range s.sessions {
+		if !open.matches(filter) {
+			continue
+		}
+		cur := open.record(session)
+		live[open.id] = cur
+		totals.BytesSent += cur.BytesSent - open.saved.BytesSent
+		totals.BytesReceived += cur.BytesReceived - open.saved.BytesReceived
+		totals.Errors += cur.Errors - open.saved.Errors
+	}
+	s.mu.Unlock()
+
+	for i := range records {
+		if cur, ok := live[records[i].ID]; ok && records[i].ClosedAt.IsZero() {
+			records[i].BytesSent, records[i].BytesReceived, records[i].Errors = cur.BytesSent, cur.BytesReceived, cur.Errors
+		}
+	}
+
+	return records, totals, nil
+}
+
+// Captures returns the most recently started captures matching filter,
+// oldest first
+func (s *Store) Captures(filter CaptureFilter) ([]CaptureRecord, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var q query
+	if !filter.Since.IsZero() {
+		q.where("(stopped_at IS NULL OR stopped_at >= ?)", filter.Since.UnixNano())
+	}
+	if !filter.Until.IsZero() {
+		q.where("started_at <= ?", filter.Until.UnixNano())
+	}
+	if filter.PortName != "" {
+		q.where("port_name = ?", filter.PortName)
+	}
+	if filter.SessionID != "" {
+		q.where("session_id = ?", filter.SessionID)
+	}
+
+	rows, err := s.db.Query(`SELECT tap_id, session_id, port_name, file, tcp_address, direction, started_at, stopped_at,
+		bytes_written, records_dropped, interrupted FROM captures`+q.clause()+
+		` ORDER BY started_at DESC LIMIT ?`, append(q.args, queryLimit(filter.Limit))...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []CaptureRecord
+	for rows.Next() {
+		var rec CaptureRecord
+		var direction int
+		var startedAt int64
+		var stoppedAt sql.NullInt64
+		var written, dropped int64
+		if err := rows.Scan(&rec.TapID, &rec.SessionID, &rec.PortName, &rec.File, &rec.TCPAddress, &direction,
+			&startedAt, &stoppedAt, &written, &dropped, &rec.Interrupted); err != nil {
+			return nil, err
+		}
+		rec.Direction = serial.TapDirection(direction)
+		rec.StartedAt = time.Unix(0, startedAt)
+		rec.StoppedAt = fromUnixNano(stoppedAt)
+		rec.BytesWritten, rec.RecordsDropped = uint64(written), uint64(dropped)
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	slices.Reverse(records)
+
+	return records, nil
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jobs runs transactions against ports on a schedule and keeps the
+// latest result of each, so periodic polling needs no external cron job
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// ClientID is the client ID of sessions opened by the scheduler
+const ClientID = "baudlink-scheduler"
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrDuplicateJob = errors.New("a job with this name already exists")
+)
+
+// Job periodically writes a request to a port and collects the response.
+// Without a session, a job uses the port's managed session, or opens the
+// port for the length of each run when it is not open.
+type Job struct {
+	ID            string
+	Name          string
+	Port          string // Port name or alias
+	SessionID     string // Session the job runs on (optional)
+	Schedule      string // Cron expression, or a descriptor such as @every 60s
+	Request       []byte
+	Terminator    []byte         // Response is complete once this is seen
+	Pattern       *regexp.Regexp // Response is complete once this matches
+	ExpectedBytes int            // Response is complete after this many bytes
+	Timeout       time.Duration
+	FlushInput    bool              // Discard stale input before sending
+	Config        serial.PortConfig // Line settings used when the job opens the port
+}
+
+// Result is the outcome of a job's latest run
+type Result struct {
+	Timestamp     time.Time
+	Success       bool
+	Data          []byte
+	Matched       bool
+	ChecksumError bool
+	Elapsed       time.Duration
+	Message       string
+}
+
+// Status describes a scheduled job
+type Status struct {
+	Job
+	NextRun    time.Time
+	Runs       uint64
+	Failures   uint64
+	LastResult *Result // nil until the job has run
+}
+
+// entry is a scheduled job and its state
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+	stop     chan struct{}
+
+	// Guarded by the scheduler lock
+	nextRun  time.Time
+	runs     uint64
+	failures uint64
+	result   *Result
+}
+
+// Scheduler runs jobs on their schedules
+type Scheduler struct {
+	manager *serial.Manager
+	resolve func(string) (string, error)
+
+	mu      sync.Mutex
+	jobs    map[string]*entry // key: job ID
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler. resolve maps port aliases to port names
+// and may be nil.
+func NewScheduler(manager *serial.Manager, resolve func(string) (string, error)) *Scheduler {
+	if resolve == nil {
+		resolve = func(name string) (string, error) { return name, nil }
+	}
+	return &Scheduler{
+		manager: manager,
+		resolve: resolve,
+		jobs:    make(map[string]*entry),
+	}
+}
+
+// ParseSchedule parses a standard five-field cron expression or a
+// descriptor such as @hourly or @every 60s
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	return cron.ParseStandard(spec)
+}
+
+// Add validates a job and schedules it, assigning an ID if it has none
+func (s *Scheduler) Add(job Job) (*Job, error) {
+	if job.Name == "" {
+		return nil, fmt.Errorf("job name is required")
+	}
+	if job.Port == "" {
+		return nil, fmt.Errorf("job %s requires a port", job.Name)
+	}
+	if len(job.Request) == 0 {
+		return nil, fmt.Errorf("job %s requires a request", job.Name)
+	}
+	if len(job.Terminator) == 0 && job.Pattern == nil && job.ExpectedBytes <= 0 {
+		return nil, fmt.Errorf("job %s requires a terminator, pattern, or expected byte count", job.Name)
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("job %s has an invalid schedule: %w", job.Name, err)
+	}
+	if job.SessionID == "" {
+		if err := job.Config.Validate(); err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+	}
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return nil, fmt.Errorf("scheduler is stopped")
+	}
+	for _, e := range s.jobs {
+		if e.job.Name == job.Name {
+			return nil, ErrDuplicateJob
+		}
+	}
+
+	e := &entry{
+		job:      job,
+		schedule: schedule,
+		stop:     make(chan struct{}),
+		nextRun:  schedule.Next(time.Now()),
+	}
+	s.jobs[job.ID] = e
+
+	s.wg.Add(1)
+	go s.loop(e)
+
+	return &e.job, nil
+}
+
+// Remove stops and removes a job by ID or name
+func (s *Scheduler) Remove(idOrName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findLocked(idOrName)
+	if e == nil {
+		return ErrJobNotFound
+	}
+	s.removeLocked(e)
+	return nil
+}
+
+// Get returns the status of a job by ID or name
+func (s *Scheduler) Get(idOrName string) (*Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.findLocked(idOrName)
+	if e == nil {
+		return nil, ErrJobNotFound
+	}
+	return e.status(), nil
+}
+
+// List returns the status of every job, ordered by name
+func (s *Scheduler) List() []*Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*Status, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		result = append(result, e.status())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Stop stops every job and waits for running transactions to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	for _, e := range s.jobs {
+		s.removeLocked(e)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// findLocked looks up a job by ID, then by name
+// (must be called with the scheduler lock held)
+func (s *Scheduler) findLocked(idOrName string) *entry {
+	if e, ok := s.jobs[idOrName]; ok {
+		return e
+	}
+	for _, e := range s.jobs {
+		if e.job.Name == idOrName {
+			return e
+		}
+	}
+	return nil
+}
+
+// removeLocked stops a job's loop and forgets it
+// (must be called with the scheduler lock held)
+func (s *Scheduler) removeLocked(e *entry) {
+	delete(s.jobs, e.job.ID)
+	close(e.stop)
+}
+
+// status returns a snapshot of the job's state
+// (must be called with the scheduler lock held)
+func (e *entry) status() *Status {
+	st := &Status{
+		Job:      e.job,
+		NextRun:  e.nextRun,
+		Runs:     e.runs,
+		Failures: e.failures,
+	}
+	if e.result != nil {
+		r := *e.result
+		st.LastResult = &r
+	}
+	return st
+}
+
+// loop runs a job each time its schedule fires until it is removed
+func (s *Scheduler) loop(e *entry) {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		next := e.nextRun
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-e.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		result, sessionGone := s.run(e.job)
+
+		s.mu.Lock()
+		e.runs++
+		if !result.Success {
+			e.failures++
+		}
+		e.result = result
+		// Runs that overlap the next firing time skip it rather than queue up
+		e.nextRun = e.schedule.Next(time.Now())
+		if sessionGone && s.jobs[e.job.ID] == e {
+			log.Printf("Job %s removed: session %s has closed", e.job.Name, e.job.SessionID)
+			s.removeLocked(e)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// run performs one transaction and publishes its result. It reports whether
+// the job's session has closed, which ends the job.
+func (s *Scheduler) run(job Job) (*Result, bool) {
+	result := &Result{Timestamp: time.Now()}
+
+	portName, err := s.resolve(job.Port)
+	if err != nil {
+		result.Message = err.Error()
+		return result, false
+	}
+
+	sessionID, release, err := s.session(job, portName)
+	if err != nil {
+		result.Message = err.Error()
+		gone := job.SessionID != "" && sessionClosed(err)
+		s.publish(job, portName, sessionID, result)
+		return result, gone
+	}
+	defer release()
+
+	tr, err := s.manager.Transact(portName, sessionID, serial.TransactOptions{
+		Request:       job.Request,
+		Terminator:    job.Terminator,
+		Pattern:       job.Pattern,
+		ExpectedBytes: job.ExpectedBytes,
+		Timeout:       job.Timeout,
+		FlushInput:    job.FlushInput,
+	})
+	switch {
+	case err != nil:
+		result.Message = err.Error()
+	case tr.ChecksumError:
+		result.Message = "response checksum mismatch"
+	case !tr.Matched:
+		result.Message = "timeout waiting for response"
+	default:
+		result.Success = true
+	}
+	if tr != nil {
+		result.Data = tr.Data
+		result.Matched = tr.Matched
+		result.ChecksumError = tr.ChecksumError
+		result.Elapsed = tr.Elapsed
+	}
+
+	s.publish(job, portName, sessionID, result)
+	return result, false
+}
+
+// session returns the session a job runs on and a function releasing it.
+// Ports that are not open are opened for the run and closed again.
+func (s *Scheduler) session(job Job, portName string) (string, func(), error) {
+	if job.SessionID != "" {
+		if _, err := s.manager.ValidateSession(portName, job.SessionID); err != nil {
+			return job.SessionID, nil, err
+		}
+		return job.SessionID, func() {}, nil
+	}
+
+	if session := s.manager.GetSession(portName); session != nil && session.Managed {
+		return session.ID, func() {}, nil
+	}
+
+	session, err := s.manager.OpenPort(portName, job.Config, ClientID, true, 0)
+	if err != nil {
+		if errors.Is(err, serial.ErrPortLocked) {
+			return "", nil, fmt.Errorf("port %s is in use by another client", portName)
+		}
+		return "", nil, err
+	}
+	return session.ID, func() { s.manager.ClosePort(portName, session.ID) }, nil
+}
+
+// sessionClosed reports whether a session validation error means the
+// session will never be usable again
+func sessionClosed(err error) bool {
+	return errors.Is(err, serial.ErrPortNotOpen) ||
+		errors.Is(err, serial.ErrInvalidSession) ||
+		errors.Is(err, serial.ErrSessionTakenOver) ||
+		errors.Is(err, serial.ErrPortClosed)
+}
+
+// publish announces a job result on the manager's event stream
+func (s *Scheduler) publish(job Job, portName, sessionID string, result *Result) {
+	message := result.Message
+	if result.Success {
+		message = fmt.Sprintf("job %s completed", job.Name)
+	}
+	s.manager.Events().Publish(serial.Event{
+		Type:      serial.EventJobCompleted,
+		PortName:  portName,
+		SessionID: sessionID,
+		Job:       job.Name,
+		Data:      result.Data,
+		Message:   message,
+		Timestamp: result.Timestamp,
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import "golang.org/x/sys/windows/svc/eventlog"
+
+// eventID is the ID of events written by the sink, matching the service's
+// own lifecycle events
+const eventID = 1
+
+// EventLog writes log messages to the Windows Event Log
+type EventLog struct {
+	log *eventlog.Log
+}
+
+// OpenEventLog opens the Event Log under a source name. The source is
+// registered when the service is installed; messages from an unregistered
+// source are still recorded, without a message description.
+func OpenEventLog(source string) (Sink, error) {
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &EventLog{log: l}, nil
+}
+
+// Write records one log line as an information, warning, or error event
+func (e *EventLog) Write(line []byte) (int, error) {
+	msg := message(line)
+	if msg == "" {
+		return len(line), nil
+	}
+	switch Classify(msg) {
+	case SeverityError:
+		e.log.Error(eventID, msg)
+	case SeverityWarning:
+		e.log.Warning(eventID, msg)
+	default:
+		e.log.Info(eventID, msg)
+	}
+	return len(line), nil
+}
+
+// Close closes the Event Log handle
+func (e *EventLog) Close() error {
+	return e.log.Close()
+}
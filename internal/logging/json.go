@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// severityNames are the level field values of JSON log lines
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// jsonLine is one line of JSON log output
+type jsonLine struct {
+	Time  string `json:"time"`
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+// JSONWriter rewrites the standard logger's lines as JSON objects with
+// time, level, and msg fields, one per line, for log collectors that parse
+// structured output
+type JSONWriter struct {
+	w io.Writer
+}
+
+// NewJSONWriter creates a writer sending JSON lines to w
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: w}
+}
+
+// Write converts one log line. The standard logger writes each line with a
+// single call.
+func (j *JSONWriter) Write(line []byte) (int, error) {
+	msg := message(line)
+	data, err := json.Marshal(jsonLine{
+		Time:  time.Now().UTC().Format(time.RFC3339Nano),
+		Level: severityNames[Classify(msg)],
+		Msg:   msg,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := j.w.Write(append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return len(line), nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging provides sinks that receive the agent's log output in
+// addition to the console or log file: syslog, local or remote, and the
+// Windows Event Log
+package logging
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrUnsupported is returned for sinks the platform does not provide
+var ErrUnsupported = errors.New("log sink is not supported on this platform")
+
+// Severity is the importance of a log message
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// Sink receives complete lines written by the standard logger. Write never
+// fails, so one unreachable sink does not stop logging to the others.
+type Sink interface {
+	Write(line []byte) (int, error)
+	Close() error
+}
+
+// timestamp matches the date and time the standard logger prefixes
+var timestamp = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(\.\d+)? `)
+
+// message strips the standard logger's timestamp and line ending, as sinks
+// record their own time
+func message(line []byte) string {
+	return strings.TrimRight(timestamp.ReplaceAllString(string(line), ""), "\r\n")
+}
+
+// Classify derives a message's severity from its wording, as the agent's
+// log lines carry no level: "Warning: ..." is a warning, and messages
+// reporting an error or failure are errors
+func Classify(msg string) Severity {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.HasPrefix(lower, "warning"):
+		return SeverityWarning
+	case strings.Contains(lower, "error"), strings.Contains(lower, "failed"), strings.Contains(lower, "panic"):
+		return SeverityError
+	default:
+		return SeverityInfo
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Syslog message formats
+const (
+	FormatRFC5424 = "rfc5424"
+	FormatRFC3164 = "rfc3164"
+)
+
+// writeTimeout bounds each write to a syslog server, so a stalled server
+// delays logging only briefly
+const writeTimeout = 5 * time.Second
+
+// localSockets are where local syslog daemons listen, in order of preference
+var localSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// facilities maps syslog facility names to their codes
+var facilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// SyslogOptions configures a syslog sink
+type SyslogOptions struct {
+	Network  string // udp or tcp; empty for the local syslog daemon
+	Address  string // host:port of a remote server
+	Facility string // Facility name (default: daemon)
+	Tag      string // APP-NAME (default: baudlink)
+	Format   string // rfc5424 (default) or rfc3164
+}
+
+// Syslog sends log messages to a syslog daemon or remote server
+type Syslog struct {
+	opts     SyslogOptions
+	facility int
+	hostname string
+
+	// mu guards conn, redialed after a write fails
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// OpenSyslog validates the options and connects to the syslog daemon or
+// server
+func OpenSyslog(opts SyslogOptions) (*Syslog, error) {
+	if opts.Facility == "" {
+		opts.Facility = "daemon"
+	}
+	if opts.Tag == "" {
+		opts.Tag = "baudlink"
+	}
+	if opts.Format == "" {
+		opts.Format = FormatRFC5424
+	}
+
+	facility, ok := facilities[strings.ToLower(opts.Facility)]
+	if !ok {
+		return nil, fmt.Errorf("unknown syslog facility: %s", opts.Facility)
+	}
+	if opts.Format != FormatRFC5424 && opts.Format != FormatRFC3164 {
+		return nil, fmt.Errorf("unknown syslog format: %s (must be rfc5424 or rfc3164)", opts.Format)
+	}
+	switch opts.Network {
+	case "":
+	case "udp", "tcp":
+		if opts.Address == "" {
+			return nil, fmt.Errorf("syslog address is required for %s", opts.Network)
+		}
+	default:
+		return nil, fmt.Errorf("unknown syslog network: %s (must be udp, tcp, or empty for local)", opts.Network)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	s := &Syslog{opts: opts, facility: facility, hostname: hostname}
+	conn, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+	return s, nil
+}
+
+// dial connects to the local daemon or remote server
+func (s *Syslog) dial() (net.Conn, error) {
+	if s.opts.Network != "" {
+		return net.DialTimeout(s.opts.Network, s.opts.Address, writeTimeout)
+	}
+
+	var lastErr error
+	for _, path := range localSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.Dial(network, path)
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+	}
+	return nil, fmt.Errorf("no local syslog daemon found: %w", lastErr)
+}
+
+// Write sends one log line as a syslog message. Messages that cannot be
+// delivered after one reconnect are dropped.
+func (s *Syslog) Write(line []byte) (int, error) {
+	msg := message(line)
+	if msg == "" {
+		return len(line), nil
+	}
+	data := s.format(msg, time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			conn, err := s.dial()
+			if err != nil {
+				return len(line), nil
+			}
+			s.conn = conn
+		}
+		s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := s.conn.Write(data); err == nil {
+			break
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	return len(line), nil
+}
+
+// format renders a message in the configured format and frames it for TCP
+// with octet counting (RFC 6587)
+func (s *Syslog) format(msg string, now time.Time) []byte {
+	pri := s.facility*8 + severityCode(Classify(msg))
+	pid := os.Getpid()
+
+	var out string
+	if s.opts.Format == FormatRFC3164 {
+		out = fmt.Sprintf("<%d>%s %s %s[%d]: %s", pri, now.Format(time.Stamp), s.hostname, s.opts.Tag, pid, msg)
+	} else {
+		out = fmt.Sprintf("<%d>1 %s %s %s %d - - %s", pri, now.UTC().Format("2006-01-02T15:04:05.000000Z"), s.hostname, s.opts.Tag, pid, msg)
+	}
+
+	if s.opts.Network == "tcp" {
+		out = fmt.Sprintf("%d %s", len(out), out)
+	}
+	return []byte(out)
+}
+
+// Close closes the connection
+func (s *Syslog) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// severityCode returns the syslog severity of a message
+func severityCode(sev Severity) int {
+	switch sev {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 4
+	default:
+		return 6
+	}
+}
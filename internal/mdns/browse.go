@@ -0,0 +1,226 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// queryInterval is how often a browse query is repeated
+const queryInterval = time.Second
+
+// defaultBrowseTimeout bounds browsing when ctx has no deadline
+const defaultBrowseTimeout = 2 * time.Second
+
+// Browse queries the network for instances of a service type, such as
+// "_baudlink._tcp", and returns those that answered before ctx is done
+func Browse(ctx context.Context, serviceType string) ([]Service, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultBrowseTimeout)
+		defer cancel()
+	}
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	query, err := browseQuery(serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(queryInterval)
+		defer ticker.Stop()
+		for {
+			conn.WriteToUDP(query, groupAddr)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	deadline, _ := ctx.Deadline()
+	conn.SetReadDeadline(deadline)
+
+	found := newCollector(typeName(serviceType))
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				break
+			}
+			return nil, err
+		}
+		found.add(buf[:n], src.IP)
+	}
+
+	return found.services(serviceType), nil
+}
+
+// browseQuery builds a PTR query for a service type
+func browseQuery(serviceType string) ([]byte, error) {
+	name, err := dnsmessage.NewName(typeName(serviceType))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service type: %w", err)
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// collector assembles service instances from the records of many responses
+type collector struct {
+	typeName  string
+	instances map[string]*Service // By lower-case instance name
+	sources   map[string]net.IP   // Address each instance was heard from
+	hosts     map[string][]net.IP // By lower-case host name
+}
+
+func newCollector(typeName string) *collector {
+	return &collector{
+		typeName:  typeName,
+		instances: make(map[string]*Service),
+		sources:   make(map[string]net.IP),
+		hosts:     make(map[string][]net.IP),
+	}
+}
+
+// instance returns the entry for an instance name, creating it if needed
+func (c *collector) instance(name string) *Service {
+	key := strings.ToLower(name)
+	s, ok := c.instances[key]
+	if !ok {
+		s = &Service{Instance: strings.TrimSuffix(name[:len(name)-len(c.typeName)], ".")}
+		c.instances[key] = s
+	}
+	return s
+}
+
+// belongs reports whether name is an instance of the browsed type
+func (c *collector) belongs(name string) bool {
+	return len(name) > len(c.typeName) && strings.EqualFold(name[len(name)-len(c.typeName):], c.typeName)
+}
+
+// add records the answers and additional records of a response
+func (c *collector) add(packet []byte, src net.IP) {
+	var p dnsmessage.Parser
+	header, err := p.Start(packet)
+	if err != nil || !header.Response {
+		return
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return
+	}
+
+	var records []dnsmessage.Resource
+	if answers, err := p.AllAnswers(); err == nil {
+		records = append(records, answers...)
+	}
+	if err := p.SkipAllAuthorities(); err == nil {
+		if additionals, err := p.AllAdditionals(); err == nil {
+			records = append(records, additionals...)
+		}
+	}
+
+	for _, rr := range records {
+		name := rr.Header.Name.String()
+
+		switch body := rr.Body.(type) {
+		case *dnsmessage.PTRResource:
+			target := body.PTR.String()
+			if !sameName(rr.Header.Name, c.typeName) || !c.belongs(target) {
+				continue
+			}
+			if rr.Header.TTL == 0 {
+				// Goodbye: the instance was withdrawn
+				delete(c.instances, strings.ToLower(target))
+				continue
+			}
+			c.instance(target)
+			c.sources[strings.ToLower(target)] = src
+		case *dnsmessage.SRVResource:
+			if c.belongs(name) {
+				s := c.instance(name)
+				s.Host = body.Target.String()
+				s.Port = int(body.Port)
+			}
+		case *dnsmessage.TXTResource:
+			if c.belongs(name) {
+				c.instance(name).Text = decodeText(body.TXT)
+			}
+		case *dnsmessage.AResource:
+			key := strings.ToLower(name)
+			ip := net.IP(body.A[:])
+			if !containsIP(c.hosts[key], ip) {
+				c.hosts[key] = append(c.hosts[key], ip)
+			}
+		}
+	}
+}
+
+// services returns the complete instances found, sorted by name. Instances
+// whose host has no address records are reached at the address they
+// answered from.
+func (c *collector) services(serviceType string) []Service {
+	var services []Service
+	for key, s := range c.instances {
+		if s.Host == "" || s.Port == 0 {
+			continue
+		}
+		s.Type = serviceType
+		s.Addrs = c.hosts[strings.ToLower(s.Host)]
+		if len(s.Addrs) == 0 && c.sources[key] != nil {
+			s.Addrs = []net.IP{c.sources[key]}
+		}
+		services = append(services, *s)
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Instance < services[j].Instance
+	})
+	return services
+}
+
+// containsIP reports whether ips contains ip
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, other := range ips {
+		if other.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
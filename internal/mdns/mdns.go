@@ -0,0 +1,148 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mdns advertises and discovers DNS-SD services over multicast DNS
+package mdns
+
+import (
+	"net"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Multicast DNS group and port
+var groupAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+const (
+	// domain is the multicast DNS domain
+	domain = "local."
+
+	// servicesName enumerates the service types on the network
+	servicesName = "_services._dns-sd._udp.local."
+
+	// recordTTL is the TTL of advertised records in seconds
+	recordTTL = 120
+
+	// legacyTTL caps TTLs in replies to one-shot queries, per RFC 6762
+	legacyTTL = 10
+
+	// unicastResponseBit in a question's class asks for a unicast reply
+	unicastResponseBit = 1 << 15
+
+	// maxPacketSize is the largest multicast DNS packet handled
+	maxPacketSize = 9000
+)
+
+// Service is a DNS-SD service instance
+type Service struct {
+	// Instance is the instance name, e.g. "raspberrypi"
+	Instance string
+	// Type is the service type, e.g. "_baudlink._tcp"
+	Type string
+	// Host is the target host name, e.g. "raspberrypi.local."
+	Host string
+	Port int
+	// Addrs are the host's IPv4 addresses
+	Addrs []net.IP
+	Text  map[string]string
+}
+
+// typeName returns the service type's full name
+func typeName(serviceType string) string {
+	return strings.TrimSuffix(serviceType, ".") + "." + domain
+}
+
+// instanceName returns the full name of a service instance. Dots in the
+// instance are replaced since they would split it into several labels.
+func instanceName(instance, serviceType string) string {
+	return strings.ReplaceAll(instance, ".", "-") + "." + typeName(serviceType)
+}
+
+// hostLabel derives a host name label from an instance name, which may hold
+// characters not allowed in host names
+func hostLabel(instance string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '-'
+	}, instance)
+}
+
+// mustName converts a full domain name to a dnsmessage.Name
+func mustName(name string) dnsmessage.Name {
+	return dnsmessage.MustNewName(name)
+}
+
+// sameName compares domain names case-insensitively
+func sameName(a dnsmessage.Name, b string) bool {
+	return strings.EqualFold(a.String(), b)
+}
+
+// encodeText encodes TXT key=value pairs
+func encodeText(text map[string]string) []string {
+	if len(text) == 0 {
+		// A TXT record must hold at least one string
+		return []string{""}
+	}
+	var txt []string
+	for k, v := range text {
+		txt = append(txt, k+"="+v)
+	}
+	return txt
+}
+
+// decodeText decodes TXT key=value pairs
+func decodeText(txt []string) map[string]string {
+	text := make(map[string]string)
+	for _, s := range txt {
+		if s == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(s, "=")
+		text[strings.ToLower(k)] = v
+	}
+	return text
+}
+
+// interfaceAddrs returns the IPv4 addresses of the up, non-loopback
+// interfaces
+func interfaceAddrs() []net.IP {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var ips []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				if ip4 := ipNet.IP.To4(); ip4 != nil {
+					ips = append(ips, ip4)
+				}
+			}
+		}
+	}
+	return ips
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mdns
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// announceCount is how many unsolicited announcements are sent at startup
+const announceCount = 2
+
+// Responder advertises a service by answering multicast DNS queries
+type Responder struct {
+	conn    *net.UDPConn
+	service Service
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Advertise starts answering queries for the service and announces it. If
+// the service has no addresses, those of the host's interfaces are used.
+func Advertise(service Service) (*Responder, error) {
+	if service.Host == "" {
+		service.Host = hostLabel(service.Instance) + "." + domain
+	}
+	if _, err := dnsmessage.NewName(instanceName(service.Instance, service.Type)); err != nil {
+		return nil, fmt.Errorf("invalid service name: %w", err)
+	}
+	if _, err := dnsmessage.NewName(service.Host); err != nil {
+		return nil, fmt.Errorf("invalid host name: %w", err)
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, groupAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join multicast group: %w", err)
+	}
+
+	r := &Responder{
+		conn:    conn,
+		service: service,
+		done:    make(chan struct{}),
+	}
+
+	r.wg.Add(2)
+	go r.serve()
+	go r.announce()
+	return r, nil
+}
+
+// Close withdraws the service and stops answering queries
+func (r *Responder) Close() error {
+	close(r.done)
+
+	// A record with a zero TTL tells caches the service is gone
+	if msg, err := r.response(0, nil, 0, true); err == nil {
+		r.conn.WriteToUDP(msg, groupAddr)
+	}
+
+	err := r.conn.Close()
+	r.wg.Wait()
+	return err
+}
+
+// announce sends unsolicited responses so listeners learn of the service
+// without querying
+func (r *Responder) announce() {
+	defer r.wg.Done()
+
+	for i := 0; i < announceCount; i++ {
+		if msg, err := r.response(0, nil, recordTTL, false); err == nil {
+			r.conn.WriteToUDP(msg, groupAddr)
+		}
+
+		select {
+		case <-r.done:
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// serve answers queries until the responder is closed
+func (r *Responder) serve() {
+	defer r.wg.Done()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, src, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-r.done:
+				return
+			default:
+				continue
+			}
+		}
+		r.handle(buf[:n], src)
+	}
+}
+
+// handle answers a query if it asks about the service
+func (r *Responder) handle(packet []byte, src *net.UDPAddr) {
+	var p dnsmessage.Parser
+	header, err := p.Start(packet)
+	if err != nil || header.Response {
+		return
+	}
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return
+	}
+
+	var answered []dnsmessage.Question
+	unicast := false
+	for _, q := range questions {
+		if r.answers(q) {
+			answered = append(answered, q)
+			if q.Class&unicastResponseBit != 0 {
+				unicast = true
+			}
+		}
+	}
+	if len(answered) == 0 {
+		return
+	}
+
+	// Queries from ports other than 5353 come from simple resolvers that
+	// expect a conventional unicast reply echoing the query
+	if src.Port != groupAddr.Port {
+		if msg, err := r.response(header.ID, answered, legacyTTL, false); err == nil {
+			r.conn.WriteToUDP(msg, src)
+		}
+		return
+	}
+
+	dst := groupAddr
+	if unicast {
+		dst = src
+	}
+	if msg, err := r.response(0, nil, recordTTL, false); err == nil {
+		r.conn.WriteToUDP(msg, dst)
+	}
+}
+
+// answers reports whether the question asks about the service
+func (r *Responder) answers(q dnsmessage.Question) bool {
+	if q.Class&^unicastResponseBit != dnsmessage.ClassINET && q.Class&^unicastResponseBit != dnsmessage.ClassANY {
+		return false
+	}
+
+	switch {
+	case sameName(q.Name, typeName(r.service.Type)), sameName(q.Name, servicesName):
+		return q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL
+	case sameName(q.Name, instanceName(r.service.Instance, r.service.Type)):
+		return q.Type == dnsmessage.TypeSRV || q.Type == dnsmessage.TypeTXT || q.Type == dnsmessage.TypeALL
+	case sameName(q.Name, r.service.Host):
+		return q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeALL
+	}
+	return false
+}
+
+// response builds a response carrying all of the service's records. With
+// goodbye set, only the service's PTR record is sent; callers pass a zero
+// TTL to withdraw it.
+func (r *Responder) response(id uint16, questions []dnsmessage.Question, ttl uint32, goodbye bool) ([]byte, error) {
+	service := mustName(typeName(r.service.Type))
+	instance := mustName(instanceName(r.service.Instance, r.service.Type))
+	host := mustName(r.service.Host)
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, Response: true, Authoritative: true})
+	b.EnableCompression()
+
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		if err := b.Question(q); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+
+	hdr := func(name dnsmessage.Name) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: ttl}
+	}
+
+	if goodbye {
+		if err := b.PTRResource(hdr(service), dnsmessage.PTRResource{PTR: instance}); err != nil {
+			return nil, err
+		}
+		return b.Finish()
+	}
+
+	if err := b.PTRResource(hdr(mustName(servicesName)), dnsmessage.PTRResource{PTR: service}); err != nil {
+		return nil, err
+	}
+	if err := b.PTRResource(hdr(service), dnsmessage.PTRResource{PTR: instance}); err != nil {
+		return nil, err
+	}
+	if err := b.SRVResource(hdr(instance), dnsmessage.SRVResource{Target: host, Port: uint16(r.service.Port)}); err != nil {
+		return nil, err
+	}
+	if err := b.TXTResource(hdr(instance), dnsmessage.TXTResource{TXT: encodeText(r.service.Text)}); err != nil {
+		return nil, err
+	}
+
+	addrs := r.service.Addrs
+	if len(addrs) == 0 {
+		addrs = interfaceAddrs()
+	}
+	for _, ip := range addrs {
+		ip4 := ip.To4()
+		if ip4 == nil {
+			continue
+		}
+		var a dnsmessage.AResource
+		copy(a.A[:], ip4)
+		if err := b.AResource(hdr(host), a); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
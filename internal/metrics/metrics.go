@@ -0,0 +1,200 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes agent statistics in the Prometheus text format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/crash"
+	"github.com/Shoaibashk/BaudLink/internal/ratelimit"
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// Metric types
+const (
+	Counter = "counter"
+	Gauge   = "gauge"
+	Summary = "summary"
+)
+
+// Writer writes metric families in the Prometheus text exposition format
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter creates a new metrics writer
+func NewWriter(w *bufio.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Header writes the HELP and TYPE lines for a metric family
+func (w *Writer) Header(name, metricType, help string) {
+	fmt.Fprintf(w.w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w.w, "# TYPE %s %s\n", name, metricType)
+}
+
+// Sample writes a single sample with the given label pairs
+func (w *Writer) Sample(name string, value float64, labels ...string) {
+	w.w.WriteString(name)
+	if len(labels) > 0 {
+		w.w.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.w.WriteByte(',')
+			}
+			fmt.Fprintf(w.w, "%s=\"%s\"", labels[i], labelEscaper.Replace(labels[i+1]))
+		}
+		w.w.WriteByte('}')
+	}
+	w.w.WriteByte(' ')
+	w.w.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	w.w.WriteByte('\n')
+}
+
+// labelEscaper escapes label values as required by the text format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// portMetric describes a per-port metric derived from session statistics
+type portMetric struct {
+	name       string
+	metricType string
+	help       string
+	value      func(serial.PortStatistics) float64
+}
+
+var portMetrics = []portMetric{
+	{"baudlink_port_bytes_sent_total", Counter, "Bytes written to the port.",
+		func(s serial.PortStatistics) float64 { return float64(s.BytesSent) }},
+	{"baudlink_port_bytes_received_total", Counter, "Bytes read from the port.",
+		func(s serial.PortStatistics) float64 { return float64(s.BytesReceived) }},
+	{"baudlink_port_errors_total", Counter, "I/O errors on the port.",
+		func(s serial.PortStatistics) float64 { return float64(s.Errors) }},
+	{"baudlink_port_frames_parsed_total", Counter, "Frames parsed by the active framer.",
+		func(s serial.PortStatistics) float64 { return float64(s.FramesParsed) }},
+	{"baudlink_port_framing_errors_total", Counter, "Malformed or oversized frames.",
+		func(s serial.PortStatistics) float64 { return float64(s.FramingErrors) }},
+	{"baudlink_port_checksum_errors_total", Counter, "Frames failing checksum verification.",
+		func(s serial.PortStatistics) float64 { return float64(s.ChecksumErrors) }},
+	{"baudlink_port_average_frame_size_bytes", Gauge, "Mean size of parsed frames.",
+		func(s serial.PortStatistics) float64 { return s.AverageFrameSize() }},
+	{"baudlink_port_health_score", Gauge, "Link health from 0 (bad) to 100 (clean).",
+		func(s serial.PortStatistics) float64 { return s.HealthScore() }},
+	{"baudlink_port_rate_limited_total", Counter, "Writes rejected by the port rate limit.",
+		func(s serial.PortStatistics) float64 { return float64(s.RateLimited) }},
+}
+
+// latencyMetric describes a per-port latency summary
+type latencyMetric struct {
+	name  string
+	help  string
+	value func(serial.PortStatistics) serial.LatencyStats
+}
+
+var latencyMetrics = []latencyMetric{
+	{"baudlink_port_read_latency_seconds", "Time received data waited in the agent before a stream took it.",
+		func(s serial.PortStatistics) serial.LatencyStats { return s.ReadLatency }},
+	{"baudlink_port_write_duration_seconds", "Duration of write calls to the port.",
+		func(s serial.PortStatistics) serial.LatencyStats { return s.WriteDuration }},
+}
+
+// Handler returns an HTTP handler serving the manager's session statistics
+// and the rejections of the client rate limiter, if any
+func Handler(manager *serial.Manager, clientLimiter *ratelimit.Limiter) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		bw := bufio.NewWriter(rw)
+		defer bw.Flush()
+
+		Write(NewWriter(bw), manager, clientLimiter)
+	})
+}
+
+// Write writes all agent metrics
+func Write(w *Writer, manager *serial.Manager, clientLimiter *ratelimit.Limiter) {
+	ports := manager.ListOpenPorts()
+	sort.Strings(ports)
+
+	type portStats struct {
+		name   string
+		framer string
+		stats  serial.PortStatistics
+	}
+
+	sessions := make([]portStats, 0, len(ports))
+	for _, name := range ports {
+		if status, err := manager.GetStatus(name); err == nil {
+			sessions = append(sessions, portStats{
+				name:   name,
+				framer: status.Config.Framer,
+				stats:  status.Statistics,
+			})
+		}
+	}
+
+	w.Header("baudlink_open_ports", Gauge, "Number of open serial ports.")
+	w.Sample("baudlink_open_ports", float64(len(sessions)))
+
+	for _, m := range portMetrics {
+		w.Header(m.name, m.metricType, m.help)
+		for _, s := range sessions {
+			w.Sample(m.name, m.value(s.stats), "port", s.name, "framer", s.framer)
+		}
+	}
+
+	for _, m := range latencyMetrics {
+		w.Header(m.name, Summary, m.help)
+		for _, s := range sessions {
+			writeLatency(w, m.name, m.value(s.stats), "port", s.name, "framer", s.framer)
+		}
+	}
+
+	w.Header("baudlink_client_rate_limited_total", Counter, "Requests rejected by the client rate limit.")
+	for _, r := range clientLimiter.Rejections() {
+		w.Sample("baudlink_client_rate_limited_total", float64(r.Count), "client", r.Key)
+	}
+
+	w.Header("baudlink_panics_total", Counter, "Panics recovered in RPC handlers and port readers.")
+	for _, c := range crash.Counts() {
+		w.Sample("baudlink_panics_total", float64(c.Count), "component", c.Component)
+	}
+}
+
+// writeLatency writes the quantiles, sum, and count of a latency summary
+func writeLatency(w *Writer, name string, stats serial.LatencyStats, labels ...string) {
+	quantiles := []struct {
+		label string
+		value time.Duration
+	}{
+		{"0.5", stats.P50},
+		{"0.9", stats.P90},
+		{"0.99", stats.P99},
+	}
+	for _, q := range quantiles {
+		w.Sample(name, q.value.Seconds(), slices.Concat(labels, []string{"quantile", q.label})...)
+	}
+	w.Sample(name+"_sum", stats.Total.Seconds(), labels...)
+	w.Sample(name+"_count", float64(stats.Count), labels...)
+}
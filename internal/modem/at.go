@@ -0,0 +1,165 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package modem drives cellular modems with AT commands. An ATSession
+// serializes commands on a port session, parses their final result codes,
+// and delivers unsolicited result codes (URCs) as a separate event stream.
+package modem
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultURCPrefixes are unsolicited result codes common to 3GPP modems.
+// While a command is pending, a line starting with one of these is treated
+// as a URC unless it carries the command's own prefix, e.g. +CREG: in the
+// response to AT+CREG?.
+var DefaultURCPrefixes = []string{
+	"RING",
+	"+CRING:",
+	"+CLIP:",
+	"+CCWA:",
+	"+CMTI:",
+	"+CMT:",
+	"+CDSI:",
+	"+CDS:",
+	"+CBM:",
+	"+CUSD:",
+	"+CREG:",
+	"+CGREG:",
+	"+CEREG:",
+	"+CGEV:",
+	"+CIEV:",
+	"+CPIN:",
+}
+
+// bodyURCs are followed by a second line carrying their payload, such as the
+// text of an SMS delivered with +CMT:
+var bodyURCs = map[string]bool{
+	"+CMT": true,
+	"+CDS": true,
+	"+CBM": true,
+}
+
+// Response is the outcome of an AT command
+type Response struct {
+	Command string
+	Lines   []string // Information lines between the echo and the final result
+	Result  string   // Final result code, e.g. OK or +CME ERROR: 10
+	OK      bool     // The final result reports success
+
+	// ErrorCode is the numeric +CME or +CMS error, or -1 when the modem
+	// reported none or used verbose errors (AT+CMEE=2)
+	ErrorCode    int
+	ErrorMessage string
+
+	Elapsed time.Duration
+}
+
+// URC is an unsolicited result code
+type URC struct {
+	Name  string // e.g. +CMTI or RING
+	Value string // Text after the colon, e.g. "SM",3
+	Line  string // The complete line
+	Body  string // Second line of +CMT, +CDS, and +CBM
+	Time  time.Time
+}
+
+// finalResult parses a final result code, reporting false for any other line
+func finalResult(line string) (Response, bool) {
+	r := Response{Result: line, ErrorCode: -1}
+
+	switch {
+	case line == "OK":
+		r.OK = true
+	case strings.HasPrefix(line, "CONNECT"):
+		r.OK = true
+	case line == "ERROR", line == "NO CARRIER", line == "BUSY", line == "NO ANSWER", line == "NO DIALTONE":
+	case strings.HasPrefix(line, "+CME ERROR:"), strings.HasPrefix(line, "+CMS ERROR:"):
+		detail := strings.TrimSpace(line[len("+CME ERROR:"):])
+		r.ErrorMessage = detail
+		if code, err := strconv.Atoi(detail); err == nil {
+			r.ErrorCode = code
+			if strings.HasPrefix(line, "+CME") {
+				if msg, ok := cmeErrors[code]; ok {
+					r.ErrorMessage = msg
+				}
+			}
+		}
+	default:
+		return Response{}, false
+	}
+	return r, true
+}
+
+// cmeErrors describes the most common +CME ERROR codes of 3GPP TS 27.007
+var cmeErrors = map[int]string{
+	0:   "phone failure",
+	3:   "operation not allowed",
+	4:   "operation not supported",
+	10:  "SIM not inserted",
+	11:  "SIM PIN required",
+	12:  "SIM PUK required",
+	13:  "SIM failure",
+	14:  "SIM busy",
+	15:  "SIM wrong",
+	16:  "incorrect password",
+	17:  "SIM PIN2 required",
+	18:  "SIM PUK2 required",
+	20:  "memory full",
+	21:  "invalid index",
+	22:  "not found",
+	30:  "no network service",
+	31:  "network timeout",
+	32:  "network not allowed - emergency calls only",
+	100: "unknown",
+}
+
+// parseURC splits a line into a URC
+func parseURC(line string) URC {
+	u := URC{Name: line, Line: line, Time: time.Now()}
+	if name, value, ok := strings.Cut(line, ":"); ok {
+		u.Name = name
+		u.Value = strings.TrimSpace(value)
+	}
+	return u
+}
+
+// commandPrefix returns the prefix of a command's information lines, e.g.
+// +CSQ for AT+CSQ and +CREG for AT+CREG?, or "" for basic commands
+func commandPrefix(command string) string {
+	upper := strings.ToUpper(strings.TrimSpace(command))
+	rest, ok := strings.CutPrefix(upper, "AT")
+	if !ok || rest == "" || !strings.ContainsAny(rest[:1], "+^$#%") {
+		return ""
+	}
+	if i := strings.IndexAny(rest, "=?;"); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// matchesPrefix reports whether line starts with one of prefixes
+func matchesPrefix(line string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(line, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// DefaultTimeout bounds a command when no timeout is given. Network
+// commands such as AT+COPS=? can take minutes and need a longer one.
+const DefaultTimeout = 5 * time.Second
+
+// maxLineLength bounds a line without a terminator, e.g. binary data read
+// while the modem is in data mode
+const maxLineLength = 4096
+
+// urcBuffer is how many URCs a subscriber may fall behind before URCs are
+// dropped for it
+const urcBuffer = 64
+
+var (
+	// ErrTimeout is returned when a command gets no final result in time
+	ErrTimeout = errors.New("timeout waiting for final result")
+
+	// ErrClosed is returned once the port session has closed
+	ErrClosed = errors.New("AT session closed")
+)
+
+// ATSession sends AT commands on an open port session. It reads the port
+// continuously, so other reads on the same session receive no data while it
+// is active.
+type ATSession struct {
+	manager   *serial.Manager
+	portName  string
+	sessionID string
+	reader    *serial.Reader
+	prefixes  []string
+
+	// cmdMu serializes commands
+	cmdMu sync.Mutex
+
+	// mu guards the fields below
+	mu          sync.Mutex
+	pending     *pendingCommand
+	awaiting    *URC // URC waiting for its body line
+	subscribers map[chan URC]struct{}
+	closed      bool
+
+	done chan struct{}
+}
+
+// pendingCommand collects the response to the command in flight
+type pendingCommand struct {
+	command string
+	prefix  string
+	lines   []string
+	result  chan Response
+}
+
+// NewATSession starts an AT session on an open port session. Lines starting
+// with one of urcPrefixes are delivered as URCs (default: DefaultURCPrefixes).
+func NewATSession(manager *serial.Manager, portName, sessionID string, urcPrefixes []string) (*ATSession, error) {
+	if len(urcPrefixes) == 0 {
+		urcPrefixes = DefaultURCPrefixes
+	}
+
+	reader := serial.NewReader(manager, portName, sessionID, 1024)
+	s := &ATSession{
+		manager:     manager,
+		portName:    portName,
+		sessionID:   sessionID,
+		reader:      reader,
+		prefixes:    urcPrefixes,
+		subscribers: make(map[chan URC]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	events := reader.Subscribe()
+	if err := reader.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	go s.readLoop(events)
+
+	return s, nil
+}
+
+// SessionID returns the port session the AT session runs on
+func (s *ATSession) SessionID() string {
+	return s.sessionID
+}
+
+// Done is closed once the AT session has ended
+func (s *ATSession) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops reading the port. Pending commands fail with ErrClosed.
+func (s *ATSession) Close() {
+	s.reader.Stop()
+}
+
+// Send writes an AT command and waits for its final result. The command is
+// terminated with a carriage return; "AT" is not added.
+func (s *ATSession) Send(ctx context.Context, command string, timeout time.Duration) (*Response, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	command = strings.TrimRight(command, "\r\n")
+
+	s.cmdMu.Lock()
+	defer s.cmdMu.Unlock()
+
+	p := &pendingCommand{
+		command: command,
+		prefix:  commandPrefix(command),
+		result:  make(chan Response, 1),
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, ErrClosed
+	}
+	s.pending = p
+	s.mu.Unlock()
+
+	start := time.Now()
+	if _, err := s.manager.Write(s.portName, s.sessionID, []byte(command+"\r")); err != nil {
+		s.clearPending(p)
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-p.result:
+		r.Command = command
+		r.Elapsed = time.Since(start)
+		return &r, nil
+	case <-timer.C:
+		return s.partial(p, start), ErrTimeout
+	case <-ctx.Done():
+		return s.partial(p, start), ctx.Err()
+	case <-s.done:
+		return s.partial(p, start), ErrClosed
+	}
+}
+
+// partial abandons a pending command, returning the lines received so far
+func (s *ATSession) partial(p *pendingCommand, start time.Time) *Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == p {
+		s.pending = nil
+	}
+	return &Response{
+		Command:   p.command,
+		Lines:     p.lines,
+		ErrorCode: -1,
+		Elapsed:   time.Since(start),
+	}
+}
+
+// clearPending abandons a pending command
+func (s *ATSession) clearPending(p *pendingCommand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == p {
+		s.pending = nil
+	}
+}
+
+// SubscribeURC returns a channel receiving URCs and a function ending the
+// subscription. The channel is closed when the session ends.
+func (s *ATSession) SubscribeURC() (<-chan URC, func()) {
+	ch := make(chan URC, urcBuffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	s.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// readLoop splits received data into lines until the reader stops
+func (s *ATSession) readLoop(events <-chan serial.DataEvent) {
+	var buf []byte
+
+	for event := range events {
+		if event.Error != nil {
+			// The reader stops by itself for most session errors, but not
+			// once the port has been closed
+			if errors.Is(event.Error, serial.ErrPortNotOpen) {
+				s.reader.Stop()
+			}
+			continue
+		}
+		if event.Gap || event.Overflow != nil {
+			// Partial lines from before a reconnect or lost data cannot be
+			// completed
+			buf = buf[:0]
+			continue
+		}
+
+		buf = append(buf, event.Data...)
+		for {
+			i := bytes.IndexByte(buf, '\n')
+			if i < 0 {
+				break
+			}
+			line := strings.TrimSpace(string(buf[:i]))
+			buf = buf[i+1:]
+			if line != "" {
+				s.handleLine(line)
+			}
+		}
+		if len(buf) > maxLineLength {
+			buf = buf[:0]
+		}
+	}
+
+	s.mu.Lock()
+	s.closed = true
+	s.pending = nil
+	for ch := range s.subscribers {
+		close(ch)
+	}
+	s.subscribers = nil
+	s.mu.Unlock()
+
+	close(s.done)
+}
+
+// handleLine routes a line to the pending command or the URC subscribers
+func (s *ATSession) handleLine(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u := s.awaiting; u != nil {
+		s.awaiting = nil
+		u.Body = line
+		s.publish(*u)
+		return
+	}
+
+	if p := s.pending; p != nil && !s.isURC(line, p) {
+		if line == p.command {
+			// Echo of the command (ATE1)
+			return
+		}
+		if r, ok := finalResult(line); ok {
+			r.Lines = p.lines
+			p.result <- r
+			s.pending = nil
+			return
+		}
+		p.lines = append(p.lines, line)
+		return
+	}
+
+	u := parseURC(line)
+	if bodyURCs[u.Name] {
+		s.awaiting = &u
+		return
+	}
+	s.publish(u)
+}
+
+// isURC reports whether a line received while p is pending is unsolicited
+func (s *ATSession) isURC(line string, p *pendingCommand) bool {
+	if p.prefix != "" && strings.HasPrefix(line, p.prefix) {
+		return false
+	}
+	return matchesPrefix(line, s.prefixes)
+}
+
+// publish delivers a URC to every subscriber, dropping it for subscribers
+// that have fallen behind
+func (s *ATSession) publish(u URC) {
+	for ch := range s.subscribers {
+		select {
+		case ch <- u:
+		default:
+		}
+	}
+}
+
+// Registry keeps one AT session per port session
+type Registry struct {
+	manager  *serial.Manager
+	prefixes []string
+
+	mu       sync.Mutex
+	sessions map[string]*ATSession
+}
+
+// NewRegistry creates a registry of AT sessions. urcPrefixes configures the
+// sessions it starts (default: DefaultURCPrefixes).
+func NewRegistry(manager *serial.Manager, urcPrefixes []string) *Registry {
+	return &Registry{
+		manager:  manager,
+		prefixes: urcPrefixes,
+		sessions: make(map[string]*ATSession),
+	}
+}
+
+// Session returns the AT session of a port session, starting one if needed.
+// It ends when the port session closes.
+func (r *Registry) Session(portName, sessionID string) (*ATSession, error) {
+	if _, err := r.manager.ValidateSession(portName, sessionID); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.sessions[sessionID]; ok {
+		select {
+		case <-s.Done():
+		default:
+			return s, nil
+		}
+	}
+
+	s, err := NewATSession(r.manager, portName, sessionID, r.prefixes)
+	if err != nil {
+		return nil, err
+	}
+	r.sessions[sessionID] = s
+
+	go func() {
+		<-s.Done()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.sessions[sessionID] == s {
+			delete(r.sessions, sessionID)
+		}
+	}()
+
+	return s, nil
+}
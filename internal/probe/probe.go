@@ -0,0 +1,432 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe identifies the device on a serial port by trying well-known
+// protocols at several baud rates
+package probe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// Protocols recognized by the probes
+const (
+	ProtocolNMEA   = "nmea"
+	ProtocolAT     = "at"
+	ProtocolSCPI   = "scpi"
+	ProtocolModbus = "modbus"
+
+	// ProtocolText is reported for readable data no probe recognized,
+	// which suggests the baud rate is right even if the protocol is not
+	ProtocolText = "text"
+)
+
+// Probe defaults
+const (
+	DefaultTimeout          = 500 * time.Millisecond
+	DefaultListenTime       = 1500 * time.Millisecond
+	DefaultModbusMaxAddress = 10
+
+	// confident is the confidence at which probing stops unless Exhaustive
+	// is set
+	confident = 0.9
+)
+
+// DefaultBaudRates are tried in order when no rates are given. Common
+// rates come first so typical devices are found quickly.
+var DefaultBaudRates = []int{9600, 115200, 19200, 38400, 57600, 4800}
+
+// Probes lists the available probes in the order they run at each baud
+// rate. NMEA only listens, so it runs before anything is sent.
+var Probes = []string{ProtocolNMEA, ProtocolAT, ProtocolSCPI, ProtocolModbus}
+
+// Options configures a probe run
+type Options struct {
+	BaudRates        []int
+	Probes           []string
+	Config           serial.PortConfig // Line settings; the baud rate is replaced
+	Timeout          time.Duration     // Response timeout of each request
+	ListenTime       time.Duration     // How long to listen for unsolicited data
+	ModbusMaxAddress int               // Highest Modbus slave address scanned
+	Exhaustive       bool              // Keep probing after a confident match
+	ClientID         string
+}
+
+// Candidate is a possible identification of the device
+type Candidate struct {
+	Protocol   string
+	BaudRate   int
+	Confidence float64 // 0 to 1
+	Detail     string  // e.g. the *IDN? reply or Modbus slave addresses
+	Response   []byte  // Raw data the identification is based on
+}
+
+// Report is the outcome of a probe run
+type Report struct {
+	Candidates []Candidate // Most likely first
+	BaudRates  []int       // Rates that were tried
+}
+
+// Best returns the most likely candidate, or nil if nothing was recognized
+func (r *Report) Best() *Candidate {
+	if len(r.Candidates) == 0 {
+		return nil
+	}
+	return &r.Candidates[0]
+}
+
+// prober runs probes on an open session
+type prober struct {
+	manager   *serial.Manager
+	portName  string
+	sessionID string
+	opts      Options
+}
+
+// Run opens the port exclusively, tries each probe at each baud rate, and
+// closes the port again. The port must not be open by another client.
+func Run(ctx context.Context, manager *serial.Manager, portName string, opts Options) (*Report, error) {
+	if len(opts.BaudRates) == 0 {
+		opts.BaudRates = DefaultBaudRates
+	}
+	if len(opts.Probes) == 0 {
+		opts.Probes = Probes
+	}
+	for _, name := range opts.Probes {
+		if !contains(Probes, name) {
+			return nil, fmt.Errorf("unknown probe: %s", name)
+		}
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.ListenTime <= 0 {
+		opts.ListenTime = DefaultListenTime
+	}
+	if opts.ModbusMaxAddress <= 0 {
+		opts.ModbusMaxAddress = DefaultModbusMaxAddress
+	}
+	if opts.ModbusMaxAddress > 247 {
+		opts.ModbusMaxAddress = 247
+	}
+
+	cfg := opts.Config
+	cfg.BaudRate = opts.BaudRates[0]
+	session, err := manager.OpenPort(portName, cfg, opts.ClientID, true, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer manager.ClosePort(portName, session.ID)
+
+	p := &prober{manager: manager, portName: portName, sessionID: session.ID, opts: opts}
+	report := &Report{}
+
+	for _, baud := range opts.BaudRates {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		cfg.BaudRate = baud
+		if err := manager.Configure(portName, session.ID, cfg); err != nil {
+			return report, fmt.Errorf("failed to set baud rate %d: %w", baud, err)
+		}
+		report.BaudRates = append(report.BaudRates, baud)
+
+		found := false
+		for _, name := range Probes {
+			if !contains(opts.Probes, name) {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+
+			c, err := p.run(name)
+			if err != nil {
+				return report, err
+			}
+			if c != nil {
+				c.BaudRate = baud
+				report.Candidates = append(report.Candidates, *c)
+				found = found || c.Confidence >= confident
+			}
+		}
+		if found && !opts.Exhaustive {
+			break
+		}
+	}
+
+	sort.SliceStable(report.Candidates, func(i, j int) bool {
+		return report.Candidates[i].Confidence > report.Candidates[j].Confidence
+	})
+	return report, nil
+}
+
+// run runs one probe
+func (p *prober) run(name string) (*Candidate, error) {
+	switch name {
+	case ProtocolNMEA:
+		return p.listen()
+	case ProtocolAT:
+		return p.at()
+	case ProtocolSCPI:
+		return p.scpi()
+	case ProtocolModbus:
+		return p.modbus()
+	}
+	return nil, nil
+}
+
+// transact sends a request and collects the response
+func (p *prober) transact(opts serial.TransactOptions) ([]byte, error) {
+	opts.FlushInput = true
+	result, err := p.manager.Transact(p.portName, p.sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// nmeaSentence matches an NMEA 0183 sentence with its checksum
+var nmeaSentence = regexp.MustCompile(`[$!]([A-Z]{2})([A-Z]{3}),[^*\r\n]*\*([0-9A-Fa-f]{2})`)
+
+// listen waits for unsolicited data, recognizing NMEA sentences and
+// reporting other readable text
+func (p *prober) listen() (*Candidate, error) {
+	data, err := p.transact(serial.TransactOptions{
+		Pattern: regexp.MustCompile(`(?:` + nmeaSentence.String() + `[\s\S]*){2}`),
+		Timeout: p.opts.ListenTime,
+	})
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+
+	var valid, invalid int
+	var types []string
+	for _, m := range nmeaSentence.FindAllSubmatchIndex(data, -1) {
+		sentence := data[m[0]+1 : m[6]-1]
+		if fmt.Sprintf("%02X", nmeaChecksum(sentence)) == strings.ToUpper(string(data[m[6]:m[7]])) {
+			valid++
+			if t := string(data[m[2]:m[5]]); !contains(types, t) {
+				types = append(types, t)
+			}
+		} else {
+			invalid++
+		}
+	}
+
+	switch {
+	case valid > 0:
+		confidence := 0.95
+		if invalid > valid {
+			confidence = 0.6
+		}
+		return &Candidate{
+			Protocol:   ProtocolNMEA,
+			Confidence: confidence,
+			Detail:     strings.Join(types, " "),
+			Response:   data,
+		}, nil
+	case printable(data) > 0.9:
+		return &Candidate{
+			Protocol:   ProtocolText,
+			Confidence: 0.3,
+			Detail:     "unrecognized text output",
+			Response:   data,
+		}, nil
+	}
+	return nil, nil
+}
+
+// nmeaChecksum is the XOR of the bytes between $ and *
+func nmeaChecksum(sentence []byte) byte {
+	var sum byte
+	for _, b := range sentence {
+		sum ^= b
+	}
+	return sum
+}
+
+// atResult matches a final AT result code
+var atResult = regexp.MustCompile(`(?:^|\r\n)(OK|ERROR|\+CM[ES] ERROR:[^\r\n]*)\r\n`)
+
+// at sends AT and, if a modem answers, ATI for its identification
+func (p *prober) at() (*Candidate, error) {
+	data, err := p.transact(serial.TransactOptions{
+		Request: []byte("AT\r"),
+		Pattern: atResult,
+		Timeout: p.opts.Timeout,
+	})
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+
+	m := atResult.FindSubmatch(data)
+	if m == nil {
+		return nil, nil
+	}
+	if string(m[1]) != "OK" {
+		return &Candidate{Protocol: ProtocolAT, Confidence: 0.6, Detail: string(m[1]), Response: data}, nil
+	}
+
+	c := &Candidate{Protocol: ProtocolAT, Confidence: 0.9, Response: data}
+	info, err := p.transact(serial.TransactOptions{
+		Request: []byte("ATI\r"),
+		Pattern: atResult,
+		Timeout: p.opts.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if atResult.Match(info) {
+		c.Confidence = 0.95
+		c.Detail = atInformation(info)
+	}
+	return c, nil
+}
+
+// atInformation returns the identification lines of an ATI response,
+// without the echoed command and the final result
+func atInformation(data []byte) string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "OK" || strings.HasPrefix(line, "AT") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "; ")
+}
+
+// scpi sends *IDN? and expects a comma separated identification
+func (p *prober) scpi() (*Candidate, error) {
+	data, err := p.transact(serial.TransactOptions{
+		Request:    []byte("*IDN?\n"),
+		Terminator: []byte("\n"),
+		Timeout:    p.opts.Timeout,
+	})
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+
+	line := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(line, "*IDN?"):
+		// Echoed by something that is not an instrument
+		return nil, nil
+	case strings.Count(line, ",") >= 3 && printable(data) > 0.95:
+		// Manufacturer,Model,Serial,Firmware
+		return &Candidate{Protocol: ProtocolSCPI, Confidence: 0.95, Detail: line, Response: data}, nil
+	}
+	return nil, nil
+}
+
+// modbus scans slave addresses with a read of holding register 0. Any
+// well-formed reply, including an exception, identifies a slave.
+func (p *prober) modbus() (*Candidate, error) {
+	var slaves []string
+	var response []byte
+
+	for addr := 1; addr <= p.opts.ModbusMaxAddress; addr++ {
+		frame := appendCRC([]byte{byte(addr), 0x03, 0x00, 0x00, 0x00, 0x01})
+		data, err := p.transact(serial.TransactOptions{
+			Request:       frame,
+			ExpectedBytes: 7,
+			Timeout:       p.opts.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if validModbusReply(byte(addr), data) {
+			slaves = append(slaves, fmt.Sprint(addr))
+			if response == nil {
+				response = data
+			}
+		}
+	}
+
+	if len(slaves) == 0 {
+		return nil, nil
+	}
+	return &Candidate{
+		Protocol:   ProtocolModbus,
+		Confidence: 0.9,
+		Detail:     "slave " + strings.Join(slaves, ", "),
+		Response:   response,
+	}, nil
+}
+
+// validModbusReply reports whether data is a read holding registers reply
+// or exception from addr with a valid CRC
+func validModbusReply(addr byte, data []byte) bool {
+	if len(data) >= 5 && data[0] == addr && data[1] == 0x83 {
+		return bytes.Equal(appendCRC(data[:3:3]), data[:5])
+	}
+	if len(data) >= 7 && data[0] == addr && data[1] == 0x03 && data[2] == 2 {
+		return bytes.Equal(appendCRC(data[:5:5]), data[:7])
+	}
+	return false
+}
+
+// appendCRC appends the Modbus RTU CRC-16 of frame, low byte first
+func appendCRC(frame []byte) []byte {
+	crc := uint16(0xFFFF)
+	for _, b := range frame {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return append(frame, byte(crc), byte(crc>>8))
+}
+
+// printable returns the fraction of data that is printable ASCII or
+// whitespace
+func printable(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	n := 0
+	for _, b := range data {
+		if (b >= 0x20 && b < 0x7F) || b == '\r' || b == '\n' || b == '\t' {
+			n++
+		}
+	}
+	return float64(n) / float64(len(data))
+}
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
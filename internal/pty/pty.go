@@ -0,0 +1,25 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pty creates local terminals that other programs can open as if
+// they were serial ports: pseudo-terminals on Linux and macOS, and named
+// pipes on Windows
+package pty
+
+import "errors"
+
+// ErrUnsupported is returned on platforms without pseudo-terminal support
+var ErrUnsupported = errors.New("pseudo-terminals are not supported on this platform")
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pty
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ioctlGetTermios = unix.TIOCGETA
+	ioctlSetTermios = unix.TIOCSETA
+)
+
+// unlock grants and unlocks the slave side of a pseudo-terminal and returns
+// its path
+func unlock(master *os.File) (string, error) {
+	fd := master.Fd()
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, unix.TIOCPTYGRANT, 0); errno != 0 {
+		return "", errno
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, unix.TIOCPTYUNLK, 0); errno != 0 {
+		return "", errno
+	}
+
+	// TIOCPTYGNAME fills a 128-byte buffer with the NUL-terminated path
+	var buf [128]byte
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, unix.TIOCPTYGNAME, uintptr(unsafe.Pointer(&buf[0]))); errno != 0 {
+		return "", errno
+	}
+	return unix.ByteSliceToString(buf[:]), nil
+}
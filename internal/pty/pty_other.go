@@ -0,0 +1,47 @@
+//go:build !linux && !darwin && !windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pty
+
+// PTY is a pseudo-terminal
+type PTY struct{}
+
+// Open creates a pseudo-terminal
+func Open(name string) (*PTY, error) {
+	return nil, ErrUnsupported
+}
+
+// Name returns the path programs use to open the terminal
+func (p *PTY) Name() string {
+	return ""
+}
+
+// Read reads data written to the terminal by other programs
+func (p *PTY) Read(b []byte) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// Write writes data for other programs to read from the terminal
+func (p *PTY) Write(b []byte) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// Close closes the terminal
+func (p *PTY) Close() error {
+	return nil
+}
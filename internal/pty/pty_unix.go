@@ -0,0 +1,105 @@
+//go:build linux || darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pty
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// PTY is a pseudo-terminal. Data written by programs using the terminal
+// device is read from the PTY, and data written to the PTY is read by them.
+type PTY struct {
+	master *os.File
+	slave  *os.File
+	name   string
+}
+
+// Open creates a pseudo-terminal in raw mode. The name is only used on
+// platforms where terminals are named pipes.
+func Open(name string) (*PTY, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pseudo-terminal: %w", err)
+	}
+
+	slaveName, err := unlock(master)
+	if err != nil {
+		master.Close()
+		return nil, fmt.Errorf("failed to unlock pseudo-terminal: %w", err)
+	}
+
+	// Holding the slave open keeps reads on the master from failing while no
+	// program has the terminal open
+	slave, err := os.OpenFile(slaveName, os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", slaveName, err)
+	}
+
+	if err := makeRaw(int(slave.Fd())); err != nil {
+		slave.Close()
+		master.Close()
+		return nil, fmt.Errorf("failed to set raw mode: %w", err)
+	}
+
+	return &PTY{master: master, slave: slave, name: slaveName}, nil
+}
+
+// Name returns the path programs use to open the terminal
+func (p *PTY) Name() string {
+	return p.name
+}
+
+// Read reads data written to the terminal by other programs
+func (p *PTY) Read(b []byte) (int, error) {
+	return p.master.Read(b)
+}
+
+// Write writes data for other programs to read from the terminal
+func (p *PTY) Write(b []byte) (int, error) {
+	return p.master.Write(b)
+}
+
+// Close closes the terminal, unblocking pending reads and writes
+func (p *PTY) Close() error {
+	p.slave.Close()
+	return p.master.Close()
+}
+
+// makeRaw disables echo, line editing, and character translation so bytes
+// pass through unchanged, as on a serial line
+func makeRaw(fd int) error {
+	t, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return err
+	}
+
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Oflag &^= unix.OPOST
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARENB
+	t.Cflag |= unix.CS8
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+
+	return unix.IoctlSetTermios(fd, ioctlSetTermios, t)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pty
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sys/windows"
+)
+
+// pipeBufferSize is the pipe's buffer size in each direction
+const pipeBufferSize = 4096
+
+// PTY is a named pipe standing in for a terminal. One program at a time can
+// connect to it; data written while nobody is connected is discarded, as on
+// a serial line with nothing attached.
+type PTY struct {
+	name   string
+	handle windows.Handle
+
+	// connectMu serialises waiting for a client
+	connectMu sync.Mutex
+	connected atomic.Bool
+	closed    atomic.Bool
+}
+
+// Open creates a named pipe called \\.\pipe\<name>
+func Open(name string) (*PTY, error) {
+	path := `\\.\pipe\` + name
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := windows.CreateNamedPipe(pathPtr,
+		windows.PIPE_ACCESS_DUPLEX|windows.FILE_FLAG_OVERLAPPED|windows.FILE_FLAG_FIRST_PIPE_INSTANCE,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		1, pipeBufferSize, pipeBufferSize, 0, nil)
+	if err != nil {
+		return nil, &os.PathError{Op: "create", Path: path, Err: err}
+	}
+
+	return &PTY{name: path, handle: handle}, nil
+}
+
+// Name returns the path programs use to open the pipe
+func (p *PTY) Name() string {
+	return p.name
+}
+
+// Read reads data written by the connected program, waiting for a program
+// to connect if necessary
+func (p *PTY) Read(b []byte) (int, error) {
+	for {
+		if err := p.connect(); err != nil {
+			return 0, p.mapError(err)
+		}
+
+		n, err := p.overlapped(func(ov *windows.Overlapped) error {
+			return windows.ReadFile(p.handle, b, nil, ov)
+		})
+		if err == nil {
+			return int(n), nil
+		}
+		if !isDisconnect(err) {
+			return 0, p.mapError(err)
+		}
+
+		// The program went away; wait for the next one
+		p.disconnect()
+	}
+}
+
+// Write writes data for the connected program to read. Data is discarded
+// when no program is connected.
+func (p *PTY) Write(b []byte) (int, error) {
+	if p.closed.Load() {
+		return 0, os.ErrClosed
+	}
+	if !p.connected.Load() {
+		return len(b), nil
+	}
+
+	n, err := p.overlapped(func(ov *windows.Overlapped) error {
+		return windows.WriteFile(p.handle, b, nil, ov)
+	})
+	if err != nil {
+		if isDisconnect(err) {
+			return len(b), nil
+		}
+		return int(n), p.mapError(err)
+	}
+	return int(n), nil
+}
+
+// Close closes the pipe, unblocking pending reads and writes
+func (p *PTY) Close() error {
+	if !p.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	windows.CancelIoEx(p.handle, nil)
+	return windows.CloseHandle(p.handle)
+}
+
+// connect waits for a program to connect to the pipe
+func (p *PTY) connect() error {
+	p.connectMu.Lock()
+	defer p.connectMu.Unlock()
+
+	if p.connected.Load() {
+		return nil
+	}
+
+	_, err := p.overlapped(func(ov *windows.Overlapped) error {
+		return windows.ConnectNamedPipe(p.handle, ov)
+	})
+	if err != nil && err != windows.ERROR_PIPE_CONNECTED {
+		return err
+	}
+
+	p.connected.Store(true)
+	return nil
+}
+
+// disconnect drops the connected program so the next one can connect
+func (p *PTY) disconnect() {
+	p.connectMu.Lock()
+	defer p.connectMu.Unlock()
+
+	if p.connected.CompareAndSwap(true, false) {
+		windows.DisconnectNamedPipe(p.handle)
+	}
+}
+
+// overlapped runs an overlapped operation on the pipe and waits for it to
+// complete. The pipe is opened for overlapped I/O so a pending read does not
+// block writes.
+func (p *PTY) overlapped(op func(*windows.Overlapped) error) (uint32, error) {
+	event, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(event)
+
+	ov := windows.Overlapped{HEvent: event}
+	if err := op(&ov); err != nil && err != windows.ERROR_IO_PENDING {
+		return 0, err
+	}
+
+	var n uint32
+	err = windows.GetOverlappedResult(p.handle, &ov, &n, true)
+	return n, err
+}
+
+// mapError reports operations interrupted by Close as os.ErrClosed
+func (p *PTY) mapError(err error) error {
+	if p.closed.Load() {
+		return os.ErrClosed
+	}
+	return err
+}
+
+// isDisconnect reports whether err means the connected program went away
+func isDisconnect(err error) bool {
+	return errors.Is(err, windows.ERROR_BROKEN_PIPE) ||
+		errors.Is(err, windows.ERROR_PIPE_NOT_CONNECTED) ||
+		errors.Is(err, windows.ERROR_NO_DATA)
+}
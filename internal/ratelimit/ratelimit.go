@@ -0,0 +1,175 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit provides keyed token-bucket rate limiters
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Limit is a request and byte rate. Zero values mean unlimited.
+type Limit struct {
+	RequestsPerSecond float64
+	BytesPerSecond    float64
+}
+
+// IsZero reports whether the limit allows unlimited traffic
+func (l Limit) IsZero() bool {
+	return l.RequestsPerSecond <= 0 && l.BytesPerSecond <= 0
+}
+
+// bucket is a token bucket holding up to one second of its rate
+type bucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, now time.Time) *bucket {
+	return &bucket{rate: rate, tokens: rate, last: now}
+}
+
+// refill adds the tokens accrued since the last call
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+}
+
+// ready reports whether n tokens may be taken. Requests larger than the
+// bucket are allowed once it is full and leave it in debt.
+func (b *bucket) ready(n float64) bool {
+	if n > b.rate {
+		n = b.rate
+	}
+	return b.tokens >= n
+}
+
+// keyState holds the buckets for a single key
+type keyState struct {
+	requests *bucket
+	bytes    *bucket
+	rejected uint64
+}
+
+// Limiter enforces a Limit independently for each key
+type Limiter struct {
+	limit Limit
+	mu    sync.Mutex
+	keys  map[string]*keyState
+}
+
+// NewLimiter creates a limiter applying limit to every key
+func NewLimiter(limit Limit) *Limiter {
+	return &Limiter{
+		limit: limit,
+		keys:  make(map[string]*keyState),
+	}
+}
+
+// Allow records one request carrying n bytes for key and reports whether it
+// is within the limit. Rejected requests consume nothing.
+func (l *Limiter) Allow(key string, n int) bool {
+	if l == nil || l.limit.IsZero() {
+		return true
+	}
+
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state, exists := l.keys[key]
+	if !exists {
+		state = &keyState{}
+		if l.limit.RequestsPerSecond > 0 {
+			state.requests = newBucket(l.limit.RequestsPerSecond, now)
+		}
+		if l.limit.BytesPerSecond > 0 {
+			state.bytes = newBucket(l.limit.BytesPerSecond, now)
+		}
+		l.keys[key] = state
+	}
+
+	if state.requests != nil {
+		state.requests.refill(now)
+		if !state.requests.ready(1) {
+			state.rejected++
+			return false
+		}
+	}
+	if state.bytes != nil && n > 0 {
+		state.bytes.refill(now)
+		if !state.bytes.ready(float64(n)) {
+			state.rejected++
+			return false
+		}
+		state.bytes.tokens -= float64(n)
+	}
+	if state.requests != nil {
+		state.requests.tokens--
+	}
+
+	return true
+}
+
+// Rejection is the number of requests rejected for a key
+type Rejection struct {
+	Key   string
+	Count uint64
+}
+
+// Rejections returns the rejection count of every key that has been limited,
+// ordered by key
+func (l *Limiter) Rejections() []Rejection {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	list := make([]Rejection, 0, len(l.keys))
+	for key, state := range l.keys {
+		if state.rejected > 0 {
+			list = append(list, Rejection{Key: key, Count: state.rejected})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
+
+// Rejected returns the number of requests rejected for key
+func (l *Limiter) Rejected(key string) uint64 {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, exists := l.keys[key]; exists {
+		return state.rejected
+	}
+	return 0
+}
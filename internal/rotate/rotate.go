@@ -0,0 +1,117 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rotate provides append-only files that are rotated by size
+package rotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File is a file that is rotated once it reaches a maximum size, keeping a
+// fixed number of numbered backups. It is not safe for concurrent use.
+type File struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// Open opens path for appending, creating it and its directory if needed.
+// A maxSize of zero disables rotation.
+func Open(path string, maxSize int64, maxBackups int) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	f := &File{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the current file for appending
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if it would exceed the maximum size
+func (f *File) Write(p []byte) (int, error) {
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the backups up by one, moves the current file to .1, and
+// starts a new file
+func (f *File) rotate() error {
+	f.file.Close()
+
+	if f.maxBackups <= 0 {
+		os.Remove(f.path)
+	} else {
+		os.Remove(backupPath(f.path, f.maxBackups))
+		for i := f.maxBackups - 1; i >= 1; i-- {
+			os.Rename(backupPath(f.path, i), backupPath(f.path, i+1))
+		}
+		os.Rename(f.path, backupPath(f.path, 1))
+	}
+
+	return f.open()
+}
+
+// Paths returns the backups that exist, oldest first, followed by the
+// current file
+func (f *File) Paths() []string {
+	var paths []string
+	for i := f.maxBackups; i >= 1; i-- {
+		if _, err := os.Stat(backupPath(f.path, i)); err == nil {
+			paths = append(paths, backupPath(f.path, i))
+		}
+	}
+	return append(paths, f.path)
+}
+
+// Close closes the current file
+func (f *File) Close() error {
+	return f.file.Close()
+}
+
+// backupPath returns the path of the nth backup of path
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
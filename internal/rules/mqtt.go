@@ -0,0 +1,222 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// mqttDialTimeout bounds connecting to the broker and the CONNACK
+const mqttDialTimeout = 10 * time.Second
+
+// MQTT control packet types
+const (
+	mqttConnect    = 0x10
+	mqttConnack    = 0x20
+	mqttPublish    = 0x30
+	mqttDisconnect = 0xe0
+)
+
+// MQTTOptions describes the broker used by mqtt actions
+type MQTTOptions struct {
+	Broker   string // tcp://host:1883, or tls://host:8883 for TLS
+	ClientID string
+	Username string
+	Password string
+}
+
+// mqttClient publishes QoS 0 messages over MQTT 3.1.1. It connects on first
+// use and reconnects after a failed publish. Keep-alive is disabled because
+// the client never waits for the broker after connecting.
+type mqttClient struct {
+	opts MQTTOptions
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// newMQTTClient validates the broker address and creates a client
+func newMQTTClient(opts MQTTOptions) (*mqttClient, error) {
+	u, err := url.Parse(opts.Broker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT broker: %w", err)
+	}
+	switch u.Scheme {
+	case "tcp", "mqtt", "tls", "ssl", "mqtts":
+	default:
+		return nil, fmt.Errorf("unsupported MQTT broker scheme: %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("MQTT broker has no host: %s", opts.Broker)
+	}
+	if opts.ClientID == "" {
+		opts.ClientID = "baudlink"
+	}
+	return &mqttClient{opts: opts}, nil
+}
+
+// Publish sends a message, connecting first if necessary. A publish on a
+// broken connection is retried once on a new one.
+func (c *mqttClient) Publish(topic string, payload []byte, retain bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	packet := publishPacket(topic, payload, retain)
+	for attempt := 0; ; attempt++ {
+		if c.conn == nil {
+			if err := c.connect(); err != nil {
+				return err
+			}
+		}
+
+		_, err := c.conn.Write(packet)
+		if err == nil {
+			return nil
+		}
+		c.conn.Close()
+		c.conn = nil
+		if attempt > 0 {
+			return err
+		}
+	}
+}
+
+// Close disconnects from the broker
+func (c *mqttClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn != nil {
+		c.conn.Write([]byte{mqttDisconnect, 0})
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// connect dials the broker and completes the MQTT handshake
+// (must be called with the client lock held)
+func (c *mqttClient) connect() error {
+	u, _ := url.Parse(c.opts.Broker)
+	host := u.Host
+	secure := u.Scheme == "tls" || u.Scheme == "ssl" || u.Scheme == "mqtts"
+	if u.Port() == "" {
+		port := "1883"
+		if secure {
+			port = "8883"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: mqttDialTimeout}
+	var conn net.Conn
+	var err error
+	if secure {
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+
+	conn.SetDeadline(time.Now().Add(mqttDialTimeout))
+	if _, err := conn.Write(c.connectPacket()); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+
+	var ack [4]byte
+	if _, err := io.ReadFull(conn, ack[:]); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	if ack[0] != mqttConnack || ack[1] != 2 {
+		conn.Close()
+		return errors.New("unexpected reply from MQTT broker")
+	}
+	if ack[3] != 0 {
+		conn.Close()
+		return fmt.Errorf("MQTT broker refused the connection (code %d)", ack[3])
+	}
+	conn.SetDeadline(time.Time{})
+
+	c.conn = conn
+	return nil
+}
+
+// connectPacket builds a CONNECT packet for a clean session
+func (c *mqttClient) connectPacket() []byte {
+	flags := byte(0x02) // Clean session
+	body := mqttString(nil, "MQTT")
+	body = append(body, 4) // Protocol level 3.1.1
+	if c.opts.Username != "" {
+		flags |= 0x80
+	}
+	if c.opts.Password != "" {
+		flags |= 0x40
+	}
+	body = append(body, flags, 0, 0) // Keep-alive disabled
+	body = mqttString(body, c.opts.ClientID)
+	if c.opts.Username != "" {
+		body = mqttString(body, c.opts.Username)
+	}
+	if c.opts.Password != "" {
+		body = mqttString(body, c.opts.Password)
+	}
+	return mqttPacket(mqttConnect, body)
+}
+
+// publishPacket builds a QoS 0 PUBLISH packet
+func publishPacket(topic string, payload []byte, retain bool) []byte {
+	header := byte(mqttPublish)
+	if retain {
+		header |= 0x01
+	}
+	body := mqttString(nil, topic)
+	return mqttPacket(header, append(body, payload...))
+}
+
+// mqttPacket prefixes a packet body with its fixed header
+func mqttPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	n := len(body)
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		packet = append(packet, b)
+		if n == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+// mqttString appends a length-prefixed UTF-8 string
+func mqttString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
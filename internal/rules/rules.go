@@ -0,0 +1,323 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rules runs configured actions when data received on a port
+// matches a pattern, turning the agent into a small automation hub
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// maxBuffer bounds the unmatched input kept per rule and port, so patterns
+// can match data split across reads
+const maxBuffer = 4096
+
+// queueSize is the number of triggered rules waiting for their actions
+// before further triggers are dropped
+const queueSize = 64
+
+// webhookTimeout bounds each webhook request
+const webhookTimeout = 10 * time.Second
+
+// Action types
+const (
+	ActionWrite   = "write"
+	ActionMQTT    = "mqtt"
+	ActionWebhook = "webhook"
+	ActionEvent   = "event"
+)
+
+// Rule triggers actions when data received on a port matches a pattern.
+// Exactly one of Pattern and Bytes is set.
+type Rule struct {
+	Name     string
+	Port     string // Port name or alias (empty = every port)
+	Pattern  *regexp.Regexp
+	Bytes    []byte
+	Cooldown time.Duration // Minimum time between triggers on a port
+	Actions  []Action
+}
+
+// Action is performed when a rule matches. Text, Topic, and Payload may
+// refer to capture groups of a regular expression as $1 or ${name}, and to
+// the whole match as $0.
+type Action struct {
+	Type    string
+	Text    string // write: text written back to the port
+	Data    []byte // write: bytes written back verbatim, if Text is empty
+	Topic   string // mqtt: topic to publish to
+	Retain  bool   // mqtt: publish as a retained message
+	URL     string // webhook: URL receiving a POST
+	Payload string // mqtt, webhook: message body (default: JSON description)
+}
+
+// Options configures the engine
+type Options struct {
+	Rules []Rule
+	MQTT  MQTTOptions
+	// Alias returns the alias of a port, if any, so rules can name ports by alias
+	Alias func(portName string) string
+}
+
+// trigger is a rule match waiting for its actions to run
+type trigger struct {
+	rule      *Rule
+	portName  string
+	sessionID string
+	data      []byte
+	match     []int // Submatch indexes into data
+	at        time.Time
+}
+
+// Engine matches received data against rules and runs their actions
+type Engine struct {
+	manager *serial.Manager
+	rules   []*Rule
+	alias   func(string) string
+	mqtt    *mqttClient
+	http    *http.Client
+
+	buffers   map[string][]byte    // key: rule index and port
+	lastFired map[string]time.Time // key: rule index and port
+
+	triggers chan trigger
+	cancel   func()
+	wg       sync.WaitGroup
+}
+
+// New validates the rules and creates an engine
+func New(manager *serial.Manager, opts Options) (*Engine, error) {
+	e := &Engine{
+		manager:   manager,
+		alias:     opts.Alias,
+		http:      &http.Client{Timeout: webhookTimeout},
+		buffers:   make(map[string][]byte),
+		lastFired: make(map[string]time.Time),
+		triggers:  make(chan trigger, queueSize),
+	}
+	if e.alias == nil {
+		e.alias = func(string) string { return "" }
+	}
+
+	for i := range opts.Rules {
+		r := &opts.Rules[i]
+		if (r.Pattern == nil) == (len(r.Bytes) == 0) {
+			return nil, fmt.Errorf("rule %s needs exactly one of a pattern or bytes", r.Name)
+		}
+		if r.Pattern != nil && r.Pattern.MatchString("") {
+			return nil, fmt.Errorf("rule %s pattern matches empty input", r.Name)
+		}
+		for _, a := range r.Actions {
+			switch a.Type {
+			case ActionWrite, ActionWebhook, ActionEvent:
+			case ActionMQTT:
+				if e.mqtt == nil {
+					if opts.MQTT.Broker == "" {
+						return nil, fmt.Errorf("rule %s publishes to MQTT but no broker is configured", r.Name)
+					}
+					client, err := newMQTTClient(opts.MQTT)
+					if err != nil {
+						return nil, err
+					}
+					e.mqtt = client
+				}
+			default:
+				return nil, fmt.Errorf("rule %s has unknown action: %s", r.Name, a.Type)
+			}
+		}
+		e.rules = append(e.rules, r)
+	}
+
+	return e, nil
+}
+
+// Start begins matching received data
+func (e *Engine) Start() {
+	data, cancel := e.manager.Monitor().Subscribe()
+	e.cancel = cancel
+
+	e.wg.Add(2)
+	go func() {
+		defer e.wg.Done()
+		defer close(e.triggers)
+		for chunk := range data {
+			e.feed(chunk)
+		}
+	}()
+	go func() {
+		defer e.wg.Done()
+		for t := range e.triggers {
+			e.run(t)
+		}
+	}()
+}
+
+// Stop stops matching and waits for running actions to finish
+func (e *Engine) Stop() {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	if e.mqtt != nil {
+		e.mqtt.Close()
+	}
+}
+
+// applies reports whether a rule watches a port
+func (e *Engine) applies(r *Rule, portName string) bool {
+	return r.Port == "" || r.Port == portName || r.Port == e.alias(portName)
+}
+
+// feed matches a chunk of received data against every rule watching its port
+func (e *Engine) feed(chunk serial.PortData) {
+	for i, r := range e.rules {
+		if !e.applies(r, chunk.PortName) {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", i, chunk.PortName)
+		buf := append(e.buffers[key], chunk.Data...)
+
+		for {
+			match := r.find(buf)
+			if match == nil {
+				break
+			}
+			data := append([]byte(nil), buf[:match[1]]...)
+			buf = buf[match[1]:]
+
+			if last, ok := e.lastFired[key]; ok && chunk.Timestamp.Sub(last) < r.Cooldown {
+				continue
+			}
+			e.lastFired[key] = chunk.Timestamp
+
+			select {
+			case e.triggers <- trigger{rule: r, portName: chunk.PortName, sessionID: chunk.SessionID, data: data, match: match, at: chunk.Timestamp}:
+			default:
+				log.Printf("Rule %s: action queue full, dropping trigger on %s", r.Name, chunk.PortName)
+			}
+		}
+
+		if len(buf) > maxBuffer {
+			buf = buf[len(buf)-maxBuffer:]
+		}
+		e.buffers[key] = append([]byte(nil), buf...)
+	}
+}
+
+// find returns the submatch indexes of the first match in data
+func (r *Rule) find(data []byte) []int {
+	if r.Pattern != nil {
+		return r.Pattern.FindSubmatchIndex(data)
+	}
+	i := bytes.Index(data, r.Bytes)
+	if i < 0 {
+		return nil
+	}
+	return []int{i, i + len(r.Bytes)}
+}
+
+// expand substitutes capture group references in a template
+func (t trigger) expand(template string) string {
+	if t.rule.Pattern == nil {
+		return strings.ReplaceAll(template, "$0", string(t.matched()))
+	}
+	return string(t.rule.Pattern.Expand(nil, []byte(template), t.data, t.match))
+}
+
+// matched returns the data matched by the rule
+func (t trigger) matched() []byte {
+	return t.data[t.match[0]:t.match[1]]
+}
+
+// payload returns the message body of an mqtt or webhook action
+func (t trigger) payload(a Action) []byte {
+	if a.Payload != "" {
+		return []byte(t.expand(a.Payload))
+	}
+	body, _ := json.Marshal(struct {
+		Rule      string    `json:"rule"`
+		Port      string    `json:"port"`
+		Match     string    `json:"match"`
+		Timestamp time.Time `json:"timestamp"`
+	}{t.rule.Name, t.portName, string(t.matched()), t.at})
+	return body
+}
+
+// run performs the actions of a triggered rule in order
+func (e *Engine) run(t trigger) {
+	for _, a := range t.rule.Actions {
+		if err := e.perform(t, a); err != nil {
+			log.Printf("Rule %s: %s action on %s failed: %v", t.rule.Name, a.Type, t.portName, err)
+		}
+	}
+}
+
+// perform runs a single action
+func (e *Engine) perform(t trigger, a Action) error {
+	switch a.Type {
+	case ActionWrite:
+		data := a.Data
+		if a.Text != "" {
+			data = []byte(t.expand(a.Text))
+		}
+		_, err := e.manager.Write(t.portName, t.sessionID, data)
+		return err
+
+	case ActionMQTT:
+		return e.mqtt.Publish(t.expand(a.Topic), t.payload(a), a.Retain)
+
+	case ActionWebhook:
+		contentType := "application/json"
+		if a.Payload != "" {
+			contentType = "text/plain; charset=utf-8"
+		}
+		resp, err := e.http.Post(a.URL, contentType, bytes.NewReader(t.payload(a)))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return errors.New(resp.Status)
+		}
+		return nil
+
+	case ActionEvent:
+		e.manager.Events().Publish(serial.Event{
+			Type:      serial.EventRuleMatched,
+			PortName:  t.portName,
+			SessionID: t.sessionID,
+			Rule:      t.rule.Name,
+			Data:      append([]byte(nil), t.matched()...),
+			Message:   fmt.Sprintf("rule %s matched", t.rule.Name),
+			Timestamp: t.at,
+		})
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scpi implements SCPI instrument queries, command batches, and
+// error queue draining on top of the serial transaction engine
+package scpi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// Defaults
+const (
+	DefaultTerminator = "\n"
+	DefaultMaxErrors  = 32
+
+	// ErrorQuery reads the oldest entry of the instrument's error queue
+	ErrorQuery = "SYST:ERR?"
+)
+
+// ErrTimeout is returned when a query is not answered in time
+var ErrTimeout = errors.New("timeout waiting for response")
+
+// Instrument sends SCPI commands over an open port session
+type Instrument struct {
+	manager   *serial.Manager
+	portName  string
+	sessionID string
+
+	// Terminator ends every command and response (default: newline)
+	Terminator string
+
+	// Timeout bounds each query (default: the session's read timeout)
+	Timeout time.Duration
+}
+
+// New creates an instrument for an open session
+func New(manager *serial.Manager, portName, sessionID string) *Instrument {
+	return &Instrument{
+		manager:    manager,
+		portName:   portName,
+		sessionID:  sessionID,
+		Terminator: DefaultTerminator,
+	}
+}
+
+// Result is the outcome of one command in a batch
+type Result struct {
+	Command  string
+	Response string // Empty for commands that are not queries
+	Err      error
+	Elapsed  time.Duration
+	Sent     int // Bytes written
+}
+
+// Error is an entry of the instrument's error queue
+type Error struct {
+	Code    int
+	Message string
+}
+
+func (e Error) String() string {
+	return fmt.Sprintf("%d, %s", e.Code, e.Message)
+}
+
+// IsQuery reports whether a command expects a response, i.e. whether any of
+// its semicolon separated parts has a header ending in '?'
+func IsQuery(command string) bool {
+	for _, part := range strings.Split(command, ";") {
+		header, _, _ := strings.Cut(strings.TrimSpace(part), " ")
+		if strings.HasSuffix(header, "?") {
+			return true
+		}
+	}
+	return false
+}
+
+// Query sends a query and returns its response without the terminator
+func (i *Instrument) Query(command string) (string, error) {
+	r := i.Exec(command)
+	return r.Response, r.Err
+}
+
+// Write sends a command that has no response
+func (i *Instrument) Write(command string) error {
+	return i.Exec(command).Err
+}
+
+// Exec sends a command, reading the response if it is a query. Stale input
+// is discarded first so the response cannot be mistaken for an earlier one.
+func (i *Instrument) Exec(command string) Result {
+	start := time.Now()
+	r := Result{Command: command}
+	request := []byte(strings.TrimRight(command, "\r\n") + i.terminator())
+
+	if !IsQuery(command) {
+		r.Sent, r.Err = i.manager.Write(i.portName, i.sessionID, request)
+		r.Elapsed = time.Since(start)
+		return r
+	}
+
+	result, err := i.manager.Transact(i.portName, i.sessionID, serial.TransactOptions{
+		Request:    request,
+		Terminator: []byte(i.terminator()),
+		Timeout:    i.Timeout,
+		FlushInput: true,
+	})
+	r.Elapsed = time.Since(start)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	r.Sent = len(request)
+	r.Response = strings.TrimRight(string(result.Data), "\r\n")
+	if !result.Matched {
+		r.Err = ErrTimeout
+	}
+	return r
+}
+
+// Batch sends commands in order and returns a result for each. With
+// stopOnError, commands after the first failure are not sent.
+func (i *Instrument) Batch(commands []string, stopOnError bool) []Result {
+	results := make([]Result, 0, len(commands))
+	for _, command := range commands {
+		r := i.Exec(command)
+		results = append(results, r)
+		if r.Err != nil && stopOnError {
+			break
+		}
+	}
+	return results
+}
+
+// Errors drains the instrument's error queue, reading SYST:ERR? until it
+// reports no error or max entries have been read (default: 32)
+func (i *Instrument) Errors(max int) ([]Error, error) {
+	if max <= 0 {
+		max = DefaultMaxErrors
+	}
+
+	var errs []Error
+	for len(errs) < max {
+		response, err := i.Query(ErrorQuery)
+		if err != nil {
+			return errs, err
+		}
+		e, err := ParseError(response)
+		if err != nil {
+			return errs, err
+		}
+		if e.Code == 0 {
+			break
+		}
+		errs = append(errs, e)
+	}
+	return errs, nil
+}
+
+// ParseError parses a SYST:ERR? response such as -113,"Undefined header"
+func ParseError(response string) (Error, error) {
+	code, message, _ := strings.Cut(strings.TrimSpace(response), ",")
+	n, err := strconv.Atoi(strings.TrimSpace(code))
+	if err != nil {
+		return Error{}, fmt.Errorf("invalid error queue response: %q", response)
+	}
+	return Error{
+		Code:    n,
+		Message: strings.Trim(strings.TrimSpace(message), `"`),
+	}, nil
+}
+
+// terminator returns the configured terminator or the default
+func (i *Instrument) terminator() string {
+	if i.Terminator == "" {
+		return DefaultTerminator
+	}
+	return i.Terminator
+}
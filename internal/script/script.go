@@ -0,0 +1,268 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package script implements expect-style send/expect scripts that run
+// server-side against an open serial session
+package script
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Shoaibashk/BaudLink/internal/serial"
+)
+
+// DefaultTimeout is used for expect steps when the script sets no timeout
+const DefaultTimeout = 5 * time.Second
+
+// pollInterval bounds each read while waiting for an expected pattern
+const pollInterval = 200 * time.Millisecond
+
+// maxBuffer bounds the unmatched input retained between expect steps
+const maxBuffer = 64 * 1024
+
+// Script is a sequence of steps loaded from YAML
+type Script struct {
+	Name    string        `yaml:"name"`
+	Timeout time.Duration `yaml:"timeout"`
+	Steps   []Step        `yaml:"steps"`
+}
+
+// Step is a single script action. Exactly one of Send, Expect, or Sleep is set.
+type Step struct {
+	Send    string        `yaml:"send,omitempty"`
+	Expect  string        `yaml:"expect,omitempty"`
+	Sleep   time.Duration `yaml:"sleep,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"` // Overrides the script timeout for an expect step
+
+	pattern *regexp.Regexp
+}
+
+// Parse parses and validates a YAML script
+func Parse(data []byte) (*Script, error) {
+	var s Script
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Validate checks the script and compiles its expect patterns
+func (s *Script) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("script has no steps")
+	}
+
+	for i := range s.Steps {
+		step := &s.Steps[i]
+
+		actions := 0
+		if step.Send != "" {
+			actions++
+		}
+		if step.Expect != "" {
+			actions++
+		}
+		if step.Sleep > 0 {
+			actions++
+		}
+		if actions != 1 {
+			return fmt.Errorf("step %d: exactly one of send, expect, or sleep is required", i+1)
+		}
+
+		if step.Expect != "" {
+			re, err := regexp.Compile(step.Expect)
+			if err != nil {
+				return fmt.Errorf("step %d: invalid expect pattern: %w", i+1, err)
+			}
+			step.pattern = re
+		}
+	}
+
+	return nil
+}
+
+// EventType identifies a transcript entry
+type EventType int
+
+const (
+	EventUnknown EventType = iota
+	EventSent
+	EventReceived
+	EventMatched
+	EventSlept
+	EventFailed
+	EventCompleted
+)
+
+// String returns the string representation of EventType
+func (t EventType) String() string {
+	switch t {
+	case EventSent:
+		return "sent"
+	case EventReceived:
+		return "received"
+	case EventMatched:
+		return "matched"
+	case EventSlept:
+		return "slept"
+	case EventFailed:
+		return "failed"
+	case EventCompleted:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single transcript entry produced while running a script
+type Event struct {
+	Step      int // 1-based step number, 0 for script-level events
+	Type      EventType
+	Data      []byte
+	Message   string
+	Timestamp time.Time
+}
+
+// Runner executes scripts against a session
+type Runner struct {
+	manager   *serial.Manager
+	portName  string
+	sessionID string
+	emit      func(Event)
+	buffer    []byte
+}
+
+// NewRunner creates a runner for a session. emit receives the transcript and
+// may be nil.
+func NewRunner(manager *serial.Manager, portName, sessionID string, emit func(Event)) *Runner {
+	if emit == nil {
+		emit = func(Event) {}
+	}
+	return &Runner{
+		manager:   manager,
+		portName:  portName,
+		sessionID: sessionID,
+		emit:      emit,
+	}
+}
+
+// Run executes the script step by step. It stops at the first failed step
+// and returns its error.
+func (r *Runner) Run(ctx context.Context, s *Script) error {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for i, step := range s.Steps {
+		n := i + 1
+
+		var err error
+		switch {
+		case step.Send != "":
+			err = r.send(n, []byte(step.Send))
+		case step.Expect != "":
+			t := step.Timeout
+			if t <= 0 {
+				t = timeout
+			}
+			err = r.expect(ctx, n, step.pattern, t)
+		case step.Sleep > 0:
+			err = r.sleep(ctx, n, step.Sleep)
+		}
+
+		if err != nil {
+			r.emit(Event{Step: n, Type: EventFailed, Message: err.Error(), Timestamp: time.Now()})
+			return fmt.Errorf("step %d: %w", n, err)
+		}
+	}
+
+	r.emit(Event{Type: EventCompleted, Message: fmt.Sprintf("%d steps completed", len(s.Steps)), Timestamp: time.Now()})
+	return nil
+}
+
+// send writes data to the session
+func (r *Runner) send(step int, data []byte) error {
+	if _, err := r.manager.Write(r.portName, r.sessionID, data); err != nil {
+		return err
+	}
+	r.emit(Event{Step: step, Type: EventSent, Data: data, Timestamp: time.Now()})
+	return nil
+}
+
+// expect reads until the pattern matches the unconsumed input or the
+// timeout expires. Input following the match is kept for the next step.
+func (r *Runner) expect(ctx context.Context, step int, pattern *regexp.Regexp, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if loc := pattern.FindIndex(r.buffer); loc != nil {
+			r.emit(Event{Step: step, Type: EventMatched, Data: append([]byte(nil), r.buffer[loc[0]:loc[1]]...), Timestamp: time.Now()})
+			r.buffer = r.buffer[loc[1]:]
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after %s waiting for %q", timeout, pattern.String())
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		wait := pollInterval
+		if remaining < wait {
+			wait = remaining
+		}
+
+		result, err := r.manager.Transact(r.portName, r.sessionID, serial.TransactOptions{
+			ExpectedBytes: 1,
+			Timeout:       wait,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(result.Data) > 0 {
+			r.emit(Event{Step: step, Type: EventReceived, Data: result.Data, Timestamp: time.Now()})
+			r.buffer = append(r.buffer, result.Data...)
+			if len(r.buffer) > maxBuffer {
+				r.buffer = r.buffer[len(r.buffer)-maxBuffer:]
+			}
+		}
+	}
+}
+
+// sleep pauses the script
+func (r *Runner) sleep(ctx context.Context, step int, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+	}
+	r.emit(Event{Step: step, Type: EventSlept, Message: d.String(), Timestamp: time.Now()})
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrStreamNotFound is returned when acknowledging a stream that does not
+// exist or has ended
+var ErrStreamNotFound = errors.New("stream not found")
+
+// ackWindow tracks the chunks an acknowledged reader has delivered but its
+// client has not yet acknowledged
+type ackWindow struct {
+	id     string
+	size   int
+	mu     sync.Mutex
+	sent   []uint32 // Unacknowledged sequence numbers, oldest first
+	notify chan struct{}
+}
+
+// EnableAcks switches the reader to acknowledged delivery: chunks are never
+// dropped, and once window chunks are unacknowledged the reader stops taking
+// data from the session, leaving it in the receive buffer. It must be called
+// before Start and returns the ID the client acknowledges the stream with.
+func (r *Reader) EnableAcks(window int) string {
+	if window < 1 {
+		window = 1
+	}
+	r.acks = &ackWindow{
+		id:     uuid.New().String(),
+		size:   window,
+		notify: make(chan struct{}),
+	}
+	return r.acks.id
+}
+
+// Ack acknowledges the chunk with the given sequence number and every chunk
+// before it, returning the number still unacknowledged
+func (r *Reader) Ack(sequence uint32) int {
+	w := r.acks
+	if w == nil {
+		return 0
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := 0
+	// Compare as a signed difference so acks survive sequence wraparound
+	for n < len(w.sent) && int32(w.sent[n]-sequence) <= 0 {
+		n++
+	}
+	if n > 0 {
+		w.sent = w.sent[n:]
+		close(w.notify)
+		w.notify = make(chan struct{})
+	}
+	return len(w.sent)
+}
+
+// awaitWindow blocks while the reader's window is full. It returns false if
+// the reader is stopped first; the session closing ends the wait so the
+// error reaches the client.
+func (r *Reader) awaitWindow() bool {
+	w := r.acks
+	for {
+		w.mu.Lock()
+		if len(w.sent) < w.size {
+			w.mu.Unlock()
+			return true
+		}
+		notify := w.notify
+		w.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-r.stopChan:
+			return false
+		case <-time.After(streamPollInterval):
+			if r.session.closed.Load() {
+				return true
+			}
+		}
+	}
+}
+
+// track records a delivered chunk as awaiting acknowledgement
+func (w *ackWindow) track(sequence uint32) {
+	w.mu.Lock()
+	w.sent = append(w.sent, sequence)
+	w.mu.Unlock()
+}
+
+// ackedReader returns the acknowledged reader with the given stream ID
+// reading for id, or nil
+func (s *Session) ackedReader(id, streamID string) *Reader {
+	s.streamsMu.Lock()
+	st := s.streams[id]
+	s.streamsMu.Unlock()
+
+	if st == nil {
+		return nil
+	}
+	for _, r := range st.list() {
+		if r.acks != nil && r.acks.id == streamID {
+			return r
+		}
+	}
+	return nil
+}
+
+// AckStream acknowledges delivery of an acknowledged stream's chunks up to
+// and including sequence, resuming the stream if its window was full. It
+// returns the number of chunks still unacknowledged.
+func (m *Manager) AckStream(portName, sessionID, streamID string, sequence uint32) (int, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	r := session.ackedReader(sessionID, streamID)
+	if r == nil {
+		return 0, ErrStreamNotFound
+	}
+	return r.Ack(sequence), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAliasNotConnected is returned when an alias matches no connected device
+var ErrAliasNotConnected = errors.New("no connected device matches alias")
+
+// Alias gives a device a stable friendly name independent of the device
+// path the OS assigned to it
+type Alias struct {
+	Name    string
+	Matches func(PortInfo) bool
+}
+
+// SetAliases replaces the scanner's aliases
+func (s *Scanner) SetAliases(aliases []Alias) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.aliases = aliases
+}
+
+// IsAlias reports whether name is a configured alias
+func (s *Scanner) IsAlias(name string) bool {
+	return s.findAlias(name) != nil
+}
+
+// Resolve maps an alias to the device path of the matching port. Names that
+// are not aliases are returned unchanged.
+func (s *Scanner) Resolve(name string) (string, error) {
+	alias := s.findAlias(name)
+	if alias == nil {
+		return name, nil
+	}
+
+	// Try the cached list first, then rescan in case the device re-enumerated
+	if port := matchAlias(alias, s.GetCached()); port != "" {
+		return port, nil
+	}
+
+	ports, err := s.Scan()
+	if err != nil {
+		return "", err
+	}
+	if port := matchAlias(alias, ports); port != "" {
+		return port, nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrAliasNotConnected, name)
+}
+
+// aliasFor returns the name of the first alias matching a port
+func (s *Scanner) aliasFor(port PortInfo) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, a := range s.aliases {
+		if a.Matches(port) {
+			return a.Name
+		}
+	}
+	return ""
+}
+
+func (s *Scanner) findAlias(name string) *Alias {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i := range s.aliases {
+		if s.aliases[i].Name == name {
+			return &s.aliases[i]
+		}
+	}
+	return nil
+}
+
+func matchAlias(alias *Alias, ports []PortInfo) string {
+	for _, p := range ports {
+		if alias.Matches(p) {
+			return p.Name
+		}
+	}
+	return ""
+}
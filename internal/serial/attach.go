@@ -0,0 +1,244 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrReadOnly is returned when a read-only attachment tries to modify a port
+	ErrReadOnly = errors.New("attachment is read-only")
+	// ErrSniffSession is returned when a session opened for sniffing tries
+	// to modify a port
+	ErrSniffSession = errors.New("port is open for sniffing only")
+)
+
+// Role is the access level of a session attachment
+type Role int
+
+const (
+	RoleReadWrite Role = iota
+	RoleReadOnly
+)
+
+// String returns the string representation of Role
+func (r Role) String() string {
+	switch r {
+	case RoleReadOnly:
+		return "read-only"
+	default:
+		return "read-write"
+	}
+}
+
+// Attachment is a client attached to a long-lived session. The attachment ID
+// can be used wherever a session ID is accepted.
+type Attachment struct {
+	ID            string
+	ClientID      string
+	Role          Role
+	AttachedAt    time.Time
+	BytesSent     uint64
+	BytesReceived uint64
+	buffer        *RingBuffer // Per-attachment copy of received data
+	owner         string      // Identity the attachment is bound to (guarded by the session's attachMu)
+
+	// Read filters of the attachment's stream, built from readSpecs
+	// (guarded by the session's mu)
+	readFilters Pipeline
+	readSpecs   []string
+	filtersSet  bool
+}
+
+// Attach attaches a client to an open session. Managed sessions accept any
+// number of attachments; other sessions only when shared access is allowed
+// and the session is not exclusive.
+func (m *Manager) Attach(portName string, clientID string, role Role) (*Attachment, *Session, error) {
+	session := m.GetSession(portName)
+	if session == nil {
+		return nil, nil, ErrPortNotOpen
+	}
+	if session.closed.Load() {
+		return nil, nil, ErrPortClosed
+	}
+
+	if !session.Managed {
+		m.mu.RLock()
+		shared := m.allowSharedAccess
+		m.mu.RUnlock()
+
+		if session.Exclusive || !shared {
+			return nil, nil, ErrPortLocked
+		}
+	}
+
+	return session.attach(clientID, role), session, nil
+}
+
+// attach adds an attachment to the session
+func (s *Session) attach(clientID string, role Role) *Attachment {
+	att := &Attachment{
+		ID:         uuid.New().String(),
+		ClientID:   clientID,
+		Role:       role,
+		AttachedAt: time.Now(),
+		buffer:     NewRingBuffer(s.buffer.Cap()),
+	}
+	if s.buffer.keepsPackets() {
+		att.buffer.KeepPackets()
+	}
+
+	s.attachMu.Lock()
+	if s.attachments == nil {
+		s.attachments = make(map[string]*Attachment)
+	}
+	s.attachments[att.ID] = att
+	s.attachMu.Unlock()
+
+	return att
+}
+
+// readPipeline returns the read filters for data taken from att's buffer,
+// or from the session's own buffer when att is nil. Filters keep state
+// between chunks, so every attachment has a pipeline of its own, built on
+// first use and rebuilt when the configured filters change
+// (must be called with the session lock held)
+func (s *Session) readPipeline(att *Attachment) Pipeline {
+	if att == nil || att.buffer == nil {
+		return s.readFilters
+	}
+	if !att.filtersSet || !slices.Equal(att.readSpecs, s.Config.ReadFilters) {
+		att.readFilters, _ = NewPipeline(s.Config.ReadFilters)
+		att.readSpecs = s.Config.ReadFilters
+		att.filtersSet = true
+	}
+	return att.readFilters
+}
+
+// Detach removes an attachment from a session
+func (m *Manager) Detach(portName string, attachmentID string) error {
+	session := m.GetSession(portName)
+	if session == nil {
+		return ErrPortNotOpen
+	}
+
+	if !session.detach(attachmentID) {
+		return ErrInvalidSession
+	}
+	return nil
+}
+
+// detach removes an attachment from the session and reports whether it existed
+func (s *Session) detach(attachmentID string) bool {
+	s.attachMu.Lock()
+	att, exists := s.attachments[attachmentID]
+	delete(s.attachments, attachmentID)
+	s.attachMu.Unlock()
+
+	if exists && att.buffer != nil {
+		att.buffer.Close()
+	}
+	return exists
+}
+
+// Attachments returns the session's attachments ordered by attach time
+func (s *Session) Attachments() []*Attachment {
+	s.attachMu.RLock()
+	defer s.attachMu.RUnlock()
+
+	list := make([]*Attachment, 0, len(s.attachments))
+	for _, att := range s.attachments {
+		list = append(list, att)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].AttachedAt.Before(list[j].AttachedAt)
+	})
+	return list
+}
+
+// AttachmentCount returns the number of clients attached to the session
+func (s *Session) AttachmentCount() int {
+	s.attachMu.RLock()
+	defer s.attachMu.RUnlock()
+	return len(s.attachments)
+}
+
+// attachment returns the attachment with the given ID, or nil
+func (s *Session) attachment(id string) *Attachment {
+	s.attachMu.RLock()
+	defer s.attachMu.RUnlock()
+	return s.attachments[id]
+}
+
+// checkWritable returns ErrReadOnly if id names a read-only attachment, and
+// ErrSniffSession for any ID of a session opened for sniffing
+func (s *Session) checkWritable(id string) (*Attachment, error) {
+	att := s.attachment(id)
+	if s.sniff {
+		return att, ErrSniffSession
+	}
+	if att != nil && att.Role == RoleReadOnly {
+		return att, ErrReadOnly
+	}
+	return att, nil
+}
+
+// distribute copies data received at the given time into every
+// attachment's buffer
+func (s *Session) distribute(data []byte, at time.Time) {
+	s.attachMu.RLock()
+	defer s.attachMu.RUnlock()
+
+	for _, att := range s.attachments {
+		if att.buffer != nil {
+			att.buffer.WriteAt(data, at)
+		}
+	}
+}
+
+// closeAttachments wakes readers blocked on attachment buffers
+func (s *Session) closeAttachments() {
+	s.attachMu.Lock()
+	defer s.attachMu.Unlock()
+
+	for _, att := range s.attachments {
+		if att.buffer != nil {
+			att.buffer.Close()
+		}
+	}
+}
+
+// recordSent adds to the attachment's sent byte counter
+func (a *Attachment) recordSent(n int) {
+	if a != nil {
+		atomic.AddUint64(&a.BytesSent, uint64(n))
+	}
+}
+
+// recordReceived adds to the attachment's received byte counter
+func (a *Attachment) recordReceived(n int) {
+	if a != nil {
+		atomic.AddUint64(&a.BytesReceived, uint64(n))
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+// ErrEchoMismatch is returned when a batch item's expected echo is not
+// received before its timeout
+var ErrEchoMismatch = errors.New("expected echo not received")
+
+// BatchItem is one write of a batch
+type BatchItem struct {
+	Data        []byte
+	Delay       time.Duration // Wait after the write, e.g. while the device applies it
+	Echo        []byte        // The device must echo this back; nil skips verification
+	EchoTimeout time.Duration // Wait for the echo (default: read timeout)
+}
+
+// BatchResult is the outcome of one batch item
+type BatchResult struct {
+	Written  int
+	Echo     []byte // Data received while waiting for the echo
+	Err      error
+	Executed bool // False for items skipped after an earlier failure
+}
+
+// WriteBatch writes the items in order while holding the session lock, so no
+// other read or write can interleave with the batch. Items with an expected
+// echo are verified before the next item is written. The batch stops at the
+// first failure; later items are reported as not executed. The returned
+// error is set when the batch could not start.
+func (m *Manager) WriteBatch(portName string, sessionID string, items []BatchItem) ([]BatchResult, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	att, err := session.checkWritable(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.Managed && session.IsDisconnected() {
+		return nil, ErrPortDisconnected
+	}
+
+	total := 0
+	for _, item := range items {
+		total += len(item.Data)
+	}
+	if err := m.checkRateLimit(session, total); err != nil {
+		return nil, err
+	}
+	if err := m.checkWriteQuota(session, att, total); err != nil {
+		return nil, err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if err := session.checkPassthrough(); err != nil {
+		return nil, err
+	}
+
+	// Echoes belong to the batch, not to other readers
+	session.readMu.Lock()
+	defer session.readMu.Unlock()
+
+	readTimeout := time.Duration(session.Config.ReadTimeoutMs) * time.Millisecond
+	if readTimeout <= 0 {
+		readTimeout = time.Second
+	}
+
+	results := make([]BatchResult, len(items))
+	for i, item := range items {
+		result := &results[i]
+		result.Executed = true
+
+		if len(item.Echo) > 0 {
+			session.resetInput(att)
+		}
+
+		data, err := session.writeFilters.Apply(item.Data)
+		if err != nil {
+			result.Err = err
+			break
+		}
+		data = session.checksum.Append(data)
+		n, err := session.writeData(data)
+		result.Written = n
+		if err != nil {
+			session.Statistics.Errors.Add(1)
+			result.Err = m.handleDeviceLoss(session, err)
+			break
+		}
+		session.recordSent(data[:n])
+		att.recordSent(n)
+
+		if len(item.Echo) > 0 {
+			timeout := item.EchoTimeout
+			if timeout <= 0 {
+				timeout = readTimeout
+			}
+			result.Echo, result.Err = session.awaitEcho(att, item.Echo, timeout)
+			if result.Err != nil {
+				break
+			}
+		}
+
+		if item.Delay > 0 {
+			time.Sleep(item.Delay)
+		}
+	}
+
+	session.Statistics.Touch()
+
+	return results, nil
+}
+
+// awaitEcho collects input until it contains the expected echo or the timeout
+// expires. It returns everything received.
+func (s *Session) awaitEcho(att *Attachment, echo []byte, timeout time.Duration) ([]byte, error) {
+	pooled := chunkPool.Get().(*[]byte)
+	defer chunkPool.Put(pooled)
+	buffer := *pooled
+
+	var received []byte
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		n, err := s.readInput(att, buffer, transactPollInterval)
+		if err != nil {
+			return received, err
+		}
+		if n == 0 {
+			continue
+		}
+		att.recordReceived(n)
+
+		received = append(received, buffer[:n]...)
+		if bytes.Contains(received, echo) {
+			return received, nil
+		}
+	}
+
+	return received, ErrEchoMismatch
+}
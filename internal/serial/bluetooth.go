@@ -0,0 +1,180 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.bug.st/serial"
+)
+
+// BluetoothPrefix starts the names of Bluetooth ports opened by address,
+// e.g. bt:00:11:22:33:44:55, or bt:00:11:22:33:44:55/2 for RFCOMM channel 2
+const BluetoothPrefix = "bt:"
+
+// defaultRFCOMMChannel is the channel used when a port name gives none, the
+// one the Serial Port Profile is found on for most adapters
+const defaultRFCOMMChannel = 1
+
+var ErrBluetoothUnsupported = errors.New("Bluetooth ports by address are not supported on this platform")
+
+// BluetoothDevice is a paired device offering the Serial Port Profile
+type BluetoothDevice struct {
+	Address string // e.g. 00:11:22:33:44:55
+	Name    string // Name the device announced when it was paired
+	Adapter string // Address of the local adapter it is paired with
+}
+
+// PortName returns the name the device is opened with
+func (d BluetoothDevice) PortName() string {
+	return BluetoothPrefix + d.Address
+}
+
+// PairedBluetoothDevices lists the paired devices offering the Serial Port
+// Profile. Each can be opened as bt:<address> without binding an rfcomm
+// device first.
+func PairedBluetoothDevices() ([]BluetoothDevice, error) {
+	return pairedBluetoothDevices()
+}
+
+// IsBluetoothPort reports whether a port name is a Bluetooth address
+func IsBluetoothPort(name string) bool {
+	return len(name) > len(BluetoothPrefix) && strings.EqualFold(name[:len(BluetoothPrefix)], BluetoothPrefix)
+}
+
+// parseBluetoothPort splits a Bluetooth port name into the device address
+// and RFCOMM channel
+func parseBluetoothPort(name string) (string, uint8, error) {
+	addr := name[len(BluetoothPrefix):]
+	channel := uint64(defaultRFCOMMChannel)
+	if i := strings.IndexByte(addr, '/'); i >= 0 {
+		var err error
+		if channel, err = strconv.ParseUint(addr[i+1:], 10, 8); err != nil || channel < 1 || channel > 30 {
+			return "", 0, fmt.Errorf("invalid RFCOMM channel in %s: must be 1-30", name)
+		}
+		addr = addr[:i]
+	}
+	if _, err := parseBluetoothAddress(addr); err != nil {
+		return "", 0, fmt.Errorf("invalid Bluetooth port %s: %w", name, err)
+	}
+	return strings.ToUpper(addr), uint8(channel), nil
+}
+
+// parseBluetoothAddress parses a colon-separated device address into its
+// six octets, most significant first
+func parseBluetoothAddress(addr string) ([6]byte, error) {
+	var b [6]byte
+	parts := strings.Split(addr, ":")
+	if len(parts) != len(b) {
+		return b, fmt.Errorf("address %q is not of the form 00:11:22:33:44:55", addr)
+	}
+	for i, part := range parts {
+		v, err := strconv.ParseUint(part, 16, 8)
+		if err != nil || len(part) != 2 {
+			return b, fmt.Errorf("address %q is not of the form 00:11:22:33:44:55", addr)
+		}
+		b[i] = byte(v)
+	}
+	return b, nil
+}
+
+// canonicalBluetoothName upper-cases the address of a Bluetooth port name
+// and drops the default channel, so sessions are keyed by one name
+func canonicalBluetoothName(name string) string {
+	addr, channel, err := parseBluetoothPort(name)
+	if err != nil {
+		return name
+	}
+	if channel != defaultRFCOMMChannel {
+		return fmt.Sprintf("%s%s/%d", BluetoothPrefix, addr, channel)
+	}
+	return BluetoothPrefix + addr
+}
+
+// openBluetooth binds an rfcomm device to a Bluetooth port and opens it.
+// The binding is released when the port is closed, unless it already
+// existed.
+func openBluetooth(portName string, config PortConfig, locking SystemLockSettings) (serial.Port, bool, error) {
+	addr, channel, err := parseBluetoothPort(portName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	device, release, err := bindRFCOMM(addr, channel)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to bind %s: %w", portName, err)
+	}
+
+	port, kernel, err := openDevice(device, config, locking)
+	if err != nil {
+		release()
+		return nil, false, err
+	}
+	return &boundPort{Port: port, release: release}, kernel, nil
+}
+
+// boundPort is a port on an rfcomm device bound for it, released when the
+// port is closed
+type boundPort struct {
+	serial.Port
+	release func()
+}
+
+// Close closes the port and then releases its binding
+func (p *boundPort) Close() error {
+	err := p.Port.Close()
+	p.release()
+	return err
+}
+
+// bluetoothPorts lists paired devices as ports that pass the scanner's
+// filters
+func (s *Scanner) bluetoothPorts() []PortInfo {
+	devices, err := pairedBluetoothDevices()
+	if err != nil {
+		return nil
+	}
+
+	var ports []PortInfo
+	for _, d := range devices {
+		info := PortInfo{
+			Name:        d.PortName(),
+			Description: "Bluetooth SPP",
+			PortType:    PortTypeBluetooth,
+			Product:     d.Name,
+		}
+		if d.Name != "" {
+			info.Description = d.Name + " (Bluetooth SPP)"
+		}
+		if s.isExcluded(info.Name) || s.isFiltered(info) {
+			continue
+		}
+		info.Alias = s.aliasFor(info)
+
+		if s.manager != nil {
+			if session := s.manager.GetSession(info.Name); session != nil {
+				info.IsOpen = true
+				info.LockedBy = session.ClientID
+			}
+		}
+		ports = append(ports, info)
+	}
+	return ports
+}
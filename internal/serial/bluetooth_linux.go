@@ -0,0 +1,204 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// bluetoothStateDir is where BlueZ keeps paired devices, one directory
+	// per adapter and device address
+	bluetoothStateDir = "/var/lib/bluetooth"
+
+	// sppUUID is the service class of the Serial Port Profile
+	sppUUID = "00001101-0000-1000-8000-00805f9b34fb"
+
+	// rfcommCreateDev and rfcommReleaseDev are RFCOMMCREATEDEV and
+	// RFCOMMRELEASEDEV, _IOW('R', 200, int) and _IOW('R', 201, int)
+	rfcommCreateDev  = 0x400452c8
+	rfcommReleaseDev = 0x400452c9
+
+	// rfcommHangupNow is 1 << RFCOMM_HANGUP_NOW, dropping the connection of
+	// a device being released
+	rfcommHangupNow = 1 << 2
+)
+
+// rfcommDevReq is struct rfcomm_dev_req; addresses are stored least
+// significant octet first
+type rfcommDevReq struct {
+	devID   int16
+	_       [2]byte
+	flags   uint32
+	src     [6]byte
+	dst     [6]byte
+	channel uint8
+	_       [3]byte
+}
+
+// pairedBluetoothDevices reads the devices BlueZ has link keys for and
+// keeps those announcing the Serial Port Profile
+func pairedBluetoothDevices() ([]BluetoothDevice, error) {
+	infos, err := filepath.Glob(filepath.Join(bluetoothStateDir, "*", "*", "info"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var devices []BluetoothDevice
+	for _, path := range infos {
+		info, err := readBluetoothInfo(path)
+		if err != nil || !info.paired || !strings.Contains(strings.ToLower(info.services), sppUUID) {
+			continue
+		}
+
+		dir := filepath.Dir(path)
+		d := BluetoothDevice{
+			Address: strings.ToUpper(filepath.Base(dir)),
+			Name:    info.name,
+			Adapter: strings.ToUpper(filepath.Base(filepath.Dir(dir))),
+		}
+		if _, err := parseBluetoothAddress(d.Address); err != nil || seen[d.Address] {
+			continue
+		}
+		seen[d.Address] = true
+		devices = append(devices, d)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Address < devices[j].Address
+	})
+	return devices, nil
+}
+
+// bluetoothInfo is what pairedBluetoothDevices needs from a device's info
+// file
+type bluetoothInfo struct {
+	name     string
+	services string
+	paired   bool
+}
+
+// readBluetoothInfo reads the keys of a BlueZ device info file
+func readBluetoothInfo(path string) (bluetoothInfo, error) {
+	var info bluetoothInfo
+
+	f, err := os.Open(path)
+	if err != nil {
+		return info, err
+	}
+	defer f.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			if section == "LinkKey" {
+				info.paired = true
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || section != "General" {
+			continue
+		}
+		switch key {
+		case "Name":
+			info.name = value
+		case "Services":
+			info.services = value
+		}
+	}
+	return info, scanner.Err()
+}
+
+// bindRFCOMM returns an rfcomm device connected to a Bluetooth address and
+// channel, and a function releasing it. A device bound beforehand, e.g.
+// with rfcomm bind, is reused and left bound.
+func bindRFCOMM(addr string, channel uint8) (string, func(), error) {
+	if device := boundRFCOMM(addr, channel); device != "" {
+		return device, func() {}, nil
+	}
+
+	dst, err := parseBluetoothAddress(addr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW, unix.BTPROTO_RFCOMM)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open an RFCOMM socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	// A negative ID lets the kernel pick the first free rfcomm device
+	req := rfcommDevReq{devID: -1, channel: channel}
+	for i := range dst {
+		req.dst[i] = dst[len(dst)-1-i]
+	}
+	id, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), rfcommCreateDev, uintptr(unsafe.Pointer(&req)))
+	if errno != 0 {
+		return "", nil, fmt.Errorf("failed to create an rfcomm device: %w", errno)
+	}
+
+	release := func() {
+		fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW, unix.BTPROTO_RFCOMM)
+		if err != nil {
+			return
+		}
+		defer unix.Close(fd)
+		req := rfcommDevReq{devID: int16(id), flags: rfcommHangupNow}
+		unix.Syscall(unix.SYS_IOCTL, uintptr(fd), rfcommReleaseDev, uintptr(unsafe.Pointer(&req)))
+	}
+
+	// udev creates the device node shortly after the kernel device
+	device := fmt.Sprintf("/dev/rfcomm%d", id)
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(20 * time.Millisecond) {
+		if _, err := os.Stat(device); err == nil {
+			return device, release, nil
+		}
+		if time.Now().After(deadline) {
+			release()
+			return "", nil, fmt.Errorf("%s did not appear", device)
+		}
+	}
+}
+
+// boundRFCOMM returns the rfcomm device already bound to an address and
+// channel, or "" if there is none
+func boundRFCOMM(addr string, channel uint8) string {
+	dirs, _ := filepath.Glob(filepath.Join(sysClassTTY, "rfcomm*"))
+	for _, dir := range dirs {
+		if strings.EqualFold(readSysfs(dir, "address"), addr) && readSysfs(dir, "channel") == fmt.Sprint(channel) {
+			return "/dev/" + filepath.Base(dir)
+		}
+	}
+	return ""
+}
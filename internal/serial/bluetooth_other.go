@@ -0,0 +1,30 @@
+//go:build !linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// pairedBluetoothDevices is not available on this platform, where paired
+// devices get serial ports of their own
+func pairedBluetoothDevices() ([]BluetoothDevice, error) {
+	return nil, ErrBluetoothUnsupported
+}
+
+// bindRFCOMM is not available on this platform
+func bindRFCOMM(addr string, channel uint8) (string, func(), error) {
+	return "", nil, ErrBluetoothUnsupported
+}
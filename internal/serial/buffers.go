@@ -0,0 +1,61 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// BufferStatus reports how much data is waiting in a session's buffers
+type BufferStatus struct {
+	DriverInput     int    // Bytes received by the driver and not read by the agent
+	DriverOutput    int    // Bytes written to the driver and not transmitted
+	DriverReported  bool   // The driver reported its queue lengths
+	Buffered        int    // Received bytes waiting in the agent's buffer
+	BufferSize      int    // Capacity of the agent's buffer
+	Dropped         uint64 // Bytes discarded because the agent's buffer overflowed
+	WriteQueueDepth int    // Pending queued writes
+}
+
+// GetBufferStatus reports the driver's queue lengths, where the platform
+// exposes them, and the fill level of the agent's receive buffer. On an
+// attachment the attachment's own buffer is reported.
+func (m *Manager) GetBufferStatus(portName string, sessionID string) (BufferStatus, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return BufferStatus{}, err
+	}
+
+	buffer := session.buffer
+	if att := session.attachment(sessionID); att != nil && att.buffer != nil {
+		buffer = att.buffer
+	}
+
+	status := BufferStatus{
+		Buffered:        buffer.Len(),
+		BufferSize:      buffer.Cap(),
+		Dropped:         buffer.Dropped(),
+		WriteQueueDepth: m.QueueDepth(session.PortName),
+	}
+
+	if !session.IsDisconnected() {
+		in, out, err := driverQueues(session.PortName)
+		if err == nil {
+			status.DriverInput = in
+			status.DriverOutput = out
+			status.DriverReported = true
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math/bits"
+)
+
+// Checksum algorithms
+const (
+	ChecksumNone  = ""
+	ChecksumCRC16 = "crc16"
+	ChecksumCRC32 = "crc32"
+	ChecksumLRC   = "lrc"
+	ChecksumXOR   = "xor"
+)
+
+// ChecksumAlgorithms lists the supported checksum algorithms
+var ChecksumAlgorithms = []string{ChecksumCRC16, ChecksumCRC32, ChecksumLRC, ChecksumXOR}
+
+// Checksum placements
+const (
+	PlacementEnd              = "end"
+	PlacementBeforeTerminator = "before_terminator"
+)
+
+// Default CRC polynomials in normal (MSB-first) form
+const (
+	DefaultCRC16Polynomial = 0x8005     // CRC-16/MODBUS
+	DefaultCRC32Polynomial = 0x04C11DB7 // CRC-32/IEEE
+)
+
+// ChecksumConfig describes the checksum the agent appends to each write and
+// verifies on framed responses. CRCs use the reflected algorithm: CRC-16
+// starts from 0xFFFF as Modbus RTU does, CRC-32 is the IEEE variant.
+type ChecksumConfig struct {
+	Algorithm  string
+	Polynomial uint32 // CRC polynomial in normal form (0 = algorithm default)
+	Placement  string // "end" (default) or "before_terminator"
+	Terminator string // Trailer the checksum precedes with before_terminator
+	BigEndian  bool   // Send multi-byte checksums most significant byte first
+	Hex        bool   // Encode the checksum as uppercase ASCII hex digits
+}
+
+// Checksum computes, appends, and verifies a configured checksum
+type Checksum struct {
+	config ChecksumConfig
+	width  int // Checksum size in bytes before encoding
+	sum    func(data []byte) uint32
+}
+
+// NewChecksum creates a checksum from its configuration. An empty algorithm
+// returns a nil checksum, which leaves data unchanged.
+func NewChecksum(cfg ChecksumConfig) (*Checksum, error) {
+	c := &Checksum{config: cfg}
+
+	switch cfg.Algorithm {
+	case ChecksumNone:
+		return nil, nil
+	case ChecksumCRC16:
+		poly := cfg.Polynomial
+		if poly == 0 {
+			poly = DefaultCRC16Polynomial
+		}
+		if poly > 0xFFFF {
+			return nil, fmt.Errorf("CRC-16 polynomial out of range: %#x", poly)
+		}
+		table := makeCRC16Table(bits.Reverse16(uint16(poly)))
+		c.width = 2
+		c.sum = func(data []byte) uint32 {
+			crc := uint16(0xFFFF)
+			for _, b := range data {
+				crc = crc>>8 ^ table[byte(crc)^b]
+			}
+			return uint32(crc)
+		}
+	case ChecksumCRC32:
+		poly := cfg.Polynomial
+		if poly == 0 {
+			poly = DefaultCRC32Polynomial
+		}
+		table := crc32.MakeTable(bits.Reverse32(poly))
+		c.width = 4
+		c.sum = func(data []byte) uint32 {
+			return crc32.Checksum(data, table)
+		}
+	case ChecksumLRC:
+		c.width = 1
+		c.sum = func(data []byte) uint32 {
+			var sum byte
+			for _, b := range data {
+				sum += b
+			}
+			return uint32(-sum)
+		}
+	case ChecksumXOR:
+		c.width = 1
+		c.sum = func(data []byte) uint32 {
+			var sum byte
+			for _, b := range data {
+				sum ^= b
+			}
+			return uint32(sum)
+		}
+	default:
+		return nil, fmt.Errorf("unknown checksum algorithm: %s", cfg.Algorithm)
+	}
+
+	if cfg.Polynomial != 0 && cfg.Algorithm != ChecksumCRC16 && cfg.Algorithm != ChecksumCRC32 {
+		return nil, fmt.Errorf("polynomial only applies to CRC checksums")
+	}
+
+	switch cfg.Placement {
+	case "", PlacementEnd:
+	case PlacementBeforeTerminator:
+		if cfg.Terminator == "" {
+			return nil, fmt.Errorf("checksum placement %s requires a terminator", cfg.Placement)
+		}
+	default:
+		return nil, fmt.Errorf("unknown checksum placement: %s", cfg.Placement)
+	}
+
+	return c, nil
+}
+
+// makeCRC16Table builds the lookup table of a reflected CRC-16
+func makeCRC16Table(poly uint16) *[256]uint16 {
+	table := new([256]uint16)
+	for i := range table {
+		crc := uint16(i)
+		for range 8 {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ poly
+			} else {
+				crc >>= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
+
+// encode returns the checksum of data in its wire form
+func (c *Checksum) encode(data []byte) []byte {
+	var raw [4]byte
+	sum := c.sum(data)
+	if c.config.BigEndian {
+		binary.BigEndian.PutUint32(raw[:], sum)
+		copy(raw[:], raw[4-c.width:])
+	} else {
+		binary.LittleEndian.PutUint32(raw[:], sum)
+	}
+	value := raw[:c.width]
+
+	if c.config.Hex {
+		return []byte(fmt.Sprintf("%X", value))
+	}
+	return append([]byte(nil), value...)
+}
+
+// size returns the length of the checksum on the wire
+func (c *Checksum) size() int {
+	if c.config.Hex {
+		return c.width * 2
+	}
+	return c.width
+}
+
+// split separates a frame into the checksummed body and its trailer. With
+// before_terminator placement and data not ending in the terminator, the
+// whole frame is the body.
+func (c *Checksum) split(frame []byte) ([]byte, []byte, bool) {
+	if c.config.Placement != PlacementBeforeTerminator {
+		return frame, nil, true
+	}
+	term := []byte(c.config.Terminator)
+	if !bytes.HasSuffix(frame, term) {
+		return frame, nil, false
+	}
+	return frame[:len(frame)-len(term)], term, true
+}
+
+// Append returns data with its checksum added at the configured place
+func (c *Checksum) Append(data []byte) []byte {
+	if c == nil {
+		return data
+	}
+
+	body, trailer, ok := c.split(data)
+	if !ok {
+		// Terminate messages sent without their terminator
+		trailer = []byte(c.config.Terminator)
+	}
+
+	out := make([]byte, 0, len(body)+c.size()+len(trailer))
+	out = append(out, body...)
+	out = append(out, c.encode(body)...)
+	return append(out, trailer...)
+}
+
+// Verify checks the checksum of a complete frame and returns the frame with
+// the checksum removed. It reports false if the frame is too short, lacks
+// its terminator, or carries a wrong checksum.
+func (c *Checksum) Verify(frame []byte) ([]byte, bool) {
+	if c == nil {
+		return frame, true
+	}
+
+	body, trailer, ok := c.split(frame)
+	if !ok || len(body) < c.size() {
+		return frame, false
+	}
+
+	payload := body[:len(body)-c.size()]
+	got := body[len(payload):]
+	want := c.encode(payload)
+	if c.config.Hex {
+		if !bytes.EqualFold(got, want) {
+			return frame, false
+		}
+	} else if !bytes.Equal(got, want) {
+		return frame, false
+	}
+
+	out := make([]byte, 0, len(payload)+len(trailer))
+	out = append(out, payload...)
+	return append(out, trailer...), true
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import "fmt"
+
+// Services that claim ports
+const (
+	ClaimantGetty        = "getty"
+	ClaimantModemManager = "ModemManager"
+)
+
+// PortClaimant is a system service that grabs a port on its own: a login
+// prompt answering everything received, or ModemManager probing the port
+// for a modem with AT commands
+type PortClaimant struct {
+	Name string // ClaimantGetty or ClaimantModemManager
+	PID  int
+}
+
+// String formats the claimant as "name (pid N)"
+func (c PortClaimant) String() string {
+	return fmt.Sprintf("%s (pid %d)", c.Name, c.PID)
+}
+
+// PortClaimants returns the services claiming each of the ports, keyed by
+// port name. Platforms without such services return no claimants.
+func PortClaimants(portNames ...string) map[string][]PortClaimant {
+	return findPortClaimants(portNames)
+}
+
+// addClaimants records the services claiming each port
+func addClaimants(ports []PortInfo) {
+	names := make([]string, len(ports))
+	for i, p := range ports {
+		names[i] = p.Name
+	}
+	claimants := findPortClaimants(names)
+	for i := range ports {
+		for _, c := range claimants[ports[i].Name] {
+			ports[i].Claimants = append(ports[i].Claimants, c.Name)
+		}
+	}
+}
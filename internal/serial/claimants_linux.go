@@ -0,0 +1,104 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// gettyNames are the login programs systemd and inittab start on serial
+// lines
+var gettyNames = []string{"agetty", "getty", "mgetty", "uugetty"}
+
+// findPortClaimants looks for login prompts started on the ports and for
+// a running ModemManager that probes them. Process names and command lines
+// are readable by every user, so no privileges are needed.
+func findPortClaimants(portNames []string) map[string][]PortClaimant {
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+
+	type getty struct {
+		pid  int
+		args []string
+	}
+	var gettys []getty
+	modemManager := 0
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		switch name := processName(pid); {
+		case name == ClaimantModemManager:
+			modemManager = pid
+		case slices.Contains(gettyNames, name):
+			cmdline, err := os.ReadFile(filepath.Join("/proc", proc.Name(), "cmdline"))
+			if err == nil {
+				gettys = append(gettys, getty{pid: pid, args: strings.Split(string(cmdline), "\x00")})
+			}
+		}
+	}
+	if len(gettys) == 0 && modemManager == 0 {
+		return nil
+	}
+
+	claimants := make(map[string][]PortClaimant)
+	for _, name := range portNames {
+		device := name
+		if resolved, err := filepath.EvalSymlinks(name); err == nil {
+			device = resolved
+		}
+		tty := filepath.Base(device)
+
+		for _, g := range gettys {
+			if slices.Contains(g.args, tty) || slices.Contains(g.args, device) {
+				claimants[name] = append(claimants[name], PortClaimant{Name: ClaimantGetty, PID: g.pid})
+			}
+		}
+		if modemManager != 0 && modemManagerProbes(tty) {
+			claimants[name] = append(claimants[name], PortClaimant{Name: ClaimantModemManager, PID: modemManager})
+		}
+	}
+	return claimants
+}
+
+// modemManagerProbes reports whether ModemManager probes a tty. Its udev
+// rules mark the ttys it considers with ID_MM_CANDIDATE, and devices opt
+// out with ID_MM_DEVICE_IGNORE or ID_MM_PORT_IGNORE. Without a udev
+// database, e.g. in a container, USB and ACM ports are assumed to be
+// probed.
+func modemManagerProbes(tty string) bool {
+	var props map[string]string
+	if devNum := readSysfs(filepath.Join(sysClassTTY, tty), "dev"); devNum != "" {
+		props = readUdevProperties(filepath.Join(udevDataDir, "c"+devNum))
+	}
+	if props == nil {
+		return strings.HasPrefix(tty, "ttyUSB") || strings.HasPrefix(tty, "ttyACM")
+	}
+	if props["ID_MM_DEVICE_IGNORE"] == "1" || props["ID_MM_PORT_IGNORE"] == "1" {
+		return false
+	}
+	return props["ID_MM_CANDIDATE"] == "1"
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of session event
+type EventType int
+
+const (
+	EventUnknown EventType = iota
+	EventWriteComplete
+	EventSessionTerminated
+	EventSessionSuspended
+	EventSessionResumed
+	EventRuleMatched
+	EventJobCompleted
+	EventSessionExpired
+	EventAgentShutdown
+	EventQueuePosition
+)
+
+// String returns the string representation of EventType
+func (t EventType) String() string {
+	switch t {
+	case EventWriteComplete:
+		return "write-complete"
+	case EventSessionTerminated:
+		return "session-terminated"
+	case EventSessionSuspended:
+		return "session-suspended"
+	case EventSessionResumed:
+		return "session-resumed"
+	case EventRuleMatched:
+		return "rule-matched"
+	case EventJobCompleted:
+		return "job-completed"
+	case EventSessionExpired:
+		return "session-expired"
+	case EventAgentShutdown:
+		return "agent-shutdown"
+	case EventQueuePosition:
+		return "queue-position"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a notification about something that happened on a session
+type Event struct {
+	Type          EventType
+	PortName      string
+	SessionID     string
+	ClientID      string // Owner of a closed session, or client waiting in a port's queue
+	TicketID      string
+	CorrelationID string
+	BytesWritten  int
+	Drained       bool
+	Message       string
+	Rule          string // Name of the matching rule
+	Job           string // Name of the completed job
+	QueuePosition int    // Place in the port's queue, 1 = next; 0 once granted
+	Data          []byte // Data matched by the rule, or the job's response
+	Timestamp     time.Time
+}
+
+// EventBus fans out session events to any number of subscribers
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewEventBus creates a new event bus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. The returned function removes the
+// subscription and closes the channel.
+func (b *EventBus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 100)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+// Publish delivers an event to all subscribers without blocking
+func (b *EventBus) Publish(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is not keeping up, drop the event
+		}
+	}
+}
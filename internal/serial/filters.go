@@ -0,0 +1,96 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PortFilter selects ports by USB identity, type, name, or description.
+// All set criteria must match; a filter with none matches every port.
+type PortFilter struct {
+	VID         string
+	PID         string
+	Type        *PortType
+	Name        *regexp.Regexp
+	Description *regexp.Regexp
+}
+
+// Matches reports whether a port satisfies every criterion of the filter
+func (f PortFilter) Matches(p PortInfo) bool {
+	if f.VID != "" && !strings.EqualFold(f.VID, p.VID) {
+		return false
+	}
+	if f.PID != "" && !strings.EqualFold(f.PID, p.PID) {
+		return false
+	}
+	if f.Type != nil && *f.Type != p.PortType {
+		return false
+	}
+	if f.Name != nil && !f.Name.MatchString(p.Name) {
+		return false
+	}
+	if f.Description != nil && !f.Description.MatchString(p.Description) {
+		return false
+	}
+	return true
+}
+
+// ParsePortType parses a port type name such as "usb" or "bluetooth"
+func ParsePortType(name string) (PortType, error) {
+	for _, t := range []PortType{PortTypeUnknown, PortTypeUSB, PortTypeNative, PortTypeBluetooth, PortTypeVirtual, PortTypeNetwork} {
+		if strings.EqualFold(name, t.String()) {
+			return t, nil
+		}
+	}
+	return PortTypeUnknown, fmt.Errorf("unknown port type: %s", name)
+}
+
+// SetFilters replaces the scanner's structured filters. When include is
+// non-empty only ports matching one of its filters are listed. Ports
+// matching any exclude filter are hidden.
+func (s *Scanner) SetFilters(include, exclude []PortFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.include = include
+	s.exclude = exclude
+}
+
+// isFiltered reports whether the structured filters hide a port
+func (s *Scanner) isFiltered(p PortInfo) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, f := range s.exclude {
+		if f.Matches(p) {
+			return true
+		}
+	}
+
+	if len(s.include) == 0 {
+		return false
+	}
+	for _, f := range s.include {
+		if f.Matches(p) {
+			return false
+		}
+	}
+	return true
+}
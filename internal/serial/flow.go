@@ -0,0 +1,113 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Default software flow control characters
+const (
+	DefaultXonChar  byte = 0x11 // DC1
+	DefaultXoffChar byte = 0x13 // DC3
+)
+
+// ErrFlowControlUnsupported is returned when flow control is requested on a
+// platform where the agent cannot configure it
+var ErrFlowControlUnsupported = errors.New("flow control is not supported on this platform")
+
+// FlowStatus reports the modem lines that govern flow control
+type FlowStatus struct {
+	CTS    bool // Clear To Send is asserted
+	DSR    bool // Data Set Ready is asserted
+	DCD    bool // Data Carrier Detect is asserted
+	Paused bool // Output is held because hardware flow control sees CTS deasserted
+}
+
+// flowChars returns the XON/XOFF characters, applying the defaults
+func (c PortConfig) flowChars() (byte, byte) {
+	xon, xoff := byte(c.XonChar), byte(c.XoffChar)
+	if xon == 0 {
+		xon = DefaultXonChar
+	}
+	if xoff == 0 {
+		xoff = DefaultXoffChar
+	}
+	return xon, xoff
+}
+
+// validateFlow checks the flow control settings
+func (c PortConfig) validateFlow() error {
+	if c.XonChar < 0 || c.XonChar > 0xFF || c.XoffChar < 0 || c.XoffChar > 0xFF {
+		return fmt.Errorf("XON and XOFF characters must be between 0 and 255")
+	}
+	if c.FlowControl != FlowControlSoftware {
+		return nil
+	}
+	xon, xoff := c.flowChars()
+	if xon == xoff {
+		return fmt.Errorf("XON and XOFF characters must differ")
+	}
+	return nil
+}
+
+// flowChanged reports whether the flow control settings differ
+func (c PortConfig) flowChanged(other PortConfig) bool {
+	if c.FlowControl != other.FlowControl {
+		return true
+	}
+	if c.FlowControl != FlowControlSoftware {
+		return false
+	}
+	xon, xoff := c.flowChars()
+	otherXon, otherXoff := other.flowChars()
+	return xon != otherXon || xoff != otherXoff
+}
+
+// applyFlowControl configures the port's flow control. Ports opened by the
+// serial library start with flow control disabled, so nothing is done when
+// none is requested.
+func applyFlowControl(portName string, config PortConfig, reset bool) error {
+	if config.FlowControl == FlowControlNone && !reset {
+		return nil
+	}
+	xon, xoff := config.flowChars()
+	return setFlowControl(portName, config.FlowControl, xon, xoff)
+}
+
+// FlowStatus reads the modem lines of the session's port. It returns nil
+// when the port is disconnected or the device does not report modem lines.
+func (s *Session) FlowStatus() *FlowStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.port == nil {
+		return nil
+	}
+	bits, err := s.port.GetModemStatusBits()
+	if err != nil {
+		return nil
+	}
+
+	return &FlowStatus{
+		CTS:    bits.CTS,
+		DSR:    bits.DSR,
+		DCD:    bits.DCD,
+		Paused: s.Config.FlowControl == FlowControlHardware && !bits.CTS,
+	}
+}
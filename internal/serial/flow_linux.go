@@ -0,0 +1,48 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import "golang.org/x/sys/unix"
+
+// setFlowControl sets the terminal's flow control flags and XON/XOFF
+// characters. Like RS-485 mode, the settings belong to the tty rather than
+// the descriptor, so the device is opened separately.
+func setFlowControl(portName string, flow FlowControl, xon, xoff byte) error {
+	fd, err := unix.Open(portName, unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	t, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return err
+	}
+
+	t.Cflag &^= unix.CRTSCTS
+	t.Iflag &^= unix.IXON | unix.IXOFF | unix.IXANY
+	switch flow {
+	case FlowControlHardware:
+		t.Cflag |= unix.CRTSCTS
+	case FlowControlSoftware:
+		t.Iflag |= unix.IXON | unix.IXOFF
+		t.Cc[unix.VSTART] = xon
+		t.Cc[unix.VSTOP] = xoff
+	}
+
+	return unix.IoctlSetTermios(fd, unix.TCSETS, t)
+}
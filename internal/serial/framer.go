@@ -0,0 +1,167 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Built-in framer names
+const (
+	FramerNone = ""
+	FramerLine = "line"
+	FramerNMEA = "nmea"
+)
+
+// Framers lists the built-in framers
+var Framers = []string{FramerLine, FramerNMEA}
+
+// maxFrameSize bounds a frame before it is discarded as a framing error
+const maxFrameSize = 4096
+
+// FrameStatus describes the outcome of parsing a frame
+type FrameStatus int
+
+const (
+	FrameOK FrameStatus = iota
+	FrameFramingError
+	FrameChecksumError
+)
+
+// Frame is a single frame extracted from the received byte stream
+type Frame struct {
+	Data   []byte
+	Status FrameStatus
+}
+
+// Framer splits a received byte stream into protocol frames
+type Framer interface {
+	// Feed consumes received bytes and returns any frames completed by them
+	Feed(data []byte) []Frame
+	// Reset discards any partially received frame
+	Reset()
+}
+
+// NewFramer creates a framer by name. An empty name returns a nil framer.
+func NewFramer(name string) (Framer, error) {
+	switch name {
+	case FramerNone:
+		return nil, nil
+	case FramerLine:
+		return &lineFramer{}, nil
+	case FramerNMEA:
+		return &nmeaFramer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown framer: %s", name)
+	}
+}
+
+// lineFramer treats each newline-terminated line as a frame
+type lineFramer struct {
+	buf      []byte
+	overflow bool
+}
+
+func (f *lineFramer) Feed(data []byte) []Frame {
+	var frames []Frame
+
+	for _, b := range data {
+		if b == '\n' {
+			if f.overflow {
+				frames = append(frames, Frame{Status: FrameFramingError})
+			} else {
+				line := bytes.TrimSuffix(f.buf, []byte{'\r'})
+				frames = append(frames, Frame{Data: append([]byte(nil), line...), Status: FrameOK})
+			}
+			f.buf = f.buf[:0]
+			f.overflow = false
+			continue
+		}
+
+		if len(f.buf) >= maxFrameSize {
+			f.overflow = true
+			continue
+		}
+		f.buf = append(f.buf, b)
+	}
+
+	return frames
+}
+
+func (f *lineFramer) Reset() {
+	f.buf = f.buf[:0]
+	f.overflow = false
+}
+
+// nmeaFramer parses NMEA 0183 sentences ($...*hh) and verifies checksums
+type nmeaFramer struct {
+	line lineFramer
+}
+
+func (f *nmeaFramer) Feed(data []byte) []Frame {
+	var frames []Frame
+
+	for _, frame := range f.line.Feed(data) {
+		if frame.Status == FrameOK {
+			if len(frame.Data) == 0 {
+				// Blank lines are not sentences, skip them silently
+				continue
+			}
+			frame.Status = checkNMEA(frame.Data)
+		}
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
+
+func (f *nmeaFramer) Reset() {
+	f.line.Reset()
+}
+
+// checkNMEA validates the structure and checksum of an NMEA sentence
+func checkNMEA(sentence []byte) FrameStatus {
+	if sentence[0] != '$' && sentence[0] != '!' {
+		return FrameFramingError
+	}
+
+	star := bytes.LastIndexByte(sentence, '*')
+	if star < 0 {
+		// The checksum is optional for some sentences
+		return FrameOK
+	}
+	if len(sentence)-star-1 != 2 {
+		return FrameFramingError
+	}
+
+	want, err := hex.DecodeString(string(sentence[star+1:]))
+	if err != nil {
+		return FrameFramingError
+	}
+
+	var sum byte
+	for _, b := range sentence[1:star] {
+		sum ^= b
+	}
+
+	if sum != want[0] {
+		return FrameChecksumError
+	}
+	return FrameOK
+}
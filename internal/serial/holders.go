@@ -0,0 +1,102 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"go.bug.st/serial"
+)
+
+// PortHolder is a process that has a port's device open
+type PortHolder struct {
+	PID  int
+	Name string // Executable or command name, empty when it cannot be read
+}
+
+// String formats the holder as "name (pid N)"
+func (h PortHolder) String() string {
+	if h.Name == "" {
+		return fmt.Sprintf("pid %d", h.PID)
+	}
+	return fmt.Sprintf("%s (pid %d)", h.Name, h.PID)
+}
+
+// PortHeldError is an open failure caused by other processes holding the
+// port. It wraps the original error.
+type PortHeldError struct {
+	Err     error
+	Holders []PortHolder
+}
+
+// Error lists the holding processes after the original error
+func (e *PortHeldError) Error() string {
+	names := make([]string, len(e.Holders))
+	for i, h := range e.Holders {
+		names[i] = h.String()
+	}
+	return fmt.Sprintf("%v; held by %s", e.Err, strings.Join(names, ", "))
+}
+
+// Unwrap returns the original error
+func (e *PortHeldError) Unwrap() error {
+	return e.Err
+}
+
+// PortHolders returns the processes other than the agent that have the
+// port's device open
+func PortHolders(portName string) ([]PortHolder, error) {
+	holders, err := findPortHolders(portName)
+	if err != nil {
+		return nil, err
+	}
+	self := os.Getpid()
+	others := holders[:0]
+	for _, h := range holders {
+		if h.PID != self {
+			others = append(others, h)
+		}
+	}
+	return others, nil
+}
+
+// withHolders adds the processes holding the port to an open failure caused
+// by the port being busy. Other errors, and busy ports whose holders cannot
+// be found, are returned unchanged.
+func withHolders(portName string, err error) error {
+	if !isBusyError(err) {
+		return err
+	}
+	holders, lookupErr := PortHolders(portName)
+	if lookupErr != nil || len(holders) == 0 {
+		return err
+	}
+	return &PortHeldError{Err: err, Holders: holders}
+}
+
+// isBusyError reports whether an open failed because the port is in use
+func isBusyError(err error) bool {
+	var portErr *serial.PortError
+	if errors.As(err, &portErr) {
+		return portErr.Code() == serial.PortBusy
+	}
+	return errors.Is(err, ErrSystemLocked) || errors.Is(err, syscall.EBUSY)
+}
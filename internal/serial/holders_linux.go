@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// findPortHolders scans the open file descriptors of every process in
+// /proc, as lsof does. Processes the agent may not inspect are skipped.
+func findPortHolders(portName string) ([]PortHolder, error) {
+	device := portName
+	if resolved, err := filepath.EvalSymlinks(portName); err == nil {
+		device = resolved
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []PortHolder
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", proc.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			if target, err := os.Readlink(filepath.Join(fdDir, fd.Name())); err == nil && target == device {
+				holders = append(holders, PortHolder{PID: pid, Name: processName(pid)})
+				break
+			}
+		}
+	}
+	return holders, nil
+}
+
+// processName returns the command name of a process
+func processName(pid int) string {
+	comm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,76 @@
+//go:build !linux && !windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// lsofTimeout bounds the lsof run, which can stall on network mounts
+const lsofTimeout = 5 * time.Second
+
+// findPortHolders asks lsof for the processes with the device open. It
+// returns no holders when lsof is not installed.
+func findPortHolders(portName string) ([]PortHolder, error) {
+	device := portName
+	if resolved, err := filepath.EvalSymlinks(portName); err == nil {
+		device = resolved
+	}
+
+	path, err := exec.LookPath("lsof")
+	if err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lsofTimeout)
+	defer cancel()
+
+	// lsof exits with status 1 when no process has the file open
+	out, err := exec.CommandContext(ctx, path, "-w", "-F", "pc", "--", device).Output()
+	if err != nil && len(out) == 0 {
+		return nil, nil
+	}
+
+	// Each process is a "p<pid>" line followed by a "c<command>" line
+	var holders []PortHolder
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			if pid, err := strconv.Atoi(line[1:]); err == nil {
+				holders = append(holders, PortHolder{PID: pid})
+			}
+		case 'c':
+			if len(holders) > 0 {
+				holders[len(holders)-1].Name = line[1:]
+			}
+		}
+	}
+	return holders, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	ntdll             = windows.NewLazySystemDLL("ntdll.dll")
+	procNtQueryObject = ntdll.NewProc("NtQueryObject")
+)
+
+// objectNameInformation is the NtQueryObject class returning an object's
+// name
+const objectNameInformation = 1
+
+// systemHandleEntry is a SYSTEM_HANDLE_TABLE_ENTRY_INFO_EX
+type systemHandleEntry struct {
+	Object                uintptr
+	UniqueProcessID       uintptr
+	HandleValue           uintptr
+	GrantedAccess         uint32
+	CreatorBackTraceIndex uint16
+	ObjectTypeIndex       uint16
+	HandleAttributes      uint32
+	Reserved              uint32
+}
+
+// findPortHolders walks the system handle table for character device
+// handles naming the port's kernel device, such as \Device\Serial0.
+// Handles are duplicated into the agent to query their names, so only
+// processes the agent may open are found.
+func findPortHolders(portName string) ([]PortHolder, error) {
+	device, err := dosDeviceTarget(devicePath(portName))
+	if err != nil {
+		return nil, err
+	}
+
+	handles, err := systemHandles()
+	if err != nil {
+		return nil, err
+	}
+
+	self := windows.CurrentProcess()
+	selfPID := uintptr(windows.GetCurrentProcessId())
+	processes := make(map[uintptr]windows.Handle)
+	defer func() {
+		for _, p := range processes {
+			if p != 0 {
+				windows.CloseHandle(p)
+			}
+		}
+	}()
+
+	var holders []PortHolder
+	found := make(map[uintptr]bool)
+	for _, h := range handles {
+		pid := h.UniqueProcessID
+		if pid == selfPID || found[pid] {
+			continue
+		}
+		process, ok := processes[pid]
+		if !ok {
+			process, _ = windows.OpenProcess(windows.PROCESS_DUP_HANDLE|windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+			processes[pid] = process
+		}
+		if process == 0 {
+			continue
+		}
+
+		var dup windows.Handle
+		if err := windows.DuplicateHandle(process, windows.Handle(h.HandleValue), self, &dup, 0, false, windows.DUPLICATE_SAME_ACCESS); err != nil {
+			continue
+		}
+		// Only character devices are named; querying pipes can block
+		if t, _ := windows.GetFileType(dup); t == windows.FILE_TYPE_CHAR && strings.EqualFold(objectName(dup), device) {
+			found[pid] = true
+			holders = append(holders, PortHolder{PID: int(pid), Name: imageName(process)})
+		}
+		windows.CloseHandle(dup)
+	}
+	return holders, nil
+}
+
+// dosDeviceTarget returns the kernel device a DOS device name such as COM3
+// links to
+func dosDeviceTarget(name string) (string, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]uint16, windows.MAX_PATH)
+	n, err := windows.QueryDosDevice(namePtr, &buf[0], uint32(len(buf)))
+	if err != nil {
+		return "", err
+	}
+	// The result is a list of NUL-terminated strings; the first is current
+	return windows.UTF16ToString(buf[:n]), nil
+}
+
+// systemHandles returns every open handle in the system
+func systemHandles() ([]systemHandleEntry, error) {
+	size := uint32(1 << 20)
+	for {
+		buf := make([]byte, size)
+		var needed uint32
+		err := windows.NtQuerySystemInformation(windows.SystemExtendedHandleInformation, unsafe.Pointer(&buf[0]), size, &needed)
+		if errors.Is(err, windows.STATUS_INFO_LENGTH_MISMATCH) {
+			size = max(needed, size) * 2
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// SYSTEM_HANDLE_INFORMATION_EX: a count, a reserved word, then entries
+		count := *(*uintptr)(unsafe.Pointer(&buf[0]))
+		first := unsafe.Pointer(&buf[2*unsafe.Sizeof(uintptr(0))])
+		return append([]systemHandleEntry(nil), unsafe.Slice((*systemHandleEntry)(first), count)...), nil
+	}
+}
+
+// objectName returns the kernel object name of a handle
+func objectName(handle windows.Handle) string {
+	buf := make([]byte, 1024)
+	var needed uint32
+	status, _, _ := procNtQueryObject.Call(uintptr(handle), objectNameInformation,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), uintptr(unsafe.Pointer(&needed)))
+	if status != 0 {
+		return ""
+	}
+	return (*windows.NTUnicodeString)(unsafe.Pointer(&buf[0])).String()
+}
+
+// imageName returns the executable name of a process
+func imageName(process windows.Handle) string {
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+	if err := windows.QueryFullProcessImageName(process, 0, &buf[0], &size); err != nil {
+		return ""
+	}
+	return filepath.Base(windows.UTF16ToString(buf[:size]))
+}
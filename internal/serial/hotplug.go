@@ -0,0 +1,70 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"time"
+)
+
+// hotplugSettle is how long the watcher waits after a device notification
+// before rescanning, so bursts of events from one device cause a single scan
+const hotplugSettle = 250 * time.Millisecond
+
+// errHotplugUnsupported is returned when the platform has no device
+// notification mechanism and the scanner must poll
+var errHotplugUnsupported = errors.New("hot-plug notifications not supported on this platform")
+
+// hotplugMonitor delivers a notification whenever a serial device is added
+// to or removed from the system
+type hotplugMonitor interface {
+	// Events returns the channel notifications are delivered on. It is
+	// closed when the monitor stops.
+	Events() <-chan struct{}
+	Close() error
+}
+
+// notify signals a device change without blocking; a pending notification
+// already covers the new one
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// SetDeviceDir makes WatchPorts watch dir for device nodes being created
+// and removed instead of listening for the platform's device notifications,
+// for containers that see the host's /dev but not its kernel events. It is
+// supported on Linux and macOS and must be called before WatchPorts.
+func (s *Scanner) SetDeviceDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deviceDir = dir
+}
+
+// newMonitor opens the hot-plug monitor WatchPorts uses
+func (s *Scanner) newMonitor() (hotplugMonitor, error) {
+	s.mu.RLock()
+	dir := s.deviceDir
+	s.mu.RUnlock()
+
+	if dir != "" {
+		return newDevMonitor(dir)
+	}
+	return newHotplugMonitor()
+}
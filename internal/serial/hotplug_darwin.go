@@ -0,0 +1,157 @@
+//go:build darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// kqueueMonitor watches a device directory with kqueue. The directory
+// reports a write whenever a node is created or removed in it; the monitor
+// then lists it and notifies only when the serial device nodes changed, so
+// the rest of /dev's churn is ignored.
+type kqueueMonitor struct {
+	dir    string
+	dirfd  int
+	kq     int
+	wake   [2]int // Pipe written to by Close to stop run
+	events chan struct{}
+	once   sync.Once
+}
+
+// newHotplugMonitor watches /dev, where the kernel creates the tty.* and
+// cu.* nodes of serial devices. kqueue needs no cgo, unlike IOKit
+// notifications.
+func newHotplugMonitor() (hotplugMonitor, error) {
+	return newDevMonitor("/dev")
+}
+
+// newDevMonitor watches dir with kqueue for serial device nodes being
+// created or removed
+func newDevMonitor(dir string) (hotplugMonitor, error) {
+	dirfd, err := unix.Open(dir, unix.O_RDONLY|unix.O_EVTONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+
+	kq, err := unix.Kqueue()
+	if err != nil {
+		unix.Close(dirfd)
+		return nil, err
+	}
+	unix.CloseOnExec(kq)
+
+	m := &kqueueMonitor{
+		dir:    dir,
+		dirfd:  dirfd,
+		kq:     kq,
+		events: make(chan struct{}, 1),
+	}
+	if err := unix.Pipe(m.wake[:]); err != nil {
+		unix.Close(kq)
+		unix.Close(dirfd)
+		return nil, err
+	}
+	unix.CloseOnExec(m.wake[0])
+	unix.CloseOnExec(m.wake[1])
+
+	changes := make([]unix.Kevent_t, 2)
+	unix.SetKevent(&changes[0], dirfd, unix.EVFILT_VNODE, unix.EV_ADD|unix.EV_CLEAR)
+	changes[0].Fflags = unix.NOTE_WRITE
+	unix.SetKevent(&changes[1], m.wake[0], unix.EVFILT_READ, unix.EV_ADD)
+	if _, err := unix.Kevent(kq, changes, nil, nil); err != nil {
+		m.closeFds()
+		return nil, err
+	}
+
+	go m.run()
+	return m, nil
+}
+
+// Events returns the notification channel
+func (m *kqueueMonitor) Events() <-chan struct{} {
+	return m.events
+}
+
+// Close stops the monitor
+func (m *kqueueMonitor) Close() error {
+	var err error
+	m.once.Do(func() {
+		_, err = unix.Write(m.wake[1], []byte{0})
+	})
+	return err
+}
+
+// run waits for the directory to change until Close wakes it
+func (m *kqueueMonitor) run() {
+	defer close(m.events)
+	defer m.closeFds()
+
+	nodes := serialNodes(m.dir)
+	events := make([]unix.Kevent_t, 4)
+	for {
+		n, err := unix.Kevent(m.kq, nil, events, nil)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return
+		}
+		for _, ev := range events[:n] {
+			if int(ev.Ident) == m.wake[0] {
+				return
+			}
+		}
+
+		current := serialNodes(m.dir)
+		if !slices.Equal(current, nodes) {
+			nodes = current
+			notify(m.events)
+		}
+	}
+}
+
+// closeFds closes the monitor's descriptors
+func (m *kqueueMonitor) closeFds() {
+	unix.Close(m.kq)
+	unix.Close(m.dirfd)
+	unix.Close(m.wake[0])
+	unix.Close(m.wake[1])
+}
+
+// serialNodes lists the serial device nodes in dir, sorted by name
+func serialNodes(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var nodes []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "cu.") || strings.HasPrefix(name, "tty.") {
+			nodes = append(nodes, name)
+		}
+	}
+	return nodes
+}
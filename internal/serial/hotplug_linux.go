@@ -0,0 +1,165 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// fileMonitor reads device notifications from a netlink or inotify
+// descriptor and delivers those match accepts
+type fileMonitor struct {
+	file   *os.File
+	match  func(buf []byte) bool
+	events chan struct{}
+	once   sync.Once
+}
+
+// newFileMonitor starts a monitor reading fd
+func newFileMonitor(fd int, name string, match func(buf []byte) bool) *fileMonitor {
+	m := &fileMonitor{
+		file:   os.NewFile(uintptr(fd), name),
+		match:  match,
+		events: make(chan struct{}, 1),
+	}
+	go m.run()
+	return m
+}
+
+// newHotplugMonitor opens a netlink socket subscribed to kernel uevents.
+// Kernel events are used rather than udev's so the monitor works without
+// udevd.
+func newHotplugMonitor() (hotplugMonitor, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC|unix.SOCK_NONBLOCK, unix.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group 1 carries events broadcast by the kernel
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: 1}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	return newFileMonitor(fd, "uevent", isSerialUevent), nil
+}
+
+// newDevMonitor watches dir with inotify for serial device nodes being
+// created or removed. Containers with their own network namespace receive
+// no kernel uevents, but one that mounts the host's /dev sees its nodes
+// appear and disappear.
+func newDevMonitor(dir string) (hotplugMonitor, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	mask := uint32(unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO)
+	if _, err := unix.InotifyAddWatch(fd, dir, mask); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	return newFileMonitor(fd, "inotify", hasSerialNode), nil
+}
+
+// Events returns the notification channel
+func (m *fileMonitor) Events() <-chan struct{} {
+	return m.events
+}
+
+// Close stops the monitor
+func (m *fileMonitor) Close() error {
+	var err error
+	m.once.Do(func() {
+		err = m.file.Close()
+	})
+	return err
+}
+
+// run reads notifications until the descriptor is closed
+func (m *fileMonitor) run() {
+	defer close(m.events)
+
+	buf := make([]byte, 8192)
+	for {
+		n, err := m.file.Read(buf)
+		if err != nil {
+			return
+		}
+		if m.match(buf[:n]) {
+			notify(m.events)
+		}
+	}
+}
+
+// isSerialUevent reports whether a uevent announces a tty device being added
+// or removed. Messages are a header followed by NUL-separated KEY=value pairs.
+func isSerialUevent(msg []byte) bool {
+	var action, subsystem, devname []byte
+	for _, field := range bytes.Split(msg, []byte{0}) {
+		key, value, ok := bytes.Cut(field, []byte("="))
+		if !ok {
+			continue
+		}
+		switch string(key) {
+		case "ACTION":
+			action = value
+		case "SUBSYSTEM":
+			subsystem = value
+		case "DEVNAME":
+			devname = value
+		}
+	}
+
+	if string(subsystem) != "tty" || len(devname) == 0 {
+		return false
+	}
+	return string(action) == "add" || string(action) == "remove"
+}
+
+// hasSerialNode reports whether a buffer of inotify events names a serial
+// device node such as ttyUSB0, ttyACM0, or rfcomm0. Virtual consoles (tty,
+// tty1, ...) are ignored.
+func hasSerialNode(buf []byte) bool {
+	for len(buf) >= unix.SizeofInotifyEvent {
+		// The name's length is the header's last field
+		nameLen := int(binary.NativeEndian.Uint32(buf[unix.SizeofInotifyEvent-4:]))
+		end := unix.SizeofInotifyEvent + nameLen
+		if end > len(buf) {
+			return false
+		}
+		name := string(bytes.TrimRight(buf[unix.SizeofInotifyEvent:end], "\x00"))
+		buf = buf[end:]
+
+		switch {
+		case strings.HasPrefix(name, "rfcomm"):
+			return true
+		case strings.HasPrefix(name, "tty") && len(name) > 3 && (name[3] < '0' || name[3] > '9'):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+//go:build !linux && !windows && !darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// newHotplugMonitor is not implemented on this platform; the scanner polls
+// instead
+func newHotplugMonitor() (hotplugMonitor, error) {
+	return nil, errHotplugUnsupported
+}
+
+// newDevMonitor is only implemented on Linux and macOS
+func newDevMonitor(dir string) (hotplugMonitor, error) {
+	return nil, errHotplugUnsupported
+}
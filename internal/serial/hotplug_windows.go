@@ -0,0 +1,262 @@
+//go:build windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wmDeviceChange               = 0x0219
+	wmQuit                       = 0x0012
+	dbtDeviceArrival             = 0x8000
+	dbtDeviceRemoveComplete      = 0x8004
+	dbtDevtypDeviceInterface     = 0x00000005
+	deviceNotifyWindowHandle     = 0x00000000
+	errorClassAlreadyExists      = 1410
+	hwndMessage                  = ^uintptr(2) // HWND_MESSAGE (-3)
+	hotplugWindowClass           = "BaudLinkHotplug"
+	guidDevinterfaceComportValue = "{86E0D1E0-8089-11D0-9CE4-08003E301F73}"
+)
+
+var (
+	user32                           = windows.NewLazySystemDLL("user32.dll")
+	procRegisterClassExW             = user32.NewProc("RegisterClassExW")
+	procCreateWindowExW              = user32.NewProc("CreateWindowExW")
+	procDestroyWindow                = user32.NewProc("DestroyWindow")
+	procDefWindowProcW               = user32.NewProc("DefWindowProcW")
+	procGetMessageW                  = user32.NewProc("GetMessageW")
+	procDispatchMessageW             = user32.NewProc("DispatchMessageW")
+	procPostThreadMessageW           = user32.NewProc("PostThreadMessageW")
+	procRegisterDeviceNotificationW  = user32.NewProc("RegisterDeviceNotificationW")
+	procUnregisterDeviceNotification = user32.NewProc("UnregisterDeviceNotification")
+)
+
+// wndClassEx mirrors WNDCLASSEXW
+type wndClassEx struct {
+	Size       uint32
+	Style      uint32
+	WndProc    uintptr
+	ClsExtra   int32
+	WndExtra   int32
+	Instance   windows.Handle
+	Icon       windows.Handle
+	Cursor     windows.Handle
+	Background windows.Handle
+	MenuName   *uint16
+	ClassName  *uint16
+	IconSm     windows.Handle
+}
+
+// devBroadcastDeviceInterface mirrors DEV_BROADCAST_DEVICEINTERFACE_W
+type devBroadcastDeviceInterface struct {
+	Size       uint32
+	DeviceType uint32
+	Reserved   uint32
+	ClassGUID  windows.GUID
+	Name       [1]uint16
+}
+
+// winMsg mirrors MSG
+type winMsg struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	PtX     int32
+	PtY     int32
+	Private uint32
+}
+
+var (
+	// Windows limits the number of callbacks a process can create, so the
+	// window procedure is shared by all monitors
+	wndProcOnce     sync.Once
+	wndProcCallback uintptr
+
+	hotplugWindowsMu sync.Mutex
+	hotplugWindows   = make(map[uintptr]*deviceChangeMonitor)
+)
+
+// deviceChangeMonitor receives WM_DEVICECHANGE messages for COM port device
+// interfaces on a hidden message-only window
+type deviceChangeMonitor struct {
+	events   chan struct{}
+	threadID uint32
+	once     sync.Once
+}
+
+// newHotplugMonitor creates a message-only window registered for COM port
+// arrival and removal notifications
+func newHotplugMonitor() (hotplugMonitor, error) {
+	m := &deviceChangeMonitor{
+		events: make(chan struct{}, 1),
+	}
+
+	ready := make(chan error, 1)
+	go m.run(ready)
+
+	if err := <-ready; err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newDevMonitor is only implemented on Linux
+func newDevMonitor(dir string) (hotplugMonitor, error) {
+	return nil, errHotplugUnsupported
+}
+
+// Events returns the notification channel
+func (m *deviceChangeMonitor) Events() <-chan struct{} {
+	return m.events
+}
+
+// Close stops the message loop and destroys the window
+func (m *deviceChangeMonitor) Close() error {
+	var err error
+	m.once.Do(func() {
+		r, _, e := procPostThreadMessageW.Call(uintptr(m.threadID), wmQuit, 0, 0)
+		if r == 0 {
+			err = e
+		}
+	})
+	return err
+}
+
+// run creates the window and pumps its messages. Windows delivers messages
+// to the thread that created the window, so the goroutine stays on one OS
+// thread for its lifetime.
+func (m *deviceChangeMonitor) run(ready chan<- error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	m.threadID = windows.GetCurrentThreadId()
+
+	hwnd, notification, err := createHotplugWindow()
+	if err != nil {
+		ready <- err
+		return
+	}
+
+	hotplugWindowsMu.Lock()
+	hotplugWindows[hwnd] = m
+	hotplugWindowsMu.Unlock()
+
+	defer func() {
+		hotplugWindowsMu.Lock()
+		delete(hotplugWindows, hwnd)
+		hotplugWindowsMu.Unlock()
+
+		procUnregisterDeviceNotification.Call(notification)
+		procDestroyWindow.Call(hwnd)
+		close(m.events)
+	}()
+
+	ready <- nil
+
+	var msg winMsg
+	for {
+		// GetMessage returns 0 for WM_QUIT and -1 on error
+		r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+		if int32(r) <= 0 {
+			return
+		}
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
+	}
+}
+
+// createHotplugWindow creates a message-only window and registers it for
+// COM port device interface notifications. Message-only windows do not
+// receive broadcasts, so the registration is required.
+func createHotplugWindow() (uintptr, uintptr, error) {
+	className, err := windows.UTF16PtrFromString(hotplugWindowClass)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var instance windows.Handle
+	if err := windows.GetModuleHandleEx(0, nil, &instance); err != nil {
+		return 0, 0, err
+	}
+
+	wndProcOnce.Do(func() {
+		wndProcCallback = windows.NewCallback(hotplugWndProc)
+	})
+
+	wc := wndClassEx{
+		WndProc:   wndProcCallback,
+		Instance:  instance,
+		ClassName: className,
+	}
+	wc.Size = uint32(unsafe.Sizeof(wc))
+	if r, _, e := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))); r == 0 && e != windows.Errno(errorClassAlreadyExists) {
+		return 0, 0, e
+	}
+
+	hwnd, _, e := procCreateWindowExW.Call(0,
+		uintptr(unsafe.Pointer(className)), uintptr(unsafe.Pointer(className)),
+		0, 0, 0, 0, 0, hwndMessage, 0, uintptr(instance), 0)
+	if hwnd == 0 {
+		return 0, 0, e
+	}
+
+	guid, err := windows.GUIDFromString(guidDevinterfaceComportValue)
+	if err != nil {
+		procDestroyWindow.Call(hwnd)
+		return 0, 0, err
+	}
+
+	filter := devBroadcastDeviceInterface{
+		DeviceType: dbtDevtypDeviceInterface,
+		ClassGUID:  guid,
+	}
+	filter.Size = uint32(unsafe.Sizeof(filter))
+
+	notification, _, e := procRegisterDeviceNotificationW.Call(hwnd, uintptr(unsafe.Pointer(&filter)), deviceNotifyWindowHandle)
+	if notification == 0 {
+		procDestroyWindow.Call(hwnd)
+		return 0, 0, e
+	}
+
+	return hwnd, notification, nil
+}
+
+// hotplugWndProc is the window procedure for monitor windows
+func hotplugWndProc(hwnd, msg, wParam, lParam uintptr) uintptr {
+	if msg == wmDeviceChange {
+		if wParam == dbtDeviceArrival || wParam == dbtDeviceRemoveComplete {
+			hotplugWindowsMu.Lock()
+			m := hotplugWindows[hwnd]
+			hotplugWindowsMu.Unlock()
+			if m != nil {
+				notify(m.events)
+			}
+		}
+		return 1
+	}
+
+	r, _, _ := procDefWindowProcW.Call(hwnd, msg, wParam, lParam)
+	return r
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// maxIdleCheckInterval bounds how late an idle session is closed
+const maxIdleCheckInterval = 30 * time.Second
+
+// SetIdleTimeout closes client sessions that have not been used for the
+// given duration. Managed sessions, sessions with active streams or
+// subscribers, passthrough and proxy sessions, and sessions with queued
+// writes are never considered idle. A zero timeout disables the policy.
+func (m *Manager) SetIdleTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	interval := min(timeout/4, maxIdleCheckInterval)
+	go m.idleLoop(timeout, interval)
+}
+
+// idleLoop closes idle sessions for the lifetime of the manager
+func (m *Manager) idleLoop(timeout, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for _, session := range m.Sessions() {
+			if idle := session.idleFor(now); idle >= timeout {
+				m.closeIdle(session, idle)
+			}
+		}
+	}
+}
+
+// touchUsed records that a client used the session
+func (s *Session) touchUsed() {
+	s.lastUsed.Store(time.Now().UnixNano())
+}
+
+// idleFor returns how long the session has gone unused, or zero if it is in
+// use or kept open by the agent
+func (s *Session) idleFor(now time.Time) time.Duration {
+	if s.Managed || s.SubscriberCount() > 0 || s.passthrough.Load() != nil || s.proxy.Load() != nil {
+		return 0
+	}
+
+	s.queueMu.Lock()
+	queued := s.queue != nil && s.queue.len() > 0
+	s.queueMu.Unlock()
+	if queued {
+		return 0
+	}
+
+	return now.Sub(time.Unix(0, s.lastUsed.Load()))
+}
+
+// closeIdle closes an idle session unless a client used it in the meantime
+func (m *Manager) closeIdle(session *Session, idle time.Duration) {
+	m.mu.Lock()
+	if m.sessions[session.PortName] != session || session.idleFor(time.Now()) < idle {
+		m.mu.Unlock()
+		return
+	}
+	sessionID, clientID := session.ID, session.ClientID
+	m.closeSessionLocked(session)
+	m.mu.Unlock()
+
+	log.Printf("Closed idle session on %s (client %s)", session.PortName, clientID)
+
+	m.events.Publish(Event{
+		Type:      EventSessionExpired,
+		PortName:  session.PortName,
+		SessionID: sessionID,
+		ClientID:  clientID,
+		Message:   fmt.Sprintf("session closed after %s without activity", idle.Round(time.Second)),
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencySamples is the number of recent measurements latency percentiles
+// are computed over
+const latencySamples = 1024
+
+// LatencyStats summarises durations measured on a session. Count and Total
+// cover the whole session; the percentiles, maximum, and jitter cover the
+// most recent measurements.
+type LatencyStats struct {
+	Count  uint64
+	Total  time.Duration
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+	Jitter time.Duration // Standard deviation
+}
+
+// latencyRecorder keeps a ring of recent durations
+type latencyRecorder struct {
+	mu      sync.Mutex
+	samples [latencySamples]time.Duration
+	next    int
+	count   uint64
+	total   time.Duration
+}
+
+// record adds a measurement, overwriting the oldest once the ring is full
+func (r *latencyRecorder) record(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencySamples
+	r.count++
+	r.total += d
+}
+
+// stats computes the summary of the recorded durations
+func (r *latencyRecorder) stats() LatencyStats {
+	r.mu.Lock()
+	n := min(r.count, latencySamples)
+	recent := slices.Clone(r.samples[:n])
+	stats := LatencyStats{Count: r.count, Total: r.total}
+	r.mu.Unlock()
+
+	if n == 0 {
+		return stats
+	}
+	slices.Sort(recent)
+
+	quantile := func(q float64) time.Duration {
+		return recent[int(q*float64(len(recent)-1))]
+	}
+	stats.P50 = quantile(0.5)
+	stats.P90 = quantile(0.9)
+	stats.P99 = quantile(0.99)
+	stats.Max = recent[len(recent)-1]
+
+	var sum float64
+	for _, d := range recent {
+		sum += float64(d)
+	}
+	mean := sum / float64(len(recent))
+	var variance float64
+	for _, d := range recent {
+		variance += (float64(d) - mean) * (float64(d) - mean)
+	}
+	stats.Jitter = time.Duration(math.Sqrt(variance / float64(len(recent))))
+
+	return stats
+}
+
+// portWrite writes to the port, measuring how long the write call takes
+func (s *Session) portWrite(p []byte) (int, error) {
+	start := time.Now()
+	n, err := s.port.Write(p)
+	s.Statistics.writeDuration.record(time.Since(start))
+	return n, err
+}
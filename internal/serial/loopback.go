@@ -0,0 +1,166 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"time"
+)
+
+// Loopback test defaults
+const (
+	DefaultLoopbackPatternSize = 64
+	DefaultLoopbackIterations  = 10
+)
+
+// LoopbackOptions describes a loopback test
+type LoopbackOptions struct {
+	Pattern    []byte        // Data sent each iteration (default: counting bytes)
+	Iterations int           // Number of round trips
+	Timeout    time.Duration // Per-iteration timeout (default: derived from baud rate)
+}
+
+// LoopbackReport is the outcome of a loopback test
+type LoopbackReport struct {
+	Iterations     int
+	PacketsLost    int // Iterations that timed out before the full pattern returned
+	BytesSent      int
+	BytesReceived  int
+	ByteErrors     int // Received bytes that differ from the pattern
+	MinLatency     time.Duration
+	MaxLatency     time.Duration
+	AvgLatency     time.Duration
+	ThroughputBps  float64 // Measured payload bytes per second
+	TheoreticalBps float64 // Maximum bytes per second at the configured baud rate
+	BaudRate       int
+}
+
+// ErrorRate returns the fraction of sent bytes that were lost or corrupted
+func (r LoopbackReport) ErrorRate() float64 {
+	if r.BytesSent == 0 {
+		return 0
+	}
+	bad := r.ByteErrors + (r.BytesSent - r.BytesReceived)
+	if bad < 0 {
+		bad = 0
+	}
+	return float64(bad) / float64(r.BytesSent)
+}
+
+// bitsPerChar returns the number of bits on the wire for each byte
+func (c PortConfig) bitsPerChar() float64 {
+	bits := 1 + float64(c.DataBits)
+	if c.Parity != ParityNone {
+		bits++
+	}
+	switch c.StopBits {
+	case StopBits1Half:
+		bits += 1.5
+	case StopBits2:
+		bits += 2
+	default:
+		bits++
+	}
+	return bits
+}
+
+// LoopbackTest sends a pattern repeatedly and measures how it comes back.
+// The port's TX and RX must be jumpered or connected to an echo device.
+func (m *Manager) LoopbackTest(portName string, sessionID string, opts LoopbackOptions) (*LoopbackReport, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Pattern) == 0 {
+		opts.Pattern = make([]byte, DefaultLoopbackPatternSize)
+		for i := range opts.Pattern {
+			opts.Pattern[i] = byte(i)
+		}
+	}
+	if opts.Iterations <= 0 {
+		opts.Iterations = DefaultLoopbackIterations
+	}
+
+	config := session.Config
+	report := &LoopbackReport{
+		Iterations: opts.Iterations,
+		BaudRate:   config.BaudRate,
+	}
+	if config.BaudRate > 0 {
+		report.TheoreticalBps = float64(config.BaudRate) / config.bitsPerChar()
+	}
+
+	if opts.Timeout <= 0 {
+		// Twice the time on the wire, plus slack for USB adapters and echo devices
+		opts.Timeout = 500 * time.Millisecond
+		if report.TheoreticalBps > 0 {
+			wire := time.Duration(float64(len(opts.Pattern)) / report.TheoreticalBps * float64(time.Second))
+			opts.Timeout += 2 * wire
+		}
+	}
+
+	var total time.Duration
+	for i := 0; i < opts.Iterations; i++ {
+		result, err := m.Transact(portName, sessionID, TransactOptions{
+			Request:       opts.Pattern,
+			ExpectedBytes: len(opts.Pattern),
+			Timeout:       opts.Timeout,
+			FlushInput:    true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report.BytesSent += len(opts.Pattern)
+
+		received := result.Data
+		if len(received) > len(opts.Pattern) {
+			received = received[:len(opts.Pattern)]
+		}
+		report.BytesReceived += len(received)
+		for j := range received {
+			if received[j] != opts.Pattern[j] {
+				report.ByteErrors++
+			}
+		}
+
+		if !result.Matched {
+			report.PacketsLost++
+			continue
+		}
+
+		total += result.Elapsed
+		if report.MinLatency == 0 || result.Elapsed < report.MinLatency {
+			report.MinLatency = result.Elapsed
+		}
+		if result.Elapsed > report.MaxLatency {
+			report.MaxLatency = result.Elapsed
+		}
+	}
+
+	if completed := opts.Iterations - report.PacketsLost; completed > 0 {
+		report.AvgLatency = total / time.Duration(completed)
+		report.ThroughputBps = float64(completed*len(opts.Pattern)) / total.Seconds()
+	}
+
+	// Leave the session usable by discarding any late echoes
+	session.mu.Lock()
+	session.resetInput(session.attachment(sessionID))
+	session.mu.Unlock()
+
+	return report, nil
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ManagedClientID is the client ID owning sessions opened by the agent
+const ManagedClientID = "baudlink-managed"
+
+// DefaultManagedBufferSize is the default receive buffer for managed ports
+const DefaultManagedBufferSize = 64 * 1024
+
+// ErrPortDisconnected is returned for I/O on a managed or reconnecting
+// session whose device is currently unplugged
+var ErrPortDisconnected = errors.New("port is disconnected")
+
+// ManagedPort describes a port the agent keeps open on behalf of clients
+type ManagedPort struct {
+	Name       string // Port name or alias
+	Config     PortConfig
+	BufferSize int
+}
+
+// readInput reads received data into p from the attachment's or the
+// session's receive buffer, waiting up to timeout for data to arrive
+// (must be called with the session's read lock held)
+func (s *Session) readInput(att *Attachment, p []byte, timeout time.Duration) (int, error) {
+	n, _, err := s.readInputTimed(att, p, timeout)
+	return n, err
+}
+
+// readInputTimed is readInput, also returning when the data read was
+// received
+func (s *Session) readInputTimed(att *Attachment, p []byte, timeout time.Duration) (int, ReceiveTimes, error) {
+	if att != nil && att.buffer != nil {
+		return att.buffer.ReadTimed(p, timeout)
+	}
+	return s.buffer.ReadTimed(p, timeout)
+}
+
+// unreadInput puts data taken by readInput back at the front of the buffer
+// it came from, as received at the given time
+func (s *Session) unreadInput(att *Attachment, p []byte, at time.Time) {
+	if att != nil && att.buffer != nil {
+		att.buffer.Unread(p, at)
+		return
+	}
+	s.buffer.Unread(p, at)
+}
+
+// resetInput discards pending received data
+// (must be called with the session lock held)
+func (s *Session) resetInput(att *Attachment) error {
+	if att != nil && att.buffer != nil {
+		att.buffer.Reset()
+		return nil
+	}
+	s.buffer.Reset()
+	return s.port.ResetInputBuffer()
+}
+
+// IsDisconnected reports whether a managed or reconnecting session has lost
+// its device
+func (s *Session) IsDisconnected() bool {
+	return s.disconnected.Load()
+}
+
+// BufferedBytes returns the number of received bytes waiting in the
+// session's buffer
+func (s *Session) BufferedBytes() int {
+	return s.buffer.Len()
+}
+
+// OpenManaged opens a port as a managed session. The session is owned by the
+// agent, shared with any client, and continuously buffers received data.
+func (m *Manager) OpenManaged(portName string, managedName string, config PortConfig, bufferSize int) (*Session, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if bufferSize <= 0 {
+		bufferSize = DefaultManagedBufferSize
+	}
+	portName = CanonicalPortName(portName)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[portName]; exists {
+		return nil, ErrPortLocked
+	}
+
+	port, rs485Kernel, err := openDevice(portName, config, m.systemLock)
+	if err != nil {
+		return nil, err
+	}
+
+	framer, _ := NewFramer(config.Framer)
+	checksum, _ := NewChecksum(config.Checksum)
+	readFilters, _ := NewPipeline(config.ReadFilters)
+	writeFilters, _ := NewPipeline(config.WriteFilters)
+
+	session := &Session{
+		ID:           uuid.New().String(),
+		PortName:     portName,
+		ClientID:     ManagedClientID,
+		Config:       config,
+		Statistics:   newStatistics(),
+		port:         port,
+		readers:      make([]chan []byte, 0),
+		framer:       framer,
+		checksum:     checksum,
+		readFilters:  readFilters,
+		writeFilters: writeFilters,
+		readTimeout:  time.Duration(config.ReadTimeoutMs) * time.Millisecond,
+		Managed:      true,
+		ManagedName:  managedName,
+		buffer:       NewRingBuffer(bufferSize),
+		monitor:      m.monitor,
+	}
+	session.rs485Kernel.Store(rs485Kernel)
+	if keepsPackets(port) {
+		session.buffer.KeepPackets()
+	}
+
+	m.sessions[portName] = session
+	m.sessionsByID[session.ID] = session
+	if m.observer != nil {
+		m.observer.SessionOpened(session)
+	}
+
+	go m.pump(session, port)
+
+	return session, nil
+}
+
+// ReopenManaged reconnects a disconnected managed session to a device path,
+// keeping its session ID, statistics, and buffered data
+func (m *Manager) ReopenManaged(session *Session, portName string) error {
+	port, err := m.reopenSession(session, CanonicalPortName(portName))
+	if err != nil {
+		return err
+	}
+
+	session.disconnected.Store(false)
+	go m.pump(session, port)
+
+	return nil
+}
+
+// Supervisor keeps managed ports open, reopening them when their device
+// re-enumerates after being unplugged
+type Supervisor struct {
+	manager  *Manager
+	scanner  *Scanner
+	ports    []ManagedPort
+	interval time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+
+	// mu guards sessions, changed by reconcile and read by Status
+	mu       sync.Mutex
+	sessions map[string]*Session // key: managed port name
+}
+
+// NewSupervisor creates a supervisor for the given managed ports
+func NewSupervisor(manager *Manager, scanner *Scanner, ports []ManagedPort, interval time.Duration) *Supervisor {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	return &Supervisor{
+		manager:  manager,
+		scanner:  scanner,
+		ports:    ports,
+		interval: interval,
+		sessions: make(map[string]*Session),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start opens the managed ports and begins supervising them
+func (s *Supervisor) Start() {
+	s.reconcile()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.reconcile()
+			}
+		}
+	}()
+}
+
+// Stop ends supervision. Managed sessions stay open until the manager closes them.
+func (s *Supervisor) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// ManagedPortStatus reports whether a managed port is open and connected
+type ManagedPortStatus struct {
+	Name     string // Port name or alias, as configured
+	PortName string // Device path of the open session, if any
+	Healthy  bool
+	Reason   string // Why the port is unhealthy
+}
+
+// Status reports the health of every managed port in configuration order.
+// A port is healthy while its session is open and its device connected.
+func (s *Supervisor) Status() []ManagedPortStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]ManagedPortStatus, 0, len(s.ports))
+	for _, mp := range s.ports {
+		status := ManagedPortStatus{Name: mp.Name}
+		session := s.sessions[mp.Name]
+		switch {
+		case session == nil || session.closed.Load():
+			status.Reason = "not open"
+		case session.IsDisconnected():
+			status.PortName = session.PortName
+			status.Reason = "disconnected"
+		default:
+			status.PortName = session.PortName
+			status.Healthy = true
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// reconcile opens or reopens every managed port that is not connected
+func (s *Supervisor) reconcile() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, mp := range s.ports {
+		session := s.sessions[mp.Name]
+		if session != nil && session.closed.Load() {
+			// Closed by an administrator; open a fresh session
+			session = nil
+		}
+		if session != nil && !session.IsDisconnected() {
+			continue
+		}
+
+		portName, err := s.scanner.Resolve(mp.Name)
+		if err != nil {
+			continue
+		}
+
+		if session == nil {
+			session, err = s.manager.OpenManaged(portName, mp.Name, mp.Config, mp.BufferSize)
+			if err != nil {
+				log.Printf("Failed to open managed port %s: %v", mp.Name, err)
+				continue
+			}
+			s.sessions[mp.Name] = session
+			log.Printf("Opened managed port %s (%s)", mp.Name, portName)
+			continue
+		}
+
+		if err := s.manager.ReopenManaged(session, portName); err != nil {
+			continue
+		}
+		log.Printf("Reopened managed port %s (%s)", mp.Name, portName)
+	}
+}
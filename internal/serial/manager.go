@@ -17,14 +17,20 @@ limitations under the License.
 package serial
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"go.bug.st/serial"
+
+	"github.com/Shoaibashk/BaudLink/internal/ratelimit"
 )
 
 // Common errors
@@ -38,6 +44,7 @@ var (
 	ErrWriteTimeout     = errors.New("write timeout")
 	ErrReadTimeout      = errors.New("read timeout")
 	ErrPortClosed       = errors.New("port has been closed")
+	ErrRateLimited      = errors.New("port rate limit exceeded")
 )
 
 // Parity represents the parity setting
@@ -76,8 +83,30 @@ type PortConfig struct {
 	StopBits       StopBits
 	Parity         Parity
 	FlowControl    FlowControl
+	XonChar        int // Software flow control characters (0 = DC1/DC3)
+	XoffChar       int
 	ReadTimeoutMs  int
 	WriteTimeoutMs int
+	Framer         string // Frame dissector used for protocol statistics
+
+	// Write pacing for devices that drop data arriving too fast
+	WriteChunkSize    int // Maximum bytes per write (0 = unlimited)
+	WriteChunkDelayMs int // Delay between chunks
+	WriteLineDelayMs  int // Delay after each newline
+
+	// Half-duplex RS-485 driver control through RTS
+	RS485 RS485Config
+
+	// Checksum appended to writes and verified on framed responses
+	Checksum ChecksumConfig
+
+	// Filters applied in order to data read from and written to the port
+	ReadFilters  []string
+	WriteFilters []string
+
+	// Observe the port only: DTR and RTS are left deasserted on open and
+	// writes are refused (see Sniff)
+	Sniff bool
 }
 
 // DefaultConfig returns a default port configuration
@@ -101,6 +130,27 @@ func (c PortConfig) Validate() error {
 	if c.DataBits < 5 || c.DataBits > 8 {
 		return fmt.Errorf("invalid data bits: %d", c.DataBits)
 	}
+	if _, err := NewFramer(c.Framer); err != nil {
+		return err
+	}
+	if c.WriteChunkSize < 0 || c.WriteChunkDelayMs < 0 || c.WriteLineDelayMs < 0 {
+		return fmt.Errorf("write pacing values must not be negative")
+	}
+	if err := c.validateFlow(); err != nil {
+		return err
+	}
+	if err := c.RS485.validate(c.FlowControl); err != nil {
+		return err
+	}
+	if _, err := NewChecksum(c.Checksum); err != nil {
+		return err
+	}
+	if _, err := NewPipeline(c.ReadFilters); err != nil {
+		return err
+	}
+	if _, err := NewPipeline(c.WriteFilters); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -136,13 +186,27 @@ func (c PortConfig) toSerialMode() *serial.Mode {
 	return mode
 }
 
-// PortStatistics contains statistics about port usage
+// PortStatistics is a copy of a session's statistics
 type PortStatistics struct {
 	BytesSent     uint64
 	BytesReceived uint64
 	Errors        uint64
 	OpenedAt      time.Time
 	LastActivity  time.Time
+
+	// Protocol statistics, collected when a framer is active
+	FramesParsed   uint64
+	FramingErrors  uint64
+	ChecksumErrors uint64
+	FrameBytes     uint64
+
+	// Writes rejected by the port rate limit
+	RateLimited uint64
+
+	// Agent-induced latency: how long received data waited before a stream
+	// took it, and how long writes to the port took
+	ReadLatency   LatencyStats
+	WriteDuration LatencyStats
 }
 
 // Session represents an active serial port session
@@ -151,13 +215,64 @@ type Session struct {
 	PortName     string
 	ClientID     string
 	Exclusive    bool
+	Priority     int // Owner priority used to arbitrate takeovers
 	Config       PortConfig
-	Statistics   PortStatistics
+	Statistics   *Statistics
 	port         serial.Port
 	mu           sync.Mutex
 	closed       atomic.Bool
 	readers      []chan []byte
 	readersMu    sync.RWMutex
+	queue        *writeQueue
+	queueMu      sync.Mutex
+	framer       Framer
+	framerMu     sync.Mutex
+	checksum     *Checksum // Guarded by mu
+	readFilters  Pipeline  // Guarded by mu
+	writeFilters Pipeline  // Guarded by mu
+	readTimeout  time.Duration // Guarded by mu
+
+	// A pump reads the port into the receive buffer, which reads and
+	// streams consume; readMu keeps one consumer reading at a time
+	buffer    *RingBuffer
+	readMu    sync.Mutex
+	streams   map[string]*stream // key: session or attachment ID
+	streamsMu sync.Mutex
+
+	// Managed sessions are kept open by the agent and buffer received data
+	// so clients can attach at any time
+	Managed      bool
+	ManagedName  string
+	disconnected atomic.Bool
+
+	// Reconnecting sessions are suspended when their device is lost and
+	// reopened when it reappears, matched by serial number
+	reconnect    atomic.Bool
+	deviceSerial string
+	openedAs     string // Port name the session was opened with
+
+	attachments map[string]*Attachment // key: attachment ID
+	attachMu    sync.RWMutex
+	owner       string // Identity the session ID is bound to (guarded by attachMu)
+
+	sniff bool // Opened to observe only; every write is refused
+
+	sampler      rateSampler
+	lastSent     atomic.Int64 // Unix nanoseconds
+	lastReceived atomic.Int64 // Unix nanoseconds
+	lastUsed     atomic.Int64 // Unix nanoseconds of the last client request
+
+	chunkSeq atomic.Uint64 // Last sequence number given to a chunk read from the session
+
+	taps   map[string]*Tap // key: tap ID
+	tapsMu sync.RWMutex
+
+	passthrough atomic.Pointer[Passthrough]
+	proxy       atomic.Pointer[proxyLeg] // Forwards received data while proxied
+
+	rs485Kernel atomic.Bool // The kernel keys the RS-485 driver
+
+	monitor *DataMonitor
 }
 
 // Manager handles serial port sessions and operations
@@ -165,46 +280,130 @@ type Manager struct {
 	mu               sync.RWMutex
 	sessions         map[string]*Session // key: port name
 	sessionsByID     map[string]*Session // key: session ID
+	takenOver        map[string]*Session // key: session ID replaced by a takeover
 	allowSharedAccess bool
 	defaultConfig    PortConfig
+	events           *EventBus
+	writeQueueDepth  int
+	portLimiter      *ratelimit.Limiter
+	writerLimiter    *ratelimit.Limiter // key: port name and client ID
+	openRetry        RetryPolicy
+	tapSettings      TapSettings
+	passthroughSettings PassthroughSettings
+	systemLock       SystemLockSettings
+	monitor          *DataMonitor
+	queues           map[string][]*portWaiter // key: port name
+	observer         SessionObserver
 }
 
 // NewManager creates a new serial port manager
 func NewManager(allowSharedAccess bool, defaultConfig PortConfig) *Manager {
-	return &Manager{
+	m := &Manager{
 		sessions:          make(map[string]*Session),
 		sessionsByID:      make(map[string]*Session),
+		takenOver:         make(map[string]*Session),
 		allowSharedAccess: allowSharedAccess,
 		defaultConfig:     defaultConfig,
+		events:            NewEventBus(),
+		monitor:           NewDataMonitor(),
+		writeQueueDepth:   DefaultWriteQueueDepth,
+	}
+
+	go m.sampleLoop()
+
+	return m
+}
+
+// SetWriteQueueDepth sets the maximum number of pending queued writes per session
+func (m *Manager) SetWriteQueueDepth(depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writeQueueDepth = depth
+}
+
+// SetPortRateLimit limits the request and byte rate of writes to each port
+func (m *Manager) SetPortRateLimit(limit ratelimit.Limit) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.portLimiter = ratelimit.NewLimiter(limit)
+}
+
+// checkRateLimit applies the port rate limit to a write of n bytes
+func (m *Manager) checkRateLimit(session *Session, n int) error {
+	m.mu.RLock()
+	limiter := m.portLimiter
+	m.mu.RUnlock()
+
+	if !limiter.Allow(session.PortName, n) {
+		session.Statistics.RateLimited.Add(1)
+		return ErrRateLimited
 	}
+	return nil
+}
+
+// Events returns the event bus used to publish session events
+func (m *Manager) Events() *EventBus {
+	return m.events
+}
+
+// OpenPort opens a serial port and creates a new session, retrying transient
+// failures according to the manager's retry policy. The priority is compared
+// against later takeover requests.
+func (m *Manager) OpenPort(portName string, config PortConfig, clientID string, exclusive bool, priority int) (*Session, error) {
+	return m.OpenPortWithRetry(context.Background(), portName, config, clientID, exclusive, priority, m.OpenRetry())
 }
 
-// OpenPort opens a serial port and creates a new session
-func (m *Manager) OpenPort(portName string, config PortConfig, clientID string, exclusive bool) (*Session, error) {
+// openPort makes a single attempt to open a port
+func (m *Manager) openPort(portName string, config PortConfig, clientID string, exclusive bool, priority int) (*Session, error) {
+	return m.openPortFor(nil, portName, config, clientID, exclusive, priority)
+}
+
+// openPortFor makes a single attempt to open a port for the client holding
+// a place in its queue, or for a client that did not queue when ticket is
+// nil
+func (m *Manager) openPortFor(ticket *portWaiter, portName string, config PortConfig, clientID string, exclusive bool, priority int) (*Session, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	portName = CanonicalPortName(portName)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.openPortLocked(ticket, portName, config, clientID, exclusive, priority)
+}
+
+// openPortLocked opens a port by its canonical name (must be called with
+// lock held)
+func (m *Manager) openPortLocked(ticket *portWaiter, portName string, config PortConfig, clientID string, exclusive bool, priority int) (*Session, error) {
 	// Check if port is already open
 	if existingSession, exists := m.sessions[portName]; exists {
-		if existingSession.Exclusive || exclusive || !m.allowSharedAccess {
+		// Managed sessions are shared; clients attach instead of opening
+		if existingSession.Managed && !exclusive {
+			return existingSession, nil
+		}
+		if existingSession.Exclusive || existingSession.sniff || exclusive || !m.allowSharedAccess {
 			return nil, ErrPortLocked
 		}
+		// A second session would reopen the device; sharing clients attach
+		return nil, ErrPortShared
+	}
+
+	// Clients waiting in the port's queue are served first
+	if m.queueBlocks(portName, ticket) {
+		return nil, ErrPortLocked
 	}
 
 	// Open the serial port
-	port, err := serial.Open(portName, config.toSerialMode())
+	port, rs485Kernel, err := openDevice(portName, config, m.systemLock)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open port: %w", err)
+		return nil, err
 	}
 
-	// Set read timeout
-	if config.ReadTimeoutMs > 0 {
-		port.SetReadTimeout(time.Duration(config.ReadTimeoutMs) * time.Millisecond)
-	}
+	framer, _ := NewFramer(config.Framer)
+	checksum, _ := NewChecksum(config.Checksum)
+	readFilters, _ := NewPipeline(config.ReadFilters)
+	writeFilters, _ := NewPipeline(config.WriteFilters)
 
 	// Create session
 	session := &Session{
@@ -212,23 +411,41 @@ func (m *Manager) OpenPort(portName string, config PortConfig, clientID string,
 		PortName:  portName,
 		ClientID:  clientID,
 		Exclusive: exclusive,
-		Config:    config,
-		Statistics: PortStatistics{
-			OpenedAt:     time.Now(),
-			LastActivity: time.Now(),
-		},
-		port:    port,
-		readers: make([]chan []byte, 0),
+		Priority:  priority,
+		sniff:     config.Sniff,
+		Config:       config,
+		Statistics:   newStatistics(),
+		port:         port,
+		readers:      make([]chan []byte, 0),
+		framer:       framer,
+		checksum:     checksum,
+		readFilters:  readFilters,
+		writeFilters: writeFilters,
+		readTimeout:  time.Duration(config.ReadTimeoutMs) * time.Millisecond,
+		buffer:       NewRingBuffer(receiveBufferSize),
+		monitor:      m.monitor,
+	}
+	session.rs485Kernel.Store(rs485Kernel)
+	session.touchUsed()
+	if keepsPackets(port) {
+		session.buffer.KeepPackets()
 	}
 
 	m.sessions[portName] = session
 	m.sessionsByID[session.ID] = session
+	if m.observer != nil {
+		m.observer.SessionOpened(session)
+	}
+
+	go m.pump(session, port)
 
 	return session, nil
 }
 
 // ClosePort closes a serial port session
 func (m *Manager) ClosePort(portName string, sessionID string) error {
+	portName = CanonicalPortName(portName)
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -238,9 +455,18 @@ func (m *Manager) ClosePort(portName string, sessionID string) error {
 	}
 
 	if session.ID != sessionID {
+		// Clients sharing the port close it by detaching
+		if session.detach(sessionID) {
+			return nil
+		}
 		return ErrInvalidSession
 	}
 
+	// Managed sessions stay open when a client is done with them
+	if session.Managed {
+		return nil
+	}
+
 	return m.closeSessionLocked(session)
 }
 
@@ -248,6 +474,23 @@ func (m *Manager) ClosePort(portName string, sessionID string) error {
 func (m *Manager) closeSessionLocked(session *Session) error {
 	session.closed.Store(true)
 
+	if m.observer != nil {
+		for _, tap := range session.Taps() {
+			m.observer.TapRemoved(session, tap)
+		}
+		m.observer.SessionClosed(session)
+	}
+
+	m.stopWriteQueue(session)
+
+	if session.buffer != nil {
+		session.buffer.Close()
+	}
+	session.closeAttachments()
+	session.closeTaps()
+	session.stopPassthrough()
+	session.stopProxy(ErrPortClosed)
+
 	// Close all reader channels
 	session.readersMu.Lock()
 	for _, ch := range session.readers {
@@ -258,9 +501,12 @@ func (m *Manager) closeSessionLocked(session *Session) error {
 
 	// Close the port
 	err := session.port.Close()
+	session.releaseRS485()
 
 	delete(m.sessions, session.PortName)
 	delete(m.sessionsByID, session.ID)
+	m.forgetTakenOver(session)
+	m.wakeQueueLocked(session.PortName)
 
 	return err
 }
@@ -269,7 +515,7 @@ func (m *Manager) closeSessionLocked(session *Session) error {
 func (m *Manager) GetSession(portName string) *Session {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return m.sessions[portName]
+	return m.sessions[CanonicalPortName(portName)]
 }
 
 // GetSessionByID returns a session by its ID
@@ -281,15 +527,25 @@ func (m *Manager) GetSessionByID(sessionID string) *Session {
 
 // ValidateSession checks if a session is valid
 func (m *Manager) ValidateSession(portName string, sessionID string) (*Session, error) {
+	portName = CanonicalPortName(portName)
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	session, exists := m.sessions[portName]
 	if !exists {
-		return nil, ErrPortNotOpen
+		// A reconnected device may have come back under another path
+		session = m.sessionsByID[sessionID]
+		if session == nil || session.openedAs != portName {
+			return nil, ErrPortNotOpen
+		}
 	}
 
-	if session.ID != sessionID {
+	// Attachment IDs are accepted wherever a session ID is
+	if session.ID != sessionID && session.attachment(sessionID) == nil {
+		if m.takenOver[sessionID] == session {
+			return nil, ErrSessionTakenOver
+		}
 		return nil, ErrInvalidSession
 	}
 
@@ -297,6 +553,7 @@ func (m *Manager) ValidateSession(portName string, sessionID string) (*Session,
 		return nil, ErrPortClosed
 	}
 
+	session.touchUsed()
 	return session, nil
 }
 
@@ -307,22 +564,81 @@ func (m *Manager) Write(portName string, sessionID string, data []byte) (int, er
 		return 0, err
 	}
 
+	att, err := session.checkWritable(sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if session.IsDisconnected() {
+		return 0, ErrPortDisconnected
+	}
+
+	if err := m.checkRateLimit(session, len(data)); err != nil {
+		return 0, err
+	}
+	if err := m.checkWriteQuota(session, att, len(data)); err != nil {
+		return 0, err
+	}
+
 	session.mu.Lock()
 	defer session.mu.Unlock()
 
-	n, err := session.port.Write(data)
+	if err := session.checkPassthrough(); err != nil {
+		return 0, err
+	}
+
+	data, err = session.writeFilters.Apply(data)
+	if err != nil {
+		return 0, err
+	}
+	data = session.checksum.Append(data)
+	n, err := session.writeData(data)
+	if err != nil {
+		session.Statistics.Errors.Add(1)
+		return n, m.handleDeviceLoss(session, err)
+	}
+
+	session.recordSent(data[:n])
+	att.recordSent(n)
+	session.Statistics.Touch()
+
+	return n, nil
+}
+
+// WriteTracked writes data to a port and publishes a write-complete event
+// carrying the given correlation ID once the data has been handed to the
+// driver. If drain is set, the output buffer is drained before the event is
+// published so that the event reflects data actually sent on the wire.
+func (m *Manager) WriteTracked(portName string, sessionID string, data []byte, correlationID string, drain bool) (int, error) {
+	n, err := m.Write(portName, sessionID, data)
+
+	event := Event{
+		Type:          EventWriteComplete,
+		PortName:      portName,
+		SessionID:     sessionID,
+		CorrelationID: correlationID,
+		BytesWritten:  n,
+	}
+
 	if err != nil {
-		atomic.AddUint64(&session.Statistics.Errors, 1)
+		event.Message = err.Error()
+		m.events.Publish(event)
 		return n, err
 	}
 
-	atomic.AddUint64(&session.Statistics.BytesSent, uint64(n))
-	session.Statistics.LastActivity = time.Now()
+	if drain {
+		if derr := m.Drain(portName, sessionID); derr != nil {
+			event.Message = derr.Error()
+		} else {
+			event.Drained = true
+		}
+	}
 
+	m.events.Publish(event)
 	return n, nil
 }
 
-// Read reads data from a port
+// Read reads data from a port, waiting up to the session's read timeout
 func (m *Manager) Read(portName string, sessionID string, maxBytes int) ([]byte, error) {
 	session, err := m.ValidateSession(portName, sessionID)
 	if err != nil {
@@ -330,19 +646,67 @@ func (m *Manager) Read(portName string, sessionID string, maxBytes int) ([]byte,
 	}
 
 	session.mu.Lock()
-	defer session.mu.Unlock()
+	timeout := session.readTimeout
+	session.mu.Unlock()
+
+	return m.read(session, sessionID, maxBytes, timeout)
+}
+
+// ReadWithin reads data from a port, waiting up to timeout for it to arrive.
+// It returns no data and no error if the timeout expires.
+func (m *Manager) ReadWithin(portName string, sessionID string, maxBytes int, timeout time.Duration) ([]byte, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return m.read(session, sessionID, maxBytes, timeout)
+}
+
+// read takes received data from the session's or attachment's buffer. The
+// session lock is not held while waiting, so writes are not delayed by a
+// pending read.
+func (m *Manager) read(session *Session, sessionID string, maxBytes int, timeout time.Duration) ([]byte, error) {
+	// Managed sessions keep serving buffered data while disconnected
+	if !session.Managed && session.IsDisconnected() {
+		return nil, ErrPortDisconnected
+	}
 
-	buffer := make([]byte, maxBytes)
-	n, err := session.port.Read(buffer)
+	if err := session.checkPassthrough(); err != nil {
+		return nil, err
+	}
+
+	att := session.attachment(sessionID)
+
+	// Reads up to the chunk size use a pooled buffer; only the data read is
+	// copied out
+	var buffer []byte
+	if maxBytes <= readChunkSize {
+		pooled := chunkPool.Get().(*[]byte)
+		defer chunkPool.Put(pooled)
+		buffer = (*pooled)[:maxBytes]
+	} else {
+		buffer = make([]byte, maxBytes)
+	}
+
+	session.readMu.Lock()
+	n, err := session.readInput(att, buffer, timeout)
+	session.readMu.Unlock()
 	if err != nil {
-		atomic.AddUint64(&session.Statistics.Errors, 1)
 		return nil, err
 	}
+	if n == 0 {
+		return nil, nil
+	}
+	att.recordReceived(n)
 
-	atomic.AddUint64(&session.Statistics.BytesReceived, uint64(n))
-	session.Statistics.LastActivity = time.Now()
+	data := bytes.Clone(buffer[:n])
 
-	return buffer[:n], nil
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	session.Statistics.Touch()
+
+	return session.readPipeline(att).Apply(data)
 }
 
 // Configure updates port configuration
@@ -352,6 +716,10 @@ func (m *Manager) Configure(portName string, sessionID string, config PortConfig
 		return err
 	}
 
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return err
+	}
+
 	if err := config.Validate(); err != nil {
 		return err
 	}
@@ -363,25 +731,96 @@ func (m *Manager) Configure(portName string, sessionID string, config PortConfig
 		return fmt.Errorf("failed to configure port: %w", err)
 	}
 
+	if config.flowChanged(session.Config) {
+		if err := applyFlowControl(session.PortName, config, true); err != nil {
+			return fmt.Errorf("failed to configure flow control: %w", err)
+		}
+	}
+
+	if config.RS485 != session.Config.RS485 {
+		if err := session.applyRS485(config.RS485); err != nil {
+			return fmt.Errorf("failed to configure RS-485: %w", err)
+		}
+	}
+
 	if config.ReadTimeoutMs > 0 {
-		session.port.SetReadTimeout(time.Duration(config.ReadTimeoutMs) * time.Millisecond)
+		session.readTimeout = time.Duration(config.ReadTimeoutMs) * time.Millisecond
+	}
+
+	if config.Framer != session.Config.Framer {
+		session.framerMu.Lock()
+		session.framer, _ = NewFramer(config.Framer)
+		session.framerMu.Unlock()
+	}
+
+	session.checksum, _ = NewChecksum(config.Checksum)
+
+	// Keep the state of unchanged filters, such as a partial escape sequence
+	if !slices.Equal(config.ReadFilters, session.Config.ReadFilters) {
+		session.readFilters, _ = NewPipeline(config.ReadFilters)
+	}
+	if !slices.Equal(config.WriteFilters, session.Config.WriteFilters) {
+		session.writeFilters, _ = NewPipeline(config.WriteFilters)
 	}
 
 	session.Config = config
 	return nil
 }
 
-// GetStatus returns the status of a port
-func (m *Manager) GetStatus(portName string) (*Session, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// PortStatus is a copy of the state of an open port, safe to use while the
+// session keeps changing
+type PortStatus struct {
+	PortName      string
+	SessionID     string
+	ClientID      string
+	Exclusive     bool
+	Priority      int
+	Managed       bool
+	Sniff         bool
+	Config        PortConfig
+	Statistics    PortStatistics
+	Disconnected  bool
+	Reconnect     bool
+	BufferedBytes int
+	Taps          []*Tap
+	Attachments   []*Attachment
+	Flow          *FlowStatus // nil when the modem lines cannot be read
+}
 
-	session, exists := m.sessions[portName]
+// GetStatus returns a copy of the status of a port
+func (m *Manager) GetStatus(portName string) (PortStatus, error) {
+	// Ownership changes with takeovers under the manager lock
+	m.mu.RLock()
+	session, exists := m.sessions[CanonicalPortName(portName)]
 	if !exists {
-		return nil, ErrPortNotOpen
+		m.mu.RUnlock()
+		return PortStatus{}, ErrPortNotOpen
 	}
+	status := PortStatus{
+		SessionID: session.ID,
+		ClientID:  session.ClientID,
+		Exclusive: session.Exclusive,
+		Priority:  session.Priority,
+		Managed:   session.Managed,
+		Sniff:     session.sniff,
+	}
+	m.mu.RUnlock()
 
-	return session, nil
+	// The port name and configuration change under the session lock
+	session.mu.Lock()
+	status.PortName = session.PortName
+	status.Config = session.Config
+	session.mu.Unlock()
+
+	status.Statistics = session.Statistics.Snapshot()
+	status.Disconnected = session.IsDisconnected()
+	status.Reconnect = session.Reconnects()
+	status.BufferedBytes = session.BufferedBytes()
+	status.Taps = session.Taps()
+	status.Attachments = session.Attachments()
+	status.Flow = session.FlowStatus()
+
+	return status, nil
 }
 
 // ListOpenPorts returns all open port names
@@ -396,6 +835,51 @@ func (m *Manager) ListOpenPorts() []string {
 	return ports
 }
 
+// Sessions returns all active sessions ordered by port name
+func (m *Manager) Sessions() []*Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].PortName < sessions[j].PortName
+	})
+	return sessions
+}
+
+// ForceClose closes a session regardless of its owner. Unlike ClosePort this
+// also closes managed sessions; the supervisor reopens them with a new session.
+func (m *Manager) ForceClose(sessionID string) (*Session, error) {
+	m.mu.Lock()
+	session, exists := m.sessionsByID[sessionID]
+	if !exists {
+		m.mu.Unlock()
+		return nil, ErrInvalidSession
+	}
+	err := m.closeSessionLocked(session)
+	m.mu.Unlock()
+
+	m.events.Publish(Event{
+		Type:      EventSessionTerminated,
+		PortName:  session.PortName,
+		SessionID: session.ID,
+		Message:   "session closed by administrator",
+	})
+
+	return session, err
+}
+
+// SubscriberCount returns the number of channels subscribed to the session's
+// reads and streams
+func (s *Session) SubscriberCount() int {
+	s.readersMu.RLock()
+	defer s.readersMu.RUnlock()
+	return len(s.readers) + s.streamSubscribers()
+}
+
 // CloseAll closes all open ports
 func (m *Manager) CloseAll() {
 	m.mu.Lock()
@@ -422,8 +906,55 @@ func (m *Manager) SubscribeToReads(portName string, sessionID string) (<-chan []
 	return ch, nil
 }
 
-// Flush drains both input and output buffers
+// FlushInput discards received data that has not been read yet. On an
+// attachment only the attachment's own copy is discarded.
+func (m *Manager) FlushInput(portName string, sessionID string) error {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if err := session.checkPassthrough(); err != nil {
+		return err
+	}
+
+	return session.resetInput(session.attachment(sessionID))
+}
+
+// FlushOutput discards written data that has not been transmitted yet
+func (m *Manager) FlushOutput(portName string, sessionID string) error {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return err
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if err := session.checkPassthrough(); err != nil {
+		return err
+	}
+
+	return session.port.ResetOutputBuffer()
+}
+
+// Flush discards both pending input and untransmitted output
 func (m *Manager) Flush(portName string, sessionID string) error {
+	if err := m.FlushOutput(portName, sessionID); err != nil {
+		return err
+	}
+	return m.FlushInput(portName, sessionID)
+}
+
+// Drain waits until all data in the output buffer has been transmitted
+func (m *Manager) Drain(portName string, sessionID string) error {
 	session, err := m.ValidateSession(portName, sessionID)
 	if err != nil {
 		return err
@@ -432,5 +963,5 @@ func (m *Manager) Flush(portName string, sessionID string) error {
 	session.mu.Lock()
 	defer session.mu.Unlock()
 
-	return session.port.ResetInputBuffer()
+	return session.port.Drain()
 }
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sysClassTTY = "/sys/class/tty"
+	udevDataDir = "/run/udev/data"
+)
+
+// udevProperties are the udev properties reported for each port
+var udevProperties = map[string]bool{
+	"ID_BUS":                  true,
+	"ID_PATH":                 true,
+	"ID_SERIAL":               true,
+	"ID_SERIAL_SHORT":         true,
+	"ID_VENDOR":               true,
+	"ID_VENDOR_FROM_DATABASE": true,
+	"ID_MODEL":                true,
+	"ID_MODEL_FROM_DATABASE":  true,
+	"ID_USB_DRIVER":           true,
+	"ID_USB_INTERFACE_NUM":    true,
+	"ID_MM_CANDIDATE":         true,
+	"ID_MM_DEVICE_IGNORE":     true,
+	"ID_MM_PORT_IGNORE":       true,
+}
+
+// addPlatformMetadata adds the sysfs driver, USB topology, and udev
+// properties of each port
+func addPlatformMetadata(ports []PortInfo) {
+	for i := range ports {
+		addSysfsMetadata(&ports[i])
+	}
+}
+
+// addSysfsMetadata fills in the details of one port from sysfs and the udev
+// database
+func addSysfsMetadata(p *PortInfo) {
+	ttyDir := filepath.Join(sysClassTTY, filepath.Base(p.Name))
+	deviceDir := filepath.Join(ttyDir, "device")
+
+	if driver, err := os.Readlink(filepath.Join(deviceDir, "driver")); err == nil {
+		p.Driver = filepath.Base(driver)
+	}
+
+	if dev, err := filepath.EvalSymlinks(deviceDir); err == nil {
+		p.DevicePath = dev
+
+		// Walk up to the USB interface and the USB device it belongs to. The
+		// device directory is named after its port path, e.g. 1-1.2, which
+		// stays the same as long as the adapter is plugged into the same
+		// hub port.
+		for dir := dev; dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+			if p.USBInterface == "" {
+				if n := readSysfs(dir, "bInterfaceNumber"); n != "" {
+					p.USBInterface = n
+					continue
+				}
+			}
+			if readSysfs(dir, "busnum") != "" {
+				p.BusPath = filepath.Base(dir)
+				break
+			}
+		}
+	}
+
+	if devNum := readSysfs(ttyDir, "dev"); devNum != "" {
+		p.Properties = readUdevProperties(filepath.Join(udevDataDir, "c"+devNum))
+	}
+}
+
+// readSysfs returns the trimmed contents of a sysfs attribute, or "" if it
+// does not exist
+func readSysfs(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readUdevProperties reads the selected properties and device links from a
+// udev database entry
+func readUdevProperties(path string) map[string]string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	props := make(map[string]string)
+	var links []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "E:"):
+			key, value, ok := strings.Cut(line[2:], "=")
+			if ok && udevProperties[key] {
+				props[key] = value
+			}
+		case strings.HasPrefix(line, "S:"):
+			links = append(links, "/dev/"+line[2:])
+		}
+	}
+	if len(links) > 0 {
+		props["DEVLINKS"] = strings.Join(links, " ")
+	}
+
+	if len(props) == 0 {
+		return nil
+	}
+	return props
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// enumRoot is the registry key listing devices by enumerator
+const enumRoot = `SYSTEM\CurrentControlSet\Enum`
+
+// serialEnumerators are the enumerators whose devices may expose COM ports
+var serialEnumerators = []string{"USB", "FTDIBUS", "BTHENUM", "ACPI"}
+
+// registryProperties are the device values reported for each port
+var registryProperties = []string{"ContainerID", "Mfg", "DeviceDesc"}
+
+// addPlatformMetadata adds the friendly name, location, driver service,
+// and device instance ID of each port from the device registry
+func addPlatformMetadata(ports []PortInfo) {
+	byName := make(map[string]*PortInfo, len(ports))
+	for i := range ports {
+		byName[strings.ToUpper(ports[i].Name)] = &ports[i]
+	}
+	if len(byName) == 0 {
+		return
+	}
+
+	for _, enumerator := range serialEnumerators {
+		for _, device := range subKeys(enumRoot + `\` + enumerator) {
+			for _, instance := range subKeys(enumRoot + `\` + enumerator + `\` + device) {
+				id := enumerator + `\` + device + `\` + instance
+				p := byName[strings.ToUpper(portNameOf(id))]
+				if p == nil {
+					continue
+				}
+				addRegistryMetadata(p, id, device)
+			}
+		}
+	}
+}
+
+// addRegistryMetadata fills in the details of one port from its device key
+func addRegistryMetadata(p *PortInfo, id, device string) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, enumRoot+`\`+id, registry.QUERY_VALUE)
+	if err != nil {
+		return
+	}
+	defer k.Close()
+
+	p.DevicePath = id
+	p.FriendlyName = registryString(k, "FriendlyName")
+	p.BusPath = registryString(k, "LocationInformation")
+	p.Driver = registryString(k, "Service")
+
+	// Composite devices name each interface MI_xx
+	if _, rest, ok := strings.Cut(device, "MI_"); ok && len(rest) >= 2 {
+		p.USBInterface = rest[:2]
+	}
+
+	for _, name := range registryProperties {
+		if v := registryString(k, name); v != "" {
+			if p.Properties == nil {
+				p.Properties = make(map[string]string)
+			}
+			p.Properties[name] = v
+		}
+	}
+}
+
+// portNameOf returns the COM port name of a device instance, or ""
+func portNameOf(id string) string {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, enumRoot+`\`+id+`\Device Parameters`, registry.QUERY_VALUE)
+	if err != nil {
+		return ""
+	}
+	defer k.Close()
+	return registryString(k, "PortName")
+}
+
+// subKeys lists the subkeys of a key under HKEY_LOCAL_MACHINE
+func subKeys(path string) []string {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer k.Close()
+
+	names, _ := k.ReadSubKeyNames(-1)
+	return names
+}
+
+// registryString reads a string value. Localizable values of the form
+// "@driver.inf,%key%;Text" are reduced to their text.
+func registryString(k registry.Key, name string) string {
+	v, _, err := k.GetStringValue(name)
+	if err != nil {
+		return ""
+	}
+	if strings.HasPrefix(v, "@") {
+		if _, text, ok := strings.Cut(v, ";"); ok {
+			return text
+		}
+	}
+	return v
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"sync"
+	"time"
+)
+
+// PortData is a chunk of data received on a session
+type PortData struct {
+	PortName  string
+	SessionID string
+	Data      []byte
+	Timestamp time.Time
+}
+
+// DataMonitor fans out the data received on every session to subscribers
+// such as the rules engine. Data is only seen as it is read, so ports must
+// be managed or read by a client for their data to reach the monitor.
+type DataMonitor struct {
+	mu          sync.RWMutex
+	subscribers map[chan PortData]struct{}
+}
+
+// NewDataMonitor creates a new data monitor
+func NewDataMonitor() *DataMonitor {
+	return &DataMonitor{
+		subscribers: make(map[chan PortData]struct{}),
+	}
+}
+
+// Monitor returns the monitor receiving the data of all sessions
+func (m *Manager) Monitor() *DataMonitor {
+	return m.monitor
+}
+
+// Subscribe registers a new subscriber. The returned function removes the
+// subscription and closes the channel.
+func (d *DataMonitor) Subscribe() (<-chan PortData, func()) {
+	ch := make(chan PortData, 256)
+
+	d.mu.Lock()
+	d.subscribers[ch] = struct{}{}
+	d.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			d.mu.Lock()
+			delete(d.subscribers, ch)
+			d.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+// publish delivers received data to all subscribers without blocking
+func (d *DataMonitor) publish(s *Session, data []byte) {
+	if d == nil || len(data) == 0 {
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if len(d.subscribers) == 0 {
+		return
+	}
+
+	chunk := PortData{
+		PortName:  s.PortName,
+		SessionID: s.ID,
+		Data:      append([]byte(nil), data...),
+		Timestamp: time.Now(),
+	}
+	for ch := range d.subscribers {
+		select {
+		case ch <- chunk:
+		default:
+			// Subscriber is not keeping up, drop the data
+		}
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.bug.st/serial"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// networkDialTimeout bounds how long opening a network port waits for the
+// device server to accept the connection
+const networkDialTimeout = 5 * time.Second
+
+// ErrNoModemLines is returned for modem line status of a network port
+var ErrNoModemLines = errors.New("network ports have no modem lines")
+
+// NetworkPort is a serial device server, such as a Moxa NPort or an
+// ESP-Link, reached over the network and listed alongside local ports
+type NetworkPort struct {
+	Address     string // e.g. tcp://10.0.0.5:4001 or udp://239.0.0.1:5000
+	Description string
+}
+
+// SetNetworkPorts replaces the network ports the scanner lists
+func (s *Scanner) SetNetworkPorts(ports []NetworkPort) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.networkPorts = ports
+}
+
+// IsNetworkPort reports whether a port name is the address of a device
+// server, e.g. tcp://host:port, or of a UDP peer, e.g. udp://host:port
+func IsNetworkPort(name string) bool {
+	scheme, _, ok := strings.Cut(name, "://")
+	return ok && (strings.EqualFold(scheme, "tcp") || strings.EqualFold(scheme, "udp"))
+}
+
+// parseNetworkPort parses and checks a network port name. UDP ports may
+// give the local address to receive on as bind=[host]:port, and the
+// interface to join a multicast group on as iface=name.
+func parseNetworkPort(name string) (*url.URL, error) {
+	u, err := url.Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network port %s: %w", name, err)
+	}
+	if !IsNetworkPort(name) || u.Port() == "" || u.Hostname() == "" || (u.Path != "" && u.Path != "/") {
+		return nil, fmt.Errorf("invalid network port %s: must be of the form tcp://host:port or udp://host:port", name)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	for key := range u.Query() {
+		if u.Scheme != "udp" || (key != "bind" && key != "iface") {
+			return nil, fmt.Errorf("invalid network port %s: unknown option %s", name, key)
+		}
+	}
+	return u, nil
+}
+
+// canonicalNetworkName lower-cases the scheme and host of a network port
+// name, leaving its options alone
+func canonicalNetworkName(name string) string {
+	u, err := parseNetworkPort(name)
+	if err != nil {
+		return name
+	}
+	u.Host = strings.ToLower(u.Host)
+	return u.String()
+}
+
+// openNetwork connects to a device server, or opens a UDP socket. Line
+// settings are left to the other end, so the port accepts any
+// configuration.
+func openNetwork(portName string) (serial.Port, bool, error) {
+	u, err := parseNetworkPort(portName)
+	if err != nil {
+		return nil, false, err
+	}
+	if u.Scheme == "udp" {
+		return openUDP(u)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, networkDialTimeout)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open port: %w", err)
+	}
+	return &netPort{conn: conn}, false, nil
+}
+
+// openUDP opens a socket sending datagrams to the port's address. Replies
+// are received on the bind address, an ephemeral port by default; for a
+// multicast group the socket joins the group and receives what is sent to
+// it, apart from its own datagrams.
+func openUDP(u *url.URL) (serial.Port, bool, error) {
+	target, err := net.ResolveUDPAddr("udp", u.Host)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open port: %w", err)
+	}
+
+	var conn *net.UDPConn
+	if target.IP.IsMulticast() {
+		var ifi *net.Interface
+		if name := u.Query().Get("iface"); name != "" {
+			if ifi, err = net.InterfaceByName(name); err != nil {
+				return nil, false, fmt.Errorf("failed to open port: %w", err)
+			}
+		}
+		if conn, err = net.ListenMulticastUDP("udp", ifi, target); err != nil {
+			return nil, false, fmt.Errorf("failed to join %s: %w", target, err)
+		}
+		if target.IP.To4() != nil {
+			ipv4.NewPacketConn(conn).SetMulticastLoopback(false)
+		} else {
+			ipv6.NewPacketConn(conn).SetMulticastLoopback(false)
+		}
+	} else {
+		local := &net.UDPAddr{}
+		if bind := u.Query().Get("bind"); bind != "" {
+			if local, err = net.ResolveUDPAddr("udp", bind); err != nil {
+				return nil, false, fmt.Errorf("invalid bind address %s: %w", bind, err)
+			}
+		}
+		if conn, err = net.ListenUDP("udp", local); err != nil {
+			return nil, false, fmt.Errorf("failed to open port: %w", err)
+		}
+	}
+	return &netPort{conn: conn, target: target}, false, nil
+}
+
+// netPort presents a connection to a device server, or a UDP socket, as a
+// serial port
+type netPort struct {
+	conn        net.Conn
+	target      net.Addr     // Where datagrams are sent; nil for connections
+	readTimeout atomic.Int64 // Nanoseconds; 0 or less blocks until data arrives
+}
+
+// keepsPackets reports whether each read from a port returns one datagram,
+// whose boundaries are kept in the data streamed to clients
+func keepsPackets(port serial.Port) bool {
+	p, ok := port.(*netPort)
+	return ok && p.target != nil
+}
+
+// Read reads from the connection, or reads one datagram; like a serial port
+// it returns no data and no error when the read timeout passes
+func (p *netPort) Read(b []byte) (int, error) {
+	var deadline time.Time
+	if t := time.Duration(p.readTimeout.Load()); t > 0 {
+		deadline = time.Now().Add(t)
+	}
+	p.conn.SetReadDeadline(deadline)
+
+	n, err := p.conn.Read(b)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return n, nil
+	}
+	return n, err
+}
+
+// Write writes to the connection, or sends the data as one datagram
+func (p *netPort) Write(b []byte) (int, error) {
+	if p.target != nil {
+		return p.conn.(net.PacketConn).WriteTo(b, p.target)
+	}
+	return p.conn.Write(b)
+}
+
+// SetReadTimeout sets the timeout of later reads
+func (p *netPort) SetReadTimeout(t time.Duration) error {
+	p.readTimeout.Store(int64(t))
+	return nil
+}
+
+// Close closes the connection
+func (p *netPort) Close() error {
+	return p.conn.Close()
+}
+
+// SetMode is accepted and ignored; the device server's own configuration
+// sets the line
+func (p *netPort) SetMode(mode *serial.Mode) error { return nil }
+
+// Drain returns at once, as writes have already been handed to the network
+func (p *netPort) Drain() error { return nil }
+
+// ResetInputBuffer is accepted and ignored; the server's buffers are not
+// reachable
+func (p *netPort) ResetInputBuffer() error { return nil }
+
+// ResetOutputBuffer is accepted and ignored; the server's buffers are not
+// reachable
+func (p *netPort) ResetOutputBuffer() error { return nil }
+
+// SetDTR is accepted and ignored
+func (p *netPort) SetDTR(dtr bool) error { return nil }
+
+// SetRTS is accepted and ignored
+func (p *netPort) SetRTS(rts bool) error { return nil }
+
+// GetModemStatusBits fails, as the modem lines are not visible
+func (p *netPort) GetModemStatusBits() (*serial.ModemStatusBits, error) {
+	return nil, ErrNoModemLines
+}
+
+// Break fails, as a break cannot be sent over the network
+func (p *netPort) Break(d time.Duration) error {
+	return fmt.Errorf("break is not supported on network ports")
+}
+
+// networkPortInfos lists the configured network ports that pass the
+// scanner's filters
+func (s *Scanner) networkPortInfos() []PortInfo {
+	s.mu.RLock()
+	configured := s.networkPorts
+	s.mu.RUnlock()
+
+	var ports []PortInfo
+	for _, np := range configured {
+		info := PortInfo{
+			Name:        CanonicalPortName(np.Address),
+			Description: np.Description,
+			PortType:    PortTypeNetwork,
+		}
+		if info.Description == "" {
+			info.Description = "Network serial device"
+		}
+		if s.isExcluded(info.Name) || s.isFiltered(info) {
+			continue
+		}
+		info.Alias = s.aliasFor(info)
+
+		if s.manager != nil {
+			if session := s.manager.GetSession(info.Name); session != nil {
+				info.IsOpen = true
+				info.LockedBy = session.ClientID
+			}
+		}
+		ports = append(ports, info)
+	}
+	return ports
+}
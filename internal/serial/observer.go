@@ -0,0 +1,37 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// SessionObserver is told when sessions and taps start and end, e.g. to
+// keep a history of them. Methods are called with the manager's lock held,
+// so they must not block or call back into the manager. A takeover ends the
+// previous owner's session and starts the new owner's on the same port;
+// statistics carry over, so observers count bytes from their value when a
+// session started.
+type SessionObserver interface {
+	SessionOpened(s *Session)
+	SessionClosed(s *Session)
+	TapAdded(s *Session, t *Tap)
+	TapRemoved(s *Session, t *Tap)
+}
+
+// SetSessionObserver sets the observer told about sessions and taps
+func (m *Manager) SetSessionObserver(o SessionObserver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observer = o
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// SetOwner binds a session or attachment ID to the identity that opened it.
+// The ID of a managed session is shared by all its clients and stays unbound.
+func (s *Session) SetOwner(id string, owner string) {
+	s.attachMu.Lock()
+	defer s.attachMu.Unlock()
+
+	if id == s.ID {
+		if !s.Managed {
+			s.owner = owner
+		}
+		return
+	}
+	if att, ok := s.attachments[id]; ok {
+		att.owner = owner
+	}
+}
+
+// usableBy reports whether the identity named owner may use a session or
+// attachment ID. The ID of a managed session is usable by every client;
+// other IDs only by the identity bound to them, so IDs opened by the agent
+// itself are never usable. IDs naming neither the session nor one of its
+// attachments are reported usable, leaving callers to reject them as invalid.
+func (s *Session) usableBy(id string, owner string) bool {
+	s.attachMu.RLock()
+	defer s.attachMu.RUnlock()
+
+	if id == s.ID {
+		return s.Managed || s.owner == owner
+	}
+	if att, ok := s.attachments[id]; ok {
+		return att.owner == owner
+	}
+	return true
+}
+
+// SessionUsableBy reports whether the identity named owner may use a session
+// or attachment ID of the port's session
+func (m *Manager) SessionUsableBy(portName string, id string, owner string) bool {
+	portName = CanonicalPortName(portName)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, exists := m.sessions[portName]
+	if !exists {
+		session = m.sessionsByID[id]
+		if session == nil || session.openedAs != portName {
+			return true
+		}
+	}
+
+	return session.usableBy(id, owner)
+}
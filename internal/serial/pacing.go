@@ -0,0 +1,88 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"time"
+)
+
+// paced reports whether writes on the port must be split and delayed
+func (c PortConfig) paced() bool {
+	return c.WriteChunkSize > 0 || c.WriteChunkDelayMs > 0 || c.WriteLineDelayMs > 0
+}
+
+// writeData writes data to the port, applying the configured pacing and
+// RS-485 driver control
+// (must be called with the session lock held)
+func (s *Session) writeData(data []byte) (int, error) {
+	return s.keyed(s.writePaced, data)
+}
+
+// writePaced writes data to the port in paced chunks. Each chunk is drained
+// to the wire before the delay so the device sees the gap.
+func (s *Session) writePaced(data []byte) (int, error) {
+	if !s.Config.paced() {
+		return s.portWrite(data)
+	}
+
+	chunkDelay := time.Duration(s.Config.WriteChunkDelayMs) * time.Millisecond
+	lineDelay := time.Duration(s.Config.WriteLineDelayMs) * time.Millisecond
+
+	written := 0
+	for len(data) > 0 {
+		chunk := nextChunk(data, s.Config.WriteChunkSize, lineDelay > 0)
+		data = data[len(chunk):]
+
+		n, err := s.portWrite(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if len(data) == 0 {
+			break
+		}
+
+		delay := chunkDelay
+		if lineDelay > 0 && chunk[len(chunk)-1] == '\n' {
+			delay = lineDelay
+		}
+		if delay > 0 {
+			if err := s.port.Drain(); err != nil {
+				return written, err
+			}
+			time.Sleep(delay)
+		}
+	}
+
+	return written, nil
+}
+
+// nextChunk returns the next chunk of data to write: at most size bytes
+// (unlimited if size is 0), ending early after a newline if splitLines is set
+func nextChunk(data []byte, size int, splitLines bool) []byte {
+	end := len(data)
+	if size > 0 && size < end {
+		end = size
+	}
+	if splitLines {
+		if i := bytes.IndexByte(data[:end], '\n'); i >= 0 {
+			end = i + 1
+		}
+	}
+	return data[:end]
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/Shoaibashk/BaudLink/internal/pty"
+)
+
+// Passthrough errors
+var (
+	ErrPassthroughDisabled = errors.New("passthrough is disabled")
+	ErrPassthroughActive   = errors.New("port is in passthrough mode")
+	ErrPassthroughShared   = errors.New("passthrough requires an exclusive session")
+	ErrInvalidPassthrough  = errors.New("passthrough requires exactly one of a pty or TCP address")
+	ErrNoPassthrough       = errors.New("passthrough is not active")
+)
+
+// PassthroughOptions describes where a passthrough exposes the port.
+// Exactly one of PTY and TCPAddress must be set.
+type PassthroughOptions struct {
+	PTY        bool   // Expose the port as a pseudo-terminal on the agent
+	TCPAddress string // Listen on this host:port, e.g. 127.0.0.1:0
+}
+
+// PassthroughSettings holds the agent-wide limits for passthrough
+type PassthroughSettings struct {
+	Enabled  bool
+	AllowTCP bool
+}
+
+// Passthrough hands a session's port to a local program as raw bytes, for
+// pppd or SLIP daemons. While it runs the session's framer, write pacing,
+// and rate limit are bypassed and all other reads and writes on the session
+// fail with ErrPassthroughActive, so the program is the only reader.
+type Passthrough struct {
+	Options PassthroughOptions
+	Address string // Terminal path or listening TCP address
+	Started time.Time
+
+	session  *Session
+	term     *pty.PTY
+	listener net.Listener
+
+	// connMu guards conn, the connected TCP client
+	connMu sync.Mutex
+	conn   net.Conn
+
+	bytesIn  atomic.Uint64 // From the port to the program
+	bytesOut atomic.Uint64 // From the program to the port
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// BytesIn returns the number of bytes passed from the port to the program
+func (p *Passthrough) BytesIn() uint64 {
+	return p.bytesIn.Load()
+}
+
+// BytesOut returns the number of bytes passed from the program to the port
+func (p *Passthrough) BytesOut() uint64 {
+	return p.bytesOut.Load()
+}
+
+// SetPassthroughSettings configures whether and how ports may be passed
+// through
+func (m *Manager) SetPassthroughSettings(settings PassthroughSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.passthroughSettings = settings
+}
+
+// StartPassthrough exposes an exclusive session's port on a pseudo-terminal
+// or TCP listener of the agent
+func (m *Manager) StartPassthrough(portName string, sessionID string, opts PassthroughOptions) (*Passthrough, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.ID != sessionID || !session.Exclusive || session.Managed {
+		return nil, ErrPassthroughShared
+	}
+
+	m.mu.RLock()
+	settings := m.passthroughSettings
+	m.mu.RUnlock()
+
+	if !settings.Enabled {
+		return nil, ErrPassthroughDisabled
+	}
+	if opts.PTY == (opts.TCPAddress != "") {
+		return nil, ErrInvalidPassthrough
+	}
+	if opts.TCPAddress != "" && !settings.AllowTCP {
+		return nil, fmt.Errorf("TCP passthrough is disabled")
+	}
+
+	p := &Passthrough{
+		Options: opts,
+		Started: time.Now(),
+		session: session,
+		stop:    make(chan struct{}),
+	}
+
+	if opts.PTY {
+		p.term, err = pty.Open(passthroughPipeName(session.PortName))
+		if err != nil {
+			return nil, err
+		}
+		p.Address = p.term.Name()
+	} else {
+		p.listener, err = net.Listen("tcp", opts.TCPAddress)
+		if err != nil {
+			return nil, err
+		}
+		p.Address = p.listener.Addr().String()
+	}
+
+	if !session.passthrough.CompareAndSwap(nil, p) {
+		p.closeEndpoints()
+		return nil, ErrPassthroughActive
+	}
+
+	// The session's pump now hands received data to the program
+	p.wg.Add(1)
+	if p.term != nil {
+		go p.pumpTerminal()
+	} else {
+		go p.acceptLoop()
+	}
+
+	return p, nil
+}
+
+// StopPassthrough ends a session's passthrough and returns the port to
+// normal operation
+func (m *Manager) StopPassthrough(portName string, sessionID string) error {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return err
+	}
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return err
+	}
+
+	p := session.passthrough.Load()
+	if p == nil {
+		return ErrNoPassthrough
+	}
+	p.close()
+	return nil
+}
+
+// Passthrough returns the session's active passthrough, or nil
+func (s *Session) Passthrough() *Passthrough {
+	return s.passthrough.Load()
+}
+
+// checkPassthrough returns ErrPassthroughActive while the port is passed
+// through
+func (s *Session) checkPassthrough() error {
+	if s.passthrough.Load() != nil {
+		return ErrPassthroughActive
+	}
+	return nil
+}
+
+// stopPassthrough ends an active passthrough, e.g. when the session closes
+func (s *Session) stopPassthrough() {
+	if p := s.passthrough.Load(); p != nil {
+		p.close()
+	}
+}
+
+// close stops the pumps, closes the terminal or listener, and waits for the
+// pumps to finish before the session's normal I/O resumes
+func (p *Passthrough) close() {
+	p.once.Do(func() {
+		close(p.stop)
+		p.closeEndpoints()
+		p.wg.Wait()
+		p.session.passthrough.CompareAndSwap(p, nil)
+	})
+}
+
+// closeEndpoints closes the terminal, listener, and TCP client
+func (p *Passthrough) closeEndpoints() {
+	if p.term != nil {
+		p.term.Close()
+	}
+	if p.listener != nil {
+		p.listener.Close()
+	}
+	p.connMu.Lock()
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.connMu.Unlock()
+}
+
+// stopped reports whether the passthrough has been stopped
+func (p *Passthrough) stopped() bool {
+	select {
+	case <-p.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// receive passes data read by the session's pump to the program. Data
+// received while no TCP client is connected is discarded, as on an
+// unconnected line.
+func (p *Passthrough) receive(data []byte) {
+	s := p.session
+	s.Statistics.BytesReceived.Add(uint64(len(data)))
+	s.lastReceived.Store(time.Now().UnixNano())
+	s.tapData(TapRX, data)
+
+	var w io.Writer = p.term
+	if p.term == nil {
+		p.connMu.Lock()
+		conn := p.conn
+		p.connMu.Unlock()
+		if conn == nil {
+			return
+		}
+		w = conn
+	}
+	if _, err := w.Write(data); err == nil {
+		p.bytesIn.Add(uint64(len(data)))
+	}
+}
+
+// pumpTerminal copies data written to the terminal to the port
+func (p *Passthrough) pumpTerminal() {
+	defer p.wg.Done()
+	p.copyToPort(p.term)
+}
+
+// acceptLoop serves one TCP client at a time; further clients are refused
+// so the port keeps a single reader
+func (p *Passthrough) acceptLoop() {
+	defer p.wg.Done()
+
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		p.connMu.Lock()
+		busy := p.conn != nil
+		if !busy {
+			p.conn = conn
+		}
+		p.connMu.Unlock()
+
+		if busy {
+			conn.Close()
+			continue
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.copyToPort(conn)
+
+			p.connMu.Lock()
+			p.conn = nil
+			p.connMu.Unlock()
+			conn.Close()
+		}()
+	}
+}
+
+// copyToPort writes everything read from r to the port until r fails or the
+// passthrough stops
+func (p *Passthrough) copyToPort(r io.Reader) {
+	s := p.session
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 && !p.stopped() {
+			s.mu.Lock()
+			_, werr := s.keyed(s.port.Write, buf[:n])
+			s.mu.Unlock()
+			if werr != nil {
+				s.Statistics.Errors.Add(1)
+				return
+			}
+			s.recordSent(buf[:n])
+			p.bytesOut.Add(uint64(n))
+		}
+		if err != nil || p.stopped() {
+			return
+		}
+	}
+}
+
+// passthroughPipeName derives a named pipe name from a port name, used where
+// terminals are named pipes
+func passthroughPipeName(portName string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '-'
+	}, portName)
+	return "baudlink-passthrough-" + strings.Trim(name, "-")
+}
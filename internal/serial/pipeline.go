@@ -0,0 +1,256 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Filter transforms data flowing through a session. Filters may keep state
+// between calls, so each instance serves a single stream.
+type Filter interface {
+	// Apply transforms the next chunk of the stream
+	Apply(data []byte) ([]byte, error)
+}
+
+// Pipeline is an ordered list of filters applied to a stream
+type Pipeline []Filter
+
+// NewPipeline creates a pipeline from filter specifications, applied in
+// order. An empty list returns a nil pipeline, which leaves data unchanged.
+func NewPipeline(specs []string) (Pipeline, error) {
+	var p Pipeline
+	for _, spec := range specs {
+		f, err := NewFilter(spec)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, f)
+	}
+	return p, nil
+}
+
+// Apply runs data through every filter of the pipeline
+func (p Pipeline) Apply(data []byte) ([]byte, error) {
+	for _, f := range p {
+		var err error
+		if data, err = f.Apply(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// Filters lists the names of the available filters
+var Filters = []string{"strip_ansi", "eol", "hex", "unhex", "gzip", "replace"}
+
+// NewFilter creates a filter from its specification, a name optionally
+// followed by a colon and an argument:
+//
+//	strip_ansi        remove ANSI escape sequences
+//	eol:lf|crlf|cr    normalize line endings
+//	hex               encode bytes as uppercase hex digits
+//	unhex             decode hex digits, ignoring whitespace
+//	gzip              compress the stream, flushing after every chunk
+//	replace:XX=YY..   replace byte XX with the bytes YY.. (hex, may be empty)
+func NewFilter(spec string) (Filter, error) {
+	name, arg, _ := strings.Cut(spec, ":")
+
+	switch name {
+	case "strip_ansi":
+		return &ansiFilter{}, nil
+	case "eol":
+		eol, ok := map[string]string{"lf": "\n", "crlf": "\r\n", "cr": "\r"}[arg]
+		if !ok {
+			return nil, fmt.Errorf("invalid line ending in filter %s", spec)
+		}
+		return &eolFilter{eol: []byte(eol)}, nil
+	case "hex":
+		return hexFilter{}, nil
+	case "unhex":
+		return &unhexFilter{}, nil
+	case "gzip":
+		f := &gzipFilter{}
+		f.w = gzip.NewWriter(&f.buf)
+		return f, nil
+	case "replace":
+		from, to, ok := strings.Cut(arg, "=")
+		fromBytes, err := hex.DecodeString(from)
+		if !ok || err != nil || len(fromBytes) != 1 {
+			return nil, fmt.Errorf("filter %s must replace a single hex byte", spec)
+		}
+		toBytes, err := hex.DecodeString(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replacement in filter %s", spec)
+		}
+		return replaceFilter{from: fromBytes[0], to: toBytes}, nil
+	default:
+		return nil, fmt.Errorf("unknown filter: %s", spec)
+	}
+}
+
+// ansiState tracks a partially received escape sequence
+type ansiState int
+
+const (
+	ansiText ansiState = iota
+	ansiEscape
+	ansiCSI
+	ansiOSC
+	ansiOSCEscape
+)
+
+// ansiFilter removes CSI (ESC [ ... final) and OSC (ESC ] ... BEL or ST)
+// sequences and two-byte escapes, including sequences split across chunks
+type ansiFilter struct {
+	state ansiState
+}
+
+func (f *ansiFilter) Apply(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+
+	for _, b := range data {
+		switch f.state {
+		case ansiText:
+			if b == 0x1b {
+				f.state = ansiEscape
+			} else {
+				out = append(out, b)
+			}
+		case ansiEscape:
+			switch b {
+			case '[':
+				f.state = ansiCSI
+			case ']':
+				f.state = ansiOSC
+			default:
+				f.state = ansiText
+			}
+		case ansiCSI:
+			if b >= 0x40 && b <= 0x7e {
+				f.state = ansiText
+			}
+		case ansiOSC:
+			if b == 0x07 {
+				f.state = ansiText
+			} else if b == 0x1b {
+				f.state = ansiOSCEscape
+			}
+		case ansiOSCEscape:
+			if b == '\\' {
+				f.state = ansiText
+			} else {
+				f.state = ansiOSC
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// eolFilter rewrites CR, LF, and CRLF line endings to a single style
+type eolFilter struct {
+	eol       []byte
+	pendingCR bool // The last chunk ended in CR, so a leading LF is its pair
+}
+
+func (f *eolFilter) Apply(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+
+	for _, b := range data {
+		switch {
+		case b == '\r':
+			out = append(out, f.eol...)
+			f.pendingCR = true
+			continue
+		case b == '\n' && f.pendingCR:
+		case b == '\n':
+			out = append(out, f.eol...)
+		default:
+			out = append(out, b)
+		}
+		f.pendingCR = false
+	}
+
+	return out, nil
+}
+
+// hexFilter encodes bytes as uppercase hex digits
+type hexFilter struct{}
+
+func (hexFilter) Apply(data []byte) ([]byte, error) {
+	return []byte(fmt.Sprintf("%X", data)), nil
+}
+
+// unhexFilter decodes hex digits, keeping an odd trailing digit for the
+// next chunk
+type unhexFilter struct {
+	pending []byte
+}
+
+func (f *unhexFilter) Apply(data []byte) ([]byte, error) {
+	digits := f.pending
+	for _, b := range data {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		digits = append(digits, b)
+	}
+
+	n := len(digits) &^ 1
+	out := make([]byte, n/2)
+	if _, err := hex.Decode(out, digits[:n]); err != nil {
+		f.pending = nil
+		return nil, fmt.Errorf("unhex filter: %w", err)
+	}
+	f.pending = append([]byte(nil), digits[n:]...)
+	return out, nil
+}
+
+// gzipFilter compresses the stream as a single gzip member, flushing after
+// every chunk so the receiver can decompress data as it arrives
+type gzipFilter struct {
+	buf bytes.Buffer
+	w   *gzip.Writer
+}
+
+func (f *gzipFilter) Apply(data []byte) ([]byte, error) {
+	if _, err := f.w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := f.w.Flush(); err != nil {
+		return nil, err
+	}
+	out := append([]byte(nil), f.buf.Bytes()...)
+	f.buf.Reset()
+	return out, nil
+}
+
+// replaceFilter substitutes every occurrence of a byte
+type replaceFilter struct {
+	from byte
+	to   []byte
+}
+
+func (f replaceFilter) Apply(data []byte) ([]byte, error) {
+	return bytes.ReplaceAll(data, []byte{f.from}, f.to), nil
+}
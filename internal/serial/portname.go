@@ -0,0 +1,41 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// CanonicalPortName returns the form of a port name sessions are keyed by
+// and reported under, so differently typed names of the same port match.
+// On Windows the \\.\ device namespace prefix is removed and names are
+// matched case-insensitively (com3, COM3, and \\.\COM3 are all COM3); on
+// other systems device paths are cleaned. Bluetooth addresses are
+// upper-cased and network addresses lower-cased.
+func CanonicalPortName(name string) string {
+	if name == "" {
+		return ""
+	}
+	if IsBluetoothPort(name) {
+		return canonicalBluetoothName(name)
+	}
+	if IsNetworkPort(name) {
+		return canonicalNetworkName(name)
+	}
+	return canonicalPortName(name)
+}
+
+// SamePort reports whether two port names refer to the same port
+func SamePort(a, b string) bool {
+	return CanonicalPortName(a) == CanonicalPortName(b)
+}
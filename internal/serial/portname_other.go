@@ -0,0 +1,38 @@
+//go:build !windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"path"
+	"strings"
+)
+
+// canonicalPortName cleans device paths, so /dev//ttyUSB0 and /dev/ttyUSB0
+// name the same port. Other names are case-sensitive and left alone.
+func canonicalPortName(name string) string {
+	if strings.HasPrefix(name, "/") {
+		return path.Clean(name)
+	}
+	return name
+}
+
+// devicePath returns the name go.bug.st/serial is given to open a port
+func devicePath(name string) string {
+	return name
+}
@@ -0,0 +1,47 @@
+//go:build windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import "strings"
+
+// deviceNamespaces are the prefixes of Win32 device paths
+var deviceNamespaces = []string{`\\.\`, `\\?\`}
+
+// canonicalPortName strips the device namespace and upper-cases device
+// names, which Windows treats case-insensitively. Names with path or
+// scheme separators are left alone.
+func canonicalPortName(name string) string {
+	for _, prefix := range deviceNamespaces {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			name = name[len(prefix):]
+			break
+		}
+	}
+	if strings.ContainsAny(name, `/\:`) {
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
+// devicePath returns the name go.bug.st/serial is given to open a port. The
+// library opens \\.\<name> itself, the form COM10 and above require, so a
+// prefix typed by the client is dropped rather than doubled.
+func devicePath(name string) string {
+	return canonicalPortName(name)
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Proxy errors
+var (
+	ErrProxySamePort = errors.New("a port cannot be proxied to itself")
+	ErrProxyStopped  = errors.New("proxy stopped")
+)
+
+// proxyQueueDepth bounds the chunks waiting to be forwarded in each
+// direction; a full queue holds up the port's reads
+const proxyQueueDepth = 256
+
+// proxyEventBuffer is how many captured chunks wait for the client before
+// further ones are dropped
+const proxyEventBuffer = 1024
+
+// ProxyDirection is the direction data travels through a proxy
+type ProxyDirection int
+
+const (
+	ProxyAToB ProxyDirection = iota
+	ProxyBToA
+)
+
+// String returns the string representation of ProxyDirection
+func (d ProxyDirection) String() string {
+	if d == ProxyBToA {
+		return "B>A"
+	}
+	return "A>B"
+}
+
+// ProxyOptions configures a proxy between two ports
+type ProxyOptions struct {
+	ClientID   string
+	Config     PortConfig // Configuration of port A
+	PeerConfig PortConfig // Configuration of port B
+
+	// Filter pipelines applied to data before it is forwarded, so traffic
+	// can be modified in flight
+	FiltersAToB []string
+	FiltersBToA []string
+
+	// Latency added to data before it is forwarded
+	DelayAToB time.Duration
+	DelayBToA time.Duration
+}
+
+// ProxyEvent is a chunk forwarded by a proxy
+type ProxyEvent struct {
+	Direction   ProxyDirection
+	Data        []byte    // Bytes as received
+	Forwarded   []byte    // Bytes written to the other port, set when filters changed them
+	FilterError error     // The filters rejected the chunk, which was not forwarded
+	Time        time.Time // When the chunk was received
+	Sequence    uint64
+	Lost        uint64 // Events dropped before this one because the consumer fell behind
+}
+
+// Proxy connects two ports back to back through the agent: everything one
+// port receives is written to the other. The proxy owns exclusive sessions
+// on both ports and closes them when it stops. Clients may still sniff
+// either session.
+type Proxy struct {
+	A, B    *Session
+	Started time.Time
+
+	manager *Manager
+	legs    [2]*proxyLeg
+	events  chan ProxyEvent
+	seq     atomic.Uint64
+	lost    atomic.Uint64
+
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+	err  error // Set before done is closed
+}
+
+// proxyLeg forwards one direction of a proxy
+type proxyLeg struct {
+	proxy     *Proxy
+	direction ProxyDirection
+	from, to  *Session
+	filters   Pipeline
+	delay     time.Duration
+	queue     chan ProxyEvent
+	bytes     atomic.Uint64
+}
+
+// StartProxy opens two ports exclusively and forwards data between them
+// until the proxy is stopped or either port fails
+func (m *Manager) StartProxy(portA, portB string, opts ProxyOptions) (*Proxy, error) {
+	if SamePort(portA, portB) {
+		return nil, ErrProxySamePort
+	}
+
+	filtersAToB, err := NewPipeline(opts.FiltersAToB)
+	if err != nil {
+		return nil, err
+	}
+	filtersBToA, err := NewPipeline(opts.FiltersBToA)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := m.OpenPort(portA, opts.Config, opts.ClientID, true, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", portA, err)
+	}
+	b, err := m.OpenPort(portB, opts.PeerConfig, opts.ClientID, true, 0)
+	if err != nil {
+		m.ClosePort(a.PortName, a.ID)
+		return nil, fmt.Errorf("%s: %w", portB, err)
+	}
+
+	p := &Proxy{
+		A:       a,
+		B:       b,
+		Started: time.Now(),
+		manager: m,
+		events:  make(chan ProxyEvent, proxyEventBuffer),
+		done:    make(chan struct{}),
+	}
+	p.legs[ProxyAToB] = p.newLeg(ProxyAToB, a, b, filtersAToB, opts.DelayAToB)
+	p.legs[ProxyBToA] = p.newLeg(ProxyBToA, b, a, filtersBToA, opts.DelayBToA)
+
+	// The sessions' pumps now hand received data to the legs
+	for _, leg := range p.legs {
+		p.wg.Add(1)
+		go leg.run()
+		leg.from.proxy.Store(leg)
+	}
+
+	return p, nil
+}
+
+// newLeg creates the leg forwarding data from one session to another
+func (p *Proxy) newLeg(direction ProxyDirection, from, to *Session, filters Pipeline, delay time.Duration) *proxyLeg {
+	return &proxyLeg{
+		proxy:     p,
+		direction: direction,
+		from:      from,
+		to:        to,
+		filters:   filters,
+		delay:     delay,
+		queue:     make(chan ProxyEvent, proxyQueueDepth),
+	}
+}
+
+// Events returns the chunks forwarded by the proxy. Chunks are dropped,
+// and counted in the next event's Lost, when the consumer falls behind.
+func (p *Proxy) Events() <-chan ProxyEvent {
+	return p.events
+}
+
+// Done is closed when the proxy stops
+func (p *Proxy) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns why the proxy stopped, or nil while it runs
+func (p *Proxy) Err() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return nil
+	}
+}
+
+// Bytes returns the number of bytes forwarded in a direction
+func (p *Proxy) Bytes(direction ProxyDirection) uint64 {
+	return p.legs[direction].bytes.Load()
+}
+
+// Stop stops forwarding and closes both sessions
+func (p *Proxy) Stop() {
+	p.stop(ErrProxyStopped)
+}
+
+// stop ends the proxy with the given reason. Both sessions are closed once
+// the legs have finished.
+func (p *Proxy) stop(reason error) {
+	p.once.Do(func() {
+		p.err = reason
+		close(p.done)
+		for _, leg := range p.legs {
+			leg.from.proxy.CompareAndSwap(leg, nil)
+		}
+		p.wg.Wait()
+		p.manager.ClosePort(p.A.PortName, p.A.ID)
+		p.manager.ClosePort(p.B.PortName, p.B.ID)
+	})
+}
+
+// stopProxy ends the session's proxy, e.g. when the session is closed. The
+// proxy closes the other session, so it stops in the background.
+func (s *Session) stopProxy(reason error) {
+	if leg := s.proxy.Load(); leg != nil {
+		go leg.proxy.stop(reason)
+	}
+}
+
+// emit hands an event to the consumer without blocking the legs
+func (p *Proxy) emit(event ProxyEvent) {
+	event.Sequence = p.seq.Add(1)
+	event.Lost = p.lost.Swap(0)
+	select {
+	case p.events <- event:
+	default:
+		p.lost.Add(event.Lost + 1)
+	}
+}
+
+// receive queues data read by the session's pump for forwarding
+func (l *proxyLeg) receive(data []byte, at time.Time) {
+	l.from.recordReceived(data)
+	select {
+	case l.queue <- ProxyEvent{Direction: l.direction, Data: bytes.Clone(data), Time: at}:
+	case <-l.proxy.done:
+	}
+}
+
+// run forwards queued data to the other port, after the leg's delay and
+// filters, until the proxy stops or a write fails
+func (l *proxyLeg) run() {
+	defer l.proxy.wg.Done()
+
+	for {
+		var event ProxyEvent
+		select {
+		case <-l.proxy.done:
+			return
+		case event = <-l.queue:
+		}
+
+		if wait := time.Until(event.Time.Add(l.delay)); l.delay > 0 && wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-l.proxy.done:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		out := event.Data
+		if l.filters != nil {
+			filtered, err := l.filters.Apply(event.Data)
+			if err != nil {
+				event.FilterError = err
+				l.proxy.emit(event)
+				continue
+			}
+			if !bytes.Equal(filtered, event.Data) {
+				event.Forwarded = filtered
+			}
+			out = filtered
+		}
+
+		if len(out) > 0 {
+			to := l.to
+			to.mu.Lock()
+			_, err := to.keyed(to.port.Write, out)
+			to.mu.Unlock()
+			if err != nil {
+				to.Statistics.Errors.Add(1)
+				log.Printf("proxy %s -> %s stopped: %v", l.from.PortName, to.PortName, err)
+				go l.proxy.stop(fmt.Errorf("write to %s failed: %w", to.PortName, err))
+				return
+			}
+			to.recordSent(out)
+			l.bytes.Add(uint64(len(out)))
+		}
+
+		l.proxy.emit(event)
+	}
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+
+	"github.com/Shoaibashk/BaudLink/internal/crash"
+)
+
+// readChunkSize is the size of the buffers received data is read into
+const readChunkSize = 4096
+
+// receiveBufferSize is the receive buffer of sessions opened by clients
+const receiveBufferSize = DefaultManagedBufferSize
+
+// streamPollInterval bounds each buffer read of a stream so it notices
+// being stopped and its session ending
+const streamPollInterval = 100 * time.Millisecond
+
+// chunkPool recycles read buffers so reads do not allocate a buffer per call
+var chunkPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, readChunkSize)
+		return &b
+	},
+}
+
+// pump is the only reader of a session's port. It moves received data into
+// the session's and its attachments' buffers, to the program while the port
+// is passed through, or to the other port while it is proxied, until the port fails or is closed.
+func (m *Manager) pump(session *Session, port serial.Port) {
+	// A panic fails only this session rather than the whole agent
+	defer func() {
+		if v := recover(); v != nil {
+			m.pumpFailed(session, crash.Report("pump", v, "port", session.PortName, "session", session.ID))
+		}
+	}()
+
+	buf := chunkPool.Get().(*[]byte)
+	defer chunkPool.Put(buf)
+
+	for !session.closed.Load() {
+		n, err := port.Read(*buf)
+		if err != nil {
+			m.pumpFailed(session, err)
+			return
+		}
+		if n == 0 {
+			continue
+		}
+
+		at := time.Now()
+		data := (*buf)[:n]
+		if p := session.passthrough.Load(); p != nil && !p.stopped() {
+			p.receive(data)
+			continue
+		}
+		if leg := session.proxy.Load(); leg != nil {
+			// Sniffers still see what the proxied port receives
+			leg.receive(data, at)
+			session.distribute(data, at)
+			session.Statistics.Touch()
+			continue
+		}
+
+		session.recordReceived(data)
+		session.buffer.WriteAt(data, at)
+		session.distribute(data, at)
+		session.Statistics.Touch()
+	}
+}
+
+// pumpFailed handles a read error of a session's port. Managed sessions keep
+// serving buffered data until the supervisor reopens them, and reconnecting
+// sessions are suspended until their device returns. Other sessions fail
+// their readers with the error once buffered data has been read.
+func (m *Manager) pumpFailed(session *Session, err error) {
+	// The port was closed on purpose, or released after the device was lost
+	if session.closed.Load() || session.IsDisconnected() {
+		return
+	}
+
+	if session.Managed {
+		session.disconnected.Store(true)
+		log.Printf("Managed port %s disconnected: %v", session.PortName, err)
+		return
+	}
+
+	session.Statistics.Errors.Add(1)
+	if p := session.passthrough.Load(); p != nil {
+		log.Printf("passthrough on %s stopped: %v", session.PortName, err)
+		go p.close()
+	}
+	if leg := session.proxy.Load(); leg != nil {
+		log.Printf("proxy on %s stopped: %v", session.PortName, err)
+		go leg.proxy.stop(fmt.Errorf("%s failed: %w", session.PortName, err))
+	}
+
+	if m.handleDeviceLoss(session, err) == ErrPortDisconnected {
+		return
+	}
+	session.buffer.CloseWithError(err)
+}
+
+// stream fans the data received for a session or attachment ID out to every
+// Reader of that ID, so concurrent streams share one pump instead of each
+// competing for the data
+type stream struct {
+	manager  *Manager
+	portName string
+	id       string // Session or attachment ID the data is read for
+
+	mu      sync.Mutex
+	readers map[*Reader]struct{}
+	stop    chan struct{}
+}
+
+// joinStream adds a reader to the stream for id, starting the stream's pump
+// if the reader is the first
+func (s *Session) joinStream(m *Manager, portName, id string, r *Reader) *stream {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+
+	st, exists := s.streams[id]
+	if !exists {
+		st = &stream{
+			manager:  m,
+			portName: portName,
+			id:       id,
+			readers:  make(map[*Reader]struct{}),
+			stop:     make(chan struct{}),
+		}
+		if s.streams == nil {
+			s.streams = make(map[string]*stream)
+		}
+		s.streams[id] = st
+		go st.run(s)
+	}
+
+	st.mu.Lock()
+	st.readers[r] = struct{}{}
+	st.mu.Unlock()
+
+	return st
+}
+
+// leaveStream removes a reader from its stream, stopping the stream's pump
+// once no readers remain
+func (s *Session) leaveStream(st *stream, r *Reader) {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+
+	st.mu.Lock()
+	delete(st.readers, r)
+	empty := len(st.readers) == 0
+	st.mu.Unlock()
+
+	if empty && s.streams[st.id] == st {
+		delete(s.streams, st.id)
+		close(st.stop)
+	}
+}
+
+// streamSubscribers returns the number of subscriptions to the session's
+// streams
+func (s *Session) streamSubscribers() int {
+	s.streamsMu.Lock()
+	defer s.streamsMu.Unlock()
+
+	count := 0
+	for _, st := range s.streams {
+		for _, r := range st.list() {
+			count += r.SubscriberCount()
+		}
+	}
+	return count
+}
+
+// list returns the stream's readers
+func (st *stream) list() []*Reader {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	readers := make([]*Reader, 0, len(st.readers))
+	for r := range st.readers {
+		readers = append(readers, r)
+	}
+	return readers
+}
+
+// run reads the stream's buffer and delivers the data to its readers until
+// the stream is stopped or the session ends. Each chunk is copied once and
+// shared by every reader.
+func (st *stream) run(session *Session) {
+	buf := chunkPool.Get().(*[]byte)
+	defer chunkPool.Put(buf)
+
+	var suspended bool
+
+	for {
+		select {
+		case <-st.stop:
+			return
+		default:
+		}
+
+		if _, err := st.manager.ValidateSession(st.portName, st.id); err != nil {
+			st.fail(session, err)
+			return
+		}
+		if err := session.checkPassthrough(); err != nil {
+			st.fail(session, err)
+			return
+		}
+
+		// A reconnecting session is suspended until its device returns;
+		// mark the gap in the stream once it is back
+		if !session.Managed && session.IsDisconnected() {
+			suspended = true
+			select {
+			case <-st.stop:
+				return
+			case <-time.After(streamPollInterval):
+			}
+			continue
+		}
+		if suspended {
+			suspended = false
+			st.deliver(DataEvent{Timestamp: time.Now(), Gap: true})
+		}
+
+		att := session.attachment(st.id)
+
+		session.readMu.Lock()
+		n, received, err := session.readInputTimed(att, *buf, streamPollInterval)
+		session.readMu.Unlock()
+		if err != nil {
+			st.fail(session, err)
+			return
+		}
+		if n == 0 {
+			continue
+		}
+		att.recordReceived(n)
+		if !received.First.IsZero() {
+			session.Statistics.readLatency.record(time.Since(received.First))
+		}
+
+		data := bytes.Clone((*buf)[:n])
+
+		session.mu.Lock()
+		session.Statistics.Touch()
+		data, err = session.readPipeline(att).Apply(data)
+		session.mu.Unlock()
+
+		if err != nil {
+			st.deliver(DataEvent{Timestamp: time.Now(), Error: err})
+			continue
+		}
+		if len(data) > 0 {
+			st.deliver(DataEvent{Data: data, Timestamp: time.Now(), Received: received})
+		}
+	}
+}
+
+// deliver passes an event to every reader of the stream
+func (st *stream) deliver(event DataEvent) {
+	for _, r := range st.list() {
+		r.deliver(event)
+	}
+}
+
+// fail delivers a final error to the stream's readers and stops them
+func (st *stream) fail(session *Session, err error) {
+	session.streamsMu.Lock()
+	if session.streams[st.id] == st {
+		delete(session.streams, st.id)
+	}
+	session.streamsMu.Unlock()
+
+	event := DataEvent{Timestamp: time.Now(), Error: err}
+	for _, r := range st.list() {
+		r.deliver(event)
+		r.Stop()
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrQueueTimeout is returned when a port stays locked for as long as a
+// client was willing to wait in its queue
+var ErrQueueTimeout = errors.New("timed out waiting for port")
+
+// portWaiter is a client waiting in a port's queue
+type portWaiter struct {
+	clientID string
+	wake     chan struct{} // Signalled when the port may have become free
+}
+
+// OpenPortQueued opens a port like OpenPortWithRetry. When the port is
+// locked and wait is positive, the client joins the port's queue instead of
+// failing, and is given the port once the clients ahead of it have been
+// served. Clients that do not wait cannot take a port while others queue
+// for it. Queue position changes are published as EventQueuePosition.
+func (m *Manager) OpenPortQueued(ctx context.Context, portName string, config PortConfig, clientID string, exclusive bool, priority int, retry RetryPolicy, wait time.Duration) (*Session, error) {
+	session, err := m.OpenPortWithRetry(ctx, portName, config, clientID, exclusive, priority, retry)
+	if err != ErrPortLocked || wait <= 0 {
+		return session, err
+	}
+
+	portName = CanonicalPortName(portName)
+	w := m.enqueue(portName, clientID)
+	defer m.dequeue(portName, w)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, ErrQueueTimeout
+		case <-w.wake:
+		}
+
+		session, err := m.openPortFor(w, portName, config, clientID, exclusive, priority)
+		if err == ErrPortLocked {
+			continue
+		}
+		if err == nil {
+			m.events.Publish(Event{
+				Type:      EventQueuePosition,
+				PortName:  portName,
+				SessionID: session.ID,
+				ClientID:  clientID,
+				Message:   "port granted",
+			})
+		}
+		return session, err
+	}
+}
+
+// enqueue adds a client to the end of a port's queue. The port is retried
+// at once, in case it was released before the client joined.
+func (m *Manager) enqueue(portName string, clientID string) *portWaiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.queues == nil {
+		m.queues = make(map[string][]*portWaiter)
+	}
+	w := &portWaiter{clientID: clientID, wake: make(chan struct{}, 1)}
+	m.queues[portName] = append(m.queues[portName], w)
+	m.publishQueueLocked(portName, len(m.queues[portName])-1)
+	m.wakeQueueLocked(portName)
+	return w
+}
+
+// dequeue removes a client from a port's queue and lets the next client
+// try the port
+func (m *Manager) dequeue(portName string, w *portWaiter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queue := m.queues[portName]
+	removed := len(queue)
+	for i, waiter := range queue {
+		if waiter == w {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			removed = i
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(m.queues, portName)
+		return
+	}
+	m.queues[portName] = queue
+	m.publishQueueLocked(portName, removed)
+	m.wakeQueueLocked(portName)
+}
+
+// queueBlocks reports whether clients queued for a port come before the
+// given one, which is nil for clients that did not queue
+// (must be called with lock held)
+func (m *Manager) queueBlocks(portName string, ticket *portWaiter) bool {
+	queue := m.queues[portName]
+	return len(queue) > 0 && queue[0] != ticket
+}
+
+// wakeQueueLocked lets the first client in a port's queue try the port
+// (must be called with lock held)
+func (m *Manager) wakeQueueLocked(portName string) {
+	if queue := m.queues[portName]; len(queue) > 0 {
+		select {
+		case queue[0].wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// publishQueueLocked publishes the positions of the clients in a port's
+// queue from the given index on (must be called with lock held)
+func (m *Manager) publishQueueLocked(portName string, from int) {
+	queue := m.queues[portName]
+	for i := from; i < len(queue); i++ {
+		m.events.Publish(Event{
+			Type:          EventQueuePosition,
+			PortName:      portName,
+			ClientID:      queue[i].clientID,
+			QueuePosition: i + 1,
+		})
+	}
+}
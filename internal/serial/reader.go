@@ -23,7 +23,9 @@ import (
 	"time"
 )
 
-// Reader provides continuous reading from a serial port with streaming support
+// Reader provides continuous reading from a serial port with streaming
+// support. Readers of the same session share the session's stream, so each
+// receives every chunk.
 type Reader struct {
 	manager     *Manager
 	portName    string
@@ -31,8 +33,12 @@ type Reader struct {
 	bufferSize  int
 	running     atomic.Bool
 	stopChan    chan struct{}
-	subscribers []chan DataEvent
+	session     *Session
+	stream      *stream
+	sequence    atomic.Uint32
+	subscribers []*subscription
 	subMu       sync.RWMutex
+	acks        *ackWindow // Set for acknowledged delivery
 }
 
 // DataEvent represents a data read event
@@ -41,6 +47,38 @@ type DataEvent struct {
 	Timestamp time.Time
 	Sequence  uint32
 	Error     error
+	Gap       bool      // Data may have been lost while the device was reconnected
+	Overflow  *Overflow // Marker: chunks were dropped because the subscriber fell behind
+
+	// Set by the stream for received data
+	SessionSequence uint64       // Sequence number across every reader of the session
+	Received        ReceiveTimes // When the pump received the chunk's first and last bytes
+}
+
+// Overflow describes the chunks a subscriber lost because it did not keep up
+// with the stream
+type Overflow struct {
+	Chunks        int
+	Bytes         int
+	FirstSequence uint32 // Sequence number of the first chunk lost
+	LastSequence  uint32 // Sequence number of the last chunk lost
+}
+
+// subscription is a subscriber's channel and the data it has lost since
+// last receiving an event
+type subscription struct {
+	ch   chan DataEvent
+	lost *Overflow
+}
+
+// drop records an event the subscriber had no room for
+func (s *subscription) drop(event DataEvent) {
+	if s.lost == nil {
+		s.lost = &Overflow{FirstSequence: event.Sequence}
+	}
+	s.lost.Chunks++
+	s.lost.Bytes += len(event.Data)
+	s.lost.LastSequence = event.Sequence
 }
 
 // NewReader creates a new continuous reader for a port
@@ -55,7 +93,7 @@ func NewReader(manager *Manager, portName, sessionID string, bufferSize int) *Re
 		sessionID:   sessionID,
 		bufferSize:  bufferSize,
 		stopChan:    make(chan struct{}),
-		subscribers: make([]chan DataEvent, 0),
+		subscribers: make([]*subscription, 0),
 	}
 }
 
@@ -66,31 +104,39 @@ func (r *Reader) Start(ctx context.Context) error {
 	}
 
 	// Validate session
-	_, err := r.manager.ValidateSession(r.portName, r.sessionID)
+	session, err := r.manager.ValidateSession(r.portName, r.sessionID)
 	if err != nil {
 		return err
 	}
 
 	r.running.Store(true)
+	r.session = session
+	r.stream = session.joinStream(r.manager, r.portName, r.sessionID, r)
 
-	go r.readLoop(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+			r.Stop()
+		case <-r.stopChan:
+		}
+	}()
 
 	return nil
 }
 
 // Stop stops the continuous reader
 func (r *Reader) Stop() {
-	if !r.running.Load() {
+	if !r.running.CompareAndSwap(true, false) {
 		return
 	}
 
-	r.running.Store(false)
 	close(r.stopChan)
+	r.session.leaveStream(r.stream, r)
 
 	// Close all subscriber channels
 	r.subMu.Lock()
-	for _, ch := range r.subscribers {
-		close(ch)
+	for _, sub := range r.subscribers {
+		close(sub.ch)
 	}
 	r.subscribers = nil
 	r.subMu.Unlock()
@@ -101,7 +147,7 @@ func (r *Reader) Subscribe() <-chan DataEvent {
 	ch := make(chan DataEvent, 100)
 
 	r.subMu.Lock()
-	r.subscribers = append(r.subscribers, ch)
+	r.subscribers = append(r.subscribers, &subscription{ch: ch})
 	r.subMu.Unlock()
 
 	return ch
@@ -113,69 +159,88 @@ func (r *Reader) Unsubscribe(ch <-chan DataEvent) {
 	defer r.subMu.Unlock()
 
 	for i, sub := range r.subscribers {
-		if sub == ch {
-			close(sub)
+		if sub.ch == ch {
+			close(sub.ch)
 			r.subscribers = append(r.subscribers[:i], r.subscribers[i+1:]...)
 			return
 		}
 	}
 }
 
-// readLoop continuously reads from the port
-func (r *Reader) readLoop(ctx context.Context) {
-	var sequence uint32
-
-	for r.running.Load() {
-		select {
-		case <-ctx.Done():
-			r.Stop()
-			return
-		case <-r.stopChan:
+// deliver numbers an event from the stream and broadcasts it, split into
+// chunks of at most the reader's buffer size. The data is shared with the
+// stream's other readers and must not be modified.
+func (r *Reader) deliver(event DataEvent) {
+	data := event.Data
+	for {
+		chunk := event
+		if len(data) > r.bufferSize {
+			chunk.Data = data[:r.bufferSize:r.bufferSize]
+		} else {
+			chunk.Data = data
+		}
+		if r.acks != nil && chunk.Error == nil && !r.awaitWindow() {
 			return
-		default:
-			data, err := r.manager.Read(r.portName, r.sessionID, r.bufferSize)
-			
-			// Skip if no data (timeout with no data is normal)
-			if err == nil && len(data) == 0 {
-				continue
-			}
-
-			event := DataEvent{
-				Data:      data,
-				Timestamp: time.Now(),
-				Sequence:  atomic.AddUint32(&sequence, 1),
-				Error:     err,
+		}
+		chunk.Sequence = r.sequence.Add(1)
+		if chunk.Error == nil {
+			chunk.SessionSequence = r.session.chunkSeq.Add(1)
+			if r.acks != nil {
+				r.acks.track(chunk.Sequence)
 			}
+		}
+		r.broadcast(chunk)
 
-			r.broadcast(event)
-
-			if err != nil {
-				// Check if it's a fatal error
-				if err == ErrPortClosed || err == ErrInvalidSession {
-					r.Stop()
-					return
-				}
-				// Non-fatal errors - continue reading
-				time.Sleep(10 * time.Millisecond)
-			}
+		data = data[len(chunk.Data):]
+		if len(data) == 0 {
+			return
 		}
+		event.Gap = false
 	}
 }
 
-// broadcast sends an event to all subscribers
+// broadcast sends an event to all subscribers. A subscriber whose channel
+// is full loses the event, and is sent an overflow marker describing what it
+// lost before its next event.
 func (r *Reader) broadcast(event DataEvent) {
-	r.subMu.RLock()
-	defer r.subMu.RUnlock()
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	for _, sub := range r.subscribers {
+		if r.acks != nil {
+			// Acknowledged readers wait for the subscriber instead
+			select {
+			case sub.ch <- event:
+			case <-r.stopChan:
+			}
+			continue
+		}
+
+		if sub.lost != nil {
+			select {
+			case sub.ch <- DataEvent{Timestamp: event.Timestamp, Overflow: sub.lost}:
+				sub.lost = nil
+			default:
+				sub.drop(event)
+				continue
+			}
+		}
 
-	for _, ch := range r.subscribers {
 		select {
-		case ch <- event:
+		case sub.ch <- event:
 		default:
-			// Channel full, drop the event
+			sub.drop(event)
 		}
 	}
 }
 
+// SubscriberCount returns the number of active subscriptions
+func (r *Reader) SubscriberCount() int {
+	r.subMu.RLock()
+	defer r.subMu.RUnlock()
+	return len(r.subscribers)
+}
+
 // IsRunning returns whether the reader is currently running
 func (r *Reader) IsRunning() bool {
 	return r.running.Load()
@@ -187,24 +252,14 @@ type ReadResult struct {
 	Error error
 }
 
-// ReadWithTimeout reads data with a specific timeout
+// ReadWithTimeout reads data with a specific timeout, returning
+// ErrReadTimeout if nothing is received in time
 func ReadWithTimeout(manager *Manager, portName, sessionID string, maxBytes int, timeout time.Duration) ReadResult {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	resultChan := make(chan ReadResult, 1)
-
-	go func() {
-		data, err := manager.Read(portName, sessionID, maxBytes)
-		resultChan <- ReadResult{Data: data, Error: err}
-	}()
-
-	select {
-	case result := <-resultChan:
-		return result
-	case <-ctx.Done():
-		return ReadResult{Error: ErrReadTimeout}
+	data, err := manager.ReadWithin(portName, sessionID, maxBytes, timeout)
+	if err == nil && len(data) == 0 {
+		err = ErrReadTimeout
 	}
+	return ReadResult{Data: data, Error: err}
 }
 
 // WriteWithTimeout writes data with a specific timeout
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"log"
+	"runtime"
+	"syscall"
+	"time"
+
+	"go.bug.st/serial"
+	"go.bug.st/serial/enumerator"
+)
+
+// reconnectInterval is how often a suspended session looks for its device
+const reconnectInterval = time.Second
+
+// Windows error codes reported when a USB serial device is removed
+const (
+	errorBadCommand         = 22
+	errorOperationAborted   = 995
+	errorDeviceNotConnected = 1167
+)
+
+// EnableReconnect puts a session in reconnect mode. When its device is lost
+// the session is suspended instead of failing, and once a device with the
+// given serial number reappears it is reopened with the same configuration.
+// Without a serial number the device must come back at the same path.
+func (m *Manager) EnableReconnect(session *Session, serialNumber string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.deviceSerial = serialNumber
+	session.openedAs = session.PortName
+	session.reconnect.Store(true)
+}
+
+// Reconnects reports whether the session is in reconnect mode
+func (s *Session) Reconnects() bool {
+	return s.reconnect.Load()
+}
+
+// isDeviceLost reports whether an I/O error means the device went away
+func isDeviceLost(err error) bool {
+	var portErr *serial.PortError
+	if errors.As(err, &portErr) {
+		return portErr.Code() == serial.PortClosed
+	}
+
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch errno {
+		case errorBadCommand, errorOperationAborted, errorDeviceNotConnected:
+			return true
+		}
+		return false
+	}
+	switch errno {
+	case syscall.EIO, syscall.ENXIO, syscall.ENODEV:
+		return true
+	}
+	return false
+}
+
+// handleDeviceLoss suspends a reconnecting session whose device was lost and
+// starts looking for it, returning ErrPortDisconnected in place of the I/O
+// error. Other errors are returned unchanged.
+func (m *Manager) handleDeviceLoss(session *Session, err error) error {
+	if !session.reconnect.Load() || session.closed.Load() || !isDeviceLost(err) {
+		return err
+	}
+
+	if session.disconnected.CompareAndSwap(false, true) {
+		log.Printf("Session %s on %s suspended, waiting for the device: %v", session.ID, session.PortName, err)
+		m.events.Publish(Event{
+			Type:      EventSessionSuspended,
+			PortName:  session.PortName,
+			SessionID: session.ID,
+			Message:   err.Error(),
+		})
+		go m.recoverSession(session)
+	}
+
+	return ErrPortDisconnected
+}
+
+// recoverSession waits for a suspended session's device to reappear and
+// reopens it, until the session is closed
+func (m *Manager) recoverSession(session *Session) {
+	// Release the lost device so its path can be opened again
+	session.mu.Lock()
+	session.port.Close()
+	session.mu.Unlock()
+
+	ticker := time.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if session.closed.Load() {
+			return
+		}
+
+		portName, ok := findDevice(session.deviceSerial, session.PortName)
+		if !ok {
+			continue
+		}
+
+		port, err := m.reopenSession(session, portName)
+		if err != nil {
+			continue
+		}
+		session.disconnected.Store(false)
+		go m.pump(session, port)
+
+		log.Printf("Session %s resumed on %s", session.ID, portName)
+		m.events.Publish(Event{
+			Type:      EventSessionResumed,
+			PortName:  portName,
+			SessionID: session.ID,
+		})
+		return
+	}
+}
+
+// findDevice looks up the current path of a device, by serial number when
+// known and otherwise by its previous path
+func findDevice(serialNumber, portName string) (string, bool) {
+	ports, err := enumerator.GetDetailedPortsList()
+	if err != nil {
+		return "", false
+	}
+
+	for _, port := range ports {
+		if serialNumber != "" {
+			if port.SerialNumber == serialNumber {
+				return port.Name, true
+			}
+			continue
+		}
+		if port.Name == portName {
+			return port.Name, true
+		}
+	}
+	return "", false
+}
+
+// reopenSession opens a device for a session that lost its port, keeping
+// the session ID, statistics, and buffered data. The session moves to the
+// new path if the device re-enumerated under another name.
+func (m *Manager) reopenSession(session *Session, portName string) (serial.Port, error) {
+	// Opening reconfigures RS-485 on the device, so leave devices held by
+	// other sessions alone
+	m.mu.RLock()
+	existing, exists := m.sessions[portName]
+	locking := m.systemLock
+	m.mu.RUnlock()
+	if exists && existing != session {
+		return nil, ErrPortLocked
+	}
+
+	port, rs485Kernel, err := openDevice(portName, session.Config, locking)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if session.closed.Load() {
+		m.mu.Unlock()
+		port.Close()
+		return nil, ErrPortClosed
+	}
+	if existing, exists := m.sessions[portName]; exists && existing != session {
+		m.mu.Unlock()
+		port.Close()
+		return nil, ErrPortLocked
+	}
+
+	delete(m.sessions, session.PortName)
+	m.sessions[portName] = session
+	m.mu.Unlock()
+
+	session.mu.Lock()
+	old := session.port
+	session.port = port
+	session.PortName = portName
+	session.rs485Kernel.Store(rs485Kernel)
+	session.mu.Unlock()
+
+	old.Close()
+
+	session.framerMu.Lock()
+	if session.framer != nil {
+		session.framer.Reset()
+	}
+	session.framerMu.Unlock()
+
+	return port, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"syscall"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// RetryPolicy controls how opening a port retries transient failures, such
+// as a USB adapter reporting busy or briefly disappearing while it enumerates
+type RetryPolicy struct {
+	Attempts int           // Total attempts including the first; 0 or 1 disables retries
+	Delay    time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay time.Duration // Upper bound on the delay (0 = no bound)
+	Jitter   float64       // Fraction of each delay that is randomized, 0-1
+}
+
+// Validate checks the retry policy
+func (p RetryPolicy) Validate() error {
+	if p.Attempts < 0 || p.Delay < 0 || p.MaxDelay < 0 {
+		return fmt.Errorf("retry values must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1: %g", p.Jitter)
+	}
+	return nil
+}
+
+// backoff returns the delay to wait after the given failed attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.Delay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		spread := float64(delay) * p.Jitter
+		delay += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+	return delay
+}
+
+// isTransientOpenError reports whether an open failure may succeed when
+// retried: the port is busy, or the device node is missing or not ready
+func isTransientOpenError(err error) bool {
+	var portErr *serial.PortError
+	if errors.As(err, &portErr) {
+		switch portErr.Code() {
+		case serial.PortBusy, serial.PortNotFound:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, ErrSystemLocked) {
+		return true
+	}
+
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		switch errno {
+		case syscall.EBUSY, syscall.ENODEV, syscall.ENXIO, syscall.EIO, syscall.EAGAIN:
+			return true
+		}
+	}
+	return false
+}
+
+// SetOpenRetry sets the retry policy OpenPort uses for transient failures
+func (m *Manager) SetOpenRetry(policy RetryPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.openRetry = policy
+}
+
+// OpenRetry returns the default retry policy for opening ports
+func (m *Manager) OpenRetry() RetryPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.openRetry
+}
+
+// OpenPortWithRetry opens a port like OpenPort, retrying transient failures
+// according to the given policy. The manager lock is released between
+// attempts, and retries stop early when the context is cancelled.
+func (m *Manager) OpenPortWithRetry(ctx context.Context, portName string, config PortConfig, clientID string, exclusive bool, priority int, retry RetryPolicy) (*Session, error) {
+	if err := retry.Validate(); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		session, err := m.openPort(portName, config, clientID, exclusive, priority)
+		if err == nil || attempt >= retry.Attempts || !isTransientOpenError(err) {
+			return session, err
+		}
+
+		timer := time.NewTimer(retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"sync"
+	"time"
+)
+
+// RingBuffer is a fixed-size byte buffer that overwrites the oldest data
+// when full. Reads block until data is available or a timeout expires.
+type RingBuffer struct {
+	mu      sync.Mutex
+	data    []byte
+	start   int
+	size    int
+	dropped uint64
+	written uint64        // Total bytes written, including dropped bytes
+	marks   []receiveMark // Receive times of the buffered data, oldest first
+	notify  chan struct{}
+	closed  bool
+	err     error // Returned by reads once closed and drained
+	packets bool  // Reads stop at the end of each write
+}
+
+// receiveMark records when the bytes written up to a position arrived
+type receiveMark struct {
+	end uint64 // Value of written after the write
+	at  time.Time
+}
+
+// NewRingBuffer creates a ring buffer holding up to capacity bytes
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{
+		data:   make([]byte, capacity),
+		notify: make(chan struct{}),
+	}
+}
+
+// Write appends data, discarding the oldest bytes if the buffer overflows
+func (b *RingBuffer) Write(p []byte) {
+	b.WriteAt(p, time.Now())
+}
+
+// WriteAt appends data received at the given time
+func (b *RingBuffer) WriteAt(p []byte, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.written += uint64(len(p))
+	b.marks = append(b.marks, receiveMark{end: b.written, at: at})
+
+	capacity := len(b.data)
+	if len(p) > capacity {
+		b.dropped += uint64(len(p) - capacity)
+		p = p[len(p)-capacity:]
+	}
+
+	if overflow := b.size + len(p) - capacity; overflow > 0 {
+		b.start = (b.start + overflow) % capacity
+		b.size -= overflow
+		b.dropped += uint64(overflow)
+	}
+
+	end := (b.start + b.size) % capacity
+	n := copy(b.data[end:], p)
+	copy(b.data, p[n:])
+	b.size += len(p)
+	b.trimMarks()
+
+	// Wake any blocked readers
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// Unread puts data taken by a read back at the front of the buffer, received
+// at the given time, so the next read returns it first. If the buffer
+// cannot hold it all, the oldest of it is discarded.
+func (b *RingBuffer) Unread(p []byte, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	capacity := len(b.data)
+	if free := capacity - b.size; len(p) > free {
+		b.dropped += uint64(len(p) - free)
+		p = p[len(p)-free:]
+	}
+	if len(p) == 0 {
+		return
+	}
+
+	// The unread bytes end where the buffered data starts
+	b.marks = append([]receiveMark{{end: b.written - uint64(b.size), at: at}}, b.marks...)
+
+	b.start = (b.start - len(p) + capacity) % capacity
+	n := copy(b.data[b.start:], p)
+	copy(b.data, p[n:])
+	b.size += len(p)
+
+	// Wake any blocked readers
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// Read copies buffered data into p, waiting up to timeout for data to
+// arrive. A zero timeout waits indefinitely. It returns 0 and no error if the
+// timeout expires, and ErrPortClosed or the error the buffer was closed with
+// once it is closed and drained.
+func (b *RingBuffer) Read(p []byte, timeout time.Duration) (int, error) {
+	n, _, err := b.ReadTimed(p, timeout)
+	return n, err
+}
+
+// ReadTimed is Read, also returning when the first and last bytes read
+// were written
+func (b *RingBuffer) ReadTimed(p []byte, timeout time.Duration) (int, ReceiveTimes, error) {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		b.mu.Lock()
+		if b.size > 0 {
+			n := min(len(p), b.size)
+			if b.packets {
+				n = min(n, b.packetLen())
+			}
+			times := b.receiveTimes(n)
+			b.readLocked(p[:n])
+			b.trimMarks()
+			b.mu.Unlock()
+			return n, times, nil
+		}
+		if b.closed {
+			b.mu.Unlock()
+			return 0, ReceiveTimes{}, b.err
+		}
+		notify := b.notify
+		b.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-deadline:
+			return 0, ReceiveTimes{}, nil
+		}
+	}
+}
+
+// readLocked copies out up to len(p) bytes (must be called with lock held)
+func (b *RingBuffer) readLocked(p []byte) int {
+	capacity := len(b.data)
+
+	n := len(p)
+	if n > b.size {
+		n = b.size
+	}
+
+	first := capacity - b.start
+	if first > n {
+		first = n
+	}
+	copy(p, b.data[b.start:b.start+first])
+	copy(p[first:n], b.data[:n-first])
+
+	b.start = (b.start + n) % capacity
+	b.size -= n
+	return n
+}
+
+// receiveTimes returns when the next n buffered bytes were written
+// (must be called with lock held)
+func (b *RingBuffer) receiveTimes(n int) ReceiveTimes {
+	first := b.written - uint64(b.size)
+	last := first + uint64(n) - 1
+
+	var times ReceiveTimes
+	for _, m := range b.marks {
+		if times.First.IsZero() && m.end > first {
+			times.First = m.at
+		}
+		if m.end > last {
+			times.Last = m.at
+			break
+		}
+	}
+	return times
+}
+
+// KeepPackets makes reads stop at the end of each write, so data written as
+// one packet is read back as one, unless it is larger than the read
+func (b *RingBuffer) KeepPackets() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.packets = true
+}
+
+// keepsPackets reports whether reads stop at the end of each write
+func (b *RingBuffer) keepsPackets() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.packets
+}
+
+// packetLen returns the length of the first buffered write
+// (must be called with lock held)
+func (b *RingBuffer) packetLen() int {
+	start := b.written - uint64(b.size)
+	for _, m := range b.marks {
+		if m.end > start {
+			return int(m.end - start)
+		}
+	}
+	return b.size
+}
+
+// trimMarks forgets the receive times of data no longer buffered
+// (must be called with lock held)
+func (b *RingBuffer) trimMarks() {
+	start := b.written - uint64(b.size)
+	for len(b.marks) > 0 && b.marks[0].end <= start {
+		b.marks = b.marks[1:]
+	}
+}
+
+// Reset discards all buffered data
+func (b *RingBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.start = 0
+	b.size = 0
+	b.marks = b.marks[:0]
+}
+
+// Cap returns the buffer capacity in bytes
+func (b *RingBuffer) Cap() int {
+	return len(b.data)
+}
+
+// Len returns the number of buffered bytes
+func (b *RingBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// Dropped returns the number of bytes discarded due to overflow
+func (b *RingBuffer) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Close wakes blocked readers; buffered data can still be read
+func (b *RingBuffer) Close() {
+	b.CloseWithError(ErrPortClosed)
+}
+
+// CloseWithError closes the buffer; once buffered data has been read, reads
+// return err
+func (b *RingBuffer) CloseWithError(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.err = err
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
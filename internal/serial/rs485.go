@@ -0,0 +1,168 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"fmt"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+// RS485Config controls the driver enable line of a half-duplex RS-485
+// transceiver wired to RTS. The kernel keys the driver where the UART
+// supports it; otherwise the agent toggles RTS around each write.
+type RS485Config struct {
+	Enabled           bool
+	RTSActiveLow      bool // Drive RTS low rather than high while sending
+	DelayBeforeSendMs int  // Delay between enabling the driver and the first byte
+	DelayAfterSendMs  int  // Delay between the last byte and releasing the driver
+}
+
+// validate checks the RS-485 settings against the rest of the configuration
+func (c RS485Config) validate(flow FlowControl) error {
+	if c.DelayBeforeSendMs < 0 || c.DelayAfterSendMs < 0 {
+		return fmt.Errorf("RS-485 delays must not be negative")
+	}
+	if c.Enabled && flow == FlowControlHardware {
+		return fmt.Errorf("RS-485 mode cannot be combined with hardware flow control")
+	}
+	return nil
+}
+
+// openDevice opens a port, handing RS-485 driver control to the kernel
+// first when requested, and applies its flow control. It reports whether the
+// kernel keys the driver. A port busy in another process is reported with
+// the processes holding it. With system locking enabled the port is locked
+// against other programs first, and the lock is released when it closes.
+func openDevice(portName string, config PortConfig, locking SystemLockSettings) (serial.Port, bool, error) {
+	if IsBluetoothPort(portName) {
+		return openBluetooth(portName, config, locking)
+	}
+	if IsNetworkPort(portName) {
+		return openNetwork(portName)
+	}
+
+	var lock *systemLock
+	if locking.Enabled {
+		var err error
+		if lock, err = acquireSystemLock(portName, locking); err != nil {
+			return nil, false, withHolders(portName, err)
+		}
+	}
+
+	kernel := config.RS485.Enabled && setKernelRS485(portName, config.RS485) == nil
+
+	port, err := serial.Open(devicePath(portName), config.toSerialMode())
+	if err != nil {
+		if kernel {
+			setKernelRS485(portName, RS485Config{})
+		}
+		lock.release()
+		return nil, false, fmt.Errorf("failed to open port: %w", withHolders(portName, err))
+	}
+	if lock != nil {
+		port = &lockedPort{Port: port, lock: lock}
+	}
+
+	if config.Sniff {
+		// Stay off the link; pseudo-terminals have no modem lines to clear
+		port.SetDTR(false)
+		port.SetRTS(false)
+	}
+
+	if config.RS485.Enabled && !kernel {
+		// Keep the driver off the bus until the first write
+		if err := port.SetRTS(config.RS485.RTSActiveLow); err != nil {
+			port.Close()
+			return nil, false, fmt.Errorf("failed to release RS-485 driver: %w", err)
+		}
+	}
+
+	if err := applyFlowControl(portName, config, false); err != nil {
+		port.Close()
+		if kernel {
+			setKernelRS485(portName, RS485Config{})
+		}
+		return nil, false, fmt.Errorf("failed to configure flow control: %w", err)
+	}
+
+	return port, kernel, nil
+}
+
+// applyRS485 switches the session to new RS-485 settings, falling back to
+// manual RTS toggling if the kernel refuses them. A kernel that already keys
+// the driver must accept the change, or it would fight the manual toggling.
+// (must be called with the session lock held)
+func (s *Session) applyRS485(cfg RS485Config) error {
+	kernel := false
+	if cfg.Enabled || s.rs485Kernel.Load() {
+		err := setKernelRS485(s.PortName, cfg)
+		if err != nil && s.rs485Kernel.Load() {
+			return err
+		}
+		kernel = err == nil && cfg.Enabled
+	}
+
+	if cfg.Enabled && !kernel {
+		if err := s.port.SetRTS(cfg.RTSActiveLow); err != nil {
+			return err
+		}
+	}
+
+	s.rs485Kernel.Store(kernel)
+	return nil
+}
+
+// releaseRS485 returns a closed port to normal operation if the kernel was
+// keying its driver
+func (s *Session) releaseRS485() {
+	if s.rs485Kernel.Swap(false) {
+		setKernelRS485(s.PortName, RS485Config{})
+	}
+}
+
+// keyed runs write with the RS-485 driver enabled when RTS is toggled by
+// the agent. The output is drained before the driver is released so the
+// last byte is not cut off.
+// (must be called with the session lock held)
+func (s *Session) keyed(write func([]byte) (int, error), data []byte) (int, error) {
+	rs := s.Config.RS485
+	if !rs.Enabled || s.rs485Kernel.Load() {
+		return write(data)
+	}
+
+	if err := s.port.SetRTS(!rs.RTSActiveLow); err != nil {
+		return 0, err
+	}
+	if rs.DelayBeforeSendMs > 0 {
+		time.Sleep(time.Duration(rs.DelayBeforeSendMs) * time.Millisecond)
+	}
+
+	n, err := write(data)
+	if derr := s.port.Drain(); err == nil {
+		err = derr
+	}
+
+	if rs.DelayAfterSendMs > 0 {
+		time.Sleep(time.Duration(rs.DelayAfterSendMs) * time.Millisecond)
+	}
+	if rerr := s.port.SetRTS(rs.RTSActiveLow); err == nil {
+		err = rerr
+	}
+	return n, err
+}
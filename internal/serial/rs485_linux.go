@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Flags of struct serial_rs485
+const (
+	rs485Enabled      = 1 << 0
+	rs485RTSOnSend    = 1 << 1
+	rs485RTSAfterSend = 1 << 2
+)
+
+// serialRS485 mirrors the kernel's struct serial_rs485
+type serialRS485 struct {
+	Flags              uint32
+	DelayRTSBeforeSend uint32
+	DelayRTSAfterSend  uint32
+	Padding            [5]uint32
+}
+
+// setKernelRS485 configures the UART's RS-485 mode with TIOCSRS485. The
+// device is opened separately because the serial library does not expose
+// its descriptor; the setting belongs to the UART, not the descriptor.
+func setKernelRS485(portName string, cfg RS485Config) error {
+	fd, err := unix.Open(portName, unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var rs serialRS485
+	if cfg.Enabled {
+		rs.Flags = rs485Enabled
+		if cfg.RTSActiveLow {
+			rs.Flags |= rs485RTSAfterSend
+		} else {
+			rs.Flags |= rs485RTSOnSend
+		}
+		rs.DelayRTSBeforeSend = uint32(cfg.DelayBeforeSendMs)
+		rs.DelayRTSAfterSend = uint32(cfg.DelayAfterSendMs)
+	}
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.TIOCSRS485, uintptr(unsafe.Pointer(&rs)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
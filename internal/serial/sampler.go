@@ -0,0 +1,153 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"sync"
+	"time"
+)
+
+// sampleInterval is how often session counters are sampled
+const sampleInterval = time.Second
+
+// samplerSlots holds enough samples for the longest rate window
+const samplerSlots = 61
+
+// RateWindows are the windows rolling throughput is reported over
+var RateWindows = []time.Duration{time.Second, 10 * time.Second, 60 * time.Second}
+
+// Rate is the throughput of a session over a window
+type Rate struct {
+	Window      time.Duration
+	SentBps     float64 // Bytes per second written
+	ReceivedBps float64 // Bytes per second received
+}
+
+// counterSample is the session's byte counters at a point in time
+type counterSample struct {
+	at       time.Time
+	sent     uint64
+	received uint64
+}
+
+// rateSampler keeps a minute of per-second counter samples for a session
+type rateSampler struct {
+	mu      sync.Mutex
+	samples [samplerSlots]counterSample
+	next    int
+	count   int
+}
+
+// record adds a sample, overwriting the oldest once the ring is full
+func (r *rateSampler) record(sample counterSample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % samplerSlots
+	if r.count < samplerSlots {
+		r.count++
+	}
+}
+
+// rates computes throughput over each window from the newest sample and the
+// oldest sample inside the window. Sessions younger than a window report the
+// rate since they were first sampled.
+func (r *rateSampler) rates(windows []time.Duration) []Rate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]Rate, len(windows))
+	for i, window := range windows {
+		result[i].Window = window
+	}
+	if r.count < 2 {
+		return result
+	}
+
+	newest := r.samples[(r.next-1+samplerSlots)%samplerSlots]
+
+	for i, window := range windows {
+		oldest := newest
+		for age := 1; age < r.count; age++ {
+			s := r.samples[(r.next-1-age+2*samplerSlots)%samplerSlots]
+			// Allow for ticker jitter so a window spans whole intervals
+			if newest.at.Sub(s.at) > window+sampleInterval/2 {
+				break
+			}
+			oldest = s
+		}
+
+		elapsed := newest.at.Sub(oldest.at).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+		result[i].SentBps = float64(newest.sent-oldest.sent) / elapsed
+		result[i].ReceivedBps = float64(newest.received-oldest.received) / elapsed
+	}
+
+	return result
+}
+
+// Rates returns the session's rolling throughput over RateWindows
+func (s *Session) Rates() []Rate {
+	return s.sampler.rates(RateWindows)
+}
+
+// LastSent returns when data was last written to the port
+func (s *Session) LastSent() time.Time {
+	return unixNanoTime(s.lastSent.Load())
+}
+
+// LastReceived returns when data was last received from the port
+func (s *Session) LastReceived() time.Time {
+	return unixNanoTime(s.lastReceived.Load())
+}
+
+// recordSent updates transmit statistics and mirrors the data to taps
+func (s *Session) recordSent(data []byte) {
+	s.Statistics.BytesSent.Add(uint64(len(data)))
+	s.lastSent.Store(time.Now().UnixNano())
+	s.tapData(TapTX, data)
+}
+
+// unixNanoTime converts a stored timestamp, treating zero as never
+func unixNanoTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// sampleLoop samples the counters of every open session once a second for
+// the lifetime of the manager
+func (m *Manager) sampleLoop() {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		m.mu.RLock()
+		for _, session := range m.sessions {
+			session.sampler.record(counterSample{
+				at:       now,
+				sent:     session.Statistics.BytesSent.Load(),
+				received: session.Statistics.BytesReceived.Load(),
+			})
+		}
+		m.mu.RUnlock()
+	}
+}
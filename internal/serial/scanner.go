@@ -18,10 +18,12 @@ limitations under the License.
 package serial
 
 import (
+	"log"
 	"regexp"
 	"runtime"
 	"sort"
 	"sync"
+	"time"
 
 	"go.bug.st/serial/enumerator"
 )
@@ -35,6 +37,7 @@ const (
 	PortTypeNative
 	PortTypeBluetooth
 	PortTypeVirtual
+	PortTypeNetwork
 )
 
 // String returns the string representation of PortType
@@ -48,6 +51,8 @@ func (p PortType) String() string {
 		return "Bluetooth"
 	case PortTypeVirtual:
 		return "Virtual"
+	case PortTypeNetwork:
+		return "Network"
 	default:
 		return "Unknown"
 	}
@@ -66,6 +71,18 @@ type PortInfo struct {
 	PortType     PortType `json:"port_type"`
 	IsOpen       bool     `json:"is_open"`
 	LockedBy     string   `json:"locked_by"`
+	Alias        string   `json:"alias,omitempty"`
+
+	// Platform details for telling identical adapters apart
+	Driver       string            `json:"driver,omitempty"`        // Kernel driver or Windows driver service
+	BusPath      string            `json:"bus_path,omitempty"`      // Physical USB port, e.g. 1-1.2 or Port_#0002.Hub_#0004
+	USBInterface string            `json:"usb_interface,omitempty"` // Interface number on multi-port adapters
+	DevicePath   string            `json:"device_path,omitempty"`   // sysfs device path or Windows device instance ID
+	FriendlyName string            `json:"friendly_name,omitempty"` // Windows device manager name
+	Properties   map[string]string `json:"properties,omitempty"`    // Selected udev properties or registry values
+
+	// Services that grab the port on their own, e.g. ModemManager or getty
+	Claimants []string `json:"claimants,omitempty"`
 }
 
 // Scanner handles serial port discovery and enumeration
@@ -74,6 +91,13 @@ type Scanner struct {
 	excludePatterns []*regexp.Regexp
 	cachedPorts     []PortInfo
 	manager         *Manager
+	aliases         []Alias
+	include         []PortFilter
+	exclude         []PortFilter
+	networkPorts    []NetworkPort
+	lastScan        time.Time
+	watching        bool
+	deviceDir       string
 }
 
 // NewScanner creates a new port scanner
@@ -109,7 +133,7 @@ func (s *Scanner) Scan() ([]PortInfo, error) {
 		}
 
 		info := PortInfo{
-			Name:         port.Name,
+			Name:         CanonicalPortName(port.Name),
 			Product:      port.Product,
 			SerialNumber: port.SerialNumber,
 			VID:          port.VID,
@@ -125,9 +149,15 @@ func (s *Scanner) Scan() ([]PortInfo, error) {
 		// Set description based on available info
 		info.Description = s.buildDescription(port)
 
+		if s.isFiltered(info) {
+			continue
+		}
+
+		info.Alias = s.aliasFor(info)
+
 		// Check if port is currently open/locked
 		if s.manager != nil {
-			if session := s.manager.GetSession(port.Name); session != nil {
+			if session := s.manager.GetSession(info.Name); session != nil {
 				info.IsOpen = true
 				info.LockedBy = session.ClientID
 			}
@@ -136,6 +166,11 @@ func (s *Scanner) Scan() ([]PortInfo, error) {
 		result = append(result, info)
 	}
 
+	addPlatformMetadata(result)
+	addClaimants(result)
+	result = append(result, s.bluetoothPorts()...)
+	result = append(result, s.networkPortInfos()...)
+
 	// Sort ports by name
 	sort.Slice(result, func(i, j int) bool {
 		return result[i].Name < result[j].Name
@@ -144,11 +179,47 @@ func (s *Scanner) Scan() ([]PortInfo, error) {
 	// Cache the results
 	s.mu.Lock()
 	s.cachedPorts = result
+	s.lastScan = time.Now()
 	s.mu.Unlock()
 
 	return result, nil
 }
 
+// LastScan returns the time of the last successful scan
+func (s *Scanner) LastScan() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastScan
+}
+
+// Ports returns the current port list. While WatchPorts keeps the cache up
+// to date the list is served from the cache, with the open state of each
+// port refreshed from the manager; otherwise, or when refresh is set, the
+// ports are enumerated again.
+func (s *Scanner) Ports(refresh bool) ([]PortInfo, error) {
+	s.mu.RLock()
+	cached := s.cachedPorts
+	fresh := s.watching && !s.lastScan.IsZero()
+	s.mu.RUnlock()
+
+	if refresh || !fresh {
+		return s.Scan()
+	}
+
+	ports := make([]PortInfo, len(cached))
+	copy(ports, cached)
+	if s.manager != nil {
+		for i := range ports {
+			ports[i].IsOpen, ports[i].LockedBy = false, ""
+			if session := s.manager.GetSession(ports[i].Name); session != nil {
+				ports[i].IsOpen = true
+				ports[i].LockedBy = session.ClientID
+			}
+		}
+	}
+	return ports, nil
+}
+
 // GetCached returns the last cached port list
 func (s *Scanner) GetCached() []PortInfo {
 	s.mu.RLock()
@@ -164,7 +235,7 @@ func (s *Scanner) GetPort(name string) (*PortInfo, error) {
 	}
 
 	for _, port := range ports {
-		if port.Name == name {
+		if SamePort(port.Name, name) || (port.Alias != "" && port.Alias == name) {
 			return &port, nil
 		}
 	}
@@ -227,34 +298,87 @@ func (s *Scanner) buildDescription(port *enumerator.PortDetails) string {
 	return "Serial Port"
 }
 
-// WatchPorts starts watching for port changes and calls the callback when ports change
-func (s *Scanner) WatchPorts(interval int, callback func([]PortInfo)) chan struct{} {
+// WatchPorts starts watching for port changes and calls the callback when
+// ports change. When hotplug is set and the platform supports device
+// notifications, ports are rescanned as soon as a device is added or removed;
+// otherwise they are polled every interval seconds. Polling continues at the
+// interval alongside notifications to pick up sessions opening and closing.
+func (s *Scanner) WatchPorts(interval int, hotplug bool, callback func([]PortInfo)) chan struct{} {
 	stop := make(chan struct{})
 
-	if interval <= 0 {
+	var monitor hotplugMonitor
+	if hotplug {
+		m, err := s.newMonitor()
+		if err != nil {
+			log.Printf("Hot-plug detection unavailable, polling for port changes: %v", err)
+		} else {
+			monitor = m
+		}
+	}
+
+	if interval <= 0 && monitor == nil {
 		return stop
 	}
 
+	s.mu.Lock()
+	s.watching = true
+	s.mu.Unlock()
+
 	go func() {
-		ticker := NewTicker(interval)
-		defer ticker.Stop()
+		defer func() {
+			s.mu.Lock()
+			s.watching = false
+			s.mu.Unlock()
+		}()
+
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticker := NewTicker(interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		var events <-chan struct{}
+		if monitor != nil {
+			defer monitor.Close()
+			events = monitor.Events()
+		}
+
+		// Device notifications arrive before the device node is ready and
+		// often in bursts, so scans are delayed until they settle
+		settle := time.NewTimer(hotplugSettle)
+		settle.Stop()
+		defer settle.Stop()
 
 		var lastPorts []PortInfo
 
+		rescan := func() {
+			ports, err := s.Scan()
+			if err != nil {
+				return
+			}
+
+			if !s.portsEqual(lastPorts, ports) {
+				lastPorts = ports
+				callback(ports)
+			}
+		}
+
 		for {
 			select {
 			case <-stop:
 				return
-			case <-ticker.C:
-				ports, err := s.Scan()
-				if err != nil {
+			case <-tick:
+				rescan()
+			case _, ok := <-events:
+				if !ok {
+					log.Printf("Hot-plug monitor stopped, polling for port changes")
+					events = nil
 					continue
 				}
-
-				if !s.portsEqual(lastPorts, ports) {
-					lastPorts = ports
-					callback(ports)
-				}
+				settle.Reset(hotplugSettle)
+			case <-settle.C:
+				rescan()
 			}
 		}
 	}()
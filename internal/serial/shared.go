@@ -0,0 +1,156 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shoaibashk/BaudLink/internal/ratelimit"
+)
+
+var (
+	// ErrPortShared is returned when opening a port another client has open
+	// for sharing; the client attaches to the session instead
+	ErrPortShared = errors.New("port is open by another client for sharing")
+	// ErrWriteQuota is returned when a client sharing a port writes faster
+	// than the shared write quota allows. It wraps ErrRateLimited.
+	ErrWriteQuota = fmt.Errorf("%w: shared write quota", ErrRateLimited)
+)
+
+// Holder is a client holding a port: the client that opened the session or
+// a client attached to it
+type Holder struct {
+	ID              string // Session ID for the opener, attachment ID otherwise
+	ClientID        string
+	Role            Role
+	Opener          bool
+	Since           time.Time
+	BytesSent       uint64
+	BytesReceived   uint64
+	QueuedWrites    int
+	QuotaRejections uint64 // Client's writes refused by the shared write quota, across sessions
+}
+
+// SetSharedWriteQuota limits the request and byte rate of writes by each
+// client on a port that several clients share
+func (m *Manager) SetSharedWriteQuota(limit ratelimit.Limit) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.writerLimiter = ratelimit.NewLimiter(limit)
+}
+
+// checkWriteQuota applies the shared write quota to a write of n bytes by
+// the session owner or the given attachment. Ports held by a single client
+// are not subject to the quota.
+func (m *Manager) checkWriteQuota(session *Session, att *Attachment, n int) error {
+	if session.AttachmentCount() == 0 {
+		return nil
+	}
+
+	m.mu.RLock()
+	limiter := m.writerLimiter
+	m.mu.RUnlock()
+
+	clientID := session.ClientID
+	if att != nil {
+		clientID = att.ClientID
+	}
+	if !limiter.Allow(writerKey(session.PortName, clientID), n) {
+		session.Statistics.RateLimited.Add(1)
+		return ErrWriteQuota
+	}
+	return nil
+}
+
+// writerKey is the shared write quota key of a client on a port
+func writerKey(portName string, clientID string) string {
+	return portName + "\x00" + clientID
+}
+
+// JoinShared attaches a client opening a port that another client already
+// has open, when shared access is allowed and neither wants exclusive
+// access. It returns ErrPortNotOpen when the port is not open, and
+// ErrPortLocked when the session cannot be shared.
+func (m *Manager) JoinShared(portName string, clientID string, exclusive bool) (*Attachment, *Session, error) {
+	session := m.GetSession(portName)
+	if session == nil {
+		return nil, nil, ErrPortNotOpen
+	}
+	if session.Managed || session.sniff || exclusive {
+		return nil, nil, ErrPortLocked
+	}
+	return m.Attach(portName, clientID, RoleReadWrite)
+}
+
+// Holders returns the clients holding a port, the opener first and then
+// attached clients in the order they attached
+func (m *Manager) Holders(portName string) ([]Holder, error) {
+	session := m.GetSession(portName)
+	if session == nil {
+		return nil, ErrPortNotOpen
+	}
+
+	m.mu.RLock()
+	limiter := m.writerLimiter
+	m.mu.RUnlock()
+
+	var queued map[string]int
+	session.queueMu.Lock()
+	if session.queue != nil {
+		queued = session.queue.pending()
+	}
+	session.queueMu.Unlock()
+
+	stats := session.Statistics.Snapshot()
+	opener := Holder{
+		ID:              session.ID,
+		ClientID:        session.ClientID,
+		Opener:          true,
+		Since:           stats.OpenedAt,
+		BytesSent:       stats.BytesSent,
+		BytesReceived:   stats.BytesReceived,
+		QueuedWrites:    queued[session.ID],
+		QuotaRejections: limiter.Rejected(writerKey(session.PortName, session.ClientID)),
+	}
+	if session.sniff {
+		opener.Role = RoleReadOnly
+	}
+
+	holders := []Holder{opener}
+	for _, att := range session.Attachments() {
+		sent := atomic.LoadUint64(&att.BytesSent)
+		// Session counters include writes made through attachments
+		if sent <= holders[0].BytesSent {
+			holders[0].BytesSent -= sent
+		}
+		holders = append(holders, Holder{
+			ID:              att.ID,
+			ClientID:        att.ClientID,
+			Role:            att.Role,
+			Since:           att.AttachedAt,
+			BytesSent:       sent,
+			BytesReceived:   atomic.LoadUint64(&att.BytesReceived),
+			QueuedWrites:    queued[att.ID],
+			QuotaRejections: limiter.Rejected(writerKey(session.PortName, att.ClientID)),
+		})
+	}
+
+	return holders, nil
+}
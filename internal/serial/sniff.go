@@ -0,0 +1,62 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+// Sniff observes a port without being able to write to it. If the port is
+// open, even exclusively, a read-only attachment is added to its session and
+// the attachment ID is returned. Otherwise, unless attachOnly is set, the
+// port is opened for sniffing: DTR and RTS are left deasserted, flow control
+// and RS-485 are disabled so the agent drives no lines, and the session
+// refuses every write. Other clients may sniff the same session but cannot
+// open the port until it is closed, so clients without write access sniff
+// with attachOnly and get ErrPortNotOpen for a closed port.
+func (m *Manager) Sniff(portName string, config PortConfig, clientID string, attachOnly bool) (string, *Session, error) {
+	config.Sniff = true
+	config.FlowControl = FlowControlNone
+	config.RS485 = RS485Config{}
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	portName = CanonicalPortName(portName)
+
+	// Deciding between attaching and opening under the lock keeps a port
+	// opened by another client in the meantime from failing the sniff
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, exists := m.sessions[portName]; exists {
+		if session.closed.Load() {
+			return "", nil, ErrPortClosed
+		}
+		att := session.attach(clientID, RoleReadOnly)
+		return att.ID, session, nil
+	}
+	if attachOnly {
+		return "", nil, ErrPortNotOpen
+	}
+
+	session, err := m.openPortLocked(nil, portName, config, clientID, false, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	return session.ID, session, nil
+}
+
+// IsSniff reports whether the session was opened to observe only
+func (s *Session) IsSniff() bool {
+	return s.sniff
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ioErrorPenalty is the health score deducted for each I/O error
+const ioErrorPenalty = 5.0
+
+// Statistics holds a session's counters. Every field is updated atomically
+// or under its own lock, so the counters can be read while I/O is in
+// progress; Snapshot returns a consistent copy.
+type Statistics struct {
+	BytesSent     atomic.Uint64
+	BytesReceived atomic.Uint64
+	Errors        atomic.Uint64
+
+	FramesParsed   atomic.Uint64
+	FramingErrors  atomic.Uint64
+	ChecksumErrors atomic.Uint64
+	FrameBytes     atomic.Uint64
+
+	RateLimited atomic.Uint64
+
+	readLatency   latencyRecorder // Wait between receiving data and a stream taking it
+	writeDuration latencyRecorder // Duration of write calls to the port
+
+	openedAt     atomic.Int64 // Unix nanoseconds
+	lastActivity atomic.Int64 // Unix nanoseconds
+}
+
+// newStatistics returns statistics for a session opened now
+func newStatistics() *Statistics {
+	s := &Statistics{}
+	now := time.Now().UnixNano()
+	s.openedAt.Store(now)
+	s.lastActivity.Store(now)
+	return s
+}
+
+// Touch records activity on the session
+func (s *Statistics) Touch() {
+	s.lastActivity.Store(time.Now().UnixNano())
+}
+
+// Snapshot returns a copy of the counters
+func (s *Statistics) Snapshot() PortStatistics {
+	return PortStatistics{
+		BytesSent:      s.BytesSent.Load(),
+		BytesReceived:  s.BytesReceived.Load(),
+		Errors:         s.Errors.Load(),
+		OpenedAt:       unixNanoTime(s.openedAt.Load()),
+		LastActivity:   unixNanoTime(s.lastActivity.Load()),
+		FramesParsed:   s.FramesParsed.Load(),
+		FramingErrors:  s.FramingErrors.Load(),
+		ChecksumErrors: s.ChecksumErrors.Load(),
+		FrameBytes:     s.FrameBytes.Load(),
+		RateLimited:    s.RateLimited.Load(),
+		ReadLatency:    s.readLatency.stats(),
+		WriteDuration:  s.writeDuration.stats(),
+	}
+}
+
+// recordReceived updates receive statistics, mirrors the data to taps, and
+// feeds the session's framer
+func (s *Session) recordReceived(data []byte) {
+	s.Statistics.BytesReceived.Add(uint64(len(data)))
+	s.lastReceived.Store(time.Now().UnixNano())
+	s.tapData(TapRX, data)
+	s.monitor.publish(s, data)
+
+	s.framerMu.Lock()
+	defer s.framerMu.Unlock()
+
+	if s.framer == nil {
+		return
+	}
+
+	for _, frame := range s.framer.Feed(data) {
+		switch frame.Status {
+		case FrameOK:
+			s.Statistics.FramesParsed.Add(1)
+			s.Statistics.FrameBytes.Add(uint64(len(frame.Data)))
+		case FrameFramingError:
+			s.Statistics.FramingErrors.Add(1)
+		case FrameChecksumError:
+			s.Statistics.ChecksumErrors.Add(1)
+		}
+	}
+}
+
+// recordChecksum counts a response verified against the session checksum.
+// Verified responses count as parsed frames unless a framer already counts
+// the received stream; failures always count as checksum errors.
+func (s *Session) recordChecksum(ok bool, size int) {
+	if !ok {
+		s.Statistics.ChecksumErrors.Add(1)
+		return
+	}
+
+	s.framerMu.Lock()
+	defer s.framerMu.Unlock()
+
+	if s.framer == nil {
+		s.Statistics.FramesParsed.Add(1)
+		s.Statistics.FrameBytes.Add(uint64(size))
+	}
+}
+
+// AverageFrameSize returns the mean size in bytes of successfully parsed frames
+func (s PortStatistics) AverageFrameSize() float64 {
+	if s.FramesParsed == 0 {
+		return 0
+	}
+	return float64(s.FrameBytes) / float64(s.FramesParsed)
+}
+
+// HealthScore rates the link from 0 (unusable) to 100 (clean). The score is
+// the share of frames received intact, reduced by a fixed penalty for each
+// I/O error, so marginal wiring shows up before a link fails outright.
+func (s PortStatistics) HealthScore() float64 {
+	score := 100.0
+
+	bad := s.FramingErrors + s.ChecksumErrors
+	if total := s.FramesParsed + bad; total > 0 {
+		score = 100 * float64(s.FramesParsed) / float64(total)
+	}
+
+	score -= float64(s.Errors) * ioErrorPenalty
+	if score < 0 {
+		score = 0
+	}
+
+	return score
+}
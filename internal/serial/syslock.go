@@ -0,0 +1,67 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+
+	"go.bug.st/serial"
+)
+
+// ErrSystemLocked is returned when another program on the machine holds the
+// port's lock
+var ErrSystemLocked = errors.New("port is locked by another program")
+
+// DefaultLockDir is where UUCP-style lock files are kept when no directory
+// is configured
+const DefaultLockDir = "/var/lock"
+
+// SystemLockSettings controls the OS-level locks taken on opened ports so
+// other programs on the machine cannot use them at the same time
+type SystemLockSettings struct {
+	Enabled bool
+	LockDir string // Directory of LCK..<device> files (empty = DefaultLockDir)
+}
+
+// SetSystemLockSettings configures OS-level locking of opened ports
+func (m *Manager) SetSystemLockSettings(settings SystemLockSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.systemLock = settings
+}
+
+// lockedPort is a port holding an OS-level lock, released when the port
+// is closed
+type lockedPort struct {
+	serial.Port
+	lock *systemLock
+}
+
+// Close closes the port and then releases its lock
+func (p *lockedPort) Close() error {
+	err := p.Port.Close()
+	p.lock.release()
+	return err
+}
+
+// release drops the lock once; it is safe on a nil lock
+func (l *systemLock) release() {
+	if l == nil {
+		return
+	}
+	l.once.Do(l.unlock)
+}
@@ -0,0 +1,135 @@
+//go:build !windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// systemLock holds a UUCP lock file and an flock on the device node. The
+// serial library already sets TIOCEXCL on every port it opens, which keeps
+// out other opens but is not visible to programs that check lock files.
+type systemLock struct {
+	once     sync.Once
+	device   *os.File
+	lockFile string
+}
+
+// acquireSystemLock creates the port's lock file, honoring one left by a
+// running program, and takes an exclusive flock on the device
+func acquireSystemLock(portName string, settings SystemLockSettings) (*systemLock, error) {
+	device := portName
+	if resolved, err := filepath.EvalSymlinks(portName); err == nil {
+		device = resolved
+	}
+
+	dir := settings.LockDir
+	if dir == "" {
+		dir = DefaultLockDir
+	}
+	lockFile := filepath.Join(dir, "LCK.."+filepath.Base(device))
+	if err := createLockFile(lockFile); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(device, os.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		os.Remove(lockFile)
+		if errors.Is(err, unix.EBUSY) {
+			return nil, fmt.Errorf("%w: %s is opened exclusively elsewhere", ErrSystemLocked, device)
+		}
+		return nil, fmt.Errorf("failed to open port: %w", err)
+	}
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		f.Close()
+		os.Remove(lockFile)
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%w: %s is flocked by another process", ErrSystemLocked, device)
+		}
+		return nil, fmt.Errorf("failed to lock port: %w", err)
+	}
+
+	return &systemLock{device: f, lockFile: lockFile}, nil
+}
+
+// unlock closes the flocked descriptor and removes the lock file
+func (l *systemLock) unlock() {
+	l.device.Close()
+	os.Remove(l.lockFile)
+}
+
+// createLockFile writes an HDB UUCP lock file containing the agent's PID.
+// A lock file whose process is no longer running is stale and replaced.
+func createLockFile(path string) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = fmt.Fprintf(f, "%10d\n", os.Getpid())
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return fmt.Errorf("failed to write lock file %s: %w", path, err)
+			}
+			return nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to create lock file %s: %w", path, err)
+		}
+
+		if pid := lockFilePID(path); pid > 0 && processAlive(pid) {
+			return fmt.Errorf("%w: %s is held by process %d", ErrSystemLocked, path, pid)
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove stale lock file %s: %w", path, err)
+		}
+	}
+	return fmt.Errorf("%w: %s was recreated by another process", ErrSystemLocked, path)
+}
+
+// lockFilePID reads the PID from a lock file in either the ASCII (HDB) or
+// the older binary format, returning 0 when it cannot be read
+func lockFilePID(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+		return pid
+	}
+	if len(data) == 4 {
+		return int(data[0]) | int(data[1])<<8 | int(data[2])<<16 | int(data[3])<<24
+	}
+	return 0
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	err := unix.Kill(pid, 0)
+	return err == nil || errors.Is(err, unix.EPERM)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Takeover errors
+var (
+	ErrSessionTakenOver = errors.New("session was taken over by another client")
+	ErrTakeOverDenied   = errors.New("takeover requires admin rights or a higher priority than the current owner")
+)
+
+// TakeOverResult describes a completed takeover
+type TakeOverResult struct {
+	Session          *Session
+	PreviousID       string
+	PreviousClientID string
+}
+
+// TakeOver transfers ownership of an open port to a new client. The previous
+// owner's session ID stops working, its streams are terminated, and pending
+// queued writes are failed; the port stays open and statistics are preserved.
+// Unless force is set, the new client's priority must exceed the owner's.
+func (m *Manager) TakeOver(portName string, clientID string, priority int, exclusive bool, force bool) (*TakeOverResult, error) {
+	m.mu.Lock()
+
+	session, exists := m.sessions[CanonicalPortName(portName)]
+	if !exists {
+		m.mu.Unlock()
+		return nil, ErrPortNotOpen
+	}
+	if session.Managed {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("managed sessions cannot be taken over; attach instead")
+	}
+	if !force && priority <= session.Priority {
+		m.mu.Unlock()
+		return nil, ErrTakeOverDenied
+	}
+
+	result := &TakeOverResult{
+		Session:          session,
+		PreviousID:       session.ID,
+		PreviousClientID: session.ClientID,
+	}
+
+	// Fail writes queued by the previous owner; the new owner gets a fresh queue
+	m.stopWriteQueue(session)
+	session.queueMu.Lock()
+	session.queue = nil
+	session.queueMu.Unlock()
+
+	// Operations already in flight for the previous owner complete normally;
+	// any later use of its session ID fails with ErrSessionTakenOver
+	if m.observer != nil {
+		m.observer.SessionClosed(session)
+	}
+	delete(m.sessionsByID, session.ID)
+	m.takenOver[session.ID] = session
+	session.ID = uuid.New().String()
+	session.ClientID = clientID
+	session.Priority = priority
+	session.Exclusive = exclusive
+	session.touchUsed()
+	session.SetOwner(session.ID, "")
+	m.sessionsByID[session.ID] = session
+	if m.observer != nil {
+		m.observer.SessionOpened(session)
+	}
+
+	m.mu.Unlock()
+
+	m.events.Publish(Event{
+		Type:      EventSessionTerminated,
+		PortName:  portName,
+		SessionID: result.PreviousID,
+		Message:   fmt.Sprintf("session taken over by %s", clientID),
+	})
+
+	return result, nil
+}
+
+// forgetTakenOver drops the record of sessions replaced by a takeover
+// (must be called with lock held)
+func (m *Manager) forgetTakenOver(session *Session) {
+	for id, s := range m.takenOver {
+		if s == session {
+			delete(m.takenOver, id)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Shoaibashk/BaudLink/internal/rotate"
+)
+
+// Tap errors
+var (
+	ErrTapsDisabled = errors.New("traffic taps are disabled")
+	ErrTapNotFound  = errors.New("tap not found")
+	ErrInvalidTap   = errors.New("tap requires exactly one of a file or TCP address")
+)
+
+// tapQueueSize is the number of records a tap buffers before dropping
+const tapQueueSize = 256
+
+// tapRedialInterval is the minimum time between reconnects of a TCP tap
+const tapRedialInterval = 5 * time.Second
+
+// TapDirection selects which traffic a tap mirrors
+type TapDirection int
+
+const (
+	TapRX TapDirection = 1 << iota
+	TapTX
+	TapBoth = TapRX | TapTX
+)
+
+// String returns the record header label of a single direction
+func (d TapDirection) String() string {
+	switch d {
+	case TapRX:
+		return "RX"
+	case TapTX:
+		return "TX"
+	default:
+		return "RX+TX"
+	}
+}
+
+// TapOptions describes where a tap mirrors traffic to. Exactly one of File
+// and TCPAddress must be set.
+type TapOptions struct {
+	Direction  TapDirection
+	File       string // Path relative to the tap directory
+	TCPAddress string // host:port receiving the records
+}
+
+// TapSettings holds the agent-wide limits for taps
+type TapSettings struct {
+	Enabled     bool
+	Directory   string
+	MaxFileSize int64 // Bytes before a tap file is rotated (0 = never)
+	MaxBackups  int   // Rotated files kept per tap
+	AllowTCP    bool
+}
+
+// Tap mirrors a session's traffic to a file or socket. Records are queued
+// and written in the background so a slow destination never delays the
+// session; records that do not fit in the queue are dropped and counted.
+type Tap struct {
+	ID      string
+	Options TapOptions
+
+	records chan tapRecord
+	sink    io.WriteCloser
+	once    sync.Once
+
+	bytesWritten   atomic.Uint64
+	recordsDropped atomic.Uint64
+}
+
+// tapRecord is one chunk of mirrored traffic
+type tapRecord struct {
+	direction TapDirection
+	at        time.Time
+	data      []byte
+}
+
+// BytesWritten returns the number of payload bytes mirrored
+func (t *Tap) BytesWritten() uint64 {
+	return t.bytesWritten.Load()
+}
+
+// RecordsDropped returns the number of records dropped because the
+// destination was slow or unavailable
+func (t *Tap) RecordsDropped() uint64 {
+	return t.recordsDropped.Load()
+}
+
+// SetTapSettings configures where and whether taps may be created
+func (m *Manager) SetTapSettings(settings TapSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tapSettings = settings
+}
+
+// AddTap starts mirroring a session's traffic
+func (m *Manager) AddTap(portName string, sessionID string, opts TapOptions) (*Tap, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	settings := m.tapSettings
+	m.mu.RUnlock()
+
+	if !settings.Enabled {
+		return nil, ErrTapsDisabled
+	}
+	if (opts.File == "") == (opts.TCPAddress == "") {
+		return nil, ErrInvalidTap
+	}
+	if opts.Direction&TapBoth == 0 {
+		opts.Direction = TapBoth
+	}
+
+	var sink io.WriteCloser
+	if opts.File != "" {
+		sink, err = newRotatingFile(settings.Directory, opts.File, settings.MaxFileSize, settings.MaxBackups)
+	} else {
+		if !settings.AllowTCP {
+			return nil, fmt.Errorf("TCP taps are disabled")
+		}
+		sink, err = newTCPSink(opts.TCPAddress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tap := &Tap{
+		ID:      uuid.New().String(),
+		Options: opts,
+		records: make(chan tapRecord, tapQueueSize),
+		sink:    sink,
+	}
+	go tap.run()
+
+	session.tapsMu.Lock()
+	if session.taps == nil {
+		session.taps = make(map[string]*Tap)
+	}
+	session.taps[tap.ID] = tap
+	session.tapsMu.Unlock()
+
+	m.mu.RLock()
+	if m.observer != nil {
+		m.observer.TapAdded(session, tap)
+	}
+	m.mu.RUnlock()
+
+	return tap, nil
+}
+
+// RemoveTap stops a tap and closes its destination
+func (m *Manager) RemoveTap(portName string, sessionID string, tapID string) error {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return err
+	}
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return err
+	}
+
+	session.tapsMu.Lock()
+	tap, exists := session.taps[tapID]
+	delete(session.taps, tapID)
+	session.tapsMu.Unlock()
+
+	if !exists {
+		return ErrTapNotFound
+	}
+
+	m.mu.RLock()
+	if m.observer != nil {
+		m.observer.TapRemoved(session, tap)
+	}
+	m.mu.RUnlock()
+
+	tap.close()
+	return nil
+}
+
+// Taps returns the session's active taps
+func (s *Session) Taps() []*Tap {
+	s.tapsMu.RLock()
+	defer s.tapsMu.RUnlock()
+
+	taps := make([]*Tap, 0, len(s.taps))
+	for _, tap := range s.taps {
+		taps = append(taps, tap)
+	}
+	return taps
+}
+
+// tapData mirrors traffic to every tap interested in its direction
+func (s *Session) tapData(direction TapDirection, data []byte) {
+	s.tapsMu.RLock()
+	defer s.tapsMu.RUnlock()
+
+	if len(s.taps) == 0 || len(data) == 0 {
+		return
+	}
+
+	record := tapRecord{direction: direction, at: time.Now(), data: append([]byte(nil), data...)}
+	for _, tap := range s.taps {
+		if tap.Options.Direction&direction == 0 {
+			continue
+		}
+		select {
+		case tap.records <- record:
+		default:
+			tap.recordsDropped.Add(1)
+		}
+	}
+}
+
+// closeTaps stops all of the session's taps
+func (s *Session) closeTaps() {
+	s.tapsMu.Lock()
+	taps := s.taps
+	s.taps = nil
+	s.tapsMu.Unlock()
+
+	for _, tap := range taps {
+		tap.close()
+	}
+}
+
+// close stops the tap. Queued records are still written in the background
+// before the destination is closed, so callers holding locks never wait on
+// a slow destination.
+func (t *Tap) close() {
+	t.once.Do(func() {
+		close(t.records)
+	})
+}
+
+// run writes queued records to the sink. Each record is a text header line
+// of the form "<RFC 3339 timestamp> <RX|TX> <length>" followed by the raw
+// data and a newline.
+func (t *Tap) run() {
+	defer t.sink.Close()
+
+	for record := range t.records {
+		header := record.at.UTC().Format(time.RFC3339Nano) + " " + record.direction.String() + " " + strconv.Itoa(len(record.data)) + "\n"
+
+		buf := make([]byte, 0, len(header)+len(record.data)+1)
+		buf = append(buf, header...)
+		buf = append(buf, record.data...)
+		buf = append(buf, '\n')
+
+		if _, err := t.sink.Write(buf); err != nil {
+			t.recordsDropped.Add(1)
+			continue
+		}
+		t.bytesWritten.Add(uint64(len(record.data)))
+	}
+}
+
+// newRotatingFile opens name inside dir for appending. The name must be a
+// local path so taps cannot write outside the tap directory.
+func newRotatingFile(dir, name string, maxSize int64, maxBackups int) (*rotate.File, error) {
+	if !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("tap file must be a relative path inside the tap directory: %s", name)
+	}
+	return rotate.Open(filepath.Join(dir, name), maxSize, maxBackups)
+}
+
+// tcpSink sends records to a remote socket, redialing after failures
+type tcpSink struct {
+	address    string
+	conn       net.Conn
+	lastDialed time.Time
+}
+
+// newTCPSink connects to address so that unreachable destinations are
+// reported when the tap is created
+func newTCPSink(address string) (*tcpSink, error) {
+	s := &tcpSink{address: address}
+	if err := s.dial(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// dial connects to the destination
+func (s *tcpSink) dial() error {
+	s.lastDialed = time.Now()
+	conn, err := net.DialTimeout("tcp", s.address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// Write sends p, reconnecting at most every tapRedialInterval while the
+// destination is unavailable
+func (s *tcpSink) Write(p []byte) (int, error) {
+	if s.conn == nil {
+		if time.Since(s.lastDialed) < tapRedialInterval {
+			return 0, errors.New("tap destination unavailable")
+		}
+		if err := s.dial(); err != nil {
+			return 0, err
+		}
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	n, err := s.conn.Write(p)
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return n, err
+}
+
+// Close closes the connection
+func (s *tcpSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
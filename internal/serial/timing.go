@@ -0,0 +1,38 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import "time"
+
+// clockBase is the origin of monotonic timestamps
+var clockBase = time.Now()
+
+// ReceiveTimes records when the first and last bytes of a chunk were read
+// from the port. Bytes read from the port together share a time.
+type ReceiveTimes struct {
+	First time.Time
+	Last  time.Time
+}
+
+// MonotonicNanos returns t as nanoseconds since the agent started, measured
+// on the monotonic clock so intervals are unaffected by wall clock changes
+func MonotonicNanos(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Sub(clockBase).Nanoseconds()
+}
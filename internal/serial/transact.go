@@ -0,0 +1,195 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"bytes"
+	"regexp"
+	"time"
+)
+
+// transactPollInterval bounds each read while collecting a response so the
+// overall transaction timeout is honored
+const transactPollInterval = 50 * time.Millisecond
+
+// TransactOptions describes a command/response exchange
+type TransactOptions struct {
+	Request       []byte
+	Terminator    []byte         // Response ends with the first occurrence of this
+	Pattern       *regexp.Regexp // Response ends with the first match of this
+	ExpectedBytes int            // Response is this many bytes
+	Timeout       time.Duration
+	FlushInput    bool // Discard stale input before sending the request
+}
+
+// TransactResult is the outcome of a transaction
+type TransactResult struct {
+	Data          []byte
+	Matched       bool
+	ChecksumError bool // The response failed checksum verification
+	Elapsed       time.Duration
+}
+
+// responseEnd returns the length of the response at the start of the
+// collected data once the data satisfies the options, or -1. When several
+// conditions are met the response ends with the first one.
+func (o TransactOptions) responseEnd(data []byte) int {
+	end := -1
+	satisfied := func(n int) {
+		if end < 0 || n < end {
+			end = n
+		}
+	}
+
+	if o.ExpectedBytes > 0 && len(data) >= o.ExpectedBytes {
+		satisfied(o.ExpectedBytes)
+	}
+	if len(o.Terminator) > 0 {
+		if i := bytes.Index(data, o.Terminator); i >= 0 {
+			satisfied(i + len(o.Terminator))
+		}
+	}
+	if o.Pattern != nil {
+		if loc := o.Pattern.FindIndex(data); loc != nil {
+			satisfied(loc[1])
+		}
+	}
+	return end
+}
+
+// Transact writes a request and collects the response until the terminator,
+// pattern, or expected byte count is satisfied or the timeout expires. Data
+// received after the end of the response is left for the next read. The
+// session lock is held for the whole exchange so no other read or write can
+// interleave with it.
+func (m *Manager) Transact(portName string, sessionID string, opts TransactOptions) (*TransactResult, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	att := session.attachment(sessionID)
+	if len(opts.Request) > 0 {
+		if att, err = session.checkWritable(sessionID); err != nil {
+			return nil, err
+		}
+		if err := m.checkRateLimit(session, len(opts.Request)); err != nil {
+			return nil, err
+		}
+		if err := m.checkWriteQuota(session, att, len(opts.Request)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !session.Managed && session.IsDisconnected() {
+		return nil, ErrPortDisconnected
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = time.Duration(session.Config.ReadTimeoutMs) * time.Millisecond
+		if opts.Timeout <= 0 {
+			opts.Timeout = time.Second
+		}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if err := session.checkPassthrough(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+
+	// Keep other readers from taking the response
+	session.readMu.Lock()
+	defer session.readMu.Unlock()
+
+	if opts.FlushInput {
+		session.resetInput(att)
+	}
+
+	// The request and response pass through the session's filters like
+	// other writes and reads, the checksum being added after the filters
+	if len(opts.Request) > 0 {
+		opts.Request, err = session.writeFilters.Apply(opts.Request)
+		if err != nil {
+			return nil, err
+		}
+		opts.Request = session.checksum.Append(opts.Request)
+		n, err := session.writeData(opts.Request)
+		if err != nil {
+			session.Statistics.Errors.Add(1)
+			return nil, m.handleDeviceLoss(session, err)
+		}
+		session.recordSent(opts.Request[:n])
+		att.recordSent(n)
+	}
+
+	result := &TransactResult{}
+	pooled := chunkPool.Get().(*[]byte)
+	defer chunkPool.Put(pooled)
+	buffer := *pooled
+
+	for time.Now().Before(deadline) {
+		n, times, err := session.readInputTimed(att, buffer, transactPollInterval)
+		if err != nil {
+			att.recordReceived(len(result.Data))
+			result.Elapsed = time.Since(start)
+			return result, err
+		}
+		if n == 0 {
+			continue
+		}
+
+		result.Data = append(result.Data, buffer[:n]...)
+
+		if end := opts.responseEnd(result.Data); end >= 0 {
+			if rest := result.Data[end:]; len(rest) > 0 {
+				session.unreadInput(att, rest, times.Last)
+				result.Data = result.Data[:end]
+			}
+			result.Matched = true
+			break
+		}
+	}
+	att.recordReceived(len(result.Data))
+
+	// A complete response is a frame: verify and strip its checksum, leaving
+	// failed responses intact for inspection
+	if result.Matched && session.checksum != nil {
+		data, ok := session.checksum.Verify(result.Data)
+		if ok {
+			result.Data = data
+		} else {
+			result.ChecksumError = true
+		}
+		session.recordChecksum(ok, len(data))
+	}
+
+	session.Statistics.Touch()
+	result.Elapsed = time.Since(start)
+
+	if len(result.Data) > 0 {
+		if result.Data, err = session.readPipeline(att).Apply(result.Data); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
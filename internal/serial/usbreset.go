@@ -0,0 +1,32 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import "errors"
+
+var (
+	ErrNotUSB           = errors.New("port is not a USB device")
+	ErrResetUnsupported = errors.New("USB reset is not supported on this platform")
+)
+
+// ResetDevice resets the USB device behind a port, as if it had been
+// unplugged and plugged back in, to recover a wedged adapter. Sessions on
+// the port lose the device; those that reconnect reopen it once it has
+// enumerated again.
+func ResetDevice(portName string) error {
+	return resetUSBDevice(CanonicalPortName(portName))
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// usbdevfsReset is USBDEVFS_RESET, _IO('U', 20)
+const usbdevfsReset = 0x5514
+
+// resetUSBDevice issues USBDEVFS_RESET on the USB device the port's tty
+// belongs to, found by walking up its sysfs device path
+func resetUSBDevice(portName string) error {
+	// Follow /dev/serial/by-id and similar links to the tty itself
+	if resolved, err := filepath.EvalSymlinks(portName); err == nil {
+		portName = resolved
+	}
+
+	dev, err := filepath.EvalSymlinks(filepath.Join(sysClassTTY, filepath.Base(portName), "device"))
+	if err != nil {
+		return ErrNotUSB
+	}
+
+	for dir := dev; dir != "/" && dir != "."; dir = filepath.Dir(dir) {
+		bus, err1 := strconv.Atoi(readSysfs(dir, "busnum"))
+		num, err2 := strconv.Atoi(readSysfs(dir, "devnum"))
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		path := fmt.Sprintf("/dev/bus/usb/%03d/%03d", bus, num)
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if _, err := unix.IoctlRetInt(int(f.Fd()), usbdevfsReset); err != nil {
+			return fmt.Errorf("USB reset of %s failed: %w", path, err)
+		}
+		return nil
+	}
+	return ErrNotUSB
+}
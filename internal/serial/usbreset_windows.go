@@ -0,0 +1,84 @@
+//go:build windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// usbEnumerators are the enumerators of USB serial adapters
+var usbEnumerators = []string{"USB", "FTDIBUS"}
+
+// resetUSBDevice restarts the driver stack of the USB device exposing the
+// port, as devcon restart does
+func resetUSBDevice(portName string) error {
+	for _, enumerator := range usbEnumerators {
+		for _, device := range subKeys(enumRoot + `\` + enumerator) {
+			for _, instance := range subKeys(enumRoot + `\` + enumerator + `\` + device) {
+				id := enumerator + `\` + device + `\` + instance
+				if strings.EqualFold(portNameOf(id), portName) {
+					return restartDevice(id)
+				}
+			}
+		}
+	}
+	return ErrNotUSB
+}
+
+// restartDevice asks the class installer to apply a property change to a
+// present device, which stops and restarts it
+func restartDevice(instanceID string) error {
+	devs, err := windows.SetupDiGetClassDevsEx(nil, "", 0, windows.DIGCF_ALLCLASSES|windows.DIGCF_PRESENT, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to list devices: %w", err)
+	}
+	defer devs.Close()
+
+	for i := 0; ; i++ {
+		data, err := devs.EnumDeviceInfo(i)
+		if errors.Is(err, windows.ERROR_NO_MORE_ITEMS) {
+			return ErrPortNotFound
+		}
+		if err != nil {
+			continue
+		}
+		id, err := windows.SetupDiGetDeviceInstanceId(devs, data)
+		if err != nil || !strings.EqualFold(id, instanceID) {
+			continue
+		}
+
+		params := windows.PropChangeParams{
+			ClassInstallHeader: *windows.MakeClassInstallHeader(windows.DIF_PROPERTYCHANGE),
+			StateChange:        windows.DICS_PROPCHANGE,
+			Scope:              windows.DICS_FLAG_CONFIGSPECIFIC,
+		}
+		if err := devs.SetClassInstallParams(data, &params.ClassInstallHeader, uint32(unsafe.Sizeof(params))); err != nil {
+			return fmt.Errorf("failed to restart %s: %w", instanceID, err)
+		}
+		if err := devs.CallClassInstaller(windows.DIF_PROPERTYCHANGE, data); err != nil {
+			return fmt.Errorf("failed to restart %s: %w", instanceID, err)
+		}
+		return nil
+	}
+}
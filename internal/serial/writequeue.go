@@ -0,0 +1,326 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serial
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrQueueFull is returned when a session's write queue has reached its depth
+var ErrQueueFull = errors.New("write queue is full")
+
+// DefaultWriteQueueDepth is the default maximum number of pending writes per session
+const DefaultWriteQueueDepth = 64
+
+// WriteTicket describes a write waiting in a session's queue
+type WriteTicket struct {
+	ID            string
+	Priority      int
+	CorrelationID string
+	NotBefore     time.Time
+	Data          []byte
+	owner         string // Session or attachment ID that queued the write
+	seq           uint64
+}
+
+// writeQueue orders pending writes by priority, then round-robin between
+// the clients that queued them, then FIFO, and executes them one at a time
+// so that writes from concurrent clients never interleave
+type writeQueue struct {
+	mu      sync.Mutex
+	items   []*WriteTicket
+	depth   int
+	seq     uint64
+	turn    uint64
+	served  map[string]uint64 // key: owner, value: turn it was last served
+	wake    chan struct{}
+	stop    chan struct{}
+	stopped bool
+}
+
+// newWriteQueue creates a write queue and starts its worker
+func newWriteQueue(depth int, execute func(*WriteTicket)) *writeQueue {
+	if depth <= 0 {
+		depth = DefaultWriteQueueDepth
+	}
+
+	q := &writeQueue{
+		depth:  depth,
+		served: make(map[string]uint64),
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+
+	go q.run(execute)
+
+	return q
+}
+
+// push adds a ticket to the queue
+func (q *writeQueue) push(t *WriteTicket) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return ErrPortClosed
+	}
+	if len(q.items) >= q.depth {
+		return ErrQueueFull
+	}
+
+	q.seq++
+	t.seq = q.seq
+	q.items = append(q.items, t)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// len returns the number of pending tickets
+func (q *writeQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// pending returns the number of pending tickets queued by each owner
+func (q *writeQueue) pending() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counts := make(map[string]int)
+	for _, t := range q.items {
+		counts[t.owner]++
+	}
+	return counts
+}
+
+// close stops the worker and returns the tickets that were never executed
+func (q *writeQueue) close() []*WriteTicket {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return nil
+	}
+	q.stopped = true
+	close(q.stop)
+
+	pending := q.items
+	q.items = nil
+	return pending
+}
+
+// next removes and returns the highest-priority ticket that is due, taking
+// turns between owners at equal priority. If no ticket is due, it returns
+// the time until the earliest one becomes due.
+func (q *writeQueue) next(now time.Time) (*WriteTicket, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	best := -1
+	var wait time.Duration = -1
+
+	for i, t := range q.items {
+		if t.NotBefore.After(now) {
+			if d := t.NotBefore.Sub(now); wait < 0 || d < wait {
+				wait = d
+			}
+			continue
+		}
+		if best < 0 || q.before(t, q.items[best]) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return nil, wait
+	}
+
+	t := q.items[best]
+	q.items = append(q.items[:best], q.items[best+1:]...)
+
+	q.turn++
+	q.served[t.owner] = q.turn
+	for _, other := range q.items {
+		if other.owner == t.owner {
+			return t, 0
+		}
+	}
+	// An owner with nothing left queued starts afresh when it queues again
+	delete(q.served, t.owner)
+	return t, 0
+}
+
+// before reports whether ticket a should be written before ticket b: higher
+// priority first, then the owner served least recently, then FIFO
+func (q *writeQueue) before(a, b *WriteTicket) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if sa, sb := q.served[a.owner], q.served[b.owner]; sa != sb {
+		return sa < sb
+	}
+	return a.seq < b.seq
+}
+
+// run executes queued writes until the queue is closed
+func (q *writeQueue) run(execute func(*WriteTicket)) {
+	for {
+		t, wait := q.next(time.Now())
+		if t != nil {
+			execute(t)
+			continue
+		}
+
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+
+		select {
+		case <-q.stop:
+			return
+		case <-q.wake:
+		case <-timer:
+		}
+	}
+}
+
+// QueueWrite enqueues data for ordered delivery on a session. Higher
+// priorities are written first; at equal priority the session owner and
+// each attached client take turns, and each one's writes keep FIFO order.
+// A write-complete event carrying the ticket ID is published once the write
+// has been executed.
+func (m *Manager) QueueWrite(portName string, sessionID string, data []byte, priority int, correlationID string, delay time.Duration) (*WriteTicket, error) {
+	session, err := m.ValidateSession(portName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := session.checkWritable(sessionID); err != nil {
+		return nil, err
+	}
+	if err := session.checkPassthrough(); err != nil {
+		return nil, err
+	}
+
+	ticket := &WriteTicket{
+		ID:            uuid.New().String(),
+		Priority:      priority,
+		CorrelationID: correlationID,
+		Data:          data,
+		owner:         sessionID,
+	}
+	if delay > 0 {
+		ticket.NotBefore = time.Now().Add(delay)
+	}
+
+	if err := session.writeQueue(m).push(ticket); err != nil {
+		return nil, err
+	}
+
+	// The session may have been closed while the ticket was being queued
+	if session.closed.Load() {
+		m.stopWriteQueue(session)
+		return nil, ErrPortClosed
+	}
+
+	return ticket, nil
+}
+
+// QueueDepth returns the number of writes pending on a session
+func (m *Manager) QueueDepth(portName string) int {
+	session := m.GetSession(portName)
+	if session == nil {
+		return 0
+	}
+
+	session.queueMu.Lock()
+	defer session.queueMu.Unlock()
+
+	if session.queue == nil {
+		return 0
+	}
+	return session.queue.len()
+}
+
+// writeQueue returns the session's write queue, creating it on first use
+func (s *Session) writeQueue(m *Manager) *writeQueue {
+	m.mu.RLock()
+	depth := m.writeQueueDepth
+	m.mu.RUnlock()
+
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+
+	if s.queue == nil {
+		s.queue = newWriteQueue(depth, func(t *WriteTicket) {
+			m.executeTicket(s, t)
+		})
+	}
+	return s.queue
+}
+
+// stopWriteQueue stops the session's queue and fails any pending tickets
+func (m *Manager) stopWriteQueue(s *Session) {
+	s.queueMu.Lock()
+	q := s.queue
+	s.queueMu.Unlock()
+
+	if q == nil {
+		return
+	}
+
+	for _, t := range q.close() {
+		m.events.Publish(Event{
+			Type:          EventWriteComplete,
+			PortName:      s.PortName,
+			SessionID:     t.owner,
+			TicketID:      t.ID,
+			CorrelationID: t.CorrelationID,
+			Message:       ErrPortClosed.Error(),
+		})
+	}
+}
+
+// executeTicket performs a queued write and publishes its completion
+func (m *Manager) executeTicket(s *Session, t *WriteTicket) {
+	n, err := m.Write(s.PortName, t.owner, t.Data)
+
+	event := Event{
+		Type:          EventWriteComplete,
+		PortName:      s.PortName,
+		SessionID:     t.owner,
+		TicketID:      t.ID,
+		CorrelationID: t.CorrelationID,
+		BytesWritten:  n,
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+
+	m.events.Publish(event)
+}
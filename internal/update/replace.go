@@ -0,0 +1,86 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Executable returns the resolved path of the running binary
+func Executable() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return filepath.EvalSymlinks(exe)
+}
+
+// Replace atomically swaps the executable at exePath for binary. The new
+// file is written next to the old one and renamed over it, so a failure
+// at any point leaves a working binary in place. Windows cannot replace a
+// running executable, so it is first moved aside to exePath.old, which is
+// removed by the next update.
+func Replace(exePath string, binary []byte) error {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(exePath)+".new-*")
+	if err != nil {
+		return fmt.Errorf("failed to stage update: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to stage update: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to stage update: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to stage update: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to stage update: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		oldPath := exePath + ".old"
+		_ = os.Remove(oldPath)
+		if err := os.Rename(exePath, oldPath); err != nil {
+			return fmt.Errorf("failed to move current binary aside: %w", err)
+		}
+		if err := os.Rename(tmpPath, exePath); err != nil {
+			_ = os.Rename(oldPath, exePath)
+			return fmt.Errorf("failed to install update: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		return fmt.Errorf("failed to install update: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package update replaces the running binary with a signed release
+// published on GitHub
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRepository is the GitHub repository releases are fetched from
+	DefaultRepository = "Shoaibashk/BaudLink"
+
+	// DefaultAPIURL is the GitHub REST API endpoint
+	DefaultAPIURL = "https://api.github.com"
+
+	// ChecksumsFile is the release asset listing SHA-256 sums of the archives
+	ChecksumsFile = "checksums.txt"
+
+	// SignatureFile is the release asset holding the base64 ed25519
+	// signature of the checksums file
+	SignatureFile = ChecksumsFile + ".sig"
+
+	// maxDownloadSize bounds a single asset download
+	maxDownloadSize = 256 << 20
+)
+
+// ErrNoSignature is returned when a release is not signed but a public key
+// is configured
+var ErrNoSignature = errors.New("release is not signed")
+
+// ErrNoPublicKey is returned when there is no public key to verify a
+// release with and unsigned installs are not allowed
+var ErrNoPublicKey = errors.New("no release signing key to verify the update with")
+
+// Release describes a published release
+type Release struct {
+	Tag        string
+	Name       string
+	Prerelease bool
+	Published  time.Time
+	URL        string
+
+	// assets maps asset names to their download URLs
+	assets map[string]string
+}
+
+// Version returns the release tag without its leading "v"
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Updater fetches and verifies releases
+type Updater struct {
+	// Repository is the GitHub repository in owner/name form
+	Repository string
+
+	// APIURL is the GitHub API base URL
+	APIURL string
+
+	// PublicKey verifies the checksums signature. Without it, Download
+	// fails unless AllowUnsigned is set.
+	PublicKey ed25519.PublicKey
+
+	// AllowUnsigned lets Download check releases against their checksums
+	// alone when there is no PublicKey. The checksums come from the same
+	// release, so they catch corruption but not tampering.
+	AllowUnsigned bool
+
+	// Client performs the HTTP requests
+	Client *http.Client
+}
+
+// New creates an updater for the default repository
+func New(publicKey ed25519.PublicKey) *Updater {
+	return &Updater{
+		Repository: DefaultRepository,
+		APIURL:     DefaultAPIURL,
+		PublicKey:  publicKey,
+		Client:     &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// ParsePublicKey decodes a base64 ed25519 public key. An empty string
+// yields a nil key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid update public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// githubRelease is the subset of the GitHub release object we use
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	HTMLURL     string    `json:"html_url"`
+	Assets      []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Latest returns the latest stable release
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	return u.release(ctx, fmt.Sprintf("%s/repos/%s/releases/latest", u.APIURL, u.Repository))
+}
+
+// Tag returns the release with the given tag, adding a leading "v" if missing
+func (u *Updater) Tag(ctx context.Context, tag string) (*Release, error) {
+	if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+	return u.release(ctx, fmt.Sprintf("%s/repos/%s/releases/tags/%s", u.APIURL, u.Repository, tag))
+}
+
+// release fetches and decodes a release from the GitHub API
+func (u *Updater) release(ctx context.Context, url string) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("release not found")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query releases: %s", resp.Status)
+	}
+
+	var gr githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&gr); err != nil {
+		return nil, fmt.Errorf("invalid release response: %w", err)
+	}
+
+	rel := &Release{
+		Tag:        gr.TagName,
+		Name:       gr.Name,
+		Prerelease: gr.Prerelease,
+		Published:  gr.PublishedAt,
+		URL:        gr.HTMLURL,
+		assets:     make(map[string]string, len(gr.Assets)),
+	}
+	for _, a := range gr.Assets {
+		rel.assets[a.Name] = a.BrowserDownloadURL
+	}
+	return rel, nil
+}
+
+// AssetName returns the archive name goreleaser publishes for a platform
+func AssetName(goos, goarch, goarm string) string {
+	arch := goarch
+	switch goarch {
+	case "amd64":
+		arch = "x86_64"
+	case "386":
+		arch = "i386"
+	}
+	if goarch == "arm" && goarm != "" {
+		arch += "v" + goarm
+	}
+
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+
+	return "baudlink_" + strings.ToUpper(goos[:1]) + goos[1:] + "_" + arch + ext
+}
+
+// Download fetches the archive for the running platform, verifies it
+// against the release's checksums and signature, and returns the binary
+// it contains
+func (u *Updater) Download(ctx context.Context, rel *Release) ([]byte, error) {
+	name := AssetName(runtime.GOOS, runtime.GOARCH, goarm())
+	archiveURL, ok := rel.assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no build for %s/%s", rel.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumsURL, ok := rel.assets[ChecksumsFile]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s", rel.Tag, ChecksumsFile)
+	}
+
+	if u.PublicKey == nil && !u.AllowUnsigned {
+		return nil, ErrNoPublicKey
+	}
+
+	checksums, err := u.fetch(ctx, checksumsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.PublicKey != nil {
+		sigURL, ok := rel.assets[SignatureFile]
+		if !ok {
+			return nil, ErrNoSignature
+		}
+		sig, err := u.fetch(ctx, sigURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := VerifySignature(u.PublicKey, checksums, sig); err != nil {
+			return nil, err
+		}
+	}
+
+	archive, err := u.fetch(ctx, archiveURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyChecksum(checksums, name, archive); err != nil {
+		return nil, err
+	}
+
+	return ExtractBinary(name, archive)
+}
+
+// goarm returns the ARM version the running binary was built for
+func goarm() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "GOARM" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
+
+// fetch downloads a release asset
+func (u *Updater) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", path.Base(url), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", path.Base(url), resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", path.Base(url), err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("failed to download %s: too large", path.Base(url))
+	}
+	return data, nil
+}
+
+// VerifySignature checks a base64 ed25519 signature of the checksums file
+func VerifySignature(key ed25519.PublicKey, checksums, sig []byte) error {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !ed25519.Verify(key, checksums, raw) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
+
+// VerifyChecksum checks data against its entry in a sha256sum style
+// checksums file
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		want, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return fmt.Errorf("invalid checksum for %s", name)
+		}
+		got := sha256.Sum256(data)
+		if !bytes.Equal(got[:], want) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum for %s", name)
+}
+
+// ExtractBinary returns the baudlink executable from a release archive
+func ExtractBinary(name string, archive []byte) ([]byte, error) {
+	if strings.HasSuffix(name, ".zip") {
+		return extractZip(archive)
+	}
+	return extractTarGz(archive)
+}
+
+// isBinary reports whether an archive entry is the baudlink executable
+func isBinary(name string) bool {
+	base := path.Base(name)
+	return base == "baudlink" || base == "baudlink.exe"
+}
+
+// extractTarGz extracts the binary from a gzipped tarball
+func extractTarGz(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && isBinary(hdr.Name) {
+			return io.ReadAll(io.LimitReader(tr, maxDownloadSize))
+		}
+	}
+	return nil, fmt.Errorf("archive does not contain the baudlink binary")
+}
+
+// extractZip extracts the binary from a zip archive
+func extractZip(archive []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isBinary(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		defer rc.Close()
+		return io.ReadAll(io.LimitReader(rc, maxDownloadSize))
+	}
+	return nil, fmt.Errorf("archive does not contain the baudlink binary")
+}
+
+// Newer reports whether version a is newer than version b. Both may carry
+// a leading "v"; pre-release suffixes sort before the release itself.
+// Versions that do not parse, such as "dev", are older than any release.
+func Newer(a, b string) bool {
+	av, aok := parseVersion(a)
+	bv, bok := parseVersion(b)
+	if !aok || !bok {
+		return aok && !bok
+	}
+
+	for i := 0; i < 3; i++ {
+		if av.parts[i] != bv.parts[i] {
+			return av.parts[i] > bv.parts[i]
+		}
+	}
+	if av.pre == "" || bv.pre == "" {
+		return av.pre == "" && bv.pre != ""
+	}
+	return av.pre > bv.pre
+}
+
+// semver is a parsed major.minor.patch[-pre] version
+type semver struct {
+	parts [3]int
+	pre   string
+}
+
+// parseVersion parses a semantic version, ignoring build metadata
+func parseVersion(s string) (semver, bool) {
+	var v semver
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+
+	fields := strings.Split(s, ".")
+	if len(fields) != 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.parts[i] = n
+	}
+	return v, true
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// Capabilities is what an agent reports it supports. Agents predating
+// capability reporting report none, so checks against them fail safely.
+type Capabilities struct {
+	list   []*pb.Capability
+	limits *pb.AgentLimits
+}
+
+// Capabilities asks the agent what it supports
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	info, err := c.service.GetAgentInfo(ctx, &pb.GetAgentInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &Capabilities{list: info.Capabilities, limits: info.Limits}, nil
+}
+
+// Version returns the version of a capability, or 0 if the agent does not
+// support it
+func (c *Capabilities) Version(category, name string) uint32 {
+	if capability := c.find(category, name); capability != nil {
+		return capability.Version
+	}
+	return 0
+}
+
+// Supports reports whether the agent supports a capability at minVersion or
+// later and has it enabled
+func (c *Capabilities) Supports(category, name string, minVersion uint32) bool {
+	capability := c.find(category, name)
+	return capability != nil && capability.Enabled && capability.Version >= minVersion
+}
+
+// All returns every capability the agent reported
+func (c *Capabilities) All() []*pb.Capability {
+	return c.list
+}
+
+// MaxChunkSize returns the largest chunk or read size the agent accepts, or
+// 0 if it did not say
+func (c *Capabilities) MaxChunkSize() uint32 {
+	return c.limits.GetMaxChunkSize()
+}
+
+// MaxWriteBytes returns the largest write the agent accepts, or 0 if it did
+// not say
+func (c *Capabilities) MaxWriteBytes() uint32 {
+	return c.limits.GetMaxWriteBytes()
+}
+
+// find returns the named capability, or nil
+func (c *Capabilities) find(category, name string) *pb.Capability {
+	for _, capability := range c.list {
+		if capability.Category == category && capability.Name == name {
+			return capability
+		}
+	}
+	return nil
+}
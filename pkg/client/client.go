@@ -0,0 +1,216 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+	"github.com/Shoaibashk/BaudLink/internal/auth"
+)
+
+// Defaults applied by Dial
+const (
+	DefaultKeepaliveInterval = 30 * time.Second
+	DefaultKeepaliveTimeout  = 10 * time.Second
+	DefaultReconnectWait     = time.Minute
+	DefaultClientID          = "baudlink-go-client"
+)
+
+// Client is a connection to a BaudLink agent
+type Client struct {
+	address string
+	conn    *grpc.ClientConn
+	service pb.SerialServiceClient
+	opts    options
+}
+
+// options holds the settings collected from Dial options
+type options struct {
+	token             string
+	tlsConfig         *tls.Config
+	keepaliveInterval time.Duration
+	keepaliveTimeout  time.Duration
+	clientID          string
+	reconnectWait     time.Duration
+	dialOptions       []grpc.DialOption
+}
+
+// Option configures a Client
+type Option func(*options)
+
+// WithToken authenticates every call with the given access token
+func WithToken(token string) Option {
+	return func(o *options) { o.token = token }
+}
+
+// WithTLS connects over TLS using the given configuration. A nil config
+// uses the system roots.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		if config == nil {
+			config = &tls.Config{}
+		}
+		o.tlsConfig = config
+	}
+}
+
+// WithKeepalive sets how often the connection is pinged while idle and how
+// long to wait for a ping response before the connection is considered dead.
+// A zero interval disables keepalive pings.
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(o *options) {
+		o.keepaliveInterval = interval
+		o.keepaliveTimeout = timeout
+	}
+}
+
+// WithClientID sets the client ID used to lock ports
+func WithClientID(id string) Option {
+	return func(o *options) { o.clientID = id }
+}
+
+// WithReconnect sets how long a Port waits for an unreachable agent before
+// giving up. Zero disables reconnecting.
+func WithReconnect(maxWait time.Duration) Option {
+	return func(o *options) { o.reconnectWait = maxWait }
+}
+
+// WithDialOptions appends raw gRPC dial options
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// Dial connects to the agent at target, which is a host:port address or a
+// baudlink:// connection string. The connection is established lazily, so
+// an unreachable agent is reported by the first call.
+func Dial(target string, opts ...Option) (*Client, error) {
+	o := options{
+		keepaliveInterval: DefaultKeepaliveInterval,
+		keepaliveTimeout:  DefaultKeepaliveTimeout,
+		clientID:          DefaultClientID,
+		reconnectWait:     DefaultReconnectWait,
+	}
+
+	address := target
+	if strings.HasPrefix(target, "baudlink://") {
+		addr, token, err := auth.ParseConnectionString(target)
+		if err != nil {
+			return nil, err
+		}
+		address = addr
+		o.token = token
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var dialOpts []grpc.DialOption
+	if o.tlsConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(o.tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{
+			token:  o.token,
+			secure: o.tlsConfig != nil,
+		}))
+	}
+	if o.keepaliveInterval > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                o.keepaliveInterval,
+			Timeout:             o.keepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	dialOpts = append(dialOpts, o.dialOptions...)
+
+	conn, err := grpc.NewClient(address, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent at %s: %w", address, err)
+	}
+
+	return &Client{
+		address: address,
+		conn:    conn,
+		service: pb.NewSerialServiceClient(conn),
+		opts:    o,
+	}, nil
+}
+
+// Close closes the connection to the agent. Open ports are not closed on
+// the agent; close them first to release them.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Service returns the generated gRPC client, for calls not wrapped by Client
+func (c *Client) Service() pb.SerialServiceClient {
+	return c.service
+}
+
+// Conn returns the underlying gRPC connection, e.g. for proxying calls
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// ListPorts returns the serial ports available on the agent
+func (c *Client) ListPorts(ctx context.Context) ([]*pb.PortInfo, error) {
+	resp, err := c.service.ListPorts(ctx, &pb.ListPortsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ports, nil
+}
+
+// ListPortsIfChanged returns the agent's serial ports and the list's ETag,
+// or nil ports and changed false if the list still matches etag
+func (c *Client) ListPortsIfChanged(ctx context.Context, etag string) (ports []*pb.PortInfo, newETag string, changed bool, err error) {
+	resp, err := c.service.ListPorts(ctx, &pb.ListPortsRequest{IfChangedSince: etag})
+	if err != nil {
+		return nil, "", false, err
+	}
+	if resp.NotModified {
+		return nil, resp.Etag, false, nil
+	}
+	return resp.Ports, resp.Etag, true, nil
+}
+
+// tokenCredentials attaches a bearer token to every RPC
+type tokenCredentials struct {
+	token  string
+	secure bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/Shoaibashk/BaudLink/internal/mdns"
+)
+
+// DiscoveryServiceType is the DNS-SD service type agents advertise
+const DiscoveryServiceType = "_baudlink._tcp"
+
+// Agent is an agent found on the local network
+type Agent struct {
+	// Name is the agent's instance name, by default its host name
+	Name string
+	// Address is the host:port to pass to Dial
+	Address string
+	Version string
+	// TLS reports whether the agent requires TLS
+	TLS bool
+	// Auth reports whether the agent requires an access token
+	Auth bool
+}
+
+// Discover finds agents advertising themselves over mDNS on the local
+// network. It waits until ctx is done, or two seconds if ctx has no
+// deadline, collecting answers.
+func Discover(ctx context.Context) ([]Agent, error) {
+	services, err := mdns.Browse(ctx, DiscoveryServiceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var agents []Agent
+	for _, s := range services {
+		if len(s.Addrs) == 0 {
+			continue
+		}
+		agents = append(agents, Agent{
+			Name:    s.Instance,
+			Address: net.JoinHostPort(s.Addrs[0].String(), strconv.Itoa(s.Port)),
+			Version: s.Text["version"],
+			TLS:     s.Text["tls"] == "true",
+			Auth:    s.Text["auth"] == "true",
+		})
+	}
+	return agents, nil
+}
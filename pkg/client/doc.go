@@ -0,0 +1,48 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client is a Go client library for the BaudLink agent.
+//
+// Dial connects to an agent, optionally with TLS and an access token, and
+// keeps the connection alive with HTTP/2 pings. Open returns a Port, an
+// io.ReadWriteCloser over a port session, so serial traffic can be used with
+// io.Copy, bufio, and the rest of the standard library:
+//
+//	c, err := client.Dial("localhost:50051", client.WithToken(token))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	port, err := c.Open(ctx, "/dev/ttyUSB0", client.WithBaudRate(115200))
+//	if err != nil {
+//		return err
+//	}
+//	defer port.Close()
+//
+//	fmt.Fprintf(port, "AT\r\n")
+//	line, err := bufio.NewReader(port).ReadString('\n')
+//
+// Port also implements net.Conn, with read and write deadlines, and
+// OpenReadWriter returns one as a ReadWriter for code written against a
+// serial port or network connection.
+//
+// Reads are served from a StreamRead stream and writes are sent with the
+// Write RPC; Transact sends a request and collects its response on the
+// agent with the Transact RPC. When the agent becomes unreachable, a Port
+// waits for it to return and resumes its session, reopening the port if the
+// agent lost it. Service exposes the generated gRPC client for calls not wrapped here.
+package client
@@ -0,0 +1,421 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// ErrClosed is returned by writes to a closed Port
+var ErrClosed = errors.New("port closed")
+
+// Reconnect backoff bounds
+const (
+	reconnectInitialDelay = 250 * time.Millisecond
+	reconnectMaxDelay     = 5 * time.Second
+)
+
+// receiveBuffer is how many chunks are received ahead of Read
+const receiveBuffer = 16
+
+// closeTimeout bounds the ClosePort call made by Port.Close
+const closeTimeout = 5 * time.Second
+
+// PortOption configures a port opened with Client.Open
+type PortOption func(*portOptions)
+
+// portOptions holds the settings collected from port options
+type portOptions struct {
+	request   *pb.OpenPortRequest
+	chunkSize uint32
+}
+
+// WithBaudRate opens the port at the given baud rate with 8N1 framing and
+// no flow control
+func WithBaudRate(baud uint32) PortOption {
+	return func(o *portOptions) {
+		o.request.Config = &pb.PortConfig{
+			BaudRate:      baud,
+			DataBits:      pb.DataBits_DATA_BITS_8,
+			StopBits:      pb.StopBits_STOP_BITS_1,
+			Parity:        pb.Parity_PARITY_NONE,
+			FlowControl:   pb.FlowControl_FLOW_CONTROL_NONE,
+			ReadTimeoutMs: 1000,
+		}
+	}
+}
+
+// WithPortConfig opens the port with the given configuration. Without a
+// config the agent applies the matching profile or its defaults.
+func WithPortConfig(config *pb.PortConfig) PortOption {
+	return func(o *portOptions) { o.request.Config = config }
+}
+
+// WithExclusive requests exclusive access to the port
+func WithExclusive() PortOption {
+	return func(o *portOptions) { o.request.Exclusive = true }
+}
+
+// WithPriority sets the session priority used for takeovers
+func WithPriority(priority int32) PortOption {
+	return func(o *portOptions) { o.request.Priority = priority }
+}
+
+// WithDeviceReconnect asks the agent to suspend the session rather than
+// close it when the device is unplugged, and reopen it when it returns
+func WithDeviceReconnect() PortOption {
+	return func(o *portOptions) { o.request.Reconnect = true }
+}
+
+// WithQueueWait waits up to d in the port's queue when another client has
+// it locked, instead of failing at once
+func WithQueueWait(d time.Duration) PortOption {
+	return func(o *portOptions) { o.request.WaitMs = uint32(d.Milliseconds()) }
+}
+
+// WithChunkSize sets the preferred size of chunks read from the agent
+func WithChunkSize(size uint32) PortOption {
+	return func(o *portOptions) { o.chunkSize = size }
+}
+
+// Port is an open port session on the agent. It implements net.Conn, so it
+// can stand in for a network connection or any io.ReadWriteCloser; Read and
+// Write may be called concurrently.
+type Port struct {
+	client    *Client
+	request   *pb.OpenPortRequest
+	chunkSize uint32
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// mu guards sessionID
+	mu        sync.Mutex
+	sessionID string
+
+	// reconnectMu serialises session restores between readers and writers
+	reconnectMu sync.Mutex
+
+	// chunks carries data from the receive loop, which stores its final
+	// error in receiveErr before closing the channel
+	chunks     chan []byte
+	receiveErr error
+
+	// readMu guards the unread remainder of the last chunk
+	readMu  sync.Mutex
+	pending []byte
+
+	readDeadline  *deadline
+	writeDeadline *deadline
+
+	gaps      atomic.Uint64
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Open opens a port on the agent and starts receiving its data
+func (c *Client) Open(ctx context.Context, portName string, opts ...PortOption) (*Port, error) {
+	o := portOptions{
+		request: &pb.OpenPortRequest{
+			PortName: portName,
+			ClientId: c.opts.clientID,
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &Port{
+		client:        c,
+		request:       o.request,
+		chunkSize:     o.chunkSize,
+		chunks:        make(chan []byte, receiveBuffer),
+		readDeadline:  newDeadline(),
+		writeDeadline: newDeadline(),
+	}
+	if err := p.open(ctx); err != nil {
+		return nil, err
+	}
+
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	go p.receive()
+	return p, nil
+}
+
+// Name returns the name of the port
+func (p *Port) Name() string {
+	return p.request.PortName
+}
+
+// SessionID returns the current session ID. It changes when the port is
+// reopened after the agent lost the session.
+func (p *Port) SessionID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sessionID
+}
+
+// Gaps returns how many times received data may have been lost, because the
+// device or the agent was reconnected or the client fell behind the stream
+func (p *Port) Gaps() uint64 {
+	return p.gaps.Load()
+}
+
+// Read reads data received from the port. It blocks until data arrives or
+// the read deadline passes, and returns io.EOF once the port is closed.
+func (p *Port) Read(b []byte) (int, error) {
+	p.readMu.Lock()
+	defer p.readMu.Unlock()
+
+	for len(p.pending) == 0 {
+		if p.ctx.Err() != nil {
+			return 0, io.EOF
+		}
+
+		select {
+		case data, ok := <-p.chunks:
+			if !ok {
+				return 0, p.receiveErr
+			}
+			p.pending = data
+		case <-p.readDeadline.wait():
+			return 0, os.ErrDeadlineExceeded
+		case <-p.ctx.Done():
+			return 0, io.EOF
+		}
+	}
+
+	n := copy(b, p.pending)
+	p.pending = p.pending[n:]
+	return n, nil
+}
+
+// Write writes data to the port. It returns os.ErrDeadlineExceeded if the
+// write deadline passes first, in which case the data may still be written.
+func (p *Port) Write(b []byte) (int, error) {
+	expired := p.writeDeadline.wait()
+	if isClosed(expired) {
+		return 0, os.ErrDeadlineExceeded
+	}
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-expired:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		resp, err := p.client.service.Write(ctx, &pb.WriteRequest{
+			PortName:  p.request.PortName,
+			SessionId: p.SessionID(),
+			Data:      b,
+		})
+		if err == nil {
+			if !resp.Success {
+				return int(resp.BytesWritten), fmt.Errorf("write failed: %s", resp.Message)
+			}
+			if int(resp.BytesWritten) < len(b) {
+				return int(resp.BytesWritten), io.ErrShortWrite
+			}
+			return len(b), nil
+		}
+
+		if err := p.reconnect(ctx, err); err != nil {
+			switch {
+			case p.ctx.Err() != nil:
+				return 0, ErrClosed
+			case ctx.Err() != nil:
+				return 0, os.ErrDeadlineExceeded
+			}
+			return 0, err
+		}
+	}
+}
+
+// Close stops reading and closes the port on the agent. Pending reads
+// return io.EOF.
+func (p *Port) Close() error {
+	p.closeOnce.Do(func() {
+		p.cancel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+
+		resp, err := p.client.service.ClosePort(ctx, &pb.ClosePortRequest{
+			PortName:  p.request.PortName,
+			SessionId: p.SessionID(),
+		})
+		if err != nil {
+			p.closeErr = err
+		} else if !resp.Success {
+			p.closeErr = fmt.Errorf("failed to close port: %s", resp.Message)
+		}
+	})
+	return p.closeErr
+}
+
+// open opens the port on the agent and records the new session
+func (p *Port) open(ctx context.Context) error {
+	resp, err := p.client.service.OpenPort(ctx, p.request)
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to open port: %s", resp.Message)
+	}
+
+	p.mu.Lock()
+	p.sessionID = resp.SessionId
+	p.mu.Unlock()
+	return nil
+}
+
+// receive streams the port's data into the chunks channel until the port is
+// closed, restarting the stream after the agent is reconnected
+func (p *Port) receive() {
+	defer close(p.chunks)
+
+	for {
+		err := p.stream()
+		if p.ctx.Err() != nil || err == io.EOF {
+			// The stream ends cleanly when the agent closes the port
+			p.receiveErr = io.EOF
+			return
+		}
+		if err := p.reconnect(p.ctx, err); err != nil {
+			p.receiveErr = err
+			if p.ctx.Err() != nil {
+				p.receiveErr = io.EOF
+			}
+			return
+		}
+		p.gaps.Add(1)
+	}
+}
+
+// stream runs a single read stream, returning the error that ended it
+func (p *Port) stream() error {
+	ctx, cancel := context.WithCancel(p.ctx)
+	defer cancel()
+
+	stream, err := p.client.service.StreamRead(ctx, &pb.StreamReadRequest{
+		PortName:  p.request.PortName,
+		SessionId: p.SessionID(),
+		ChunkSize: p.chunkSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if chunk.Gap || chunk.Overflow != nil {
+			p.gaps.Add(1)
+		}
+		if len(chunk.Data) == 0 {
+			continue
+		}
+
+		select {
+		case p.chunks <- chunk.Data:
+		case <-p.ctx.Done():
+			return p.ctx.Err()
+		}
+	}
+}
+
+// reconnect waits for an unreachable agent to return and restores the
+// session, reopening the port if the agent no longer has it. Errors other
+// than an unreachable agent are returned unchanged.
+func (p *Port) reconnect(ctx context.Context, err error) error {
+	wait := p.client.opts.reconnectWait
+	if wait <= 0 || status.Code(err) != codes.Unavailable {
+		return err
+	}
+
+	p.mu.Lock()
+	sessionID := p.sessionID
+	p.mu.Unlock()
+
+	deadline := time.Now().Add(wait)
+	delay := reconnectInitialDelay
+
+	for {
+		err = p.restore(ctx, sessionID)
+		if err == nil {
+			return nil
+		}
+		if status.Code(err) != codes.Unavailable || time.Now().After(deadline) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, reconnectMaxDelay)
+	}
+}
+
+// restore makes sure the agent holds the session last seen as sessionID,
+// opening the port again if it does not
+func (p *Port) restore(ctx context.Context, sessionID string) error {
+	p.reconnectMu.Lock()
+	defer p.reconnectMu.Unlock()
+
+	p.mu.Lock()
+	current := p.sessionID
+	p.mu.Unlock()
+	if current != sessionID {
+		// Another caller already reopened the port
+		return nil
+	}
+
+	portStatus, err := p.client.service.GetPortStatus(ctx, &pb.GetPortStatusRequest{
+		PortName: p.request.PortName,
+	})
+	if err != nil {
+		return err
+	}
+	if portStatus.IsOpen && portStatus.SessionId == sessionID {
+		return nil
+	}
+
+	return p.open(ctx)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// Port satisfies net.Conn so it can be passed to code written for network
+// connections
+var _ net.Conn = (*Port)(nil)
+
+// ReadWriter is a remote port used as a plain byte stream, with net.Conn
+// style deadlines
+type ReadWriter interface {
+	io.ReadWriteCloser
+	SetDeadline(t time.Time) error
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// OpenReadWriter opens a port with the given configuration and returns it as
+// a ReadWriter, for code that expects an io.ReadWriteCloser or a net.Conn
+// rather than the BaudLink API. The port is opened exclusively, as a local
+// serial port would be. A nil config applies the agent's profile or defaults
+// for the port.
+func (c *Client) OpenReadWriter(ctx context.Context, portName string, config *pb.PortConfig) (ReadWriter, error) {
+	return c.Open(ctx, portName, WithPortConfig(config), WithExclusive())
+}
+
+// Addr is the network address of a port on an agent
+type Addr struct {
+	Agent string
+	Port  string
+}
+
+// Network returns the address network name
+func (a Addr) Network() string {
+	return "baudlink"
+}
+
+// String returns the address as agent/port
+func (a Addr) String() string {
+	if a.Port == "" {
+		return a.Agent
+	}
+	return a.Agent + "/" + a.Port
+}
+
+// LocalAddr returns the address of the client
+func (p *Port) LocalAddr() net.Addr {
+	return Addr{Agent: p.client.opts.clientID}
+}
+
+// RemoteAddr returns the agent address and port name
+func (p *Port) RemoteAddr() net.Addr {
+	return Addr{Agent: p.client.address, Port: p.request.PortName}
+}
+
+// SetDeadline sets both the read and write deadlines
+func (p *Port) SetDeadline(t time.Time) error {
+	p.readDeadline.set(t)
+	p.writeDeadline.set(t)
+	return nil
+}
+
+// SetReadDeadline sets the deadline for pending and future reads. A zero
+// time means reads do not time out.
+func (p *Port) SetReadDeadline(t time.Time) error {
+	p.readDeadline.set(t)
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for pending and future writes. A zero
+// time means writes do not time out.
+func (p *Port) SetWriteDeadline(t time.Time) error {
+	p.writeDeadline.set(t)
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Shoaibashk/BaudLink/api/proto"
+)
+
+// ErrIncomplete is returned by Transact when the response is incomplete
+// once the timeout expires
+var ErrIncomplete = errors.New("incomplete response")
+
+// TransactOption configures a Transact call
+type TransactOption func(*pb.TransactRequest)
+
+// WithTerminator ends the response with the first occurrence of terminator
+func WithTerminator(terminator []byte) TransactOption {
+	return func(r *pb.TransactRequest) { r.Terminator = terminator }
+}
+
+// WithPattern ends the response with the first match of a regular
+// expression
+func WithPattern(pattern string) TransactOption {
+	return func(r *pb.TransactRequest) { r.Pattern = pattern }
+}
+
+// WithExpectedBytes ends the response after n bytes
+func WithExpectedBytes(n int) TransactOption {
+	return func(r *pb.TransactRequest) { r.ExpectedBytes = uint32(n) }
+}
+
+// WithResponseTimeout bounds the whole exchange. Without it the agent waits
+// for the port's read timeout.
+func WithResponseTimeout(d time.Duration) TransactOption {
+	return func(r *pb.TransactRequest) { r.TimeoutMs = uint32(d.Milliseconds()) }
+}
+
+// WithFlushInput discards data received before the request
+func WithFlushInput() TransactOption {
+	return func(r *pb.TransactRequest) { r.FlushInput = true }
+}
+
+// Transact writes request with the Transact RPC and returns the response the
+// agent collects until it ends with the terminator, the pattern, or the
+// expected byte count, whichever comes first; no other client's reads or
+// writes interleave with the exchange. Data received after the response is
+// left for Read. Data received before the request but not read yet is not
+// part of the response; WithFlushInput discards it. If the timeout expires
+// first, the partial response is returned with ErrIncomplete.
+func (p *Port) Transact(ctx context.Context, request []byte, opts ...TransactOption) ([]byte, error) {
+	req := &pb.TransactRequest{
+		PortName:  p.request.PortName,
+		SessionId: p.SessionID(),
+		Data:      request,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	if req.FlushInput {
+		p.discardPending()
+	}
+
+	resp, err := p.client.service.Transact(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Success {
+		return resp.Data, nil
+	}
+	// Only a transaction that ran reports its elapsed time
+	if !resp.Matched && resp.ElapsedMs > 0 {
+		return resp.Data, ErrIncomplete
+	}
+	return resp.Data, fmt.Errorf("transact failed: %s", resp.Message)
+}
+
+// discardPending drops received data not read yet
+func (p *Port) discardPending() {
+	p.readMu.Lock()
+	defer p.readMu.Unlock()
+
+	p.pending = nil
+	for {
+		select {
+		case _, ok := <-p.chunks:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 BaudLink Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using BaudLink.Client.Proto;
+using Grpc.Core;
+using Grpc.Core.Interceptors;
+using Grpc.Net.Client;
+
+namespace BaudLink.Client;
+
+/// <summary>Settings for connecting to an agent.</summary>
+public sealed class BaudLinkClientOptions
+{
+    /// <summary>Access token for agents with authentication enabled.</summary>
+    public string? Token { get; set; }
+
+    /// <summary>Connect with TLS.</summary>
+    public bool Tls { get; set; }
+
+    /// <summary>Identifies the client in locks and audit records.</summary>
+    public string ClientId { get; set; } = BaudLinkClient.DefaultClientId;
+
+    /// <summary>
+    /// Handler for the underlying HTTP/2 connection, for example to trust
+    /// the agent's self-signed certificate.
+    /// </summary>
+    public HttpMessageHandler? HttpHandler { get; set; }
+}
+
+/// <summary>A connection to a BaudLink agent.</summary>
+public sealed class BaudLinkClient : IDisposable
+{
+    /// <summary>Address used when none is given.</summary>
+    public const string DefaultAddress = "localhost:50051";
+
+    /// <summary>Client ID used when none is given.</summary>
+    public const string DefaultClientId = "baudlink-dotnet-client";
+
+    private readonly GrpcChannel _channel;
+
+    /// <summary>
+    /// Connects to an agent at a host:port address or baudlink:// connection
+    /// string, which may carry the token. The connection is made by the
+    /// first call.
+    /// </summary>
+    public BaudLinkClient(string target = DefaultAddress, BaudLinkClientOptions? options = null)
+    {
+        options ??= new BaudLinkClientOptions();
+        var (address, linkToken) = ParseTarget(target);
+        var token = options.Token ?? linkToken;
+
+        _channel = GrpcChannel.ForAddress(
+            (options.Tls ? "https://" : "http://") + address,
+            new GrpcChannelOptions { HttpHandler = options.HttpHandler });
+
+        CallInvoker invoker = _channel.CreateCallInvoker();
+        if (!string.IsNullOrEmpty(token))
+        {
+            invoker = invoker.Intercept(metadata =>
+            {
+                metadata.Add("authorization", "Bearer " + token);
+                return metadata;
+            });
+        }
+
+        Service = new SerialService.SerialServiceClient(invoker);
+        ClientId = options.ClientId;
+    }
+
+    /// <summary>Generated client for RPCs the wrapper does not cover.</summary>
+    public SerialService.SerialServiceClient Service { get; }
+
+    /// <summary>Identifies the client in locks and audit records.</summary>
+    public string ClientId { get; }
+
+    /// <summary>Returns the serial ports available on the agent.</summary>
+    public async Task<IReadOnlyList<PortInfo>> ListPortsAsync(CancellationToken cancellationToken = default)
+    {
+        var resp = await Service.ListPortsAsync(new ListPortsRequest(), cancellationToken: cancellationToken).ConfigureAwait(false);
+        return resp.Ports;
+    }
+
+    /// <summary>Returns the agent's version, capabilities, and limits.</summary>
+    public async Task<AgentInfo> GetAgentInfoAsync(CancellationToken cancellationToken = default)
+    {
+        return await Service.GetAgentInfoAsync(new GetAgentInfoRequest(), cancellationToken: cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <summary>
+    /// Reports whether the agent supports and enables a capability at
+    /// minVersion or later, such as ("framing", "nmea").
+    /// </summary>
+    public async Task<bool> SupportsAsync(string category, string name, uint minVersion = 1, CancellationToken cancellationToken = default)
+    {
+        var info = await GetAgentInfoAsync(cancellationToken).ConfigureAwait(false);
+        var capability = info.Capabilities.FirstOrDefault(c => c.Category == category && c.Name == name);
+        return capability is not null && capability.Enabled && capability.Version >= minVersion;
+    }
+
+    /// <summary>Creates a closed port; set its properties and call Open.</summary>
+    public BaudLinkSerialPort CreatePort(string portName, int baudRate = 9600)
+    {
+        return new BaudLinkSerialPort(this, portName) { BaudRate = baudRate };
+    }
+
+    /// <summary>
+    /// Closes the connection. Open ports are not closed on the agent; close
+    /// them first to release them.
+    /// </summary>
+    public void Dispose()
+    {
+        _channel.Dispose();
+    }
+
+    private static (string Address, string? Token) ParseTarget(string target)
+    {
+        const string scheme = "baudlink://";
+        if (!target.StartsWith(scheme, StringComparison.Ordinal))
+        {
+            return (target, null);
+        }
+
+        var rest = target[scheme.Length..];
+        var query = "";
+        var q = rest.IndexOf('?');
+        if (q >= 0)
+        {
+            query = rest[(q + 1)..];
+            rest = rest[..q];
+        }
+
+        string? token = null;
+        foreach (var pair in query.Split('&', StringSplitOptions.RemoveEmptyEntries))
+        {
+            var kv = pair.Split('=', 2);
+            if (kv.Length == 2 && kv[0] == "token")
+            {
+                token = Uri.UnescapeDataString(kv[1]);
+            }
+        }
+        return (rest.TrimEnd('/'), token);
+    }
+}
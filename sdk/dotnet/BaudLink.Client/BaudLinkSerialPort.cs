@@ -0,0 +1,416 @@
+// Copyright 2024 BaudLink Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+using System.Text;
+using BaudLink.Client.Proto;
+using Google.Protobuf;
+using Grpc.Core;
+
+namespace BaudLink.Client;
+
+/// <summary>Data arrival reported by <see cref="BaudLinkSerialPort.DataReceived"/>.</summary>
+public sealed class BaudLinkDataReceivedEventArgs : EventArgs
+{
+    internal BaudLinkDataReceivedEventArgs(int bytesReceived) => BytesReceived = bytesReceived;
+
+    /// <summary>Number of bytes that arrived.</summary>
+    public int BytesReceived { get; }
+}
+
+/// <summary>Stream failure reported by <see cref="BaudLinkSerialPort.ErrorReceived"/>.</summary>
+public sealed class BaudLinkErrorEventArgs : EventArgs
+{
+    internal BaudLinkErrorEventArgs(Exception exception) => Exception = exception;
+
+    /// <summary>Why the port stopped receiving.</summary>
+    public Exception Exception { get; }
+}
+
+/// <summary>
+/// A port on a BaudLink agent, shaped like System.IO.Ports.SerialPort so
+/// existing code ports over with few changes. Received data is streamed
+/// into a local buffer in the background and announced by DataReceived;
+/// reads take from that buffer. Timeouts are in milliseconds.
+/// </summary>
+public sealed class BaudLinkSerialPort : IDisposable
+{
+    private static readonly TimeSpan CloseTimeout = TimeSpan.FromSeconds(5);
+
+    private readonly BaudLinkClient _client;
+
+    // _lock guards the receive state and is pulsed when it changes
+    private readonly object _lock = new();
+    private readonly List<byte> _buffer = new();
+    private bool _ended = true;
+    private Exception? _error;
+
+    private CancellationTokenSource? _cts;
+    private Task? _receiver;
+
+    internal BaudLinkSerialPort(BaudLinkClient client, string portName)
+    {
+        _client = client;
+        PortName = portName;
+    }
+
+    /// <summary>Name of the port on the agent, such as COM3 or /dev/ttyUSB0.</summary>
+    public string PortName { get; }
+
+    /// <summary>Baud rate applied by Open.</summary>
+    public int BaudRate { get; set; } = 9600;
+
+    /// <summary>Data bits (5 to 8) applied by Open.</summary>
+    public int DataBits { get; set; } = 8;
+
+    /// <summary>Parity applied by Open.</summary>
+    public Parity Parity { get; set; } = Parity.None;
+
+    /// <summary>Stop bits applied by Open.</summary>
+    public StopBits StopBits { get; set; } = StopBits._1;
+
+    /// <summary>Flow control applied by Open.</summary>
+    public FlowControl Handshake { get; set; } = FlowControl.None;
+
+    /// <summary>
+    /// Full port configuration, used instead of the individual settings
+    /// when set.
+    /// </summary>
+    public PortConfig? Config { get; set; }
+
+    /// <summary>Request exclusive access to the port.</summary>
+    public bool Exclusive { get; set; }
+
+    /// <summary>Line terminator used by ReadLine and WriteLine.</summary>
+    public string NewLine { get; set; } = "\n";
+
+    /// <summary>Encoding of text read and written.</summary>
+    public Encoding Encoding { get; set; } = Encoding.ASCII;
+
+    /// <summary>How long reads wait for data; Timeout.Infinite waits indefinitely.</summary>
+    public int ReadTimeout { get; set; } = Timeout.Infinite;
+
+    /// <summary>How long writes wait for the agent; Timeout.Infinite waits indefinitely.</summary>
+    public int WriteTimeout { get; set; } = Timeout.Infinite;
+
+    /// <summary>Session ID on the agent while the port is open.</summary>
+    public string? SessionId { get; private set; }
+
+    /// <summary>Whether the port is open.</summary>
+    public bool IsOpen => SessionId is not null;
+
+    /// <summary>Number of received bytes not read yet.</summary>
+    public int BytesToRead
+    {
+        get
+        {
+            lock (_lock)
+            {
+                return _buffer.Count;
+            }
+        }
+    }
+
+    /// <summary>
+    /// Raised on a background thread when data arrives. Handlers run before
+    /// the next data is received, so they should return quickly.
+    /// </summary>
+    public event EventHandler<BaudLinkDataReceivedEventArgs>? DataReceived;
+
+    /// <summary>Raised when the port stops receiving because of an error.</summary>
+    public event EventHandler<BaudLinkErrorEventArgs>? ErrorReceived;
+
+    /// <summary>Opens the port on the agent and starts receiving its data.</summary>
+    public void Open() => OpenAsync().GetAwaiter().GetResult();
+
+    /// <summary>Opens the port on the agent and starts receiving its data.</summary>
+    public async Task OpenAsync(CancellationToken cancellationToken = default)
+    {
+        if (IsOpen)
+        {
+            throw new InvalidOperationException("the port is already open");
+        }
+
+        var resp = await _client.Service.OpenPortAsync(new OpenPortRequest
+        {
+            PortName = PortName,
+            ClientId = _client.ClientId,
+            Exclusive = Exclusive,
+            Config = Config ?? new PortConfig
+            {
+                BaudRate = (uint)BaudRate,
+                DataBits = (Proto.DataBits)DataBits,
+                StopBits = StopBits,
+                Parity = Parity,
+                FlowControl = Handshake,
+                ReadTimeoutMs = 1000,
+            },
+        }, cancellationToken: cancellationToken).ConfigureAwait(false);
+        if (!resp.Success)
+        {
+            throw new IOException($"failed to open port: {resp.Message}");
+        }
+
+        lock (_lock)
+        {
+            _buffer.Clear();
+            _ended = false;
+            _error = null;
+        }
+        SessionId = resp.SessionId;
+        _cts = new CancellationTokenSource();
+        _receiver = Task.Run(() => ReceiveAsync(resp.SessionId, _cts.Token));
+    }
+
+    private async Task ReceiveAsync(string sessionId, CancellationToken cancellationToken)
+    {
+        try
+        {
+            using var call = _client.Service.StreamRead(new StreamReadRequest
+            {
+                PortName = PortName,
+                SessionId = sessionId,
+            }, cancellationToken: cancellationToken);
+
+            await foreach (var chunk in call.ResponseStream.ReadAllAsync(cancellationToken).ConfigureAwait(false))
+            {
+                if (chunk.Data.IsEmpty)
+                {
+                    continue;
+                }
+                lock (_lock)
+                {
+                    _buffer.AddRange(chunk.Data.ToByteArray());
+                    Monitor.PulseAll(_lock);
+                }
+                DataReceived?.Invoke(this, new BaudLinkDataReceivedEventArgs(chunk.Data.Length));
+            }
+        }
+        catch (Exception) when (cancellationToken.IsCancellationRequested)
+        {
+            // Closed
+        }
+        catch (Exception e)
+        {
+            lock (_lock)
+            {
+                _error = e;
+            }
+            ErrorReceived?.Invoke(this, new BaudLinkErrorEventArgs(e));
+        }
+        finally
+        {
+            lock (_lock)
+            {
+                _ended = true;
+                Monitor.PulseAll(_lock);
+            }
+        }
+    }
+
+    // WaitFor waits with _lock held until ready returns true, throwing
+    // TimeoutException once ReadTimeout expires
+    private void WaitFor(Func<bool> ready)
+    {
+        long? deadline = ReadTimeout == Timeout.Infinite ? null : Environment.TickCount64 + ReadTimeout;
+        while (!ready())
+        {
+            if (_ended)
+            {
+                if (_error is not null)
+                {
+                    throw new IOException("the port stopped receiving", _error);
+                }
+                throw new InvalidOperationException("the port is not open");
+            }
+
+            if (deadline is null)
+            {
+                Monitor.Wait(_lock);
+                continue;
+            }
+            var remaining = deadline.Value - Environment.TickCount64;
+            if (remaining <= 0)
+            {
+                throw new TimeoutException("the read timed out");
+            }
+            Monitor.Wait(_lock, (int)remaining);
+        }
+    }
+
+    private byte[] Take(int count)
+    {
+        var data = _buffer.GetRange(0, count).ToArray();
+        _buffer.RemoveRange(0, count);
+        return data;
+    }
+
+    private int IndexOf(byte[] value)
+    {
+        for (var i = 0; i + value.Length <= _buffer.Count; i++)
+        {
+            var j = 0;
+            while (j < value.Length && _buffer[i + j] == value[j])
+            {
+                j++;
+            }
+            if (j == value.Length)
+            {
+                return i;
+            }
+        }
+        return -1;
+    }
+
+    /// <summary>
+    /// Reads up to count bytes into buffer, waiting for at least one, and
+    /// returns the number read.
+    /// </summary>
+    public int Read(byte[] buffer, int offset, int count)
+    {
+        lock (_lock)
+        {
+            WaitFor(() => _buffer.Count > 0);
+            var data = Take(Math.Min(count, _buffer.Count));
+            data.CopyTo(buffer, offset);
+            return data.Length;
+        }
+    }
+
+    /// <summary>Reads one byte, waiting for it to arrive.</summary>
+    public int ReadByte()
+    {
+        lock (_lock)
+        {
+            WaitFor(() => _buffer.Count > 0);
+            return Take(1)[0];
+        }
+    }
+
+    /// <summary>Returns all received text not read yet without waiting.</summary>
+    public string ReadExisting()
+    {
+        lock (_lock)
+        {
+            return Encoding.GetString(Take(_buffer.Count));
+        }
+    }
+
+    /// <summary>Reads up to the next NewLine, which is consumed but not returned.</summary>
+    public string ReadLine() => ReadTo(NewLine);
+
+    /// <summary>Reads up to value, which is consumed but not returned.</summary>
+    public string ReadTo(string value)
+    {
+        var terminator = Encoding.GetBytes(value);
+        lock (_lock)
+        {
+            WaitFor(() => IndexOf(terminator) >= 0);
+            var data = Take(IndexOf(terminator) + terminator.Length);
+            return Encoding.GetString(data, 0, data.Length - terminator.Length);
+        }
+    }
+
+    /// <summary>Discards received data not read yet.</summary>
+    public void DiscardInBuffer()
+    {
+        lock (_lock)
+        {
+            _buffer.Clear();
+        }
+    }
+
+    /// <summary>Writes count bytes from buffer to the port.</summary>
+    public void Write(byte[] buffer, int offset, int count) =>
+        WriteAsync(buffer.AsMemory(offset, count)).GetAwaiter().GetResult();
+
+    /// <summary>Writes text to the port.</summary>
+    public void Write(string text)
+    {
+        var data = Encoding.GetBytes(text);
+        Write(data, 0, data.Length);
+    }
+
+    /// <summary>Writes text followed by NewLine to the port.</summary>
+    public void WriteLine(string text) => Write(text + NewLine);
+
+    /// <summary>Writes data to the port.</summary>
+    public async Task WriteAsync(ReadOnlyMemory<byte> data, CancellationToken cancellationToken = default)
+    {
+        var sessionId = SessionId ?? throw new InvalidOperationException("the port is not open");
+        DateTime? deadline = WriteTimeout == Timeout.Infinite ? null : DateTime.UtcNow.AddMilliseconds(WriteTimeout);
+
+        WriteResponse resp;
+        try
+        {
+            resp = await _client.Service.WriteAsync(new WriteRequest
+            {
+                PortName = PortName,
+                SessionId = sessionId,
+                Data = ByteString.CopyFrom(data.Span),
+            }, deadline: deadline, cancellationToken: cancellationToken).ConfigureAwait(false);
+        }
+        catch (RpcException e) when (e.StatusCode == StatusCode.DeadlineExceeded)
+        {
+            throw new TimeoutException("the write timed out", e);
+        }
+        if (!resp.Success)
+        {
+            throw new IOException($"write failed: {resp.Message}");
+        }
+    }
+
+    /// <summary>Stops receiving and closes the port on the agent.</summary>
+    public void Close() => CloseAsync().GetAwaiter().GetResult();
+
+    /// <summary>Stops receiving and closes the port on the agent.</summary>
+    public async Task CloseAsync()
+    {
+        var sessionId = SessionId;
+        if (sessionId is null)
+        {
+            return;
+        }
+        SessionId = null;
+
+        _cts?.Cancel();
+        if (_receiver is not null)
+        {
+            await _receiver.ConfigureAwait(false);
+        }
+        _cts?.Dispose();
+        _cts = null;
+
+        var resp = await _client.Service.ClosePortAsync(new ClosePortRequest
+        {
+            PortName = PortName,
+            SessionId = sessionId,
+        }, deadline: DateTime.UtcNow.Add(CloseTimeout)).ConfigureAwait(false);
+        if (!resp.Success)
+        {
+            throw new IOException($"failed to close port: {resp.Message}");
+        }
+    }
+
+    /// <summary>Closes the port, ignoring errors from the agent.</summary>
+    public void Dispose()
+    {
+        try
+        {
+            Close();
+        }
+        catch (Exception e) when (e is RpcException or IOException)
+        {
+        }
+    }
+}
@@ -0,0 +1,33 @@
+# Copyright 2024 BaudLink Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Python client for the BaudLink serial port agent.
+
+    import baudlink
+
+    with baudlink.Client("localhost:50051") as client:
+        with client.open("/dev/ttyUSB0", baud_rate=115200) as port:
+            print(port.transact(b"AT\r", terminator=b"OK\r\n"))
+
+The generated gRPC stubs are available as ``baudlink.pb`` and
+``baudlink.pb_grpc`` for RPCs the wrapper does not cover.
+"""
+
+from baudlink._proto import serial_pb2 as pb
+from baudlink._proto import serial_pb2_grpc as pb_grpc
+from baudlink.client import BaudLinkError, Client, Port
+
+__version__ = "0.0.0"  # Set from the release tag when publishing
+
+__all__ = ["BaudLinkError", "Client", "Port", "pb", "pb_grpc"]
@@ -0,0 +1,2 @@
+# Code generated from api/proto/serial.proto by `make proto-python`; the
+# generated modules are not checked in.
@@ -0,0 +1,307 @@
+# Copyright 2024 BaudLink Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Client and port classes mirroring the Go client in pkg/client."""
+
+import re
+import threading
+from typing import List, Optional, Pattern, Union
+from urllib.parse import parse_qs, urlparse
+
+import grpc
+
+from baudlink._proto import serial_pb2 as pb
+from baudlink._proto import serial_pb2_grpc as pb_grpc
+
+DEFAULT_ADDRESS = "localhost:50051"
+DEFAULT_CLIENT_ID = "baudlink-python-client"
+
+# Bounds the ClosePort call made by Port.close
+CLOSE_TIMEOUT = 5.0
+
+Data = Union[bytes, bytearray, str]
+
+
+class BaudLinkError(Exception):
+    """An operation the agent reported as failed."""
+
+
+def _to_bytes(data: Data) -> bytes:
+    return data.encode() if isinstance(data, str) else bytes(data)
+
+
+def _parse_target(target: str):
+    """Returns the address and token of an address or baudlink:// URL."""
+    if not target.startswith("baudlink://"):
+        return target, None
+    url = urlparse(target)
+    token = parse_qs(url.query).get("token", [None])[0]
+    return url.netloc, token
+
+
+class Client:
+    """A connection to a BaudLink agent.
+
+    ``target`` is a host:port address or a ``baudlink://`` connection
+    string, which may carry the token. Pass ``tls=True``, optionally with
+    PEM ``root_certificates``, for agents with TLS enabled.
+    """
+
+    def __init__(
+        self,
+        target: str = DEFAULT_ADDRESS,
+        *,
+        token: Optional[str] = None,
+        tls: bool = False,
+        root_certificates: Optional[bytes] = None,
+        client_id: str = DEFAULT_CLIENT_ID,
+    ):
+        address, link_token = _parse_target(target)
+        token = token or link_token
+
+        if tls:
+            credentials = grpc.ssl_channel_credentials(root_certificates)
+            self.channel = grpc.secure_channel(address, credentials)
+        else:
+            self.channel = grpc.insecure_channel(address)
+
+        self.stub = pb_grpc.SerialServiceStub(self.channel)
+        self.client_id = client_id
+        self._metadata = (("authorization", "Bearer " + token),) if token else ()
+
+    def call(self, method: str, request, **kwargs):
+        """Calls an RPC by name with the client's token, for RPCs the
+        wrapper does not cover."""
+        return getattr(self.stub, method)(request, metadata=self._metadata, **kwargs)
+
+    def list_ports(self) -> List["pb.PortInfo"]:
+        """Returns the serial ports available on the agent."""
+        return list(self.call("ListPorts", pb.ListPortsRequest()).ports)
+
+    def agent_info(self) -> "pb.AgentInfo":
+        """Returns the agent's version, capabilities, and limits."""
+        return self.call("GetAgentInfo", pb.GetAgentInfoRequest())
+
+    def supports(self, category: str, name: str, min_version: int = 1) -> bool:
+        """Reports whether the agent supports and enables a capability at
+        min_version or later, such as ("framing", "nmea")."""
+        for capability in self.agent_info().capabilities:
+            if capability.category == category and capability.name == name:
+                return capability.enabled and capability.version >= min_version
+        return False
+
+    def open(
+        self,
+        port_name: str,
+        baud_rate: Optional[int] = None,
+        *,
+        config: Optional["pb.PortConfig"] = None,
+        exclusive: bool = False,
+        priority: int = 0,
+        reconnect: bool = False,
+    ) -> "Port":
+        """Opens a port and starts receiving its data.
+
+        ``baud_rate`` opens the port with 8N1 framing and no flow control;
+        ``config`` gives the full configuration. Without either the agent
+        applies the matching profile or its defaults.
+        """
+        if config is None and baud_rate is not None:
+            config = pb.PortConfig(
+                baud_rate=baud_rate,
+                data_bits=pb.DATA_BITS_8,
+                stop_bits=pb.STOP_BITS_1,
+                parity=pb.PARITY_NONE,
+                flow_control=pb.FLOW_CONTROL_NONE,
+                read_timeout_ms=1000,
+            )
+
+        resp = self.call(
+            "OpenPort",
+            pb.OpenPortRequest(
+                port_name=port_name,
+                config=config,
+                client_id=self.client_id,
+                exclusive=exclusive,
+                priority=priority,
+                reconnect=reconnect,
+            ),
+        )
+        if not resp.success:
+            raise BaudLinkError("failed to open port: " + resp.message)
+        return Port(self, port_name, resp.session_id)
+
+    def close(self) -> None:
+        """Closes the connection. Open ports are not closed on the agent;
+        close them first to release them."""
+        self.channel.close()
+
+    def __enter__(self) -> "Client":
+        return self
+
+    def __exit__(self, *exc) -> None:
+        self.close()
+
+
+class Port:
+    """An open port session on the agent.
+
+    Received data is streamed into a local buffer in the background, so
+    ``read`` and ``readline`` never miss data arriving between calls;
+    ``transact`` collects its response on the agent. Timeouts are in
+    seconds; None waits indefinitely.
+    """
+
+    def __init__(self, client: Client, port_name: str, session_id: str):
+        self.client = client
+        self.name = port_name
+        self.session_id = session_id
+
+        self._cond = threading.Condition()
+        self._buffer = bytearray()
+        self._ended = False
+        self._error: Optional[Exception] = None
+
+        self._stream = client.call(
+            "StreamRead",
+            pb.StreamReadRequest(port_name=port_name, session_id=session_id),
+        )
+        self._thread = threading.Thread(target=self._receive, daemon=True)
+        self._thread.start()
+
+    def _receive(self) -> None:
+        try:
+            for chunk in self._stream:
+                if chunk.data:
+                    with self._cond:
+                        self._buffer += chunk.data
+                        self._cond.notify_all()
+        except grpc.RpcError as e:
+            if e.code() != grpc.StatusCode.CANCELLED:
+                self._error = e
+        finally:
+            with self._cond:
+                self._ended = True
+                self._cond.notify_all()
+
+    def _take(self, n: int) -> bytes:
+        data = bytes(self._buffer[:n])
+        del self._buffer[:n]
+        return data
+
+    def _check_ended(self) -> None:
+        if self._error is not None:
+            raise self._error
+        raise BaudLinkError("port closed")
+
+    def read(self, size: int = -1, timeout: Optional[float] = None) -> bytes:
+        """Returns up to size bytes (all buffered data if negative), waiting
+        for at least one. Returns b"" if the timeout expires first."""
+        with self._cond:
+            self._cond.wait_for(lambda: self._buffer or self._ended, timeout)
+            if not self._buffer:
+                if self._ended:
+                    self._check_ended()
+                return b""
+            return self._take(len(self._buffer) if size < 0 else size)
+
+    def readline(self, terminator: Data = b"\n", timeout: Optional[float] = None) -> bytes:
+        """Returns the next line including its terminator. Raises
+        TimeoutError, leaving partial data buffered, if the timeout
+        expires first."""
+        terminator = _to_bytes(terminator)
+        with self._cond:
+            self._cond.wait_for(lambda: terminator in self._buffer or self._ended, timeout)
+            i = self._buffer.find(terminator)
+            if i < 0:
+                if self._ended:
+                    self._check_ended()
+                raise TimeoutError("no complete line received")
+            return self._take(i + len(terminator))
+
+    def write(self, data: Data) -> int:
+        """Writes data to the port and returns the number of bytes written."""
+        resp = self.client.call(
+            "Write",
+            pb.WriteRequest(port_name=self.name, session_id=self.session_id, data=_to_bytes(data)),
+        )
+        if not resp.success:
+            raise BaudLinkError("write failed: " + resp.message)
+        return resp.bytes_written
+
+    def transact(
+        self,
+        data: Data,
+        *,
+        terminator: Optional[Data] = None,
+        pattern: Union[str, bytes, Pattern, None] = None,
+        expected_bytes: int = 0,
+        timeout: float = 1.0,
+        flush_input: bool = False,
+    ) -> bytes:
+        """Writes a request with the Transact RPC and returns the response
+        the agent collects until it ends with the terminator, the first
+        match of the pattern (a Go regular expression), or expected_bytes.
+        Data after the end of the response stays buffered for read; data
+        received before the request is not part of the response, and
+        flush_input discards it. Raises TimeoutError if the response is
+        incomplete when the timeout expires."""
+        if isinstance(pattern, re.Pattern):
+            pattern = pattern.pattern
+        if isinstance(pattern, bytes):
+            pattern = pattern.decode()
+
+        if flush_input:
+            self.flush_input()
+        resp = self.client.call(
+            "Transact",
+            pb.TransactRequest(
+                port_name=self.name,
+                session_id=self.session_id,
+                data=_to_bytes(data),
+                terminator=_to_bytes(terminator) if terminator is not None else b"",
+                pattern=pattern or "",
+                expected_bytes=expected_bytes,
+                timeout_ms=int(timeout * 1000),
+                flush_input=flush_input,
+            ),
+        )
+        if resp.success:
+            return resp.data
+        # Only a transaction that ran reports its elapsed time
+        if not resp.matched and resp.elapsed_ms > 0:
+            raise TimeoutError("incomplete response")
+        raise BaudLinkError("transact failed: " + resp.message)
+
+    def flush_input(self) -> None:
+        """Discards received data not read yet."""
+        with self._cond:
+            self._buffer.clear()
+
+    def close(self) -> None:
+        """Stops receiving and closes the port on the agent."""
+        self._stream.cancel()
+        resp = self.client.call(
+            "ClosePort",
+            pb.ClosePortRequest(port_name=self.name, session_id=self.session_id),
+            timeout=CLOSE_TIMEOUT,
+        )
+        if not resp.success:
+            raise BaudLinkError("failed to close port: " + resp.message)
+
+    def __enter__(self) -> "Port":
+        return self
+
+    def __exit__(self, *exc) -> None:
+        self.close()
@@ -0,0 +1,328 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Client and port classes mirroring the Go client in pkg/client
+
+import type { MessageInitShape } from "@bufbuild/protobuf";
+import { createClient, type Client, type Transport } from "@connectrpc/connect";
+
+import {
+  DataBits,
+  FlowControl,
+  Parity,
+  SerialService,
+  StopBits,
+  type AgentInfo,
+  type PortConfigSchema,
+  type PortInfo,
+} from "./gen/serial_pb.js";
+
+export const DEFAULT_CLIENT_ID = "baudlink-ts-client";
+
+// Bounds the ClosePort call made by Port.close
+const CLOSE_TIMEOUT_MS = 5000;
+
+export type Data = Uint8Array | string;
+
+/** An operation the agent reported as failed */
+export class BaudLinkError extends Error {
+  constructor(message: string) {
+    super(message);
+    this.name = "BaudLinkError";
+  }
+}
+
+/** A read or transaction that did not complete in time */
+export class TimeoutError extends BaudLinkError {
+  constructor(message: string) {
+    super(message);
+    this.name = "TimeoutError";
+  }
+}
+
+export interface ClientOptions {
+  /** Identifies the client in locks and audit records */
+  clientId?: string;
+}
+
+export interface OpenOptions {
+  /** Open at this baud rate with 8N1 framing and no flow control */
+  baudRate?: number;
+  /** Full configuration; without it or baudRate the agent applies the matching profile or its defaults */
+  config?: MessageInitShape<typeof PortConfigSchema>;
+  exclusive?: boolean;
+  priority?: number;
+  /** Suspend instead of failing when the device is unplugged */
+  reconnect?: boolean;
+}
+
+export interface TransactOptions {
+  /** Response ends with the first occurrence of this */
+  terminator?: Data;
+  /** Response ends with the first match of this Go regular expression; a RegExp's source is used, without its flags */
+  pattern?: RegExp | string;
+  /** Response is this many bytes */
+  expectedBytes?: number;
+  /** Overall timeout (default 1000) */
+  timeoutMs?: number;
+  /** Discard data received before the request */
+  flushInput?: boolean;
+}
+
+const encoder = new TextEncoder();
+const decoder = new TextDecoder();
+
+function toBytes(data: Data): Uint8Array {
+  return typeof data === "string" ? encoder.encode(data) : data;
+}
+
+function concat(a: Uint8Array, b: Uint8Array): Uint8Array {
+  const out = new Uint8Array(a.length + b.length);
+  out.set(a);
+  out.set(b, a.length);
+  return out;
+}
+
+function indexOf(haystack: Uint8Array, needle: Uint8Array): number {
+  outer: for (let i = 0; i + needle.length <= haystack.length; i++) {
+    for (let j = 0; j < needle.length; j++) {
+      if (haystack[i + j] !== needle[j]) {
+        continue outer;
+      }
+    }
+    return i;
+  }
+  return -1;
+}
+
+/** A connection to a BaudLink agent over any Connect transport */
+export class BaudLinkClient {
+  /** Generated client for RPCs the wrapper does not cover */
+  readonly service: Client<typeof SerialService>;
+  readonly clientId: string;
+
+  constructor(transport: Transport, options: ClientOptions = {}) {
+    this.service = createClient(SerialService, transport);
+    this.clientId = options.clientId ?? DEFAULT_CLIENT_ID;
+  }
+
+  /** Returns the serial ports available on the agent */
+  async listPorts(): Promise<PortInfo[]> {
+    return (await this.service.listPorts({})).ports;
+  }
+
+  /** Returns the agent's version, capabilities, and limits */
+  async agentInfo(): Promise<AgentInfo> {
+    return this.service.getAgentInfo({});
+  }
+
+  /** Reports whether the agent supports and enables a capability at minVersion or later */
+  async supports(category: string, name: string, minVersion = 1): Promise<boolean> {
+    const info = await this.agentInfo();
+    const capability = info.capabilities.find((c) => c.category === category && c.name === name);
+    return capability !== undefined && capability.enabled && capability.version >= minVersion;
+  }
+
+  /** Opens a port and starts receiving its data */
+  async open(portName: string, options: OpenOptions = {}): Promise<Port> {
+    let config = options.config;
+    if (config === undefined && options.baudRate !== undefined) {
+      config = {
+        baudRate: options.baudRate,
+        dataBits: DataBits.DATA_BITS_8,
+        stopBits: StopBits.STOP_BITS_1,
+        parity: Parity.NONE,
+        flowControl: FlowControl.NONE,
+        readTimeoutMs: 1000,
+      };
+    }
+
+    const resp = await this.service.openPort({
+      portName,
+      config,
+      clientId: this.clientId,
+      exclusive: options.exclusive ?? false,
+      priority: options.priority ?? 0,
+      reconnect: options.reconnect ?? false,
+    });
+    if (!resp.success) {
+      throw new BaudLinkError(`failed to open port: ${resp.message}`);
+    }
+    return new Port(this, portName, resp.sessionId);
+  }
+}
+
+/**
+ * An open port session on the agent. Received data is streamed into a local
+ * buffer in the background, so read and readLine never miss data arriving
+ * between calls; transact collects its response on the agent. Timeouts are
+ * in milliseconds; undefined waits indefinitely.
+ */
+export class Port {
+  private buffer = new Uint8Array(0);
+  private waiters: Array<() => void> = [];
+  private ended = false;
+  private error: unknown;
+  private readonly abort = new AbortController();
+
+  constructor(
+    private readonly client: BaudLinkClient,
+    readonly name: string,
+    readonly sessionId: string,
+  ) {
+    void this.receive();
+  }
+
+  private async receive(): Promise<void> {
+    try {
+      const stream = this.client.service.streamRead(
+        { portName: this.name, sessionId: this.sessionId },
+        { signal: this.abort.signal },
+      );
+      for await (const chunk of stream) {
+        if (chunk.data.length > 0) {
+          this.buffer = concat(this.buffer, chunk.data);
+          this.wake();
+        }
+      }
+    } catch (err) {
+      if (!this.abort.signal.aborted) {
+        this.error = err;
+      }
+    } finally {
+      this.ended = true;
+      this.wake();
+    }
+  }
+
+  private wake(): void {
+    const waiters = this.waiters;
+    this.waiters = [];
+    waiters.forEach((resolve) => resolve());
+  }
+
+  // waitFor waits until ready returns true, throwing TimeoutError when the
+  // timeout expires and the stream's error once it has ended
+  private async waitFor(ready: () => boolean, timeoutMs: number | undefined, what: string): Promise<void> {
+    const deadline = timeoutMs === undefined ? undefined : Date.now() + timeoutMs;
+    while (!ready()) {
+      if (this.ended) {
+        throw this.error ?? new BaudLinkError("port closed");
+      }
+      const remaining = deadline === undefined ? undefined : deadline - Date.now();
+      if (remaining !== undefined && remaining <= 0) {
+        throw new TimeoutError(what);
+      }
+      await new Promise<void>((resolve) => {
+        const timer = remaining === undefined ? undefined : setTimeout(resolve, remaining);
+        this.waiters.push(() => {
+          clearTimeout(timer);
+          resolve();
+        });
+      });
+    }
+  }
+
+  private take(n: number): Uint8Array {
+    const data = this.buffer.slice(0, n);
+    this.buffer = this.buffer.slice(n);
+    return data;
+  }
+
+  /** Returns up to maxBytes (all buffered data by default), waiting for at least one; empty if the timeout expires */
+  async read(maxBytes?: number, timeoutMs?: number): Promise<Uint8Array> {
+    try {
+      await this.waitFor(() => this.buffer.length > 0, timeoutMs, "no data received");
+    } catch (err) {
+      if (err instanceof TimeoutError) {
+        return new Uint8Array(0);
+      }
+      throw err;
+    }
+    return this.take(maxBytes ?? this.buffer.length);
+  }
+
+  /** Returns the next line including its terminator, leaving partial data buffered on timeout */
+  async readLine(terminator: Data = "\n", timeoutMs?: number): Promise<string> {
+    const term = toBytes(terminator);
+    await this.waitFor(() => indexOf(this.buffer, term) >= 0, timeoutMs, "no complete line received");
+    return decoder.decode(this.take(indexOf(this.buffer, term) + term.length));
+  }
+
+  /** Writes data to the port and returns the number of bytes written */
+  async write(data: Data): Promise<number> {
+    const resp = await this.client.service.write({
+      portName: this.name,
+      sessionId: this.sessionId,
+      data: toBytes(data),
+    });
+    if (!resp.success) {
+      throw new BaudLinkError(`write failed: ${resp.message}`);
+    }
+    return resp.bytesWritten;
+  }
+
+  /**
+   * Writes a request with the Transact RPC and returns the response the
+   * agent collects until it ends with the terminator, the first match of
+   * the pattern, or expectedBytes. Data after the end of the response stays
+   * buffered for read; data received before the request is not part of the
+   * response, and flushInput discards it.
+   */
+  async transact(data: Data, options: TransactOptions = {}): Promise<Uint8Array> {
+    const pattern = options.pattern instanceof RegExp ? options.pattern.source : options.pattern;
+    const timeoutMs = options.timeoutMs ?? 1000;
+
+    if (options.flushInput) {
+      this.flushInput();
+    }
+    const resp = await this.client.service.transact({
+      portName: this.name,
+      sessionId: this.sessionId,
+      data: toBytes(data),
+      terminator: options.terminator === undefined ? undefined : toBytes(options.terminator),
+      pattern: pattern ?? "",
+      expectedBytes: options.expectedBytes ?? 0,
+      timeoutMs,
+      flushInput: options.flushInput ?? false,
+    });
+    if (resp.success) {
+      return resp.data;
+    }
+    // Only a transaction that ran reports its elapsed time
+    if (!resp.matched && resp.elapsedMs > 0) {
+      throw new TimeoutError("incomplete response");
+    }
+    throw new BaudLinkError(`transact failed: ${resp.message}`);
+  }
+
+  /** Discards received data not read yet */
+  flushInput(): void {
+    this.buffer = new Uint8Array(0);
+  }
+
+  /** Stops receiving and closes the port on the agent */
+  async close(): Promise<void> {
+    this.abort.abort();
+    const resp = await this.client.service.closePort(
+      { portName: this.name, sessionId: this.sessionId },
+      { timeoutMs: CLOSE_TIMEOUT_MS },
+    );
+    if (!resp.success) {
+      throw new BaudLinkError(`failed to close port: ${resp.message}`);
+    }
+  }
+}
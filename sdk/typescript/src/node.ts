@@ -0,0 +1,62 @@
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Connecting from Node.js over native gRPC
+
+import type { Interceptor } from "@connectrpc/connect";
+import { createGrpcTransport } from "@connectrpc/connect-node";
+
+import { BaudLinkClient, type ClientOptions } from "./client.js";
+
+export const DEFAULT_ADDRESS = "localhost:50051";
+
+export interface ConnectOptions extends ClientOptions {
+  /** Access token for agents with authentication enabled */
+  token?: string;
+  /** Connect with TLS */
+  tls?: boolean;
+  /** PEM certificate authorities trusted for TLS, e.g. the agent's self-signed certificate */
+  ca?: string | Buffer;
+}
+
+/**
+ * Connects to an agent at a host:port address or baudlink:// connection
+ * string, which may carry the token
+ */
+export function connect(target: string = DEFAULT_ADDRESS, options: ConnectOptions = {}): BaudLinkClient {
+  let address = target;
+  let token = options.token;
+  if (target.startsWith("baudlink://")) {
+    const url = new URL(target);
+    address = url.host;
+    token ??= url.searchParams.get("token") ?? undefined;
+  }
+
+  const interceptors: Interceptor[] = [];
+  if (token) {
+    interceptors.push((next) => (req) => {
+      req.header.set("authorization", `Bearer ${token}`);
+      return next(req);
+    });
+  }
+
+  const transport = createGrpcTransport({
+    baseUrl: `${options.tls ? "https" : "http"}://${address}`,
+    interceptors,
+    nodeOptions: options.ca ? { ca: options.ca } : undefined,
+  });
+  return new BaudLinkClient(transport, options);
+}
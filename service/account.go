@@ -0,0 +1,153 @@
+//go:build linux || darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+// serialGroups are the groups owning serial devices on common distributions
+var serialGroups = []string{"dialout", "uucp"}
+
+// udevRuleTemplate grants access through an ACL rather than changing the
+// device group, so existing dialout/uucp members keep their access
+const udevRuleTemplate = `# Generated by baudlink service install.
+# Grants the {{.Group}} group access to serial devices.
+SUBSYSTEM=="tty", KERNEL=="ttyS[0-9]*|ttyUSB[0-9]*|ttyACM[0-9]*|ttyAMA[0-9]*|rfcomm[0-9]*", RUN+="/usr/bin/setfacl -m g:{{.Group}}:rw $env{DEVNAME}"
+`
+
+// udevRulePath returns the path of the service's udev rule
+func udevRulePath(cfg *config.Config) string {
+	return fmt.Sprintf("/etc/udev/rules.d/99-%s.rules", cfg.Service.Name)
+}
+
+// serviceAccount returns the configured user and group, defaulting to root
+func serviceAccount(cfg *config.Config) (string, string) {
+	userName := cfg.Service.User
+	if userName == "" {
+		userName = "root"
+	}
+	group := cfg.Service.Group
+	if group == "" {
+		group = userName
+	}
+	return userName, group
+}
+
+// ensureAccount creates the service user and group if they do not exist and
+// adds the user to the groups owning serial devices. It returns the
+// supplementary groups the user belongs to.
+func ensureAccount(userName, group, homeDir string) ([]string, error) {
+	if userName == "root" {
+		return nil, nil
+	}
+
+	if _, err := user.LookupGroup(group); err != nil {
+		if err := runCommand("groupadd", "--system", group); err != nil {
+			return nil, fmt.Errorf("failed to create group %s: %w", group, err)
+		}
+	}
+
+	if _, err := user.Lookup(userName); err != nil {
+		if err := runCommand("useradd", "--system",
+			"--gid", group,
+			"--home-dir", homeDir,
+			"--no-create-home",
+			"--shell", "/usr/sbin/nologin",
+			userName); err != nil {
+			return nil, fmt.Errorf("failed to create user %s: %w", userName, err)
+		}
+	}
+
+	var groups []string
+	for _, g := range serialGroups {
+		if _, err := user.LookupGroup(g); err != nil {
+			continue
+		}
+		if err := runCommand("usermod", "--append", "--groups", g, userName); err != nil {
+			return nil, fmt.Errorf("failed to add %s to group %s: %w", userName, g, err)
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// chownAll gives the service account ownership of the given paths
+func chownAll(userName, group string, paths ...string) error {
+	u, err := user.Lookup(userName)
+	if err != nil {
+		return err
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return err
+	}
+
+	uid, _ := strconv.Atoi(u.Uid)
+	gid, _ := strconv.Atoi(g.Gid)
+
+	for _, path := range paths {
+		if err := os.Chown(path, uid, gid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installUdevRule writes a udev rule granting group access to serial devices
+// and applies it to devices that are already present
+func installUdevRule(cfg *config.Config, group string) error {
+	tmpl, err := template.New("udev").Parse(udevRuleTemplate)
+	if err != nil {
+		return err
+	}
+
+	var rule strings.Builder
+	if err := tmpl.Execute(&rule, struct{ Group string }{group}); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(udevRulePath(cfg), []byte(rule.String()), 0644); err != nil {
+		return err
+	}
+
+	if err := runCommand("udevadm", "control", "--reload-rules"); err != nil {
+		return err
+	}
+	return runCommand("udevadm", "trigger", "--subsystem-match=tty")
+}
+
+// removeUdevRule removes the service's udev rule
+func removeUdevRule(cfg *config.Config) error {
+	if err := os.Remove(udevRulePath(cfg)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return runCommand("udevadm", "control", "--reload-rules")
+}
@@ -15,5 +15,6 @@ limitations under the License.
 */
 
 // Package service provides system service wrappers for BaudLink agent.
-// It supports Windows services and Linux/macOS systemd services.
+// It supports Windows services, systemd services on Linux, and launchd
+// daemons on macOS.
 package service
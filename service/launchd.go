@@ -0,0 +1,261 @@
+//go:build linux || darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+const launchdPlistTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{.ExecPath}}</string>
+		<string>serve</string>
+		<string>--config</string>
+		<string>{{.ConfigPath}}</string>
+	</array>
+	<key>WorkingDirectory</key>
+	<string>{{.WorkingDirectory}}</string>
+	<key>RunAtLoad</key>
+	<{{.RunAtLoad}}/>
+	<key>KeepAlive</key>
+	{{.KeepAlive}}
+	<key>ThrottleInterval</key>
+	<integer>{{.RestartDelay}}</integer>
+	<key>StandardOutPath</key>
+	<string>{{.LogPath}}/baudlink.log</string>
+	<key>StandardErrorPath</key>
+	<string>{{.LogPath}}/baudlink.err.log</string>
+</dict>
+</plist>
+`
+
+// launchdBackend manages the agent as a launchd daemon on macOS
+type launchdBackend struct{}
+
+// launchdData holds data for the launchd plist template
+type launchdData struct {
+	Label            string
+	ExecPath         string
+	ConfigPath       string
+	LogPath          string
+	WorkingDirectory string
+	RunAtLoad        bool
+	KeepAlive        string
+	RestartDelay     int
+}
+
+// launchdLabel returns the launchd job label for the service
+func launchdLabel(cfg *config.Config) string {
+	return "com.baudlink." + cfg.Service.Name
+}
+
+// launchdPlistPath returns the path of the service's plist
+func launchdPlistPath(cfg *config.Config) string {
+	return filepath.Join("/Library/LaunchDaemons", launchdLabel(cfg)+".plist")
+}
+
+// launchdTarget returns the service target used by launchctl
+func launchdTarget(cfg *config.Config) string {
+	return "system/" + launchdLabel(cfg)
+}
+
+// Install writes the launchd plist and loads the daemon
+func (b launchdBackend) Install(cfg *config.Config) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	exePath, err = filepath.Abs(exePath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	configPath := GetConfigPath()
+	logPath := GetLogPath()
+
+	// Ensure directories exist
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.MkdirAll(logPath, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	// Copy config if it doesn't exist
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		if err := cfg.Save(configPath); err != nil {
+			fmt.Printf("Warning: failed to save config: %v\n", err)
+		}
+	}
+
+	data := launchdData{
+		Label:            launchdLabel(cfg),
+		ExecPath:         exePath,
+		ConfigPath:       configPath,
+		LogPath:          logPath,
+		WorkingDirectory: "/",
+		RunAtLoad:        cfg.Service.AutoStart,
+		KeepAlive:        convertKeepAlive(cfg.Service.RestartPolicy),
+		RestartDelay:     cfg.Service.RestartDelay,
+	}
+
+	tmpl, err := template.New("launchd").Parse(launchdPlistTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	// launchd refuses plists that are writable by anyone but root
+	plistPath := launchdPlistPath(cfg)
+	f, err := os.OpenFile(plistPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create plist: %w", err)
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, data); err != nil {
+		return fmt.Errorf("failed to write plist: %w", err)
+	}
+
+	// Load the daemon so it starts now and at boot
+	if cfg.Service.AutoStart {
+		if err := runCommand("launchctl", "bootstrap", "system", plistPath); err != nil {
+			fmt.Printf("Warning: failed to load service: %v\n", err)
+		}
+	}
+
+	fmt.Printf("Service %s installed successfully\n", cfg.Service.Name)
+	fmt.Printf("  Plist: %s\n", plistPath)
+	fmt.Printf("  Config: %s\n", configPath)
+	fmt.Printf("  Logs: %s\n", logPath)
+	fmt.Println()
+	fmt.Println("To start the service:")
+	fmt.Println("  sudo baudlink service start")
+	fmt.Println()
+	fmt.Println("To check status:")
+	fmt.Printf("  sudo launchctl print %s\n", launchdTarget(cfg))
+
+	return nil
+}
+
+// Uninstall unloads the daemon and removes its plist
+func (b launchdBackend) Uninstall(cfg *config.Config) error {
+	if b.loaded(cfg) {
+		_ = runCommand("launchctl", "bootout", launchdTarget(cfg))
+	}
+
+	if err := os.Remove(launchdPlistPath(cfg)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove plist: %w", err)
+	}
+
+	fmt.Printf("Service %s removed successfully\n", cfg.Service.Name)
+	return nil
+}
+
+// Start loads the daemon if necessary and starts it
+func (b launchdBackend) Start(cfg *config.Config) error {
+	if !b.loaded(cfg) {
+		if err := runCommand("launchctl", "bootstrap", "system", launchdPlistPath(cfg)); err != nil {
+			return fmt.Errorf("failed to load service: %w", err)
+		}
+	}
+
+	if err := runCommand("launchctl", "kickstart", launchdTarget(cfg)); err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
+	}
+	fmt.Printf("Service %s started\n", cfg.Service.Name)
+	return nil
+}
+
+// Stop unloads the daemon. Killing the process alone is not enough because
+// launchd restarts it according to KeepAlive.
+func (b launchdBackend) Stop(cfg *config.Config) error {
+	if !b.loaded(cfg) {
+		fmt.Printf("Service %s is not running\n", cfg.Service.Name)
+		return nil
+	}
+
+	if err := runCommand("launchctl", "bootout", launchdTarget(cfg)); err != nil {
+		return fmt.Errorf("failed to stop service: %w", err)
+	}
+	fmt.Printf("Service %s stopped\n", cfg.Service.Name)
+	return nil
+}
+
+// Status returns the status of the launchd daemon
+func (b launchdBackend) Status(cfg *config.Config) (string, error) {
+	if _, err := os.Stat(launchdPlistPath(cfg)); os.IsNotExist(err) {
+		return "not installed", nil
+	}
+
+	out, err := exec.Command("launchctl", "print", launchdTarget(cfg)).Output()
+	if err != nil {
+		// print fails for daemons that are installed but not loaded
+		return "inactive", nil
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if state, ok := strings.CutPrefix(line, "state = "); ok {
+			if state == "running" {
+				return "active", nil
+			}
+			return state, nil
+		}
+	}
+
+	return "inactive", nil
+}
+
+// Installed reports whether the daemon's plist exists
+func (launchdBackend) Installed(cfg *config.Config) bool {
+	_, err := os.Stat(launchdPlistPath(cfg))
+	return err == nil
+}
+
+// loaded reports whether the daemon is loaded into launchd
+func (launchdBackend) loaded(cfg *config.Config) bool {
+	return exec.Command("launchctl", "print", launchdTarget(cfg)).Run() == nil
+}
+
+// convertKeepAlive converts our restart policy to a launchd KeepAlive value
+func convertKeepAlive(policy string) string {
+	switch strings.ToLower(policy) {
+	case "always":
+		return "<true/>"
+	case "never":
+		return "<false/>"
+	default:
+		// Restart only after a non-zero exit
+		return "<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>"
+	}
+}
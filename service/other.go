@@ -0,0 +1,80 @@
+//go:build !linux && !darwin && !windows
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+// errUnsupported is returned on systems without a supported service manager
+var errUnsupported = fmt.Errorf("system services are not supported on %s: %w", runtime.GOOS, errors.ErrUnsupported)
+
+// Install installs the agent as a system service
+func Install(cfg *config.Config) error {
+	return errUnsupported
+}
+
+// Uninstall removes the system service
+func Uninstall(cfg *config.Config) error {
+	return errUnsupported
+}
+
+// Start starts the system service
+func Start(cfg *config.Config) error {
+	return errUnsupported
+}
+
+// Stop stops the system service
+func Stop(cfg *config.Config) error {
+	return errUnsupported
+}
+
+// Status returns the status of the system service
+func Status(cfg *config.Config) (string, error) {
+	return "", errUnsupported
+}
+
+// Installed reports whether the system service is installed
+func Installed(cfg *config.Config) bool {
+	return false
+}
+
+// Restart stops and starts the system service so it runs the current binary
+func Restart(cfg *config.Config) error {
+	return errUnsupported
+}
+
+// Running reports whether the system service is installed and active
+func Running(cfg *config.Config) bool {
+	return false
+}
+
+// GetConfigPath returns the default config path
+func GetConfigPath() string {
+	return config.DefaultConfigPath()
+}
+
+// GetLogPath returns the default log path
+func GetLogPath() string {
+	return "/var/log/baudlink"
+}
@@ -0,0 +1,178 @@
+//go:build linux
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+// protectRuleHeader starts the udev rules written by Protect
+const protectRuleHeader = `# Generated by baudlink service protect.
+# Keeps ModemManager from probing the ports below.
+`
+
+// protectPortPrefix starts the comment naming the port of each rule
+const protectPortPrefix = "# port: "
+
+// ProtectedPort is a port kept away from ModemManager and login prompts
+type ProtectedPort struct {
+	Device string // Device path, e.g. /dev/ttyUSB0
+	Serial string // udev ID_SERIAL of the adapter, so the rule follows it to other USB ports; empty to match the device name
+}
+
+// protectRulePath returns the path of the udev rules protecting ports
+func protectRulePath(cfg *config.Config) string {
+	return fmt.Sprintf("/etc/udev/rules.d/99-%s-protect.rules", cfg.Service.Name)
+}
+
+// gettyUnit returns the systemd unit running a login prompt on a device
+func gettyUnit(device string) string {
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+	return fmt.Sprintf("serial-getty@%s.service", filepath.Base(device))
+}
+
+// Protect stops ModemManager from probing the port with a udev rule, and
+// masks the systemd login prompt of its tty so it cannot be started on it
+func Protect(cfg *config.Config, port ProtectedPort) error {
+	rules, err := readProtectRules(cfg)
+	if err != nil {
+		return err
+	}
+
+	match := fmt.Sprintf(`KERNEL=="%s"`, filepath.Base(port.Device))
+	if resolved, err := filepath.EvalSymlinks(port.Device); err == nil {
+		match = fmt.Sprintf(`KERNEL=="%s"`, filepath.Base(resolved))
+	}
+	if port.Serial != "" {
+		match = fmt.Sprintf(`ENV{ID_SERIAL}=="%s"`, port.Serial)
+	}
+	rules[port.Device] = fmt.Sprintf(`SUBSYSTEM=="tty", %s, ENV{ID_MM_DEVICE_IGNORE}="1", ENV{ID_MM_PORT_IGNORE}="1"`, match)
+
+	if err := writeProtectRules(cfg, rules); err != nil {
+		return err
+	}
+	if err := runCommand("systemctl", "mask", "--now", gettyUnit(port.Device)); err != nil {
+		return fmt.Errorf("failed to mask %s: %w", gettyUnit(port.Device), err)
+	}
+	return nil
+}
+
+// Unprotect removes the udev rule of a port protected by Protect and
+// unmasks the login prompt of its tty
+func Unprotect(cfg *config.Config, device string) error {
+	rules, err := readProtectRules(cfg)
+	if err != nil {
+		return err
+	}
+	if _, ok := rules[device]; !ok {
+		return fmt.Errorf("%s is not protected", device)
+	}
+	delete(rules, device)
+
+	if err := writeProtectRules(cfg, rules); err != nil {
+		return err
+	}
+	if err := runCommand("systemctl", "unmask", gettyUnit(device)); err != nil {
+		return fmt.Errorf("failed to unmask %s: %w", gettyUnit(device), err)
+	}
+	return nil
+}
+
+// ProtectedPorts returns the devices protected by Protect
+func ProtectedPorts(cfg *config.Config) ([]string, error) {
+	rules, err := readProtectRules(cfg)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]string, 0, len(rules))
+	for device := range rules {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
+
+// readProtectRules reads the protection rules keyed by device
+func readProtectRules(cfg *config.Config) (map[string]string, error) {
+	rules := make(map[string]string)
+
+	f, err := os.Open(protectRulePath(cfg))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rules, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	device := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, protectPortPrefix):
+			device = strings.TrimPrefix(line, protectPortPrefix)
+		case line != "" && !strings.HasPrefix(line, "#") && device != "":
+			rules[device] = line
+			device = ""
+		}
+	}
+	return rules, scanner.Err()
+}
+
+// writeProtectRules writes the protection rules, removing the file when
+// none are left, and applies them to devices that are already present
+func writeProtectRules(cfg *config.Config, rules map[string]string) error {
+	path := protectRulePath(cfg)
+
+	if len(rules) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	} else {
+		devices := make([]string, 0, len(rules))
+		for device := range rules {
+			devices = append(devices, device)
+		}
+		sort.Strings(devices)
+
+		var b strings.Builder
+		b.WriteString(protectRuleHeader)
+		for _, device := range devices {
+			fmt.Fprintf(&b, "\n%s%s\n%s\n", protectPortPrefix, device, rules[device])
+		}
+		if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := runCommand("udevadm", "control", "--reload-rules"); err != nil {
+		return err
+	}
+	return runCommand("udevadm", "trigger", "--subsystem-match=tty")
+}
@@ -40,13 +40,19 @@ Restart={{.RestartPolicy}}
 RestartSec={{.RestartDelay}}
 User={{.User}}
 Group={{.Group}}
+{{- if .SupplementaryGroups}}
+SupplementaryGroups={{.SupplementaryGroups}}
+{{- end}}
 WorkingDirectory={{.WorkingDirectory}}
+# Writable /run/baudlink for the optional local socket
+RuntimeDirectory=baudlink
+RuntimeDirectoryMode=0755
 
 # Security settings
 NoNewPrivileges=true
 ProtectSystem=strict
 ProtectHome=true
-ReadWritePaths={{.LogPath}} {{.ConfigDir}}
+ReadWritePaths={{.LogPath}} {{.ConfigDir}} {{.DataDir}}
 
 # Resource limits
 LimitNOFILE=65535
@@ -79,21 +85,26 @@ func (ss *SystemdService) Run() error {
 
 // serviceData holds data for the systemd template
 type serviceData struct {
-	Name             string
-	Description      string
-	ExecPath         string
-	ConfigPath       string
-	ConfigDir        string
-	LogPath          string
-	WorkingDirectory string
-	User             string
-	Group            string
-	RestartPolicy    string
-	RestartDelay     int
+	Name                string
+	Description         string
+	ExecPath            string
+	ConfigPath          string
+	ConfigDir           string
+	LogPath             string
+	DataDir             string
+	WorkingDirectory    string
+	User                string
+	Group               string
+	SupplementaryGroups string
+	RestartPolicy       string
+	RestartDelay        int
 }
 
+// systemdBackend manages the agent as a systemd unit
+type systemdBackend struct{}
+
 // Install installs the systemd service
-func Install(cfg *config.Config) error {
+func (systemdBackend) Install(cfg *config.Config) error {
 	exePath, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("failed to get executable path: %w", err)
@@ -108,6 +119,7 @@ func Install(cfg *config.Config) error {
 	configPath := GetConfigPath()
 	configDir := filepath.Dir(configPath)
 	logPath := GetLogPath()
+	dataDir := config.DefaultDataDir()
 
 	// Ensure directories exist
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -116,6 +128,9 @@ func Install(cfg *config.Config) error {
 	if err := os.MkdirAll(logPath, 0755); err != nil {
 		return fmt.Errorf("failed to create log directory: %w", err)
 	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
 
 	// Copy config if it doesn't exist
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -124,18 +139,43 @@ func Install(cfg *config.Config) error {
 		}
 	}
 
+	// Create the service account and give it access to serial devices
+	userName, group := serviceAccount(cfg)
+	groups, err := ensureAccount(userName, group, dataDir)
+	if err != nil {
+		return err
+	}
+	if userName != "root" {
+		if err := chownAll(userName, group, logPath, dataDir); err != nil {
+			return fmt.Errorf("failed to set directory ownership: %w", err)
+		}
+
+		// The config may hold access tokens: readable by the service group only
+		if err := chownAll("root", group, configPath); err != nil {
+			fmt.Printf("Warning: failed to set config ownership: %v\n", err)
+		} else if err := os.Chmod(configPath, 0640); err != nil {
+			fmt.Printf("Warning: failed to set config permissions: %v\n", err)
+		}
+
+		if err := installUdevRule(cfg, group); err != nil {
+			fmt.Printf("Warning: failed to install udev rule: %v\n", err)
+		}
+	}
+
 	data := serviceData{
-		Name:             cfg.Service.Name,
-		Description:      cfg.Service.Description,
-		ExecPath:         exePath,
-		ConfigPath:       configPath,
-		ConfigDir:        configDir,
-		LogPath:          logPath,
-		WorkingDirectory: "/",
-		User:             "root", // Could be configurable
-		Group:            "root",
-		RestartPolicy:    convertRestartPolicy(cfg.Service.RestartPolicy),
-		RestartDelay:     cfg.Service.RestartDelay,
+		Name:                cfg.Service.Name,
+		Description:         cfg.Service.Description,
+		ExecPath:            exePath,
+		ConfigPath:          configPath,
+		ConfigDir:           configDir,
+		LogPath:             logPath,
+		DataDir:             dataDir,
+		WorkingDirectory:    "/",
+		User:                userName,
+		Group:               group,
+		SupplementaryGroups: strings.Join(groups, " "),
+		RestartPolicy:       convertRestartPolicy(cfg.Service.RestartPolicy),
+		RestartDelay:        cfg.Service.RestartDelay,
 	}
 
 	// Parse and execute template
@@ -169,6 +209,7 @@ func Install(cfg *config.Config) error {
 	}
 
 	fmt.Printf("Service %s installed successfully\n", cfg.Service.Name)
+	fmt.Printf("  User: %s (group %s)\n", userName, group)
 	fmt.Printf("  Config: %s\n", configPath)
 	fmt.Printf("  Logs: %s\n", logPath)
 	fmt.Println()
@@ -182,9 +223,9 @@ func Install(cfg *config.Config) error {
 }
 
 // Uninstall removes the systemd service
-func Uninstall(cfg *config.Config) error {
+func (b systemdBackend) Uninstall(cfg *config.Config) error {
 	// Stop the service first
-	_ = Stop(cfg)
+	_ = b.Stop(cfg)
 
 	// Disable the service
 	_ = runCommand("systemctl", "disable", cfg.Service.Name)
@@ -195,6 +236,11 @@ func Uninstall(cfg *config.Config) error {
 		return fmt.Errorf("failed to remove service file: %w", err)
 	}
 
+	// The service account is kept so files it owns keep a valid owner
+	if err := removeUdevRule(cfg); err != nil {
+		fmt.Printf("Warning: failed to remove udev rule: %v\n", err)
+	}
+
 	// Reload systemd
 	if err := runCommand("systemctl", "daemon-reload"); err != nil {
 		return fmt.Errorf("failed to reload systemd: %w", err)
@@ -205,7 +251,7 @@ func Uninstall(cfg *config.Config) error {
 }
 
 // Start starts the systemd service
-func Start(cfg *config.Config) error {
+func (systemdBackend) Start(cfg *config.Config) error {
 	if err := runCommand("systemctl", "start", cfg.Service.Name); err != nil {
 		return fmt.Errorf("failed to start service: %w", err)
 	}
@@ -214,7 +260,7 @@ func Start(cfg *config.Config) error {
 }
 
 // Stop stops the systemd service
-func Stop(cfg *config.Config) error {
+func (systemdBackend) Stop(cfg *config.Config) error {
 	if err := runCommand("systemctl", "stop", cfg.Service.Name); err != nil {
 		return fmt.Errorf("failed to stop service: %w", err)
 	}
@@ -223,7 +269,7 @@ func Stop(cfg *config.Config) error {
 }
 
 // Status returns the status of the systemd service
-func Status(cfg *config.Config) (string, error) {
+func (systemdBackend) Status(cfg *config.Config) (string, error) {
 	out, err := exec.Command("systemctl", "is-active", cfg.Service.Name).Output()
 	if err != nil {
 		// is-active returns exit code 3 for inactive/failed
@@ -236,22 +282,10 @@ func Status(cfg *config.Config) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// GetConfigPath returns the config path for Linux/macOS
-func GetConfigPath() string {
-	return "/etc/baudlink/agent.yaml"
-}
-
-// GetLogPath returns the log path for Linux/macOS
-func GetLogPath() string {
-	return "/var/log/baudlink"
-}
-
-// runCommand runs a command and returns an error if it fails
-func runCommand(name string, args ...string) error {
-	cmd := exec.Command(name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// Installed reports whether the unit file exists
+func (systemdBackend) Installed(cfg *config.Config) bool {
+	_, err := os.Stat(fmt.Sprintf("/etc/systemd/system/%s.service", cfg.Service.Name))
+	return err == nil
 }
 
 // convertRestartPolicy converts our restart policy to systemd format
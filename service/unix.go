@@ -0,0 +1,111 @@
+//go:build linux || darwin
+
+/*
+Copyright 2024 BaudLink Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/Shoaibashk/BaudLink/config"
+)
+
+// backend is a service manager able to run the agent as a daemon
+type backend interface {
+	Install(cfg *config.Config) error
+	Uninstall(cfg *config.Config) error
+	Start(cfg *config.Config) error
+	Stop(cfg *config.Config) error
+	Status(cfg *config.Config) (string, error)
+	Installed(cfg *config.Config) bool
+}
+
+// detectBackend selects the service manager for the running system:
+// launchd on macOS, systemd elsewhere
+func detectBackend() backend {
+	if runtime.GOOS == "darwin" {
+		return launchdBackend{}
+	}
+	return systemdBackend{}
+}
+
+// Install installs the agent as a system service
+func Install(cfg *config.Config) error {
+	return detectBackend().Install(cfg)
+}
+
+// Uninstall removes the system service
+func Uninstall(cfg *config.Config) error {
+	return detectBackend().Uninstall(cfg)
+}
+
+// Start starts the system service
+func Start(cfg *config.Config) error {
+	return detectBackend().Start(cfg)
+}
+
+// Stop stops the system service
+func Stop(cfg *config.Config) error {
+	return detectBackend().Stop(cfg)
+}
+
+// Status returns the status of the system service
+func Status(cfg *config.Config) (string, error) {
+	return detectBackend().Status(cfg)
+}
+
+// Installed reports whether the system service is installed
+func Installed(cfg *config.Config) bool {
+	return detectBackend().Installed(cfg)
+}
+
+// Restart stops and starts the system service so it runs the current binary
+func Restart(cfg *config.Config) error {
+	if err := Stop(cfg); err != nil {
+		return err
+	}
+	return Start(cfg)
+}
+
+// Running reports whether the system service is installed and active
+func Running(cfg *config.Config) bool {
+	status, err := Status(cfg)
+	return err == nil && status == "active"
+}
+
+// GetConfigPath returns the config path for Linux/macOS
+func GetConfigPath() string {
+	return config.DefaultConfigPath()
+}
+
+// GetLogPath returns the log path for Linux/macOS
+func GetLogPath() string {
+	if runtime.GOOS == "darwin" {
+		return "/usr/local/var/log/baudlink"
+	}
+	return "/var/log/baudlink"
+}
+
+// runCommand runs a command and returns an error if it fails
+func runCommand(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -300,6 +300,36 @@ func Status(cfg *config.Config) (string, error) {
 	}
 }
 
+// Installed reports whether the Windows service is installed
+func Installed(cfg *config.Config) bool {
+	m, err := mgr.Connect()
+	if err != nil {
+		return false
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(cfg.Service.Name)
+	if err != nil {
+		return false
+	}
+	s.Close()
+	return true
+}
+
+// Restart stops and starts the Windows service so it runs the current binary
+func Restart(cfg *config.Config) error {
+	if err := Stop(cfg); err != nil {
+		return err
+	}
+	return Start(cfg)
+}
+
+// Running reports whether the Windows service is installed and running
+func Running(cfg *config.Config) bool {
+	status, err := Status(cfg)
+	return err == nil && status == "running"
+}
+
 // GetConfigPath returns the config path for Windows
 func GetConfigPath() string {
 	programData := os.Getenv("ProgramData")
@@ -9,6 +9,9 @@ Tests the BaudLink gRPC server by:
   5. Reading data with streaming
   6. Closing the port
 
+It is built on the pkg/client library, which is the starting point for Go
+programs talking to the agent.
+
 Usage:
   grpcclient -addr localhost:50051 -port COM3 -baud 115200 -write "AT\r\n" -read-time 10
 */
@@ -16,20 +19,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"time"
 
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-
 	pb "github.com/Shoaibashk/BaudLink/api/proto"
+	"github.com/Shoaibashk/BaudLink/pkg/client"
 )
 
 func main() {
-	addr := flag.String("addr", "localhost:50051", "BaudLink gRPC server address")
+	addr := flag.String("addr", "localhost:50051", "BaudLink gRPC server address or baudlink:// connection string")
+	token := flag.String("token", "", "Access token for agents with authentication enabled")
 	portName := flag.String("port", "", "Serial port to open (e.g., COM3). Leave empty to just list ports.")
 	baud := flag.Uint("baud", 9600, "Baud rate")
 	writeData := flag.String("write", "", "Data to write after opening the port")
@@ -42,35 +45,33 @@ func main() {
 	fmt.Printf("Server: %s\n\n", *addr)
 
 	// Connect to BaudLink gRPC server
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	var opts []client.Option
+	if *token != "" {
+		opts = append(opts, client.WithToken(*token))
+	}
+	opts = append(opts, client.WithClientID("grpc-test-client"))
 
-	conn, err := grpc.DialContext(ctx, *addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-	)
+	c, err := client.Dial(*addr, opts...)
 	if err != nil {
-		log.Fatalf("❌ Failed to connect to BaudLink: %v\n   Make sure 'baudlink serve' is running.", err)
+		log.Fatalf("❌ Failed to connect to BaudLink: %v", err)
 	}
-	defer conn.Close()
-	fmt.Println("✅ Connected to BaudLink")
-
-	// Create the client using the generated protobuf client
-	client := pb.NewSerialServiceClient(conn)
+	defer c.Close()
 
 	// 1. Ping
 	fmt.Println("━━━ Ping ━━━")
-	pingResp, err := client.Ping(context.Background(), &pb.PingRequest{Message: "hello"})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pingResp, err := c.Service().Ping(ctx, &pb.PingRequest{Message: "hello"})
 	if err != nil {
-		log.Printf("⚠ Ping failed: %v", err)
-	} else {
-		fmt.Printf("Pong: %s (server time: %d)\n", pingResp.Message, pingResp.ServerTime)
+		log.Fatalf("❌ Failed to connect to BaudLink: %v\n   Make sure 'baudlink serve' is running.", err)
 	}
+	fmt.Println("✅ Connected to BaudLink")
+	fmt.Printf("Pong: %s (server time: %d)\n", pingResp.Message, pingResp.ServerTime)
 	fmt.Println()
 
 	// 2. Get Agent Info
 	fmt.Println("━━━ Agent Info ━━━")
-	info, err := client.GetAgentInfo(context.Background(), &pb.GetAgentInfoRequest{})
+	info, err := c.Service().GetAgentInfo(context.Background(), &pb.GetAgentInfoRequest{})
 	if err != nil {
 		log.Printf("⚠ GetAgentInfo failed: %v", err)
 	} else {
@@ -78,19 +79,24 @@ func main() {
 		fmt.Printf("OS/Arch:  %s/%s\n", info.Os, info.Arch)
 		fmt.Printf("Uptime:   %d seconds\n", info.UptimeSeconds)
 		fmt.Printf("Features: %v\n", info.SupportedFeatures)
+		for _, c := range info.Capabilities {
+			if c.Enabled {
+				fmt.Printf("  %s/%s v%d\n", c.Category, c.Name, c.Version)
+			}
+		}
 	}
 	fmt.Println()
 
 	// 3. List Ports
 	fmt.Println("━━━ Serial Ports ━━━")
-	listResp, err := client.ListPorts(context.Background(), &pb.ListPortsRequest{})
+	ports, err := c.ListPorts(context.Background())
 	if err != nil {
 		log.Fatalf("❌ ListPorts failed: %v", err)
 	}
-	if len(listResp.Ports) == 0 {
+	if len(ports) == 0 {
 		fmt.Println("No serial ports found")
 	} else {
-		for _, p := range listResp.Ports {
+		for _, p := range ports {
 			status := "available"
 			if p.IsOpen {
 				status = fmt.Sprintf("open (locked by %s)", p.LockedBy)
@@ -108,97 +114,67 @@ func main() {
 
 	// 4. Open Port
 	fmt.Printf("━━━ Open Port: %s @ %d baud ━━━\n", *portName, *baud)
-	openResp, err := client.OpenPort(context.Background(), &pb.OpenPortRequest{
-		PortName: *portName,
-		Config: &pb.PortConfig{
-			BaudRate:      uint32(*baud),
-			DataBits:      pb.DataBits_DATA_BITS_8,
-			StopBits:      pb.StopBits_STOP_BITS_1,
-			Parity:        pb.Parity_PARITY_NONE,
-			FlowControl:   pb.FlowControl_FLOW_CONTROL_NONE,
-			ReadTimeoutMs: 1000,
-		},
-		ClientId:  "grpc-test-client",
-		Exclusive: true,
-	})
+	port, err := c.Open(context.Background(), *portName,
+		client.WithBaudRate(uint32(*baud)),
+		client.WithExclusive(),
+		client.WithChunkSize(256),
+	)
 	if err != nil {
 		log.Fatalf("❌ OpenPort failed: %v", err)
 	}
-	if !openResp.Success {
-		log.Fatalf("❌ OpenPort error: %s", openResp.Message)
-	}
-	sessionID := openResp.SessionId
-	fmt.Printf("✅ Port opened (session: %s)\n\n", sessionID)
+	fmt.Printf("✅ Port opened (session: %s)\n\n", port.SessionID())
 
 	// Ensure we close the port on exit
 	defer func() {
 		fmt.Println("\n━━━ Closing Port ━━━")
-		closeResp, err := client.ClosePort(context.Background(), &pb.ClosePortRequest{
-			PortName:  *portName,
-			SessionId: sessionID,
-		})
-		if err != nil {
+		if err := port.Close(); err != nil {
 			log.Printf("⚠ ClosePort failed: %v", err)
-		} else if closeResp.Success {
-			fmt.Println("✅ Port closed")
 		} else {
-			fmt.Printf("⚠ ClosePort: %s\n", closeResp.Message)
+			fmt.Println("✅ Port closed")
 		}
 	}()
 
 	// 5. Write Data (optional)
 	if *writeData != "" {
 		fmt.Println("━━━ Write Data ━━━")
-		writeResp, err := client.Write(context.Background(), &pb.WriteRequest{
-			PortName:  *portName,
-			SessionId: sessionID,
-			Data:      []byte(*writeData),
-			Flush:     true,
-		})
+		n, err := port.Write([]byte(*writeData))
 		if err != nil {
 			log.Printf("⚠ Write failed: %v", err)
-		} else if writeResp.Success {
-			fmt.Printf("✅ Wrote %d bytes: %q\n", writeResp.BytesWritten, *writeData)
 		} else {
-			fmt.Printf("⚠ Write: %s\n", writeResp.Message)
+			fmt.Printf("✅ Wrote %d bytes: %q\n", n, *writeData)
 		}
 		fmt.Println()
 	}
 
-	// 6. Read Data (streaming)
+	// 6. Read Data (streaming). Reads are interrupted by closing the port
+	// when the read time is up.
 	fmt.Printf("━━━ Reading Data (for %d seconds, press Ctrl+C to stop) ━━━\n", *readTimeSec)
-	readCtx, readCancel := context.WithTimeout(context.Background(), time.Duration(*readTimeSec)*time.Second)
-	defer readCancel()
-
-	stream, err := client.StreamRead(readCtx, &pb.StreamReadRequest{
-		PortName:          *portName,
-		SessionId:         sessionID,
-		ChunkSize:         256,
-		IncludeTimestamps: true,
+	timedOut := make(chan struct{})
+	timer := time.AfterFunc(time.Duration(*readTimeSec)*time.Second, func() {
+		close(timedOut)
+		port.Close()
 	})
-	if err != nil {
-		log.Printf("⚠ StreamRead failed: %v", err)
-		return
-	}
+	defer timer.Stop()
 
 	bytesTotal := 0
+	buf := make([]byte, 256)
 	for {
-		chunk, err := stream.Recv()
-		if err == io.EOF {
-			break
+		n, err := port.Read(buf)
+		if n > 0 {
+			bytesTotal += n
+			fmt.Printf("← %s", string(buf[:n]))
 		}
 		if err != nil {
-			if readCtx.Err() != nil {
+			select {
+			case <-timedOut:
 				fmt.Printf("\n⏱ Read timeout (%d seconds)\n", *readTimeSec)
-			} else {
-				log.Printf("⚠ StreamRead error: %v", err)
+			default:
+				if !errors.Is(err, io.EOF) {
+					log.Printf("⚠ Read error: %v", err)
+				}
 			}
 			break
 		}
-		if len(chunk.Data) > 0 {
-			bytesTotal += len(chunk.Data)
-			fmt.Printf("← %s", string(chunk.Data))
-		}
 	}
 	if bytesTotal > 0 {
 		fmt.Printf("\n\n📊 Total received: %d bytes\n", bytesTotal)
@@ -0,0 +1,69 @@
+/*
+BaudLink Release Signer
+
+Signs the release checksums file with an ed25519 key so `baudlink update`
+can verify downloads. The private key is read from the
+BAUDLINK_RELEASE_SIGNING_KEY environment variable as a base64 seed, and the
+signature is written base64 encoded.
+
+Usage:
+
+	signrelease -genkey
+	signrelease -in dist/checksums.txt -out dist/checksums.txt.sig
+
+The public key printed by -genkey is built into release binaries through
+the BAUDLINK_UPDATE_PUBLIC_KEY variable read by .goreleaser.yaml.
+*/
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+const keyEnv = "BAUDLINK_RELEASE_SIGNING_KEY"
+
+func main() {
+	genkey := flag.Bool("genkey", false, "Generate a new signing key pair and print it")
+	in := flag.String("in", "", "File to sign")
+	out := flag.String("out", "", "Signature output file (default: <in>.sig)")
+	flag.Parse()
+
+	if *genkey {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+		fmt.Printf("%s=%s\n", keyEnv, base64.StdEncoding.EncodeToString(priv.Seed()))
+		fmt.Printf("BAUDLINK_UPDATE_PUBLIC_KEY=%s\n", base64.StdEncoding.EncodeToString(pub))
+		return
+	}
+
+	if *in == "" {
+		log.Fatal("-in is required")
+	}
+	if *out == "" {
+		*out = *in + ".sig"
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(os.Getenv(keyEnv)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		log.Fatalf("%s must hold a base64 ed25519 seed", keyEnv)
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *in, err)
+	}
+
+	sig := ed25519.Sign(ed25519.NewKeyFromSeed(seed), data)
+	if err := os.WriteFile(*out, []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}